@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/novoseltcev/passkeeper/internal/adapters"
+	"github.com/novoseltcev/passkeeper/internal/app/client"
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/v1/user"
+)
+
+// The environment variables of the secrets, which are asked otherwise.
+const (
+	envPassword       = "PASSKEEPER_PASSWORD"
+	envPassphrase     = "PASSKEEPER_PASSPHRASE"
+	envExportPassword = "PASSKEEPER_EXPORT_PASSWORD"
+	envMasterPassword = "PASSKEEPER_MASTER_PASSWORD"
+	envSendPassword   = "PASSKEEPER_SEND_PASSWORD"
+)
+
+// secretsHelp describes how the secrets are read, it is appended to the help of the commands.
+const secretsHelp = "\n\nThe password and the passphrase are taken from " + envPassword + " and " + envPassphrase +
+	", or asked without echo. If the standard input is not a terminal, the asked secrets are read from it " +
+	"one per line in the order they are asked."
+
+// account are the credentials of the commands, which run on behalf of the user.
+type account struct {
+	login, password, passphrase string
+}
+
+// initFlags adds the login flag and reads the password and the passphrase before the command runs.
+func (a *account) initFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&a.login, "login", "", "Login of the account")
+	_ = cmd.MarkFlagRequired("login")
+
+	if cmd.Long == "" {
+		cmd.Long = cmd.Short + "."
+	}
+
+	cmd.Long += secretsHelp
+	cmd.PreRunE = func(cmd *cobra.Command, _ []string) (err error) {
+		if a.password, err = readSecret(cmd, envPassword, "Password of the account"); err != nil {
+			return err
+		}
+
+		a.passphrase, err = readSecret(cmd, envPassphrase, "Passphrase of the vault")
+
+		return err
+	}
+}
+
+// signIn returns the API of the configured server with the token of the account.
+func (a *account) signIn(ctx context.Context, cfg *client.Config) (*adapters.HTTP, string, error) {
+	if err := cfg.LoadEnv(); err != nil {
+		return nil, "", err
+	}
+
+	api := adapters.NewHTTP(http.DefaultClient, cfg.ServerAddress)
+
+	token, err := api.Login(ctx, &user.LoginData{Login: a.login, Password: a.password})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return api, token, nil
+}
+
+// readSecret returns the value of the environment variable or asks to enter the secret,
+// the environment is not checked if the variable is empty.
+//
+// The secret is not echoed on a terminal, otherwise it is read as the next line of the standard input.
+func readSecret(cmd *cobra.Command, env, prompt string) (string, error) {
+	if value, ok := os.LookupEnv(env); env != "" && ok {
+		return value, nil
+	}
+
+	in := cmd.InOrStdin()
+	if file, ok := in.(*os.File); ok && term.IsTerminal(int(file.Fd())) {
+		cmd.PrintErr(prompt + ": ")
+		line, err := term.ReadPassword(int(file.Fd()))
+		cmd.PrintErrln()
+
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", prompt, err)
+		}
+
+		return string(line), nil
+	}
+
+	line, err := readLine(in)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", prompt, err)
+	}
+
+	return line, nil
+}
+
+// readLine reads a line byte by byte, so the next lines are left for the next secrets.
+func readLine(r io.Reader) (string, error) {
+	var (
+		line []byte
+		b    = make([]byte, 1)
+	)
+
+	for {
+		n, err := r.Read(b)
+		if n > 0 {
+			if b[0] == '\n' {
+				break
+			}
+
+			line = append(line, b[0])
+		}
+
+		if errors.Is(err, io.EOF) {
+			if len(line) == 0 {
+				return "", io.ErrUnexpectedEOF
+			}
+
+			break
+		}
+
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return strings.TrimSuffix(string(line), "\r"), nil
+}
@@ -50,7 +50,11 @@ func Cmd() *cobra.Command {
 			app.Run(ctx)
 		},
 	}
-	initFlags(cfg, cmd.Flags())
+	initFlags(cfg, cmd.PersistentFlags())
+	cmd.AddCommand(
+		receiveCmd(), matchCmd(cfg), exportCmd(cfg), importCmd(cfg), importKeePassCmd(cfg), importFromCmd(cfg),
+		exportPlainCmd(cfg),
+	)
 
 	return cmd
 }
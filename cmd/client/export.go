@@ -0,0 +1,69 @@
+package main
+
+import (
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/novoseltcev/passkeeper/internal/app/client"
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/v1/secrets"
+)
+
+func exportCmd(cfg *client.Config) *cobra.Command {
+	var acc account
+
+	cmd := &cobra.Command{
+		Use:   "export <file>",
+		Short: "Write the personal vault encrypted with the export password to a new file",
+		Long: "Write the personal vault encrypted with the export password to a new file.\n\n" +
+			"The export password is taken from " + envExportPassword + " or asked after the passphrase.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			exportPassword, err := readSecret(cmd, envExportPassword, "Password to encrypt the archive with")
+			if err != nil {
+				return err
+			}
+
+			api, token, err := acc.signIn(cmd.Context(), cfg)
+			if err != nil {
+				return err
+			}
+
+			archive, err := api.ExportVault(cmd.Context(), token, &secrets.ExportData{
+				Passphrase: acc.passphrase,
+				Password:   exportPassword,
+			})
+			if err != nil {
+				return err
+			}
+
+			return writePrivate(args[0], func(w io.Writer) error {
+				_, err := w.Write(archive)
+
+				return err
+			})
+		},
+	}
+
+	acc.initFlags(cmd)
+
+	return cmd
+}
+
+// writePrivate writes a new file readable only by the current user, the file is removed if the write fails.
+func writePrivate(path string, write func(w io.Writer) error) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600) // nolint: mnd
+	if err != nil {
+		return err
+	}
+
+	if err := write(file); err != nil {
+		file.Close()
+		os.Remove(path)
+
+		return err
+	}
+
+	return file.Close()
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/spf13/cobra"
+
+	"github.com/novoseltcev/passkeeper/internal/adapters"
+	"github.com/novoseltcev/passkeeper/internal/app/client"
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/v1/secrets"
+)
+
+func exportPlainCmd(cfg *client.Config) *cobra.Command {
+	var (
+		acc          account
+		format       string
+		acknowledged bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export-plain <path>",
+		Short: "Write the personal vault decrypted to JSON lines or to CSV files, one per type, in a new directory",
+		Long: "Write the personal vault decrypted to the JSON lines file or to the CSV files, one per type, " +
+			"in the directory.\n\nThe secrets are NOT encrypted, the files are readable only by the current user. " +
+			"The passphrase is asked again, the confirmation is never taken from the environment.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !acknowledged {
+				return errors.New("the export is not encrypted, acknowledge it with --acknowledge-plaintext")
+			}
+
+			if format != "jsonl" && format != "csv" {
+				return fmt.Errorf("unknown format %q, expected jsonl or csv", format)
+			}
+
+			if err := confirmPassphrase(cmd, acc.passphrase); err != nil {
+				return err
+			}
+
+			api, token, err := acc.signIn(cmd.Context(), cfg)
+			if err != nil {
+				return err
+			}
+
+			plain, err := adapters.PlainSecrets(cmd.Context(), api, token, acc.passphrase)
+			if err != nil {
+				return err
+			}
+
+			if format == "jsonl" {
+				return writePrivate(args[0], func(w io.Writer) error { return adapters.WritePlainJSONL(w, plain) })
+			}
+
+			if err := os.Mkdir(args[0], 0o700); err != nil { // nolint: mnd
+				return err
+			}
+
+			for _, name := range secrets.TypeNames() {
+				if !slices.ContainsFunc(plain, func(s *adapters.PlainSecret) bool { return s.Type == name }) {
+					continue
+				}
+
+				if err := writePrivate(filepath.Join(args[0], name+".csv"), func(w io.Writer) error {
+					return adapters.WritePlainCSV(w, name, plain)
+				}); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	}
+
+	acc.initFlags(cmd)
+	cmd.Flags().StringVar(&format, "format", "jsonl", "Format of the export, jsonl or csv")
+	cmd.Flags().BoolVar(&acknowledged, "acknowledge-plaintext", false, "Acknowledge that the export is not encrypted")
+
+	return cmd
+}
+
+// confirmPassphrase asks to enter the passphrase again, it is not echoed on a terminal.
+func confirmPassphrase(cmd *cobra.Command, passphrase string) error {
+	entered, err := readSecret(cmd, "", "Enter the passphrase again")
+	if err != nil {
+		return err
+	}
+
+	if entered != passphrase {
+		return errors.New("the passphrases don't match")
+	}
+
+	return nil
+}
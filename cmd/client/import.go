@@ -0,0 +1,60 @@
+package main
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/novoseltcev/passkeeper/internal/app/client"
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/v1/secrets"
+)
+
+func importCmd(cfg *client.Config) *cobra.Command {
+	var (
+		acc    account
+		dryRun bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "import <file>",
+		Short: "Create the secrets of an exported archive in the personal vault, the duplicates are skipped",
+		Long: "Create the secrets of an exported archive in the personal vault, the duplicates are skipped.\n\n" +
+			"The export password is taken from " + envExportPassword + " or asked after the passphrase.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			archive, err := os.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+
+			exportPassword, err := readSecret(cmd, envExportPassword, "Password the archive is encrypted with")
+			if err != nil {
+				return err
+			}
+
+			api, token, err := acc.signIn(cmd.Context(), cfg)
+			if err != nil {
+				return err
+			}
+
+			result, err := api.ImportVault(cmd.Context(), token, &secrets.ImportData{
+				Passphrase: acc.passphrase,
+				Password:   exportPassword,
+				Archive:    archive,
+				DryRun:     dryRun,
+			})
+			if result != nil {
+				for _, item := range result.Items {
+					cmd.Printf("%s\t%s <%s>\t%s\n", item.Status, item.Name, item.Type, item.Error)
+				}
+			}
+
+			return err
+		},
+	}
+
+	acc.initFlags(cmd)
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Check the archive and print the results without saving")
+
+	return cmd
+}
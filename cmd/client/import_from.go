@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/novoseltcev/passkeeper/internal/adapters"
+	"github.com/novoseltcev/passkeeper/internal/app/client"
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/v1/secrets"
+)
+
+func importFromCmd(cfg *client.Config) *cobra.Command {
+	var (
+		acc                   account
+		dryRun, skipConflicts bool
+	)
+
+	formats := make([]string, 0, len(adapters.ImportFormats))
+	for name := range adapters.ImportFormats {
+		formats = append(formats, name)
+	}
+
+	sort.Strings(formats)
+
+	cmd := &cobra.Command{
+		Use:   "import-from <format> <file>",
+		Short: "Create the secrets of an export of another password manager in the personal vault",
+		Long: "Create the secrets of an export of another password manager in the personal vault.\n\n" +
+			"The formats are " + strings.Join(formats, ", ") + ". The numbers of the secrets by their types " +
+			"and the names, which are already taken, are printed before anything is created.",
+		Args: cobra.ExactArgs(2), // nolint: mnd
+		RunE: func(cmd *cobra.Command, args []string) error {
+			parse, ok := adapters.ImportFormats[args[0]]
+			if !ok {
+				return fmt.Errorf("unknown format %q, expected one of %s", args[0], strings.Join(formats, ", "))
+			}
+
+			data, err := os.ReadFile(args[1])
+			if err != nil {
+				return err
+			}
+
+			items, skipped, err := parse(data, acc.passphrase)
+			if err != nil {
+				return err
+			}
+
+			api, token, err := acc.signIn(cmd.Context(), cfg)
+			if err != nil {
+				return err
+			}
+
+			preview, err := adapters.PreviewItems(cmd.Context(), api, token, items)
+			if err != nil {
+				return err
+			}
+
+			for _, name := range secrets.TypeNames() {
+				if count := preview.Counts[name]; count > 0 {
+					cmd.Printf("%s\t%d\n", name, count)
+				}
+			}
+
+			conflicts := make(map[adapters.ImportItem]bool, len(preview.Conflicts))
+			for _, item := range preview.Conflicts {
+				_, name := item.Payload.Credentials()
+				cmd.Printf("conflict\t%s\t%s\n", name, item.Source)
+				conflicts[item] = true
+			}
+
+			for _, item := range skipped {
+				cmd.Printf("skipped\t%s\t%s\n", item.Source, item.Reason)
+			}
+
+			if dryRun {
+				return nil
+			}
+
+			if skipConflicts {
+				items = slices.DeleteFunc(items, func(item adapters.ImportItem) bool { return conflicts[item] })
+			}
+
+			failed, err := adapters.CreateItems(cmd.Context(), api, token, items, func(done, total int) {
+				cmd.PrintErrf("\rimporting %d/%d", done, total)
+			})
+			cmd.PrintErrln()
+
+			for _, item := range failed {
+				cmd.Printf("failed\t%s\t%s\n", item.Source, item.Reason)
+			}
+
+			cmd.Printf("imported %d of %d secrets\n", len(items)-len(failed), len(items))
+
+			return err
+		},
+	}
+
+	acc.initFlags(cmd)
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the preview without creating the secrets")
+	cmd.Flags().BoolVar(&skipConflicts, "skip-conflicts", false, "Don't create the secrets, whose names are taken")
+
+	return cmd
+}
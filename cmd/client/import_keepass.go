@@ -0,0 +1,76 @@
+package main
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/novoseltcev/passkeeper/internal/adapters"
+	"github.com/novoseltcev/passkeeper/internal/app/client"
+	"github.com/novoseltcev/passkeeper/pkg/kdbx"
+)
+
+func importKeePassCmd(cfg *client.Config) *cobra.Command {
+	var (
+		acc     account
+		keyFile string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "import-keepass <file.kdbx>",
+		Short: "Create the entries and the attachments of a KeePass database in the personal vault",
+		Long: "Create the entries and the attachments of a KeePass database in the personal vault.\n\n" +
+			"The master password is taken from " + envMasterPassword + " or asked after the passphrase, " +
+			"it is empty if the database is opened by the key file only.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			data, err := os.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+
+			masterPassword, err := readSecret(cmd, envMasterPassword, "Master password of the database")
+			if err != nil {
+				return err
+			}
+
+			creds := &kdbx.Credentials{Password: masterPassword}
+			if keyFile != "" {
+				if creds.KeyFile, err = os.ReadFile(keyFile); err != nil {
+					return err
+				}
+			}
+
+			db, err := kdbx.Open(data, creds)
+			if err != nil {
+				return err
+			}
+
+			api, token, err := acc.signIn(cmd.Context(), cfg)
+			if err != nil {
+				return err
+			}
+
+			items, skipped := adapters.KeePassItems(db, acc.passphrase)
+
+			failed, err := adapters.CreateItems(cmd.Context(), api, token, items, func(done, total int) {
+				cmd.PrintErrf("\rimporting %d/%d", done, total)
+			})
+			cmd.PrintErrln()
+
+			skipped = append(skipped, failed...)
+			for _, item := range skipped {
+				cmd.Printf("skipped\t%s\t%s\n", item.Source, item.Reason)
+			}
+
+			cmd.Printf("imported %d of %d secrets\n", len(items)-len(failed), len(items))
+
+			return err
+		},
+	}
+
+	acc.initFlags(cmd)
+	cmd.Flags().StringVar(&keyFile, "key-file", "", "Key file of the database")
+
+	return cmd
+}
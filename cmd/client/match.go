@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/novoseltcev/passkeeper/internal/adapters"
+	"github.com/novoseltcev/passkeeper/internal/app/client"
+)
+
+func matchCmd(cfg *client.Config) *cobra.Command {
+	var (
+		acc  account
+		show bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "match <url>",
+		Short: "Print the passwords, whose URIs match the URL of a website",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			api, token, err := acc.signIn(cmd.Context(), cfg)
+			if err != nil {
+				return err
+			}
+
+			found, err := adapters.FindByURL(cmd.Context(), api, token, acc.passphrase, args[0])
+			if err != nil {
+				return err
+			}
+
+			if len(found) == 0 {
+				return fmt.Errorf("no passwords for %s", args[0])
+			}
+
+			for _, secret := range found {
+				cmd.Printf("%s\t%s\t%v\n", secret.ID, secret.Name, secret.Data["login"])
+
+				if show {
+					cmd.Printf("\t%v\n", secret.Data["password"])
+				}
+			}
+
+			return nil
+		},
+	}
+
+	acc.initFlags(cmd)
+	cmd.Flags().BoolVar(&show, "show", false, "Print the passwords too")
+
+	return cmd
+}
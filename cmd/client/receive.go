@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/novoseltcev/passkeeper/internal/adapters"
+	"github.com/novoseltcev/passkeeper/internal/domains/sends"
+	"github.com/novoseltcev/passkeeper/pkg/aes"
+)
+
+func receiveCmd() *cobra.Command {
+	var protected bool
+
+	cmd := &cobra.Command{
+		Use:   "receive <link>",
+		Short: "Print the secret of a send link, it takes one of the views of the send",
+		Long: "Print the secret of a send link, it takes one of the views of the send.\n\n" +
+			"The access password of a protected send is taken from " + envSendPassword + " or asked without echo, " +
+			"it is read from the standard input if it is not a terminal.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			address, key, err := sends.ParseLink(args[0])
+			if err != nil {
+				return err
+			}
+
+			var password string
+			if _, ok := os.LookupEnv(envSendPassword); ok || protected {
+				if password, err = readSecret(cmd, envSendPassword, "Access password of the send"); err != nil {
+					return err
+				}
+			}
+
+			data, err := adapters.NewHTTP(http.DefaultClient, "").GetSend(cmd.Context(), address, password)
+			if err != nil {
+				return err
+			}
+
+			payload, err := sends.Open(aes.New(aes.AES256BitKeyLength), key, data)
+			if err != nil {
+				return err
+			}
+
+			var content bytes.Buffer
+			if err := json.Indent(&content, payload.Data, "", "  "); err != nil {
+				return err
+			}
+
+			if payload.Name != "" {
+				cmd.Printf("%s <%s>\n", payload.Name, payload.Type)
+			}
+
+			cmd.Println(content.String())
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&protected, "protected", "p", false, "Ask the access password of the send")
+
+	return cmd
+}
@@ -2,7 +2,10 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"net/http"
+	"os"
 	"os/signal"
 	"syscall"
 
@@ -12,10 +15,17 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/novoseltcev/passkeeper/internal/app/server"
+	"github.com/novoseltcev/passkeeper/internal/domains/emergency"
+	"github.com/novoseltcev/passkeeper/internal/domains/orgs"
 	"github.com/novoseltcev/passkeeper/internal/domains/secrets"
+	"github.com/novoseltcev/passkeeper/internal/domains/sends"
+	"github.com/novoseltcev/passkeeper/internal/domains/templates"
 	"github.com/novoseltcev/passkeeper/internal/domains/user"
+	"github.com/novoseltcev/passkeeper/internal/notify"
 	"github.com/novoseltcev/passkeeper/internal/repo"
 	"github.com/novoseltcev/passkeeper/pkg/aes"
+	"github.com/novoseltcev/passkeeper/pkg/blobstore"
+	"github.com/novoseltcev/passkeeper/pkg/breach"
 	"github.com/novoseltcev/passkeeper/pkg/pwdhash"
 )
 
@@ -53,12 +63,44 @@ func Cmd() *cobra.Command {
 			defer db.Close()
 
 			hasher := pwdhash.NewBCrypt(cfg.Bcrypt.Cost)
+			encryptor := aes.New(aes.AES256BitKeyLength)
+
+			secretOpts := []secrets.Option{secrets.WithQuota(secrets.Quota{
+				MaxSecrets:     cfg.Quota.MaxSecrets,
+				MaxBytes:       cfg.Quota.MaxBytes,
+				MaxSecretBytes: cfg.Quota.MaxSecretBytes,
+			})}
+			if cfg.URIIndex.Key != "" {
+				secretOpts = append(secretOpts, secrets.WithURIIndex([]byte(cfg.URIIndex.Key)))
+			}
+
+			if index := openBreachIndex(cfg.Breach.Index, logger); index != nil {
+				defer index.Close()
+
+				secretOpts = append(secretOpts, secrets.WithBreachChecker(index))
+			}
+
+			blobs, err := newBlobStore(&cfg.Blobs)
+			if err != nil {
+				logger.Fatal("failed to open blob store", zap.Error(err), zap.String("backend", cfg.Blobs.Backend))
+			}
+
+			if blobs != nil {
+				secretOpts = append(secretOpts, secrets.WithBlobStore(blobs))
+			}
+
+			secretService := secrets.NewService(repo.NewSecretRepository(db), hasher, encryptor, secretOpts...)
 
 			app := server.New(
 				cfg, logger, db,
 				repo.NewTokenRepository(db),
-				secrets.NewService(repo.NewSecretRepository(db), hasher, aes.New(aes.AES256BitKeyLength)),
-				user.NewService(repo.NewUserRepository(db), hasher),
+				secretService,
+				templates.NewService(repo.NewTemplateRepository(db)),
+				user.NewService(repo.NewUserRepository(db), hasher, encryptor),
+				orgs.NewService(repo.NewOrgRepository(db), hasher, encryptor),
+				sends.NewService(repo.NewSendRepository(db), secretService, hasher, encryptor),
+				emergency.NewService(repo.NewEmergencyRepository(db), secretService, hasher, encryptor),
+				newNotifier(cfg, logger),
 			)
 
 			ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT, syscall.SIGQUIT)
@@ -68,12 +110,226 @@ func Cmd() *cobra.Command {
 		},
 	}
 	initFlags(cfg, cmd.Flags())
+	cmd.AddCommand(breachIndexCmd(), backupCmd(), restoreCmd())
 
 	return cmd
 }
 
+// newNotifier returns the notifier of due secrets, which mails them if SMTP is configured.
+func newNotifier(cfg *server.Config, logger *zap.Logger) secrets.Notifier {
+	log := notify.NewLog(logger)
+	if cfg.SMTP.Host == "" {
+		return log
+	}
+
+	return notify.NewSMTP(cfg.SMTP.Host, cfg.SMTP.Port, cfg.SMTP.Username, cfg.SMTP.Password, cfg.SMTP.From, log)
+}
+
+// newBlobStore returns the store of the file contents of the configured backend, it is nil without a backend.
+func newBlobStore(cfg *server.BlobsConfig) (secrets.BlobStore, error) {
+	switch cfg.Backend {
+	case "":
+		return nil, nil // nolint: nilnil
+	case "fs":
+		return blobstore.NewFS(cfg.Dir)
+	case "s3":
+		return blobstore.NewS3(http.DefaultClient, blobstore.S3Config{
+			Endpoint:  cfg.S3.Endpoint,
+			Region:    cfg.S3.Region,
+			Bucket:    cfg.S3.Bucket,
+			Prefix:    cfg.S3.Prefix,
+			AccessKey: cfg.S3.AccessKey,
+			SecretKey: cfg.S3.SecretKey,
+		})
+	default:
+		return nil, fmt.Errorf("unknown blob store backend %q", cfg.Backend)
+	}
+}
+
+// openBreachIndex opens the index of breached passwords, if it is configured and readable.
+func openBreachIndex(path string, logger *zap.Logger) *breach.Index {
+	if path == "" {
+		logger.Info("breach index is not configured, breached passwords are not checked")
+
+		return nil
+	}
+
+	index, err := breach.Open(path)
+	if err != nil {
+		logger.Warn("failed to open breach index, breached passwords are not checked",
+			zap.Error(err), zap.String("path", path))
+
+		return nil
+	}
+
+	return index
+}
+
+func breachIndexCmd() *cobra.Command {
+	var src, dst string
+
+	cmd := &cobra.Command{
+		Use:   "breach-index",
+		Short: "Build the index of breached passwords from a directory of HIBP-style range files",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			total, err := breach.Build(src, dst)
+			if err != nil {
+				return err
+			}
+
+			cmd.Printf("indexed %d hashes into %s\n", total, dst)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&src, "src", "", "Directory with range files named by 5 hex characters")
+	cmd.Flags().StringVar(&dst, "out", "breach.idx", "Path of the index file")
+	_ = cmd.MarkFlagRequired("src")
+
+	return cmd
+}
+
+func backupCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "backup <file>",
+		Short: "Back up the accounts, the sessions, the encrypted secrets and the rest into an archive, - writes to stdout",
+		Long: `Back up the rows of all tables into a compressed archive with a checksum and the schema version.
+
+The secrets stay encrypted, the blobs they refer to, such as the contents of files, are backed up with them.
+The database is read from DB_DSN, the blob store is configured by the BLOBS_ variables as for the server.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db, blobs, err := openStores()
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			var counts *repo.BackupCounts
+			if args[0] == "-" {
+				counts, err = repo.Backup(cmd.Context(), db, blobs, cmd.OutOrStdout())
+			} else {
+				counts, err = backupToFile(cmd.Context(), db, blobs, args[0])
+			}
+
+			if err != nil {
+				return err
+			}
+
+			printCounts(cmd, "backed up", counts)
+
+			return nil
+		},
+	}
+}
+
+// backupToFile writes the backup to a new file, the file is removed if the backup fails.
+func backupToFile(ctx context.Context, db *sqlx.DB, blobs repo.BlobStore, path string) (*repo.BackupCounts, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600) // nolint: mnd
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := repo.Backup(ctx, db, blobs, file)
+	if err == nil {
+		err = file.Sync()
+	}
+
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		_ = os.Remove(path)
+
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+func restoreCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "restore <file>",
+		Short: "Restore a backup into the empty database of the same schema version, - reads from stdin",
+		Long: `Restore a backup into the empty database, whose migrations are applied up to the version of the backup.
+
+The rows are restored in a single transaction, nothing is restored if the checksum does not match
+or a blob the secrets refer to is neither in the backup nor in the blob store.
+The database is read from DB_DSN, the blob store is configured by the BLOBS_ variables as for the server.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db, blobs, err := openStores()
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			in := cmd.InOrStdin()
+
+			if args[0] != "-" {
+				file, err := os.Open(args[0])
+				if err != nil {
+					return err
+				}
+				defer file.Close()
+
+				in = file
+			}
+
+			counts, err := repo.Restore(cmd.Context(), db, blobs, in)
+			if err != nil {
+				return err
+			}
+
+			printCounts(cmd, "restored", counts)
+
+			return nil
+		},
+	}
+}
+
+// openStores opens the database and the blob store configured by the environment, the blob store may be nil.
+func openStores() (*sqlx.DB, repo.BlobStore, error) {
+	var (
+		dbCfg    server.DBConfig
+		blobsCfg server.BlobsConfig
+	)
+
+	if err := dbCfg.LoadEnv(); err != nil {
+		return nil, nil, fmt.Errorf("failed to load environment variables: %w", err)
+	}
+
+	if err := blobsCfg.LoadEnv(); err != nil {
+		return nil, nil, fmt.Errorf("failed to load environment variables: %w", err)
+	}
+
+	blobs, err := newBlobStore(&blobsCfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open blob store: %w", err)
+	}
+
+	db, err := sqlx.Open("pgx", dbCfg.Dsn)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return db, blobs, nil
+}
+
+// printCounts prints the numbers of the rows of the tables and of the blobs to stderr.
+func printCounts(cmd *cobra.Command, action string, counts *repo.BackupCounts) {
+	for _, table := range repo.BackupTables {
+		cmd.PrintErrf("%s %d rows of %s\n", action, counts.Rows[table], table)
+	}
+
+	cmd.PrintErrf("%s %d blobs\n", action, counts.Blobs)
+}
+
 // initFlags initializes flags for parsing and help command.
 func initFlags(cfg *server.Config, flags *pflag.FlagSet) {
 	flags.StringVarP(&cfg.Address, "address", "a", ":8080", "Address to listen on")
 	flags.StringVarP(&cfg.Level, "level", "l", "info", "Log level")
+	flags.StringVar(&cfg.Breach.Index, "breach-index", "", "Path to the index of breached passwords")
 }
@@ -4,12 +4,23 @@ import (
 	"context"
 	"errors"
 
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/common/response"
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/v1/emergency"
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/v1/generate"
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/v1/orgs"
 	"github.com/novoseltcev/passkeeper/internal/controllers/http/v1/secrets"
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/v1/sends"
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/v1/templates"
 	"github.com/novoseltcev/passkeeper/internal/controllers/http/v1/user"
 )
 
-var ErrUnauthorized = errors.New("unauthorized")
+var (
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrPreconditionFailed means that the secret was changed since the entity tag was received.
+	ErrPreconditionFailed = errors.New("secret was changed by another client")
+)
 
+//go:generate mockgen -destination=./mocks/api_mock.go -package=mocks -source=api.go -typed
 type API interface {
 	GetSecretsPage(
 		ctx context.Context,
@@ -17,6 +28,8 @@ type API interface {
 		params *secrets.PaginationRequest,
 	) ([]secrets.SecretItemSchema, uint64, error)
 
+	MatchSecrets(ctx context.Context, token string, params *secrets.MatchRequest) ([]secrets.SecretItemSchema, error)
+
 	DecryptSecret(
 		ctx context.Context,
 		token string,
@@ -24,9 +37,90 @@ type API interface {
 		data *secrets.DecryptByIDData,
 	) (*secrets.SecretSchema, error)
 
-	Add(ctx context.Context, token string, data any) (string, error)
-	Update(ctx context.Context, token string, uuid string, data any) error
+	GenerateOTP(
+		ctx context.Context,
+		token string,
+		uuid string,
+		data *secrets.DecryptByIDData,
+	) (*secrets.OTPSchema, error)
+
+	GenerateSSHKey(
+		ctx context.Context,
+		token string,
+		data *secrets.GenerateSSHKeyData,
+	) (*secrets.GeneratedSSHKeySchema, error)
+
+	Report(ctx context.Context, token string, data *secrets.ReportData) (*secrets.ReportSchema, error)
+	GetUsage(ctx context.Context, token string) (*secrets.UsageSchema, error)
+
+	Add(ctx context.Context, token string, data secrets.Payload) (string, []response.Warning, error)
+	Update(ctx context.Context, token, uuid, etag string, data secrets.Payload) ([]response.Warning, error)
 	DeleteSecret(ctx context.Context, token string, uuid string) error
+	Batch(ctx context.Context, token string, data *secrets.BatchData) ([]secrets.BatchResultSchema, error)
+
+	ExportVault(ctx context.Context, token string, data *secrets.ExportData) ([]byte, error)
+	ImportVault(ctx context.Context, token string, data *secrets.ImportData) (*secrets.ImportSchema, error)
+
+	UploadFile(
+		ctx context.Context,
+		token string,
+		path string,
+		data *secrets.UploadFileData,
+	) (string, []response.Warning, error)
+	DownloadFile(
+		ctx context.Context,
+		token string,
+		uuid string,
+		data *secrets.DecryptByIDData,
+		path string,
+	) (string, error)
+
+	ShareSecret(ctx context.Context, token string, uuid string, data *secrets.ShareData) error
+	GetShares(ctx context.Context, token string, uuid string) ([]secrets.ShareSchema, error)
+	RevokeShare(ctx context.Context, token string, uuid string, userID string, data *secrets.RevokeData) error
+	GetSharedWithMe(ctx context.Context, token string) ([]secrets.SharedSecretSchema, error)
+
+	CreateOrg(ctx context.Context, token string, data *orgs.CreateOrgData) (string, error)
+	GetOrgs(ctx context.Context, token string) ([]orgs.OrgSchema, error)
+	GetOrgMembers(ctx context.Context, token string, uuid string) ([]orgs.MemberSchema, error)
+	InviteToOrg(ctx context.Context, token string, uuid string, data *orgs.InviteData) (string, error)
+	RemoveOrgMember(ctx context.Context, token string, uuid string, userID string) error
+	GetInvitations(ctx context.Context, token string) ([]orgs.InvitationSchema, error)
+	AcceptInvitation(ctx context.Context, token string, uuid string) error
+	DeclineInvitation(ctx context.Context, token string, uuid string) error
+
+	CreateSend(ctx context.Context, token string, data *sends.CreateSendData) (*sends.SendSchema, error)
+	GetSend(ctx context.Context, address string, password string) ([]byte, error)
+
+	InviteEmergencyContact(ctx context.Context, token string, data *emergency.InviteData) (string, error)
+	GetEmergencyContacts(ctx context.Context, token string) ([]emergency.AccessSchema, error)
+	GetEmergencyGrantors(ctx context.Context, token string) ([]emergency.AccessSchema, error)
+	RequestEmergencyAccess(ctx context.Context, token string, uuid string) error
+	ApproveEmergencyAccess(ctx context.Context, token string, uuid string) error
+	RejectEmergencyAccess(ctx context.Context, token string, uuid string) error
+	RevokeEmergencyAccess(ctx context.Context, token string, uuid string) error
+	GetEmergencyVault(
+		ctx context.Context,
+		token string,
+		uuid string,
+		params *emergency.PaginationRequest,
+	) ([]emergency.SecretItemSchema, uint64, error)
+	DecryptEmergencySecret(
+		ctx context.Context,
+		token, uuid, secretID string,
+		data *emergency.DecryptData,
+	) (*emergency.SecretSchema, error)
+
+	GetTemplates(ctx context.Context, token string) ([]templates.TemplateSchema, error)
+	GetTemplate(ctx context.Context, token string, uuid string) (*templates.TemplateSchema, error)
+	CreateTemplate(ctx context.Context, token string, data *templates.CreateTemplateData) (string, error)
+	DeleteTemplate(ctx context.Context, token string, uuid string) error
+
+	GeneratePassword(
+		ctx context.Context,
+		token string,
+		data *generate.GeneratePasswordData,
+	) (*generate.PasswordSchema, error)
 
 	Login(ctx context.Context, data *user.LoginData) (string, error)
 	Register(ctx context.Context, data *user.RegisterData) (string, error)
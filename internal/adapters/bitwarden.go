@@ -0,0 +1,142 @@
+package adapters
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+)
+
+const bitwardenSource = "Bitwarden"
+
+// The types of the Bitwarden items.
+const (
+	bitwardenLogin = 1
+	bitwardenNote  = 2
+	bitwardenCard  = 3
+)
+
+type bitwardenExport struct {
+	Encrypted bool `json:"encrypted"`
+	Folders   []struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"folders"`
+	Items []bitwardenItem `json:"items"`
+}
+
+type bitwardenItem struct {
+	FolderID string `json:"folderId"`
+	Type     int    `json:"type"`
+	Name     string `json:"name"`
+	Notes    string `json:"notes"`
+	Fields   []struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	} `json:"fields"`
+	Login struct {
+		URIs []struct {
+			URI string `json:"uri"`
+		} `json:"uris"`
+		Username string `json:"username"`
+		Password string `json:"password"`
+		TOTP     string `json:"totp"`
+	} `json:"login"`
+	Card struct {
+		CardholderName string `json:"cardholderName"`
+		Number         string `json:"number"`
+		ExpMonth       string `json:"expMonth"`
+		ExpYear        string `json:"expYear"`
+		Code           string `json:"code"`
+	} `json:"card"`
+}
+
+// BitwardenItems maps the logins, the cards and the secure notes of the unencrypted Bitwarden JSON export.
+//
+// The custom fields and the TOTP keys are in the meta, the other types of items are skipped.
+func BitwardenItems(data []byte, passphrase string) ([]ImportItem, []SkippedItem, error) {
+	var export bitwardenExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", ErrInvalidExport, err)
+	}
+
+	if export.Encrypted {
+		return nil, nil, fmt.Errorf("%w: the export is encrypted", ErrInvalidExport)
+	}
+
+	folders := make(map[string]string, len(export.Folders))
+	for _, folder := range export.Folders {
+		folders[folder.ID] = folder.Name
+	}
+
+	var (
+		items   []ImportItem
+		skipped []SkippedItem
+	)
+
+	for _, item := range export.Items {
+		folder := folders[item.FolderID]
+		source := path.Join(folder, item.Name)
+
+		meta := make(map[string]any, len(item.Fields))
+		for _, field := range item.Fields {
+			meta[field.Name] = field.Value
+		}
+
+		switch item.Type {
+		case bitwardenLogin:
+			if item.Login.TOTP != "" {
+				meta["totp"] = item.Login.TOTP
+			}
+
+			record := &loginRecord{
+				Source:   source,
+				Folder:   folder,
+				Title:    item.Name,
+				Username: item.Login.Username,
+				Password: item.Login.Password,
+				Notes:    item.Notes,
+				Meta:     meta,
+			}
+			for _, uri := range item.Login.URIs {
+				record.URLs = append(record.URLs, uri.URI)
+			}
+
+			if imported, ok := record.item(bitwardenSource, passphrase); ok {
+				items = append(items, imported)
+			} else {
+				skipped = append(skipped, SkippedItem{Source: source, Reason: "no password or notes"})
+			}
+		case bitwardenNote:
+			record := &loginRecord{Source: source, Folder: folder, Title: item.Name, Notes: item.Notes, Meta: meta}
+
+			if imported, ok := record.item(bitwardenSource, passphrase); ok {
+				items = append(items, imported)
+			} else {
+				skipped = append(skipped, SkippedItem{Source: source, Reason: "empty note"})
+			}
+		case bitwardenCard:
+			record := &cardRecord{
+				Source: source,
+				Folder: folder,
+				Title:  item.Name,
+				Holder: item.Card.CardholderName,
+				Number: item.Card.Number,
+				Month:  item.Card.ExpMonth,
+				Year:   item.Card.ExpYear,
+				CVV:    item.Card.Code,
+				Notes:  item.Notes,
+				Meta:   meta,
+			}
+
+			if imported, reason := record.item(bitwardenSource, passphrase); reason == "" {
+				items = append(items, imported)
+			} else {
+				skipped = append(skipped, SkippedItem{Source: source, Reason: reason})
+			}
+		default:
+			skipped = append(skipped, SkippedItem{Source: source, Reason: fmt.Sprintf("unsupported item type %d", item.Type)})
+		}
+	}
+
+	return items, skipped, nil
+}
@@ -0,0 +1,96 @@
+package adapters_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/novoseltcev/passkeeper/internal/adapters"
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/v1/secrets"
+)
+
+func readTestdata(t *testing.T, name string) []byte {
+	t.Helper()
+
+	data, err := os.ReadFile("testdata/" + name)
+	require.NoError(t, err)
+
+	return data
+}
+
+func TestBitwardenItems(t *testing.T) {
+	t.Parallel()
+
+	items, skipped, err := adapters.BitwardenItems(readTestdata(t, "bitwarden.json"), testPassphrase)
+	require.NoError(t, err)
+
+	assert.Equal(t, []adapters.ImportItem{
+		{Source: "Work/GitHub", Payload: &secrets.PasswordSecretData{
+			Passphrase: testPassphrase,
+			Name:       "GitHub",
+			Login:      "jane",
+			Password:   "gh-s3cr3t!",
+			// the app is not a website, so it is kept in the meta
+			URIs: []secrets.URIData{{URI: "https://github.com/login"}},
+			Meta: map[string]any{
+				"PIN":               "1234",
+				"totp":              "otpauth://totp/GitHub:jane?secret=JBSWY3DPEHPK3PXP&issuer=GitHub",
+				"notes":             "recovery codes are in the safe",
+				adapters.MetaApps:   "androidapp://com.github.android",
+				adapters.MetaFolder: "Work",
+			},
+		}},
+		{Source: "X", Payload: &secrets.PasswordSecretData{
+			Passphrase: testPassphrase,
+			Name:       "Bitwarden/X",
+			Login:      "jane",
+			Password:   "x-s3cr3t",
+			Meta:       map[string]any{adapters.MetaApps: "iosapp://com.atebits.Tweetie2"},
+		}},
+		{Source: "Work/Wi-Fi at the office", Payload: &secrets.TextSecretData{
+			Passphrase: testPassphrase,
+			Name:       "Wi-Fi at the office",
+			Content:    "SSID: office, key: w1f1-k3y",
+			Meta:       map[string]any{adapters.MetaFolder: "Work"},
+		}},
+		{Source: "Visa", Payload: &secrets.CardSecretData{
+			Passphrase: testPassphrase,
+			Name:       "Visa",
+			Number:     "4111111111111111",
+			Holder:     "Jane Doe",
+			Exp:        "07/29",
+			CVV:        "123",
+			Meta:       map[string]any{},
+		}},
+	}, items)
+
+	assert.Equal(t, []adapters.SkippedItem{
+		{Source: "Store card", Reason: "card without number, expiration or CVV"},
+		{Source: "Passkey only", Reason: "no password or notes"},
+		{Source: "Passport", Reason: "unsupported item type 4"},
+	}, skipped)
+}
+
+func TestBitwardenItems_Fails(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		data string
+	}{
+		{name: "not json", data: "name,url,username,password\n"},
+		{name: "encrypted", data: `{"encrypted":true,"encKeyValidation_DO_NOT_EDIT":"2.AAAA","data":"2.BBBB"}`},
+		{name: "items of another type", data: `{"encrypted":false,"items":{}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, _, err := adapters.BitwardenItems([]byte(tt.data), testPassphrase)
+			require.ErrorIs(t, err, adapters.ErrInvalidExport)
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package adapters
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+)
+
+const csvSource = "CSV"
+
+// csvColumns are the known columns of the CSV exports by their lowercased names.
+var csvColumns = map[string]string{
+	"title":          "title",
+	"name":           "title",
+	"url":            "url",
+	"website":        "url",
+	"login_uri":      "url",
+	"username":       "username",
+	"login_username": "username",
+	"password":       "password",
+	"login_password": "password",
+	"notes":          "notes",
+	"note":           "notes",
+	"notesplain":     "notes",
+	"folder":         "folder",
+	"vault":          "folder",
+}
+
+// CSVItems maps the rows of a CSV export with a header, such as the passwords of Chrome and Firefox.
+//
+// The known columns are mapped by their names, e.g. name or title, url, username, password and note,
+// the other columns are in the meta. The host of the URL is the name if there is no name column.
+func CSVItems(data []byte, passphrase string) ([]ImportItem, []SkippedItem, error) {
+	return csvItems(data, passphrase, csvSource)
+}
+
+func csvItems(data []byte, passphrase, source string) ([]ImportItem, []SkippedItem, error) {
+	r := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+	r.FieldsPerRecord = -1
+
+	header, err := r.Read()
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", ErrInvalidExport, err)
+	}
+
+	var (
+		items   []ImportItem
+		skipped []SkippedItem
+	)
+
+	for n := 1; ; n++ {
+		row, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			return items, skipped, nil
+		}
+
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %w", ErrInvalidExport, err)
+		}
+
+		record := &loginRecord{Meta: make(map[string]any)}
+
+		var rawURL string
+
+		for i, value := range row {
+			if i >= len(header) || value == "" {
+				continue
+			}
+
+			switch csvColumns[strings.ToLower(strings.TrimSpace(header[i]))] {
+			case "title":
+				record.Title = value
+			case "url":
+				rawURL = value
+			case "username":
+				record.Username = value
+			case "password":
+				record.Password = value
+			case "notes":
+				record.Notes = value
+			case "folder":
+				record.Folder = value
+			default:
+				record.Meta[header[i]] = value
+			}
+		}
+
+		record.URLs = []string{rawURL}
+
+		if record.Title == "" {
+			if parsed, err := url.Parse(rawURL); err == nil {
+				record.Title = parsed.Hostname()
+			}
+		}
+
+		record.Source = path.Join(record.Folder, record.Title)
+		if record.Source == "" {
+			record.Source = "row " + strconv.Itoa(n)
+		}
+
+		if imported, ok := record.item(source, passphrase); ok {
+			items = append(items, imported)
+		} else {
+			skipped = append(skipped, SkippedItem{Source: record.Source, Reason: "no password or notes"})
+		}
+	}
+}
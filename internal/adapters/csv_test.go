@@ -0,0 +1,165 @@
+package adapters_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/novoseltcev/passkeeper/internal/adapters"
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/v1/secrets"
+)
+
+func TestCSVItems(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		data    []byte
+		items   []adapters.ImportItem
+		skipped []adapters.SkippedItem
+	}{
+		{
+			name: "chrome",
+			data: readTestdata(t, "chrome.csv"),
+			items: []adapters.ImportItem{
+				{Source: "github.com", Payload: &secrets.PasswordSecretData{
+					Passphrase: testPassphrase,
+					Name:       "github.com",
+					Login:      "jane",
+					Password:   "gh-s3cr3t!",
+					URIs:       []secrets.URIData{{URI: "https://github.com/login"}},
+					Meta:       map[string]any{},
+				}},
+				{Source: "mail.example.com", Payload: &secrets.PasswordSecretData{
+					Passphrase: testPassphrase,
+					Name:       "mail.example.com",
+					Login:      "jane@example.com",
+					Password:   "m41l",
+					URIs:       []secrets.URIData{{URI: "https://mail.example.com/"}},
+					Meta:       map[string]any{"notes": "two\nlines"},
+				}},
+				{Source: "com.example.app", Payload: &secrets.PasswordSecretData{
+					Passphrase: testPassphrase,
+					Name:       "com.example.app",
+					Login:      "jane",
+					Password:   "app-pass",
+					Meta:       map[string]any{adapters.MetaApps: "android://Zm9vYmFy@com.example.app/"},
+				}},
+			},
+			skipped: []adapters.SkippedItem{{Source: "example.org", Reason: "no password or notes"}},
+		},
+		{
+			name: "firefox",
+			data: readTestdata(t, "firefox.csv"),
+			items: []adapters.ImportItem{
+				{Source: "accounts.example.com", Payload: &secrets.PasswordSecretData{
+					Passphrase: testPassphrase,
+					Name:       "accounts.example.com",
+					Login:      "jane",
+					Password:   "f1r3f0x",
+					URIs:       []secrets.URIData{{URI: "https://accounts.example.com"}},
+					Meta: map[string]any{
+						"formActionOrigin":    "https://accounts.example.com",
+						"guid":                "{6f3b4a2e-1d2c-4b5a-9e8f-0a1b2c3d4e5f}",
+						"timeCreated":         "1700000000000",
+						"timeLastUsed":        "1710000000000",
+						"timePasswordChanged": "1700000000000",
+					},
+				}},
+				{Source: "router.local", Payload: &secrets.PasswordSecretData{
+					Passphrase: testPassphrase,
+					Name:       "router.local",
+					Login:      "admin",
+					Password:   "r0uter",
+					URIs:       []secrets.URIData{{URI: "https://router.local:8443"}},
+					Meta: map[string]any{
+						"httpRealm":           "Router",
+						"guid":                "{7a4c5b3f-2e3d-4c6b-8f90-1b2c3d4e5f60}",
+						"timeCreated":         "1700000000000",
+						"timeLastUsed":        "1700000000000",
+						"timePasswordChanged": "1700000000000",
+					},
+				}},
+			},
+		},
+		{
+			name: "generic with folders",
+			data: []byte("Folder,Title,Login_Username,Login_Password,Notes,Extra\n" +
+				"Home,Wi-Fi,,w1f1,guest network,\n" +
+				"Home,Bank,jane,b4nk,,pin 1234\n"),
+			items: []adapters.ImportItem{
+				{Source: "Home/Wi-Fi", Payload: &secrets.TextSecretData{
+					Passphrase: testPassphrase,
+					Name:       "Wi-Fi",
+					Content:    "w1f1",
+					Meta:       map[string]any{"notes": "guest network", adapters.MetaFolder: "Home"},
+				}},
+				{Source: "Home/Bank", Payload: &secrets.PasswordSecretData{
+					Passphrase: testPassphrase,
+					Name:       "Bank",
+					Login:      "jane",
+					Password:   "b4nk",
+					Meta:       map[string]any{"Extra": "pin 1234", adapters.MetaFolder: "Home"},
+				}},
+			},
+		},
+		{
+			name: "missing fields",
+			data: []byte("name,url,username,password,note\n" +
+				",,,,\n" +
+				"short\n" +
+				",not a url,,,remember me\n"),
+			items: []adapters.ImportItem{
+				{Source: "row 3", Payload: &secrets.TextSecretData{
+					Passphrase: testPassphrase,
+					Name:       "Untitled",
+					Content:    "remember me",
+					Meta:       map[string]any{"url": "not a url"},
+				}},
+			},
+			skipped: []adapters.SkippedItem{
+				{Source: "row 1", Reason: "no password or notes"},
+				{Source: "short", Reason: "no password or notes"},
+			},
+		},
+		{
+			name: "header only",
+			data: []byte("name,url,username,password,note\n"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			items, skipped, err := adapters.CSVItems(tt.data, testPassphrase)
+			require.NoError(t, err)
+			assert.Equal(t, tt.items, items)
+			assert.Equal(t, tt.skipped, skipped)
+		})
+	}
+}
+
+func TestCSVItems_Fails(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		data string
+	}{
+		{name: "empty", data: ""},
+		{name: "bare quote in header", data: "na\"me,url\n"},
+		{name: "bare quote in row", data: "name,password\nGit\"Hub,secret\n"},
+		{name: "unclosed quote", data: "name,password\n\"GitHub,secret\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, _, err := adapters.CSVItems([]byte(tt.data), testPassphrase)
+			require.ErrorIs(t, err, adapters.ErrInvalidExport)
+		})
+	}
+}
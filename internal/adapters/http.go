@@ -6,11 +6,22 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"mime"
+	"mime/multipart"
 	"net/http"
 	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
 
 	"github.com/novoseltcev/passkeeper/internal/controllers/http/common/response"
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/v1/emergency"
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/v1/generate"
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/v1/orgs"
 	"github.com/novoseltcev/passkeeper/internal/controllers/http/v1/secrets"
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/v1/sends"
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/v1/templates"
 	"github.com/novoseltcev/passkeeper/internal/controllers/http/v1/user"
 )
 
@@ -26,7 +37,7 @@ func NewHTTP(client *http.Client, baseURL string) *HTTP {
 }
 
 func (a *HTTP) doRequest(req *http.Request, codes []int) ([]byte, error) {
-	if req.Body != nil {
+	if req.Body != nil && req.Header.Get("Content-Type") == "" {
 		req.Header.Set("Content-Type", "application/json")
 	}
 
@@ -52,8 +63,11 @@ func (a *HTTP) doRequest(req *http.Request, codes []int) ([]byte, error) {
 		}
 	}
 
-	if resp.StatusCode == http.StatusUnauthorized {
+	switch resp.StatusCode {
+	case http.StatusUnauthorized:
 		return nil, ErrUnauthorized
+	case http.StatusPreconditionFailed:
+		return nil, ErrPreconditionFailed
 	}
 
 	return body, fmt.Errorf("failed to get response: %s", resp.Status)
@@ -68,6 +82,10 @@ func (a *HTTP) GetSecretsPage(
 	v.Set("limit", fmt.Sprint(params.Limit))
 	v.Set("offset", fmt.Sprint(params.Offset))
 
+	if params.OrgID != "" {
+		v.Set("org_id", params.OrgID)
+	}
+
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, a.baseURL+"/api/v1/secrets?"+v.Encode(), nil)
 	if err != nil {
 		return nil, 0, err
@@ -133,34 +151,210 @@ func (a *HTTP) DecryptSecret(
 	return schema.Result, nil
 }
 
-func (a *HTTP) Add(ctx context.Context, token string, data any) (string, error) {
+func (a *HTTP) GenerateOTP(
+	ctx context.Context,
+	token, uuid string,
+	data *secrets.DecryptByIDData,
+) (*secrets.OTPSchema, error) {
 	reqBody, err := json.Marshal(data)
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		a.baseURL+"/api/v1/secrets/"+uuid+"/otp",
+		bytes.NewBuffer(reqBody),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	body, err := a.doRequest(req, []int{http.StatusOK})
+	if err != nil {
+		return nil, err
+	}
+
+	var schema response.Response[secrets.OTPSchema]
+	if err := json.Unmarshal(body, &schema); err != nil {
+		return nil, err
+	}
+
+	if !schema.Success {
+		return nil, fmt.Errorf("failed to generate otp: %s", schema.Errors)
 	}
 
-	var secretType string
-	switch data.(type) {
-	case *secrets.PasswordSecretData:
-		secretType = "password"
-	case *secrets.CardSecretData:
-		secretType = "card"
-	case *secrets.TextSecretData:
-		secretType = "text"
-	case *secrets.FileSecretData:
-		secretType = "file"
-	default:
-		return "", fmt.Errorf("unknown secret type")
+	return schema.Result, nil
+}
+
+func (a *HTTP) Report(
+	ctx context.Context,
+	token string,
+	data *secrets.ReportData,
+) (*secrets.ReportSchema, error) {
+	reqBody, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
 	}
 
 	req, err := http.NewRequestWithContext(
 		ctx,
 		http.MethodPost,
-		a.baseURL+"/api/v1/secrets/"+secretType,
+		a.baseURL+"/api/v1/secrets/report",
 		bytes.NewBuffer(reqBody),
 	)
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	body, err := a.doRequest(req, []int{http.StatusOK})
+	if err != nil {
+		return nil, err
+	}
+
+	var schema response.Response[secrets.ReportSchema]
+	if err := json.Unmarshal(body, &schema); err != nil {
+		return nil, err
+	}
+
+	if !schema.Success {
+		return nil, fmt.Errorf("failed to build report: %s", schema.Errors)
+	}
+
+	return schema.Result, nil
+}
+
+// Batch runs the operations at once, the results tell the failed operation if the batch fails.
+func (a *HTTP) Batch(
+	ctx context.Context,
+	token string,
+	data *secrets.BatchData,
+) ([]secrets.BatchResultSchema, error) {
+	reqBody, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		a.baseURL+"/api/v1/secrets/batch",
+		bytes.NewBuffer(reqBody),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	body, err := a.doRequest(req, []int{http.StatusOK, http.StatusUnprocessableEntity})
+	if err != nil {
+		return nil, err
+	}
+
+	var schema response.Response[[]secrets.BatchResultSchema]
+	if err := json.Unmarshal(body, &schema); err != nil {
+		return nil, err
+	}
+
+	var results []secrets.BatchResultSchema
+	if schema.Result != nil {
+		results = *schema.Result
+	}
+
+	if !schema.Success {
+		return results, fmt.Errorf("failed to run batch: %s", schema.Errors)
+	}
+
+	return results, nil
+}
+
+// ExportVault returns the archive of the personal vault encrypted with the export password.
+func (a *HTTP) ExportVault(ctx context.Context, token string, data *secrets.ExportData) ([]byte, error) {
+	reqBody, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		a.baseURL+"/api/v1/vault/export",
+		bytes.NewBuffer(reqBody),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	return a.doRequest(req, []int{http.StatusOK})
+}
+
+// ImportVault imports the archive, the results tell the invalid and the failed secrets if the import fails.
+func (a *HTTP) ImportVault(ctx context.Context, token string, data *secrets.ImportData) (*secrets.ImportSchema, error) {
+	reqBody, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		a.baseURL+"/api/v1/vault/import",
+		bytes.NewBuffer(reqBody),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	body, err := a.doRequest(req, []int{http.StatusOK, http.StatusUnprocessableEntity})
+	if err != nil {
+		return nil, err
+	}
+
+	var schema response.Response[secrets.ImportSchema]
+	if err := json.Unmarshal(body, &schema); err != nil {
+		return nil, err
+	}
+
+	if !schema.Success {
+		return schema.Result, fmt.Errorf("failed to import: %s", schema.Errors)
+	}
+
+	return schema.Result, nil
+}
+
+func (a *HTTP) GenerateSSHKey(
+	ctx context.Context,
+	token string,
+	data *secrets.GenerateSSHKeyData,
+) (*secrets.GeneratedSSHKeySchema, error) {
+	reqBody, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		a.baseURL+"/api/v1/secrets/ssh_key/generate",
+		bytes.NewBuffer(reqBody),
+	)
+	if err != nil {
+		return nil, err
 	}
 
 	req.Header.Set("Accept", "application/json")
@@ -168,84 +362,1061 @@ func (a *HTTP) Add(ctx context.Context, token string, data any) (string, error)
 
 	body, err := a.doRequest(req, []int{http.StatusCreated})
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+
+	var schema response.Response[secrets.GeneratedSSHKeySchema]
+	if err := json.Unmarshal(body, &schema); err != nil {
+		return nil, err
+	}
+
+	if !schema.Success {
+		return nil, fmt.Errorf("failed to generate ssh key: %s", schema.Errors)
+	}
+
+	return schema.Result, nil
+}
+
+func (a *HTTP) Add(ctx context.Context, token string, data secrets.Payload) (string, []response.Warning, error) {
+	reqBody, err := json.Marshal(data)
+	if err != nil {
+		return "", nil, err
+	}
+
+	t, ok := secrets.TypeOf(data)
+	if !ok {
+		return "", nil, fmt.Errorf("unknown secret type %T", data)
+	}
+
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		a.baseURL+"/api/v1/secrets/"+t.Name,
+		bytes.NewBuffer(reqBody),
+	)
+	if err != nil {
+		return "", nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	body, err := a.doRequest(req, []int{http.StatusCreated})
+	if err != nil {
+		return "", nil, err
 	}
 
 	var schema response.Response[response.CreatedData[string]]
 	if err := json.Unmarshal(body, &schema); err != nil {
-		return "", err
+		return "", nil, err
 	}
 
 	if !schema.Success {
-		return "", fmt.Errorf("failed to add secret: %s", schema.Errors)
+		return "", nil, fmt.Errorf("failed to add secret: %s", schema.Errors)
 	}
 
-	return schema.Result.ID, nil
+	return schema.Result.ID, schema.Warnings, nil
 }
 
-func (a *HTTP) Update(ctx context.Context, token string, uuid string, data any) error {
+// Update replaces the secret of the revision of the entity tag, "*" replaces any revision.
+func (a *HTTP) Update(
+	ctx context.Context,
+	token string,
+	uuid string,
+	etag string,
+	data secrets.Payload,
+) ([]response.Warning, error) {
 	reqBody, err := json.Marshal(data)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	var secretType string
-	switch data.(type) {
-	case *secrets.PasswordSecretData:
-		secretType = "password"
-	case *secrets.CardSecretData:
-		secretType = "card"
-	case *secrets.TextSecretData:
-		secretType = "text"
-	case *secrets.FileSecretData:
-		secretType = "file"
-	default:
-		return fmt.Errorf("unknown secret type")
+	t, ok := secrets.TypeOf(data)
+	if !ok {
+		return nil, fmt.Errorf("unknown secret type %T", data)
 	}
 
 	req, err := http.NewRequestWithContext(
 		ctx,
 		http.MethodPut,
-		a.baseURL+"/api/v1/secrets/"+secretType+"/"+uuid,
+		a.baseURL+"/api/v1/secrets/"+t.Name+"/"+uuid,
 		bytes.NewBuffer(reqBody),
 	)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("If-Match", etag)
 
-	body, err := a.doRequest(req, []int{http.StatusNoContent})
+	body, err := a.doRequest(req, []int{http.StatusOK, http.StatusNoContent})
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if len(body) > 0 {
 		var schema response.Response[any]
 		if err := json.Unmarshal(body, &schema); err != nil {
-			return err
+			return nil, err
 		}
 
 		if !schema.Success {
-			return fmt.Errorf("failed to update secret: %s", schema.Errors)
+			return nil, fmt.Errorf("failed to update secret: %s", schema.Errors)
 		}
+
+		return schema.Warnings, nil
 	}
 
-	return nil
+	return nil, nil
 }
 
-func (a *HTTP) DeleteSecret(ctx context.Context, token string, uuid string) error {
-	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, a.baseURL+"/api/v1/secrets/"+uuid, nil)
+// UploadFile creates a file secret with the content of the file at the path.
+//
+// The file is streamed as the last part of a multipart form, so it is never read into memory whole.
+// The filename defaults to the base name of the path.
+func (a *HTTP) UploadFile(
+	ctx context.Context,
+	token string,
+	path string,
+	data *secrets.UploadFileData,
+) (string, []response.Warning, error) {
+	file, err := os.Open(path)
 	if err != nil {
-		return err
+		return "", nil, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return "", nil, err
+	}
+
+	if info.Size() > secrets.MaxUploadSize {
+		return "", nil, fmt.Errorf("file is larger than %d bytes", secrets.MaxUploadSize)
+	}
+
+	filename := data.Filename
+	if filename == "" {
+		filename = filepath.Base(path)
+	}
+
+	reader, writer := io.Pipe()
+	form := multipart.NewWriter(writer)
+
+	go func() {
+		writer.CloseWithError(writeUpload(form, file, filename, data))
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.baseURL+"/api/v1/secrets/file/upload", reader)
+	if err != nil {
+		reader.Close()
+
+		return "", nil, err
 	}
 
+	req.Header.Set("Accept", "application/json")
 	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", form.FormDataContentType())
 
-	_, err = a.doRequest(req, []int{http.StatusNoContent})
+	body, err := a.doRequest(req, []int{http.StatusCreated})
+	if err != nil {
+		return "", nil, err
+	}
 
-	return err
+	var schema response.Response[response.CreatedData[string]]
+	if err := json.Unmarshal(body, &schema); err != nil {
+		return "", nil, err
+	}
+
+	if !schema.Success {
+		return "", nil, fmt.Errorf("failed to upload file: %s", schema.Errors)
+	}
+
+	return schema.Result.ID, schema.Warnings, nil
+}
+
+// writeUpload writes the fields of the upload form and then the content of the file.
+func writeUpload(form *multipart.Writer, file io.Reader, filename string, data *secrets.UploadFileData) error {
+	meta, err := json.Marshal(data.Meta)
+	if err != nil {
+		return err
+	}
+
+	fields := [][2]string{
+		{"passphrase", data.Passphrase},
+		{"name", data.Name},
+		{"filename", filename},
+		{"meta", string(meta)},
+	}
+
+	if data.ExpiresAt != nil {
+		fields = append(fields, [2]string{"expires_at", data.ExpiresAt.Format(time.RFC3339)})
+	}
+
+	if data.RotateEveryDays > 0 {
+		fields = append(fields, [2]string{"rotate_every_days", strconv.Itoa(data.RotateEveryDays)})
+	}
+
+	if data.OrgID != "" {
+		fields = append(fields, [2]string{"org_id", data.OrgID})
+	}
+
+	for _, field := range fields {
+		if err := form.WriteField(field[0], field[1]); err != nil {
+			return err
+		}
+	}
+
+	part, err := form.CreateFormFile(secrets.FilePart, filename)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(part, file); err != nil {
+		return err
+	}
+
+	return form.Close()
+}
+
+// DownloadFile saves the decrypted content of a file secret at the path and returns the path of the saved file.
+//
+// If the path is a directory, the file is saved there under its own filename.
+// Existing files are not overwritten and the saved file is readable by the user only.
+func (a *HTTP) DownloadFile(
+	ctx context.Context,
+	token string,
+	uuid string,
+	data *secrets.DecryptByIDData,
+	path string,
+) (string, error) {
+	reqBody, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		a.baseURL+"/api/v1/secrets/"+uuid+"/download",
+		bytes.NewBuffer(reqBody),
+	)
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("Accept", "application/octet-stream")
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return "", ErrUnauthorized
+	} else if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to get response: %s", resp.Status)
+	}
+
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		path = filepath.Join(path, attachmentName(resp.Header.Get("Content-Disposition"), uuid))
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600) // nolint: mnd
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := io.Copy(file, resp.Body); err != nil {
+		file.Close()
+		os.Remove(path)
+
+		return "", err
+	}
+
+	return path, file.Close()
+}
+
+// attachmentName returns the base name of the attachment filename, which can't escape the directory.
+func attachmentName(disposition string, fallback string) string {
+	_, params, err := mime.ParseMediaType(disposition)
+	if err != nil {
+		return fallback
+	}
+
+	name := filepath.Base(params["filename"])
+	if name == "." || name == ".." || name == string(filepath.Separator) {
+		return fallback
+	}
+
+	return name
+}
+
+func (a *HTTP) DeleteSecret(ctx context.Context, token string, uuid string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, a.baseURL+"/api/v1/secrets/"+uuid, nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	_, err = a.doRequest(req, []int{http.StatusNoContent})
+
+	return err
+}
+
+func (a *HTTP) ShareSecret(ctx context.Context, token string, uuid string, data *secrets.ShareData) error {
+	reqBody, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		a.baseURL+"/api/v1/secrets/"+uuid+"/shares",
+		bytes.NewBuffer(reqBody),
+	)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	_, err = a.doRequest(req, []int{http.StatusNoContent})
+
+	return err
+}
+
+func (a *HTTP) GetShares(ctx context.Context, token string, uuid string) ([]secrets.ShareSchema, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, a.baseURL+"/api/v1/secrets/"+uuid+"/shares", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	body, err := a.doRequest(req, []int{http.StatusOK})
+	if err != nil {
+		return nil, err
+	}
+
+	var schema response.Response[[]secrets.ShareSchema]
+	if err := json.Unmarshal(body, &schema); err != nil {
+		return nil, err
+	}
+
+	if !schema.Success {
+		return nil, fmt.Errorf("failed to get shares: %s", schema.Errors)
+	}
+
+	return *schema.Result, nil
+}
+
+func (a *HTTP) GetUsage(ctx context.Context, token string) (*secrets.UsageSchema, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, a.baseURL+"/api/v1/user/usage", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	body, err := a.doRequest(req, []int{http.StatusOK})
+	if err != nil {
+		return nil, err
+	}
+
+	var schema response.Response[secrets.UsageSchema]
+	if err := json.Unmarshal(body, &schema); err != nil {
+		return nil, err
+	}
+
+	if !schema.Success {
+		return nil, fmt.Errorf("failed to get usage: %s", schema.Errors)
+	}
+
+	return schema.Result, nil
+}
+
+func (a *HTTP) RevokeShare(
+	ctx context.Context,
+	token string,
+	uuid string,
+	userID string,
+	data *secrets.RevokeData,
+) error {
+	reqBody, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodDelete,
+		a.baseURL+"/api/v1/secrets/"+uuid+"/shares/"+userID,
+		bytes.NewBuffer(reqBody),
+	)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	_, err = a.doRequest(req, []int{http.StatusNoContent})
+
+	return err
+}
+
+// MatchSecrets returns the candidate passwords for the URL, their URIs must be matched after decryption.
+func (a *HTTP) MatchSecrets(
+	ctx context.Context,
+	token string,
+	params *secrets.MatchRequest,
+) ([]secrets.SecretItemSchema, error) {
+	v := make(url.Values)
+	v.Set("url", params.URL)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, a.baseURL+"/api/v1/secrets/match?"+v.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	body, err := a.doRequest(req, []int{http.StatusOK})
+	if err != nil {
+		return nil, err
+	}
+
+	var schema response.Response[[]secrets.SecretItemSchema]
+	if err := json.Unmarshal(body, &schema); err != nil {
+		return nil, err
+	}
+
+	if !schema.Success {
+		return nil, fmt.Errorf("failed to match secrets: %s", schema.Errors)
+	}
+
+	return *schema.Result, nil
+}
+
+func (a *HTTP) GetSharedWithMe(ctx context.Context, token string) ([]secrets.SharedSecretSchema, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, a.baseURL+"/api/v1/secrets/shared", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	body, err := a.doRequest(req, []int{http.StatusOK})
+	if err != nil {
+		return nil, err
+	}
+
+	var schema response.Response[[]secrets.SharedSecretSchema]
+	if err := json.Unmarshal(body, &schema); err != nil {
+		return nil, err
+	}
+
+	if !schema.Success {
+		return nil, fmt.Errorf("failed to get shared secrets: %s", schema.Errors)
+	}
+
+	return *schema.Result, nil
+}
+
+func (a *HTTP) CreateOrg(ctx context.Context, token string, data *orgs.CreateOrgData) (string, error) {
+	reqBody, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.baseURL+"/api/v1/orgs", bytes.NewBuffer(reqBody))
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	body, err := a.doRequest(req, []int{http.StatusCreated})
+	if err != nil {
+		return "", err
+	}
+
+	var schema response.Response[response.CreatedData[string]]
+	if err := json.Unmarshal(body, &schema); err != nil {
+		return "", err
+	}
+
+	if !schema.Success {
+		return "", fmt.Errorf("failed to create organization: %s", schema.Errors)
+	}
+
+	return schema.Result.ID, nil
+}
+
+func (a *HTTP) GetOrgs(ctx context.Context, token string) ([]orgs.OrgSchema, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, a.baseURL+"/api/v1/orgs", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	body, err := a.doRequest(req, []int{http.StatusOK})
+	if err != nil {
+		return nil, err
+	}
+
+	var schema response.Response[[]orgs.OrgSchema]
+	if err := json.Unmarshal(body, &schema); err != nil {
+		return nil, err
+	}
+
+	if !schema.Success {
+		return nil, fmt.Errorf("failed to get organizations: %s", schema.Errors)
+	}
+
+	return *schema.Result, nil
+}
+
+func (a *HTTP) GetOrgMembers(ctx context.Context, token string, uuid string) ([]orgs.MemberSchema, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, a.baseURL+"/api/v1/orgs/"+uuid+"/members", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	body, err := a.doRequest(req, []int{http.StatusOK})
+	if err != nil {
+		return nil, err
+	}
+
+	var schema response.Response[[]orgs.MemberSchema]
+	if err := json.Unmarshal(body, &schema); err != nil {
+		return nil, err
+	}
+
+	if !schema.Success {
+		return nil, fmt.Errorf("failed to get members: %s", schema.Errors)
+	}
+
+	return *schema.Result, nil
+}
+
+func (a *HTTP) InviteToOrg(ctx context.Context, token string, uuid string, data *orgs.InviteData) (string, error) {
+	reqBody, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		a.baseURL+"/api/v1/orgs/"+uuid+"/invitations",
+		bytes.NewBuffer(reqBody),
+	)
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	body, err := a.doRequest(req, []int{http.StatusCreated})
+	if err != nil {
+		return "", err
+	}
+
+	var schema response.Response[response.CreatedData[string]]
+	if err := json.Unmarshal(body, &schema); err != nil {
+		return "", err
+	}
+
+	if !schema.Success {
+		return "", fmt.Errorf("failed to invite: %s", schema.Errors)
+	}
+
+	return schema.Result.ID, nil
+}
+
+func (a *HTTP) RemoveOrgMember(ctx context.Context, token string, uuid string, userID string) error {
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodDelete,
+		a.baseURL+"/api/v1/orgs/"+uuid+"/members/"+userID,
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	_, err = a.doRequest(req, []int{http.StatusNoContent})
+
+	return err
+}
+
+func (a *HTTP) GetInvitations(ctx context.Context, token string) ([]orgs.InvitationSchema, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, a.baseURL+"/api/v1/invitations", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	body, err := a.doRequest(req, []int{http.StatusOK})
+	if err != nil {
+		return nil, err
+	}
+
+	var schema response.Response[[]orgs.InvitationSchema]
+	if err := json.Unmarshal(body, &schema); err != nil {
+		return nil, err
+	}
+
+	if !schema.Success {
+		return nil, fmt.Errorf("failed to get invitations: %s", schema.Errors)
+	}
+
+	return *schema.Result, nil
+}
+
+func (a *HTTP) AcceptInvitation(ctx context.Context, token string, uuid string) error {
+	return a.answerInvitation(ctx, token, uuid, "accept")
+}
+
+func (a *HTTP) DeclineInvitation(ctx context.Context, token string, uuid string) error {
+	return a.answerInvitation(ctx, token, uuid, "decline")
+}
+
+func (a *HTTP) answerInvitation(ctx context.Context, token string, uuid string, answer string) error {
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		a.baseURL+"/api/v1/invitations/"+uuid+"/"+answer,
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	_, err = a.doRequest(req, []int{http.StatusNoContent})
+
+	return err
+}
+
+func (a *HTTP) CreateSend(ctx context.Context, token string, data *sends.CreateSendData) (*sends.SendSchema, error) {
+	reqBody, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.baseURL+"/api/v1/sends", bytes.NewBuffer(reqBody))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	body, err := a.doRequest(req, []int{http.StatusCreated})
+	if err != nil {
+		return nil, err
+	}
+
+	var schema response.Response[sends.SendSchema]
+	if err := json.Unmarshal(body, &schema); err != nil {
+		return nil, err
+	}
+
+	if !schema.Success {
+		return nil, fmt.Errorf("failed to create send: %s", schema.Errors)
+	}
+
+	return schema.Result, nil
+}
+
+// GetSend returns the encrypted payload of the send at the address of its link.
+//
+// The address is used as is, since the send may be created on another server.
+func (a *HTTP) GetSend(ctx context.Context, address string, password string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, address, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	if password != "" {
+		req.Header.Set(sends.PasswordHeader, password)
+	}
+
+	body, err := a.doRequest(req, []int{http.StatusOK})
+	if err != nil {
+		return nil, err
+	}
+
+	var schema response.Response[sends.SendDataSchema]
+	if err := json.Unmarshal(body, &schema); err != nil {
+		return nil, err
+	}
+
+	if !schema.Success {
+		return nil, fmt.Errorf("failed to get send: %s", schema.Errors)
+	}
+
+	return schema.Result.Data, nil
+}
+
+func (a *HTTP) InviteEmergencyContact(ctx context.Context, token string, data *emergency.InviteData) (string, error) {
+	reqBody, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		a.baseURL+"/api/v1/emergency/contacts",
+		bytes.NewBuffer(reqBody),
+	)
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	body, err := a.doRequest(req, []int{http.StatusCreated})
+	if err != nil {
+		return "", err
+	}
+
+	var schema response.Response[response.CreatedData[string]]
+	if err := json.Unmarshal(body, &schema); err != nil {
+		return "", err
+	}
+
+	if !schema.Success {
+		return "", fmt.Errorf("failed to invite trusted contact: %s", schema.Errors)
+	}
+
+	return schema.Result.ID, nil
+}
+
+func (a *HTTP) GetEmergencyContacts(ctx context.Context, token string) ([]emergency.AccessSchema, error) {
+	return a.getEmergencyAccesses(ctx, token, "contacts")
+}
+
+func (a *HTTP) GetEmergencyGrantors(ctx context.Context, token string) ([]emergency.AccessSchema, error) {
+	return a.getEmergencyAccesses(ctx, token, "grantors")
+}
+
+func (a *HTTP) getEmergencyAccesses(ctx context.Context, token string, side string) ([]emergency.AccessSchema, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, a.baseURL+"/api/v1/emergency/"+side, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	body, err := a.doRequest(req, []int{http.StatusOK})
+	if err != nil {
+		return nil, err
+	}
+
+	var schema response.Response[[]emergency.AccessSchema]
+	if err := json.Unmarshal(body, &schema); err != nil {
+		return nil, err
+	}
+
+	if !schema.Success {
+		return nil, fmt.Errorf("failed to get emergency accesses: %s", schema.Errors)
+	}
+
+	return *schema.Result, nil
+}
+
+func (a *HTTP) RequestEmergencyAccess(ctx context.Context, token string, uuid string) error {
+	return a.changeEmergencyAccess(ctx, token, uuid, "request")
+}
+
+func (a *HTTP) ApproveEmergencyAccess(ctx context.Context, token string, uuid string) error {
+	return a.changeEmergencyAccess(ctx, token, uuid, "approve")
+}
+
+func (a *HTTP) RejectEmergencyAccess(ctx context.Context, token string, uuid string) error {
+	return a.changeEmergencyAccess(ctx, token, uuid, "reject")
+}
+
+func (a *HTTP) changeEmergencyAccess(ctx context.Context, token string, uuid string, action string) error {
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		a.baseURL+"/api/v1/emergency/"+uuid+"/"+action,
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	_, err = a.doRequest(req, []int{http.StatusNoContent})
+
+	return err
+}
+
+func (a *HTTP) RevokeEmergencyAccess(ctx context.Context, token string, uuid string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, a.baseURL+"/api/v1/emergency/"+uuid, nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	_, err = a.doRequest(req, []int{http.StatusNoContent})
+
+	return err
+}
+
+func (a *HTTP) GetEmergencyVault(
+	ctx context.Context,
+	token string,
+	uuid string,
+	params *emergency.PaginationRequest,
+) ([]emergency.SecretItemSchema, uint64, error) {
+	v := make(url.Values)
+	v.Set("limit", fmt.Sprint(params.Limit))
+	v.Set("offset", fmt.Sprint(params.Offset))
+
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		a.baseURL+"/api/v1/emergency/"+uuid+"/secrets?"+v.Encode(),
+		nil,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	body, err := a.doRequest(req, []int{http.StatusOK})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var schema response.PaginatedResponse[emergency.SecretItemSchema]
+	if err := json.Unmarshal(body, &schema); err != nil {
+		return nil, 0, err
+	}
+
+	if !schema.Success {
+		return nil, 0, fmt.Errorf("failed to get emergency vault: %s", schema.Errors)
+	}
+
+	return schema.Result, schema.Pagination.Total, nil
+}
+
+func (a *HTTP) DecryptEmergencySecret(
+	ctx context.Context,
+	token, uuid, secretID string,
+	data *emergency.DecryptData,
+) (*emergency.SecretSchema, error) {
+	reqBody, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		a.baseURL+"/api/v1/emergency/"+uuid+"/secrets/"+secretID+"/decrypt",
+		bytes.NewBuffer(reqBody),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	body, err := a.doRequest(req, []int{http.StatusOK})
+	if err != nil {
+		return nil, err
+	}
+
+	var schema response.Response[emergency.SecretSchema]
+	if err := json.Unmarshal(body, &schema); err != nil {
+		return nil, err
+	}
+
+	if !schema.Success {
+		return nil, fmt.Errorf("failed to decrypt emergency secret: %s", schema.Errors)
+	}
+
+	return schema.Result, nil
+}
+
+func (a *HTTP) GetTemplates(ctx context.Context, token string) ([]templates.TemplateSchema, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, a.baseURL+"/api/v1/templates", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	body, err := a.doRequest(req, []int{http.StatusOK})
+	if err != nil {
+		return nil, err
+	}
+
+	var schema response.Response[[]templates.TemplateSchema]
+	if err := json.Unmarshal(body, &schema); err != nil {
+		return nil, err
+	}
+
+	if !schema.Success {
+		return nil, fmt.Errorf("failed to get templates: %s", schema.Errors)
+	}
+
+	return *schema.Result, nil
+}
+
+func (a *HTTP) GetTemplate(ctx context.Context, token string, uuid string) (*templates.TemplateSchema, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, a.baseURL+"/api/v1/templates/"+uuid, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	body, err := a.doRequest(req, []int{http.StatusOK})
+	if err != nil {
+		return nil, err
+	}
+
+	var schema response.Response[templates.TemplateSchema]
+	if err := json.Unmarshal(body, &schema); err != nil {
+		return nil, err
+	}
+
+	if !schema.Success {
+		return nil, fmt.Errorf("failed to get template: %s", schema.Errors)
+	}
+
+	return schema.Result, nil
+}
+
+func (a *HTTP) CreateTemplate(ctx context.Context, token string, data *templates.CreateTemplateData) (string, error) {
+	reqBody, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		a.baseURL+"/api/v1/templates",
+		bytes.NewBuffer(reqBody),
+	)
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	body, err := a.doRequest(req, []int{http.StatusCreated})
+	if err != nil {
+		return "", err
+	}
+
+	var schema response.Response[response.CreatedData[string]]
+	if err := json.Unmarshal(body, &schema); err != nil {
+		return "", err
+	}
+
+	if !schema.Success {
+		return "", fmt.Errorf("failed to create template: %s", schema.Errors)
+	}
+
+	return schema.Result.ID, nil
+}
+
+func (a *HTTP) DeleteTemplate(ctx context.Context, token string, uuid string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, a.baseURL+"/api/v1/templates/"+uuid, nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	_, err = a.doRequest(req, []int{http.StatusNoContent})
+
+	return err
+}
+
+func (a *HTTP) GeneratePassword(
+	ctx context.Context,
+	token string,
+	data *generate.GeneratePasswordData,
+) (*generate.PasswordSchema, error) {
+	reqBody, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		a.baseURL+"/api/v1/generate/password",
+		bytes.NewBuffer(reqBody),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	body, err := a.doRequest(req, []int{http.StatusOK})
+	if err != nil {
+		return nil, err
+	}
+
+	var schema response.Response[generate.PasswordSchema]
+	if err := json.Unmarshal(body, &schema); err != nil {
+		return nil, err
+	}
+
+	if !schema.Success {
+		return nil, fmt.Errorf("failed to generate password: %s", schema.Errors)
+	}
+
+	return schema.Result, nil
 }
 
 func (a *HTTP) Login(ctx context.Context, data *user.LoginData) (string, error) { // nolint: dupl
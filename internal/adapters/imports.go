@@ -0,0 +1,315 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/v1/secrets"
+	"github.com/novoseltcev/passkeeper/pkg/urimatch"
+)
+
+const (
+	// MetaFolder is the meta key of the folder, which the imported secret was in.
+	MetaFolder = "folder"
+	// MetaApps is the meta key of the URIs of the apps, such as androidapp://com.example, one per line.
+	MetaApps = "apps"
+)
+
+const (
+	minNameLength = 4
+	maxNameLength = 32
+
+	namesPageSize = 100
+)
+
+// ErrInvalidExport means that the export of another password manager can't be read.
+var ErrInvalidExport = errors.New("invalid export")
+
+// ImportFormat parses an export of another password manager into the secrets to create.
+type ImportFormat func(data []byte, passphrase string) ([]ImportItem, []SkippedItem, error)
+
+// ImportFormats are the supported exports by their names, the browsers export their passwords as CSV.
+var ImportFormats = map[string]ImportFormat{
+	"bitwarden": BitwardenItems,
+	"1password": OnePasswordItems,
+	"chrome":    CSVItems,
+	"firefox":   CSVItems,
+	"csv":       CSVItems,
+}
+
+// ImportItem is a secret of another password manager to create.
+type ImportItem struct {
+	// Source is the location of the item in the source, e.g. the group path and the title of a KeePass entry.
+	Source  string
+	Payload secrets.Payload
+}
+
+// SkippedItem is an item of the source, which is not imported.
+type SkippedItem struct {
+	Source string
+	Reason string
+}
+
+// loginRecord is a login of another password manager.
+type loginRecord struct {
+	Source   string
+	Folder   string
+	Title    string
+	Username string
+	Password string
+	Notes    string
+	URLs     []string
+	// Meta are the unmapped fields, the folder is added to them.
+	Meta map[string]any
+}
+
+// item maps the login to a password, or to a note if the username or the password is missing.
+//
+// The note contains the password, or the notes if there is no password. It returns false if the login has neither.
+func (r *loginRecord) item(source, passphrase string) (ImportItem, bool) {
+	meta := r.Meta
+	if meta == nil {
+		meta = make(map[string]any)
+	}
+
+	if r.Folder != "" {
+		meta[MetaFolder] = r.Folder
+	}
+
+	name := secretName(source, r.Folder, r.Title)
+
+	if r.Username != "" && r.Password != "" {
+		data := &secrets.PasswordSecretData{
+			Passphrase: passphrase,
+			Name:       name,
+			Login:      r.Username,
+			Password:   r.Password,
+			Meta:       meta,
+		}
+
+		var apps []string
+
+		for _, url := range r.URLs {
+			switch {
+			case url == "":
+			case webURI(url):
+				data.URIs = append(data.URIs, secrets.URIData{URI: url})
+			default:
+				apps = append(apps, url)
+			}
+		}
+
+		if len(apps) > 0 {
+			meta[MetaApps] = strings.Join(apps, "\n")
+		}
+
+		if r.Notes != "" {
+			meta["notes"] = r.Notes
+		}
+
+		return ImportItem{Source: r.Source, Payload: data}, true
+	}
+
+	if r.Password == "" && r.Notes == "" {
+		return ImportItem{}, false
+	}
+
+	content := r.Notes
+	if r.Password != "" {
+		content = r.Password
+
+		if r.Notes != "" {
+			meta["notes"] = r.Notes
+		}
+	}
+
+	if r.Username != "" {
+		meta["login"] = r.Username
+	}
+
+	if len(r.URLs) > 0 && r.URLs[0] != "" {
+		meta["url"] = r.URLs[0]
+	}
+
+	return ImportItem{
+		Source:  r.Source,
+		Payload: &secrets.TextSecretData{Passphrase: passphrase, Name: name, Content: content, Meta: meta},
+	}, true
+}
+
+// webURI reports whether the URI is a website, which the password can be matched by.
+//
+// The URIs of the apps, e.g. androidapp://com.example of Bitwarden or android://hash@com.example/ of Chrome,
+// are not, their package names would match the websites of the same domain.
+func webURI(uri string) bool {
+	if scheme, _, ok := strings.Cut(uri, "://"); ok && !strings.EqualFold(scheme, "http") &&
+		!strings.EqualFold(scheme, "https") {
+		return false
+	}
+
+	return urimatch.Validate(uri, urimatch.Domain) == nil
+}
+
+// cardRecord is a payment card of another password manager.
+type cardRecord struct {
+	Source string
+	Folder string
+	Title  string
+	Holder string
+	Number string
+	// Month and Year are the expiration, the year has 2 or 4 digits.
+	Month string
+	Year  string
+	CVV   string
+	Notes string
+	// Meta are the unmapped fields, the folder and the notes are added to them.
+	Meta map[string]any
+}
+
+// item maps the card, the reason is set if it can't be a card.
+func (r *cardRecord) item(source, passphrase string) (ImportItem, string) {
+	month, err := strconv.Atoi(r.Month)
+	if r.Number == "" || r.CVV == "" || err != nil || month < 1 || month > 12 || len(r.Year) < 2 {
+		return ImportItem{}, "card without number, expiration or CVV"
+	}
+
+	meta := r.Meta
+	if meta == nil {
+		meta = make(map[string]any)
+	}
+
+	if r.Folder != "" {
+		meta[MetaFolder] = r.Folder
+	}
+
+	if r.Notes != "" {
+		meta["notes"] = r.Notes
+	}
+
+	return ImportItem{Source: r.Source, Payload: &secrets.CardSecretData{
+		Passphrase: passphrase,
+		Name:       secretName(source, r.Folder, r.Title),
+		Number:     strings.ReplaceAll(r.Number, " ", ""),
+		Holder:     r.Holder,
+		Exp:        fmt.Sprintf("%02d/%s", month, r.Year[len(r.Year)-2:]),
+		CVV:        r.CVV,
+		Meta:       meta,
+	}}, ""
+}
+
+// ImportPreview is the summary of the items before they are created.
+type ImportPreview struct {
+	// Counts are the numbers of the items by the names of their types.
+	Counts map[string]int
+	// Conflicts are the items, whose names are taken by the existing secrets or by the previous items.
+	Conflicts []ImportItem
+}
+
+// PreviewItems counts the items by their types and finds the conflicts of their names
+// with the secrets of the personal vault, nothing is written.
+func PreviewItems(ctx context.Context, api API, token string, items []ImportItem) (*ImportPreview, error) {
+	taken, err := secretNames(ctx, api, token)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &ImportPreview{Counts: make(map[string]int)}
+
+	for _, item := range items {
+		typeName := "unknown"
+		if t, ok := secrets.TypeOf(item.Payload); ok {
+			typeName = t.Name
+		}
+
+		preview.Counts[typeName]++
+
+		_, name := item.Payload.Credentials()
+		if taken[name] {
+			preview.Conflicts = append(preview.Conflicts, item)
+		}
+
+		taken[name] = true
+	}
+
+	return preview, nil
+}
+
+// secretNames returns the names of the secrets of the personal vault.
+func secretNames(ctx context.Context, api API, token string) (map[string]bool, error) {
+	names := make(map[string]bool)
+
+	for offset := uint64(0); ; offset += namesPageSize {
+		page, total, err := api.GetSecretsPage(ctx, token, &secrets.PaginationRequest{Limit: namesPageSize, Offset: offset})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, secret := range page {
+			names[secret.Name] = true
+		}
+
+		if len(page) == 0 || offset+namesPageSize >= total {
+			return names, nil
+		}
+	}
+}
+
+// CreateItems creates the secrets of the items one by one and returns the items, which failed.
+//
+// The progress is called after every item. It stops if the context is done or the token is rejected.
+func CreateItems(
+	ctx context.Context,
+	api API,
+	token string,
+	items []ImportItem,
+	progress func(done, total int),
+) ([]SkippedItem, error) {
+	var skipped []SkippedItem
+
+	for i, item := range items {
+		if err := ctx.Err(); err != nil {
+			return skipped, err
+		}
+
+		if _, _, err := api.Add(ctx, token, item.Payload); err != nil {
+			if errors.Is(err, ErrUnauthorized) || ctx.Err() != nil {
+				return skipped, err
+			}
+
+			skipped = append(skipped, SkippedItem{Source: item.Source, Reason: err.Error()})
+		}
+
+		if progress != nil {
+			progress(i+1, len(items))
+		}
+	}
+
+	return skipped, nil
+}
+
+// secretName fits the name into the limits of the secret names.
+//
+// The short names are prefixed with the folder, and then with the source if they are still short,
+// e.g. "b" in the folder "a" is "KeePass/a/b". The long names are cut.
+func secretName(source, folder, name string) string {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = "Untitled"
+	}
+
+	for _, prefix := range []string{strings.TrimSpace(folder), source} {
+		if prefix != "" && utf8.RuneCountInString(name) < minNameLength {
+			name = prefix + "/" + name
+		}
+	}
+
+	if runes := []rune(name); len(runes) > maxNameLength {
+		name = strings.TrimSpace(string(runes[:maxNameLength]))
+	}
+
+	return name
+}
@@ -0,0 +1,137 @@
+package adapters_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/novoseltcev/passkeeper/internal/adapters"
+	"github.com/novoseltcev/passkeeper/internal/adapters/mocks"
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/v1/secrets"
+)
+
+const testToken = "token"
+
+var errTest = errors.New("test error")
+
+func TestPreviewItems(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	api := mocks.NewMockAPI(ctrl)
+
+	firstPage := make([]secrets.SecretItemSchema, 0, 100)
+	for i := range 99 {
+		firstPage = append(firstPage, secrets.SecretItemSchema{Name: fmt.Sprintf("Secret %02d", i)})
+	}
+
+	firstPage = append(firstPage, secrets.SecretItemSchema{Name: "GitHub"})
+
+	gomock.InOrder(
+		api.EXPECT().
+			GetSecretsPage(gomock.Any(), testToken, &secrets.PaginationRequest{Limit: 100, Offset: 0}).
+			Return(firstPage, uint64(101), nil),
+		api.EXPECT().
+			GetSecretsPage(gomock.Any(), testToken, &secrets.PaginationRequest{Limit: 100, Offset: 100}).
+			Return([]secrets.SecretItemSchema{{Name: "Bank"}}, uint64(101), nil),
+	)
+
+	var (
+		github   = adapters.ImportItem{Source: "GitHub", Payload: &secrets.PasswordSecretData{Name: "GitHub"}}
+		bank     = adapters.ImportItem{Source: "Bank", Payload: &secrets.TextSecretData{Name: "Bank"}}
+		wifi     = adapters.ImportItem{Source: "Home/Wi-Fi", Payload: &secrets.TextSecretData{Name: "Wi-Fi"}}
+		wifiCopy = adapters.ImportItem{Source: "Work/Wi-Fi", Payload: &secrets.TextSecretData{Name: "Wi-Fi"}}
+		visa     = adapters.ImportItem{Source: "Visa", Payload: &secrets.CardSecretData{Name: "Visa"}}
+	)
+
+	preview, err := adapters.PreviewItems(context.Background(), api, testToken, []adapters.ImportItem{
+		github, bank, wifi, wifiCopy, visa,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, &adapters.ImportPreview{
+		Counts:    map[string]int{"password": 1, "text": 3, "card": 1},
+		Conflicts: []adapters.ImportItem{github, bank, wifiCopy},
+	}, preview)
+}
+
+func TestPreviewItems_Empty(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	api := mocks.NewMockAPI(ctrl)
+
+	api.EXPECT().GetSecretsPage(gomock.Any(), testToken, gomock.Any()).Return(nil, uint64(0), nil)
+
+	preview, err := adapters.PreviewItems(context.Background(), api, testToken, nil)
+	require.NoError(t, err)
+	assert.Equal(t, &adapters.ImportPreview{Counts: map[string]int{}}, preview)
+}
+
+func TestPreviewItems_Fails(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	api := mocks.NewMockAPI(ctrl)
+
+	api.EXPECT().GetSecretsPage(gomock.Any(), testToken, gomock.Any()).Return(nil, uint64(0), errTest)
+
+	_, err := adapters.PreviewItems(context.Background(), api, testToken, []adapters.ImportItem{
+		{Source: "GitHub", Payload: &secrets.PasswordSecretData{Name: "GitHub"}},
+	})
+	require.ErrorIs(t, err, errTest)
+}
+
+func TestCreateItems(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	api := mocks.NewMockAPI(ctrl)
+
+	items := []adapters.ImportItem{
+		{Source: "GitHub", Payload: &secrets.PasswordSecretData{Name: "GitHub"}},
+		{Source: "Bank", Payload: &secrets.TextSecretData{Name: "Bank"}},
+		{Source: "Visa", Payload: &secrets.CardSecretData{Name: "Visa"}},
+	}
+
+	gomock.InOrder(
+		api.EXPECT().Add(gomock.Any(), testToken, items[0].Payload).Return("id", nil, nil),
+		api.EXPECT().Add(gomock.Any(), testToken, items[1].Payload).Return("", nil, errTest),
+		api.EXPECT().Add(gomock.Any(), testToken, items[2].Payload).Return("id", nil, nil),
+	)
+
+	var progress []int
+
+	skipped, err := adapters.CreateItems(context.Background(), api, testToken, items, func(done, total int) {
+		assert.Equal(t, len(items), total)
+
+		progress = append(progress, done)
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []adapters.SkippedItem{{Source: "Bank", Reason: errTest.Error()}}, skipped)
+	assert.Equal(t, []int{1, 2, 3}, progress)
+}
+
+func TestCreateItems_Unauthorized(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	api := mocks.NewMockAPI(ctrl)
+
+	items := []adapters.ImportItem{
+		{Source: "GitHub", Payload: &secrets.PasswordSecretData{Name: "GitHub"}},
+		{Source: "Bank", Payload: &secrets.TextSecretData{Name: "Bank"}},
+	}
+
+	api.EXPECT().Add(gomock.Any(), testToken, items[0].Payload).Return("", nil, adapters.ErrUnauthorized)
+
+	skipped, err := adapters.CreateItems(context.Background(), api, testToken, items, nil)
+	require.ErrorIs(t, err, adapters.ErrUnauthorized)
+	assert.Empty(t, skipped)
+}
@@ -0,0 +1,119 @@
+package adapters
+
+import (
+	"encoding/hex"
+	"path"
+
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/v1/secrets"
+	"github.com/novoseltcev/passkeeper/pkg/kdbx"
+)
+
+const keePassSource = "KeePass"
+
+// KeePassItems maps the entries of the database to the secrets, the entries of the recycle bin are skipped.
+//
+// An entry with a login and a password is a password with the URL as its URI. Any other entry is a note
+// with the password as its content, or with the notes if it has no password. The custom fields and the path
+// of the group are in the meta, every attachment is a separate file.
+func KeePassItems(db *kdbx.Database, passphrase string) ([]ImportItem, []SkippedItem) {
+	var (
+		items   []ImportItem
+		skipped []SkippedItem
+	)
+
+	var walk func(group *kdbx.Group, folder string)
+	walk = func(group *kdbx.Group, folder string) {
+		for _, entry := range group.Entries {
+			source := path.Join(folder, entry.Get(kdbx.FieldTitle))
+
+			if group.RecycleBin {
+				skipped = append(skipped, SkippedItem{Source: source, Reason: "in the recycle bin"})
+
+				continue
+			}
+
+			entryItems, reason := keePassEntry(entry, folder, passphrase)
+			items = append(items, entryItems...)
+
+			if reason != "" {
+				skipped = append(skipped, SkippedItem{Source: source, Reason: reason})
+			}
+		}
+
+		for _, subgroup := range group.Groups {
+			walk(subgroup, path.Join(folder, subgroup.Name))
+		}
+	}
+
+	walk(db.Root, "")
+
+	return items, skipped
+}
+
+// keePassEntry maps the entry and its attachments, the reason is set if the entry itself is not imported.
+func keePassEntry(entry *kdbx.Entry, folder, passphrase string) ([]ImportItem, string) {
+	var (
+		items  []ImportItem
+		reason string
+
+		title  = entry.Get(kdbx.FieldTitle)
+		source = path.Join(folder, title)
+	)
+
+	record := &loginRecord{
+		Source:   source,
+		Folder:   folder,
+		Title:    title,
+		Username: entry.Get(kdbx.FieldUserName),
+		Password: entry.Get(kdbx.FieldPassword),
+		Notes:    entry.Get(kdbx.FieldNotes),
+		URLs:     []string{entry.Get(kdbx.FieldURL)},
+		Meta:     keePassMeta(entry),
+	}
+
+	if item, ok := record.item(keePassSource, passphrase); ok {
+		items = append(items, item)
+	} else if len(entry.Attachments) == 0 {
+		reason = "no password, notes or attachments"
+	}
+
+	for _, attachment := range entry.Attachments {
+		if len(attachment.Data) == 0 {
+			reason = "empty attachment " + attachment.Name
+
+			continue
+		}
+
+		fileMeta := map[string]any{"entry": title}
+		if folder != "" {
+			fileMeta[MetaFolder] = folder
+		}
+
+		items = append(items, ImportItem{Source: path.Join(source, attachment.Name), Payload: &secrets.FileSecretData{
+			Passphrase: passphrase,
+			Name:       secretName(keePassSource, folder, attachment.Name),
+			Filename:   attachment.Name,
+			Content:    hex.EncodeToString(attachment.Data),
+			Meta:       fileMeta,
+		}})
+	}
+
+	return items, reason
+}
+
+// keePassMeta returns the custom fields of the entry.
+func keePassMeta(entry *kdbx.Entry) map[string]any {
+	meta := make(map[string]any)
+
+	for key, value := range entry.Fields {
+		switch key {
+		case kdbx.FieldTitle, kdbx.FieldUserName, kdbx.FieldPassword, kdbx.FieldURL, kdbx.FieldNotes:
+		default:
+			if value != "" {
+				meta[key] = value
+			}
+		}
+	}
+
+	return meta
+}
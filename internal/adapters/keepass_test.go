@@ -0,0 +1,145 @@
+package adapters_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/novoseltcev/passkeeper/internal/adapters"
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/v1/secrets"
+	"github.com/novoseltcev/passkeeper/pkg/kdbx"
+)
+
+const testPassphrase = "passphrase"
+
+func TestKeePassItems(t *testing.T) {
+	t.Parallel()
+
+	db := &kdbx.Database{
+		Name: "Test",
+		Root: &kdbx.Group{
+			Name: "Root",
+			Entries: []*kdbx.Entry{
+				{
+					Fields: map[string]string{
+						kdbx.FieldTitle:    "GitHub",
+						kdbx.FieldUserName: "john",
+						kdbx.FieldPassword: "p@ssw0rd",
+						kdbx.FieldURL:      "https://github.com",
+						kdbx.FieldNotes:    "work",
+						"PIN":              "1234",
+						"Empty":            "",
+					},
+					Attachments: []kdbx.Attachment{{Name: "codes.txt", Data: []byte("codes")}},
+				},
+				{Fields: map[string]string{kdbx.FieldTitle: "Empty"}},
+			},
+			Groups: []*kdbx.Group{
+				{
+					Name: "Email",
+					Entries: []*kdbx.Entry{
+						{Fields: map[string]string{kdbx.FieldTitle: "Mail", kdbx.FieldNotes: "note"}},
+						{Fields: map[string]string{kdbx.FieldTitle: "Wi-Fi", kdbx.FieldPassword: "secret"}},
+						{
+							Fields:      map[string]string{kdbx.FieldTitle: "Scans"},
+							Attachments: []kdbx.Attachment{{Name: "blank.pdf"}, {Name: "id.pdf", Data: []byte("id")}},
+						},
+					},
+				},
+				{
+					Name:       "Recycle Bin",
+					RecycleBin: true,
+					Entries:    []*kdbx.Entry{{Fields: map[string]string{kdbx.FieldTitle: "Old", kdbx.FieldPassword: "old"}}},
+				},
+			},
+		},
+	}
+
+	items, skipped := adapters.KeePassItems(db, testPassphrase)
+
+	assert.Equal(t, []adapters.ImportItem{
+		{Source: "GitHub", Payload: &secrets.PasswordSecretData{
+			Passphrase: testPassphrase,
+			Name:       "GitHub",
+			Login:      "john",
+			Password:   "p@ssw0rd",
+			URIs:       []secrets.URIData{{URI: "https://github.com"}},
+			Meta:       map[string]any{"PIN": "1234", "notes": "work"},
+		}},
+		{Source: "GitHub/codes.txt", Payload: &secrets.FileSecretData{
+			Passphrase: testPassphrase,
+			Name:       "codes.txt",
+			Filename:   "codes.txt",
+			Content:    "636f646573",
+			Meta:       map[string]any{"entry": "GitHub"},
+		}},
+		{Source: "Email/Mail", Payload: &secrets.TextSecretData{
+			Passphrase: testPassphrase,
+			Name:       "Mail",
+			Content:    "note",
+			Meta:       map[string]any{adapters.MetaFolder: "Email"},
+		}},
+		{Source: "Email/Wi-Fi", Payload: &secrets.TextSecretData{
+			Passphrase: testPassphrase,
+			Name:       "Wi-Fi",
+			Content:    "secret",
+			Meta:       map[string]any{adapters.MetaFolder: "Email"},
+		}},
+		{Source: "Email/Scans/id.pdf", Payload: &secrets.FileSecretData{
+			Passphrase: testPassphrase,
+			Name:       "id.pdf",
+			Filename:   "id.pdf",
+			Content:    "6964",
+			Meta:       map[string]any{"entry": "Scans", adapters.MetaFolder: "Email"},
+		}},
+	}, items)
+
+	assert.Equal(t, []adapters.SkippedItem{
+		{Source: "Empty", Reason: "no password, notes or attachments"},
+		{Source: "Email/Scans", Reason: "empty attachment blank.pdf"},
+		{Source: "Recycle Bin/Old", Reason: "in the recycle bin"},
+	}, skipped)
+}
+
+func TestKeePassItems_Names(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		folder string
+		title  string
+		want   string
+	}{
+		{name: "fits", title: "GitHub", want: "GitHub"},
+		{name: "untitled", title: "  ", want: "Untitled"},
+		{name: "short in folder", folder: "Email", title: "a", want: "Email/a"},
+		{name: "short without folder", title: "a", want: "KeePass/a"},
+		{name: "short in short folder", folder: "a", title: "b", want: "KeePass/a/b"},
+		{name: "long", title: "A very long title of the entry, which is cut", want: "A very long title of the entry,"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			root := &kdbx.Group{}
+			entry := &kdbx.Entry{Fields: map[string]string{kdbx.FieldTitle: tt.title, kdbx.FieldNotes: "note"}}
+
+			if tt.folder == "" {
+				root.Entries = []*kdbx.Entry{entry}
+			} else {
+				root.Groups = []*kdbx.Group{{Name: tt.folder, Entries: []*kdbx.Entry{entry}}}
+			}
+
+			items, skipped := adapters.KeePassItems(&kdbx.Database{Root: root}, testPassphrase)
+			require.Empty(t, skipped)
+			require.Len(t, items, 1)
+
+			_, name := items[0].Payload.Credentials()
+			assert.Equal(t, tt.want, name)
+			assert.GreaterOrEqual(t, len([]rune(name)), 4)
+			assert.LessOrEqual(t, len([]rune(name)), 32)
+		})
+	}
+}
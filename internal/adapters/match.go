@@ -0,0 +1,44 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/v1/secrets"
+	domain "github.com/novoseltcev/passkeeper/internal/domains/secrets"
+)
+
+// FindByURL returns the decrypted passwords, whose URIs match the URL.
+//
+// The server finds the candidates by the index of the URIs, they are decrypted and matched exactly here.
+func FindByURL(ctx context.Context, api API, token, passphrase, rawURL string) ([]*secrets.SecretSchema, error) {
+	candidates, err := api.MatchSecrets(ctx, token, &secrets.MatchRequest{URL: rawURL})
+	if err != nil {
+		return nil, err
+	}
+
+	var found []*secrets.SecretSchema
+
+	for _, candidate := range candidates {
+		secret, err := api.DecryptSecret(ctx, token, candidate.ID, &secrets.DecryptByIDData{Passphrase: passphrase})
+		if err != nil {
+			return nil, err
+		}
+
+		raw, err := json.Marshal(secret.Data)
+		if err != nil {
+			return nil, err
+		}
+
+		var data domain.PasswordData
+		if err := json.Unmarshal(raw, &data); err != nil {
+			return nil, err
+		}
+
+		if data.MatchURL(rawURL) {
+			found = append(found, secret)
+		}
+	}
+
+	return found, nil
+}
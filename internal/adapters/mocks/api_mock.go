@@ -0,0 +1,1835 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: api.go
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mocks/api_mock.go -package=mocks -source=api.go -typed
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	response "github.com/novoseltcev/passkeeper/internal/controllers/http/common/response"
+	emergency "github.com/novoseltcev/passkeeper/internal/controllers/http/v1/emergency"
+	generate "github.com/novoseltcev/passkeeper/internal/controllers/http/v1/generate"
+	orgs "github.com/novoseltcev/passkeeper/internal/controllers/http/v1/orgs"
+	secrets "github.com/novoseltcev/passkeeper/internal/controllers/http/v1/secrets"
+	sends "github.com/novoseltcev/passkeeper/internal/controllers/http/v1/sends"
+	templates "github.com/novoseltcev/passkeeper/internal/controllers/http/v1/templates"
+	user "github.com/novoseltcev/passkeeper/internal/controllers/http/v1/user"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAPI is a mock of API interface.
+type MockAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIMockRecorder
+	isgomock struct{}
+}
+
+// MockAPIMockRecorder is the mock recorder for MockAPI.
+type MockAPIMockRecorder struct {
+	mock *MockAPI
+}
+
+// NewMockAPI creates a new mock instance.
+func NewMockAPI(ctrl *gomock.Controller) *MockAPI {
+	mock := &MockAPI{ctrl: ctrl}
+	mock.recorder = &MockAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPI) EXPECT() *MockAPIMockRecorder {
+	return m.recorder
+}
+
+// AcceptInvitation mocks base method.
+func (m *MockAPI) AcceptInvitation(ctx context.Context, token, uuid string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptInvitation", ctx, token, uuid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AcceptInvitation indicates an expected call of AcceptInvitation.
+func (mr *MockAPIMockRecorder) AcceptInvitation(ctx, token, uuid any) *MockAPIAcceptInvitationCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptInvitation", reflect.TypeOf((*MockAPI)(nil).AcceptInvitation), ctx, token, uuid)
+	return &MockAPIAcceptInvitationCall{Call: call}
+}
+
+// MockAPIAcceptInvitationCall wrap *gomock.Call
+type MockAPIAcceptInvitationCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAPIAcceptInvitationCall) Return(arg0 error) *MockAPIAcceptInvitationCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAPIAcceptInvitationCall) Do(f func(context.Context, string, string) error) *MockAPIAcceptInvitationCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAPIAcceptInvitationCall) DoAndReturn(f func(context.Context, string, string) error) *MockAPIAcceptInvitationCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Add mocks base method.
+func (m *MockAPI) Add(ctx context.Context, token string, data secrets.Payload) (string, []response.Warning, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, token, data)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].([]response.Warning)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockAPIMockRecorder) Add(ctx, token, data any) *MockAPIAddCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockAPI)(nil).Add), ctx, token, data)
+	return &MockAPIAddCall{Call: call}
+}
+
+// MockAPIAddCall wrap *gomock.Call
+type MockAPIAddCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAPIAddCall) Return(arg0 string, arg1 []response.Warning, arg2 error) *MockAPIAddCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAPIAddCall) Do(f func(context.Context, string, secrets.Payload) (string, []response.Warning, error)) *MockAPIAddCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAPIAddCall) DoAndReturn(f func(context.Context, string, secrets.Payload) (string, []response.Warning, error)) *MockAPIAddCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ApproveEmergencyAccess mocks base method.
+func (m *MockAPI) ApproveEmergencyAccess(ctx context.Context, token, uuid string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApproveEmergencyAccess", ctx, token, uuid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApproveEmergencyAccess indicates an expected call of ApproveEmergencyAccess.
+func (mr *MockAPIMockRecorder) ApproveEmergencyAccess(ctx, token, uuid any) *MockAPIApproveEmergencyAccessCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveEmergencyAccess", reflect.TypeOf((*MockAPI)(nil).ApproveEmergencyAccess), ctx, token, uuid)
+	return &MockAPIApproveEmergencyAccessCall{Call: call}
+}
+
+// MockAPIApproveEmergencyAccessCall wrap *gomock.Call
+type MockAPIApproveEmergencyAccessCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAPIApproveEmergencyAccessCall) Return(arg0 error) *MockAPIApproveEmergencyAccessCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAPIApproveEmergencyAccessCall) Do(f func(context.Context, string, string) error) *MockAPIApproveEmergencyAccessCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAPIApproveEmergencyAccessCall) DoAndReturn(f func(context.Context, string, string) error) *MockAPIApproveEmergencyAccessCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Batch mocks base method.
+func (m *MockAPI) Batch(ctx context.Context, token string, data *secrets.BatchData) ([]secrets.BatchResultSchema, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Batch", ctx, token, data)
+	ret0, _ := ret[0].([]secrets.BatchResultSchema)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Batch indicates an expected call of Batch.
+func (mr *MockAPIMockRecorder) Batch(ctx, token, data any) *MockAPIBatchCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Batch", reflect.TypeOf((*MockAPI)(nil).Batch), ctx, token, data)
+	return &MockAPIBatchCall{Call: call}
+}
+
+// MockAPIBatchCall wrap *gomock.Call
+type MockAPIBatchCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAPIBatchCall) Return(arg0 []secrets.BatchResultSchema, arg1 error) *MockAPIBatchCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAPIBatchCall) Do(f func(context.Context, string, *secrets.BatchData) ([]secrets.BatchResultSchema, error)) *MockAPIBatchCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAPIBatchCall) DoAndReturn(f func(context.Context, string, *secrets.BatchData) ([]secrets.BatchResultSchema, error)) *MockAPIBatchCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CreateOrg mocks base method.
+func (m *MockAPI) CreateOrg(ctx context.Context, token string, data *orgs.CreateOrgData) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrg", ctx, token, data)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrg indicates an expected call of CreateOrg.
+func (mr *MockAPIMockRecorder) CreateOrg(ctx, token, data any) *MockAPICreateOrgCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrg", reflect.TypeOf((*MockAPI)(nil).CreateOrg), ctx, token, data)
+	return &MockAPICreateOrgCall{Call: call}
+}
+
+// MockAPICreateOrgCall wrap *gomock.Call
+type MockAPICreateOrgCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAPICreateOrgCall) Return(arg0 string, arg1 error) *MockAPICreateOrgCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAPICreateOrgCall) Do(f func(context.Context, string, *orgs.CreateOrgData) (string, error)) *MockAPICreateOrgCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAPICreateOrgCall) DoAndReturn(f func(context.Context, string, *orgs.CreateOrgData) (string, error)) *MockAPICreateOrgCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CreateSend mocks base method.
+func (m *MockAPI) CreateSend(ctx context.Context, token string, data *sends.CreateSendData) (*sends.SendSchema, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSend", ctx, token, data)
+	ret0, _ := ret[0].(*sends.SendSchema)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSend indicates an expected call of CreateSend.
+func (mr *MockAPIMockRecorder) CreateSend(ctx, token, data any) *MockAPICreateSendCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSend", reflect.TypeOf((*MockAPI)(nil).CreateSend), ctx, token, data)
+	return &MockAPICreateSendCall{Call: call}
+}
+
+// MockAPICreateSendCall wrap *gomock.Call
+type MockAPICreateSendCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAPICreateSendCall) Return(arg0 *sends.SendSchema, arg1 error) *MockAPICreateSendCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAPICreateSendCall) Do(f func(context.Context, string, *sends.CreateSendData) (*sends.SendSchema, error)) *MockAPICreateSendCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAPICreateSendCall) DoAndReturn(f func(context.Context, string, *sends.CreateSendData) (*sends.SendSchema, error)) *MockAPICreateSendCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CreateTemplate mocks base method.
+func (m *MockAPI) CreateTemplate(ctx context.Context, token string, data *templates.CreateTemplateData) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTemplate", ctx, token, data)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTemplate indicates an expected call of CreateTemplate.
+func (mr *MockAPIMockRecorder) CreateTemplate(ctx, token, data any) *MockAPICreateTemplateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTemplate", reflect.TypeOf((*MockAPI)(nil).CreateTemplate), ctx, token, data)
+	return &MockAPICreateTemplateCall{Call: call}
+}
+
+// MockAPICreateTemplateCall wrap *gomock.Call
+type MockAPICreateTemplateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAPICreateTemplateCall) Return(arg0 string, arg1 error) *MockAPICreateTemplateCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAPICreateTemplateCall) Do(f func(context.Context, string, *templates.CreateTemplateData) (string, error)) *MockAPICreateTemplateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAPICreateTemplateCall) DoAndReturn(f func(context.Context, string, *templates.CreateTemplateData) (string, error)) *MockAPICreateTemplateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeclineInvitation mocks base method.
+func (m *MockAPI) DeclineInvitation(ctx context.Context, token, uuid string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeclineInvitation", ctx, token, uuid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeclineInvitation indicates an expected call of DeclineInvitation.
+func (mr *MockAPIMockRecorder) DeclineInvitation(ctx, token, uuid any) *MockAPIDeclineInvitationCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeclineInvitation", reflect.TypeOf((*MockAPI)(nil).DeclineInvitation), ctx, token, uuid)
+	return &MockAPIDeclineInvitationCall{Call: call}
+}
+
+// MockAPIDeclineInvitationCall wrap *gomock.Call
+type MockAPIDeclineInvitationCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAPIDeclineInvitationCall) Return(arg0 error) *MockAPIDeclineInvitationCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAPIDeclineInvitationCall) Do(f func(context.Context, string, string) error) *MockAPIDeclineInvitationCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAPIDeclineInvitationCall) DoAndReturn(f func(context.Context, string, string) error) *MockAPIDeclineInvitationCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DecryptEmergencySecret mocks base method.
+func (m *MockAPI) DecryptEmergencySecret(ctx context.Context, token, uuid, secretID string, data *emergency.DecryptData) (*emergency.SecretSchema, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DecryptEmergencySecret", ctx, token, uuid, secretID, data)
+	ret0, _ := ret[0].(*emergency.SecretSchema)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DecryptEmergencySecret indicates an expected call of DecryptEmergencySecret.
+func (mr *MockAPIMockRecorder) DecryptEmergencySecret(ctx, token, uuid, secretID, data any) *MockAPIDecryptEmergencySecretCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecryptEmergencySecret", reflect.TypeOf((*MockAPI)(nil).DecryptEmergencySecret), ctx, token, uuid, secretID, data)
+	return &MockAPIDecryptEmergencySecretCall{Call: call}
+}
+
+// MockAPIDecryptEmergencySecretCall wrap *gomock.Call
+type MockAPIDecryptEmergencySecretCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAPIDecryptEmergencySecretCall) Return(arg0 *emergency.SecretSchema, arg1 error) *MockAPIDecryptEmergencySecretCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAPIDecryptEmergencySecretCall) Do(f func(context.Context, string, string, string, *emergency.DecryptData) (*emergency.SecretSchema, error)) *MockAPIDecryptEmergencySecretCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAPIDecryptEmergencySecretCall) DoAndReturn(f func(context.Context, string, string, string, *emergency.DecryptData) (*emergency.SecretSchema, error)) *MockAPIDecryptEmergencySecretCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DecryptSecret mocks base method.
+func (m *MockAPI) DecryptSecret(ctx context.Context, token, uuid string, data *secrets.DecryptByIDData) (*secrets.SecretSchema, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DecryptSecret", ctx, token, uuid, data)
+	ret0, _ := ret[0].(*secrets.SecretSchema)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DecryptSecret indicates an expected call of DecryptSecret.
+func (mr *MockAPIMockRecorder) DecryptSecret(ctx, token, uuid, data any) *MockAPIDecryptSecretCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecryptSecret", reflect.TypeOf((*MockAPI)(nil).DecryptSecret), ctx, token, uuid, data)
+	return &MockAPIDecryptSecretCall{Call: call}
+}
+
+// MockAPIDecryptSecretCall wrap *gomock.Call
+type MockAPIDecryptSecretCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAPIDecryptSecretCall) Return(arg0 *secrets.SecretSchema, arg1 error) *MockAPIDecryptSecretCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAPIDecryptSecretCall) Do(f func(context.Context, string, string, *secrets.DecryptByIDData) (*secrets.SecretSchema, error)) *MockAPIDecryptSecretCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAPIDecryptSecretCall) DoAndReturn(f func(context.Context, string, string, *secrets.DecryptByIDData) (*secrets.SecretSchema, error)) *MockAPIDecryptSecretCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteSecret mocks base method.
+func (m *MockAPI) DeleteSecret(ctx context.Context, token, uuid string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSecret", ctx, token, uuid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSecret indicates an expected call of DeleteSecret.
+func (mr *MockAPIMockRecorder) DeleteSecret(ctx, token, uuid any) *MockAPIDeleteSecretCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSecret", reflect.TypeOf((*MockAPI)(nil).DeleteSecret), ctx, token, uuid)
+	return &MockAPIDeleteSecretCall{Call: call}
+}
+
+// MockAPIDeleteSecretCall wrap *gomock.Call
+type MockAPIDeleteSecretCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAPIDeleteSecretCall) Return(arg0 error) *MockAPIDeleteSecretCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAPIDeleteSecretCall) Do(f func(context.Context, string, string) error) *MockAPIDeleteSecretCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAPIDeleteSecretCall) DoAndReturn(f func(context.Context, string, string) error) *MockAPIDeleteSecretCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteTemplate mocks base method.
+func (m *MockAPI) DeleteTemplate(ctx context.Context, token, uuid string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTemplate", ctx, token, uuid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTemplate indicates an expected call of DeleteTemplate.
+func (mr *MockAPIMockRecorder) DeleteTemplate(ctx, token, uuid any) *MockAPIDeleteTemplateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTemplate", reflect.TypeOf((*MockAPI)(nil).DeleteTemplate), ctx, token, uuid)
+	return &MockAPIDeleteTemplateCall{Call: call}
+}
+
+// MockAPIDeleteTemplateCall wrap *gomock.Call
+type MockAPIDeleteTemplateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAPIDeleteTemplateCall) Return(arg0 error) *MockAPIDeleteTemplateCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAPIDeleteTemplateCall) Do(f func(context.Context, string, string) error) *MockAPIDeleteTemplateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAPIDeleteTemplateCall) DoAndReturn(f func(context.Context, string, string) error) *MockAPIDeleteTemplateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DownloadFile mocks base method.
+func (m *MockAPI) DownloadFile(ctx context.Context, token, uuid string, data *secrets.DecryptByIDData, path string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DownloadFile", ctx, token, uuid, data, path)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DownloadFile indicates an expected call of DownloadFile.
+func (mr *MockAPIMockRecorder) DownloadFile(ctx, token, uuid, data, path any) *MockAPIDownloadFileCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadFile", reflect.TypeOf((*MockAPI)(nil).DownloadFile), ctx, token, uuid, data, path)
+	return &MockAPIDownloadFileCall{Call: call}
+}
+
+// MockAPIDownloadFileCall wrap *gomock.Call
+type MockAPIDownloadFileCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAPIDownloadFileCall) Return(arg0 string, arg1 error) *MockAPIDownloadFileCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAPIDownloadFileCall) Do(f func(context.Context, string, string, *secrets.DecryptByIDData, string) (string, error)) *MockAPIDownloadFileCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAPIDownloadFileCall) DoAndReturn(f func(context.Context, string, string, *secrets.DecryptByIDData, string) (string, error)) *MockAPIDownloadFileCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ExportVault mocks base method.
+func (m *MockAPI) ExportVault(ctx context.Context, token string, data *secrets.ExportData) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportVault", ctx, token, data)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportVault indicates an expected call of ExportVault.
+func (mr *MockAPIMockRecorder) ExportVault(ctx, token, data any) *MockAPIExportVaultCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportVault", reflect.TypeOf((*MockAPI)(nil).ExportVault), ctx, token, data)
+	return &MockAPIExportVaultCall{Call: call}
+}
+
+// MockAPIExportVaultCall wrap *gomock.Call
+type MockAPIExportVaultCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAPIExportVaultCall) Return(arg0 []byte, arg1 error) *MockAPIExportVaultCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAPIExportVaultCall) Do(f func(context.Context, string, *secrets.ExportData) ([]byte, error)) *MockAPIExportVaultCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAPIExportVaultCall) DoAndReturn(f func(context.Context, string, *secrets.ExportData) ([]byte, error)) *MockAPIExportVaultCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GenerateOTP mocks base method.
+func (m *MockAPI) GenerateOTP(ctx context.Context, token, uuid string, data *secrets.DecryptByIDData) (*secrets.OTPSchema, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateOTP", ctx, token, uuid, data)
+	ret0, _ := ret[0].(*secrets.OTPSchema)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateOTP indicates an expected call of GenerateOTP.
+func (mr *MockAPIMockRecorder) GenerateOTP(ctx, token, uuid, data any) *MockAPIGenerateOTPCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateOTP", reflect.TypeOf((*MockAPI)(nil).GenerateOTP), ctx, token, uuid, data)
+	return &MockAPIGenerateOTPCall{Call: call}
+}
+
+// MockAPIGenerateOTPCall wrap *gomock.Call
+type MockAPIGenerateOTPCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAPIGenerateOTPCall) Return(arg0 *secrets.OTPSchema, arg1 error) *MockAPIGenerateOTPCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAPIGenerateOTPCall) Do(f func(context.Context, string, string, *secrets.DecryptByIDData) (*secrets.OTPSchema, error)) *MockAPIGenerateOTPCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAPIGenerateOTPCall) DoAndReturn(f func(context.Context, string, string, *secrets.DecryptByIDData) (*secrets.OTPSchema, error)) *MockAPIGenerateOTPCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GeneratePassword mocks base method.
+func (m *MockAPI) GeneratePassword(ctx context.Context, token string, data *generate.GeneratePasswordData) (*generate.PasswordSchema, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GeneratePassword", ctx, token, data)
+	ret0, _ := ret[0].(*generate.PasswordSchema)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GeneratePassword indicates an expected call of GeneratePassword.
+func (mr *MockAPIMockRecorder) GeneratePassword(ctx, token, data any) *MockAPIGeneratePasswordCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GeneratePassword", reflect.TypeOf((*MockAPI)(nil).GeneratePassword), ctx, token, data)
+	return &MockAPIGeneratePasswordCall{Call: call}
+}
+
+// MockAPIGeneratePasswordCall wrap *gomock.Call
+type MockAPIGeneratePasswordCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAPIGeneratePasswordCall) Return(arg0 *generate.PasswordSchema, arg1 error) *MockAPIGeneratePasswordCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAPIGeneratePasswordCall) Do(f func(context.Context, string, *generate.GeneratePasswordData) (*generate.PasswordSchema, error)) *MockAPIGeneratePasswordCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAPIGeneratePasswordCall) DoAndReturn(f func(context.Context, string, *generate.GeneratePasswordData) (*generate.PasswordSchema, error)) *MockAPIGeneratePasswordCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GenerateSSHKey mocks base method.
+func (m *MockAPI) GenerateSSHKey(ctx context.Context, token string, data *secrets.GenerateSSHKeyData) (*secrets.GeneratedSSHKeySchema, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateSSHKey", ctx, token, data)
+	ret0, _ := ret[0].(*secrets.GeneratedSSHKeySchema)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateSSHKey indicates an expected call of GenerateSSHKey.
+func (mr *MockAPIMockRecorder) GenerateSSHKey(ctx, token, data any) *MockAPIGenerateSSHKeyCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateSSHKey", reflect.TypeOf((*MockAPI)(nil).GenerateSSHKey), ctx, token, data)
+	return &MockAPIGenerateSSHKeyCall{Call: call}
+}
+
+// MockAPIGenerateSSHKeyCall wrap *gomock.Call
+type MockAPIGenerateSSHKeyCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAPIGenerateSSHKeyCall) Return(arg0 *secrets.GeneratedSSHKeySchema, arg1 error) *MockAPIGenerateSSHKeyCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAPIGenerateSSHKeyCall) Do(f func(context.Context, string, *secrets.GenerateSSHKeyData) (*secrets.GeneratedSSHKeySchema, error)) *MockAPIGenerateSSHKeyCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAPIGenerateSSHKeyCall) DoAndReturn(f func(context.Context, string, *secrets.GenerateSSHKeyData) (*secrets.GeneratedSSHKeySchema, error)) *MockAPIGenerateSSHKeyCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetEmergencyContacts mocks base method.
+func (m *MockAPI) GetEmergencyContacts(ctx context.Context, token string) ([]emergency.AccessSchema, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEmergencyContacts", ctx, token)
+	ret0, _ := ret[0].([]emergency.AccessSchema)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEmergencyContacts indicates an expected call of GetEmergencyContacts.
+func (mr *MockAPIMockRecorder) GetEmergencyContacts(ctx, token any) *MockAPIGetEmergencyContactsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmergencyContacts", reflect.TypeOf((*MockAPI)(nil).GetEmergencyContacts), ctx, token)
+	return &MockAPIGetEmergencyContactsCall{Call: call}
+}
+
+// MockAPIGetEmergencyContactsCall wrap *gomock.Call
+type MockAPIGetEmergencyContactsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAPIGetEmergencyContactsCall) Return(arg0 []emergency.AccessSchema, arg1 error) *MockAPIGetEmergencyContactsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAPIGetEmergencyContactsCall) Do(f func(context.Context, string) ([]emergency.AccessSchema, error)) *MockAPIGetEmergencyContactsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAPIGetEmergencyContactsCall) DoAndReturn(f func(context.Context, string) ([]emergency.AccessSchema, error)) *MockAPIGetEmergencyContactsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetEmergencyGrantors mocks base method.
+func (m *MockAPI) GetEmergencyGrantors(ctx context.Context, token string) ([]emergency.AccessSchema, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEmergencyGrantors", ctx, token)
+	ret0, _ := ret[0].([]emergency.AccessSchema)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEmergencyGrantors indicates an expected call of GetEmergencyGrantors.
+func (mr *MockAPIMockRecorder) GetEmergencyGrantors(ctx, token any) *MockAPIGetEmergencyGrantorsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmergencyGrantors", reflect.TypeOf((*MockAPI)(nil).GetEmergencyGrantors), ctx, token)
+	return &MockAPIGetEmergencyGrantorsCall{Call: call}
+}
+
+// MockAPIGetEmergencyGrantorsCall wrap *gomock.Call
+type MockAPIGetEmergencyGrantorsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAPIGetEmergencyGrantorsCall) Return(arg0 []emergency.AccessSchema, arg1 error) *MockAPIGetEmergencyGrantorsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAPIGetEmergencyGrantorsCall) Do(f func(context.Context, string) ([]emergency.AccessSchema, error)) *MockAPIGetEmergencyGrantorsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAPIGetEmergencyGrantorsCall) DoAndReturn(f func(context.Context, string) ([]emergency.AccessSchema, error)) *MockAPIGetEmergencyGrantorsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetEmergencyVault mocks base method.
+func (m *MockAPI) GetEmergencyVault(ctx context.Context, token, uuid string, params *emergency.PaginationRequest) ([]emergency.SecretItemSchema, uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEmergencyVault", ctx, token, uuid, params)
+	ret0, _ := ret[0].([]emergency.SecretItemSchema)
+	ret1, _ := ret[1].(uint64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetEmergencyVault indicates an expected call of GetEmergencyVault.
+func (mr *MockAPIMockRecorder) GetEmergencyVault(ctx, token, uuid, params any) *MockAPIGetEmergencyVaultCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmergencyVault", reflect.TypeOf((*MockAPI)(nil).GetEmergencyVault), ctx, token, uuid, params)
+	return &MockAPIGetEmergencyVaultCall{Call: call}
+}
+
+// MockAPIGetEmergencyVaultCall wrap *gomock.Call
+type MockAPIGetEmergencyVaultCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAPIGetEmergencyVaultCall) Return(arg0 []emergency.SecretItemSchema, arg1 uint64, arg2 error) *MockAPIGetEmergencyVaultCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAPIGetEmergencyVaultCall) Do(f func(context.Context, string, string, *emergency.PaginationRequest) ([]emergency.SecretItemSchema, uint64, error)) *MockAPIGetEmergencyVaultCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAPIGetEmergencyVaultCall) DoAndReturn(f func(context.Context, string, string, *emergency.PaginationRequest) ([]emergency.SecretItemSchema, uint64, error)) *MockAPIGetEmergencyVaultCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetInvitations mocks base method.
+func (m *MockAPI) GetInvitations(ctx context.Context, token string) ([]orgs.InvitationSchema, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvitations", ctx, token)
+	ret0, _ := ret[0].([]orgs.InvitationSchema)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvitations indicates an expected call of GetInvitations.
+func (mr *MockAPIMockRecorder) GetInvitations(ctx, token any) *MockAPIGetInvitationsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvitations", reflect.TypeOf((*MockAPI)(nil).GetInvitations), ctx, token)
+	return &MockAPIGetInvitationsCall{Call: call}
+}
+
+// MockAPIGetInvitationsCall wrap *gomock.Call
+type MockAPIGetInvitationsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAPIGetInvitationsCall) Return(arg0 []orgs.InvitationSchema, arg1 error) *MockAPIGetInvitationsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAPIGetInvitationsCall) Do(f func(context.Context, string) ([]orgs.InvitationSchema, error)) *MockAPIGetInvitationsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAPIGetInvitationsCall) DoAndReturn(f func(context.Context, string) ([]orgs.InvitationSchema, error)) *MockAPIGetInvitationsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetOrgMembers mocks base method.
+func (m *MockAPI) GetOrgMembers(ctx context.Context, token, uuid string) ([]orgs.MemberSchema, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrgMembers", ctx, token, uuid)
+	ret0, _ := ret[0].([]orgs.MemberSchema)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrgMembers indicates an expected call of GetOrgMembers.
+func (mr *MockAPIMockRecorder) GetOrgMembers(ctx, token, uuid any) *MockAPIGetOrgMembersCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrgMembers", reflect.TypeOf((*MockAPI)(nil).GetOrgMembers), ctx, token, uuid)
+	return &MockAPIGetOrgMembersCall{Call: call}
+}
+
+// MockAPIGetOrgMembersCall wrap *gomock.Call
+type MockAPIGetOrgMembersCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAPIGetOrgMembersCall) Return(arg0 []orgs.MemberSchema, arg1 error) *MockAPIGetOrgMembersCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAPIGetOrgMembersCall) Do(f func(context.Context, string, string) ([]orgs.MemberSchema, error)) *MockAPIGetOrgMembersCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAPIGetOrgMembersCall) DoAndReturn(f func(context.Context, string, string) ([]orgs.MemberSchema, error)) *MockAPIGetOrgMembersCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetOrgs mocks base method.
+func (m *MockAPI) GetOrgs(ctx context.Context, token string) ([]orgs.OrgSchema, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrgs", ctx, token)
+	ret0, _ := ret[0].([]orgs.OrgSchema)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrgs indicates an expected call of GetOrgs.
+func (mr *MockAPIMockRecorder) GetOrgs(ctx, token any) *MockAPIGetOrgsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrgs", reflect.TypeOf((*MockAPI)(nil).GetOrgs), ctx, token)
+	return &MockAPIGetOrgsCall{Call: call}
+}
+
+// MockAPIGetOrgsCall wrap *gomock.Call
+type MockAPIGetOrgsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAPIGetOrgsCall) Return(arg0 []orgs.OrgSchema, arg1 error) *MockAPIGetOrgsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAPIGetOrgsCall) Do(f func(context.Context, string) ([]orgs.OrgSchema, error)) *MockAPIGetOrgsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAPIGetOrgsCall) DoAndReturn(f func(context.Context, string) ([]orgs.OrgSchema, error)) *MockAPIGetOrgsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetSecretsPage mocks base method.
+func (m *MockAPI) GetSecretsPage(ctx context.Context, token string, params *secrets.PaginationRequest) ([]secrets.SecretItemSchema, uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecretsPage", ctx, token, params)
+	ret0, _ := ret[0].([]secrets.SecretItemSchema)
+	ret1, _ := ret[1].(uint64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetSecretsPage indicates an expected call of GetSecretsPage.
+func (mr *MockAPIMockRecorder) GetSecretsPage(ctx, token, params any) *MockAPIGetSecretsPageCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecretsPage", reflect.TypeOf((*MockAPI)(nil).GetSecretsPage), ctx, token, params)
+	return &MockAPIGetSecretsPageCall{Call: call}
+}
+
+// MockAPIGetSecretsPageCall wrap *gomock.Call
+type MockAPIGetSecretsPageCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAPIGetSecretsPageCall) Return(arg0 []secrets.SecretItemSchema, arg1 uint64, arg2 error) *MockAPIGetSecretsPageCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAPIGetSecretsPageCall) Do(f func(context.Context, string, *secrets.PaginationRequest) ([]secrets.SecretItemSchema, uint64, error)) *MockAPIGetSecretsPageCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAPIGetSecretsPageCall) DoAndReturn(f func(context.Context, string, *secrets.PaginationRequest) ([]secrets.SecretItemSchema, uint64, error)) *MockAPIGetSecretsPageCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetSend mocks base method.
+func (m *MockAPI) GetSend(ctx context.Context, address, password string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSend", ctx, address, password)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSend indicates an expected call of GetSend.
+func (mr *MockAPIMockRecorder) GetSend(ctx, address, password any) *MockAPIGetSendCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSend", reflect.TypeOf((*MockAPI)(nil).GetSend), ctx, address, password)
+	return &MockAPIGetSendCall{Call: call}
+}
+
+// MockAPIGetSendCall wrap *gomock.Call
+type MockAPIGetSendCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAPIGetSendCall) Return(arg0 []byte, arg1 error) *MockAPIGetSendCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAPIGetSendCall) Do(f func(context.Context, string, string) ([]byte, error)) *MockAPIGetSendCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAPIGetSendCall) DoAndReturn(f func(context.Context, string, string) ([]byte, error)) *MockAPIGetSendCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetSharedWithMe mocks base method.
+func (m *MockAPI) GetSharedWithMe(ctx context.Context, token string) ([]secrets.SharedSecretSchema, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSharedWithMe", ctx, token)
+	ret0, _ := ret[0].([]secrets.SharedSecretSchema)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSharedWithMe indicates an expected call of GetSharedWithMe.
+func (mr *MockAPIMockRecorder) GetSharedWithMe(ctx, token any) *MockAPIGetSharedWithMeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSharedWithMe", reflect.TypeOf((*MockAPI)(nil).GetSharedWithMe), ctx, token)
+	return &MockAPIGetSharedWithMeCall{Call: call}
+}
+
+// MockAPIGetSharedWithMeCall wrap *gomock.Call
+type MockAPIGetSharedWithMeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAPIGetSharedWithMeCall) Return(arg0 []secrets.SharedSecretSchema, arg1 error) *MockAPIGetSharedWithMeCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAPIGetSharedWithMeCall) Do(f func(context.Context, string) ([]secrets.SharedSecretSchema, error)) *MockAPIGetSharedWithMeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAPIGetSharedWithMeCall) DoAndReturn(f func(context.Context, string) ([]secrets.SharedSecretSchema, error)) *MockAPIGetSharedWithMeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetShares mocks base method.
+func (m *MockAPI) GetShares(ctx context.Context, token, uuid string) ([]secrets.ShareSchema, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShares", ctx, token, uuid)
+	ret0, _ := ret[0].([]secrets.ShareSchema)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShares indicates an expected call of GetShares.
+func (mr *MockAPIMockRecorder) GetShares(ctx, token, uuid any) *MockAPIGetSharesCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShares", reflect.TypeOf((*MockAPI)(nil).GetShares), ctx, token, uuid)
+	return &MockAPIGetSharesCall{Call: call}
+}
+
+// MockAPIGetSharesCall wrap *gomock.Call
+type MockAPIGetSharesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAPIGetSharesCall) Return(arg0 []secrets.ShareSchema, arg1 error) *MockAPIGetSharesCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAPIGetSharesCall) Do(f func(context.Context, string, string) ([]secrets.ShareSchema, error)) *MockAPIGetSharesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAPIGetSharesCall) DoAndReturn(f func(context.Context, string, string) ([]secrets.ShareSchema, error)) *MockAPIGetSharesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetTemplate mocks base method.
+func (m *MockAPI) GetTemplate(ctx context.Context, token, uuid string) (*templates.TemplateSchema, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplate", ctx, token, uuid)
+	ret0, _ := ret[0].(*templates.TemplateSchema)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTemplate indicates an expected call of GetTemplate.
+func (mr *MockAPIMockRecorder) GetTemplate(ctx, token, uuid any) *MockAPIGetTemplateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplate", reflect.TypeOf((*MockAPI)(nil).GetTemplate), ctx, token, uuid)
+	return &MockAPIGetTemplateCall{Call: call}
+}
+
+// MockAPIGetTemplateCall wrap *gomock.Call
+type MockAPIGetTemplateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAPIGetTemplateCall) Return(arg0 *templates.TemplateSchema, arg1 error) *MockAPIGetTemplateCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAPIGetTemplateCall) Do(f func(context.Context, string, string) (*templates.TemplateSchema, error)) *MockAPIGetTemplateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAPIGetTemplateCall) DoAndReturn(f func(context.Context, string, string) (*templates.TemplateSchema, error)) *MockAPIGetTemplateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetTemplates mocks base method.
+func (m *MockAPI) GetTemplates(ctx context.Context, token string) ([]templates.TemplateSchema, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplates", ctx, token)
+	ret0, _ := ret[0].([]templates.TemplateSchema)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTemplates indicates an expected call of GetTemplates.
+func (mr *MockAPIMockRecorder) GetTemplates(ctx, token any) *MockAPIGetTemplatesCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplates", reflect.TypeOf((*MockAPI)(nil).GetTemplates), ctx, token)
+	return &MockAPIGetTemplatesCall{Call: call}
+}
+
+// MockAPIGetTemplatesCall wrap *gomock.Call
+type MockAPIGetTemplatesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAPIGetTemplatesCall) Return(arg0 []templates.TemplateSchema, arg1 error) *MockAPIGetTemplatesCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAPIGetTemplatesCall) Do(f func(context.Context, string) ([]templates.TemplateSchema, error)) *MockAPIGetTemplatesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAPIGetTemplatesCall) DoAndReturn(f func(context.Context, string) ([]templates.TemplateSchema, error)) *MockAPIGetTemplatesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetUsage mocks base method.
+func (m *MockAPI) GetUsage(ctx context.Context, token string) (*secrets.UsageSchema, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsage", ctx, token)
+	ret0, _ := ret[0].(*secrets.UsageSchema)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsage indicates an expected call of GetUsage.
+func (mr *MockAPIMockRecorder) GetUsage(ctx, token any) *MockAPIGetUsageCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsage", reflect.TypeOf((*MockAPI)(nil).GetUsage), ctx, token)
+	return &MockAPIGetUsageCall{Call: call}
+}
+
+// MockAPIGetUsageCall wrap *gomock.Call
+type MockAPIGetUsageCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAPIGetUsageCall) Return(arg0 *secrets.UsageSchema, arg1 error) *MockAPIGetUsageCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAPIGetUsageCall) Do(f func(context.Context, string) (*secrets.UsageSchema, error)) *MockAPIGetUsageCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAPIGetUsageCall) DoAndReturn(f func(context.Context, string) (*secrets.UsageSchema, error)) *MockAPIGetUsageCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ImportVault mocks base method.
+func (m *MockAPI) ImportVault(ctx context.Context, token string, data *secrets.ImportData) (*secrets.ImportSchema, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportVault", ctx, token, data)
+	ret0, _ := ret[0].(*secrets.ImportSchema)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportVault indicates an expected call of ImportVault.
+func (mr *MockAPIMockRecorder) ImportVault(ctx, token, data any) *MockAPIImportVaultCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportVault", reflect.TypeOf((*MockAPI)(nil).ImportVault), ctx, token, data)
+	return &MockAPIImportVaultCall{Call: call}
+}
+
+// MockAPIImportVaultCall wrap *gomock.Call
+type MockAPIImportVaultCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAPIImportVaultCall) Return(arg0 *secrets.ImportSchema, arg1 error) *MockAPIImportVaultCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAPIImportVaultCall) Do(f func(context.Context, string, *secrets.ImportData) (*secrets.ImportSchema, error)) *MockAPIImportVaultCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAPIImportVaultCall) DoAndReturn(f func(context.Context, string, *secrets.ImportData) (*secrets.ImportSchema, error)) *MockAPIImportVaultCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// InviteEmergencyContact mocks base method.
+func (m *MockAPI) InviteEmergencyContact(ctx context.Context, token string, data *emergency.InviteData) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InviteEmergencyContact", ctx, token, data)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InviteEmergencyContact indicates an expected call of InviteEmergencyContact.
+func (mr *MockAPIMockRecorder) InviteEmergencyContact(ctx, token, data any) *MockAPIInviteEmergencyContactCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InviteEmergencyContact", reflect.TypeOf((*MockAPI)(nil).InviteEmergencyContact), ctx, token, data)
+	return &MockAPIInviteEmergencyContactCall{Call: call}
+}
+
+// MockAPIInviteEmergencyContactCall wrap *gomock.Call
+type MockAPIInviteEmergencyContactCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAPIInviteEmergencyContactCall) Return(arg0 string, arg1 error) *MockAPIInviteEmergencyContactCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAPIInviteEmergencyContactCall) Do(f func(context.Context, string, *emergency.InviteData) (string, error)) *MockAPIInviteEmergencyContactCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAPIInviteEmergencyContactCall) DoAndReturn(f func(context.Context, string, *emergency.InviteData) (string, error)) *MockAPIInviteEmergencyContactCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// InviteToOrg mocks base method.
+func (m *MockAPI) InviteToOrg(ctx context.Context, token, uuid string, data *orgs.InviteData) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InviteToOrg", ctx, token, uuid, data)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InviteToOrg indicates an expected call of InviteToOrg.
+func (mr *MockAPIMockRecorder) InviteToOrg(ctx, token, uuid, data any) *MockAPIInviteToOrgCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InviteToOrg", reflect.TypeOf((*MockAPI)(nil).InviteToOrg), ctx, token, uuid, data)
+	return &MockAPIInviteToOrgCall{Call: call}
+}
+
+// MockAPIInviteToOrgCall wrap *gomock.Call
+type MockAPIInviteToOrgCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAPIInviteToOrgCall) Return(arg0 string, arg1 error) *MockAPIInviteToOrgCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAPIInviteToOrgCall) Do(f func(context.Context, string, string, *orgs.InviteData) (string, error)) *MockAPIInviteToOrgCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAPIInviteToOrgCall) DoAndReturn(f func(context.Context, string, string, *orgs.InviteData) (string, error)) *MockAPIInviteToOrgCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Login mocks base method.
+func (m *MockAPI) Login(ctx context.Context, data *user.LoginData) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Login", ctx, data)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Login indicates an expected call of Login.
+func (mr *MockAPIMockRecorder) Login(ctx, data any) *MockAPILoginCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockAPI)(nil).Login), ctx, data)
+	return &MockAPILoginCall{Call: call}
+}
+
+// MockAPILoginCall wrap *gomock.Call
+type MockAPILoginCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAPILoginCall) Return(arg0 string, arg1 error) *MockAPILoginCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAPILoginCall) Do(f func(context.Context, *user.LoginData) (string, error)) *MockAPILoginCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAPILoginCall) DoAndReturn(f func(context.Context, *user.LoginData) (string, error)) *MockAPILoginCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MatchSecrets mocks base method.
+func (m *MockAPI) MatchSecrets(ctx context.Context, token string, params *secrets.MatchRequest) ([]secrets.SecretItemSchema, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MatchSecrets", ctx, token, params)
+	ret0, _ := ret[0].([]secrets.SecretItemSchema)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MatchSecrets indicates an expected call of MatchSecrets.
+func (mr *MockAPIMockRecorder) MatchSecrets(ctx, token, params any) *MockAPIMatchSecretsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MatchSecrets", reflect.TypeOf((*MockAPI)(nil).MatchSecrets), ctx, token, params)
+	return &MockAPIMatchSecretsCall{Call: call}
+}
+
+// MockAPIMatchSecretsCall wrap *gomock.Call
+type MockAPIMatchSecretsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAPIMatchSecretsCall) Return(arg0 []secrets.SecretItemSchema, arg1 error) *MockAPIMatchSecretsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAPIMatchSecretsCall) Do(f func(context.Context, string, *secrets.MatchRequest) ([]secrets.SecretItemSchema, error)) *MockAPIMatchSecretsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAPIMatchSecretsCall) DoAndReturn(f func(context.Context, string, *secrets.MatchRequest) ([]secrets.SecretItemSchema, error)) *MockAPIMatchSecretsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Register mocks base method.
+func (m *MockAPI) Register(ctx context.Context, data *user.RegisterData) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Register", ctx, data)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Register indicates an expected call of Register.
+func (mr *MockAPIMockRecorder) Register(ctx, data any) *MockAPIRegisterCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockAPI)(nil).Register), ctx, data)
+	return &MockAPIRegisterCall{Call: call}
+}
+
+// MockAPIRegisterCall wrap *gomock.Call
+type MockAPIRegisterCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAPIRegisterCall) Return(arg0 string, arg1 error) *MockAPIRegisterCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAPIRegisterCall) Do(f func(context.Context, *user.RegisterData) (string, error)) *MockAPIRegisterCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAPIRegisterCall) DoAndReturn(f func(context.Context, *user.RegisterData) (string, error)) *MockAPIRegisterCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// RejectEmergencyAccess mocks base method.
+func (m *MockAPI) RejectEmergencyAccess(ctx context.Context, token, uuid string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RejectEmergencyAccess", ctx, token, uuid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RejectEmergencyAccess indicates an expected call of RejectEmergencyAccess.
+func (mr *MockAPIMockRecorder) RejectEmergencyAccess(ctx, token, uuid any) *MockAPIRejectEmergencyAccessCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RejectEmergencyAccess", reflect.TypeOf((*MockAPI)(nil).RejectEmergencyAccess), ctx, token, uuid)
+	return &MockAPIRejectEmergencyAccessCall{Call: call}
+}
+
+// MockAPIRejectEmergencyAccessCall wrap *gomock.Call
+type MockAPIRejectEmergencyAccessCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAPIRejectEmergencyAccessCall) Return(arg0 error) *MockAPIRejectEmergencyAccessCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAPIRejectEmergencyAccessCall) Do(f func(context.Context, string, string) error) *MockAPIRejectEmergencyAccessCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAPIRejectEmergencyAccessCall) DoAndReturn(f func(context.Context, string, string) error) *MockAPIRejectEmergencyAccessCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// RemoveOrgMember mocks base method.
+func (m *MockAPI) RemoveOrgMember(ctx context.Context, token, uuid, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveOrgMember", ctx, token, uuid, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveOrgMember indicates an expected call of RemoveOrgMember.
+func (mr *MockAPIMockRecorder) RemoveOrgMember(ctx, token, uuid, userID any) *MockAPIRemoveOrgMemberCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveOrgMember", reflect.TypeOf((*MockAPI)(nil).RemoveOrgMember), ctx, token, uuid, userID)
+	return &MockAPIRemoveOrgMemberCall{Call: call}
+}
+
+// MockAPIRemoveOrgMemberCall wrap *gomock.Call
+type MockAPIRemoveOrgMemberCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAPIRemoveOrgMemberCall) Return(arg0 error) *MockAPIRemoveOrgMemberCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAPIRemoveOrgMemberCall) Do(f func(context.Context, string, string, string) error) *MockAPIRemoveOrgMemberCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAPIRemoveOrgMemberCall) DoAndReturn(f func(context.Context, string, string, string) error) *MockAPIRemoveOrgMemberCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Report mocks base method.
+func (m *MockAPI) Report(ctx context.Context, token string, data *secrets.ReportData) (*secrets.ReportSchema, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Report", ctx, token, data)
+	ret0, _ := ret[0].(*secrets.ReportSchema)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Report indicates an expected call of Report.
+func (mr *MockAPIMockRecorder) Report(ctx, token, data any) *MockAPIReportCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Report", reflect.TypeOf((*MockAPI)(nil).Report), ctx, token, data)
+	return &MockAPIReportCall{Call: call}
+}
+
+// MockAPIReportCall wrap *gomock.Call
+type MockAPIReportCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAPIReportCall) Return(arg0 *secrets.ReportSchema, arg1 error) *MockAPIReportCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAPIReportCall) Do(f func(context.Context, string, *secrets.ReportData) (*secrets.ReportSchema, error)) *MockAPIReportCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAPIReportCall) DoAndReturn(f func(context.Context, string, *secrets.ReportData) (*secrets.ReportSchema, error)) *MockAPIReportCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// RequestEmergencyAccess mocks base method.
+func (m *MockAPI) RequestEmergencyAccess(ctx context.Context, token, uuid string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestEmergencyAccess", ctx, token, uuid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequestEmergencyAccess indicates an expected call of RequestEmergencyAccess.
+func (mr *MockAPIMockRecorder) RequestEmergencyAccess(ctx, token, uuid any) *MockAPIRequestEmergencyAccessCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestEmergencyAccess", reflect.TypeOf((*MockAPI)(nil).RequestEmergencyAccess), ctx, token, uuid)
+	return &MockAPIRequestEmergencyAccessCall{Call: call}
+}
+
+// MockAPIRequestEmergencyAccessCall wrap *gomock.Call
+type MockAPIRequestEmergencyAccessCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAPIRequestEmergencyAccessCall) Return(arg0 error) *MockAPIRequestEmergencyAccessCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAPIRequestEmergencyAccessCall) Do(f func(context.Context, string, string) error) *MockAPIRequestEmergencyAccessCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAPIRequestEmergencyAccessCall) DoAndReturn(f func(context.Context, string, string) error) *MockAPIRequestEmergencyAccessCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// RevokeEmergencyAccess mocks base method.
+func (m *MockAPI) RevokeEmergencyAccess(ctx context.Context, token, uuid string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeEmergencyAccess", ctx, token, uuid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeEmergencyAccess indicates an expected call of RevokeEmergencyAccess.
+func (mr *MockAPIMockRecorder) RevokeEmergencyAccess(ctx, token, uuid any) *MockAPIRevokeEmergencyAccessCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeEmergencyAccess", reflect.TypeOf((*MockAPI)(nil).RevokeEmergencyAccess), ctx, token, uuid)
+	return &MockAPIRevokeEmergencyAccessCall{Call: call}
+}
+
+// MockAPIRevokeEmergencyAccessCall wrap *gomock.Call
+type MockAPIRevokeEmergencyAccessCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAPIRevokeEmergencyAccessCall) Return(arg0 error) *MockAPIRevokeEmergencyAccessCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAPIRevokeEmergencyAccessCall) Do(f func(context.Context, string, string) error) *MockAPIRevokeEmergencyAccessCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAPIRevokeEmergencyAccessCall) DoAndReturn(f func(context.Context, string, string) error) *MockAPIRevokeEmergencyAccessCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// RevokeShare mocks base method.
+func (m *MockAPI) RevokeShare(ctx context.Context, token, uuid, userID string, data *secrets.RevokeData) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeShare", ctx, token, uuid, userID, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeShare indicates an expected call of RevokeShare.
+func (mr *MockAPIMockRecorder) RevokeShare(ctx, token, uuid, userID, data any) *MockAPIRevokeShareCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeShare", reflect.TypeOf((*MockAPI)(nil).RevokeShare), ctx, token, uuid, userID, data)
+	return &MockAPIRevokeShareCall{Call: call}
+}
+
+// MockAPIRevokeShareCall wrap *gomock.Call
+type MockAPIRevokeShareCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAPIRevokeShareCall) Return(arg0 error) *MockAPIRevokeShareCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAPIRevokeShareCall) Do(f func(context.Context, string, string, string, *secrets.RevokeData) error) *MockAPIRevokeShareCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAPIRevokeShareCall) DoAndReturn(f func(context.Context, string, string, string, *secrets.RevokeData) error) *MockAPIRevokeShareCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ShareSecret mocks base method.
+func (m *MockAPI) ShareSecret(ctx context.Context, token, uuid string, data *secrets.ShareData) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShareSecret", ctx, token, uuid, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ShareSecret indicates an expected call of ShareSecret.
+func (mr *MockAPIMockRecorder) ShareSecret(ctx, token, uuid, data any) *MockAPIShareSecretCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShareSecret", reflect.TypeOf((*MockAPI)(nil).ShareSecret), ctx, token, uuid, data)
+	return &MockAPIShareSecretCall{Call: call}
+}
+
+// MockAPIShareSecretCall wrap *gomock.Call
+type MockAPIShareSecretCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAPIShareSecretCall) Return(arg0 error) *MockAPIShareSecretCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAPIShareSecretCall) Do(f func(context.Context, string, string, *secrets.ShareData) error) *MockAPIShareSecretCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAPIShareSecretCall) DoAndReturn(f func(context.Context, string, string, *secrets.ShareData) error) *MockAPIShareSecretCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Update mocks base method.
+func (m *MockAPI) Update(ctx context.Context, token, uuid, etag string, data secrets.Payload) ([]response.Warning, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, token, uuid, etag, data)
+	ret0, _ := ret[0].([]response.Warning)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockAPIMockRecorder) Update(ctx, token, uuid, etag, data any) *MockAPIUpdateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockAPI)(nil).Update), ctx, token, uuid, etag, data)
+	return &MockAPIUpdateCall{Call: call}
+}
+
+// MockAPIUpdateCall wrap *gomock.Call
+type MockAPIUpdateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAPIUpdateCall) Return(arg0 []response.Warning, arg1 error) *MockAPIUpdateCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAPIUpdateCall) Do(f func(context.Context, string, string, string, secrets.Payload) ([]response.Warning, error)) *MockAPIUpdateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAPIUpdateCall) DoAndReturn(f func(context.Context, string, string, string, secrets.Payload) ([]response.Warning, error)) *MockAPIUpdateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// UploadFile mocks base method.
+func (m *MockAPI) UploadFile(ctx context.Context, token, path string, data *secrets.UploadFileData) (string, []response.Warning, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadFile", ctx, token, path, data)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].([]response.Warning)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UploadFile indicates an expected call of UploadFile.
+func (mr *MockAPIMockRecorder) UploadFile(ctx, token, path, data any) *MockAPIUploadFileCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadFile", reflect.TypeOf((*MockAPI)(nil).UploadFile), ctx, token, path, data)
+	return &MockAPIUploadFileCall{Call: call}
+}
+
+// MockAPIUploadFileCall wrap *gomock.Call
+type MockAPIUploadFileCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAPIUploadFileCall) Return(arg0 string, arg1 []response.Warning, arg2 error) *MockAPIUploadFileCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAPIUploadFileCall) Do(f func(context.Context, string, string, *secrets.UploadFileData) (string, []response.Warning, error)) *MockAPIUploadFileCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAPIUploadFileCall) DoAndReturn(f func(context.Context, string, string, *secrets.UploadFileData) (string, []response.Warning, error)) *MockAPIUploadFileCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Verify mocks base method.
+func (m *MockAPI) Verify(ctx context.Context, token string, data *user.VerifyData) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", ctx, token, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockAPIMockRecorder) Verify(ctx, token, data any) *MockAPIVerifyCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockAPI)(nil).Verify), ctx, token, data)
+	return &MockAPIVerifyCall{Call: call}
+}
+
+// MockAPIVerifyCall wrap *gomock.Call
+type MockAPIVerifyCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockAPIVerifyCall) Return(arg0 error) *MockAPIVerifyCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockAPIVerifyCall) Do(f func(context.Context, string, *user.VerifyData) error) *MockAPIVerifyCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockAPIVerifyCall) DoAndReturn(f func(context.Context, string, *user.VerifyData) error) *MockAPIVerifyCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
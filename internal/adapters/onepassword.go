@@ -0,0 +1,200 @@
+package adapters
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+)
+
+const (
+	onePasswordSource = "1Password"
+	onePasswordData   = "export.data"
+
+	// the limit keeps a malicious archive from exhausting the memory
+	maxOnePasswordData = 256 << 20
+)
+
+// The categories of the 1Password items.
+const (
+	onePasswordLogin    = "001"
+	onePasswordCard     = "002"
+	onePasswordNote     = "003"
+	onePasswordPassword = "005"
+)
+
+type onePasswordExport struct {
+	Accounts []struct {
+		Vaults []struct {
+			Attrs struct {
+				Name string `json:"name"`
+			} `json:"attrs"`
+			Items []onePasswordItem `json:"items"`
+		} `json:"vaults"`
+	} `json:"accounts"`
+}
+
+type onePasswordItem struct {
+	State        string `json:"state"`
+	CategoryUUID string `json:"categoryUuid"`
+	Overview     struct {
+		Title string `json:"title"`
+		URL   string `json:"url"`
+		URLs  []struct {
+			URL string `json:"url"`
+		} `json:"urls"`
+	} `json:"overview"`
+	Details struct {
+		LoginFields []struct {
+			Value       string `json:"value"`
+			Designation string `json:"designation"`
+		} `json:"loginFields"`
+		NotesPlain string `json:"notesPlain"`
+		Password   string `json:"password"`
+		Sections   []struct {
+			Fields []struct {
+				ID    string         `json:"id"`
+				Title string         `json:"title"`
+				Value map[string]any `json:"value"`
+			} `json:"fields"`
+		} `json:"sections"`
+	} `json:"details"`
+}
+
+// OnePasswordItems maps the logins, the passwords, the cards and the secure notes of the 1Password 1PUX export,
+// the CSV export is read as the other CSV exports.
+//
+// The vault is the folder, the fields of the sections are in the meta. The archived and the other items are skipped.
+func OnePasswordItems(data []byte, passphrase string) ([]ImportItem, []SkippedItem, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return csvItems(data, passphrase, onePasswordSource)
+	}
+
+	file, err := archive.Open(onePasswordData)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", ErrInvalidExport, err)
+	}
+	defer file.Close()
+
+	var export onePasswordExport
+	if err := json.NewDecoder(io.LimitReader(file, maxOnePasswordData)).Decode(&export); err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", ErrInvalidExport, err)
+	}
+
+	var (
+		items   []ImportItem
+		skipped []SkippedItem
+	)
+
+	for _, account := range export.Accounts {
+		for _, vault := range account.Vaults {
+			for _, item := range vault.Items {
+				imported, reason := mapOnePasswordItem(&item, vault.Attrs.Name, passphrase)
+				if reason != "" {
+					skipped = append(skipped, SkippedItem{Source: path.Join(vault.Attrs.Name, item.Overview.Title), Reason: reason})
+				} else {
+					items = append(items, imported)
+				}
+			}
+		}
+	}
+
+	return items, skipped, nil
+}
+
+func mapOnePasswordItem(item *onePasswordItem, folder, passphrase string) (ImportItem, string) {
+	if item.State == "archived" {
+		return ImportItem{}, "archived"
+	}
+
+	var (
+		source = path.Join(folder, item.Overview.Title)
+		meta   = make(map[string]any)
+		card   = &cardRecord{Source: source, Folder: folder, Title: item.Overview.Title, Notes: item.Details.NotesPlain}
+	)
+
+	for _, section := range item.Details.Sections {
+		for _, field := range section.Fields {
+			value := onePasswordValue(field.Value)
+
+			switch {
+			case item.CategoryUUID == onePasswordCard && field.ID == "ccnum":
+				card.Number = value
+			case item.CategoryUUID == onePasswordCard && field.ID == "cvv":
+				card.CVV = value
+			case item.CategoryUUID == onePasswordCard && field.ID == "cardholder":
+				card.Holder = value
+			case item.CategoryUUID == onePasswordCard && field.ID == "expiry" && len(value) == len("200601"):
+				card.Year, card.Month = value[:4], value[4:]
+			case value != "":
+				key := field.Title
+				if key == "" {
+					key = field.ID
+				}
+
+				meta[key] = value
+			}
+		}
+	}
+
+	switch item.CategoryUUID {
+	case onePasswordLogin, onePasswordPassword, onePasswordNote:
+		record := &loginRecord{
+			Source:   source,
+			Folder:   folder,
+			Title:    item.Overview.Title,
+			Password: item.Details.Password,
+			Notes:    item.Details.NotesPlain,
+			URLs:     []string{item.Overview.URL},
+			Meta:     meta,
+		}
+
+		for _, url := range item.Overview.URLs {
+			if url.URL != item.Overview.URL {
+				record.URLs = append(record.URLs, url.URL)
+			}
+		}
+
+		for _, field := range item.Details.LoginFields {
+			switch field.Designation {
+			case "username":
+				record.Username = field.Value
+			case "password":
+				record.Password = field.Value
+			}
+		}
+
+		imported, ok := record.item(onePasswordSource, passphrase)
+		if !ok {
+			return ImportItem{}, "no password or notes"
+		}
+
+		return imported, ""
+	case onePasswordCard:
+		card.Meta = meta
+
+		return card.item(onePasswordSource, passphrase)
+	default:
+		return ImportItem{}, "unsupported category " + item.CategoryUUID
+	}
+}
+
+// onePasswordValue returns the value of a field as a string, the value is an object with the kind of the field.
+func onePasswordValue(value map[string]any) string {
+	for _, v := range value {
+		switch v := v.(type) {
+		case string:
+			return v
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64)
+		case bool:
+			return strconv.FormatBool(v)
+		}
+	}
+
+	return ""
+}
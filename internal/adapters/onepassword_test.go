@@ -0,0 +1,157 @@
+package adapters_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/novoseltcev/passkeeper/internal/adapters"
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/v1/secrets"
+)
+
+// zipFiles packs the files as the 1PUX export does.
+func zipFiles(t *testing.T, files map[string][]byte) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	w := zip.NewWriter(&buf)
+
+	for name, data := range files {
+		f, err := w.Create(name)
+		require.NoError(t, err)
+
+		_, err = f.Write(data)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, w.Close())
+
+	return buf.Bytes()
+}
+
+func TestOnePasswordItems(t *testing.T) {
+	t.Parallel()
+
+	data := zipFiles(t, map[string][]byte{
+		"export.attributes": readTestdata(t, "1password/export.attributes"),
+		"export.data":       readTestdata(t, "1password/export.data"),
+	})
+
+	items, skipped, err := adapters.OnePasswordItems(data, testPassphrase)
+	require.NoError(t, err)
+
+	assert.Equal(t, []adapters.ImportItem{
+		{Source: "Personal/Example", Payload: &secrets.PasswordSecretData{
+			Passphrase: testPassphrase,
+			Name:       "Example",
+			Login:      "jane@example.com",
+			Password:   "op-s3cr3t",
+			URIs:       []secrets.URIData{{URI: "https://example.com/login"}},
+			Meta: map[string]any{
+				"PIN":               "0000",
+				adapters.MetaApps:   "android://com.example.app",
+				adapters.MetaFolder: "Personal",
+			},
+		}},
+		{Source: "Personal/Router", Payload: &secrets.TextSecretData{
+			Passphrase: testPassphrase,
+			Name:       "Router",
+			Content:    "r0uter-pass",
+			Meta:       map[string]any{"notes": "admin panel at 192.168.0.1", adapters.MetaFolder: "Personal"},
+		}},
+		{Source: "Personal/Mastercard", Payload: &secrets.CardSecretData{
+			Passphrase: testPassphrase,
+			Name:       "Mastercard",
+			Number:     "5555555555554444",
+			Holder:     "Jane Doe",
+			Exp:        "12/28",
+			CVV:        "321",
+			Meta:       map[string]any{"type": "mc", adapters.MetaFolder: "Personal"},
+		}},
+		{Source: "Personal/Door", Payload: &secrets.TextSecretData{
+			Passphrase: testPassphrase,
+			Name:       "Door",
+			Content:    "door code 4711",
+			Meta:       map[string]any{adapters.MetaFolder: "Personal"},
+		}},
+	}, items)
+
+	assert.Equal(t, []adapters.SkippedItem{
+		{Source: "Personal/Old shop", Reason: "archived"},
+		{Source: "Personal/Lease", Reason: "unsupported category 006"},
+		{Source: "Shared/Passkey login", Reason: "no password or notes"},
+	}, skipped)
+}
+
+func TestOnePasswordItems_CSV(t *testing.T) {
+	t.Parallel()
+
+	data := "Title,Website,Username,Password,Notes,Favorite\n" +
+		"Example,https://example.com,jane,op-s3cr3t,,false\n"
+
+	items, skipped, err := adapters.OnePasswordItems([]byte(data), testPassphrase)
+	require.NoError(t, err)
+	assert.Empty(t, skipped)
+
+	assert.Equal(t, []adapters.ImportItem{
+		{Source: "Example", Payload: &secrets.PasswordSecretData{
+			Passphrase: testPassphrase,
+			Name:       "Example",
+			Login:      "jane",
+			Password:   "op-s3cr3t",
+			URIs:       []secrets.URIData{{URI: "https://example.com"}},
+			Meta:       map[string]any{"Favorite": "false"},
+		}},
+	}, items)
+}
+
+func TestOnePasswordItems_Fails(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		data func(t *testing.T) []byte
+	}{
+		{
+			name: "no export data",
+			data: func(t *testing.T) []byte {
+				t.Helper()
+
+				return zipFiles(t, map[string][]byte{"export.attributes": readTestdata(t, "1password/export.attributes")})
+			},
+		},
+		{
+			name: "bad export data",
+			data: func(t *testing.T) []byte {
+				t.Helper()
+
+				return zipFiles(t, map[string][]byte{"export.data": []byte(`{"accounts":`)})
+			},
+		},
+		{
+			name: "accounts of another type",
+			data: func(t *testing.T) []byte {
+				t.Helper()
+
+				return zipFiles(t, map[string][]byte{"export.data": []byte(`{"accounts":{}}`)})
+			},
+		},
+		{
+			name: "empty",
+			data: func(*testing.T) []byte { return nil },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, _, err := adapters.OnePasswordItems(tt.data(t), testPassphrase)
+			require.ErrorIs(t, err, adapters.ErrInvalidExport)
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package adapters
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/v1/secrets"
+	"github.com/novoseltcev/passkeeper/internal/models"
+)
+
+// PlainSecret is a decrypted secret of the plaintext export.
+type PlainSecret struct {
+	ID              string         `json:"id"`
+	Name            string         `json:"name"`
+	Type            string         `json:"type"`
+	Data            map[string]any `json:"data"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	ExpiresAt       *time.Time     `json:"expires_at,omitempty"`
+	RotateEveryDays int            `json:"rotate_every_days,omitempty"`
+}
+
+// PlainSecrets returns all secrets of the personal vault decrypted with the passphrase.
+func PlainSecrets(ctx context.Context, api API, token, passphrase string) ([]*PlainSecret, error) {
+	var result []*PlainSecret
+
+	for offset := uint64(0); ; offset += namesPageSize {
+		page, total, err := api.GetSecretsPage(ctx, token, &secrets.PaginationRequest{Limit: namesPageSize, Offset: offset})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range page {
+			secret, err := api.DecryptSecret(ctx, token, item.ID, &secrets.DecryptByIDData{Passphrase: passphrase})
+			if err != nil {
+				return nil, fmt.Errorf("failed to decrypt %s: %w", item.Name, err)
+			}
+
+			result = append(result, &PlainSecret{
+				ID:              item.ID,
+				Name:            item.Name,
+				Type:            item.Type,
+				Data:            secret.Data,
+				CreatedAt:       item.CreatedAt,
+				UpdatedAt:       item.UpdatedAt,
+				ExpiresAt:       item.ExpiresAt,
+				RotateEveryDays: item.RotateEveryDays,
+			})
+		}
+
+		if len(page) == 0 || offset+namesPageSize >= total {
+			return result, nil
+		}
+	}
+}
+
+// plainFields are the user-facing keys of the decrypted data of the types in the order of their CSV columns,
+// the keys of the server, such as the content key of a streamed file, aren't exported.
+var plainFields = map[models.SecretType][]string{ // nolint: gochecknoglobals
+	models.SecretTypePwd:    {"login", "password", "uris"},
+	models.SecretTypeCard:   {"number", "holder", "exp", "cvv"},
+	models.SecretTypeTxt:    {"content"},
+	models.SecretTypeFile:   {"filename", "content"},
+	models.SecretTypeOTP:    {"kind", "secret", "algorithm", "digits", "period", "counter", "issuer", "account"},
+	models.SecretTypeSSHKey: {"private_key", "public_key", "passphrase", "comment"},
+	models.SecretTypeCustom: {"template", "fields"},
+}
+
+// PlainColumns returns the columns of the CSV export of the type.
+//
+// The data columns are the user-facing fields of the type, the meta is a single JSON column.
+func PlainColumns(typeName string) ([]string, error) {
+	registered, ok := secrets.Lookup(typeName)
+	if !ok {
+		return nil, fmt.Errorf("unknown secret type %q", typeName)
+	}
+
+	fields, ok := plainFields[registered.ID]
+	if !ok {
+		return nil, fmt.Errorf("no plaintext columns of secret type %q", typeName)
+	}
+
+	columns := append([]string{"id", "name"}, fields...)
+
+	return append(columns, "meta", "created_at", "updated_at", "expires_at", "rotate_every_days"), nil
+}
+
+// WritePlainCSV writes the secrets of the type as CSV with the header, the secrets of the other types are skipped.
+//
+// The lists and the objects, such as the URIs and the meta, are JSON encoded.
+func WritePlainCSV(w io.Writer, typeName string, plain []*PlainSecret) error {
+	columns, err := PlainColumns(typeName)
+	if err != nil {
+		return err
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(columns); err != nil {
+		return err
+	}
+
+	for _, secret := range plain {
+		if secret.Type != typeName {
+			continue
+		}
+
+		row := make([]string, len(columns))
+		for i, column := range columns {
+			if row[i], err = secret.column(column); err != nil {
+				return err
+			}
+		}
+
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+}
+
+// WritePlainJSONL writes the secrets as JSON lines.
+func WritePlainJSONL(w io.Writer, plain []*PlainSecret) error {
+	encoder := json.NewEncoder(w)
+	for _, secret := range plain {
+		if err := encoder.Encode(secret); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *PlainSecret) column(column string) (string, error) {
+	switch column {
+	case "id":
+		return s.ID, nil
+	case "name":
+		return s.Name, nil
+	case "created_at":
+		return s.CreatedAt.Format(time.RFC3339), nil
+	case "updated_at":
+		return s.UpdatedAt.Format(time.RFC3339), nil
+	case "expires_at":
+		if s.ExpiresAt == nil {
+			return "", nil
+		}
+
+		return s.ExpiresAt.Format(time.RFC3339), nil
+	case "rotate_every_days":
+		if s.RotateEveryDays == 0 {
+			return "", nil
+		}
+
+		return strconv.Itoa(s.RotateEveryDays), nil
+	}
+
+	switch value := s.Data[column].(type) {
+	case nil:
+		return "", nil
+	case string:
+		return value, nil
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64), nil
+	case bool:
+		return strconv.FormatBool(value), nil
+	default:
+		encoded, err := json.Marshal(value)
+
+		return string(encoded), err
+	}
+}
@@ -0,0 +1,271 @@
+package adapters_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/novoseltcev/passkeeper/internal/adapters"
+	"github.com/novoseltcev/passkeeper/internal/adapters/mocks"
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/v1/secrets"
+)
+
+var (
+	testCreatedAt = time.Date(2024, time.May, 1, 10, 0, 0, 0, time.UTC)
+	testUpdatedAt = time.Date(2024, time.June, 1, 10, 0, 0, 0, time.UTC)
+	testExpiresAt = time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+)
+
+func testPlainSecrets() []*adapters.PlainSecret {
+	return []*adapters.PlainSecret{
+		{
+			ID:   "11111111-1111-1111-1111-111111111111",
+			Name: "GitHub",
+			Type: "password",
+			Data: map[string]any{
+				"login":    "jane",
+				"password": `p@ss,"word"`,
+				"uris":     []any{map[string]any{"uri": "https://github.com"}},
+				"meta":     map[string]any{"notes": "two\nlines"},
+			},
+			CreatedAt:       testCreatedAt,
+			UpdatedAt:       testUpdatedAt,
+			ExpiresAt:       &testExpiresAt,
+			RotateEveryDays: 90,
+		},
+		{
+			ID:        "22222222-2222-2222-2222-222222222222",
+			Name:      "Wi-Fi",
+			Type:      "text",
+			Data:      map[string]any{"content": "w1f1", "meta": map[string]any{}},
+			CreatedAt: testCreatedAt,
+			UpdatedAt: testUpdatedAt,
+		},
+		{
+			ID:        "33333333-3333-3333-3333-333333333333",
+			Name:      "Bank",
+			Type:      "password",
+			Data:      map[string]any{"login": "jane", "password": "b4nk", "meta": map[string]any{}},
+			CreatedAt: testCreatedAt,
+			UpdatedAt: testUpdatedAt,
+		},
+	}
+}
+
+func TestPlainSecrets(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	api := mocks.NewMockAPI(ctrl)
+
+	firstPage := make([]secrets.SecretItemSchema, 100)
+	for i := range firstPage {
+		firstPage[i] = secrets.SecretItemSchema{ID: "first", Name: "First", Type: "text"}
+	}
+
+	gomock.InOrder(
+		api.EXPECT().
+			GetSecretsPage(gomock.Any(), testToken, &secrets.PaginationRequest{Limit: 100, Offset: 0}).
+			Return(firstPage, uint64(101), nil),
+		api.EXPECT().
+			DecryptSecret(gomock.Any(), testToken, "first", &secrets.DecryptByIDData{Passphrase: testPassphrase}).
+			Return(&secrets.SecretSchema{Data: map[string]any{"content": "first"}}, nil).
+			Times(100),
+		api.EXPECT().
+			GetSecretsPage(gomock.Any(), testToken, &secrets.PaginationRequest{Limit: 100, Offset: 100}).
+			Return([]secrets.SecretItemSchema{{
+				ID:         "last",
+				Name:       "Last",
+				Type:       "text",
+				CreatedAt:  testCreatedAt,
+				UpdatedAt:  testUpdatedAt,
+				AttrsData:  secrets.AttrsData{ExpiresAt: &testExpiresAt, RotateEveryDays: 30},
+				AccessedAt: &testUpdatedAt,
+			}}, uint64(101), nil),
+		api.EXPECT().
+			DecryptSecret(gomock.Any(), testToken, "last", &secrets.DecryptByIDData{Passphrase: testPassphrase}).
+			Return(&secrets.SecretSchema{Data: map[string]any{"content": "last"}}, nil),
+	)
+
+	plain, err := adapters.PlainSecrets(context.Background(), api, testToken, testPassphrase)
+	require.NoError(t, err)
+	require.Len(t, plain, 101)
+
+	assert.Equal(t, &adapters.PlainSecret{
+		ID:   "first",
+		Name: "First",
+		Type: "text",
+		Data: map[string]any{"content": "first"},
+	}, plain[0])
+	assert.Equal(t, &adapters.PlainSecret{
+		ID:              "last",
+		Name:            "Last",
+		Type:            "text",
+		Data:            map[string]any{"content": "last"},
+		CreatedAt:       testCreatedAt,
+		UpdatedAt:       testUpdatedAt,
+		ExpiresAt:       &testExpiresAt,
+		RotateEveryDays: 30,
+	}, plain[100])
+}
+
+func TestPlainSecrets_Fails(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		expect func(api *mocks.MockAPI)
+	}{
+		{
+			name: "page",
+			expect: func(api *mocks.MockAPI) {
+				api.EXPECT().GetSecretsPage(gomock.Any(), testToken, gomock.Any()).Return(nil, uint64(0), errTest)
+			},
+		},
+		{
+			name: "decrypt",
+			expect: func(api *mocks.MockAPI) {
+				api.EXPECT().
+					GetSecretsPage(gomock.Any(), testToken, gomock.Any()).
+					Return([]secrets.SecretItemSchema{{ID: "id", Name: "GitHub"}}, uint64(1), nil)
+				api.EXPECT().DecryptSecret(gomock.Any(), testToken, "id", gomock.Any()).Return(nil, errTest)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			api := mocks.NewMockAPI(ctrl)
+			tt.expect(api)
+
+			_, err := adapters.PlainSecrets(context.Background(), api, testToken, testPassphrase)
+			require.ErrorIs(t, err, errTest)
+		})
+	}
+}
+
+func TestPlainColumns(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		typeName string
+		want     []string
+	}{
+		{typeName: "password", want: []string{"login", "password", "uris"}},
+		{typeName: "card", want: []string{"number", "holder", "exp", "cvv"}},
+		{typeName: "text", want: []string{"content"}},
+		{typeName: "file", want: []string{"filename", "content"}},
+		{typeName: "otp", want: []string{"kind", "secret", "algorithm", "digits", "period", "counter", "issuer", "account"}},
+		{typeName: "ssh_key", want: []string{"private_key", "public_key", "passphrase", "comment"}},
+		{typeName: "custom", want: []string{"template", "fields"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.typeName, func(t *testing.T) {
+			t.Parallel()
+
+			columns, err := adapters.PlainColumns(tt.typeName)
+			require.NoError(t, err)
+
+			want := append(append([]string{"id", "name"}, tt.want...),
+				"meta", "created_at", "updated_at", "expires_at", "rotate_every_days")
+			assert.Equal(t, want, columns)
+		})
+	}
+}
+
+func TestPlainColumns_AllTypes(t *testing.T) {
+	t.Parallel()
+
+	for _, typeName := range secrets.TypeNames() {
+		columns, err := adapters.PlainColumns(typeName)
+		require.NoError(t, err, typeName)
+		assert.Greater(t, len(columns), len([]string{"id", "name", "meta"}), typeName)
+	}
+}
+
+func TestPlainColumns_Unknown(t *testing.T) {
+	t.Parallel()
+
+	_, err := adapters.PlainColumns("unknown")
+	require.Error(t, err)
+}
+
+func TestWritePlainCSV(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	require.NoError(t, adapters.WritePlainCSV(&buf, "password", testPlainSecrets()))
+
+	assert.Equal(t,
+		"id,name,login,password,uris,meta,created_at,updated_at,expires_at,rotate_every_days\n"+
+			`11111111-1111-1111-1111-111111111111,GitHub,jane,"p@ss,""word""","[{""uri"":""https://github.com""}]",`+
+			`"{""notes"":""two\nlines""}",2024-05-01T10:00:00Z,2024-06-01T10:00:00Z,2025-01-01T00:00:00Z,90`+"\n"+
+			"33333333-3333-3333-3333-333333333333,Bank,jane,b4nk,,{},2024-05-01T10:00:00Z,2024-06-01T10:00:00Z,,\n",
+		buf.String())
+}
+
+func TestWritePlainCSV_Empty(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	require.NoError(t, adapters.WritePlainCSV(&buf, "card", testPlainSecrets()))
+
+	assert.Equal(t, "id,name,number,holder,exp,cvv,meta,created_at,updated_at,expires_at,rotate_every_days\n",
+		buf.String())
+}
+
+func TestWritePlainCSV_File(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	require.NoError(t, adapters.WritePlainCSV(&buf, "file", []*adapters.PlainSecret{{
+		ID:        "44444444-4444-4444-4444-444444444444",
+		Name:      "Notes",
+		Type:      "file",
+		Data:      map[string]any{"filename": "notes.txt", "content": "74657374", "meta": map[string]any{}},
+		CreatedAt: testCreatedAt,
+		UpdatedAt: testUpdatedAt,
+	}}))
+
+	assert.Equal(t,
+		"id,name,filename,content,meta,created_at,updated_at,expires_at,rotate_every_days\n"+
+			"44444444-4444-4444-4444-444444444444,Notes,notes.txt,74657374,{},2024-05-01T10:00:00Z,2024-06-01T10:00:00Z,,\n",
+		buf.String())
+}
+
+func TestWritePlainCSV_Unknown(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	require.Error(t, adapters.WritePlainCSV(&buf, "unknown", testPlainSecrets()))
+	assert.Empty(t, buf.String())
+}
+
+func TestWritePlainJSONL(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	require.NoError(t, adapters.WritePlainJSONL(&buf, testPlainSecrets()))
+
+	assert.Equal(t,
+		`{"id":"11111111-1111-1111-1111-111111111111","name":"GitHub","type":"password",`+
+			`"data":{"login":"jane","meta":{"notes":"two\nlines"},"password":"p@ss,\"word\"",`+
+			`"uris":[{"uri":"https://github.com"}]},"created_at":"2024-05-01T10:00:00Z",`+
+			`"updated_at":"2024-06-01T10:00:00Z","expires_at":"2025-01-01T00:00:00Z","rotate_every_days":90}`+"\n"+
+			`{"id":"22222222-2222-2222-2222-222222222222","name":"Wi-Fi","type":"text",`+
+			`"data":{"content":"w1f1","meta":{}},"created_at":"2024-05-01T10:00:00Z",`+
+			`"updated_at":"2024-06-01T10:00:00Z"}`+"\n"+
+			`{"id":"33333333-3333-3333-3333-333333333333","name":"Bank","type":"password",`+
+			`"data":{"login":"jane","meta":{},"password":"b4nk"},"created_at":"2024-05-01T10:00:00Z",`+
+			`"updated_at":"2024-06-01T10:00:00Z"}`+"\n",
+		buf.String())
+}
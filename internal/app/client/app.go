@@ -25,7 +25,7 @@ func (a *App) Run(ctx context.Context) {
 	a.log.Info("Application starting")
 
 	tApp := tview.NewApplication().EnableMouse(true).EnablePaste(true)
-	tApp.SetRoot(tui.NewLayout(a.api), true)
+	tApp.SetRoot(tui.NewLayout(tApp, a.api), true)
 	tApp.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
 		return event
 	})
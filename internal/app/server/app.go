@@ -18,7 +18,11 @@ import (
 	"github.com/novoseltcev/passkeeper/internal/auth"
 	"github.com/novoseltcev/passkeeper/internal/controllers/http/srv"
 	v1 "github.com/novoseltcev/passkeeper/internal/controllers/http/v1"
+	"github.com/novoseltcev/passkeeper/internal/domains/emergency"
+	"github.com/novoseltcev/passkeeper/internal/domains/orgs"
 	"github.com/novoseltcev/passkeeper/internal/domains/secrets"
+	"github.com/novoseltcev/passkeeper/internal/domains/sends"
+	"github.com/novoseltcev/passkeeper/internal/domains/templates"
 	"github.com/novoseltcev/passkeeper/internal/domains/user"
 	"github.com/novoseltcev/passkeeper/internal/middleware"
 	"github.com/novoseltcev/passkeeper/pkg/httpserver"
@@ -30,12 +34,17 @@ const (
 )
 
 type App struct {
-	cfg           *Config
-	log           *zap.Logger
-	db            *sqlx.DB
-	jwtStorager   jwtmanager.TokenStorager
-	secretService secrets.Service
-	userService   user.Service
+	cfg              *Config
+	log              *zap.Logger
+	db               *sqlx.DB
+	jwtStorager      jwtmanager.TokenStorager
+	secretService    secrets.Service
+	templateService  templates.Service
+	userService      user.Service
+	orgService       orgs.Service
+	sendService      sends.Service
+	emergencyService emergency.Service
+	notifier         secrets.Notifier
 }
 
 func New(
@@ -44,15 +53,25 @@ func New(
 	db *sqlx.DB,
 	jwtStorager jwtmanager.TokenStorager,
 	secretService secrets.Service,
+	templateService templates.Service,
 	userService user.Service,
+	orgService orgs.Service,
+	sendService sends.Service,
+	emergencyService emergency.Service,
+	notifier secrets.Notifier,
 ) *App {
 	return &App{
-		cfg:           cfg,
-		log:           log,
-		db:            db,
-		jwtStorager:   jwtStorager,
-		secretService: secretService,
-		userService:   userService,
+		cfg:              cfg,
+		log:              log,
+		db:               db,
+		jwtStorager:      jwtStorager,
+		secretService:    secretService,
+		templateService:  templateService,
+		userService:      userService,
+		orgService:       orgService,
+		sendService:      sendService,
+		emergencyService: emergencyService,
+		notifier:         notifier,
 	}
 }
 
@@ -68,6 +87,10 @@ func (a *App) Run(ctx context.Context) {
 
 	srv := httpserver.New(rootHandler, httpserver.WithAddr(a.cfg.Address))
 	go srv.Run()
+	go a.runReminders(ctx)
+	go a.runSendsPurge(ctx)
+	go a.runEmergencyGrants(ctx)
+	go a.runBlobsGC(ctx)
 
 	a.log.Info("Server started")
 
@@ -122,7 +145,17 @@ func (a *App) getRootHandler() (http.Handler, error) {
 	)
 
 	srv.AddRoutes(root.Group("/srv"))
-	v1.AddRoutes(root.Group("/api/v1"), jwt, middleware.JWT(jwt, auth.IdentityKey), a.secretService, a.userService)
+	v1.AddRoutes(
+		root.Group("/api/v1"),
+		jwt,
+		middleware.JWT(jwt, auth.IdentityKey),
+		a.secretService,
+		a.templateService,
+		a.userService,
+		a.orgService,
+		a.sendService,
+		a.emergencyService,
+	)
 
 	return root.Handler(), nil
 }
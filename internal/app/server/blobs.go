@@ -0,0 +1,26 @@
+package server
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// runBlobsGC periodically deletes the blobs no secret refers to until the context is done.
+func (a *App) runBlobsGC(ctx context.Context) {
+	interval := a.cfg.Blobs.GCInterval
+	if a.cfg.Blobs.Backend == "" || interval <= 0 {
+		a.log.Info("Collection of blobs is disabled")
+
+		return
+	}
+
+	runEvery(ctx, interval, func(ctx context.Context) {
+		collected, err := a.secretService.CollectBlobs(ctx, a.cfg.Blobs.GCAge)
+		if err != nil {
+			a.log.Error("Failed to collect blobs", zap.Error(err))
+		} else if collected > 0 {
+			a.log.Info("Collected blobs", zap.Int("collected", collected))
+		}
+	})
+}
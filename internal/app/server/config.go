@@ -8,12 +8,20 @@ import (
 
 // Config is a server configuration.
 type Config struct {
-	Address        string       `env:"ADDRESS"`
-	Level          string       `env:"LEVEL"`
-	TrustedProxies []string     `env:"TRUSTED_PROXIES"`
-	DB             DBConfig     `envPrefix:"DB_"`
-	JWT            JWTConfig    `envPrefix:"JWT_"`
-	Bcrypt         BcryptConfig `envPrefix:"BCRYPT_"`
+	Address        string          `env:"ADDRESS"`
+	Level          string          `env:"LEVEL"`
+	TrustedProxies []string        `env:"TRUSTED_PROXIES"`
+	DB             DBConfig        `envPrefix:"DB_"`
+	JWT            JWTConfig       `envPrefix:"JWT_"`
+	Bcrypt         BcryptConfig    `envPrefix:"BCRYPT_"`
+	Breach         BreachConfig    `envPrefix:"BREACH_"`
+	Reminders      RemindersConfig `envPrefix:"REMINDERS_"`
+	Sends          SendsConfig     `envPrefix:"SENDS_"`
+	Emergency      EmergencyConfig `envPrefix:"EMERGENCY_"`
+	Blobs          BlobsConfig     `envPrefix:"BLOBS_"`
+	Quota          QuotaConfig     `envPrefix:"QUOTA_"`
+	URIIndex       URIIndexConfig  `envPrefix:"URI_INDEX_"`
+	SMTP           SMTPConfig      `envPrefix:"SMTP_"`
 }
 
 type DBConfig struct {
@@ -29,6 +37,93 @@ type BcryptConfig struct {
 	Cost int `env:"COST" envDefault:"12"`
 }
 
+// BreachConfig points to the index of breached passwords built by the breach-index command.
+//
+// The check of breached passwords is disabled without it.
+type BreachConfig struct {
+	Index string `env:"INDEX"`
+}
+
+// RemindersConfig configures the scan of expired secrets and secrets due for rotation.
+//
+// The scan is disabled with a non-positive interval.
+type RemindersConfig struct {
+	Interval time.Duration `env:"INTERVAL" envDefault:"1h"`
+}
+
+// SendsConfig configures the purge of expired sends.
+//
+// The purge is disabled with a non-positive interval, expired sends are still not readable.
+type SendsConfig struct {
+	PurgeInterval time.Duration `env:"PURGE_INTERVAL" envDefault:"1h"`
+}
+
+// EmergencyConfig configures the grant of emergency access requests, whose wait period is over.
+//
+// The grant is disabled with a non-positive interval, requests are still approved by the grantors.
+type EmergencyConfig struct {
+	GrantInterval time.Duration `env:"GRANT_INTERVAL" envDefault:"1h"`
+}
+
+// BlobsConfig configures the store of the file contents and the collection of the blobs no secret refers to.
+//
+// The backend is "fs" or "s3", the contents are kept in the database without it.
+// The collection is disabled with a non-positive interval, it keeps the blobs younger than the age.
+type BlobsConfig struct {
+	Backend    string        `env:"BACKEND"`
+	Dir        string        `env:"DIR"         envDefault:"blobs"`
+	S3         S3Config      `envPrefix:"S3_"`
+	GCInterval time.Duration `env:"GC_INTERVAL" envDefault:"1h"`
+	GCAge      time.Duration `env:"GC_AGE"      envDefault:"1h"`
+}
+
+// QuotaConfig limits the secrets of each account, zero limits are unlimited.
+//
+// The sizes are of the encrypted data in bytes.
+type QuotaConfig struct {
+	MaxSecrets     int   `env:"MAX_SECRETS"`
+	MaxBytes       int64 `env:"MAX_BYTES"`
+	MaxSecretBytes int64 `env:"MAX_SECRET_BYTES"`
+}
+
+// URIIndexConfig keys the index of the password URIs, the lookup of passwords by URL is disabled without the key.
+//
+// Changing the key loses the index of the saved passwords until they are updated.
+type URIIndexConfig struct {
+	Key string `env:"KEY"`
+}
+
+// S3Config locates the bucket of an S3-compatible storage, such as MinIO.
+//
+// The blobs are collected only under a prefix, as the bucket may be shared.
+type S3Config struct {
+	Endpoint  string `env:"ENDPOINT"`
+	Region    string `env:"REGION"     envDefault:"us-east-1"`
+	Bucket    string `env:"BUCKET"`
+	Prefix    string `env:"PREFIX"`
+	AccessKey string `env:"ACCESS_KEY"`
+	SecretKey string `env:"SECRET_KEY"`
+}
+
+// SMTPConfig configures mailing of reminders, they are only logged without the host.
+type SMTPConfig struct {
+	Host     string `env:"HOST"`
+	Port     int    `env:"PORT"     envDefault:"587"`
+	Username string `env:"USERNAME"`
+	Password string `env:"PASSWORD"`
+	From     string `env:"FROM"     envDefault:"passkeeper@localhost"`
+}
+
 func (cfg *Config) LoadEnv() error {
 	return env.Parse(cfg)
 }
+
+// LoadEnv loads the database configuration alone, for the commands which need no other configuration.
+func (cfg *DBConfig) LoadEnv() error {
+	return env.ParseWithOptions(cfg, env.Options{Prefix: "DB_"})
+}
+
+// LoadEnv loads the blob store configuration alone, for the commands which need no other configuration.
+func (cfg *BlobsConfig) LoadEnv() error {
+	return env.ParseWithOptions(cfg, env.Options{Prefix: "BLOBS_"})
+}
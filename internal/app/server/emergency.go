@@ -0,0 +1,27 @@
+package server
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// runEmergencyGrants periodically grants emergency access requests, whose wait period is over,
+// until the context is done.
+func (a *App) runEmergencyGrants(ctx context.Context) {
+	interval := a.cfg.Emergency.GrantInterval
+	if interval <= 0 {
+		a.log.Info("Grant of emergency access requests is disabled")
+
+		return
+	}
+
+	runEvery(ctx, interval, func(ctx context.Context) {
+		granted, err := a.emergencyService.GrantExpired(ctx)
+		if err != nil {
+			a.log.Error("Failed to grant emergency access requests", zap.Error(err))
+		} else if granted > 0 {
+			a.log.Info("Granted emergency access requests", zap.Int64("granted", granted))
+		}
+	})
+}
@@ -0,0 +1,23 @@
+package server
+
+import (
+	"context"
+	"time"
+)
+
+// runEvery runs fn every interval until the context is done, the first run is after the first interval.
+//
+// The runs do not overlap, a run longer than the interval delays the next one.
+func runEvery(ctx context.Context, interval time.Duration, fn func(ctx context.Context)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			fn(ctx)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package server
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// runReminders periodically notifies owners about due secrets until the context is done.
+func (a *App) runReminders(ctx context.Context) {
+	interval := a.cfg.Reminders.Interval
+	if interval <= 0 {
+		a.log.Info("Reminders are disabled")
+
+		return
+	}
+
+	runEvery(ctx, interval, func(ctx context.Context) {
+		sent, err := a.secretService.NotifyDue(ctx, a.notifier)
+		if err != nil {
+			a.log.Error("Failed to notify about due secrets", zap.Error(err), zap.Int("sent", sent))
+		} else if sent > 0 {
+			a.log.Info("Notified about due secrets", zap.Int("sent", sent))
+		}
+	})
+}
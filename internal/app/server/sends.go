@@ -0,0 +1,26 @@
+package server
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// runSendsPurge periodically deletes expired sends until the context is done.
+func (a *App) runSendsPurge(ctx context.Context) {
+	interval := a.cfg.Sends.PurgeInterval
+	if interval <= 0 {
+		a.log.Info("Purge of sends is disabled")
+
+		return
+	}
+
+	runEvery(ctx, interval, func(ctx context.Context) {
+		purged, err := a.sendService.Purge(ctx)
+		if err != nil {
+			a.log.Error("Failed to purge expired sends", zap.Error(err))
+		} else if purged > 0 {
+			a.log.Info("Purged expired sends", zap.Int64("purged", purged))
+		}
+	})
+}
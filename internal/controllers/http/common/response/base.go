@@ -1,9 +1,16 @@
 package response
 
 type Response[T any] struct {
-	Success bool     `json:"success"`
-	Errors  []string `json:"errors,omitempty"`
-	Result  *T       `json:"result"`
+	Success  bool      `json:"success"`
+	Errors   []string  `json:"errors,omitempty"`
+	Warnings []Warning `json:"warnings,omitempty"`
+	Result   *T        `json:"result"`
+}
+
+// Warning is a non-fatal finding about a successful request.
+type Warning struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
 }
 
 func NewSuccess[T any](result *T) *Response[T] {
@@ -13,6 +20,12 @@ func NewSuccess[T any](result *T) *Response[T] {
 	}
 }
 
+func (r *Response[T]) WithWarnings(warnings ...Warning) *Response[T] {
+	r.Warnings = append(r.Warnings, warnings...)
+
+	return r
+}
+
 func NewError(errs ...error) *Response[any] {
 	response := &Response[any]{
 		Success: false,
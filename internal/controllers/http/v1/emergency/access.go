@@ -0,0 +1,130 @@
+package emergency
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/novoseltcev/passkeeper/internal/auth"
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/common/response"
+	domain "github.com/novoseltcev/passkeeper/internal/domains/emergency"
+	"github.com/novoseltcev/passkeeper/internal/models"
+)
+
+// Invite makes a user a trusted contact of the user.
+func Invite(service domain.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		userID := auth.GetUserID(c)
+
+		var body InviteData
+		if err := c.ShouldBindJSON(&body); err != nil {
+			var vErr validator.ValidationErrors
+			if errors.As(err, &vErr) {
+				c.JSON(http.StatusUnprocessableEntity, response.NewValidationError(vErr))
+			} else {
+				c.JSON(http.StatusBadRequest, response.NewError(err))
+			}
+
+			return
+		}
+
+		id, err := service.Invite(c, userID, body.Passphrase, body.Login, body.WaitDays)
+		if err != nil {
+			if errors.Is(err, domain.ErrInvalidPassphrase) {
+				c.AbortWithStatus(http.StatusConflict)
+			} else if errors.Is(err, domain.ErrNoPublicKey) {
+				c.JSON(http.StatusConflict, response.NewError(err))
+			} else if errors.Is(err, domain.ErrUserNotFound) ||
+				errors.Is(err, domain.ErrInvalidContact) ||
+				errors.Is(err, domain.ErrInvalidWaitPeriod) {
+				c.JSON(http.StatusUnprocessableEntity, response.NewError(err))
+			} else {
+				c.AbortWithError(http.StatusInternalServerError, err)
+			}
+
+			return
+		}
+
+		c.JSON(http.StatusCreated, response.NewCreate(string(id)))
+	}
+}
+
+// GetContacts lists the trusted contacts of the user.
+func GetContacts(service domain.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		accesses, err := service.GetContacts(c, auth.GetUserID(c))
+		if err != nil {
+			c.AbortWithError(http.StatusInternalServerError, err)
+
+			return
+		}
+
+		schemas := newAccessSchemas(accesses)
+		c.JSON(http.StatusOK, response.NewSuccess(&schemas))
+	}
+}
+
+// GetGrantors lists the accesses, where the user is a trusted contact.
+func GetGrantors(service domain.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		accesses, err := service.GetGrantors(c, auth.GetUserID(c))
+		if err != nil {
+			c.AbortWithError(http.StatusInternalServerError, err)
+
+			return
+		}
+
+		schemas := newAccessSchemas(accesses)
+		c.JSON(http.StatusOK, response.NewSuccess(&schemas))
+	}
+}
+
+// Request requests the access to the vault of the grantor.
+func Request(service domain.Service) func(c *gin.Context) {
+	return transition(service.Request)
+}
+
+// Approve grants the requested access before the wait period is over.
+func Approve(service domain.Service) func(c *gin.Context) {
+	return transition(service.Approve)
+}
+
+// Reject rejects the request or takes the granted access back.
+func Reject(service domain.Service) func(c *gin.Context) {
+	return transition(service.Reject)
+}
+
+// Revoke deletes the access, both parties revoke it.
+func Revoke(service domain.Service) func(c *gin.Context) {
+	return transition(service.Revoke)
+}
+
+// change is a change of an access by one of its parties.
+type change func(ctx context.Context, id models.EmergencyID, userID models.UserID) error
+
+// transition handles the change of an access.
+func transition(change change) func(*gin.Context) {
+	return func(c *gin.Context) {
+		userID := auth.GetUserID(c)
+		id := models.EmergencyID(c.Param("id"))
+
+		if err := change(c, id, userID); err != nil {
+			if errors.Is(err, domain.ErrAccessNotFound) {
+				c.AbortWithStatus(http.StatusNotFound)
+			} else if errors.Is(err, domain.ErrForbidden) {
+				c.AbortWithStatus(http.StatusForbidden)
+			} else if errors.Is(err, domain.ErrInvalidStatus) {
+				c.JSON(http.StatusConflict, response.NewError(err))
+			} else {
+				c.AbortWithError(http.StatusInternalServerError, err)
+			}
+
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
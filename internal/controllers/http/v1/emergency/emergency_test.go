@@ -0,0 +1,320 @@
+package emergency_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/steinfletcher/apitest"
+	"go.uber.org/mock/gomock"
+
+	"github.com/novoseltcev/passkeeper/internal/auth"
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/v1/emergency"
+	_ "github.com/novoseltcev/passkeeper/internal/controllers/http/v1/secrets" // registers the names of secret types
+	domain "github.com/novoseltcev/passkeeper/internal/domains/emergency"
+	"github.com/novoseltcev/passkeeper/internal/domains/emergency/mocks"
+	"github.com/novoseltcev/passkeeper/internal/domains/secrets"
+	"github.com/novoseltcev/passkeeper/internal/models"
+	"github.com/novoseltcev/passkeeper/pkg/testutils"
+)
+
+const (
+	testUserID     = models.UserID("f535204f-9283-4c1a-8e68-8834c6ae83fb")
+	testID         = models.EmergencyID("7d3e2f1a-6b5c-4d8e-9f0a-1b2c3d4e5f60")
+	testSecretID   = models.SecretID("a6a3097b-7b03-4f3c-9686-7264a163b34d")
+	testPassphrase = "passphrase"
+	testLogin      = "test@test.com"
+)
+
+func guardMock(c *gin.Context) {
+	c.Set(auth.IdentityKey, string(testUserID))
+	c.Next()
+}
+
+func setup(t *testing.T) (*gin.Engine, *mocks.MockService) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	root := gin.Default()
+	service := mocks.NewMockService(ctrl)
+	emergency.AddRoutes(&root.RouterGroup, service, guardMock)
+
+	return root, service
+}
+
+func TestInvite(t *testing.T) {
+	t.Parallel()
+
+	body := `{"passphrase":"passphrase","login":"test@test.com","wait_days":7}`
+
+	tests := []struct {
+		name   string
+		body   string
+		err    error
+		status int
+	}{
+		{name: "success", body: body, status: http.StatusCreated},
+		{name: "no wait", body: `{"passphrase":"p","login":"l"}`, status: http.StatusUnprocessableEntity},
+		{name: "long wait", body: `{"passphrase":"p","login":"l","wait_days":91}`, status: http.StatusUnprocessableEntity},
+		{name: "invalid json", body: `{`, status: http.StatusBadRequest},
+		{name: "invalid passphrase", body: body, err: domain.ErrInvalidPassphrase, status: http.StatusConflict},
+		{name: "no public key", body: body, err: domain.ErrNoPublicKey, status: http.StatusConflict},
+		{name: "user not found", body: body, err: domain.ErrUserNotFound, status: http.StatusUnprocessableEntity},
+		{name: "self", body: body, err: domain.ErrInvalidContact, status: http.StatusUnprocessableEntity},
+		{name: "other", body: body, err: testutils.Err, status: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			root, service := setup(t)
+
+			if tt.body == body {
+				service.EXPECT().Invite(gomock.Any(), testUserID, testPassphrase, testLogin, 7).Return(testID, tt.err)
+			}
+
+			apitest.New(tt.name).
+				Handler(root.Handler()).
+				Debug().
+				Post("/emergency/contacts").
+				Body(tt.body).
+				Expect(t).
+				Status(tt.status).
+				End()
+		})
+	}
+}
+
+func TestGetContacts_Success(t *testing.T) {
+	t.Parallel()
+	root, service := setup(t)
+
+	requestedAt := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	service.EXPECT().
+		GetContacts(gomock.Any(), testUserID).
+		Return([]models.EmergencyAccess{{
+			ID:          testID,
+			Grantor:     &models.User{Login: "grantor"},
+			Grantee:     &models.User{Login: testLogin},
+			Status:      models.EmergencyStatusRequested,
+			WaitDays:    7,
+			RequestedAt: requestedAt,
+		}}, nil)
+
+	apitest.Handler(root.Handler()).
+		Debug().
+		Get("/emergency/contacts").
+		Expect(t).
+		Status(http.StatusOK).
+		Bodyf(
+			`{"success":true,"result":[{"id":"%s","grantor":"grantor","grantee":"%s","status":"requested",`+
+				`"wait_days":7,"requested_at":"2030-01-01T00:00:00Z"}]}`,
+			testID, testLogin,
+		).
+		End()
+}
+
+func TestGetGrantors_Success(t *testing.T) {
+	t.Parallel()
+	root, service := setup(t)
+
+	service.EXPECT().
+		GetGrantors(gomock.Any(), testUserID).
+		Return([]models.EmergencyAccess{{
+			ID:       testID,
+			Grantor:  &models.User{Login: "grantor"},
+			Grantee:  &models.User{Login: testLogin},
+			Status:   models.EmergencyStatusInvited,
+			WaitDays: 7,
+		}}, nil)
+
+	apitest.Handler(root.Handler()).
+		Debug().
+		Get("/emergency/grantors").
+		Expect(t).
+		Status(http.StatusOK).
+		Bodyf(
+			`{"success":true,"result":[{"id":"%s","grantor":"grantor","grantee":"%s","status":"invited","wait_days":7}]}`,
+			testID, testLogin,
+		).
+		End()
+}
+
+func TestTransitions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{name: "success", status: http.StatusNoContent},
+		{name: "not found", err: domain.ErrAccessNotFound, status: http.StatusNotFound},
+		{name: "forbidden", err: domain.ErrForbidden, status: http.StatusForbidden},
+		{name: "invalid status", err: domain.ErrInvalidStatus, status: http.StatusConflict},
+		{name: "other", err: testutils.Err, status: http.StatusInternalServerError},
+	}
+
+	actions := []struct {
+		method string
+		path   string
+		expect func(service *mocks.MockService, err error)
+	}{
+		{
+			method: http.MethodPost,
+			path:   "/request",
+			expect: func(service *mocks.MockService, err error) {
+				service.EXPECT().Request(gomock.Any(), testID, testUserID).Return(err)
+			},
+		},
+		{
+			method: http.MethodPost,
+			path:   "/approve",
+			expect: func(service *mocks.MockService, err error) {
+				service.EXPECT().Approve(gomock.Any(), testID, testUserID).Return(err)
+			},
+		},
+		{
+			method: http.MethodPost,
+			path:   "/reject",
+			expect: func(service *mocks.MockService, err error) {
+				service.EXPECT().Reject(gomock.Any(), testID, testUserID).Return(err)
+			},
+		},
+		{
+			method: http.MethodDelete,
+			expect: func(service *mocks.MockService, err error) {
+				service.EXPECT().Revoke(gomock.Any(), testID, testUserID).Return(err)
+			},
+		},
+	}
+
+	for _, action := range actions {
+		for _, tt := range tests {
+			t.Run(action.method+action.path+" "+tt.name, func(t *testing.T) {
+				t.Parallel()
+				root, service := setup(t)
+
+				action.expect(service, tt.err)
+
+				apitest.New(tt.name).
+					Handler(root.Handler()).
+					Debug().
+					Method(action.method).
+					URL("/emergency/" + string(testID) + action.path).
+					Expect(t).
+					Status(tt.status).
+					End()
+			})
+		}
+	}
+}
+
+func TestGetVault(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{name: "success", status: http.StatusOK},
+		{name: "not found", err: domain.ErrAccessNotFound, status: http.StatusNotFound},
+		{name: "not granted", err: domain.ErrInvalidStatus, status: http.StatusForbidden},
+		{name: "other", err: testutils.Err, status: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			root, service := setup(t)
+
+			var page *secrets.Page[models.Secret]
+			if tt.err == nil {
+				page = &secrets.Page[models.Secret]{
+					Items: []models.Secret{{ID: testSecretID, Name: "db", Type: models.SecretTypePwd}},
+					Total: 1,
+				}
+			}
+
+			service.EXPECT().GetVault(gomock.Any(), testID, testUserID, uint64(10), uint64(0)).Return(page, tt.err)
+
+			test := apitest.New(tt.name).
+				Handler(root.Handler()).
+				Debug().
+				Getf("/emergency/%s/secrets", testID).
+				Query("limit", "10").
+				Expect(t).
+				Status(tt.status)
+
+			if tt.err == nil {
+				test = test.Bodyf(
+					`{"success":true,"result":[{"id":"%s","name":"db","type":"password"}],`+
+						`"pagination":{"offset":0,"limit":10,"total":1}}`,
+					testSecretID,
+				)
+			}
+
+			test.End()
+		})
+	}
+}
+
+func TestDecryptSecret(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{name: "success", status: http.StatusOK},
+		{name: "not found", err: domain.ErrAccessNotFound, status: http.StatusNotFound},
+		{name: "secret not found", err: secrets.ErrSecretNotFound, status: http.StatusNotFound},
+		{name: "not in vault", err: domain.ErrSecretNotInVault, status: http.StatusNotFound},
+		{name: "forbidden", err: domain.ErrForbidden, status: http.StatusForbidden},
+		{name: "not granted", err: domain.ErrInvalidStatus, status: http.StatusForbidden},
+		{name: "invalid passphrase", err: domain.ErrInvalidPassphrase, status: http.StatusConflict},
+		{name: "other", err: testutils.Err, status: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			root, service := setup(t)
+
+			var secret *models.Secret
+			if tt.err == nil {
+				secret = &models.Secret{
+					ID:   testSecretID,
+					Name: "db",
+					Type: models.SecretTypePwd,
+					Data: []byte(`{"login":"admin","password":"secret"}`),
+				}
+			}
+
+			service.EXPECT().GetSecret(gomock.Any(), testID, testUserID, testPassphrase, testSecretID).Return(secret, tt.err)
+
+			test := apitest.New(tt.name).
+				Handler(root.Handler()).
+				Debug().
+				Postf("/emergency/%s/secrets/%s/decrypt", testID, testSecretID).
+				Bodyf(`{"passphrase":"%s"}`, testPassphrase).
+				Expect(t).
+				Status(tt.status)
+
+			if tt.err == nil {
+				test = test.Bodyf(
+					`{"success":true,"result":{"id":"%s","name":"db","type":"password",`+
+						`"data":{"login":"admin","password":"secret"}}}`,
+					testSecretID,
+				)
+			}
+
+			test.End()
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package emergency
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/novoseltcev/passkeeper/internal/domains/emergency"
+)
+
+func AddRoutes(rg *gin.RouterGroup, service emergency.Service, guard gin.HandlerFunc) {
+	emergencyGroup := rg.Group("/emergency", guard)
+	{
+		emergencyGroup.GET("/contacts", GetContacts(service))
+		emergencyGroup.POST("/contacts", Invite(service))
+		emergencyGroup.GET("/grantors", GetGrantors(service))
+		emergencyGroup.POST("/:id/request", Request(service))
+		emergencyGroup.POST("/:id/approve", Approve(service))
+		emergencyGroup.POST("/:id/reject", Reject(service))
+		emergencyGroup.DELETE("/:id", Revoke(service))
+		emergencyGroup.GET("/:id/secrets", GetVault(service))
+		emergencyGroup.POST("/:id/secrets/:secret_id/decrypt", DecryptSecret(service))
+	}
+}
@@ -0,0 +1,64 @@
+package emergency
+
+import (
+	"time"
+
+	"github.com/novoseltcev/passkeeper/internal/models"
+)
+
+type InviteData struct {
+	Passphrase string `binding:"required"`
+	Login      string `binding:"required"`
+	WaitDays   int    `binding:"required,gte=1,lte=90" json:"wait_days"`
+}
+
+type AccessSchema struct {
+	ID       string `json:"id"`
+	Grantor  string `json:"grantor"` // login of the grantor
+	Grantee  string `json:"grantee"` // login of the trusted contact
+	Status   string `json:"status"`
+	WaitDays int    `json:"wait_days"`
+	// RequestedAt is the time of the last request, the access is granted after it plus the wait period.
+	RequestedAt *time.Time `json:"requested_at,omitempty"`
+}
+
+func newAccessSchemas(accesses []models.EmergencyAccess) []AccessSchema {
+	schemas := make([]AccessSchema, len(accesses))
+	for i, access := range accesses {
+		schemas[i] = AccessSchema{
+			ID:       string(access.ID),
+			Grantor:  access.Grantor.Login,
+			Grantee:  access.Grantee.Login,
+			Status:   string(access.Status),
+			WaitDays: access.WaitDays,
+		}
+
+		if !access.RequestedAt.IsZero() {
+			schemas[i].RequestedAt = &access.RequestedAt
+		}
+	}
+
+	return schemas
+}
+
+type PaginationRequest struct {
+	Limit  uint64 `binding:"required,gte=1,lte=100" form:"limit"`
+	Offset uint64 `binding:"gte=0"                  form:"offset"`
+}
+
+type SecretItemSchema struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+type DecryptData struct {
+	Passphrase string `binding:"required"`
+}
+
+type SecretSchema struct {
+	ID   string         `json:"id"`
+	Name string         `json:"name"`
+	Type string         `json:"type"`
+	Data map[string]any `json:"data"`
+}
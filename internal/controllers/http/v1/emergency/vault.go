@@ -0,0 +1,109 @@
+package emergency
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/novoseltcev/passkeeper/internal/auth"
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/common/response"
+	domain "github.com/novoseltcev/passkeeper/internal/domains/emergency"
+	"github.com/novoseltcev/passkeeper/internal/domains/secrets"
+	"github.com/novoseltcev/passkeeper/internal/models"
+)
+
+// GetVault lists the personal secrets of the grantor of a granted access.
+func GetVault(service domain.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		userID := auth.GetUserID(c)
+		id := models.EmergencyID(c.Param("id"))
+
+		var req PaginationRequest
+		if err := c.ShouldBindQuery(&req); err != nil {
+			var vErr validator.ValidationErrors
+			if errors.As(err, &vErr) {
+				c.JSON(http.StatusUnprocessableEntity, response.NewValidationError(vErr))
+			} else {
+				c.JSON(http.StatusBadRequest, response.NewError(err))
+			}
+
+			return
+		}
+
+		page, err := service.GetVault(c, id, userID, req.Limit, req.Offset)
+		if err != nil {
+			if errors.Is(err, domain.ErrAccessNotFound) {
+				c.AbortWithStatus(http.StatusNotFound)
+			} else if errors.Is(err, domain.ErrForbidden) || errors.Is(err, domain.ErrInvalidStatus) {
+				c.AbortWithStatus(http.StatusForbidden)
+			} else {
+				c.AbortWithError(http.StatusInternalServerError, err)
+			}
+
+			return
+		}
+
+		schemas := make([]SecretItemSchema, len(page.Items))
+		for i, secret := range page.Items {
+			schemas[i] = SecretItemSchema{ID: string(secret.ID), Name: secret.Name, Type: secret.Type.String()}
+		}
+
+		c.JSON(http.StatusOK, response.NewPaginated(schemas, req.Limit, req.Offset, page.Total))
+	}
+}
+
+// DecryptSecret decrypts a personal secret of the grantor of a granted access.
+func DecryptSecret(service domain.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		userID := auth.GetUserID(c)
+		id := models.EmergencyID(c.Param("id"))
+		secretID := models.SecretID(c.Param("secret_id"))
+
+		var body DecryptData
+		if err := c.ShouldBindJSON(&body); err != nil {
+			var vErr validator.ValidationErrors
+			if errors.As(err, &vErr) {
+				c.JSON(http.StatusUnprocessableEntity, response.NewValidationError(vErr))
+			} else {
+				c.JSON(http.StatusBadRequest, response.NewError(err))
+			}
+
+			return
+		}
+
+		secret, err := service.GetSecret(c, id, userID, body.Passphrase, secretID)
+		if err != nil {
+			if errors.Is(err, domain.ErrAccessNotFound) ||
+				errors.Is(err, domain.ErrSecretNotInVault) ||
+				errors.Is(err, secrets.ErrSecretNotFound) ||
+				errors.Is(err, secrets.ErrAnotherOwner) {
+				c.AbortWithStatus(http.StatusNotFound)
+			} else if errors.Is(err, domain.ErrForbidden) || errors.Is(err, domain.ErrInvalidStatus) {
+				c.AbortWithStatus(http.StatusForbidden)
+			} else if errors.Is(err, domain.ErrInvalidPassphrase) {
+				c.AbortWithStatus(http.StatusConflict)
+			} else {
+				c.AbortWithError(http.StatusInternalServerError, err)
+			}
+
+			return
+		}
+
+		var data map[string]any
+		if err := json.Unmarshal(secret.Data, &data); err != nil {
+			c.AbortWithError(http.StatusInternalServerError, err)
+
+			return
+		}
+
+		c.JSON(http.StatusOK, response.NewSuccess(&SecretSchema{
+			ID:   string(secret.ID),
+			Name: secret.Name,
+			Type: secret.Type.String(),
+			Data: data,
+		}))
+	}
+}
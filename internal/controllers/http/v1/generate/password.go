@@ -0,0 +1,92 @@
+package generate
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/common/response"
+	"github.com/novoseltcev/passkeeper/pkg/pwgen"
+)
+
+// GeneratePasswordData is a password policy.
+//
+// A diceware-style passphrase is generated when Words is set, a random character password otherwise.
+type GeneratePasswordData struct {
+	Length           int    `binding:"required_without=Words,omitempty,min=4,max=128"`
+	Lower            bool   `binding:""`
+	Upper            bool   `binding:""`
+	Digits           bool   `binding:""`
+	Symbols          bool   `binding:""`
+	ExcludeAmbiguous bool   `binding:""                                               json:"exclude_ambiguous"`
+	MinLower         int    `binding:"min=0"                                          json:"min_lower"`
+	MinUpper         int    `binding:"min=0"                                          json:"min_upper"`
+	MinDigits        int    `binding:"min=0"                                          json:"min_digits"`
+	MinSymbols       int    `binding:"min=0"                                          json:"min_symbols"`
+	Words            int    `binding:"omitempty,min=3,max=20"`
+	Separator        string `binding:"max=3"`
+	Capitalize       bool   `binding:""`
+	Digit            bool   `binding:""`
+}
+
+type PasswordSchema struct {
+	Password string  `json:"password"`
+	Entropy  float64 `json:"entropy"`
+}
+
+func GeneratePassword() func(c *gin.Context) {
+	return func(c *gin.Context) {
+		var body GeneratePasswordData
+		if err := c.ShouldBindJSON(&body); err != nil {
+			var vErr validator.ValidationErrors
+			if errors.As(err, &vErr) {
+				c.JSON(http.StatusUnprocessableEntity, response.NewValidationError(vErr))
+			} else {
+				c.JSON(http.StatusBadRequest, response.NewError(err))
+			}
+
+			return
+		}
+
+		var (
+			result *pwgen.Result
+			err    error
+		)
+
+		if body.Words > 0 {
+			result, err = pwgen.GenerateWords(pwgen.WordsPolicy{
+				Words:      body.Words,
+				Separator:  body.Separator,
+				Capitalize: body.Capitalize,
+				Digit:      body.Digit,
+			})
+		} else {
+			result, err = pwgen.Generate(pwgen.Policy{
+				Length:           body.Length,
+				Lower:            body.Lower,
+				Upper:            body.Upper,
+				Digits:           body.Digits,
+				Symbols:          body.Symbols,
+				ExcludeAmbiguous: body.ExcludeAmbiguous,
+				MinLower:         body.MinLower,
+				MinUpper:         body.MinUpper,
+				MinDigits:        body.MinDigits,
+				MinSymbols:       body.MinSymbols,
+			})
+		}
+
+		if err != nil {
+			if errors.Is(err, pwgen.ErrNoClasses) || errors.Is(err, pwgen.ErrInvalidPolicy) {
+				c.JSON(http.StatusUnprocessableEntity, response.NewError(err))
+			} else {
+				c.AbortWithError(http.StatusInternalServerError, err)
+			}
+
+			return
+		}
+
+		c.JSON(http.StatusOK, response.NewSuccess(&PasswordSchema{Password: result.Password, Entropy: result.Entropy}))
+	}
+}
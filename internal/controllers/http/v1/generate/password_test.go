@@ -0,0 +1,92 @@
+package generate_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/steinfletcher/apitest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/common/response"
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/v1/generate"
+)
+
+func guardMock(c *gin.Context) {
+	c.Next()
+}
+
+func TestGeneratePassword_Success(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		body string
+		len  int
+	}{
+		{name: "chars", body: `{"length":16,"lower":true,"digits":true,"min_digits":4}`, len: 16},
+		{name: "words", body: `{"words":4,"separator":"-"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			root := gin.Default()
+			generate.AddRoutes(&root.RouterGroup, guardMock)
+
+			result := apitest.New(tt.name).
+				Handler(root.Handler()).
+				Debug().
+				Post("/generate/password").
+				Body(tt.body).
+				Expect(t).
+				Status(http.StatusOK).
+				End()
+
+			var schema response.Response[generate.PasswordSchema]
+			result.JSON(&schema)
+
+			require.True(t, schema.Success)
+			assert.Positive(t, schema.Result.Entropy)
+
+			if tt.len > 0 {
+				assert.Len(t, schema.Result.Password, tt.len)
+			}
+		})
+	}
+}
+
+func TestGeneratePassword_Fails(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		body   string
+		status int
+	}{
+		{name: "invalid json", body: `{`, status: http.StatusBadRequest},
+		{name: "no length", body: `{"lower":true}`, status: http.StatusUnprocessableEntity},
+		{name: "too long", body: `{"length":129,"lower":true}`, status: http.StatusUnprocessableEntity},
+		{name: "few words", body: `{"words":2}`, status: http.StatusUnprocessableEntity},
+		{name: "no classes", body: `{"length":16}`, status: http.StatusUnprocessableEntity},
+		{name: "minimums", body: `{"length":4,"lower":true,"min_lower":5}`, status: http.StatusUnprocessableEntity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			root := gin.Default()
+			generate.AddRoutes(&root.RouterGroup, guardMock)
+
+			apitest.New(tt.name).
+				Handler(root.Handler()).
+				Debug().
+				Post("/generate/password").
+				Body(tt.body).
+				Expect(t).
+				Status(tt.status).
+				End()
+		})
+	}
+}
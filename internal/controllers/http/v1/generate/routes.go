@@ -0,0 +1,10 @@
+package generate
+
+import "github.com/gin-gonic/gin"
+
+func AddRoutes(rg *gin.RouterGroup, guard gin.HandlerFunc) {
+	generateGroup := rg.Group("/generate", guard)
+	{
+		generateGroup.POST("/password", GeneratePassword())
+	}
+}
@@ -0,0 +1,80 @@
+package orgs
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/novoseltcev/passkeeper/internal/auth"
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/common/response"
+	domain "github.com/novoseltcev/passkeeper/internal/domains/orgs"
+	"github.com/novoseltcev/passkeeper/internal/models"
+)
+
+// GetInvitations lists the invitations of the user.
+func GetInvitations(service domain.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		userID := auth.GetUserID(c)
+
+		invitations, err := service.GetInvitations(c, userID)
+		if err != nil {
+			c.AbortWithError(http.StatusInternalServerError, err)
+
+			return
+		}
+
+		schemas := make([]InvitationSchema, len(invitations))
+		for i, invitation := range invitations {
+			schemas[i] = InvitationSchema{
+				ID:      string(invitation.ID),
+				OrgID:   string(invitation.Org.ID),
+				OrgName: invitation.Org.Name,
+				Inviter: invitation.Inviter.Login,
+				Role:    string(invitation.Role),
+			}
+		}
+
+		c.JSON(http.StatusOK, response.NewSuccess(&schemas))
+	}
+}
+
+// Accept accepts an invitation of the user.
+func Accept(service domain.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		userID := auth.GetUserID(c)
+		id := models.InvitationID(c.Param("id"))
+
+		if err := service.Accept(c, id, userID); err != nil {
+			if errors.Is(err, domain.ErrInvitationNotFound) {
+				c.AbortWithStatus(http.StatusNotFound)
+			} else {
+				c.AbortWithError(http.StatusInternalServerError, err)
+			}
+
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// Decline declines an invitation of the user.
+func Decline(service domain.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		userID := auth.GetUserID(c)
+		id := models.InvitationID(c.Param("id"))
+
+		if err := service.Decline(c, id, userID); err != nil {
+			if errors.Is(err, domain.ErrInvitationNotFound) {
+				c.AbortWithStatus(http.StatusNotFound)
+			} else {
+				c.AbortWithError(http.StatusInternalServerError, err)
+			}
+
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
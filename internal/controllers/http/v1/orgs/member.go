@@ -0,0 +1,107 @@
+package orgs
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/novoseltcev/passkeeper/internal/auth"
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/common/response"
+	domain "github.com/novoseltcev/passkeeper/internal/domains/orgs"
+	"github.com/novoseltcev/passkeeper/internal/models"
+)
+
+// GetMembers lists the members of an organization.
+func GetMembers(service domain.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		userID := auth.GetUserID(c)
+		id := models.OrgID(c.Param("id"))
+
+		members, err := service.GetMembers(c, id, userID)
+		if err != nil {
+			if errors.Is(err, domain.ErrNotMember) {
+				c.AbortWithStatus(http.StatusForbidden)
+			} else {
+				c.AbortWithError(http.StatusInternalServerError, err)
+			}
+
+			return
+		}
+
+		schemas := make([]MemberSchema, len(members))
+		for i, member := range members {
+			schemas[i] = MemberSchema{
+				UserID: string(member.User.ID),
+				Login:  member.User.Login,
+				Role:   string(member.Role),
+			}
+		}
+
+		c.JSON(http.StatusOK, response.NewSuccess(&schemas))
+	}
+}
+
+// Invite invites a user to an organization.
+func Invite(service domain.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		userID := auth.GetUserID(c)
+		id := models.OrgID(c.Param("id"))
+
+		var body InviteData
+		if err := c.ShouldBindJSON(&body); err != nil {
+			var vErr validator.ValidationErrors
+			if errors.As(err, &vErr) {
+				c.JSON(http.StatusUnprocessableEntity, response.NewValidationError(vErr))
+			} else {
+				c.JSON(http.StatusBadRequest, response.NewError(err))
+			}
+
+			return
+		}
+
+		invitationID, err := service.Invite(c, id, userID, body.Passphrase, body.Login, models.OrgRole(body.Role))
+		if err != nil {
+			if errors.Is(err, domain.ErrNotMember) || errors.Is(err, domain.ErrForbidden) {
+				c.AbortWithStatus(http.StatusForbidden)
+			} else if errors.Is(err, domain.ErrInvalidPassphrase) {
+				c.AbortWithStatus(http.StatusConflict)
+			} else if errors.Is(err, domain.ErrNoPublicKey) || errors.Is(err, domain.ErrAlreadyMember) {
+				c.JSON(http.StatusConflict, response.NewError(err))
+			} else if errors.Is(err, domain.ErrUserNotFound) || errors.Is(err, domain.ErrInvalidRole) {
+				c.JSON(http.StatusUnprocessableEntity, response.NewError(err))
+			} else {
+				c.AbortWithError(http.StatusInternalServerError, err)
+			}
+
+			return
+		}
+
+		c.JSON(http.StatusCreated, response.NewCreate(string(invitationID)))
+	}
+}
+
+// RemoveMember removes a member from an organization, members leave by removing themselves.
+func RemoveMember(service domain.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		userID := auth.GetUserID(c)
+		id := models.OrgID(c.Param("id"))
+		memberID := models.UserID(c.Param("user_id"))
+
+		err := service.RemoveMember(c, id, userID, memberID)
+		if err != nil {
+			if errors.Is(err, domain.ErrMemberNotFound) {
+				c.AbortWithStatus(http.StatusNotFound)
+			} else if errors.Is(err, domain.ErrNotMember) || errors.Is(err, domain.ErrForbidden) {
+				c.AbortWithStatus(http.StatusForbidden)
+			} else {
+				c.AbortWithError(http.StatusInternalServerError, err)
+			}
+
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
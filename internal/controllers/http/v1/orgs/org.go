@@ -0,0 +1,66 @@
+package orgs
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/novoseltcev/passkeeper/internal/auth"
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/common/response"
+	domain "github.com/novoseltcev/passkeeper/internal/domains/orgs"
+)
+
+// Create creates an organization owned by the user.
+func Create(service domain.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		userID := auth.GetUserID(c)
+
+		var body CreateOrgData
+		if err := c.ShouldBindJSON(&body); err != nil {
+			var vErr validator.ValidationErrors
+			if errors.As(err, &vErr) {
+				c.JSON(http.StatusUnprocessableEntity, response.NewValidationError(vErr))
+			} else {
+				c.JSON(http.StatusBadRequest, response.NewError(err))
+			}
+
+			return
+		}
+
+		id, err := service.Create(c, userID, body.Name)
+		if err != nil {
+			if errors.Is(err, domain.ErrNoPublicKey) {
+				c.JSON(http.StatusConflict, response.NewError(err))
+			} else {
+				c.AbortWithError(http.StatusInternalServerError, err)
+			}
+
+			return
+		}
+
+		c.JSON(http.StatusCreated, response.NewCreate(string(id)))
+	}
+}
+
+// GetAll lists the organizations of the user.
+func GetAll(service domain.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		userID := auth.GetUserID(c)
+
+		members, err := service.GetAll(c, userID)
+		if err != nil {
+			c.AbortWithError(http.StatusInternalServerError, err)
+
+			return
+		}
+
+		schemas := make([]OrgSchema, len(members))
+		for i, member := range members {
+			schemas[i] = OrgSchema{ID: string(member.Org.ID), Name: member.Org.Name, Role: string(member.Role)}
+		}
+
+		c.JSON(http.StatusOK, response.NewSuccess(&schemas))
+	}
+}
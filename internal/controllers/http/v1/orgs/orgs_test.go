@@ -0,0 +1,306 @@
+package orgs_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/steinfletcher/apitest"
+	"go.uber.org/mock/gomock"
+
+	"github.com/novoseltcev/passkeeper/internal/auth"
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/v1/orgs"
+	domain "github.com/novoseltcev/passkeeper/internal/domains/orgs"
+	"github.com/novoseltcev/passkeeper/internal/domains/orgs/mocks"
+	"github.com/novoseltcev/passkeeper/internal/models"
+	"github.com/novoseltcev/passkeeper/pkg/testutils"
+)
+
+const (
+	testUserID       = models.UserID("f535204f-9283-4c1a-8e68-8834c6ae83fb")
+	testMemberID     = models.UserID("08108e22-a2d8-4ce7-abbb-13d91dacc758")
+	testID           = models.OrgID("5c2f9f8e-3d1a-4b7e-9f0c-6a1d2e3f4a5b")
+	testInvitationID = models.InvitationID("c1d2e3f4-a5b6-4c7d-8e9f-0a1b2c3d4e5f")
+	testName         = "acme"
+	testPassphrase   = "passphrase"
+	testLogin        = "test@test.com"
+)
+
+func guardMock(c *gin.Context) {
+	c.Set(auth.IdentityKey, string(testUserID))
+	c.Next()
+}
+
+func setup(t *testing.T) (*gin.Engine, *mocks.MockService) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	root := gin.Default()
+	service := mocks.NewMockService(ctrl)
+	orgs.AddRoutes(&root.RouterGroup, service, guardMock)
+
+	return root, service
+}
+
+func TestCreate_Success(t *testing.T) {
+	t.Parallel()
+	root, service := setup(t)
+
+	service.EXPECT().Create(gomock.Any(), testUserID, testName).Return(testID, nil)
+
+	apitest.Handler(root.Handler()).
+		Debug().
+		Post("/orgs").
+		Bodyf(`{"name":"%s"}`, testName).
+		Expect(t).
+		Status(http.StatusCreated).
+		Bodyf(`{"success":true,"result":{"id":"%s"}}`, testID).
+		End()
+}
+
+func TestCreate_Fails(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		body   string
+		err    error
+		status int
+	}{
+		{name: "no name", body: `{}`, status: http.StatusUnprocessableEntity},
+		{name: "invalid json", body: `{`, status: http.StatusBadRequest},
+		{name: "no public key", body: `{"name":"acme"}`, err: domain.ErrNoPublicKey, status: http.StatusConflict},
+		{name: "other", body: `{"name":"acme"}`, err: testutils.Err, status: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			root, service := setup(t)
+
+			if tt.err != nil {
+				service.EXPECT().Create(gomock.Any(), testUserID, testName).Return(models.OrgID(""), tt.err)
+			}
+
+			apitest.New(tt.name).
+				Handler(root.Handler()).
+				Debug().
+				Post("/orgs").
+				Body(tt.body).
+				Expect(t).
+				Status(tt.status).
+				End()
+		})
+	}
+}
+
+func TestGetAll_Success(t *testing.T) {
+	t.Parallel()
+	root, service := setup(t)
+
+	service.EXPECT().
+		GetAll(gomock.Any(), testUserID).
+		Return([]models.Member{{Org: &models.Org{ID: testID, Name: testName}, Role: models.OrgRoleAdmin}}, nil)
+
+	apitest.Handler(root.Handler()).
+		Debug().
+		Get("/orgs").
+		Expect(t).
+		Status(http.StatusOK).
+		Bodyf(`{"success":true,"result":[{"id":"%s","name":"%s","role":"admin"}]}`, testID, testName).
+		End()
+}
+
+func TestGetMembers(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{name: "success", status: http.StatusOK},
+		{name: "not member", err: domain.ErrNotMember, status: http.StatusForbidden},
+		{name: "other", err: testutils.Err, status: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			root, service := setup(t)
+
+			var members []models.Member
+			if tt.err == nil {
+				members = []models.Member{{User: &models.User{ID: testMemberID, Login: testLogin}, Role: models.OrgRoleViewer}}
+			}
+
+			service.EXPECT().GetMembers(gomock.Any(), testID, testUserID).Return(members, tt.err)
+
+			test := apitest.New(tt.name).
+				Handler(root.Handler()).
+				Debug().
+				Getf("/orgs/%s/members", testID).
+				Expect(t).
+				Status(tt.status)
+
+			if tt.err == nil {
+				test = test.Bodyf(
+					`{"success":true,"result":[{"user_id":"%s","login":"%s","role":"viewer"}]}`, testMemberID, testLogin,
+				)
+			}
+
+			test.End()
+		})
+	}
+}
+
+func TestInvite(t *testing.T) {
+	t.Parallel()
+
+	body := `{"passphrase":"passphrase","login":"test@test.com","role":"editor"}`
+
+	tests := []struct {
+		name   string
+		body   string
+		err    error
+		status int
+	}{
+		{name: "success", body: body, status: http.StatusCreated},
+		{name: "invalid role", body: `{"passphrase":"p","login":"l","role":"root"}`, status: http.StatusUnprocessableEntity},
+		{name: "invalid json", body: `{`, status: http.StatusBadRequest},
+		{name: "not member", body: body, err: domain.ErrNotMember, status: http.StatusForbidden},
+		{name: "forbidden", body: body, err: domain.ErrForbidden, status: http.StatusForbidden},
+		{name: "invalid passphrase", body: body, err: domain.ErrInvalidPassphrase, status: http.StatusConflict},
+		{name: "no public key", body: body, err: domain.ErrNoPublicKey, status: http.StatusConflict},
+		{name: "already member", body: body, err: domain.ErrAlreadyMember, status: http.StatusConflict},
+		{name: "user not found", body: body, err: domain.ErrUserNotFound, status: http.StatusUnprocessableEntity},
+		{name: "other", body: body, err: testutils.Err, status: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			root, service := setup(t)
+
+			if tt.body == body {
+				service.EXPECT().
+					Invite(gomock.Any(), testID, testUserID, testPassphrase, testLogin, models.OrgRoleEditor).
+					Return(testInvitationID, tt.err)
+			}
+
+			apitest.New(tt.name).
+				Handler(root.Handler()).
+				Debug().
+				Postf("/orgs/%s/invitations", testID).
+				Body(tt.body).
+				Expect(t).
+				Status(tt.status).
+				End()
+		})
+	}
+}
+
+func TestRemoveMember(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{name: "success", status: http.StatusNoContent},
+		{name: "member not found", err: domain.ErrMemberNotFound, status: http.StatusNotFound},
+		{name: "not member", err: domain.ErrNotMember, status: http.StatusForbidden},
+		{name: "forbidden", err: domain.ErrForbidden, status: http.StatusForbidden},
+		{name: "other", err: testutils.Err, status: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			root, service := setup(t)
+
+			service.EXPECT().RemoveMember(gomock.Any(), testID, testUserID, testMemberID).Return(tt.err)
+
+			apitest.New(tt.name).
+				Handler(root.Handler()).
+				Debug().
+				Deletef("/orgs/%s/members/%s", testID, testMemberID).
+				Expect(t).
+				Status(tt.status).
+				End()
+		})
+	}
+}
+
+func TestGetInvitations_Success(t *testing.T) {
+	t.Parallel()
+	root, service := setup(t)
+
+	service.EXPECT().
+		GetInvitations(gomock.Any(), testUserID).
+		Return([]models.Invitation{{
+			ID:      testInvitationID,
+			Org:     &models.Org{ID: testID, Name: testName},
+			Inviter: &models.User{Login: testLogin},
+			Role:    models.OrgRoleViewer,
+		}}, nil)
+
+	apitest.Handler(root.Handler()).
+		Debug().
+		Get("/invitations").
+		Expect(t).
+		Status(http.StatusOK).
+		Bodyf(
+			`{"success":true,"result":[{"id":"%s","org_id":"%s","org_name":"%s","inviter":"%s","role":"viewer"}]}`,
+			testInvitationID, testID, testName, testLogin,
+		).
+		End()
+}
+
+func TestAcceptDecline(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{name: "success", status: http.StatusNoContent},
+		{name: "not found", err: domain.ErrInvitationNotFound, status: http.StatusNotFound},
+		{name: "other", err: testutils.Err, status: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run("accept "+tt.name, func(t *testing.T) {
+			t.Parallel()
+			root, service := setup(t)
+
+			service.EXPECT().Accept(gomock.Any(), testInvitationID, testUserID).Return(tt.err)
+
+			apitest.New(tt.name).
+				Handler(root.Handler()).
+				Debug().
+				Postf("/invitations/%s/accept", testInvitationID).
+				Expect(t).
+				Status(tt.status).
+				End()
+		})
+
+		t.Run("decline "+tt.name, func(t *testing.T) {
+			t.Parallel()
+			root, service := setup(t)
+
+			service.EXPECT().Decline(gomock.Any(), testInvitationID, testUserID).Return(tt.err)
+
+			apitest.New(tt.name).
+				Handler(root.Handler()).
+				Debug().
+				Postf("/invitations/%s/decline", testInvitationID).
+				Expect(t).
+				Status(tt.status).
+				End()
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package orgs
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/novoseltcev/passkeeper/internal/domains/orgs"
+)
+
+func AddRoutes(rg *gin.RouterGroup, service orgs.Service, guard gin.HandlerFunc) {
+	orgGroup := rg.Group("/orgs", guard)
+	{
+		orgGroup.GET("", GetAll(service))
+		orgGroup.POST("", Create(service))
+		orgGroup.GET("/:id/members", GetMembers(service))
+		orgGroup.DELETE("/:id/members/:user_id", RemoveMember(service))
+		orgGroup.POST("/:id/invitations", Invite(service))
+	}
+
+	invitationGroup := rg.Group("/invitations", guard)
+	{
+		invitationGroup.GET("", GetInvitations(service))
+		invitationGroup.POST("/:id/accept", Accept(service))
+		invitationGroup.POST("/:id/decline", Decline(service))
+	}
+}
@@ -0,0 +1,31 @@
+package orgs
+
+type CreateOrgData struct {
+	Name string `binding:"required,min=1,max=64"`
+}
+
+type OrgSchema struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Role string `json:"role"` // role of the user in the organization
+}
+
+type MemberSchema struct {
+	UserID string `json:"user_id"`
+	Login  string `json:"login"`
+	Role   string `json:"role"`
+}
+
+type InviteData struct {
+	Passphrase string `binding:"required"`
+	Login      string `binding:"required"`
+	Role       string `binding:"required,oneof=owner admin editor viewer"`
+}
+
+type InvitationSchema struct {
+	ID      string `json:"id"`
+	OrgID   string `json:"org_id"`
+	OrgName string `json:"org_name"`
+	Inviter string `json:"inviter"` // login of the inviter
+	Role    string `json:"role"`
+}
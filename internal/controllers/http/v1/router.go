@@ -3,9 +3,18 @@ package v1
 import (
 	"github.com/gin-gonic/gin"
 
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/v1/emergency"
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/v1/generate"
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/v1/orgs"
 	"github.com/novoseltcev/passkeeper/internal/controllers/http/v1/secrets"
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/v1/sends"
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/v1/templates"
 	"github.com/novoseltcev/passkeeper/internal/controllers/http/v1/user"
+	emergencydomain "github.com/novoseltcev/passkeeper/internal/domains/emergency"
+	orgsdomain "github.com/novoseltcev/passkeeper/internal/domains/orgs"
 	secretsdomain "github.com/novoseltcev/passkeeper/internal/domains/secrets"
+	sendsdomain "github.com/novoseltcev/passkeeper/internal/domains/sends"
+	templatesdomain "github.com/novoseltcev/passkeeper/internal/domains/templates"
 	userdomain "github.com/novoseltcev/passkeeper/internal/domains/user"
 	"github.com/novoseltcev/passkeeper/pkg/jwtmanager"
 )
@@ -15,8 +24,17 @@ func AddRoutes(
 	jwt jwtmanager.Manager,
 	guard gin.HandlerFunc,
 	secretService secretsdomain.Service,
+	templateService templatesdomain.Service,
 	userService userdomain.Service,
+	orgService orgsdomain.Service,
+	sendService sendsdomain.Service,
+	emergencyService emergencydomain.Service,
 ) {
 	secrets.AddRoutes(rg, secretService, guard)
+	templates.AddRoutes(rg, templateService, guard)
+	generate.AddRoutes(rg, guard)
 	user.AddRoutes(rg, userService, jwt, guard)
+	orgs.AddRoutes(rg, orgService, guard)
+	sends.AddRoutes(rg, sendService, guard)
+	emergency.AddRoutes(rg, emergencyService, guard)
 }
@@ -13,84 +13,62 @@ import (
 	"github.com/novoseltcev/passkeeper/internal/models"
 )
 
-func addSecret[T any](
-	c *gin.Context,
-	fn func(c *gin.Context, ownerID models.UserID, body *T) (models.SecretID, error),
-) {
-	ownerID := auth.GetUserID(c)
+// Add creates a secret of the registered type.
+func Add(service domain.Service, t *Type) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ownerID := auth.GetUserID(c)
 
-	var body T
+		body := t.New()
+		if err := c.ShouldBindJSON(body); err != nil {
+			var vErr validator.ValidationErrors
+			if errors.As(err, &vErr) {
+				c.JSON(http.StatusUnprocessableEntity, response.NewValidationError(vErr))
+			} else {
+				c.JSON(http.StatusBadRequest, response.NewError(err))
+			}
 
-	if err := c.ShouldBindJSON(&body); err != nil {
-		var vErr validator.ValidationErrors
-		if errors.As(err, &vErr) {
-			c.JSON(http.StatusUnprocessableEntity, response.NewValidationError(vErr))
-		} else {
-			c.JSON(http.StatusBadRequest, response.NewError(err))
+			return
 		}
 
-		return
-	}
+		id, warnings, err := create(c, service, ownerID, body)
+		if err != nil {
+			if errors.Is(err, domain.ErrInvalidPassphrase) {
+				c.AbortWithStatus(http.StatusConflict)
+			} else if errors.Is(err, domain.ErrInvalidSecretData) || errors.Is(err, domain.ErrTemplateNotFound) {
+				c.JSON(http.StatusUnprocessableEntity, response.NewError(err))
+			} else if errors.Is(err, domain.ErrSecretTooLarge) {
+				c.JSON(http.StatusRequestEntityTooLarge, response.NewError(err))
+			} else if errors.Is(err, domain.ErrQuotaExceeded) {
+				c.JSON(http.StatusInsufficientStorage, response.NewError(err))
+			} else {
+				c.AbortWithError(http.StatusInternalServerError, err)
+			}
 
-	id, err := fn(c, ownerID, &body)
-	if err != nil {
-		if errors.Is(err, domain.ErrInvalidPassphrase) {
-			c.AbortWithStatus(http.StatusConflict)
-		} else {
-			c.AbortWithError(http.StatusInternalServerError, err)
+			return
 		}
 
-		return
+		c.JSON(http.StatusCreated, response.NewCreate(string(id)).WithWarnings(newWarnings(warnings)...))
 	}
-
-	c.JSON(http.StatusCreated, response.NewCreate(string(id)))
 }
 
-func AddPassword(service domain.Service) func(c *gin.Context) {
-	return func(c *gin.Context) {
-		addSecret(c, func(c *gin.Context, ownerID models.UserID, body *PasswordSecretData) (models.SecretID, error) {
-			return service.Create(c, ownerID, body.Passphrase, body.Name, &domain.PasswordData{
-				Login:    body.Login,
-				Password: body.Password,
-				Meta:     body.Meta,
-			})
-		})
+func create(
+	c *gin.Context, service domain.Service, ownerID models.UserID, body Payload,
+) (models.SecretID, []domain.Warning, error) {
+	data, err := body.ToData()
+	if err != nil {
+		return "", nil, err
 	}
-}
 
-func AddCard(service domain.Service) func(c *gin.Context) {
-	return func(c *gin.Context) {
-		addSecret(c, func(c *gin.Context, ownerID models.UserID, body *CardSecretData) (models.SecretID, error) {
-			return service.Create(c, ownerID, body.Passphrase, body.Name, &domain.CardData{
-				Number: body.Number,
-				Holder: body.Holder,
-				Exp:    body.Exp,
-				CVV:    body.CVV,
-				Meta:   body.Meta,
-			})
-		})
-	}
-}
+	passphrase, name := body.Credentials()
 
-func AddText(service domain.Service) func(c *gin.Context) {
-	return func(c *gin.Context) {
-		addSecret(c, func(c *gin.Context, ownerID models.UserID, body *TextSecretData) (models.SecretID, error) {
-			return service.Create(c, ownerID, body.Passphrase, body.Name, &domain.TextData{
-				Content: body.Content,
-				Meta:    body.Meta,
-			})
-		})
-	}
+	return service.Create(c, ownerID, passphrase, name, data, body.Attrs())
 }
 
-func AddFile(service domain.Service) func(c *gin.Context) {
-	return func(c *gin.Context) {
-		addSecret(c, func(c *gin.Context, ownerID models.UserID, body *FileSecretData) (models.SecretID, error) {
-			return service.Create(c, ownerID, body.Passphrase, body.Name, &domain.FileData{
-				Filename: body.Filename,
-				Content:  body.Content,
-				Meta:     body.Meta,
-			})
-		})
+func newWarnings(warnings []domain.Warning) []response.Warning {
+	result := make([]response.Warning, len(warnings))
+	for i, w := range warnings {
+		result[i] = response.Warning{Code: w.Code, Message: w.Message}
 	}
+
+	return result
 }
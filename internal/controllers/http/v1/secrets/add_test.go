@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/steinfletcher/apitest"
@@ -17,6 +18,7 @@ import (
 	"github.com/novoseltcev/passkeeper/internal/domains/secrets/mocks"
 	"github.com/novoseltcev/passkeeper/internal/models"
 	"github.com/novoseltcev/passkeeper/pkg/testutils"
+	"github.com/novoseltcev/passkeeper/pkg/urimatch"
 )
 
 var (
@@ -26,8 +28,12 @@ var (
 	testMeta       = `{"key":"value"}`
 	testCard       = "4111111111111111"
 	testHolder     = "John Doe"
-	testExp        = "12/25"
+	testExp        = "12/45"
 	testCVV        = "123"
+	testOTPSecret  = "JBSWY3DPEHPK3PXP"
+	testPrivateKey = "private-key"
+	testPublicKey  = "ssh-ed25519 AAAA"
+	testTemplateID = models.TemplateID("3f0b5a52-0a4c-4c1e-9a55-2f6d3b8a9c11")
 )
 
 var testMetaMap = map[string]any{"key": "value"}
@@ -58,8 +64,8 @@ func TestAdd(t *testing.T) {
 				Login:    testLogin,
 				Password: testPassword,
 				Meta:     testMetaMap,
-			}).
-			Return(testID, nil)
+			}, domain.Attrs{}).
+			Return(testID, nil, nil)
 
 		apitest.Handler(root.Handler()).
 			Debug().
@@ -78,6 +84,63 @@ func TestAdd(t *testing.T) {
 			End()
 	})
 
+	t.Run("password with uris", func(t *testing.T) {
+		t.Parallel()
+		root := gin.Default()
+		service := mocks.NewMockService(ctrl)
+		secrets.AddRoutes(&root.RouterGroup, service, guardMock)
+
+		service.EXPECT().
+			Create(gomock.Any(), testOwnerID, testPassphrase, testName, &domain.PasswordData{
+				Login:    testLogin,
+				Password: testPassword,
+				URIs: []domain.URI{
+					{URI: "github.com"},
+					{URI: "https://example.com/app", Match: urimatch.Prefix},
+				},
+				Meta: testMetaMap,
+			}, domain.Attrs{}).
+			Return(testID, nil, nil)
+
+		apitest.Handler(root.Handler()).
+			Debug().
+			Post("/secrets/password").
+			Bodyf(`
+			{
+				"passphrase":"%s",
+				"name":"%s",
+				"login":"%s",
+				"password":"%s",
+				"uris":[{"uri":"github.com"},{"uri":"https://example.com/app","match":"prefix"}],
+				"meta":%s
+			}`, testPassphrase, testName, testLogin, testPassword, testMeta).
+			Expect(t).
+			Status(http.StatusCreated).
+			End()
+	})
+
+	t.Run("password with invalid uri match", func(t *testing.T) {
+		t.Parallel()
+		root := gin.Default()
+		secrets.AddRoutes(&root.RouterGroup, mocks.NewMockService(ctrl), guardMock)
+
+		apitest.Handler(root.Handler()).
+			Debug().
+			Post("/secrets/password").
+			Bodyf(`
+			{
+				"passphrase":"%s",
+				"name":"%s",
+				"login":"%s",
+				"password":"%s",
+				"uris":[{"uri":"github.com","match":"exact"}],
+				"meta":%s
+			}`, testPassphrase, testName, testLogin, testPassword, testMeta).
+			Expect(t).
+			Status(http.StatusUnprocessableEntity).
+			End()
+	})
+
 	t.Run("card", func(t *testing.T) {
 		t.Parallel()
 		root := gin.Default()
@@ -91,8 +154,8 @@ func TestAdd(t *testing.T) {
 				Exp:    testExp,
 				CVV:    testCVV,
 				Meta:   testMetaMap,
-			}).
-			Return(testID, nil)
+			}, domain.Attrs{}).
+			Return(testID, nil, nil)
 
 		apitest.Handler(root.Handler()).
 			Debug().
@@ -123,8 +186,8 @@ func TestAdd(t *testing.T) {
 			Create(gomock.Any(), testOwnerID, testPassphrase, testName, &domain.TextData{
 				Content: testutils.STRING,
 				Meta:    testMetaMap,
-			}).
-			Return(testID, nil)
+			}, domain.Attrs{}).
+			Return(testID, nil, nil)
 
 		apitest.Handler(root.Handler()).
 			Debug().
@@ -153,8 +216,8 @@ func TestAdd(t *testing.T) {
 				Filename: testutils.STRING,
 				Content:  testHex,
 				Meta:     testMetaMap,
-			}).
-			Return(testID, nil)
+			}, domain.Attrs{}).
+			Return(testID, nil, nil)
 
 		apitest.Handler(root.Handler()).
 			Debug().
@@ -172,6 +235,105 @@ func TestAdd(t *testing.T) {
 			Bodyf(`{"success":true, "result": {"id":"%s"}}`, testID).
 			End()
 	})
+	t.Run("otp", func(t *testing.T) {
+		t.Parallel()
+		root := gin.Default()
+		service := mocks.NewMockService(ctrl)
+		secrets.AddRoutes(&root.RouterGroup, service, guardMock)
+
+		service.EXPECT().
+			Create(gomock.Any(), testOwnerID, testPassphrase, testName, &domain.OTPData{
+				Kind:      "totp",
+				Secret:    testOTPSecret,
+				Algorithm: "SHA256",
+				Digits:    8,
+				Period:    30,
+				Issuer:    "ACME",
+				Account:   "john",
+				Meta:      testMetaMap,
+			}, domain.Attrs{}).
+			Return(testID, nil, nil)
+
+		apitest.Handler(root.Handler()).
+			Debug().
+			Post("/secrets/otp").
+			Bodyf(`
+			{
+				"passphrase":"%s",
+				"name":"%s",
+				"uri":"otpauth://totp/ACME:john?secret=%s&algorithm=SHA256",
+				"digits":8,
+				"meta":%s
+			}`, testPassphrase, testName, testOTPSecret, testMeta).
+			Expect(t).
+			Status(http.StatusCreated).
+			Bodyf(`{"success":true, "result": {"id":"%s"}}`, testID).
+			End()
+	})
+
+	t.Run("ssh_key", func(t *testing.T) {
+		t.Parallel()
+		root := gin.Default()
+		service := mocks.NewMockService(ctrl)
+		secrets.AddRoutes(&root.RouterGroup, service, guardMock)
+
+		service.EXPECT().
+			Create(gomock.Any(), testOwnerID, testPassphrase, testName, &domain.SSHKeyData{
+				PrivateKey: testPrivateKey,
+				PublicKey:  testPublicKey,
+				Comment:    testutils.STRING,
+				Meta:       testMetaMap,
+			}, domain.Attrs{}).
+			Return(testID, nil, nil)
+
+		apitest.Handler(root.Handler()).
+			Debug().
+			Post("/secrets/ssh_key").
+			Bodyf(`
+			{
+				"passphrase":"%s",
+				"name":"%s",
+				"private_key":"%s",
+				"public_key":"%s",
+				"comment":"%s",
+				"meta":%s
+			}`, testPassphrase, testName, testPrivateKey, testPublicKey, testutils.STRING, testMeta).
+			Expect(t).
+			Status(http.StatusCreated).
+			Bodyf(`{"success":true, "result": {"id":"%s"}}`, testID).
+			End()
+	})
+
+	t.Run("custom", func(t *testing.T) {
+		t.Parallel()
+		root := gin.Default()
+		service := mocks.NewMockService(ctrl)
+		secrets.AddRoutes(&root.RouterGroup, service, guardMock)
+
+		service.EXPECT().
+			Create(gomock.Any(), testOwnerID, testPassphrase, testName, &domain.CustomData{
+				Template: testTemplateID,
+				Fields:   map[string]string{"ssid": testutils.STRING},
+				Meta:     testMetaMap,
+			}, domain.Attrs{}).
+			Return(testID, nil, nil)
+
+		apitest.Handler(root.Handler()).
+			Debug().
+			Post("/secrets/custom").
+			Bodyf(`
+			{
+				"passphrase":"%s",
+				"name":"%s",
+				"template":"%s",
+				"fields":{"ssid":"%s"},
+				"meta":%s
+			}`, testPassphrase, testName, testTemplateID, testutils.STRING, testMeta).
+			Expect(t).
+			Status(http.StatusCreated).
+			Bodyf(`{"success":true, "result": {"id":"%s"}}`, testID).
+			End()
+	})
 }
 
 var defaultAddData = map[models.SecretType]string{
@@ -189,7 +351,7 @@ var defaultAddData = map[models.SecretType]string{
 		"name":"test",
 		"number":"4111111111111111",
 		"holder":"John Doe",
-		"exp":"12/25",
+		"exp":"12/45",
 		"cvv":"123",
 		"meta":{}
 	}`,
@@ -208,6 +370,29 @@ var defaultAddData = map[models.SecretType]string{
 		"content":"74657374",
 		"meta":{}
 	}`,
+	models.SecretTypeOTP: `
+	{
+		"passphrase":"passphrase",
+		"name":"test",
+		"uri":"otpauth://totp/ACME:john?secret=JBSWY3DPEHPK3PXP",
+		"meta":{}
+	}`,
+	models.SecretTypeSSHKey: `
+	{
+		"passphrase":"passphrase",
+		"name":"test",
+		"private_key":"private",
+		"public_key":"public",
+		"meta":{}
+	}`,
+	models.SecretTypeCustom: `
+	{
+		"passphrase":"passphrase",
+		"name":"test",
+		"template":"3f0b5a52-0a4c-4c1e-9a55-2f6d3b8a9c11",
+		"fields":{"ssid":"home"},
+		"meta":{}
+	}`,
 }
 
 func TestAdd_Fails_Create(t *testing.T) {
@@ -219,12 +404,29 @@ func TestAdd_Fails_Create(t *testing.T) {
 		name   string
 		err    error
 		status int
+		errs   []string
 	}{
 		{
 			name:   "invalid passphrase",
 			err:    domain.ErrInvalidPassphrase,
 			status: http.StatusConflict,
 		},
+		{
+			name:   "template not found",
+			err:    domain.ErrTemplateNotFound,
+			status: http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "secret too large",
+			err:    domain.ErrSecretTooLarge,
+			status: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:   "quota exceeded",
+			err:    domain.ErrQuotaExceeded,
+			status: http.StatusInsufficientStorage,
+			errs:   []string{domain.ErrQuotaExceeded.Error()},
+		},
 		{
 			name:   "other",
 			err:    testutils.Err,
@@ -242,10 +444,10 @@ func TestAdd_Fails_Create(t *testing.T) {
 				secrets.AddRoutes(&root.RouterGroup, service, guardMock)
 
 				service.EXPECT().
-					Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-					Return("", tt.err)
+					Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), domain.Attrs{}).
+					Return("", nil, tt.err)
 
-				apitest.New(testName).
+				result := apitest.New(testName).
 					Handler(root.Handler()).
 					Debug().
 					Postf("/secrets/%s", secretType.String()).
@@ -253,6 +455,10 @@ func TestAdd_Fails_Create(t *testing.T) {
 					Expect(t).
 					Status(tt.status).
 					End()
+
+				if len(tt.errs) > 0 {
+					checkErrors(t, result, tt.errs)
+				}
 			})
 		}
 	}
@@ -477,3 +683,160 @@ func TestAddFile_Fails_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestAddOTP_Fails_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		body   string
+		status int
+		errs   []string
+	}{
+		{
+			name:   "empty",
+			body:   `{}`,
+			status: http.StatusUnprocessableEntity,
+			errs: []string{
+				"Field validation for 'Passphrase' failed on the 'required' tag",
+				"Field validation for 'Name' failed on the 'required' tag",
+				"Field validation for 'URI' failed on the 'required_without' tag",
+				"Field validation for 'Secret' failed on the 'required_without' tag",
+				"Field validation for 'Meta' failed on the 'required' tag",
+			},
+		},
+		{
+			name:   "unsupported uri parameters",
+			body:   `{"passphrase":"p","name":"test","uri":"otpauth://totp/x?secret=JBSWY3DPEHPK3PXP&digits=4","meta":{}}`,
+			status: http.StatusUnprocessableEntity,
+			errs:   []string{"invalid secret data: invalid digits: 4"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			root := gin.Default()
+			secrets.AddRoutes(&root.RouterGroup, nil, guardMock)
+
+			result := apitest.New(tt.name).
+				Handler(root.Handler()).
+				Debug().
+				Post("/secrets/otp").
+				Body(tt.body).
+				Expect(t).
+				Status(tt.status).
+				End()
+
+			checkErrors(t, result, tt.errs)
+		})
+	}
+}
+
+func TestAdd_Warnings(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	root := gin.Default()
+	service := mocks.NewMockService(ctrl)
+	secrets.AddRoutes(&root.RouterGroup, service, guardMock)
+
+	service.EXPECT().
+		Create(gomock.Any(), testOwnerID, testPassphrase, testName, gomock.Any(), domain.Attrs{}).
+		Return(testID, []domain.Warning{{Code: domain.WarningBreached, Message: "breached"}}, nil)
+
+	apitest.Handler(root.Handler()).
+		Debug().
+		Post("/secrets/password").
+		Bodyf(`
+		{
+			"passphrase":"%s",
+			"name":"%s",
+			"login":"%s",
+			"password":"%s",
+			"meta":%s
+		}`, testPassphrase, testName, testLogin, testPassword, testMeta).
+		Expect(t).
+		Status(http.StatusCreated).
+		Bodyf(`{
+			"success":true,
+			"warnings":[{"code":"breached","message":"breached"}],
+			"result":{"id":"%s"}
+		}`, testID).
+		End()
+}
+
+// The expired cards are checked by the domain only, which saves them with a warning.
+func TestAddCard_Expired(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	root := gin.Default()
+	service := mocks.NewMockService(ctrl)
+	secrets.AddRoutes(&root.RouterGroup, service, guardMock)
+
+	service.EXPECT().
+		Create(gomock.Any(), testOwnerID, testPassphrase, testName, &domain.CardData{
+			Number: "4111111111111111",
+			Exp:    "01/20",
+			CVV:    testCVV,
+			Meta:   testMetaMap,
+		}, domain.Attrs{}).
+		Return(testID, []domain.Warning{{Code: domain.WarningExpired, Message: "the card is expired"}}, nil)
+
+	apitest.Handler(root.Handler()).
+		Debug().
+		Post("/secrets/card").
+		Bodyf(`
+		{
+			"passphrase":"%s",
+			"name":"%s",
+			"number":"4111 1111 1111 1111",
+			"exp":"01/20",
+			"cvv":"%s",
+			"meta":%s
+		}`, testPassphrase, testName, testCVV, testMeta).
+		Expect(t).
+		Status(http.StatusCreated).
+		Bodyf(`{
+			"success":true,
+			"warnings":[{"code":"expired","message":"the card is expired"}],
+			"result":{"id":"%s"}
+		}`, testID).
+		End()
+}
+
+func TestAdd_Attrs(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	root := gin.Default()
+	service := mocks.NewMockService(ctrl)
+	secrets.AddRoutes(&root.RouterGroup, service, guardMock)
+
+	service.EXPECT().
+		Create(gomock.Any(), testOwnerID, testPassphrase, testName, gomock.Any(), domain.Attrs{
+			ExpiresAt:   time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC),
+			RotateEvery: 90 * 24 * time.Hour,
+		}).
+		Return(testID, nil, nil)
+
+	apitest.Handler(root.Handler()).
+		Debug().
+		Post("/secrets/text").
+		Bodyf(`
+		{
+			"passphrase":"%s",
+			"name":"%s",
+			"content":"content",
+			"meta":%s,
+			"expires_at":"2030-01-01T03:00:00+03:00",
+			"rotate_every_days":90
+		}`, testPassphrase, testName, testMeta).
+		Expect(t).
+		Status(http.StatusCreated).
+		End()
+}
@@ -0,0 +1,42 @@
+package secrets
+
+import (
+	"time"
+
+	domain "github.com/novoseltcev/passkeeper/internal/domains/secrets"
+	"github.com/novoseltcev/passkeeper/internal/models"
+)
+
+const day = 24 * time.Hour
+
+// AttrsData are the optional attributes of a secret common to all types.
+//
+// The expiration of cards is taken from the card itself.
+// The organization is used on creation only, secrets are not moved between vaults.
+type AttrsData struct {
+	ExpiresAt       *time.Time `binding:""                form:"expires_at"        json:"expires_at,omitempty"`
+	RotateEveryDays int        `binding:"omitempty,min=1" form:"rotate_every_days" json:"rotate_every_days,omitempty"`
+	OrgID           string     `binding:"omitempty,uuid"  form:"org_id"            json:"org_id,omitempty"`
+}
+
+func (d *AttrsData) Attrs() domain.Attrs {
+	attrs := domain.Attrs{RotateEvery: time.Duration(d.RotateEveryDays) * day, OrgID: models.OrgID(d.OrgID)}
+	if d.ExpiresAt != nil {
+		attrs.ExpiresAt = d.ExpiresAt.UTC()
+	}
+
+	return attrs
+}
+
+func newAttrsData(secret *models.Secret) AttrsData {
+	data := AttrsData{RotateEveryDays: int(secret.RotateEvery / day)}
+	if !secret.ExpiresAt.IsZero() {
+		data.ExpiresAt = &secret.ExpiresAt
+	}
+
+	if secret.Org != nil {
+		data.OrgID = string(secret.Org.ID)
+	}
+
+	return data
+}
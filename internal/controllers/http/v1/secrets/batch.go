@@ -0,0 +1,238 @@
+package secrets
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/novoseltcev/passkeeper/internal/auth"
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/common/response"
+	domain "github.com/novoseltcev/passkeeper/internal/domains/secrets"
+	"github.com/novoseltcev/passkeeper/internal/models"
+)
+
+var errUnknownType = errors.New("unknown secret type")
+
+type BatchData struct {
+	Passphrase string           `binding:"required"                    json:"passphrase"`
+	Operations []BatchOperation `binding:"required,min=1,max=100,dive" json:"operations"`
+}
+
+// BatchOperation is an operation of a batch.
+//
+// The data of created and updated secrets is the request body of the type without the passphrase.
+// The organization is the vault to move the secret to, it is empty for the personal vault.
+// The entity tag of an update is checked as If-Match, the update is not checked without it.
+type BatchOperation struct {
+	Op    string          `binding:"required,oneof=create update delete move" json:"op"`
+	Type  string          `binding:""                                         json:"type,omitempty"`
+	ID    string          `binding:"required_unless=Op create,omitempty,uuid" json:"id,omitempty"`
+	OrgID string          `binding:"omitempty,uuid"                           json:"org_id,omitempty"`
+	ETag  string          `binding:""                                         json:"etag,omitempty"`
+	Data  json.RawMessage `binding:""                                         json:"data,omitempty"`
+}
+
+// BatchResultSchema is the result of an operation with the status of the same single request.
+//
+// The operations after the failed one are not run and have the 424 status,
+// the ones before it are rolled back with their own statuses.
+type BatchResultSchema struct {
+	ID       string             `json:"id,omitempty"`
+	Status   int                `json:"status"`
+	Errors   []string           `json:"errors,omitempty"`
+	Warnings []response.Warning `json:"warnings,omitempty"`
+}
+
+// Batch runs the operations on secrets in one transaction, nothing is saved if one of them fails.
+func Batch(service domain.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		userID := auth.GetUserID(c)
+
+		var body BatchData
+		if err := c.ShouldBindJSON(&body); err != nil {
+			var vErr validator.ValidationErrors
+			if errors.As(err, &vErr) {
+				c.JSON(http.StatusUnprocessableEntity, response.NewValidationError(vErr))
+			} else {
+				c.JSON(http.StatusBadRequest, response.NewError(err))
+			}
+
+			return
+		}
+
+		items, schemas, ok := body.items()
+		if !ok {
+			c.JSON(http.StatusUnprocessableEntity, newBatchFailed(schemas))
+
+			return
+		}
+
+		results, err := service.Batch(c, userID, body.Passphrase, items)
+		if err != nil && !errors.Is(err, domain.ErrBatchFailed) {
+			if errors.Is(err, domain.ErrInvalidPassphrase) {
+				c.AbortWithStatus(http.StatusConflict)
+			} else {
+				c.AbortWithError(http.StatusInternalServerError, err)
+			}
+
+			return
+		}
+
+		for i, result := range results {
+			schemas[i] = newBatchResult(result)
+			if schemas[i].Status == http.StatusInternalServerError {
+				c.AbortWithError(http.StatusInternalServerError, result.Err)
+
+				return
+			}
+		}
+
+		if err != nil {
+			c.JSON(http.StatusUnprocessableEntity, newBatchFailed(schemas))
+
+			return
+		}
+
+		c.JSON(http.StatusOK, response.NewSuccess(&schemas))
+	}
+}
+
+// items converts the operations into domain ones, the results tell the invalid operations if some are.
+func (d *BatchData) items() ([]domain.BatchItem, []BatchResultSchema, bool) {
+	items := make([]domain.BatchItem, len(d.Operations))
+	schemas := make([]BatchResultSchema, len(d.Operations))
+	valid := true
+
+	for i := range d.Operations {
+		op := &d.Operations[i]
+
+		item, err := op.item(d.Passphrase)
+		if err != nil {
+			valid = false
+			schemas[i] = BatchResultSchema{ID: op.ID, Status: http.StatusUnprocessableEntity, Errors: errorsOf(err)}
+
+			continue
+		}
+
+		items[i] = item
+		schemas[i] = BatchResultSchema{ID: op.ID, Status: http.StatusFailedDependency}
+	}
+
+	return items, schemas, valid
+}
+
+func (o *BatchOperation) item(passphrase string) (domain.BatchItem, error) {
+	item := domain.BatchItem{Op: domain.BatchOp(o.Op), ID: models.SecretID(o.ID)}
+
+	switch item.Op {
+	case domain.BatchOpCreate, domain.BatchOpUpdate:
+		body, err := o.payload(passphrase)
+		if err != nil {
+			return item, err
+		}
+
+		if item.Data, err = body.ToData(); err != nil {
+			return item, err
+		}
+
+		_, item.Name = body.Credentials()
+		item.Attrs = body.Attrs()
+
+		if o.ETag != "" {
+			if item.Attrs.Revision, err = parseETag(o.ETag); err != nil {
+				return item, err
+			}
+		}
+	case domain.BatchOpMove:
+		item.Attrs.OrgID = models.OrgID(o.OrgID)
+	case domain.BatchOpDelete:
+	}
+
+	return item, nil
+}
+
+// payload binds the data to the request body of the type with the passphrase of the batch.
+func (o *BatchOperation) payload(passphrase string) (Payload, error) {
+	t, ok := Lookup(o.Type)
+	if !ok {
+		return nil, fmt.Errorf("%w %q", errUnknownType, o.Type)
+	}
+
+	fields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(o.Data, &fields); err != nil {
+		return nil, err
+	}
+
+	fields["passphrase"], _ = json.Marshal(passphrase) // nolint: errchkjson
+
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	body := t.New()
+	if err := json.Unmarshal(data, body); err != nil {
+		return nil, err
+	}
+
+	return body, binding.Validator.ValidateStruct(body)
+}
+
+func newBatchResult(result domain.BatchResult) BatchResultSchema {
+	schema := BatchResultSchema{
+		ID:       string(result.ID),
+		Status:   http.StatusOK,
+		Warnings: newWarnings(result.Warnings),
+	}
+
+	if result.Err == nil {
+		return schema
+	}
+
+	schema.Warnings = nil
+	schema.Errors = []string{result.Err.Error()}
+
+	switch {
+	case errors.Is(result.Err, domain.ErrBatchAborted):
+		schema.Status = http.StatusFailedDependency
+	case errors.Is(result.Err, domain.ErrSecretNotFound):
+		schema.Status = http.StatusNotFound
+	case errors.Is(result.Err, domain.ErrAnotherOwner) || errors.Is(result.Err, domain.ErrReadOnly):
+		schema.Status = http.StatusForbidden
+	case errors.Is(result.Err, domain.ErrInvalidSecretType) || errors.Is(result.Err, domain.ErrSameVault):
+		schema.Status = http.StatusConflict
+	case errors.Is(result.Err, domain.ErrRevisionMismatch):
+		schema.Status = http.StatusPreconditionFailed
+	case errors.Is(result.Err, domain.ErrSecretTooLarge):
+		schema.Status = http.StatusRequestEntityTooLarge
+	case errors.Is(result.Err, domain.ErrQuotaExceeded):
+		schema.Status = http.StatusInsufficientStorage
+	case errors.Is(result.Err, domain.ErrInvalidSecretData) || errors.Is(result.Err, domain.ErrTemplateNotFound):
+		schema.Status = http.StatusUnprocessableEntity
+	default:
+		schema.Status = http.StatusInternalServerError
+	}
+
+	return schema
+}
+
+func newBatchFailed(schemas []BatchResultSchema) *response.Response[[]BatchResultSchema] {
+	return &response.Response[[]BatchResultSchema]{
+		Errors: []string{domain.ErrBatchFailed.Error()},
+		Result: &schemas,
+	}
+}
+
+func errorsOf(err error) []string {
+	var vErr validator.ValidationErrors
+	if errors.As(err, &vErr) {
+		return response.NewValidationError(vErr).Errors
+	}
+
+	return []string{err.Error()}
+}
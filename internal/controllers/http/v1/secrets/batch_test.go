@@ -0,0 +1,214 @@
+package secrets_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/steinfletcher/apitest"
+	"go.uber.org/mock/gomock"
+
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/v1/secrets"
+	domain "github.com/novoseltcev/passkeeper/internal/domains/secrets"
+	"github.com/novoseltcev/passkeeper/internal/domains/secrets/mocks"
+	"github.com/novoseltcev/passkeeper/internal/models"
+	"github.com/novoseltcev/passkeeper/pkg/testutils"
+)
+
+const (
+	testOtherID = models.SecretID("9b2f4a8e-2c1d-4e5f-8a7b-6c5d4e3f2a1b")
+	testOrgUUID = models.OrgID("5d3c2b1a-4e5f-4a6b-8c7d-9e0f1a2b3c4d")
+)
+
+var testBatch = `{
+	"passphrase": "passphrase",
+	"operations": [
+		{"op": "create", "type": "password", "data": {"name": "test", "login": "login", "password": "p@ssw0rd", "meta": {}}},
+		{"op": "delete", "id": "c4865c2f-8fa8-46a1-97b1-74242c68bbd0"},
+		{"op": "move", "id": "9b2f4a8e-2c1d-4e5f-8a7b-6c5d4e3f2a1b", "org_id": "5d3c2b1a-4e5f-4a6b-8c7d-9e0f1a2b3c4d"}
+	]
+}`
+
+var testBatchItems = []domain.BatchItem{
+	{
+		Op:   domain.BatchOpCreate,
+		Name: testName,
+		Data: &domain.PasswordData{Login: testLogin, Password: testPassword, Meta: map[string]any{}},
+	},
+	{Op: domain.BatchOpDelete, ID: testID},
+	{Op: domain.BatchOpMove, ID: testOtherID, Attrs: domain.Attrs{OrgID: testOrgUUID}},
+}
+
+func setupBatch(t *testing.T) (*mocks.MockService, http.Handler) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	root := gin.Default()
+	service := mocks.NewMockService(ctrl)
+	secrets.AddRoutes(&root.RouterGroup, service, guardMock)
+
+	return service, root.Handler()
+}
+
+func TestBatch(t *testing.T) {
+	t.Parallel()
+	service, handler := setupBatch(t)
+
+	service.EXPECT().
+		Batch(gomock.Any(), testOwnerID, testPassphrase, testBatchItems).
+		Return([]domain.BatchResult{
+			{ID: "new-id", Warnings: []domain.Warning{{Code: domain.WarningBreached, Message: "seen"}}},
+			{ID: testID},
+			{ID: "moved-id"},
+		}, nil)
+
+	apitest.Handler(handler).
+		Post("/secrets/batch").
+		Body(testBatch).
+		Expect(t).
+		Status(http.StatusOK).
+		Body(`{"success": true, "result": [
+			{"id": "new-id", "status": 200, "warnings": [{"code": "breached", "message": "seen"}]},
+			{"id": "c4865c2f-8fa8-46a1-97b1-74242c68bbd0", "status": 200},
+			{"id": "moved-id", "status": 200}
+		]}`).
+		End()
+}
+
+func TestBatch_Fails_Item(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		err     error
+		results string
+	}{
+		{
+			name: "forbidden",
+			err:  domain.ErrAnotherOwner,
+			results: `[
+				{"id": "new-id", "status": 200},
+				{"id": "c4865c2f-8fa8-46a1-97b1-74242c68bbd0", "status": 403, "errors": ["another owner"]},
+				{"id": "9b2f4a8e-2c1d-4e5f-8a7b-6c5d4e3f2a1b", "status": 424, "errors": ["batch aborted"]}
+			]`,
+		},
+		{
+			name: "not found",
+			err:  domain.ErrSecretNotFound,
+			results: `[
+				{"id": "new-id", "status": 200},
+				{"id": "c4865c2f-8fa8-46a1-97b1-74242c68bbd0", "status": 404, "errors": ["secret not found"]},
+				{"id": "9b2f4a8e-2c1d-4e5f-8a7b-6c5d4e3f2a1b", "status": 424, "errors": ["batch aborted"]}
+			]`,
+		},
+		{
+			name: "quota exceeded",
+			err:  domain.ErrQuotaExceeded,
+			results: `[
+				{"id": "new-id", "status": 200},
+				{"id": "c4865c2f-8fa8-46a1-97b1-74242c68bbd0", "status": 507, "errors": ["quota exceeded"]},
+				{"id": "9b2f4a8e-2c1d-4e5f-8a7b-6c5d4e3f2a1b", "status": 424, "errors": ["batch aborted"]}
+			]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			service, handler := setupBatch(t)
+
+			service.EXPECT().
+				Batch(gomock.Any(), testOwnerID, testPassphrase, testBatchItems).
+				Return([]domain.BatchResult{
+					{ID: "new-id"},
+					{ID: testID, Err: tt.err},
+					{ID: testOtherID, Err: domain.ErrBatchAborted},
+				}, domain.ErrBatchFailed)
+
+			apitest.Handler(handler).
+				Post("/secrets/batch").
+				Body(testBatch).
+				Expect(t).
+				Status(http.StatusUnprocessableEntity).
+				Body(`{"success": false, "errors": ["batch failed"], "result": ` + tt.results + `}`).
+				End()
+		})
+	}
+}
+
+func TestBatch_Fails_InvalidItem(t *testing.T) {
+	t.Parallel()
+	_, handler := setupBatch(t)
+
+	apitest.Handler(handler).
+		Post("/secrets/batch").
+		Body(`{"passphrase": "passphrase", "operations": [
+			{"op": "create", "type": "unknown", "data": {}},
+			{"op": "update", "type": "password", "id": "c4865c2f-8fa8-46a1-97b1-74242c68bbd0", "data": {"name": "test"}},
+			{"op": "delete", "id": "9b2f4a8e-2c1d-4e5f-8a7b-6c5d4e3f2a1b"}
+		]}`).
+		Expect(t).
+		Status(http.StatusUnprocessableEntity).
+		Body(`{"success": false, "errors": ["batch failed"], "result": [
+			{"status": 422, "errors": ["unknown secret type \"unknown\""]},
+			{"id": "c4865c2f-8fa8-46a1-97b1-74242c68bbd0", "status": 422, "errors": [
+				"Field validation for 'Login' failed on the 'required' tag",
+				"Field validation for 'Password' failed on the 'required' tag",
+				"Field validation for 'Meta' failed on the 'required' tag"
+			]},
+			{"id": "9b2f4a8e-2c1d-4e5f-8a7b-6c5d4e3f2a1b", "status": 424}
+		]}`).
+		End()
+}
+
+func TestBatch_Fails(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{
+			name:   "invalid passphrase",
+			err:    domain.ErrInvalidPassphrase,
+			status: http.StatusConflict,
+		},
+		{
+			name:   "other",
+			err:    testutils.Err,
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			service, handler := setupBatch(t)
+
+			service.EXPECT().
+				Batch(gomock.Any(), testOwnerID, testPassphrase, testBatchItems).
+				Return(nil, tt.err)
+
+			apitest.Handler(handler).
+				Post("/secrets/batch").
+				Body(testBatch).
+				Expect(t).
+				Status(tt.status).
+				End()
+		})
+	}
+}
+
+func TestBatch_Fails_Validate(t *testing.T) {
+	t.Parallel()
+	_, handler := setupBatch(t)
+
+	apitest.Handler(handler).
+		Post("/secrets/batch").
+		Body(`{"passphrase": "passphrase", "operations": [{"op": "rename"}]}`).
+		Expect(t).
+		Status(http.StatusUnprocessableEntity).
+		End()
+}
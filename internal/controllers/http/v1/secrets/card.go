@@ -0,0 +1,47 @@
+package secrets
+
+import (
+	domain "github.com/novoseltcev/passkeeper/internal/domains/secrets"
+	"github.com/novoseltcev/passkeeper/internal/models"
+	"github.com/novoseltcev/passkeeper/pkg/card"
+)
+
+func init() { // nolint: gochecknoinits
+	Register(Type{
+		ID:   models.SecretTypeCard,
+		Name: "card",
+		New:  func() Payload { return &CardSecretData{} },
+		Fields: []Field{
+			{Key: "number", Label: "Number", Kind: FieldKindText},
+			{Key: "holder", Label: "Holder", Kind: FieldKindText},
+			{Key: "cvv", Label: "CVV", Kind: FieldKindConcealed},
+			{Key: "exp", Label: "Expiration", Kind: FieldKindText},
+		},
+	})
+}
+
+type CardSecretData struct {
+	Passphrase string         `binding:"required"`
+	Name       string         `binding:"required,min=4,max=32"`
+	Number     string         `binding:"required"`
+	Holder     string         `binding:""`
+	Exp        string         `binding:"required"`
+	CVV        string         `binding:"required"          json:"cvv"`
+	Meta       map[string]any `binding:"required"`
+	AttrsData
+}
+
+func (d *CardSecretData) Credentials() (string, string) {
+	return d.Passphrase, d.Name
+}
+
+// ToData normalises the number, the card is checked with its brand rules by the domain.
+func (d *CardSecretData) ToData() (domain.ISecretData, error) {
+	return &domain.CardData{
+		Number: card.Normalize(d.Number),
+		Holder: d.Holder,
+		Exp:    d.Exp,
+		CVV:    d.CVV,
+		Meta:   d.Meta,
+	}, nil
+}
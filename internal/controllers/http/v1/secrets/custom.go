@@ -0,0 +1,38 @@
+package secrets
+
+import (
+	domain "github.com/novoseltcev/passkeeper/internal/domains/secrets"
+	"github.com/novoseltcev/passkeeper/internal/models"
+)
+
+func init() { // nolint: gochecknoinits
+	Register(Type{
+		ID:   models.SecretTypeCustom,
+		Name: "custom",
+		New:  func() Payload { return &CustomSecretData{} },
+		Fields: []Field{
+			{Key: "template", Label: "Template", Kind: FieldKindTemplate},
+		},
+	})
+}
+
+type CustomSecretData struct {
+	Passphrase string            `binding:"required"`
+	Name       string            `binding:"required,min=4,max=32"`
+	Template   string            `binding:"required,uuid"`
+	Fields     map[string]string `binding:"required"`
+	Meta       map[string]any    `binding:"required"`
+	AttrsData
+}
+
+func (d *CustomSecretData) Credentials() (string, string) {
+	return d.Passphrase, d.Name
+}
+
+func (d *CustomSecretData) ToData() (domain.ISecretData, error) {
+	return &domain.CustomData{
+		Template: models.TemplateID(d.Template),
+		Fields:   d.Fields,
+		Meta:     d.Meta,
+	}, nil
+}
@@ -57,18 +57,24 @@ func DecryptByID(service domain.Service) func(c *gin.Context) {
 			return
 		}
 
+		c.Header("ETag", newETag(secret.Revision))
 		c.JSON(http.StatusOK, response.NewSuccess(&SecretSchema{
-			ID:   string(secret.ID),
-			Name: secret.Name,
-			Type: secret.Type.String(),
-			Data: data,
+			ID:        string(secret.ID),
+			ETag:      newETag(secret.Revision),
+			Name:      secret.Name,
+			Type:      secret.Type.String(),
+			Data:      data,
+			AttrsData: newAttrsData(secret),
 		}))
 	}
 }
 
 type SecretSchema struct {
-	ID   string         `json:"id"`
+	ID string `json:"id"`
+	// ETag is the entity tag of the revision, which is sent as If-Match on update.
+	ETag string         `json:"etag"`
 	Name string         `json:"name"`
 	Type string         `json:"type"`
 	Data map[string]any `json:"data"`
+	AttrsData
 }
@@ -43,10 +43,11 @@ func TestDecryptByID_Success(t *testing.T) {
 	service.EXPECT().
 		Get(gomock.Any(), testID, testOwnerID, testPassphrase).
 		Return(&models.Secret{
-			ID:   testID,
-			Name: testName,
-			Data: []byte(testDecodedData),
-			Type: models.SecretTypeFile,
+			ID:       testID,
+			Name:     testName,
+			Data:     []byte(testDecodedData),
+			Type:     models.SecretTypeFile,
+			Revision: testRevision,
 		}, nil)
 
 	apitest.Handler(root.Handler()).
@@ -55,6 +56,7 @@ func TestDecryptByID_Success(t *testing.T) {
 		Bodyf(`{"passphrase":"%s"}`, testPassphrase).
 		Expect(t).
 		Status(http.StatusOK).
+		Header("ETag", testETag).
 		Bodyf(`
 		{
 		  "success":true,
@@ -62,7 +64,8 @@ func TestDecryptByID_Success(t *testing.T) {
 		 	"id":"%s",
 		 	"name":"%s",
 		 	"type":"file",
-		 	"data":%s
+		 	"data":%s,
+		 	"etag":"\"3\""
 		  }
 		}`, testID, testName, testDecodedData).
 		End()
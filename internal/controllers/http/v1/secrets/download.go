@@ -0,0 +1,65 @@
+package secrets
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/novoseltcev/passkeeper/internal/auth"
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/common/response"
+	domain "github.com/novoseltcev/passkeeper/internal/domains/secrets"
+	"github.com/novoseltcev/passkeeper/internal/models"
+)
+
+// DownloadFile responds with the decrypted content of a file secret as an attachment.
+func DownloadFile(service domain.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ownerID := auth.GetUserID(c)
+		id := models.SecretID(c.Param("id"))
+
+		var body DecryptByIDData
+		if err := c.ShouldBindJSON(&body); err != nil {
+			var vErr validator.ValidationErrors
+			if errors.As(err, &vErr) {
+				c.JSON(http.StatusUnprocessableEntity, response.NewValidationError(vErr))
+			} else {
+				c.JSON(http.StatusBadRequest, response.NewError(err))
+			}
+
+			return
+		}
+
+		file, content, err := service.OpenFile(c, id, ownerID, body.Passphrase)
+		if err != nil {
+			if errors.Is(err, domain.ErrSecretNotFound) {
+				c.AbortWithStatus(http.StatusNotFound)
+			} else if errors.Is(err, domain.ErrAnotherOwner) {
+				c.AbortWithStatus(http.StatusForbidden)
+			} else if errors.Is(err, domain.ErrInvalidPassphrase) {
+				c.AbortWithStatus(http.StatusConflict)
+			} else if errors.Is(err, domain.ErrInvalidSecretType) {
+				c.JSON(http.StatusConflict, response.NewError(err))
+			} else {
+				c.AbortWithError(http.StatusInternalServerError, err)
+			}
+
+			return
+		}
+		defer content.Close()
+
+		// the content is decrypted as it is written, a failed chunk breaks the response off
+		c.DataFromReader(
+			http.StatusOK,
+			file.Size,
+			"application/octet-stream",
+			content,
+			map[string]string{
+				"Content-Disposition": mime.FormatMediaType("attachment", map[string]string{"filename": file.Filename}),
+				"Cache-Control":       "no-store",
+			},
+		)
+	}
+}
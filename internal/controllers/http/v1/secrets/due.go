@@ -0,0 +1,47 @@
+package secrets
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/novoseltcev/passkeeper/internal/auth"
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/common/response"
+	domain "github.com/novoseltcev/passkeeper/internal/domains/secrets"
+)
+
+// GetDue lists the secrets that are expired or due for rotation.
+func GetDue(service domain.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ownerID := auth.GetUserID(c)
+
+		due, err := service.GetDue(c, ownerID)
+		if err != nil {
+			c.AbortWithError(http.StatusInternalServerError, err)
+
+			return
+		}
+
+		schemas := make([]DueSecretSchema, len(due))
+		for i, d := range due {
+			schemas[i] = DueSecretSchema{
+				ID:     string(d.Secret.ID),
+				Name:   d.Secret.Name,
+				Type:   d.Secret.Type.String(),
+				Reason: string(d.Reason),
+				DueAt:  d.DueAt,
+			}
+		}
+
+		c.JSON(http.StatusOK, response.NewSuccess(&schemas))
+	}
+}
+
+type DueSecretSchema struct {
+	ID     string    `json:"id"`
+	Name   string    `json:"name"`
+	Type   string    `json:"type"`
+	Reason string    `json:"reason"` // expired or rotation
+	DueAt  time.Time `json:"due_at"`
+}
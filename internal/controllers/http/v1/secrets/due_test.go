@@ -0,0 +1,73 @@
+package secrets_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/steinfletcher/apitest"
+	"go.uber.org/mock/gomock"
+
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/v1/secrets"
+	domain "github.com/novoseltcev/passkeeper/internal/domains/secrets"
+	"github.com/novoseltcev/passkeeper/internal/domains/secrets/mocks"
+	"github.com/novoseltcev/passkeeper/internal/models"
+	"github.com/novoseltcev/passkeeper/pkg/testutils"
+)
+
+func TestGetDue_Success(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	root := gin.Default()
+	service := mocks.NewMockService(ctrl)
+	secrets.AddRoutes(&root.RouterGroup, service, guardMock)
+
+	dueAt := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	service.EXPECT().
+		GetDue(gomock.Any(), testOwnerID).
+		Return([]domain.DueSecret{{
+			Secret: models.Secret{ID: testID, Name: testName, Type: models.SecretTypeCard},
+			Reason: domain.DueReasonExpired,
+			DueAt:  dueAt,
+		}}, nil)
+
+	apitest.New().
+		Handler(root.Handler()).
+		Debug().
+		Get("/secrets/due").
+		Expect(t).
+		Status(http.StatusOK).
+		Bodyf(`{"success":true,"result":[{
+			"id":"%s",
+			"name":"%s",
+			"type":"card",
+			"reason":"expired",
+			"due_at":"2024-02-01T00:00:00Z"
+		}]}`, testID, testName).
+		End()
+}
+
+func TestGetDue_Fails(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	root := gin.Default()
+	service := mocks.NewMockService(ctrl)
+	secrets.AddRoutes(&root.RouterGroup, service, guardMock)
+
+	service.EXPECT().
+		GetDue(gomock.Any(), testOwnerID).
+		Return(nil, testutils.Err)
+
+	apitest.New().
+		Handler(root.Handler()).
+		Debug().
+		Get("/secrets/due").
+		Expect(t).
+		Status(http.StatusInternalServerError).
+		End()
+}
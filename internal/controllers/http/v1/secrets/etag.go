@@ -0,0 +1,61 @@
+package secrets
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/common/response"
+)
+
+var (
+	errIfMatchRequired = errors.New("If-Match header is required")
+	errInvalidETag     = errors.New("invalid entity tag")
+)
+
+// newETag returns the strong entity tag of the secret revision.
+func newETag(revision int64) string {
+	return strconv.Quote(strconv.FormatInt(revision, 10))
+}
+
+// parseETag returns the revision of the entity tag, it is zero for "*", which matches any revision.
+func parseETag(etag string) (int64, error) {
+	etag = strings.TrimSpace(etag)
+	if etag == "*" {
+		return 0, nil
+	}
+
+	value, err := strconv.Unquote(etag)
+	if err != nil || !strings.HasPrefix(etag, `"`) {
+		return 0, errInvalidETag
+	}
+
+	revision, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || revision <= 0 {
+		return 0, errInvalidETag
+	}
+
+	return revision, nil
+}
+
+// bindRevision returns the revision of the required If-Match header, it responds with the error itself.
+func bindRevision(c *gin.Context) (int64, bool) {
+	header := c.GetHeader("If-Match")
+	if header == "" {
+		c.JSON(http.StatusPreconditionRequired, response.NewError(errIfMatchRequired))
+
+		return 0, false
+	}
+
+	revision, err := parseETag(header)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.NewError(err))
+
+		return 0, false
+	}
+
+	return revision, true
+}
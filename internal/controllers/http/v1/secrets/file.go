@@ -0,0 +1,39 @@
+package secrets
+
+import (
+	domain "github.com/novoseltcev/passkeeper/internal/domains/secrets"
+	"github.com/novoseltcev/passkeeper/internal/models"
+)
+
+func init() { // nolint: gochecknoinits
+	Register(Type{
+		ID:   models.SecretTypeFile,
+		Name: "file",
+		New:  func() Payload { return &FileSecretData{} },
+		Fields: []Field{
+			{Key: "filename", Label: "Filename", Kind: FieldKindText},
+			{Key: "content", Label: "Content", Kind: FieldKindMultiline},
+		},
+	})
+}
+
+type FileSecretData struct {
+	Passphrase string         `binding:"required"`
+	Name       string         `binding:"required,min=4,max=32"`
+	Filename   string         `binding:"required"`
+	Content    string         `binding:"required,hexadecimal"`
+	Meta       map[string]any `binding:"required"`
+	AttrsData
+}
+
+func (d *FileSecretData) Credentials() (string, string) {
+	return d.Passphrase, d.Name
+}
+
+func (d *FileSecretData) ToData() (domain.ISecretData, error) {
+	return &domain.FileData{
+		Filename: d.Filename,
+		Content:  d.Content,
+		Meta:     d.Meta,
+	}, nil
+}
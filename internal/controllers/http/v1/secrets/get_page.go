@@ -3,6 +3,7 @@ package secrets
 import (
 	"errors"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
@@ -10,6 +11,7 @@ import (
 	"github.com/novoseltcev/passkeeper/internal/auth"
 	"github.com/novoseltcev/passkeeper/internal/controllers/http/common/response"
 	domain "github.com/novoseltcev/passkeeper/internal/domains/secrets"
+	"github.com/novoseltcev/passkeeper/internal/models"
 )
 
 func GetPage(service domain.Service) func(c *gin.Context) {
@@ -28,33 +30,73 @@ func GetPage(service domain.Service) func(c *gin.Context) {
 			return
 		}
 
-		page, err := service.GetPage(c, ownerID, req.Limit, req.Offset)
-		if err != nil {
-			c.AbortWithError(http.StatusInternalServerError, err)
+		var (
+			page *domain.Page[models.Secret]
+			err  error
+		)
 
-			return
+		if req.OrgID != "" {
+			page, err = service.GetOrgPage(c, models.OrgID(req.OrgID), ownerID, req.Limit, req.Offset)
+		} else {
+			page, err = service.GetPage(c, ownerID, req.Limit, req.Offset)
 		}
 
-		schemas := make([]SecretItemSchema, len(page.Items))
-		for i, secret := range page.Items {
-			schemas[i] = SecretItemSchema{
-				ID:   string(secret.ID),
-				Name: secret.Name,
-				Type: secret.Type.String(),
+		if err != nil {
+			if errors.Is(err, domain.ErrAnotherOwner) {
+				c.AbortWithStatus(http.StatusForbidden)
+			} else {
+				c.AbortWithError(http.StatusInternalServerError, err)
 			}
+
+			return
 		}
 
-		c.JSON(http.StatusOK, response.NewPaginated(schemas, req.Limit, req.Offset, page.Total))
+		c.JSON(http.StatusOK, response.NewPaginated(newSecretItems(page.Items), req.Limit, req.Offset, page.Total))
 	}
 }
 
+func newSecretItems(secrets []models.Secret) []SecretItemSchema {
+	schemas := make([]SecretItemSchema, len(secrets))
+	for i := range secrets {
+		secret := &secrets[i]
+		schemas[i] = SecretItemSchema{
+			ID:          string(secret.ID),
+			ETag:        newETag(secret.Revision),
+			Name:        secret.Name,
+			Type:        secret.Type.String(),
+			Fingerprint: secret.Fingerprint,
+			Preview:     secret.Preview,
+			CreatedAt:   secret.CreatedAt,
+			UpdatedAt:   secret.UpdatedAt,
+			AttrsData:   newAttrsData(secret),
+		}
+		if !secret.AccessedAt.IsZero() {
+			schemas[i].AccessedAt = &secret.AccessedAt
+		}
+	}
+
+	return schemas
+}
+
 type PaginationRequest struct {
 	Limit  uint64 `binding:"required,gte=1,lte=100" form:"limit"`
 	Offset uint64 `binding:"gte=0"                  form:"offset"`
+	// OrgID selects the vault of the organization instead of the personal one.
+	OrgID string `binding:"omitempty,uuid" form:"org_id"`
 }
 
 type SecretItemSchema struct {
-	ID   string `binding:"required"                               json:"id"`
-	Name string `binding:"required"                               json:"name"`
-	Type string `binding:"required,oneof=password card text file" json:"type"`
+	ID          string `binding:"required" json:"id"`
+	ETag        string `binding:"required" json:"etag"`
+	Name        string `binding:"required" json:"name"`
+	Type        string `binding:"required" json:"type"` // one of the registered type names
+	Fingerprint string `binding:""         json:"fingerprint,omitempty"`
+	// Preview are the non-secret fields to display, such as the login, the brand and the last4 digits of a card
+	// or the filename, the fields depend on the type.
+	Preview   map[string]string `binding:"" json:"preview,omitempty"`
+	CreatedAt time.Time         `binding:"" json:"created_at"`
+	UpdatedAt time.Time         `binding:"" json:"updated_at"`
+	// AccessedAt is the time the secret was last decrypted, it is omitted if it was never decrypted.
+	AccessedAt *time.Time `binding:"" json:"accessed_at,omitempty"`
+	AttrsData
 }
@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/steinfletcher/apitest"
@@ -30,14 +31,43 @@ func TestGetPage_Success(t *testing.T) {
 
 	var limit, offset, total uint64 = 10, 0, 30
 
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	updatedAt := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	accessedAt := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
 	service.EXPECT().
 		GetPage(gomock.Any(), testOwnerID, limit, offset).
 		Return(domain.NewPage([]models.Secret{
 			{
-				ID:   testID,
-				Name: testName,
-				Data: testData,
-				Type: models.SecretTypeFile,
+				ID:         testID,
+				Name:       testName,
+				Data:       testData,
+				Type:       models.SecretTypeFile,
+				Preview:    map[string]string{domain.PreviewFilename: "report.pdf"},
+				Revision:   1,
+				CreatedAt:  createdAt,
+				UpdatedAt:  updatedAt,
+				AccessedAt: accessedAt,
+			},
+			{
+				ID:          testID,
+				Name:        testName,
+				Data:        testData,
+				Type:        models.SecretTypeSSHKey,
+				Fingerprint: testFingerprint,
+				Revision:    testRevision,
+				CreatedAt:   createdAt,
+				UpdatedAt:   createdAt,
+			},
+			{
+				ID:        testID,
+				Name:      testName,
+				Data:      testData,
+				Type:      models.SecretTypeCard,
+				Preview:   map[string]string{domain.PreviewBrand: "Visa", domain.PreviewLast4: "4242"},
+				Revision:  1,
+				CreatedAt: createdAt,
+				UpdatedAt: createdAt,
 			},
 		}, total), nil)
 
@@ -57,12 +87,35 @@ func TestGetPage_Success(t *testing.T) {
 		  	{
 		 		"id":"%s",
 		 		"name":"%s",
-		 		"type":"file"
+		 		"type":"file",
+		 		"preview":{"filename":"report.pdf"},
+		 		"created_at":"2024-01-01T00:00:00Z",
+		 		"updated_at":"2024-02-01T00:00:00Z",
+		 		"accessed_at":"2024-03-01T00:00:00Z",
+		 		"etag":"\"1\""
+		  	},
+		  	{
+		 		"id":"%s",
+		 		"name":"%s",
+		 		"type":"ssh_key",
+		 		"fingerprint":"%s",
+		 		"created_at":"2024-01-01T00:00:00Z",
+		 		"updated_at":"2024-01-01T00:00:00Z",
+		 		"etag":"\"3\""
+		  	},
+		  	{
+		 		"id":"%s",
+		 		"name":"%s",
+		 		"type":"card",
+		 		"preview":{"brand":"Visa","last4":"4242"},
+		 		"created_at":"2024-01-01T00:00:00Z",
+		 		"updated_at":"2024-01-01T00:00:00Z",
+		 		"etag":"\"1\""
 		  	}
 		  ],
 		  "pagination":{"limit":%d,"offset":%d,"total":%d}
 		  
-		}`, testID, testName, limit, offset, total).
+		}`, testID, testName, testID, testName, testFingerprint, testID, testName, limit, offset, total).
 		End()
 }
 
@@ -158,3 +211,65 @@ func TestGetPage_Fails_GetPage(t *testing.T) {
 		Status(http.StatusInternalServerError).
 		End()
 }
+
+func TestGetPage_Org(t *testing.T) {
+	t.Parallel()
+
+	const orgID = models.OrgID("5c2f9f8e-3d1a-4b7e-9f0c-6a1d2e3f4a5b")
+
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{name: "success", status: http.StatusOK},
+		{name: "not member", err: domain.ErrAnotherOwner, status: http.StatusForbidden},
+		{name: "other", err: testutils.Err, status: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			root := gin.Default()
+			service := mocks.NewMockService(ctrl)
+			secrets.AddRoutes(&root.RouterGroup, service, guardMock)
+
+			var page *domain.Page[models.Secret]
+			if tt.err == nil {
+				page = domain.NewPage([]models.Secret{{
+					ID:       testID,
+					Name:     testName,
+					Type:     models.SecretTypeTxt,
+					Org:      &models.Org{ID: orgID},
+					Revision: 1,
+				}}, 1)
+			}
+
+			service.EXPECT().
+				GetOrgPage(gomock.Any(), orgID, testOwnerID, uint64(10), uint64(0)).
+				Return(page, tt.err)
+
+			test := apitest.New(tt.name).
+				Handler(root.Handler()).
+				Debug().
+				Get("/secrets").
+				QueryParams(map[string]string{"limit": "10", "org_id": string(orgID)}).
+				Expect(t).
+				Status(tt.status)
+
+			if tt.err == nil {
+				test = test.Bodyf(
+					`{"success":true,"result":[{"id":"%s","name":"%s","type":"text","org_id":"%s","etag":"\"1\"",`+
+						`"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z"}],`+
+						`"pagination":{"limit":10,"offset":0,"total":1}}`,
+					testID, testName, orgID,
+				)
+			}
+
+			test.End()
+		})
+	}
+}
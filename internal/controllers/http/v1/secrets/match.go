@@ -0,0 +1,55 @@
+package secrets
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/novoseltcev/passkeeper/internal/auth"
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/common/response"
+	domain "github.com/novoseltcev/passkeeper/internal/domains/secrets"
+)
+
+// Match lists the candidate passwords for the URL.
+//
+// The candidates are found without decrypting them, so the client decrypts them and matches their URIs itself.
+func Match(service domain.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ownerID := auth.GetUserID(c)
+
+		var req MatchRequest
+		if err := c.ShouldBindQuery(&req); err != nil {
+			var vErr validator.ValidationErrors
+			if errors.As(err, &vErr) {
+				c.JSON(http.StatusUnprocessableEntity, response.NewValidationError(vErr))
+			} else {
+				c.JSON(http.StatusBadRequest, response.NewError(err))
+			}
+
+			return
+		}
+
+		secrets, err := service.Match(c, ownerID, req.URL)
+		if err != nil {
+			switch {
+			case errors.Is(err, domain.ErrInvalidURL):
+				c.JSON(http.StatusUnprocessableEntity, response.NewError(err))
+			case errors.Is(err, domain.ErrMatchDisabled):
+				c.JSON(http.StatusNotImplemented, response.NewError(err))
+			default:
+				c.AbortWithError(http.StatusInternalServerError, err)
+			}
+
+			return
+		}
+
+		schemas := newSecretItems(secrets)
+		c.JSON(http.StatusOK, response.NewSuccess(&schemas))
+	}
+}
+
+type MatchRequest struct {
+	URL string `binding:"required" form:"url"`
+}
@@ -0,0 +1,98 @@
+package secrets_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/steinfletcher/apitest"
+	"go.uber.org/mock/gomock"
+
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/v1/secrets"
+	domain "github.com/novoseltcev/passkeeper/internal/domains/secrets"
+	"github.com/novoseltcev/passkeeper/internal/domains/secrets/mocks"
+	"github.com/novoseltcev/passkeeper/internal/models"
+	"github.com/novoseltcev/passkeeper/pkg/testutils"
+)
+
+const testURL = "https://github.com/login"
+
+func TestMatch_Success(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	root := gin.Default()
+	service := mocks.NewMockService(ctrl)
+	secrets.AddRoutes(&root.RouterGroup, service, guardMock)
+
+	service.EXPECT().
+		Match(gomock.Any(), testOwnerID, testURL).
+		Return([]models.Secret{{ID: testID, Name: testName, Type: models.SecretTypePwd, Revision: 1}}, nil)
+
+	apitest.New().
+		Handler(root.Handler()).
+		Debug().
+		Get("/secrets/match").
+		Query("url", testURL).
+		Expect(t).
+		Status(http.StatusOK).
+		Bodyf(`{"success":true,"result":[{"id":"%s","etag":"\"1\"","name":"%s","type":"password",`+
+			`"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z"}]}`, testID, testName).
+		End()
+}
+
+func TestMatch_Fails(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{name: "invalid url", err: domain.ErrInvalidURL, status: http.StatusUnprocessableEntity},
+		{name: "disabled", err: domain.ErrMatchDisabled, status: http.StatusNotImplemented},
+		{name: "other", err: testutils.Err, status: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			root := gin.Default()
+			service := mocks.NewMockService(ctrl)
+			secrets.AddRoutes(&root.RouterGroup, service, guardMock)
+
+			service.EXPECT().
+				Match(gomock.Any(), testOwnerID, testURL).
+				Return(nil, tt.err)
+
+			apitest.New(tt.name).
+				Handler(root.Handler()).
+				Debug().
+				Get("/secrets/match").
+				Query("url", testURL).
+				Expect(t).
+				Status(tt.status).
+				End()
+		})
+	}
+}
+
+func TestMatch_Fails_Validate(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	root := gin.Default()
+	secrets.AddRoutes(&root.RouterGroup, mocks.NewMockService(ctrl), guardMock)
+
+	apitest.New().
+		Handler(root.Handler()).
+		Debug().
+		Get("/secrets/match").
+		Expect(t).
+		Status(http.StatusUnprocessableEntity).
+		End()
+}
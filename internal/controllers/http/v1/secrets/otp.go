@@ -0,0 +1,150 @@
+package secrets
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/novoseltcev/passkeeper/internal/auth"
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/common/response"
+	domain "github.com/novoseltcev/passkeeper/internal/domains/secrets"
+	"github.com/novoseltcev/passkeeper/internal/models"
+	"github.com/novoseltcev/passkeeper/pkg/otp"
+)
+
+func init() { // nolint: gochecknoinits
+	Register(Type{
+		ID:   models.SecretTypeOTP,
+		Name: "otp",
+		New:  func() Payload { return &OTPSecretData{} },
+		Fields: []Field{
+			{Key: "uri", Label: "URI", Kind: FieldKindText, CreateOnly: true},
+			{Key: "secret", Label: "Secret", Kind: FieldKindConcealed},
+			{Key: "kind", Label: "Kind", Kind: FieldKindSelect, Options: []string{"", otp.KindTOTP, otp.KindHOTP}},
+			{Key: "counter", Label: "Counter", Kind: FieldKindNumber},
+			{
+				Key:     "algorithm",
+				Label:   "Algorithm",
+				Kind:    FieldKindSelect,
+				Options: []string{"", otp.AlgorithmSHA1, otp.AlgorithmSHA256, otp.AlgorithmSHA512},
+			},
+			{Key: "digits", Label: "Digits", Kind: FieldKindNumber},
+			{Key: "period", Label: "Period", Kind: FieldKindNumber},
+		},
+	})
+}
+
+type OTPSecretData struct {
+	Passphrase string         `binding:"required"`
+	Name       string         `binding:"required,min=4,max=32"`
+	URI        string         `binding:"required_without=Secret,excluded_with=Secret,omitempty,uri,startswith=otpauth://"` // nolint: lll
+	Secret     string         `binding:"required_without=URI,omitempty,base32"`
+	Kind       string         `binding:"omitempty,oneof=totp hotp"`
+	Counter    uint64         `binding:""`
+	Algorithm  string         `binding:"omitempty,oneof=SHA1 SHA256 SHA512"`
+	Digits     int            `binding:"omitempty,min=6,max=8"`
+	Period     int            `binding:"omitempty,min=1,max=300"`
+	Meta       map[string]any `binding:"required"`
+	AttrsData
+}
+
+func (d *OTPSecretData) Credentials() (string, string) {
+	return d.Passphrase, d.Name
+}
+
+// ToData converts the request body into domain data.
+//
+// The otpauth URI takes precedence, explicit parameters override its defaults otherwise.
+func (d *OTPSecretData) ToData() (domain.ISecretData, error) {
+	key := otp.NewKey(d.Secret)
+
+	if d.URI != "" {
+		var err error
+		if key, err = otp.ParseURI(d.URI); err != nil {
+			return nil, fmt.Errorf("%w: %w", domain.ErrInvalidSecretData, err)
+		}
+	}
+
+	if d.Kind != "" {
+		key.Kind = d.Kind
+	}
+
+	if d.Counter != 0 {
+		key.Counter = d.Counter
+	}
+
+	if d.Algorithm != "" {
+		key.Algorithm = d.Algorithm
+	}
+
+	if d.Digits != 0 {
+		key.Digits = d.Digits
+	}
+
+	if d.Period != 0 {
+		key.Period = d.Period
+	}
+
+	return &domain.OTPData{
+		Kind:      key.Kind,
+		Secret:    key.Secret,
+		Algorithm: key.Algorithm,
+		Digits:    key.Digits,
+		Period:    key.Period,
+		Counter:   key.Counter,
+		Issuer:    key.Issuer,
+		Account:   key.Account,
+		Meta:      d.Meta,
+	}, nil
+}
+
+func GenerateOTP(service domain.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ownerID := auth.GetUserID(c)
+		id := models.SecretID(c.Param("id"))
+
+		var body DecryptByIDData
+		if err := c.ShouldBindJSON(&body); err != nil {
+			var vErr validator.ValidationErrors
+			if errors.As(err, &vErr) {
+				c.JSON(http.StatusUnprocessableEntity, response.NewValidationError(vErr))
+			} else {
+				c.JSON(http.StatusBadRequest, response.NewError(err))
+			}
+
+			return
+		}
+
+		code, err := service.GenerateOTP(c, id, ownerID, body.Passphrase)
+		if err != nil {
+			if errors.Is(err, domain.ErrSecretNotFound) {
+				c.AbortWithStatus(http.StatusNotFound)
+			} else if errors.Is(err, domain.ErrAnotherOwner) || errors.Is(err, domain.ErrReadOnly) {
+				c.AbortWithStatus(http.StatusForbidden)
+			} else if errors.Is(err, domain.ErrInvalidPassphrase) || errors.Is(err, domain.ErrInvalidSecretType) {
+				c.AbortWithStatus(http.StatusConflict)
+			} else {
+				c.AbortWithError(http.StatusInternalServerError, err)
+			}
+
+			return
+		}
+
+		var remaining int64
+		if !code.ExpiresAt.IsZero() {
+			remaining = int64(math.Ceil(time.Until(code.ExpiresAt).Seconds()))
+		}
+
+		c.JSON(http.StatusOK, response.NewSuccess(&OTPSchema{Code: code.Code, Remaining: remaining}))
+	}
+}
+
+type OTPSchema struct {
+	Code      string `json:"code"`
+	Remaining int64  `json:"remaining"`
+}
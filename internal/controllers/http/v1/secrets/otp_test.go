@@ -0,0 +1,138 @@
+package secrets_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/steinfletcher/apitest"
+	"go.uber.org/mock/gomock"
+
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/v1/secrets"
+	domain "github.com/novoseltcev/passkeeper/internal/domains/secrets"
+	"github.com/novoseltcev/passkeeper/internal/domains/secrets/mocks"
+	"github.com/novoseltcev/passkeeper/pkg/testutils"
+)
+
+func TestGenerateOTP_Success(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	tests := []struct {
+		name      string
+		expiresAt time.Time
+		remaining int
+	}{
+		{
+			name:      "totp",
+			expiresAt: time.Now().Add(10*time.Second + 100*time.Millisecond),
+			remaining: 11,
+		},
+		{
+			name:      "hotp",
+			remaining: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			root := gin.Default()
+			service := mocks.NewMockService(ctrl)
+			secrets.AddRoutes(&root.RouterGroup, service, guardMock)
+
+			service.EXPECT().
+				GenerateOTP(gomock.Any(), testID, testOwnerID, testPassphrase).
+				Return(&domain.OTPCode{Code: "123456", ExpiresAt: tt.expiresAt}, nil)
+
+			apitest.New(tt.name).
+				Handler(root.Handler()).
+				Debug().
+				Postf("/secrets/%s/otp", testID).
+				Bodyf(`{"passphrase":"%s"}`, testPassphrase).
+				Expect(t).
+				Status(http.StatusOK).
+				Bodyf(`{"success":true,"result":{"code":"123456","remaining":%d}}`, tt.remaining).
+				End()
+		})
+	}
+}
+
+func TestGenerateOTP_Fails_Validate(t *testing.T) {
+	t.Parallel()
+
+	root := gin.Default()
+	secrets.AddRoutes(&root.RouterGroup, nil, guardMock)
+
+	result := apitest.Handler(root.Handler()).
+		Debug().
+		Postf("/secrets/%s/otp", testID).
+		Body(`{}`).
+		Expect(t).
+		Status(http.StatusUnprocessableEntity).
+		End()
+
+	checkErrors(t, result, []string{"Field validation for 'Passphrase' failed on the 'required' tag"})
+}
+
+func TestGenerateOTP_Fails_Generate(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{
+			name:   "not found",
+			err:    domain.ErrSecretNotFound,
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "not mine",
+			err:    domain.ErrAnotherOwner,
+			status: http.StatusForbidden,
+		},
+		{
+			name:   "invalid passphrase",
+			err:    domain.ErrInvalidPassphrase,
+			status: http.StatusConflict,
+		},
+		{
+			name:   "not otp",
+			err:    domain.ErrInvalidSecretType,
+			status: http.StatusConflict,
+		},
+		{
+			name:   "other",
+			err:    testutils.Err,
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			root := gin.Default()
+			service := mocks.NewMockService(ctrl)
+			secrets.AddRoutes(&root.RouterGroup, service, guardMock)
+
+			service.EXPECT().
+				GenerateOTP(gomock.Any(), testID, testOwnerID, testPassphrase).
+				Return(nil, tt.err)
+
+			apitest.New(tt.name).
+				Handler(root.Handler()).
+				Debug().
+				Postf("/secrets/%s/otp", testID).
+				Bodyf(`{"passphrase":"%s"}`, testPassphrase).
+				Expect(t).
+				Status(tt.status).
+				End()
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package secrets
+
+import (
+	domain "github.com/novoseltcev/passkeeper/internal/domains/secrets"
+	"github.com/novoseltcev/passkeeper/internal/models"
+	"github.com/novoseltcev/passkeeper/pkg/urimatch"
+)
+
+func init() { // nolint: gochecknoinits
+	Register(Type{
+		ID:   models.SecretTypePwd,
+		Name: "password",
+		New:  func() Payload { return &PasswordSecretData{} },
+		Fields: []Field{
+			{Key: "login", Label: "Login", Kind: FieldKindText},
+			{Key: "password", Label: "Password", Kind: FieldKindConcealed},
+			{Key: "uris", Label: "URIs", Kind: FieldKindURIs},
+		},
+	})
+}
+
+type PasswordSecretData struct {
+	Passphrase string         `binding:"required"`
+	Name       string         `binding:"required,min=4,max=32"`
+	Login      string         `binding:"required"`
+	Password   string         `binding:"required"`
+	URIs       []URIData      `binding:"omitempty,dive" json:"uris"`
+	Meta       map[string]any `binding:"required"`
+	AttrsData
+}
+
+// URIData is a website of the credentials, the match strategy is "domain" by default.
+type URIData struct {
+	URI   string `binding:"required"                                 json:"uri"`
+	Match string `binding:"omitempty,oneof=domain host prefix regex" json:"match,omitempty"`
+}
+
+func (d *PasswordSecretData) Credentials() (string, string) {
+	return d.Passphrase, d.Name
+}
+
+func (d *PasswordSecretData) ToData() (domain.ISecretData, error) {
+	var uris []domain.URI
+	for _, uri := range d.URIs {
+		uris = append(uris, domain.URI{URI: uri.URI, Match: urimatch.Strategy(uri.Match)})
+	}
+
+	return &domain.PasswordData{
+		Login:    d.Login,
+		Password: d.Password,
+		URIs:     uris,
+		Meta:     d.Meta,
+	}, nil
+}
@@ -0,0 +1,158 @@
+package secrets
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+
+	domain "github.com/novoseltcev/passkeeper/internal/domains/secrets"
+	"github.com/novoseltcev/passkeeper/internal/models"
+)
+
+// Payload is a request body to create or update a secret of some type.
+type Payload interface {
+	// Credentials returns the passphrase of the owner and the name of the secret.
+	Credentials() (passphrase, name string)
+
+	// ToData converts the body into domain data.
+	ToData() (domain.ISecretData, error)
+
+	// Attrs returns the unencrypted attributes of the secret.
+	Attrs() domain.Attrs
+}
+
+type FieldKind string
+
+const (
+	FieldKindText      FieldKind = "text"
+	FieldKindConcealed FieldKind = "concealed"
+	FieldKindMultiline FieldKind = "multiline"
+	FieldKindNumber    FieldKind = "number"
+	FieldKindSelect    FieldKind = "select"
+	// FieldKindTemplate is a template reference expanded into the fields of the template.
+	FieldKindTemplate FieldKind = "template"
+	// FieldKindURIs is a list of website URIs with their match strategies.
+	FieldKindURIs FieldKind = "uris"
+)
+
+// Field describes an input of a secret type for clients.
+//
+// The passphrase, the name and the meta are common to all types and aren't described.
+type Field struct {
+	// Key is the key in the request body.
+	Key string
+	// DataKey is the key in the decrypted data, if it differs from Key.
+	DataKey string
+	Label   string
+	Kind    FieldKind
+	Options []string
+	// CreateOnly fields are not filled from the decrypted data on update.
+	CreateOnly bool
+}
+
+// SourceKey returns the key of the field in the decrypted data.
+func (f Field) SourceKey() string {
+	if f.DataKey != "" {
+		return f.DataKey
+	}
+
+	return f.Key
+}
+
+// Type is a registered secret type.
+//
+// Routes, client calls and forms are built from the registered types,
+// so adding a type means describing it in its own file.
+type Type struct {
+	ID   models.SecretType
+	Name string
+	// New returns a pointer to an empty request body of the type.
+	New    func() Payload
+	Fields []Field
+}
+
+var ErrUnregisteredType = errors.New("secret type is not registered")
+
+var (
+	types   = make(map[models.SecretType]*Type)
+	payload = make(map[reflect.Type]*Type)
+)
+
+// Register registers a secret type.
+//
+// It must be called on init, it panics if the type is already registered.
+func Register(t Type) {
+	models.RegisterSecretType(t.ID, t.Name)
+
+	payloadType := reflect.TypeOf(t.New())
+	if _, ok := payload[payloadType]; ok {
+		panic(fmt.Sprintf("payload %s is already registered", payloadType))
+	}
+
+	types[t.ID] = &t
+	payload[payloadType] = &t
+}
+
+// Types returns all registered types ordered by ID.
+func Types() []*Type {
+	result := make([]*Type, 0, len(types))
+	for _, t := range types {
+		result = append(result, t)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+
+	return result
+}
+
+// Lookup returns the registered type with the name.
+func Lookup(name string) (*Type, bool) {
+	id, ok := models.ParseSecretType(name)
+	if !ok {
+		return nil, false
+	}
+
+	t, ok := types[id]
+
+	return t, ok
+}
+
+// TypeOf returns the registered type of the request body.
+func TypeOf(body Payload) (*Type, bool) {
+	t, ok := payload[reflect.TypeOf(body)]
+
+	return t, ok
+}
+
+// TypeNames returns the names of all registered types ordered by ID.
+func TypeNames() []string {
+	registered := Types()
+
+	names := make([]string, len(registered))
+	for i, t := range registered {
+		names[i] = t.Name
+	}
+
+	return names
+}
+
+// Check checks that the types are registered with their names and their domain data alike,
+// as each of them is registered on init of its own package.
+func Check() error {
+	var errs []error
+
+	for _, t := range Types() {
+		if _, ok := domain.NewData(t.ID); !ok {
+			errs = append(errs, fmt.Errorf("%w: no data of %s", ErrUnregisteredType, t.Name))
+		}
+	}
+
+	for _, id := range domain.DataTypes() {
+		if _, ok := types[id]; !ok {
+			errs = append(errs, fmt.Errorf("%w: no request body of data %d", ErrUnregisteredType, id))
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,54 @@
+package secrets_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/v1/secrets"
+	"github.com/novoseltcev/passkeeper/internal/models"
+)
+
+func TestRegistry(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t,
+		[]string{"password", "card", "text", "file", "otp", "ssh_key", "custom"},
+		secrets.TypeNames(),
+	)
+
+	for _, registered := range secrets.Types() {
+		assert.Equal(t, registered.Name, registered.ID.String())
+
+		found, ok := secrets.Lookup(registered.Name)
+		require.True(t, ok)
+		assert.Equal(t, registered, found)
+
+		found, ok = secrets.TypeOf(registered.New())
+		require.True(t, ok)
+		assert.Equal(t, registered, found)
+	}
+
+	_, ok := secrets.Lookup("unknown")
+	assert.False(t, ok)
+	assert.Equal(t, "unknown", models.SecretType(0).String())
+}
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, secrets.Check())
+}
+
+func TestRegister_Fails_Duplicate(t *testing.T) {
+	t.Parallel()
+
+	assert.Panics(t, func() {
+		secrets.Register(secrets.Type{
+			ID:   models.SecretTypePwd,
+			Name: "password",
+			New:  func() secrets.Payload { return &secrets.PasswordSecretData{} },
+		})
+	})
+}
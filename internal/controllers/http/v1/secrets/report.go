@@ -0,0 +1,166 @@
+package secrets
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/novoseltcev/passkeeper/internal/auth"
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/common/response"
+	domain "github.com/novoseltcev/passkeeper/internal/domains/secrets"
+	"github.com/novoseltcev/passkeeper/internal/models"
+)
+
+const (
+	defaultStaleMonths  = 12
+	defaultExpiringDays = 30
+	defaultMinEntropy   = 60
+)
+
+type ReportData struct {
+	Passphrase   string  `binding:"required"                json:"passphrase"`
+	StaleMonths  int     `binding:"omitempty,min=1,max=120" json:"stale_months"`
+	ExpiringDays int     `binding:"omitempty,min=1,max=365" json:"expiring_days"`
+	MinEntropy   float64 `binding:"omitempty,min=1"         json:"min_entropy"`
+}
+
+func (d *ReportData) options() domain.ReportOptions {
+	if d.StaleMonths == 0 {
+		d.StaleMonths = defaultStaleMonths
+	}
+
+	if d.ExpiringDays == 0 {
+		d.ExpiringDays = defaultExpiringDays
+	}
+
+	if d.MinEntropy == 0 {
+		d.MinEntropy = defaultMinEntropy
+	}
+
+	now := time.Now()
+
+	return domain.ReportOptions{
+		MinEntropy:     d.MinEntropy,
+		StaleAfter:     now.Sub(now.AddDate(0, -d.StaleMonths, 0)),
+		ExpiringWithin: time.Duration(d.ExpiringDays) * 24 * time.Hour,
+	}
+}
+
+func Report(service domain.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ownerID := auth.GetUserID(c)
+
+		var body ReportData
+		if err := c.ShouldBindJSON(&body); err != nil {
+			var vErr validator.ValidationErrors
+			if errors.As(err, &vErr) {
+				c.JSON(http.StatusUnprocessableEntity, response.NewValidationError(vErr))
+			} else {
+				c.JSON(http.StatusBadRequest, response.NewError(err))
+			}
+
+			return
+		}
+
+		report, err := service.Report(c, ownerID, body.Passphrase, body.options())
+		if err != nil {
+			if errors.Is(err, domain.ErrInvalidPassphrase) {
+				c.AbortWithStatus(http.StatusConflict)
+			} else {
+				c.AbortWithError(http.StatusInternalServerError, err)
+			}
+
+			return
+		}
+
+		c.JSON(http.StatusOK, response.NewSuccess(newReportSchema(report)))
+	}
+}
+
+type SecretRefSchema struct {
+	ID   models.SecretID `json:"id"`
+	Name string          `json:"name"`
+}
+
+type WeakPasswordSchema struct {
+	SecretRefSchema
+	Entropy float64 `json:"entropy"`
+}
+
+type BreachedPasswordSchema struct {
+	SecretRefSchema
+	Count int `json:"count"`
+}
+
+type StaleSecretSchema struct {
+	SecretRefSchema
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type CardExpirationSchema struct {
+	SecretRefSchema
+	ExpiresAt time.Time `json:"expires_at"`
+	Expired   bool      `json:"expired"`
+}
+
+type ReportSchema struct {
+	Weak          []WeakPasswordSchema     `json:"weak"`
+	Breached      []BreachedPasswordSchema `json:"breached"`
+	BreachChecked bool                     `json:"breach_checked"`
+	Reused        [][]SecretRefSchema      `json:"reused"`
+	Stale         []StaleSecretSchema      `json:"stale"`
+	Cards         []CardExpirationSchema   `json:"cards"`
+}
+
+func newSecretRefSchema(ref domain.SecretRef) SecretRefSchema {
+	return SecretRefSchema{ID: ref.ID, Name: ref.Name}
+}
+
+func newReportSchema(report *domain.Report) *ReportSchema {
+	schema := &ReportSchema{
+		Weak:          make([]WeakPasswordSchema, len(report.Weak)),
+		Breached:      make([]BreachedPasswordSchema, len(report.Breached)),
+		BreachChecked: report.BreachChecked,
+		Reused:        make([][]SecretRefSchema, len(report.Reused)),
+		Stale:         make([]StaleSecretSchema, len(report.Stale)),
+		Cards:         make([]CardExpirationSchema, len(report.Cards)),
+	}
+
+	for i, weak := range report.Weak {
+		schema.Weak[i] = WeakPasswordSchema{SecretRefSchema: newSecretRefSchema(weak.SecretRef), Entropy: weak.Entropy}
+	}
+
+	for i, breached := range report.Breached {
+		schema.Breached[i] = BreachedPasswordSchema{
+			SecretRefSchema: newSecretRefSchema(breached.SecretRef),
+			Count:           breached.Count,
+		}
+	}
+
+	for i, group := range report.Reused {
+		schema.Reused[i] = make([]SecretRefSchema, len(group))
+		for j, ref := range group {
+			schema.Reused[i][j] = newSecretRefSchema(ref)
+		}
+	}
+
+	for i, stale := range report.Stale {
+		schema.Stale[i] = StaleSecretSchema{
+			SecretRefSchema: newSecretRefSchema(stale.SecretRef),
+			UpdatedAt:       stale.UpdatedAt,
+		}
+	}
+
+	for i, card := range report.Cards {
+		schema.Cards[i] = CardExpirationSchema{
+			SecretRefSchema: newSecretRefSchema(card.SecretRef),
+			ExpiresAt:       card.ExpiresAt,
+			Expired:         card.Expired,
+		}
+	}
+
+	return schema
+}
@@ -0,0 +1,127 @@
+package secrets_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/steinfletcher/apitest"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/v1/secrets"
+	domain "github.com/novoseltcev/passkeeper/internal/domains/secrets"
+	"github.com/novoseltcev/passkeeper/internal/domains/secrets/mocks"
+	"github.com/novoseltcev/passkeeper/internal/models"
+	"github.com/novoseltcev/passkeeper/pkg/testutils"
+)
+
+func TestReport_Success(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	root := gin.Default()
+	service := mocks.NewMockService(ctrl)
+	secrets.AddRoutes(&root.RouterGroup, service, guardMock)
+
+	expiresAt := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	service.EXPECT().
+		Report(gomock.Any(), testOwnerID, testPassphrase, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ models.UserID, _ string, opts domain.ReportOptions) (*domain.Report, error) {
+			assert.InDelta(t, 60, opts.MinEntropy, 0)
+			assert.Equal(t, 7*24*time.Hour, opts.ExpiringWithin)
+
+			return &domain.Report{
+				Weak:          []domain.WeakPassword{{SecretRef: domain.SecretRef{ID: "1", Name: "a"}, Entropy: 12.5}},
+				Breached:      []domain.BreachedPassword{{SecretRef: domain.SecretRef{ID: "1", Name: "a"}, Count: 3}},
+				BreachChecked: true,
+				Reused:        [][]domain.SecretRef{{{ID: "2", Name: "b"}, {ID: "3", Name: "c"}}},
+				Cards: []domain.CardExpiration{
+					{SecretRef: domain.SecretRef{ID: "4", Name: "d"}, ExpiresAt: expiresAt, Expired: true},
+				},
+			}, nil
+		})
+
+	apitest.New().
+		Handler(root.Handler()).
+		Debug().
+		Post("/secrets/report").
+		Bodyf(`{"passphrase":"%s","expiring_days":7}`, testPassphrase).
+		Expect(t).
+		Status(http.StatusOK).
+		Body(`{"success":true,"result":{` +
+			`"weak":[{"id":"1","name":"a","entropy":12.5}],` +
+			`"breached":[{"id":"1","name":"a","count":3}],"breach_checked":true,` +
+			`"reused":[[{"id":"2","name":"b"},{"id":"3","name":"c"}]],` +
+			`"stale":[],` +
+			`"cards":[{"id":"4","name":"d","expires_at":"2024-02-01T00:00:00Z","expired":true}]}}`).
+		End()
+}
+
+func TestReport_Fails_Validate(t *testing.T) {
+	t.Parallel()
+
+	root := gin.Default()
+	secrets.AddRoutes(&root.RouterGroup, nil, guardMock)
+
+	result := apitest.Handler(root.Handler()).
+		Debug().
+		Post("/secrets/report").
+		Body(`{"stale_months":0,"expiring_days":1000}`).
+		Expect(t).
+		Status(http.StatusUnprocessableEntity).
+		End()
+
+	checkErrors(t, result, []string{
+		"Field validation for 'Passphrase' failed on the 'required' tag",
+		"Field validation for 'ExpiringDays' failed on the 'max' tag",
+	})
+}
+
+func TestReport_Fails_Report(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{
+			name:   "invalid passphrase",
+			err:    domain.ErrInvalidPassphrase,
+			status: http.StatusConflict,
+		},
+		{
+			name:   "other",
+			err:    testutils.Err,
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			root := gin.Default()
+			service := mocks.NewMockService(ctrl)
+			secrets.AddRoutes(&root.RouterGroup, service, guardMock)
+
+			service.EXPECT().
+				Report(gomock.Any(), testOwnerID, testPassphrase, gomock.Any()).
+				Return(nil, tt.err)
+
+			apitest.New(tt.name).
+				Handler(root.Handler()).
+				Debug().
+				Post("/secrets/report").
+				Bodyf(`{"passphrase":"%s"}`, testPassphrase).
+				Expect(t).
+				Status(tt.status).
+				End()
+		})
+	}
+}
@@ -6,21 +6,38 @@ import (
 	"github.com/novoseltcev/passkeeper/internal/domains/secrets"
 )
 
+// AddRoutes adds the routes of the secrets, it panics if the registered types don't agree.
 func AddRoutes(rg *gin.RouterGroup, service secrets.Service, guard gin.HandlerFunc) {
+	if err := Check(); err != nil {
+		panic(err)
+	}
+
+	rg.GET("/user/usage", guard, GetUsage(service))
+	rg.POST("/vault/export", guard, Export(service))
+	rg.POST("/vault/import", guard, Import(service))
+
 	secretGroup := rg.Group("/secrets", guard)
 	{
 		secretGroup.GET("", GetPage(service))
+		secretGroup.GET("/due", GetDue(service))
+		secretGroup.GET("/shared", GetSharedWithMe(service))
+		secretGroup.GET("/match", Match(service))
 		secretGroup.POST("/:id/decrypt", DecryptByID(service))
+		secretGroup.POST("/:id/otp", GenerateOTP(service))
+		secretGroup.POST("/:id/download", DownloadFile(service))
+		secretGroup.GET("/:id/shares", GetShares(service))
+		secretGroup.POST("/:id/shares", Share(service))
+		secretGroup.DELETE("/:id/shares/:user_id", Revoke(service))
 		secretGroup.DELETE("/:id", Delete(service))
+		secretGroup.POST("/ssh_key/generate", GenerateSSHKey(service))
+		secretGroup.POST("/report", Report(service))
+		secretGroup.POST("/batch", Batch(service))
+		secretGroup.POST("/file/upload", UploadFile(service))
+		secretGroup.PUT("/file/:id/upload", ReuploadFile(service))
 
-		secretGroup.POST("/password", AddPassword(service))
-		secretGroup.POST("/card", AddCard(service))
-		secretGroup.POST("/file", AddFile(service))
-		secretGroup.POST("/text", AddText(service))
-
-		secretGroup.PUT("/password/:id", UpdatePassword(service))
-		secretGroup.PUT("/card/:id", UpdateCard(service))
-		secretGroup.PUT("/file/:id", UpdateFile(service))
-		secretGroup.PUT("/text/:id", UpdateText(service))
+		for _, t := range Types() {
+			secretGroup.POST("/"+t.Name, Add(service, t))
+			secretGroup.PUT("/"+t.Name+"/:id", Update(service, t))
+		}
 	}
 }
@@ -101,7 +101,7 @@ func TestValidation_CardSecretData_Fails(t *testing.T) {
 			},
 		},
 		{
-			name: "len(name < 4, len(cvv) < 3, number is not credit card, exp is not date",
+			name: "len(name) < 4",
 			data: &secrets.CardSecretData{
 				Name:       "123",
 				CVV:        "12",
@@ -110,15 +110,10 @@ func TestValidation_CardSecretData_Fails(t *testing.T) {
 				Passphrase: " ",
 				Meta:       map[string]any{},
 			},
-			errs: []string{
-				"Field validation for 'Name' failed on the 'min' tag",
-				"Field validation for 'CVV' failed on the 'min' tag",
-				"Field validation for 'Number' failed on the 'credit_card' tag",
-				"Field validation for 'Exp' failed on the 'datetime' tag",
-			},
+			errs: []string{"Field validation for 'Name' failed on the 'min' tag"},
 		},
 		{
-			name: "len(name) > 32, len(cvv) > 4, exp mismatch layout",
+			name: "len(name) > 32",
 			data: &secrets.CardSecretData{
 				Name:       strings.Repeat("a", 33),
 				CVV:        "12345",
@@ -127,23 +122,7 @@ func TestValidation_CardSecretData_Fails(t *testing.T) {
 				Number:     "4111111111111111",
 				Meta:       map[string]any{},
 			},
-			errs: []string{
-				"Field validation for 'Name' failed on the 'max' tag",
-				"Field validation for 'CVV' failed on the 'max' tag",
-				"Field validation for 'Exp' failed on the 'datetime' tag",
-			},
-		},
-		{
-			name: "cvv is not numeric",
-			data: &secrets.CardSecretData{
-				CVV:        "12a",
-				Passphrase: " ",
-				Name:       "test",
-				Number:     "4111111111111111",
-				Exp:        "12/25",
-				Meta:       map[string]any{},
-			},
-			errs: []string{"Field validation for 'CVV' failed on the 'numeric' tag"},
+			errs: []string{"Field validation for 'Name' failed on the 'max' tag"},
 		},
 	}
 
@@ -263,3 +242,117 @@ func TestValidation_FileSecretData_Fails(t *testing.T) {
 		})
 	}
 }
+
+func TestValidation_OTPSecretData_Fails(t *testing.T) {
+	t.Parallel()
+	validate := validator.New()
+	validate.SetTagName("binding")
+
+	tests := []struct {
+		name string
+		data *secrets.OTPSecretData
+		errs []string
+	}{
+		{
+			name: "empty",
+			data: &secrets.OTPSecretData{},
+			errs: []string{
+				"Field validation for 'Passphrase' failed on the 'required' tag",
+				"Field validation for 'Name' failed on the 'required' tag",
+				"Field validation for 'URI' failed on the 'required_without' tag",
+				"Field validation for 'Secret' failed on the 'required_without' tag",
+				"Field validation for 'Meta' failed on the 'required' tag",
+			},
+		},
+		{
+			name: "uri with secret",
+			data: &secrets.OTPSecretData{
+				Name:       "test",
+				Passphrase: " ",
+				URI:        "otpauth://totp/label?secret=JBSWY3DPEHPK3PXP",
+				Secret:     "JBSWY3DPEHPK3PXP",
+				Meta:       map[string]any{},
+			},
+			errs: []string{"Field validation for 'URI' failed on the 'excluded_with' tag"},
+		},
+		{
+			name: "uri is not otpauth",
+			data: &secrets.OTPSecretData{
+				Name:       "test",
+				Passphrase: " ",
+				URI:        "https://example.com",
+				Meta:       map[string]any{},
+			},
+			errs: []string{"Field validation for 'URI' failed on the 'startswith' tag"},
+		},
+		{
+			name: "secret is not base32, unsupported parameters",
+			data: &secrets.OTPSecretData{
+				Name:       "test",
+				Passphrase: " ",
+				Secret:     "not-base32",
+				Algorithm:  "MD5",
+				Digits:     4,
+				Period:     301,
+				Meta:       map[string]any{},
+			},
+			errs: []string{
+				"Field validation for 'Secret' failed on the 'base32' tag",
+				"Field validation for 'Algorithm' failed on the 'oneof' tag",
+				"Field validation for 'Digits' failed on the 'min' tag",
+				"Field validation for 'Period' failed on the 'max' tag",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			checkValidationErrors(t, validate.Struct(tt.data), tt.errs)
+		})
+	}
+}
+
+func TestValidation_SSHKeySecretData_Fails(t *testing.T) {
+	t.Parallel()
+	validate := validator.New()
+	validate.SetTagName("binding")
+
+	tests := []struct {
+		name string
+		data *secrets.SSHKeySecretData
+		errs []string
+	}{
+		{
+			name: "empty",
+			data: &secrets.SSHKeySecretData{},
+			errs: []string{
+				"Field validation for 'Passphrase' failed on the 'required' tag",
+				"Field validation for 'Name' failed on the 'required' tag",
+				"Field validation for 'PrivateKey' failed on the 'required' tag",
+				"Field validation for 'PublicKey' failed on the 'required' tag",
+				"Field validation for 'Meta' failed on the 'required' tag",
+			},
+		},
+		{
+			name: "len(name) > 32",
+			data: &secrets.SSHKeySecretData{
+				Name:       strings.Repeat("a", 33),
+				Passphrase: " ",
+				PrivateKey: " ",
+				PublicKey:  " ",
+				Meta:       map[string]any{},
+			},
+			errs: []string{"Field validation for 'Name' failed on the 'max' tag"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			checkValidationErrors(t, validate.Struct(tt.data), tt.errs)
+		})
+	}
+}
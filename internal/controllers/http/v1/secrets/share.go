@@ -0,0 +1,176 @@
+package secrets
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/novoseltcev/passkeeper/internal/auth"
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/common/response"
+	domain "github.com/novoseltcev/passkeeper/internal/domains/secrets"
+	"github.com/novoseltcev/passkeeper/internal/models"
+)
+
+type ShareData struct {
+	Passphrase string `binding:"required"`
+	Login      string `binding:"required"`
+	Access     string `binding:"required,oneof=read write"`
+}
+
+// Share shares a secret with another user.
+func Share(service domain.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ownerID := auth.GetUserID(c)
+		id := models.SecretID(c.Param("id"))
+
+		var body ShareData
+		if err := c.ShouldBindJSON(&body); err != nil {
+			var vErr validator.ValidationErrors
+			if errors.As(err, &vErr) {
+				c.JSON(http.StatusUnprocessableEntity, response.NewValidationError(vErr))
+			} else {
+				c.JSON(http.StatusBadRequest, response.NewError(err))
+			}
+
+			return
+		}
+
+		err := service.Share(c, id, ownerID, body.Passphrase, body.Login, models.ShareAccess(body.Access))
+		if err != nil {
+			if errors.Is(err, domain.ErrSecretNotFound) {
+				c.AbortWithStatus(http.StatusNotFound)
+			} else if errors.Is(err, domain.ErrAnotherOwner) {
+				c.AbortWithStatus(http.StatusForbidden)
+			} else if errors.Is(err, domain.ErrInvalidPassphrase) {
+				c.AbortWithStatus(http.StatusConflict)
+			} else if errors.Is(err, domain.ErrNoPublicKey) {
+				c.JSON(http.StatusConflict, response.NewError(err))
+			} else if errors.Is(err, domain.ErrRecipientNotFound) || errors.Is(err, domain.ErrInvalidRecipient) {
+				c.JSON(http.StatusUnprocessableEntity, response.NewError(err))
+			} else {
+				c.AbortWithError(http.StatusInternalServerError, err)
+			}
+
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// GetShares lists the users a secret is shared with.
+func GetShares(service domain.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ownerID := auth.GetUserID(c)
+		id := models.SecretID(c.Param("id"))
+
+		shares, err := service.GetShares(c, id, ownerID)
+		if err != nil {
+			if errors.Is(err, domain.ErrSecretNotFound) {
+				c.AbortWithStatus(http.StatusNotFound)
+			} else if errors.Is(err, domain.ErrAnotherOwner) {
+				c.AbortWithStatus(http.StatusForbidden)
+			} else {
+				c.AbortWithError(http.StatusInternalServerError, err)
+			}
+
+			return
+		}
+
+		schemas := make([]ShareSchema, len(shares))
+		for i, share := range shares {
+			schemas[i] = ShareSchema{
+				UserID: string(share.Recipient.ID),
+				Login:  share.Recipient.Login,
+				Access: string(share.Access),
+			}
+		}
+
+		c.JSON(http.StatusOK, response.NewSuccess(&schemas))
+	}
+}
+
+type ShareSchema struct {
+	UserID string `json:"user_id"`
+	Login  string `json:"login"`
+	Access string `json:"access"`
+}
+
+type RevokeData struct {
+	Passphrase string `binding:"required"`
+}
+
+// Revoke revokes the access of a user to a secret.
+func Revoke(service domain.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ownerID := auth.GetUserID(c)
+		id := models.SecretID(c.Param("id"))
+		recipientID := models.UserID(c.Param("user_id"))
+
+		var body RevokeData
+		if err := c.ShouldBindJSON(&body); err != nil {
+			var vErr validator.ValidationErrors
+			if errors.As(err, &vErr) {
+				c.JSON(http.StatusUnprocessableEntity, response.NewValidationError(vErr))
+			} else {
+				c.JSON(http.StatusBadRequest, response.NewError(err))
+			}
+
+			return
+		}
+
+		err := service.Revoke(c, id, ownerID, body.Passphrase, recipientID)
+		if err != nil {
+			if errors.Is(err, domain.ErrSecretNotFound) || errors.Is(err, domain.ErrShareNotFound) {
+				c.AbortWithStatus(http.StatusNotFound)
+			} else if errors.Is(err, domain.ErrAnotherOwner) {
+				c.AbortWithStatus(http.StatusForbidden)
+			} else if errors.Is(err, domain.ErrInvalidPassphrase) {
+				c.AbortWithStatus(http.StatusConflict)
+			} else {
+				c.AbortWithError(http.StatusInternalServerError, err)
+			}
+
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// GetSharedWithMe lists the secrets of other users shared with the user.
+func GetSharedWithMe(service domain.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		userID := auth.GetUserID(c)
+
+		shares, err := service.GetSharedWithMe(c, userID)
+		if err != nil {
+			c.AbortWithError(http.StatusInternalServerError, err)
+
+			return
+		}
+
+		schemas := make([]SharedSecretSchema, len(shares))
+		for i, share := range shares {
+			schemas[i] = SharedSecretSchema{
+				ID:     string(share.Secret.ID),
+				Name:   share.Secret.Name,
+				Type:   share.Secret.Type.String(),
+				Owner:  share.Secret.Owner.Login,
+				Access: string(share.Access),
+			}
+		}
+
+		c.JSON(http.StatusOK, response.NewSuccess(&schemas))
+	}
+}
+
+type SharedSecretSchema struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	Owner  string `json:"owner"` // login of the owner
+	Access string `json:"access"`
+}
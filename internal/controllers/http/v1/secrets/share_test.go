@@ -0,0 +1,327 @@
+package secrets_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/steinfletcher/apitest"
+	"go.uber.org/mock/gomock"
+
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/v1/secrets"
+	domain "github.com/novoseltcev/passkeeper/internal/domains/secrets"
+	"github.com/novoseltcev/passkeeper/internal/domains/secrets/mocks"
+	"github.com/novoseltcev/passkeeper/internal/models"
+	"github.com/novoseltcev/passkeeper/pkg/testutils"
+)
+
+const (
+	testRecipientID    = models.UserID("0d7b0c52-52a4-4c4e-9b0a-2f1e3d4c5b6a")
+	testRecipientLogin = "recipient@example.com"
+)
+
+func TestShare_Success(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	root := gin.Default()
+	service := mocks.NewMockService(ctrl)
+	secrets.AddRoutes(&root.RouterGroup, service, guardMock)
+
+	service.EXPECT().
+		Share(gomock.Any(), testID, testOwnerID, testPassphrase, testRecipientLogin, models.ShareAccessWrite).
+		Return(nil)
+
+	apitest.New().
+		Handler(root.Handler()).
+		Debug().
+		Postf("/secrets/%s/shares", testID).
+		Bodyf(`{"passphrase":"%s","login":"%s","access":"write"}`, testPassphrase, testRecipientLogin).
+		Expect(t).
+		Status(http.StatusNoContent).
+		End()
+}
+
+func TestShare_Fails_Validate(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	tests := []struct {
+		name   string
+		body   string
+		status int
+		errs   []string
+	}{
+		{
+			name:   "invalid json body",
+			body:   `{`,
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "empty",
+			body:   `{}`,
+			status: http.StatusUnprocessableEntity,
+			errs: []string{
+				"Field validation for 'Passphrase' failed on the 'required' tag",
+				"Field validation for 'Login' failed on the 'required' tag",
+				"Field validation for 'Access' failed on the 'required' tag",
+			},
+		},
+		{
+			name:   "invalid access",
+			body:   `{"passphrase":"test","login":"test","access":"admin"}`,
+			status: http.StatusUnprocessableEntity,
+			errs:   []string{"Field validation for 'Access' failed on the 'oneof' tag"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			root := gin.Default()
+			secrets.AddRoutes(&root.RouterGroup, mocks.NewMockService(ctrl), guardMock)
+
+			result := apitest.Handler(root.Handler()).
+				Debug().
+				Postf("/secrets/%s/shares", testID).
+				Body(tt.body).
+				Expect(t).
+				Status(tt.status).
+				End()
+
+			if len(tt.errs) > 0 {
+				checkErrors(t, result, tt.errs)
+			}
+		})
+	}
+}
+
+func TestShare_Fails_Share(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{name: "not found", err: domain.ErrSecretNotFound, status: http.StatusNotFound},
+		{name: "not mine", err: domain.ErrAnotherOwner, status: http.StatusForbidden},
+		{name: "invalid passphrase", err: domain.ErrInvalidPassphrase, status: http.StatusConflict},
+		{name: "no public key", err: domain.ErrNoPublicKey, status: http.StatusConflict},
+		{name: "recipient not found", err: domain.ErrRecipientNotFound, status: http.StatusUnprocessableEntity},
+		{name: "invalid recipient", err: domain.ErrInvalidRecipient, status: http.StatusUnprocessableEntity},
+		{name: "other", err: testutils.Err, status: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			root := gin.Default()
+			service := mocks.NewMockService(ctrl)
+			secrets.AddRoutes(&root.RouterGroup, service, guardMock)
+
+			service.EXPECT().
+				Share(gomock.Any(), testID, testOwnerID, testPassphrase, testRecipientLogin, models.ShareAccessRead).
+				Return(tt.err)
+
+			apitest.New(tt.name).
+				Handler(root.Handler()).
+				Debug().
+				Postf("/secrets/%s/shares", testID).
+				Bodyf(`{"passphrase":"%s","login":"%s","access":"read"}`, testPassphrase, testRecipientLogin).
+				Expect(t).
+				Status(tt.status).
+				End()
+		})
+	}
+}
+
+func TestGetShares_Success(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	root := gin.Default()
+	service := mocks.NewMockService(ctrl)
+	secrets.AddRoutes(&root.RouterGroup, service, guardMock)
+
+	service.EXPECT().
+		GetShares(gomock.Any(), testID, testOwnerID).
+		Return([]models.Share{{
+			Recipient: &models.User{ID: testRecipientID, Login: testRecipientLogin},
+			Access:    models.ShareAccessRead,
+		}}, nil)
+
+	apitest.New().
+		Handler(root.Handler()).
+		Debug().
+		Getf("/secrets/%s/shares", testID).
+		Expect(t).
+		Status(http.StatusOK).
+		Bodyf(`{"success":true,"result":[{"user_id":"%s","login":"%s","access":"read"}]}`,
+			testRecipientID, testRecipientLogin).
+		End()
+}
+
+func TestGetShares_Fails(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{name: "not found", err: domain.ErrSecretNotFound, status: http.StatusNotFound},
+		{name: "not mine", err: domain.ErrAnotherOwner, status: http.StatusForbidden},
+		{name: "other", err: testutils.Err, status: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			root := gin.Default()
+			service := mocks.NewMockService(ctrl)
+			secrets.AddRoutes(&root.RouterGroup, service, guardMock)
+
+			service.EXPECT().
+				GetShares(gomock.Any(), testID, testOwnerID).
+				Return(nil, tt.err)
+
+			apitest.New(tt.name).
+				Handler(root.Handler()).
+				Debug().
+				Getf("/secrets/%s/shares", testID).
+				Expect(t).
+				Status(tt.status).
+				End()
+		})
+	}
+}
+
+func TestRevoke_Success(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	root := gin.Default()
+	service := mocks.NewMockService(ctrl)
+	secrets.AddRoutes(&root.RouterGroup, service, guardMock)
+
+	service.EXPECT().
+		Revoke(gomock.Any(), testID, testOwnerID, testPassphrase, testRecipientID).
+		Return(nil)
+
+	apitest.New().
+		Handler(root.Handler()).
+		Debug().
+		Deletef("/secrets/%s/shares/%s", testID, testRecipientID).
+		Bodyf(`{"passphrase":"%s"}`, testPassphrase).
+		Expect(t).
+		Status(http.StatusNoContent).
+		End()
+}
+
+func TestRevoke_Fails_Revoke(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{name: "not found", err: domain.ErrSecretNotFound, status: http.StatusNotFound},
+		{name: "share not found", err: domain.ErrShareNotFound, status: http.StatusNotFound},
+		{name: "not mine", err: domain.ErrAnotherOwner, status: http.StatusForbidden},
+		{name: "invalid passphrase", err: domain.ErrInvalidPassphrase, status: http.StatusConflict},
+		{name: "other", err: testutils.Err, status: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			root := gin.Default()
+			service := mocks.NewMockService(ctrl)
+			secrets.AddRoutes(&root.RouterGroup, service, guardMock)
+
+			service.EXPECT().
+				Revoke(gomock.Any(), testID, testOwnerID, testPassphrase, testRecipientID).
+				Return(tt.err)
+
+			apitest.New(tt.name).
+				Handler(root.Handler()).
+				Debug().
+				Deletef("/secrets/%s/shares/%s", testID, testRecipientID).
+				Bodyf(`{"passphrase":"%s"}`, testPassphrase).
+				Expect(t).
+				Status(tt.status).
+				End()
+		})
+	}
+}
+
+func TestGetSharedWithMe_Success(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	root := gin.Default()
+	service := mocks.NewMockService(ctrl)
+	secrets.AddRoutes(&root.RouterGroup, service, guardMock)
+
+	service.EXPECT().
+		GetSharedWithMe(gomock.Any(), testOwnerID).
+		Return([]models.Share{{
+			Secret: &models.Secret{
+				ID:    testID,
+				Name:  testName,
+				Type:  models.SecretTypePwd,
+				Owner: &models.User{Login: testRecipientLogin},
+			},
+			Access: models.ShareAccessWrite,
+		}}, nil)
+
+	apitest.New().
+		Handler(root.Handler()).
+		Debug().
+		Get("/secrets/shared").
+		Expect(t).
+		Status(http.StatusOK).
+		Bodyf(`{"success":true,"result":[{
+			"id":"%s",
+			"name":"%s",
+			"type":"password",
+			"owner":"%s",
+			"access":"write"
+		}]}`, testID, testName, testRecipientLogin).
+		End()
+}
+
+func TestGetSharedWithMe_Fails(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	root := gin.Default()
+	service := mocks.NewMockService(ctrl)
+	secrets.AddRoutes(&root.RouterGroup, service, guardMock)
+
+	service.EXPECT().
+		GetSharedWithMe(gomock.Any(), testOwnerID).
+		Return(nil, testutils.Err)
+
+	apitest.New().
+		Handler(root.Handler()).
+		Debug().
+		Get("/secrets/shared").
+		Expect(t).
+		Status(http.StatusInternalServerError).
+		End()
+}
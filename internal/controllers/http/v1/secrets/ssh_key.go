@@ -0,0 +1,126 @@
+package secrets
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/novoseltcev/passkeeper/internal/auth"
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/common/response"
+	domain "github.com/novoseltcev/passkeeper/internal/domains/secrets"
+	"github.com/novoseltcev/passkeeper/internal/models"
+	"github.com/novoseltcev/passkeeper/pkg/sshkey"
+)
+
+func init() { // nolint: gochecknoinits
+	Register(Type{
+		ID:   models.SecretTypeSSHKey,
+		Name: "ssh_key",
+		New:  func() Payload { return &SSHKeySecretData{} },
+		Fields: []Field{
+			{Key: "private_key", Label: "Private key", Kind: FieldKindMultiline},
+			{Key: "public_key", Label: "Public key", Kind: FieldKindText},
+			{Key: "key_passphrase", DataKey: "passphrase", Label: "Key passphrase", Kind: FieldKindConcealed},
+			{Key: "comment", Label: "Comment", Kind: FieldKindText},
+		},
+	})
+}
+
+type SSHKeySecretData struct {
+	Passphrase    string         `binding:"required"`
+	Name          string         `binding:"required,min=4,max=32"`
+	PrivateKey    string         `binding:"required"              json:"private_key"`
+	PublicKey     string         `binding:"required"              json:"public_key"`
+	KeyPassphrase string         `binding:""                      json:"key_passphrase"`
+	Comment       string         `binding:""`
+	Meta          map[string]any `binding:"required"`
+	AttrsData
+}
+
+func (d *SSHKeySecretData) Credentials() (string, string) {
+	return d.Passphrase, d.Name
+}
+
+func (d *SSHKeySecretData) ToData() (domain.ISecretData, error) {
+	return &domain.SSHKeyData{
+		PrivateKey: d.PrivateKey,
+		PublicKey:  d.PublicKey,
+		Passphrase: d.KeyPassphrase,
+		Comment:    d.Comment,
+		Meta:       d.Meta,
+	}, nil
+}
+
+type GenerateSSHKeyData struct {
+	Passphrase    string         `binding:"required"`
+	Name          string         `binding:"required,min=4,max=32"`
+	Algorithm     string         `binding:"required,oneof=ed25519 rsa"`
+	Bits          int            `binding:"excluded_unless=Algorithm rsa,omitempty,oneof=2048 3072 4096"`
+	KeyPassphrase string         `binding:""                                                            json:"key_passphrase"` // nolint: lll
+	Comment       string         `binding:""`
+	Meta          map[string]any `binding:"required"`
+	AttrsData
+}
+
+// GenerateSSHKey generates a new key pair on the server and stores it as a secret.
+func GenerateSSHKey(service domain.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ownerID := auth.GetUserID(c)
+
+		var body GenerateSSHKeyData
+		if err := c.ShouldBindJSON(&body); err != nil {
+			var vErr validator.ValidationErrors
+			if errors.As(err, &vErr) {
+				c.JSON(http.StatusUnprocessableEntity, response.NewValidationError(vErr))
+			} else {
+				c.JSON(http.StatusBadRequest, response.NewError(err))
+			}
+
+			return
+		}
+
+		pair, err := sshkey.Generate(body.Algorithm, body.Bits, body.Comment, body.KeyPassphrase)
+		if err != nil {
+			c.AbortWithError(http.StatusInternalServerError, err)
+
+			return
+		}
+
+		data := &domain.SSHKeyData{
+			PrivateKey: pair.PrivateKey,
+			PublicKey:  pair.PublicKey,
+			Passphrase: body.KeyPassphrase,
+			Comment:    body.Comment,
+			Meta:       body.Meta,
+		}
+
+		id, _, err := service.Create(c, ownerID, body.Passphrase, body.Name, data, body.Attrs())
+		if err != nil {
+			if errors.Is(err, domain.ErrInvalidPassphrase) {
+				c.AbortWithStatus(http.StatusConflict)
+			} else if errors.Is(err, domain.ErrSecretTooLarge) {
+				c.JSON(http.StatusRequestEntityTooLarge, response.NewError(err))
+			} else if errors.Is(err, domain.ErrQuotaExceeded) {
+				c.JSON(http.StatusInsufficientStorage, response.NewError(err))
+			} else {
+				c.AbortWithError(http.StatusInternalServerError, err)
+			}
+
+			return
+		}
+
+		c.JSON(http.StatusCreated, response.NewSuccess(&GeneratedSSHKeySchema{
+			ID:          string(id),
+			PublicKey:   pair.PublicKey,
+			Fingerprint: data.Fingerprint(),
+		}))
+	}
+}
+
+type GeneratedSSHKeySchema struct {
+	ID          string `json:"id"`
+	PublicKey   string `json:"public_key"`
+	Fingerprint string `json:"fingerprint"`
+}
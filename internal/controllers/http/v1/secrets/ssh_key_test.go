@@ -0,0 +1,183 @@
+package secrets_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/steinfletcher/apitest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/common/response"
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/v1/secrets"
+	domain "github.com/novoseltcev/passkeeper/internal/domains/secrets"
+	"github.com/novoseltcev/passkeeper/internal/domains/secrets/mocks"
+	"github.com/novoseltcev/passkeeper/internal/models"
+	"github.com/novoseltcev/passkeeper/pkg/testutils"
+)
+
+const testFingerprint = "SHA256:fingerprint"
+
+func TestGenerateSSHKey_Success(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	root := gin.Default()
+	service := mocks.NewMockService(ctrl)
+	secrets.AddRoutes(&root.RouterGroup, service, guardMock)
+
+	var data *domain.SSHKeyData
+
+	service.EXPECT().
+		Create(gomock.Any(), testOwnerID, testPassphrase, testName, gomock.Any(), domain.Attrs{}).
+		DoAndReturn(func(
+			_ context.Context, _ models.UserID, _, _ string, got domain.ISecretData, _ domain.Attrs,
+		) (models.SecretID, []domain.Warning, error) {
+			var ok bool
+			data, ok = got.(*domain.SSHKeyData)
+			require.True(t, ok)
+
+			return testID, nil, nil
+		})
+
+	result := apitest.Handler(root.Handler()).
+		Debug().
+		Post("/secrets/ssh_key/generate").
+		Bodyf(`
+		{
+			"passphrase":"%s",
+			"name":"%s",
+			"algorithm":"ed25519",
+			"key_passphrase":"%s",
+			"comment":"user@host",
+			"meta":%s
+		}`, testPassphrase, testName, testutils.STRING, testMeta).
+		Expect(t).
+		Status(http.StatusCreated).
+		End()
+
+	var body response.Response[secrets.GeneratedSSHKeySchema]
+	result.JSON(&body)
+
+	require.NoError(t, data.Validate())
+	assert.Equal(t, testutils.STRING, data.Passphrase)
+	assert.Equal(t, "user@host", data.Comment)
+	assert.Equal(t, &secrets.GeneratedSSHKeySchema{
+		ID:          string(testID),
+		PublicKey:   data.PublicKey,
+		Fingerprint: data.Fingerprint(),
+	}, body.Result)
+}
+
+func TestGenerateSSHKey_Fails_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		body   string
+		status int
+		errs   []string
+	}{
+		{
+			name:   "invalid json body",
+			body:   `{`,
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "empty",
+			body:   `{}`,
+			status: http.StatusUnprocessableEntity,
+			errs: []string{
+				"Field validation for 'Passphrase' failed on the 'required' tag",
+				"Field validation for 'Name' failed on the 'required' tag",
+				"Field validation for 'Algorithm' failed on the 'required' tag",
+				"Field validation for 'Meta' failed on the 'required' tag",
+			},
+		},
+		{
+			name:   "unsupported algorithm and bits",
+			body:   `{"passphrase":"p","name":"test","algorithm":"dsa","bits":1024,"meta":{}}`,
+			status: http.StatusUnprocessableEntity,
+			errs: []string{
+				"Field validation for 'Algorithm' failed on the 'oneof' tag",
+				"Field validation for 'Bits' failed on the 'excluded_unless' tag",
+			},
+		},
+		{
+			name:   "rsa bits",
+			body:   `{"passphrase":"p","name":"test","algorithm":"rsa","bits":1024,"meta":{}}`,
+			status: http.StatusUnprocessableEntity,
+			errs:   []string{"Field validation for 'Bits' failed on the 'oneof' tag"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			root := gin.Default()
+			secrets.AddRoutes(&root.RouterGroup, nil, guardMock)
+
+			result := apitest.New(tt.name).
+				Handler(root.Handler()).
+				Debug().
+				Post("/secrets/ssh_key/generate").
+				Body(tt.body).
+				Expect(t).
+				Status(tt.status).
+				End()
+
+			if len(tt.errs) > 0 {
+				checkErrors(t, result, tt.errs)
+			}
+		})
+	}
+}
+
+func TestGenerateSSHKey_Fails_Create(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{
+			name:   "invalid passphrase",
+			err:    domain.ErrInvalidPassphrase,
+			status: http.StatusConflict,
+		},
+		{
+			name:   "other",
+			err:    testutils.Err,
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			root := gin.Default()
+			service := mocks.NewMockService(ctrl)
+			secrets.AddRoutes(&root.RouterGroup, service, guardMock)
+
+			service.EXPECT().
+				Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), domain.Attrs{}).
+				Return("", nil, tt.err)
+
+			apitest.New(tt.name).
+				Handler(root.Handler()).
+				Debug().
+				Post("/secrets/ssh_key/generate").
+				Bodyf(`{"passphrase":"p","name":"test","algorithm":"ed25519","meta":{}}`).
+				Expect(t).
+				Status(tt.status).
+				End()
+		})
+	}
+}
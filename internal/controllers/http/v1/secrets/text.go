@@ -0,0 +1,36 @@
+package secrets
+
+import (
+	domain "github.com/novoseltcev/passkeeper/internal/domains/secrets"
+	"github.com/novoseltcev/passkeeper/internal/models"
+)
+
+func init() { // nolint: gochecknoinits
+	Register(Type{
+		ID:   models.SecretTypeTxt,
+		Name: "text",
+		New:  func() Payload { return &TextSecretData{} },
+		Fields: []Field{
+			{Key: "content", Label: "Content", Kind: FieldKindMultiline},
+		},
+	})
+}
+
+type TextSecretData struct {
+	Passphrase string         `binding:"required"`
+	Name       string         `binding:"required,min=4,max=32"`
+	Content    string         `binding:"required"`
+	Meta       map[string]any `binding:"required"`
+	AttrsData
+}
+
+func (d *TextSecretData) Credentials() (string, string) {
+	return d.Passphrase, d.Name
+}
+
+func (d *TextSecretData) ToData() (domain.ISecretData, error) {
+	return &domain.TextData{
+		Content: d.Content,
+		Meta:    d.Meta,
+	}, nil
+}
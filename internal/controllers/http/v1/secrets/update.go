@@ -13,89 +13,73 @@ import (
 	"github.com/novoseltcev/passkeeper/internal/models"
 )
 
-func updateSecret[T any](
-	c *gin.Context,
-	fn func(c *gin.Context, id models.SecretID, ownerID models.UserID, body *T) error,
-) {
-	ownerID := auth.GetUserID(c)
-	id := models.SecretID(c.Param("id"))
+// Update updates a secret of the registered type.
+func Update(service domain.Service, t *Type) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ownerID := auth.GetUserID(c)
+		id := models.SecretID(c.Param("id"))
+
+		revision, ok := bindRevision(c)
+		if !ok {
+			return
+		}
 
-	var body T
+		body := t.New()
+		if err := c.ShouldBindJSON(body); err != nil {
+			var vErr validator.ValidationErrors
+			if errors.As(err, &vErr) {
+				c.JSON(http.StatusUnprocessableEntity, response.NewValidationError(vErr))
+			} else {
+				c.JSON(http.StatusBadRequest, response.NewError(err))
+			}
 
-	if err := c.ShouldBindJSON(&body); err != nil {
-		var vErr validator.ValidationErrors
-		if errors.As(err, &vErr) {
-			c.JSON(http.StatusUnprocessableEntity, response.NewValidationError(vErr))
-		} else {
-			c.JSON(http.StatusBadRequest, response.NewError(err))
+			return
 		}
 
-		return
-	}
+		warnings, err := update(c, service, id, ownerID, body, revision)
+		if err != nil {
+			if errors.Is(err, domain.ErrSecretNotFound) {
+				c.Status(http.StatusNotFound)
+			} else if errors.Is(err, domain.ErrAnotherOwner) || errors.Is(err, domain.ErrReadOnly) {
+				c.Status(http.StatusForbidden)
+			} else if errors.Is(err, domain.ErrInvalidSecretType) {
+				c.AbortWithStatus(http.StatusConflict)
+			} else if errors.Is(err, domain.ErrRevisionMismatch) {
+				c.JSON(http.StatusPreconditionFailed, response.NewError(err))
+			} else if errors.Is(err, domain.ErrInvalidSecretData) || errors.Is(err, domain.ErrTemplateNotFound) {
+				c.JSON(http.StatusUnprocessableEntity, response.NewError(err))
+			} else if errors.Is(err, domain.ErrSecretTooLarge) {
+				c.JSON(http.StatusRequestEntityTooLarge, response.NewError(err))
+			} else if errors.Is(err, domain.ErrQuotaExceeded) {
+				c.JSON(http.StatusInsufficientStorage, response.NewError(err))
+			} else {
+				c.AbortWithError(http.StatusInternalServerError, err)
+			}
 
-	err := fn(c, id, ownerID, &body)
-	if err != nil {
-		if errors.Is(err, domain.ErrSecretNotFound) {
-			c.Status(http.StatusNotFound)
-		} else if errors.Is(err, domain.ErrAnotherOwner) {
-			c.Status(http.StatusForbidden)
-		} else if errors.Is(err, domain.ErrInvalidSecretType) {
-			c.AbortWithStatus(http.StatusConflict)
-		} else {
-			c.AbortWithError(http.StatusInternalServerError, err)
+			return
 		}
 
-		return
-	}
+		if len(warnings) > 0 {
+			c.JSON(http.StatusOK, response.NewSuccess[any](nil).WithWarnings(newWarnings(warnings)...))
 
-	c.Status(http.StatusNoContent)
-}
+			return
+		}
 
-func UpdatePassword(service domain.Service) func(c *gin.Context) {
-	return func(c *gin.Context) {
-		updateSecret(c, func(c *gin.Context, id models.SecretID, ownerID models.UserID, body *PasswordSecretData) error {
-			return service.Update(c, id, ownerID, body.Passphrase, body.Name, &domain.PasswordData{
-				Login:    body.Login,
-				Password: body.Password,
-				Meta:     body.Meta,
-			})
-		})
+		c.Status(http.StatusNoContent)
 	}
 }
 
-func UpdateCard(service domain.Service) func(c *gin.Context) {
-	return func(c *gin.Context) {
-		updateSecret(c, func(c *gin.Context, id models.SecretID, ownerID models.UserID, body *CardSecretData) error {
-			return service.Update(c, id, ownerID, body.Passphrase, body.Name, &domain.CardData{
-				Number: body.Number,
-				Holder: body.Holder,
-				Exp:    body.Exp,
-				CVV:    body.CVV,
-				Meta:   body.Meta,
-			})
-		})
+func update(
+	c *gin.Context, service domain.Service, id models.SecretID, ownerID models.UserID, body Payload, revision int64,
+) ([]domain.Warning, error) {
+	data, err := body.ToData()
+	if err != nil {
+		return nil, err
 	}
-}
 
-func UpdateText(service domain.Service) func(c *gin.Context) {
-	return func(c *gin.Context) {
-		updateSecret(c, func(c *gin.Context, id models.SecretID, ownerID models.UserID, body *TextSecretData) error {
-			return service.Update(c, id, ownerID, body.Passphrase, body.Name, &domain.TextData{
-				Content: body.Content,
-				Meta:    body.Meta,
-			})
-		})
-	}
-}
+	passphrase, name := body.Credentials()
+	attrs := body.Attrs()
+	attrs.Revision = revision
 
-func UpdateFile(service domain.Service) func(c *gin.Context) {
-	return func(c *gin.Context) {
-		updateSecret(c, func(c *gin.Context, id models.SecretID, ownerID models.UserID, body *FileSecretData) error {
-			return service.Update(c, id, ownerID, body.Passphrase, body.Name, &domain.FileData{
-				Filename: body.Filename,
-				Content:  body.Content,
-				Meta:     body.Meta,
-			})
-		})
-	}
+	return service.Update(c, id, ownerID, passphrase, name, data, attrs)
 }
@@ -17,11 +17,19 @@ import (
 	"github.com/novoseltcev/passkeeper/pkg/testutils"
 )
 
+const (
+	testRevision = 3
+	testETag     = `"3"`
+)
+
 var secretTypes = []models.SecretType{
 	models.SecretTypePwd,
 	models.SecretTypeCard,
 	models.SecretTypeTxt,
 	models.SecretTypeFile,
+	models.SecretTypeOTP,
+	models.SecretTypeSSHKey,
+	models.SecretTypeCustom,
 }
 
 func TestUpdate(t *testing.T) {
@@ -39,12 +47,13 @@ func TestUpdate(t *testing.T) {
 				Login:    testLogin,
 				Password: testPassword,
 				Meta:     testMetaMap,
-			}).
-			Return(nil)
+			}, domain.Attrs{Revision: testRevision}).
+			Return(nil, nil)
 
 		apitest.Handler(root.Handler()).
 			Debug().
 			Putf("/secrets/password/%s", testID).
+			Header("If-Match", testETag).
 			Bodyf(`
 			{
 				"passphrase":"%s",
@@ -71,12 +80,13 @@ func TestUpdate(t *testing.T) {
 				Exp:    testExp,
 				CVV:    testCVV,
 				Meta:   testMetaMap,
-			}).
-			Return(nil)
+			}, domain.Attrs{Revision: testRevision}).
+			Return(nil, nil)
 
 		apitest.Handler(root.Handler()).
 			Debug().
 			Putf("/secrets/card/%s", testID).
+			Header("If-Match", testETag).
 			Bodyf(`
 			{
 				"passphrase":"%s",
@@ -102,12 +112,13 @@ func TestUpdate(t *testing.T) {
 			Update(gomock.Any(), testID, testOwnerID, testPassphrase, testName, &domain.TextData{
 				Content: testutils.STRING,
 				Meta:    testMetaMap,
-			}).
-			Return(nil)
+			}, domain.Attrs{Revision: testRevision}).
+			Return(nil, nil)
 
 		apitest.Handler(root.Handler()).
 			Debug().
 			Putf("/secrets/text/%s", testID).
+			Header("If-Match", testETag).
 			Bodyf(`
 			{
 				"passphrase":"%s",
@@ -131,12 +142,13 @@ func TestUpdate(t *testing.T) {
 				Filename: testutils.STRING,
 				Content:  testHex,
 				Meta:     testMetaMap,
-			}).
-			Return(nil)
+			}, domain.Attrs{Revision: testRevision}).
+			Return(nil, nil)
 
 		apitest.Handler(root.Handler()).
 			Debug().
 			Putf("/secrets/file/%s", testID).
+			Header("If-Match", testETag).
 			Bodyf(`
 			{
 				"passphrase":"%s",
@@ -149,6 +161,106 @@ func TestUpdate(t *testing.T) {
 			Status(http.StatusNoContent).
 			End()
 	})
+
+	t.Run("otp", func(t *testing.T) {
+		t.Parallel()
+		root := gin.Default()
+		service := mocks.NewMockService(ctrl)
+		secrets.AddRoutes(&root.RouterGroup, service, guardMock)
+
+		service.EXPECT().
+			Update(gomock.Any(), testID, testOwnerID, testPassphrase, testName, &domain.OTPData{
+				Kind:      "totp",
+				Secret:    testOTPSecret,
+				Algorithm: "SHA1",
+				Digits:    6,
+				Period:    60,
+				Meta:      testMetaMap,
+			}, domain.Attrs{Revision: testRevision}).
+			Return(nil, nil)
+
+		apitest.Handler(root.Handler()).
+			Debug().
+			Putf("/secrets/otp/%s", testID).
+			Header("If-Match", testETag).
+			Bodyf(`
+			{
+				"passphrase":"%s",
+				"name":"%s",
+				"secret":"%s",
+				"period":60,
+				"meta":%s
+			}`, testPassphrase, testName, testOTPSecret, testMeta).
+			Expect(t).
+			Status(http.StatusNoContent).
+			End()
+	})
+
+	t.Run("ssh_key", func(t *testing.T) {
+		t.Parallel()
+		root := gin.Default()
+		service := mocks.NewMockService(ctrl)
+		secrets.AddRoutes(&root.RouterGroup, service, guardMock)
+
+		service.EXPECT().
+			Update(gomock.Any(), testID, testOwnerID, testPassphrase, testName, &domain.SSHKeyData{
+				PrivateKey: testPrivateKey,
+				PublicKey:  testPublicKey,
+				Passphrase: testutils.STRING,
+				Comment:    testutils.STRING,
+				Meta:       testMetaMap,
+			}, domain.Attrs{Revision: testRevision}).
+			Return(nil, nil)
+
+		apitest.Handler(root.Handler()).
+			Debug().
+			Putf("/secrets/ssh_key/%s", testID).
+			Header("If-Match", testETag).
+			Bodyf(`
+			{
+				"passphrase":"%s",
+				"name":"%s",
+				"private_key":"%s",
+				"public_key":"%s",
+				"key_passphrase":"%s",
+				"comment":"%s",
+				"meta":%s
+			}`, testPassphrase, testName, testPrivateKey, testPublicKey, testutils.STRING, testutils.STRING, testMeta).
+			Expect(t).
+			Status(http.StatusNoContent).
+			End()
+	})
+
+	t.Run("custom", func(t *testing.T) {
+		t.Parallel()
+		root := gin.Default()
+		service := mocks.NewMockService(ctrl)
+		secrets.AddRoutes(&root.RouterGroup, service, guardMock)
+
+		service.EXPECT().
+			Update(gomock.Any(), testID, testOwnerID, testPassphrase, testName, &domain.CustomData{
+				Template: testTemplateID,
+				Fields:   map[string]string{"ssid": testutils.STRING},
+				Meta:     testMetaMap,
+			}, domain.Attrs{Revision: testRevision}).
+			Return(nil, nil)
+
+		apitest.Handler(root.Handler()).
+			Debug().
+			Putf("/secrets/custom/%s", testID).
+			Header("If-Match", testETag).
+			Bodyf(`
+			{
+				"passphrase":"%s",
+				"name":"%s",
+				"template":"%s",
+				"fields":{"ssid":"%s"},
+				"meta":%s
+			}`, testPassphrase, testName, testTemplateID, testutils.STRING, testMeta).
+			Expect(t).
+			Status(http.StatusNoContent).
+			End()
+	})
 }
 
 var defaultUpdateData = map[models.SecretType]string{
@@ -166,7 +278,7 @@ var defaultUpdateData = map[models.SecretType]string{
 		"name":"test",
 		"number":"4111111111111111",
 		"holder":"John Doe",
-		"exp":"12/25",
+		"exp":"12/45",
 		"cvv":"123",
 		"meta":{}
 	}`,
@@ -185,6 +297,29 @@ var defaultUpdateData = map[models.SecretType]string{
 		"content":"74657374",
 		"meta":{}
 	}`,
+	models.SecretTypeOTP: `
+	{
+		"passphrase":"passphrase",
+		"name":"test",
+		"secret":"JBSWY3DPEHPK3PXP",
+		"meta":{}
+	}`,
+	models.SecretTypeSSHKey: `
+	{
+		"passphrase":"passphrase",
+		"name":"test",
+		"private_key":"private",
+		"public_key":"public",
+		"meta":{}
+	}`,
+	models.SecretTypeCustom: `
+	{
+		"passphrase":"passphrase",
+		"name":"test",
+		"template":"3f0b5a52-0a4c-4c1e-9a55-2f6d3b8a9c11",
+		"fields":{"ssid":"home"},
+		"meta":{}
+	}`,
 }
 
 func TestUpdate_Fails_Update(t *testing.T) {
@@ -196,6 +331,7 @@ func TestUpdate_Fails_Update(t *testing.T) {
 		name   string
 		err    error
 		status int
+		errs   []string
 	}{
 		{
 			name:   "not found",
@@ -212,6 +348,23 @@ func TestUpdate_Fails_Update(t *testing.T) {
 			err:    domain.ErrInvalidSecretType,
 			status: http.StatusConflict,
 		},
+		{
+			name:   "too large",
+			err:    domain.ErrSecretTooLarge,
+			status: http.StatusRequestEntityTooLarge,
+			errs:   []string{domain.ErrSecretTooLarge.Error()},
+		},
+		{
+			name:   "quota exceeded",
+			err:    domain.ErrQuotaExceeded,
+			status: http.StatusInsufficientStorage,
+			errs:   []string{domain.ErrQuotaExceeded.Error()},
+		},
+		{
+			name:   "revision mismatch",
+			err:    domain.ErrRevisionMismatch,
+			status: http.StatusPreconditionFailed,
+		},
 		{
 			name:   "other",
 			err:    testutils.Err,
@@ -229,17 +382,23 @@ func TestUpdate_Fails_Update(t *testing.T) {
 				secrets.AddRoutes(&root.RouterGroup, service, guardMock)
 
 				service.EXPECT().
-					Update(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(tt.err)
+					Update(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+						domain.Attrs{Revision: testRevision}).
+					Return(nil, tt.err)
 
-				apitest.New(testName).
+				result := apitest.New(testName).
 					Handler(root.Handler()).
 					Debug().
 					Putf("/secrets/%s/%s", secretType.String(), testID).
+					Header("If-Match", testETag).
 					Body(defaultUpdateData[secretType]).
 					Expect(t).
 					Status(tt.status).
 					End()
+
+				if len(tt.errs) > 0 {
+					checkErrors(t, result, tt.errs)
+				}
 			})
 		}
 	}
@@ -332,6 +491,7 @@ func TestUpdatePassword_Fails_Validate(t *testing.T) {
 				Handler(root.Handler()).
 				Debug().
 				Putf("/secrets/password/%s", testID).
+				Header("If-Match", testETag).
 				Body(tt.body).
 				Expect(t).
 				Status(tt.status).
@@ -388,6 +548,7 @@ func TestUpdateCard_Fails_Validate(t *testing.T) {
 				Handler(root.Handler()).
 				Debug().
 				Putf("/secrets/card/%s", testID).
+				Header("If-Match", testETag).
 				Body(tt.body).
 				Expect(t).
 				Status(tt.status).
@@ -442,6 +603,7 @@ func TestUpdateText_Fails_Validate(t *testing.T) {
 				Handler(root.Handler()).
 				Debug().
 				Putf("/secrets/text/%s", testID).
+				Header("If-Match", testETag).
 				Body(tt.body).
 				Expect(t).
 				Status(tt.status).
@@ -497,6 +659,7 @@ func TestUpdateFile_Fails_Validate(t *testing.T) {
 				Handler(root.Handler()).
 				Debug().
 				Putf("/secrets/file/%s", testID).
+				Header("If-Match", testETag).
 				Body(tt.body).
 				Expect(t).
 				Status(tt.status).
@@ -508,3 +671,82 @@ func TestUpdateFile_Fails_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdate_IfMatch(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	tests := []struct {
+		name    string
+		ifMatch string
+		status  int
+	}{
+		{name: "missing", ifMatch: "", status: http.StatusPreconditionRequired},
+		{name: "unquoted", ifMatch: "3", status: http.StatusBadRequest},
+		{name: "weak", ifMatch: `W/"3"`, status: http.StatusBadRequest},
+		{name: "not a revision", ifMatch: `"abc"`, status: http.StatusBadRequest},
+		{name: "zero", ifMatch: `"0"`, status: http.StatusBadRequest},
+		{name: "any", ifMatch: "*", status: http.StatusNoContent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			root := gin.Default()
+			service := mocks.NewMockService(ctrl)
+			secrets.AddRoutes(&root.RouterGroup, service, guardMock)
+
+			if tt.status == http.StatusNoContent {
+				service.EXPECT().
+					Update(gomock.Any(), testID, testOwnerID, testPassphrase, testName, gomock.Any(), domain.Attrs{}).
+					Return(nil, nil)
+			}
+
+			test := apitest.New(tt.name).
+				Handler(root.Handler()).
+				Debug().
+				Putf("/secrets/%s/%s", models.SecretTypePwd.String(), testID)
+			if tt.ifMatch != "" {
+				test = test.Header("If-Match", tt.ifMatch)
+			}
+
+			test.Body(defaultUpdateData[models.SecretTypePwd]).
+				Expect(t).
+				Status(tt.status).
+				End()
+		})
+	}
+}
+
+func TestUpdate_Warnings(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	root := gin.Default()
+	service := mocks.NewMockService(ctrl)
+	secrets.AddRoutes(&root.RouterGroup, service, guardMock)
+
+	service.EXPECT().
+		Update(gomock.Any(), testID, testOwnerID, testPassphrase, testName, gomock.Any(),
+			domain.Attrs{Revision: testRevision}).
+		Return([]domain.Warning{{Code: domain.WarningBreached, Message: "breached"}}, nil)
+
+	apitest.Handler(root.Handler()).
+		Debug().
+		Putf("/secrets/password/%s", testID).
+		Header("If-Match", testETag).
+		Bodyf(`
+		{
+			"passphrase":"%s",
+			"name":"%s",
+			"login":"%s",
+			"password":"%s",
+			"meta":%s
+		}`, testPassphrase, testName, testLogin, testPassword, testMeta).
+		Expect(t).
+		Status(http.StatusOK).
+		Body(`{"success":true,"warnings":[{"code":"breached","message":"breached"}],"result":null}`).
+		End()
+}
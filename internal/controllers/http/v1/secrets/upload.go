@@ -0,0 +1,250 @@
+package secrets
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/novoseltcev/passkeeper/internal/auth"
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/common/response"
+	domain "github.com/novoseltcev/passkeeper/internal/domains/secrets"
+	"github.com/novoseltcev/passkeeper/internal/models"
+)
+
+const (
+	// MaxUploadSize is the largest content of an uploaded file.
+	MaxUploadSize = 32 << 20
+	// FilePart is the name of the part with the content in an upload form.
+	FilePart = "file"
+
+	// maxFieldSize is the largest value of the other parts of an upload form.
+	maxFieldSize = 64 << 10
+	// maxFormSize limits the whole upload request.
+	maxFormSize = MaxUploadSize + 1<<20
+)
+
+var (
+	errTooLarge   = fmt.Errorf("file is larger than %d bytes", MaxUploadSize)
+	errNoContent  = errors.New("no file part")
+	errBadContent = errors.New("failed to read file part")
+)
+
+// UploadFileData is a multipart/form-data body to create or update a file secret.
+//
+// The content is the raw file part, which must be the last part, it is streamed to the service as it is read.
+// The filename defaults to the name of the file part, the meta is a JSON object.
+type UploadFileData struct {
+	Passphrase string         `binding:"required"             form:"passphrase"`
+	Name       string         `binding:"required,min=4,max=32" form:"name"`
+	Filename   string         `binding:"required"             form:"filename"`
+	Meta       map[string]any `binding:"required"             form:"-"`
+	AttrsData
+}
+
+func (d *UploadFileData) ToData() *domain.FileData {
+	return &domain.FileData{Filename: d.Filename, Meta: d.Meta}
+}
+
+// UploadFile creates a file secret from a multipart form.
+func UploadFile(service domain.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ownerID := auth.GetUserID(c)
+
+		body, content, ok := bindUpload(c)
+		if !ok {
+			return
+		}
+
+		id, warnings, err := service.CreateFile(
+			c, ownerID, body.Passphrase, body.Name, body.ToData(), content, body.Attrs(),
+		)
+		if err != nil {
+			if errors.Is(err, domain.ErrInvalidPassphrase) {
+				c.AbortWithStatus(http.StatusConflict)
+			} else if errors.Is(err, domain.ErrInvalidSecretData) || errors.Is(err, domain.ErrTemplateNotFound) {
+				c.JSON(http.StatusUnprocessableEntity, response.NewError(err))
+			} else if errors.Is(err, domain.ErrSecretTooLarge) {
+				c.JSON(http.StatusRequestEntityTooLarge, response.NewError(err))
+			} else if errors.Is(err, errTooLarge) {
+				c.JSON(http.StatusRequestEntityTooLarge, response.NewError(errTooLarge))
+			} else if errors.Is(err, errBadContent) {
+				c.JSON(http.StatusBadRequest, response.NewError(err))
+			} else if errors.Is(err, domain.ErrQuotaExceeded) {
+				c.JSON(http.StatusInsufficientStorage, response.NewError(err))
+			} else {
+				c.AbortWithError(http.StatusInternalServerError, err)
+			}
+
+			return
+		}
+
+		c.JSON(http.StatusCreated, response.NewCreate(string(id)).WithWarnings(newWarnings(warnings)...))
+	}
+}
+
+// ReuploadFile updates a file secret from a multipart form.
+func ReuploadFile(service domain.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ownerID := auth.GetUserID(c)
+		id := models.SecretID(c.Param("id"))
+
+		revision, ok := bindRevision(c)
+		if !ok {
+			return
+		}
+
+		body, content, ok := bindUpload(c)
+		if !ok {
+			return
+		}
+
+		attrs := body.Attrs()
+		attrs.Revision = revision
+
+		warnings, err := service.UpdateFile(c, id, ownerID, body.Passphrase, body.Name, body.ToData(), content, attrs)
+		if err != nil {
+			if errors.Is(err, domain.ErrSecretNotFound) {
+				c.Status(http.StatusNotFound)
+			} else if errors.Is(err, domain.ErrAnotherOwner) || errors.Is(err, domain.ErrReadOnly) {
+				c.Status(http.StatusForbidden)
+			} else if errors.Is(err, domain.ErrInvalidSecretType) {
+				c.AbortWithStatus(http.StatusConflict)
+			} else if errors.Is(err, domain.ErrRevisionMismatch) {
+				c.JSON(http.StatusPreconditionFailed, response.NewError(err))
+			} else if errors.Is(err, domain.ErrInvalidSecretData) || errors.Is(err, domain.ErrTemplateNotFound) {
+				c.JSON(http.StatusUnprocessableEntity, response.NewError(err))
+			} else if errors.Is(err, domain.ErrSecretTooLarge) {
+				c.JSON(http.StatusRequestEntityTooLarge, response.NewError(err))
+			} else if errors.Is(err, errTooLarge) {
+				c.JSON(http.StatusRequestEntityTooLarge, response.NewError(errTooLarge))
+			} else if errors.Is(err, errBadContent) {
+				c.JSON(http.StatusBadRequest, response.NewError(err))
+			} else if errors.Is(err, domain.ErrQuotaExceeded) {
+				c.JSON(http.StatusInsufficientStorage, response.NewError(err))
+			} else {
+				c.AbortWithError(http.StatusInternalServerError, err)
+			}
+
+			return
+		}
+
+		if len(warnings) > 0 {
+			c.JSON(http.StatusOK, response.NewSuccess[any](nil).WithWarnings(newWarnings(warnings)...))
+
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// bindUpload reads and validates the fields of the upload form and returns the reader of its file part,
+// it responds with the error itself.
+func bindUpload(c *gin.Context) (*UploadFileData, io.Reader, bool) {
+	body, content, err := readUpload(c)
+	if err == nil {
+		err = binding.Validator.ValidateStruct(body)
+	}
+
+	if err != nil {
+		var (
+			vErr    validator.ValidationErrors
+			sizeErr *http.MaxBytesError
+		)
+
+		if errors.As(err, &vErr) {
+			c.JSON(http.StatusUnprocessableEntity, response.NewValidationError(vErr))
+		} else if errors.As(err, &sizeErr) || errors.Is(err, errTooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, response.NewError(errTooLarge))
+		} else {
+			c.JSON(http.StatusBadRequest, response.NewError(err))
+		}
+
+		return nil, nil, false
+	}
+
+	return body, content, true
+}
+
+// readUpload reads the fields of the upload form up to the file part, the parts after it are not read.
+func readUpload(c *gin.Context) (*UploadFileData, io.Reader, error) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxFormSize)
+
+	reader, err := c.Request.MultipartReader()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		body   = &UploadFileData{}
+		values = make(map[string][]string)
+		file   *multipart.Part
+	)
+
+	for file == nil {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			return nil, nil, errNoContent
+		} else if err != nil {
+			return nil, nil, err
+		}
+
+		if part.FormName() == FilePart {
+			file = part
+			body.Filename = part.FileName()
+
+			continue
+		}
+
+		value, err := io.ReadAll(io.LimitReader(part, maxFieldSize))
+		if err != nil {
+			return nil, nil, err
+		}
+
+		values[part.FormName()] = append(values[part.FormName()], string(value))
+	}
+
+	if err := binding.MapFormWithTag(body, values, "form"); err != nil {
+		return nil, nil, err
+	}
+
+	body.Meta = make(map[string]any)
+	if meta := values["meta"]; len(meta) > 0 && meta[0] != "" {
+		if err := json.Unmarshal([]byte(meta[0]), &body.Meta); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return body, &partReader{r: file, n: MaxUploadSize}, nil
+}
+
+// partReader reads the file part, it fails with errTooLarge after n bytes.
+//
+// The errors of the request are wrapped with errBadContent, so they are told from the ones of the service.
+type partReader struct {
+	r io.Reader
+	n int64
+}
+
+func (p *partReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	if p.n -= int64(n); p.n < 0 {
+		return n, errTooLarge
+	}
+
+	var sizeErr *http.MaxBytesError
+	if errors.As(err, &sizeErr) {
+		return n, errTooLarge
+	} else if err != nil && !errors.Is(err, io.EOF) {
+		return n, fmt.Errorf("%w: %w", errBadContent, err)
+	}
+
+	return n, err
+}
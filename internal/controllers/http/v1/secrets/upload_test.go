@@ -0,0 +1,288 @@
+package secrets_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/steinfletcher/apitest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/v1/secrets"
+	domain "github.com/novoseltcev/passkeeper/internal/domains/secrets"
+	"github.com/novoseltcev/passkeeper/internal/domains/secrets/mocks"
+	"github.com/novoseltcev/passkeeper/internal/models"
+	"github.com/novoseltcev/passkeeper/pkg/testutils"
+)
+
+const testFilename = "report.pdf"
+
+func newUploadForm(t *testing.T, fields map[string]string, content []byte) (string, string) {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	writer := multipart.NewWriter(&buf)
+	for key, value := range fields {
+		require.NoError(t, writer.WriteField(key, value))
+	}
+
+	if content != nil {
+		part, err := writer.CreateFormFile(secrets.FilePart, testFilename)
+		require.NoError(t, err)
+		_, err = part.Write(content)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, writer.Close())
+
+	return buf.String(), writer.FormDataContentType()
+}
+
+func setupFiles(t *testing.T) (*gin.Engine, *mocks.MockService) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	root := gin.Default()
+	service := mocks.NewMockService(ctrl)
+	secrets.AddRoutes(&root.RouterGroup, service, guardMock)
+
+	return root, service
+}
+
+func TestUploadFile(t *testing.T) {
+	t.Parallel()
+
+	fields := map[string]string{"passphrase": testPassphrase, "name": testName, "meta": testMeta}
+
+	tests := []struct {
+		name    string
+		fields  map[string]string
+		content []byte
+		err     error
+		status  int
+	}{
+		{name: "success", fields: fields, content: testData, status: http.StatusCreated},
+		{
+			name:    "invalid passphrase",
+			fields:  fields,
+			content: testData,
+			err:     domain.ErrInvalidPassphrase,
+			status:  http.StatusConflict,
+		},
+		{
+			name:    "quota exceeded",
+			fields:  fields,
+			content: testData,
+			err:     domain.ErrQuotaExceeded,
+			status:  http.StatusInsufficientStorage,
+		},
+		{name: "other", fields: fields, content: testData, err: testutils.Err, status: http.StatusInternalServerError},
+		{name: "no file", fields: fields, status: http.StatusBadRequest},
+		{
+			name:    "no name",
+			fields:  map[string]string{"passphrase": testPassphrase},
+			content: testData,
+			status:  http.StatusUnprocessableEntity,
+		},
+		{
+			name:    "invalid meta",
+			fields:  map[string]string{"passphrase": testPassphrase, "name": testName, "meta": "{"},
+			content: testData,
+			status:  http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			root, service := setupFiles(t)
+
+			if tt.status == http.StatusCreated || tt.err != nil {
+				service.EXPECT().
+					CreateFile(gomock.Any(), testOwnerID, testPassphrase, testName, &domain.FileData{
+						Filename: testFilename,
+						Meta:     testMetaMap,
+					}, gomock.Any(), domain.Attrs{}).
+					DoAndReturn(func(
+						_ context.Context, _ models.UserID, _, _ string, _ *domain.FileData, content io.Reader, _ domain.Attrs,
+					) (models.SecretID, []domain.Warning, error) {
+						data, err := io.ReadAll(content)
+						require.NoError(t, err)
+						assert.Equal(t, testData, data)
+
+						return testID, nil, tt.err
+					})
+			}
+
+			body, contentType := newUploadForm(t, tt.fields, tt.content)
+
+			apitest.New(tt.name).
+				Handler(root.Handler()).
+				Post("/secrets/file/upload").
+				ContentType(contentType).
+				Body(body).
+				Expect(t).
+				Status(tt.status).
+				End()
+		})
+	}
+}
+
+// TestUploadFile_Fails_TooLarge is not parallel, the large body slows down the time-sensitive tests.
+func TestUploadFile_Fails_TooLarge(t *testing.T) { // nolint: paralleltest
+	root, service := setupFiles(t)
+
+	// the content is streamed to the service, which fails as soon as the limit is crossed
+	service.EXPECT().
+		CreateFile(gomock.Any(), testOwnerID, testPassphrase, testName, gomock.Any(), gomock.Any(), domain.Attrs{}).
+		DoAndReturn(func(
+			_ context.Context, _ models.UserID, _, _ string, _ *domain.FileData, content io.Reader, _ domain.Attrs,
+		) (models.SecretID, []domain.Warning, error) {
+			_, err := io.Copy(io.Discard, content)
+
+			return "", nil, err
+		})
+
+	body, contentType := newUploadForm(t, map[string]string{
+		"passphrase": testPassphrase,
+		"name":       testName,
+	}, make([]byte, secrets.MaxUploadSize+1))
+
+	apitest.Handler(root.Handler()).
+		Post("/secrets/file/upload").
+		ContentType(contentType).
+		Body(body).
+		Expect(t).
+		Status(http.StatusRequestEntityTooLarge).
+		End()
+}
+
+func TestReuploadFile(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{name: "success", status: http.StatusNoContent},
+		{name: "not found", err: domain.ErrSecretNotFound, status: http.StatusNotFound},
+		{name: "read only", err: domain.ErrReadOnly, status: http.StatusForbidden},
+		{name: "another type", err: domain.ErrInvalidSecretType, status: http.StatusConflict},
+		{name: "quota exceeded", err: domain.ErrQuotaExceeded, status: http.StatusInsufficientStorage},
+		{name: "other", err: testutils.Err, status: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			root, service := setupFiles(t)
+
+			service.EXPECT().
+				UpdateFile(gomock.Any(), testID, testOwnerID, testPassphrase, testName, &domain.FileData{
+					Filename: "renamed.pdf",
+					Meta:     map[string]any{},
+				}, gomock.Any(), domain.Attrs{Revision: testRevision}).
+				DoAndReturn(func(
+					_ context.Context, _ models.SecretID, _ models.UserID, _, _ string, _ *domain.FileData,
+					content io.Reader, _ domain.Attrs,
+				) ([]domain.Warning, error) {
+					data, err := io.ReadAll(content)
+					require.NoError(t, err)
+					assert.Equal(t, testData, data)
+
+					return nil, tt.err
+				})
+
+			body, contentType := newUploadForm(t, map[string]string{
+				"passphrase": testPassphrase,
+				"name":       testName,
+				"filename":   "renamed.pdf",
+			}, testData)
+
+			apitest.New(tt.name).
+				Handler(root.Handler()).
+				Putf("/secrets/file/%s/upload", testID).
+				Header("If-Match", testETag).
+				ContentType(contentType).
+				Body(body).
+				Expect(t).
+				Status(tt.status).
+				End()
+		})
+	}
+}
+
+func TestDownloadFile(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{name: "success", status: http.StatusOK},
+		{name: "not file", err: domain.ErrInvalidSecretType, status: http.StatusConflict},
+		{name: "not found", err: domain.ErrSecretNotFound, status: http.StatusNotFound},
+		{name: "another owner", err: domain.ErrAnotherOwner, status: http.StatusForbidden},
+		{name: "invalid passphrase", err: domain.ErrInvalidPassphrase, status: http.StatusConflict},
+		{name: "other", err: testutils.Err, status: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			root, service := setupFiles(t)
+
+			call := service.EXPECT().OpenFile(gomock.Any(), testID, testOwnerID, testPassphrase)
+			if tt.err != nil {
+				call.Return(nil, nil, tt.err)
+			} else {
+				content := &closeRecorder{Reader: strings.NewReader(testName)}
+				t.Cleanup(func() { assert.True(t, content.closed) })
+
+				call.Return(&domain.FileData{Filename: testFilename, Size: int64(len(testName))}, content, nil)
+			}
+
+			test := apitest.New(tt.name).
+				Handler(root.Handler()).
+				Postf("/secrets/%s/download", testID).
+				Bodyf(`{"passphrase":"%s"}`, testPassphrase).
+				Expect(t).
+				Status(tt.status)
+
+			if tt.status == http.StatusOK {
+				test = test.
+					Header("Content-Type", "application/octet-stream").
+					Header("Content-Disposition", `attachment; filename=report.pdf`).
+					Header("Content-Length", strconv.Itoa(len(testName))).
+					Header("Cache-Control", "no-store").
+					Body(testName)
+			}
+
+			test.End()
+		})
+	}
+}
+
+// closeRecorder records that the content is closed.
+type closeRecorder struct {
+	io.Reader
+	closed bool
+}
+
+func (r *closeRecorder) Close() error {
+	r.closed = true
+
+	return nil
+}
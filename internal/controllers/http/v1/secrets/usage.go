@@ -0,0 +1,40 @@
+package secrets
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/novoseltcev/passkeeper/internal/auth"
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/common/response"
+	domain "github.com/novoseltcev/passkeeper/internal/domains/secrets"
+)
+
+// GetUsage returns the storage used by the user's secrets against the quota.
+func GetUsage(service domain.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		usage, err := service.GetUsage(c, auth.GetUserID(c))
+		if err != nil {
+			c.AbortWithError(http.StatusInternalServerError, err)
+
+			return
+		}
+
+		c.JSON(http.StatusOK, response.NewSuccess(&UsageSchema{
+			Secrets:        usage.Secrets,
+			Bytes:          usage.Bytes,
+			MaxSecrets:     usage.Quota.MaxSecrets,
+			MaxBytes:       usage.Quota.MaxBytes,
+			MaxSecretBytes: usage.Quota.MaxSecretBytes,
+		}))
+	}
+}
+
+// UsageSchema is the usage of the storage, the limits are zero if unlimited.
+type UsageSchema struct {
+	Secrets        int   `json:"secrets"`
+	Bytes          int64 `json:"bytes"`
+	MaxSecrets     int   `json:"max_secrets"`
+	MaxBytes       int64 `json:"max_bytes"`
+	MaxSecretBytes int64 `json:"max_secret_bytes"`
+}
@@ -0,0 +1,57 @@
+package secrets_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/steinfletcher/apitest"
+	"go.uber.org/mock/gomock"
+
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/v1/secrets"
+	domain "github.com/novoseltcev/passkeeper/internal/domains/secrets"
+	"github.com/novoseltcev/passkeeper/internal/domains/secrets/mocks"
+	"github.com/novoseltcev/passkeeper/pkg/testutils"
+)
+
+func TestGetUsage(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	root := gin.Default()
+	service := mocks.NewMockService(ctrl)
+	secrets.AddRoutes(&root.RouterGroup, service, guardMock)
+
+	service.EXPECT().
+		GetUsage(gomock.Any(), testOwnerID).
+		Return(&domain.Usage{Secrets: 3, Bytes: 1024, Quota: domain.Quota{MaxSecrets: 100}}, nil)
+
+	apitest.Handler(root.Handler()).
+		Get("/user/usage").
+		Expect(t).
+		Status(http.StatusOK).
+		Body(`{
+			"success": true,
+			"result": {"secrets": 3, "bytes": 1024, "max_secrets": 100, "max_bytes": 0, "max_secret_bytes": 0}
+		}`).
+		End()
+}
+
+func TestGetUsage_Fails(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	root := gin.Default()
+	service := mocks.NewMockService(ctrl)
+	secrets.AddRoutes(&root.RouterGroup, service, guardMock)
+
+	service.EXPECT().GetUsage(gomock.Any(), testOwnerID).Return(nil, testutils.Err)
+
+	apitest.Handler(root.Handler()).
+		Get("/user/usage").
+		Expect(t).
+		Status(http.StatusInternalServerError).
+		End()
+}
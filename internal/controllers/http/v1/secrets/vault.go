@@ -0,0 +1,139 @@
+package secrets
+
+import (
+	"encoding/json"
+	"errors"
+	"mime"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/novoseltcev/passkeeper/internal/auth"
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/common/response"
+	domain "github.com/novoseltcev/passkeeper/internal/domains/secrets"
+)
+
+// ExportFilename is the name of the attachment with the exported archive.
+const ExportFilename = "passkeeper-export.json"
+
+type ExportData struct {
+	Passphrase string `binding:"required"       json:"passphrase"`
+	// Password is the export password, which the archive is encrypted with.
+	Password string `binding:"required,min=8" json:"password"`
+}
+
+type ImportData struct {
+	Passphrase string `binding:"required" json:"passphrase"`
+	// Password is the export password of the archive.
+	Password string `binding:"required" json:"password"`
+	// Archive is the exported archive as is.
+	Archive json.RawMessage `binding:"required" json:"archive"`
+	DryRun  bool            `binding:""         json:"dry_run"`
+}
+
+// ImportResultSchema is the result of a secret of the archive.
+type ImportResultSchema struct {
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	Status string `json:"status"` // one of created, duplicate, invalid and failed
+	ID     string `json:"id,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+type ImportSchema struct {
+	DryRun bool                 `json:"dry_run"`
+	Items  []ImportResultSchema `json:"items"`
+}
+
+// Export responds with the personal secrets encrypted with the export password as an attachment.
+func Export(service domain.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ownerID := auth.GetUserID(c)
+
+		var body ExportData
+		if err := c.ShouldBindJSON(&body); err != nil {
+			var vErr validator.ValidationErrors
+			if errors.As(err, &vErr) {
+				c.JSON(http.StatusUnprocessableEntity, response.NewValidationError(vErr))
+			} else {
+				c.JSON(http.StatusBadRequest, response.NewError(err))
+			}
+
+			return
+		}
+
+		archive, err := service.Export(c, ownerID, body.Passphrase, body.Password)
+		if err != nil {
+			if errors.Is(err, domain.ErrInvalidPassphrase) {
+				c.AbortWithStatus(http.StatusConflict)
+			} else {
+				c.AbortWithError(http.StatusInternalServerError, err)
+			}
+
+			return
+		}
+
+		c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": ExportFilename}))
+		c.Header("Cache-Control", "no-store")
+		c.Data(http.StatusOK, "application/json", archive)
+	}
+}
+
+// Import creates the secrets of the exported archive in the personal vault, nothing is saved if one of them fails.
+//
+// The duplicates are skipped, a dry run responds with the results without saving.
+func Import(service domain.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ownerID := auth.GetUserID(c)
+
+		var body ImportData
+		if err := c.ShouldBindJSON(&body); err != nil {
+			var vErr validator.ValidationErrors
+			if errors.As(err, &vErr) {
+				c.JSON(http.StatusUnprocessableEntity, response.NewValidationError(vErr))
+			} else {
+				c.JSON(http.StatusBadRequest, response.NewError(err))
+			}
+
+			return
+		}
+
+		results, err := service.Import(c, ownerID, body.Passphrase, body.Password, body.Archive, body.DryRun)
+		if err != nil && !errors.Is(err, domain.ErrImportFailed) {
+			if errors.Is(err, domain.ErrInvalidPassphrase) {
+				c.AbortWithStatus(http.StatusConflict)
+			} else if errors.Is(err, domain.ErrInvalidExportPassword) || errors.Is(err, domain.ErrInvalidArchive) {
+				c.JSON(http.StatusUnprocessableEntity, response.NewError(err))
+			} else {
+				c.AbortWithError(http.StatusInternalServerError, err)
+			}
+
+			return
+		}
+
+		schema := ImportSchema{DryRun: body.DryRun, Items: make([]ImportResultSchema, len(results))}
+		for i, result := range results {
+			schema.Items[i] = ImportResultSchema{
+				Name:   result.Name,
+				Type:   result.Type.String(),
+				Status: string(result.Status),
+				ID:     string(result.ID),
+			}
+			if result.Err != nil {
+				schema.Items[i].Error = result.Err.Error()
+			}
+		}
+
+		if err != nil {
+			c.JSON(http.StatusUnprocessableEntity, &response.Response[ImportSchema]{
+				Errors: []string{err.Error()},
+				Result: &schema,
+			})
+
+			return
+		}
+
+		c.JSON(http.StatusOK, response.NewSuccess(&schema))
+	}
+}
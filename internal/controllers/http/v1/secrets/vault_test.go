@@ -0,0 +1,185 @@
+package secrets_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/steinfletcher/apitest"
+	"go.uber.org/mock/gomock"
+
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/v1/secrets"
+	domain "github.com/novoseltcev/passkeeper/internal/domains/secrets"
+	"github.com/novoseltcev/passkeeper/internal/domains/secrets/mocks"
+	"github.com/novoseltcev/passkeeper/internal/models"
+	"github.com/novoseltcev/passkeeper/pkg/testutils"
+)
+
+const (
+	testExportPassword = "export-password"
+	testArchive        = `{"format":"passkeeper-export"}`
+)
+
+func TestExport(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{name: "success", status: http.StatusOK},
+		{name: "invalid passphrase", err: domain.ErrInvalidPassphrase, status: http.StatusConflict},
+		{name: "other", err: testutils.Err, status: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			root := gin.Default()
+			service := mocks.NewMockService(ctrl)
+			secrets.AddRoutes(&root.RouterGroup, service, guardMock)
+
+			var archive []byte
+			if tt.err == nil {
+				archive = []byte(testArchive)
+			}
+
+			service.EXPECT().
+				Export(gomock.Any(), testOwnerID, testPassphrase, testExportPassword).
+				Return(archive, tt.err)
+
+			test := apitest.New(tt.name).
+				Handler(root.Handler()).
+				Post("/vault/export").
+				JSON(fmt.Sprintf(`{"passphrase":%q,"password":%q}`, testPassphrase, testExportPassword)).
+				Expect(t).
+				Status(tt.status)
+
+			if tt.err == nil {
+				test = test.
+					Header("Content-Disposition", `attachment; filename=passkeeper-export.json`).
+					Body(testArchive)
+			}
+
+			test.End()
+		})
+	}
+}
+
+func TestExport_Fails_Validate(t *testing.T) {
+	t.Parallel()
+
+	root := gin.Default()
+	secrets.AddRoutes(&root.RouterGroup, nil, guardMock)
+
+	result := apitest.New().
+		Handler(root.Handler()).
+		Post("/vault/export").
+		JSON(`{"password":"short"}`).
+		Expect(t).
+		Status(http.StatusUnprocessableEntity).
+		End()
+
+	checkErrors(t, result, []string{
+		"Field validation for 'Passphrase' failed on the 'required' tag",
+		"Field validation for 'Password' failed on the 'min' tag",
+	})
+}
+
+func TestImport(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	root := gin.Default()
+	service := mocks.NewMockService(ctrl)
+	secrets.AddRoutes(&root.RouterGroup, service, guardMock)
+
+	service.EXPECT().
+		Import(gomock.Any(), testOwnerID, testPassphrase, testExportPassword, []byte(testArchive), false).
+		Return([]domain.ImportResult{
+			{Name: testName, Type: models.SecretTypeTxt, Status: domain.ImportCreated, ID: testID},
+			{Name: testName, Type: models.SecretTypePwd, Status: domain.ImportDuplicate},
+		}, nil)
+
+	apitest.New().
+		Handler(root.Handler()).
+		Post("/vault/import").
+		JSON(fmt.Sprintf(`{"passphrase":%q,"password":%q,"archive":%s}`, testPassphrase, testExportPassword, testArchive)).
+		Expect(t).
+		Status(http.StatusOK).
+		Bodyf(`{"success":true,"result":{"dry_run":false,"items":[
+			{"name":"%s","type":"text","status":"created","id":"%s"},
+			{"name":"%s","type":"password","status":"duplicate"}
+		]}}`, testName, testID, testName).
+		End()
+}
+
+func TestImport_Fails(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		results []domain.ImportResult
+		err     error
+		status  int
+		body    string
+	}{
+		{
+			name: "import failed",
+			results: []domain.ImportResult{
+				{Name: testName, Type: models.SecretTypeTxt, Status: domain.ImportInvalid, Err: domain.ErrInvalidSecretData},
+			},
+			err:    domain.ErrImportFailed,
+			status: http.StatusUnprocessableEntity,
+			body: `{"success":false,"errors":["import failed"],"result":{"dry_run":true,"items":[
+				{"name":"test","type":"text","status":"invalid","error":"invalid secret data"}
+			]}}`,
+		},
+		{
+			name:   "invalid export password",
+			err:    domain.ErrInvalidExportPassword,
+			status: http.StatusUnprocessableEntity,
+			body:   `{"success":false,"errors":["invalid export password"],"result":null}`,
+		},
+		{name: "invalid passphrase", err: domain.ErrInvalidPassphrase, status: http.StatusConflict},
+		{name: "other", err: testutils.Err, status: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			root := gin.Default()
+			service := mocks.NewMockService(ctrl)
+			secrets.AddRoutes(&root.RouterGroup, service, guardMock)
+
+			service.EXPECT().
+				Import(gomock.Any(), testOwnerID, testPassphrase, testExportPassword, []byte(testArchive), true).
+				Return(tt.results, tt.err)
+
+			test := apitest.New(tt.name).
+				Handler(root.Handler()).
+				Post("/vault/import").
+				JSON(fmt.Sprintf(
+					`{"passphrase":%q,"password":%q,"archive":%s,"dry_run":true}`,
+					testPassphrase, testExportPassword, testArchive,
+				)).
+				Expect(t).
+				Status(tt.status)
+
+			if tt.body != "" {
+				test = test.Body(tt.body)
+			}
+
+			test.End()
+		})
+	}
+}
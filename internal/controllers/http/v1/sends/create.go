@@ -0,0 +1,93 @@
+package sends
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/novoseltcev/passkeeper/internal/auth"
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/common/response"
+	secrets "github.com/novoseltcev/passkeeper/internal/domains/secrets"
+	domain "github.com/novoseltcev/passkeeper/internal/domains/sends"
+	"github.com/novoseltcev/passkeeper/internal/models"
+)
+
+// CreateSendData is a send of the secret or of the text.
+type CreateSendData struct {
+	SecretID       string `binding:"required_without=Text,excluded_with=Text,omitempty,uuid" json:"secret_id,omitempty"`
+	Passphrase     string `binding:"required_with=SecretID"                                  json:"passphrase,omitempty"`
+	Text           string `binding:"required_without=SecretID,max=10000"                     json:"text,omitempty"`
+	MaxViews       int    `binding:"required,min=1,max=100"                                  json:"max_views"`
+	ExpiresInHours int    `binding:"required,min=1,max=720"                                  json:"expires_in_hours"`
+	Password       string `binding:"omitempty,min=4"                                         json:"password,omitempty"`
+}
+
+type SendSchema struct {
+	ID        string    `json:"id"`
+	Link      string    `json:"link"` // link with the key, it is not stored by the server
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Create creates a one-time link to a secret or a text.
+func Create(service domain.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		userID := auth.GetUserID(c)
+
+		var body CreateSendData
+		if err := c.ShouldBindJSON(&body); err != nil {
+			var vErr validator.ValidationErrors
+			if errors.As(err, &vErr) {
+				c.JSON(http.StatusUnprocessableEntity, response.NewValidationError(vErr))
+			} else {
+				c.JSON(http.StatusBadRequest, response.NewError(err))
+			}
+
+			return
+		}
+
+		send, key, err := service.Create(
+			c,
+			userID, body.Passphrase,
+			models.SecretID(body.SecretID), body.Text,
+			domain.Options{
+				MaxViews: body.MaxViews,
+				TTL:      time.Duration(body.ExpiresInHours) * time.Hour,
+				Password: body.Password,
+			},
+		)
+		if err != nil {
+			if errors.Is(err, secrets.ErrSecretNotFound) {
+				c.AbortWithStatus(http.StatusNotFound)
+			} else if errors.Is(err, secrets.ErrAnotherOwner) {
+				c.AbortWithStatus(http.StatusForbidden)
+			} else if errors.Is(err, secrets.ErrInvalidPassphrase) {
+				c.AbortWithStatus(http.StatusConflict)
+			} else {
+				c.AbortWithError(http.StatusInternalServerError, err)
+			}
+
+			return
+		}
+
+		c.JSON(http.StatusCreated, response.NewSuccess(&SendSchema{
+			ID:        string(send.ID),
+			Link:      domain.NewLink(address(c, send.ID), key),
+			ExpiresAt: send.ExpiresAt,
+		}))
+	}
+}
+
+// address returns the address of the send as seen by the client.
+func address(c *gin.Context, id models.SendID) string {
+	scheme := "http"
+	if c.Request.TLS != nil {
+		scheme = "https"
+	} else if proto := c.GetHeader("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+
+	return scheme + "://" + c.Request.Host + c.Request.URL.Path + "/" + string(id)
+}
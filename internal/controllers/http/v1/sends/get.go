@@ -0,0 +1,44 @@
+package sends
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/common/response"
+	domain "github.com/novoseltcev/passkeeper/internal/domains/sends"
+	"github.com/novoseltcev/passkeeper/internal/models"
+)
+
+// PasswordHeader is the header with the access password of a send.
+const PasswordHeader = "X-Send-Password"
+
+type SendDataSchema struct {
+	Data []byte `json:"data"` // payload encrypted with the key of the link
+}
+
+// Get returns the encrypted payload of a send and takes a view of it.
+func Get(service domain.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		id := models.SendID(c.Param("id"))
+
+		c.Header("Cache-Control", "no-store")
+		c.Header("Referrer-Policy", "no-referrer")
+
+		data, err := service.Get(c, id, c.GetHeader(PasswordHeader))
+		if err != nil {
+			if errors.Is(err, domain.ErrSendNotFound) {
+				c.AbortWithStatus(http.StatusNotFound)
+			} else if errors.Is(err, domain.ErrInvalidPassword) {
+				c.AbortWithStatus(http.StatusUnauthorized)
+			} else {
+				c.AbortWithError(http.StatusInternalServerError, err)
+			}
+
+			return
+		}
+
+		c.JSON(http.StatusOK, response.NewSuccess(&SendDataSchema{Data: data}))
+	}
+}
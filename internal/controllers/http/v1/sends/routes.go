@@ -0,0 +1,16 @@
+package sends
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/novoseltcev/passkeeper/internal/domains/sends"
+)
+
+func AddRoutes(rg *gin.RouterGroup, service sends.Service, guard gin.HandlerFunc) {
+	sendGroup := rg.Group("/sends")
+	{
+		sendGroup.POST("", guard, Create(service))
+		// The sends are read by people without an account, the key of the link authorizes them.
+		sendGroup.GET("/:id", Get(service))
+	}
+}
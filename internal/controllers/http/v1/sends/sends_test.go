@@ -0,0 +1,221 @@
+package sends_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/steinfletcher/apitest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/novoseltcev/passkeeper/internal/auth"
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/common/response"
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/v1/sends"
+	secrets "github.com/novoseltcev/passkeeper/internal/domains/secrets"
+	domain "github.com/novoseltcev/passkeeper/internal/domains/sends"
+	"github.com/novoseltcev/passkeeper/internal/domains/sends/mocks"
+	"github.com/novoseltcev/passkeeper/internal/models"
+	"github.com/novoseltcev/passkeeper/pkg/testutils"
+)
+
+const (
+	testUserID     = models.UserID("f535204f-9283-4c1a-8e68-8834c6ae83fb")
+	testSecretID   = models.SecretID("a6a3097b-7b03-4f3c-9686-7264a163b34d")
+	testID         = models.SendID("0f5a3c2e-8d4b-4e6f-9a1b-2c3d4e5f6a7b")
+	testPassphrase = "passphrase"
+	testPassword   = "password"
+)
+
+func guardMock(c *gin.Context) {
+	c.Set(auth.IdentityKey, string(testUserID))
+	c.Next()
+}
+
+func setup(t *testing.T) (*gin.Engine, *mocks.MockService) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	root := gin.Default()
+	service := mocks.NewMockService(ctrl)
+	sends.AddRoutes(&root.RouterGroup, service, guardMock)
+
+	return root, service
+}
+
+func TestCreate_Success(t *testing.T) {
+	t.Parallel()
+	root, service := setup(t)
+
+	key := make([]byte, domain.KeySize)
+	expiresAt := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	service.EXPECT().
+		Create(
+			gomock.Any(),
+			testUserID, testPassphrase,
+			testSecretID, "",
+			domain.Options{MaxViews: 1, TTL: 24 * time.Hour, Password: testPassword},
+		).
+		Return(&models.Send{ID: testID, ExpiresAt: expiresAt}, key, nil)
+
+	result := apitest.Handler(root.Handler()).
+		Debug().
+		Post("/sends").
+		Header("Host", "example.com").
+		Bodyf(
+			`{"secret_id":"%s","passphrase":"%s","max_views":1,"expires_in_hours":24,"password":"%s"}`,
+			testSecretID, testPassphrase, testPassword,
+		).
+		Expect(t).
+		Status(http.StatusCreated).
+		End()
+
+	var schema response.Response[sends.SendSchema]
+	result.JSON(&schema)
+
+	require.True(t, schema.Success)
+	assert.Equal(t, string(testID), schema.Result.ID)
+	assert.Equal(t, expiresAt, schema.Result.ExpiresAt)
+
+	address, got, err := domain.ParseLink(schema.Result.Link)
+	require.NoError(t, err)
+	assert.True(t, strings.HasSuffix(address, "/sends/"+string(testID)), address)
+	assert.Equal(t, key, got)
+}
+
+func TestCreate_Text(t *testing.T) {
+	t.Parallel()
+	root, service := setup(t)
+
+	service.EXPECT().
+		Create(gomock.Any(), testUserID, "", models.SecretID(""), "text", domain.Options{MaxViews: 3, TTL: time.Hour}).
+		Return(&models.Send{ID: testID}, make([]byte, domain.KeySize), nil)
+
+	apitest.Handler(root.Handler()).
+		Debug().
+		Post("/sends").
+		Body(`{"text":"text","max_views":3,"expires_in_hours":1}`).
+		Expect(t).
+		Status(http.StatusCreated).
+		End()
+}
+
+func TestCreate_Fails(t *testing.T) {
+	t.Parallel()
+
+	body := `{"secret_id":"a6a3097b-7b03-4f3c-9686-7264a163b34d","passphrase":"passphrase",` +
+		`"max_views":1,"expires_in_hours":1}`
+
+	tests := []struct {
+		name   string
+		body   string
+		err    error
+		status int
+	}{
+		{name: "nothing to send", body: `{"max_views":1,"expires_in_hours":1}`, status: http.StatusUnprocessableEntity},
+		{
+			name:   "secret and text",
+			body:   `{"secret_id":"a6a3097b-7b03-4f3c-9686-7264a163b34d","passphrase":"p","text":"t","max_views":1,"expires_in_hours":1}`, // nolint: lll
+			status: http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "no passphrase",
+			body:   `{"secret_id":"a6a3097b-7b03-4f3c-9686-7264a163b34d","max_views":1,"expires_in_hours":1}`,
+			status: http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "too many views",
+			body:   `{"text":"t","max_views":1000,"expires_in_hours":1}`,
+			status: http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "too long",
+			body:   `{"text":"t","max_views":1,"expires_in_hours":10000}`,
+			status: http.StatusUnprocessableEntity,
+		},
+		{name: "invalid json", body: `{`, status: http.StatusBadRequest},
+		{name: "not found", body: body, err: secrets.ErrSecretNotFound, status: http.StatusNotFound},
+		{name: "another owner", body: body, err: secrets.ErrAnotherOwner, status: http.StatusForbidden},
+		{name: "invalid passphrase", body: body, err: secrets.ErrInvalidPassphrase, status: http.StatusConflict},
+		{name: "other", body: body, err: testutils.Err, status: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			root, service := setup(t)
+
+			if tt.err != nil {
+				service.EXPECT().
+					Create(gomock.Any(), testUserID, testPassphrase, testSecretID, "", gomock.Any()).
+					Return(nil, nil, tt.err)
+			}
+
+			apitest.New(tt.name).
+				Handler(root.Handler()).
+				Debug().
+				Post("/sends").
+				Body(tt.body).
+				Expect(t).
+				Status(tt.status).
+				End()
+		})
+	}
+}
+
+func TestGet_Success(t *testing.T) {
+	t.Parallel()
+	root, service := setup(t)
+
+	service.EXPECT().Get(gomock.Any(), testID, testPassword).Return([]byte("data"), nil)
+
+	data, err := json.Marshal([]byte("data"))
+	require.NoError(t, err)
+
+	apitest.Handler(root.Handler()).
+		Debug().
+		Getf("/sends/%s", testID).
+		Header(sends.PasswordHeader, testPassword).
+		Expect(t).
+		Status(http.StatusOK).
+		Header("Cache-Control", "no-store").
+		Bodyf(`{"success":true,"result":{"data":%s}}`, data).
+		End()
+}
+
+func TestGet_Fails(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{name: "not found", err: domain.ErrSendNotFound, status: http.StatusNotFound},
+		{name: "invalid password", err: domain.ErrInvalidPassword, status: http.StatusUnauthorized},
+		{name: "other", err: testutils.Err, status: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			root, service := setup(t)
+
+			service.EXPECT().Get(gomock.Any(), testID, "").Return(nil, tt.err)
+
+			apitest.New(tt.name).
+				Handler(root.Handler()).
+				Debug().
+				Getf("/sends/%s", testID).
+				Expect(t).
+				Status(tt.status).
+				End()
+		})
+	}
+}
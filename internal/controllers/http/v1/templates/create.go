@@ -0,0 +1,50 @@
+package templates
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/novoseltcev/passkeeper/internal/auth"
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/common/response"
+	domain "github.com/novoseltcev/passkeeper/internal/domains/templates"
+	"github.com/novoseltcev/passkeeper/internal/models"
+)
+
+func Create(service domain.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ownerID := auth.GetUserID(c)
+
+		var body CreateTemplateData
+		if err := c.ShouldBindJSON(&body); err != nil {
+			var vErr validator.ValidationErrors
+			if errors.As(err, &vErr) {
+				c.JSON(http.StatusUnprocessableEntity, response.NewValidationError(vErr))
+			} else {
+				c.JSON(http.StatusBadRequest, response.NewError(err))
+			}
+
+			return
+		}
+
+		fields := make([]models.TemplateField, len(body.Fields))
+		for i, field := range body.Fields {
+			fields[i] = models.TemplateField{Name: field.Name, Type: models.FieldType(field.Type), Required: field.Required}
+		}
+
+		id, err := service.Create(c, ownerID, body.Name, fields)
+		if err != nil {
+			if errors.Is(err, domain.ErrInvalidTemplate) {
+				c.JSON(http.StatusUnprocessableEntity, response.NewError(err))
+			} else {
+				c.AbortWithError(http.StatusInternalServerError, err)
+			}
+
+			return
+		}
+
+		c.JSON(http.StatusCreated, response.NewCreate(string(id)))
+	}
+}
@@ -0,0 +1,55 @@
+package templates
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/novoseltcev/passkeeper/internal/auth"
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/common/response"
+	domain "github.com/novoseltcev/passkeeper/internal/domains/templates"
+	"github.com/novoseltcev/passkeeper/internal/models"
+)
+
+func GetAll(service domain.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ownerID := auth.GetUserID(c)
+
+		templates, err := service.GetAll(c, ownerID)
+		if err != nil {
+			c.AbortWithError(http.StatusInternalServerError, err)
+
+			return
+		}
+
+		schemas := make([]TemplateSchema, len(templates))
+		for i := range templates {
+			schemas[i] = *newTemplateSchema(&templates[i])
+		}
+
+		c.JSON(http.StatusOK, response.NewSuccess(&schemas))
+	}
+}
+
+func Get(service domain.Service) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ownerID := auth.GetUserID(c)
+		id := models.TemplateID(c.Param("id"))
+
+		template, err := service.Get(c, id, ownerID)
+		if err != nil {
+			if errors.Is(err, domain.ErrTemplateNotFound) {
+				c.AbortWithStatus(http.StatusNotFound)
+			} else if errors.Is(err, domain.ErrAnotherOwner) {
+				c.AbortWithStatus(http.StatusForbidden)
+			} else {
+				c.AbortWithError(http.StatusInternalServerError, err)
+			}
+
+			return
+		}
+
+		c.JSON(http.StatusOK, response.NewSuccess(newTemplateSchema(template)))
+	}
+}
@@ -0,0 +1,17 @@
+package templates
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/novoseltcev/passkeeper/internal/domains/templates"
+)
+
+func AddRoutes(rg *gin.RouterGroup, service templates.Service, guard gin.HandlerFunc) {
+	templateGroup := rg.Group("/templates", guard)
+	{
+		templateGroup.GET("", GetAll(service))
+		templateGroup.GET("/:id", Get(service))
+		templateGroup.POST("", Create(service))
+		templateGroup.DELETE("/:id", Delete(service))
+	}
+}
@@ -0,0 +1,29 @@
+package templates
+
+import "github.com/novoseltcev/passkeeper/internal/models"
+
+type TemplateFieldSchema struct {
+	Name     string `binding:"required,max=64"                                                 json:"name"`
+	Type     string `binding:"required,oneof=string concealed url email date number multiline" json:"type"` // nolint: lll
+	Required bool   `binding:""                                                                json:"required"`
+}
+
+type CreateTemplateData struct {
+	Name   string                `binding:"required,min=4,max=32"`
+	Fields []TemplateFieldSchema `binding:"required,min=1,dive"`
+}
+
+type TemplateSchema struct {
+	ID     string                `json:"id"`
+	Name   string                `json:"name"`
+	Fields []TemplateFieldSchema `json:"fields"`
+}
+
+func newTemplateSchema(template *models.Template) *TemplateSchema {
+	fields := make([]TemplateFieldSchema, len(template.Fields))
+	for i, field := range template.Fields {
+		fields[i] = TemplateFieldSchema{Name: field.Name, Type: string(field.Type), Required: field.Required}
+	}
+
+	return &TemplateSchema{ID: string(template.ID), Name: template.Name, Fields: fields}
+}
@@ -0,0 +1,222 @@
+package templates_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/steinfletcher/apitest"
+	"go.uber.org/mock/gomock"
+
+	"github.com/novoseltcev/passkeeper/internal/auth"
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/v1/templates"
+	domain "github.com/novoseltcev/passkeeper/internal/domains/templates"
+	"github.com/novoseltcev/passkeeper/internal/domains/templates/mocks"
+	"github.com/novoseltcev/passkeeper/internal/models"
+	"github.com/novoseltcev/passkeeper/pkg/testutils"
+)
+
+const (
+	testOwnerID = models.UserID("f535204f-9283-4c1a-8e68-8834c6ae83fb")
+	testID      = models.TemplateID("3f0b5a52-0a4c-4c1e-9a55-2f6d3b8a9c11")
+	testName    = "wifi"
+)
+
+var testFields = []models.TemplateField{
+	{Name: "ssid", Type: models.FieldTypeString, Required: true},
+	{Name: "key", Type: models.FieldTypeConcealed},
+}
+
+const testFieldsJSON = `[
+	{"name":"ssid","type":"string","required":true},
+	{"name":"key","type":"concealed","required":false}
+]`
+
+func guardMock(c *gin.Context) {
+	c.Set(auth.IdentityKey, string(testOwnerID))
+	c.Next()
+}
+
+func setup(t *testing.T) (*gin.Engine, *mocks.MockService) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	root := gin.Default()
+	service := mocks.NewMockService(ctrl)
+	templates.AddRoutes(&root.RouterGroup, service, guardMock)
+
+	return root, service
+}
+
+func TestGetAll_Success(t *testing.T) {
+	t.Parallel()
+	root, service := setup(t)
+
+	service.EXPECT().
+		GetAll(gomock.Any(), testOwnerID).
+		Return([]models.Template{{ID: testID, Name: testName, Fields: testFields}}, nil)
+
+	apitest.Handler(root.Handler()).
+		Debug().
+		Get("/templates").
+		Expect(t).
+		Status(http.StatusOK).
+		Bodyf(`{"success":true,"result":[{"id":"%s","name":"%s","fields":%s}]}`, testID, testName, testFieldsJSON).
+		End()
+}
+
+func TestGet_Success(t *testing.T) {
+	t.Parallel()
+	root, service := setup(t)
+
+	service.EXPECT().
+		Get(gomock.Any(), testID, testOwnerID).
+		Return(&models.Template{ID: testID, Name: testName, Fields: testFields}, nil)
+
+	apitest.Handler(root.Handler()).
+		Debug().
+		Getf("/templates/%s", testID).
+		Expect(t).
+		Status(http.StatusOK).
+		Bodyf(`{"success":true,"result":{"id":"%s","name":"%s","fields":%s}}`, testID, testName, testFieldsJSON).
+		End()
+}
+
+func TestGet_Fails(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{name: "not found", err: domain.ErrTemplateNotFound, status: http.StatusNotFound},
+		{name: "not my template", err: domain.ErrAnotherOwner, status: http.StatusForbidden},
+		{name: "other", err: testutils.Err, status: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			root, service := setup(t)
+
+			service.EXPECT().
+				Get(gomock.Any(), testID, testOwnerID).
+				Return(nil, tt.err)
+
+			apitest.New(tt.name).
+				Handler(root.Handler()).
+				Debug().
+				Getf("/templates/%s", testID).
+				Expect(t).
+				Status(tt.status).
+				End()
+		})
+	}
+}
+
+func TestCreate_Success(t *testing.T) {
+	t.Parallel()
+	root, service := setup(t)
+
+	service.EXPECT().
+		Create(gomock.Any(), testOwnerID, testName, testFields).
+		Return(testID, nil)
+
+	apitest.Handler(root.Handler()).
+		Debug().
+		Post("/templates").
+		Bodyf(`{"name":"%s","fields":%s}`, testName, testFieldsJSON).
+		Expect(t).
+		Status(http.StatusCreated).
+		Bodyf(`{"success":true,"result":{"id":"%s"}}`, testID).
+		End()
+}
+
+func TestCreate_Fails(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		body   string
+		err    error
+		status int
+	}{
+		{name: "no fields", body: `{"name":"wifi","fields":[]}`, status: http.StatusUnprocessableEntity},
+		{
+			name:   "unknown type",
+			body:   `{"name":"wifi","fields":[{"name":"ssid","type":"binary"}]}`,
+			status: http.StatusUnprocessableEntity,
+		},
+		{name: "invalid json", body: `{`, status: http.StatusBadRequest},
+		{
+			name:   "invalid template",
+			body:   `{"name":"wifi","fields":[{"name":"ssid","type":"string"}]}`,
+			err:    domain.ErrInvalidTemplate,
+			status: http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "other",
+			body:   `{"name":"wifi","fields":[{"name":"ssid","type":"string"}]}`,
+			err:    testutils.Err,
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			root, service := setup(t)
+
+			if tt.err != nil {
+				service.EXPECT().
+					Create(gomock.Any(), testOwnerID, testName, gomock.Any()).
+					Return(models.TemplateID(""), tt.err)
+			}
+
+			apitest.New(tt.name).
+				Handler(root.Handler()).
+				Debug().
+				Post("/templates").
+				Body(tt.body).
+				Expect(t).
+				Status(tt.status).
+				End()
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{name: "success", status: http.StatusNoContent},
+		{name: "not found", err: domain.ErrTemplateNotFound, status: http.StatusNoContent},
+		{name: "not my template", err: domain.ErrAnotherOwner, status: http.StatusForbidden},
+		{name: "other", err: testutils.Err, status: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			root, service := setup(t)
+
+			service.EXPECT().
+				Delete(gomock.Any(), testID, testOwnerID).
+				Return(tt.err)
+
+			apitest.New(tt.name).
+				Handler(root.Handler()).
+				Debug().
+				Deletef("/templates/%s", testID).
+				Expect(t).
+				Status(tt.status).
+				End()
+		})
+	}
+}
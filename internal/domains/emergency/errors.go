@@ -0,0 +1,15 @@
+package emergency
+
+import "errors"
+
+var (
+	ErrAccessNotFound    = errors.New("emergency access not found")
+	ErrForbidden         = errors.New("not allowed for the party of the emergency access")
+	ErrInvalidStatus     = errors.New("not allowed in the status of the emergency access")
+	ErrInvalidPassphrase = errors.New("invalid passphrase")
+	ErrInvalidWaitPeriod = errors.New("invalid wait period")
+	ErrInvalidContact    = errors.New("user can not be a trusted contact of themselves")
+	ErrUserNotFound      = errors.New("user not found")
+	ErrNoPublicKey       = errors.New("user has no public key")
+	ErrSecretNotInVault  = errors.New("secret is not in the vault of the grantor")
+)
@@ -0,0 +1,391 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository.go
+//
+// Generated by this command:
+//
+//	mockgen -destination=mocks/repository_mock.go -package=mocks -source=repository.go -typed
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/novoseltcev/passkeeper/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockRepository) Delete(ctx context.Context, id models.EmergencyID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRepositoryMockRecorder) Delete(ctx, id any) *MockRepositoryDeleteCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), ctx, id)
+	return &MockRepositoryDeleteCall{Call: call}
+}
+
+// MockRepositoryDeleteCall wrap *gomock.Call
+type MockRepositoryDeleteCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRepositoryDeleteCall) Return(arg0 error) *MockRepositoryDeleteCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRepositoryDeleteCall) Do(f func(context.Context, models.EmergencyID) error) *MockRepositoryDeleteCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRepositoryDeleteCall) DoAndReturn(f func(context.Context, models.EmergencyID) error) *MockRepositoryDeleteCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Get mocks base method.
+func (m *MockRepository) Get(ctx context.Context, id models.EmergencyID) (*models.EmergencyAccess, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*models.EmergencyAccess)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockRepositoryMockRecorder) Get(ctx, id any) *MockRepositoryGetCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRepository)(nil).Get), ctx, id)
+	return &MockRepositoryGetCall{Call: call}
+}
+
+// MockRepositoryGetCall wrap *gomock.Call
+type MockRepositoryGetCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRepositoryGetCall) Return(arg0 *models.EmergencyAccess, arg1 error) *MockRepositoryGetCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRepositoryGetCall) Do(f func(context.Context, models.EmergencyID) (*models.EmergencyAccess, error)) *MockRepositoryGetCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRepositoryGetCall) DoAndReturn(f func(context.Context, models.EmergencyID) (*models.EmergencyAccess, error)) *MockRepositoryGetCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetByGrantee mocks base method.
+func (m *MockRepository) GetByGrantee(ctx context.Context, userID models.UserID) ([]models.EmergencyAccess, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByGrantee", ctx, userID)
+	ret0, _ := ret[0].([]models.EmergencyAccess)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByGrantee indicates an expected call of GetByGrantee.
+func (mr *MockRepositoryMockRecorder) GetByGrantee(ctx, userID any) *MockRepositoryGetByGranteeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByGrantee", reflect.TypeOf((*MockRepository)(nil).GetByGrantee), ctx, userID)
+	return &MockRepositoryGetByGranteeCall{Call: call}
+}
+
+// MockRepositoryGetByGranteeCall wrap *gomock.Call
+type MockRepositoryGetByGranteeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRepositoryGetByGranteeCall) Return(arg0 []models.EmergencyAccess, arg1 error) *MockRepositoryGetByGranteeCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRepositoryGetByGranteeCall) Do(f func(context.Context, models.UserID) ([]models.EmergencyAccess, error)) *MockRepositoryGetByGranteeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRepositoryGetByGranteeCall) DoAndReturn(f func(context.Context, models.UserID) ([]models.EmergencyAccess, error)) *MockRepositoryGetByGranteeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetByGrantor mocks base method.
+func (m *MockRepository) GetByGrantor(ctx context.Context, userID models.UserID) ([]models.EmergencyAccess, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByGrantor", ctx, userID)
+	ret0, _ := ret[0].([]models.EmergencyAccess)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByGrantor indicates an expected call of GetByGrantor.
+func (mr *MockRepositoryMockRecorder) GetByGrantor(ctx, userID any) *MockRepositoryGetByGrantorCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByGrantor", reflect.TypeOf((*MockRepository)(nil).GetByGrantor), ctx, userID)
+	return &MockRepositoryGetByGrantorCall{Call: call}
+}
+
+// MockRepositoryGetByGrantorCall wrap *gomock.Call
+type MockRepositoryGetByGrantorCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRepositoryGetByGrantorCall) Return(arg0 []models.EmergencyAccess, arg1 error) *MockRepositoryGetByGrantorCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRepositoryGetByGrantorCall) Do(f func(context.Context, models.UserID) ([]models.EmergencyAccess, error)) *MockRepositoryGetByGrantorCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRepositoryGetByGrantorCall) DoAndReturn(f func(context.Context, models.UserID) ([]models.EmergencyAccess, error)) *MockRepositoryGetByGrantorCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetUser mocks base method.
+func (m *MockRepository) GetUser(ctx context.Context, id models.UserID) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", ctx, id)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockRepositoryMockRecorder) GetUser(ctx, id any) *MockRepositoryGetUserCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockRepository)(nil).GetUser), ctx, id)
+	return &MockRepositoryGetUserCall{Call: call}
+}
+
+// MockRepositoryGetUserCall wrap *gomock.Call
+type MockRepositoryGetUserCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRepositoryGetUserCall) Return(arg0 *models.User, arg1 error) *MockRepositoryGetUserCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRepositoryGetUserCall) Do(f func(context.Context, models.UserID) (*models.User, error)) *MockRepositoryGetUserCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRepositoryGetUserCall) DoAndReturn(f func(context.Context, models.UserID) (*models.User, error)) *MockRepositoryGetUserCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetUserByLogin mocks base method.
+func (m *MockRepository) GetUserByLogin(ctx context.Context, login string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByLogin", ctx, login)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByLogin indicates an expected call of GetUserByLogin.
+func (mr *MockRepositoryMockRecorder) GetUserByLogin(ctx, login any) *MockRepositoryGetUserByLoginCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByLogin", reflect.TypeOf((*MockRepository)(nil).GetUserByLogin), ctx, login)
+	return &MockRepositoryGetUserByLoginCall{Call: call}
+}
+
+// MockRepositoryGetUserByLoginCall wrap *gomock.Call
+type MockRepositoryGetUserByLoginCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRepositoryGetUserByLoginCall) Return(arg0 *models.User, arg1 error) *MockRepositoryGetUserByLoginCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRepositoryGetUserByLoginCall) Do(f func(context.Context, string) (*models.User, error)) *MockRepositoryGetUserByLoginCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRepositoryGetUserByLoginCall) DoAndReturn(f func(context.Context, string) (*models.User, error)) *MockRepositoryGetUserByLoginCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GrantExpired mocks base method.
+func (m *MockRepository) GrantExpired(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GrantExpired", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GrantExpired indicates an expected call of GrantExpired.
+func (mr *MockRepositoryMockRecorder) GrantExpired(ctx any) *MockRepositoryGrantExpiredCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrantExpired", reflect.TypeOf((*MockRepository)(nil).GrantExpired), ctx)
+	return &MockRepositoryGrantExpiredCall{Call: call}
+}
+
+// MockRepositoryGrantExpiredCall wrap *gomock.Call
+type MockRepositoryGrantExpiredCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRepositoryGrantExpiredCall) Return(arg0 int64, arg1 error) *MockRepositoryGrantExpiredCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRepositoryGrantExpiredCall) Do(f func(context.Context) (int64, error)) *MockRepositoryGrantExpiredCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRepositoryGrantExpiredCall) DoAndReturn(f func(context.Context) (int64, error)) *MockRepositoryGrantExpiredCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Save mocks base method.
+func (m *MockRepository) Save(ctx context.Context, access *models.EmergencyAccess) (models.EmergencyID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, access)
+	ret0, _ := ret[0].(models.EmergencyID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockRepositoryMockRecorder) Save(ctx, access any) *MockRepositorySaveCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockRepository)(nil).Save), ctx, access)
+	return &MockRepositorySaveCall{Call: call}
+}
+
+// MockRepositorySaveCall wrap *gomock.Call
+type MockRepositorySaveCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRepositorySaveCall) Return(arg0 models.EmergencyID, arg1 error) *MockRepositorySaveCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRepositorySaveCall) Do(f func(context.Context, *models.EmergencyAccess) (models.EmergencyID, error)) *MockRepositorySaveCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRepositorySaveCall) DoAndReturn(f func(context.Context, *models.EmergencyAccess) (models.EmergencyID, error)) *MockRepositorySaveCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// SetStatus mocks base method.
+func (m *MockRepository) SetStatus(ctx context.Context, id models.EmergencyID, status models.EmergencyStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetStatus", ctx, id, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetStatus indicates an expected call of SetStatus.
+func (mr *MockRepositoryMockRecorder) SetStatus(ctx, id, status any) *MockRepositorySetStatusCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStatus", reflect.TypeOf((*MockRepository)(nil).SetStatus), ctx, id, status)
+	return &MockRepositorySetStatusCall{Call: call}
+}
+
+// MockRepositorySetStatusCall wrap *gomock.Call
+type MockRepositorySetStatusCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRepositorySetStatusCall) Return(arg0 error) *MockRepositorySetStatusCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRepositorySetStatusCall) Do(f func(context.Context, models.EmergencyID, models.EmergencyStatus) error) *MockRepositorySetStatusCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRepositorySetStatusCall) DoAndReturn(f func(context.Context, models.EmergencyID, models.EmergencyStatus) error) *MockRepositorySetStatusCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
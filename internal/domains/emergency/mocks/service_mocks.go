@@ -0,0 +1,696 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mocks/service_mocks.go -package=mocks -source=service.go -typed
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	secrets "github.com/novoseltcev/passkeeper/internal/domains/secrets"
+	models "github.com/novoseltcev/passkeeper/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// Approve mocks base method.
+func (m *MockService) Approve(ctx context.Context, id models.EmergencyID, userID models.UserID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Approve", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Approve indicates an expected call of Approve.
+func (mr *MockServiceMockRecorder) Approve(ctx, id, userID any) *MockServiceApproveCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Approve", reflect.TypeOf((*MockService)(nil).Approve), ctx, id, userID)
+	return &MockServiceApproveCall{Call: call}
+}
+
+// MockServiceApproveCall wrap *gomock.Call
+type MockServiceApproveCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockServiceApproveCall) Return(arg0 error) *MockServiceApproveCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockServiceApproveCall) Do(f func(context.Context, models.EmergencyID, models.UserID) error) *MockServiceApproveCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockServiceApproveCall) DoAndReturn(f func(context.Context, models.EmergencyID, models.UserID) error) *MockServiceApproveCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetContacts mocks base method.
+func (m *MockService) GetContacts(ctx context.Context, userID models.UserID) ([]models.EmergencyAccess, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContacts", ctx, userID)
+	ret0, _ := ret[0].([]models.EmergencyAccess)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContacts indicates an expected call of GetContacts.
+func (mr *MockServiceMockRecorder) GetContacts(ctx, userID any) *MockServiceGetContactsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContacts", reflect.TypeOf((*MockService)(nil).GetContacts), ctx, userID)
+	return &MockServiceGetContactsCall{Call: call}
+}
+
+// MockServiceGetContactsCall wrap *gomock.Call
+type MockServiceGetContactsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockServiceGetContactsCall) Return(arg0 []models.EmergencyAccess, arg1 error) *MockServiceGetContactsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockServiceGetContactsCall) Do(f func(context.Context, models.UserID) ([]models.EmergencyAccess, error)) *MockServiceGetContactsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockServiceGetContactsCall) DoAndReturn(f func(context.Context, models.UserID) ([]models.EmergencyAccess, error)) *MockServiceGetContactsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetGrantors mocks base method.
+func (m *MockService) GetGrantors(ctx context.Context, userID models.UserID) ([]models.EmergencyAccess, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGrantors", ctx, userID)
+	ret0, _ := ret[0].([]models.EmergencyAccess)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGrantors indicates an expected call of GetGrantors.
+func (mr *MockServiceMockRecorder) GetGrantors(ctx, userID any) *MockServiceGetGrantorsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGrantors", reflect.TypeOf((*MockService)(nil).GetGrantors), ctx, userID)
+	return &MockServiceGetGrantorsCall{Call: call}
+}
+
+// MockServiceGetGrantorsCall wrap *gomock.Call
+type MockServiceGetGrantorsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockServiceGetGrantorsCall) Return(arg0 []models.EmergencyAccess, arg1 error) *MockServiceGetGrantorsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockServiceGetGrantorsCall) Do(f func(context.Context, models.UserID) ([]models.EmergencyAccess, error)) *MockServiceGetGrantorsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockServiceGetGrantorsCall) DoAndReturn(f func(context.Context, models.UserID) ([]models.EmergencyAccess, error)) *MockServiceGetGrantorsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetSecret mocks base method.
+func (m *MockService) GetSecret(ctx context.Context, id models.EmergencyID, userID models.UserID, passphrase string, secretID models.SecretID) (*models.Secret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecret", ctx, id, userID, passphrase, secretID)
+	ret0, _ := ret[0].(*models.Secret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSecret indicates an expected call of GetSecret.
+func (mr *MockServiceMockRecorder) GetSecret(ctx, id, userID, passphrase, secretID any) *MockServiceGetSecretCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecret", reflect.TypeOf((*MockService)(nil).GetSecret), ctx, id, userID, passphrase, secretID)
+	return &MockServiceGetSecretCall{Call: call}
+}
+
+// MockServiceGetSecretCall wrap *gomock.Call
+type MockServiceGetSecretCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockServiceGetSecretCall) Return(arg0 *models.Secret, arg1 error) *MockServiceGetSecretCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockServiceGetSecretCall) Do(f func(context.Context, models.EmergencyID, models.UserID, string, models.SecretID) (*models.Secret, error)) *MockServiceGetSecretCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockServiceGetSecretCall) DoAndReturn(f func(context.Context, models.EmergencyID, models.UserID, string, models.SecretID) (*models.Secret, error)) *MockServiceGetSecretCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetVault mocks base method.
+func (m *MockService) GetVault(ctx context.Context, id models.EmergencyID, userID models.UserID, limit, offset uint64) (*secrets.Page[models.Secret], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVault", ctx, id, userID, limit, offset)
+	ret0, _ := ret[0].(*secrets.Page[models.Secret])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVault indicates an expected call of GetVault.
+func (mr *MockServiceMockRecorder) GetVault(ctx, id, userID, limit, offset any) *MockServiceGetVaultCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVault", reflect.TypeOf((*MockService)(nil).GetVault), ctx, id, userID, limit, offset)
+	return &MockServiceGetVaultCall{Call: call}
+}
+
+// MockServiceGetVaultCall wrap *gomock.Call
+type MockServiceGetVaultCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockServiceGetVaultCall) Return(arg0 *secrets.Page[models.Secret], arg1 error) *MockServiceGetVaultCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockServiceGetVaultCall) Do(f func(context.Context, models.EmergencyID, models.UserID, uint64, uint64) (*secrets.Page[models.Secret], error)) *MockServiceGetVaultCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockServiceGetVaultCall) DoAndReturn(f func(context.Context, models.EmergencyID, models.UserID, uint64, uint64) (*secrets.Page[models.Secret], error)) *MockServiceGetVaultCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GrantExpired mocks base method.
+func (m *MockService) GrantExpired(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GrantExpired", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GrantExpired indicates an expected call of GrantExpired.
+func (mr *MockServiceMockRecorder) GrantExpired(ctx any) *MockServiceGrantExpiredCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrantExpired", reflect.TypeOf((*MockService)(nil).GrantExpired), ctx)
+	return &MockServiceGrantExpiredCall{Call: call}
+}
+
+// MockServiceGrantExpiredCall wrap *gomock.Call
+type MockServiceGrantExpiredCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockServiceGrantExpiredCall) Return(arg0 int64, arg1 error) *MockServiceGrantExpiredCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockServiceGrantExpiredCall) Do(f func(context.Context) (int64, error)) *MockServiceGrantExpiredCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockServiceGrantExpiredCall) DoAndReturn(f func(context.Context) (int64, error)) *MockServiceGrantExpiredCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Invite mocks base method.
+func (m *MockService) Invite(ctx context.Context, userID models.UserID, passphrase, login string, waitDays int) (models.EmergencyID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Invite", ctx, userID, passphrase, login, waitDays)
+	ret0, _ := ret[0].(models.EmergencyID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Invite indicates an expected call of Invite.
+func (mr *MockServiceMockRecorder) Invite(ctx, userID, passphrase, login, waitDays any) *MockServiceInviteCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invite", reflect.TypeOf((*MockService)(nil).Invite), ctx, userID, passphrase, login, waitDays)
+	return &MockServiceInviteCall{Call: call}
+}
+
+// MockServiceInviteCall wrap *gomock.Call
+type MockServiceInviteCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockServiceInviteCall) Return(arg0 models.EmergencyID, arg1 error) *MockServiceInviteCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockServiceInviteCall) Do(f func(context.Context, models.UserID, string, string, int) (models.EmergencyID, error)) *MockServiceInviteCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockServiceInviteCall) DoAndReturn(f func(context.Context, models.UserID, string, string, int) (models.EmergencyID, error)) *MockServiceInviteCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Reject mocks base method.
+func (m *MockService) Reject(ctx context.Context, id models.EmergencyID, userID models.UserID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reject", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reject indicates an expected call of Reject.
+func (mr *MockServiceMockRecorder) Reject(ctx, id, userID any) *MockServiceRejectCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reject", reflect.TypeOf((*MockService)(nil).Reject), ctx, id, userID)
+	return &MockServiceRejectCall{Call: call}
+}
+
+// MockServiceRejectCall wrap *gomock.Call
+type MockServiceRejectCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockServiceRejectCall) Return(arg0 error) *MockServiceRejectCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockServiceRejectCall) Do(f func(context.Context, models.EmergencyID, models.UserID) error) *MockServiceRejectCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockServiceRejectCall) DoAndReturn(f func(context.Context, models.EmergencyID, models.UserID) error) *MockServiceRejectCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Request mocks base method.
+func (m *MockService) Request(ctx context.Context, id models.EmergencyID, userID models.UserID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Request", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Request indicates an expected call of Request.
+func (mr *MockServiceMockRecorder) Request(ctx, id, userID any) *MockServiceRequestCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Request", reflect.TypeOf((*MockService)(nil).Request), ctx, id, userID)
+	return &MockServiceRequestCall{Call: call}
+}
+
+// MockServiceRequestCall wrap *gomock.Call
+type MockServiceRequestCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockServiceRequestCall) Return(arg0 error) *MockServiceRequestCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockServiceRequestCall) Do(f func(context.Context, models.EmergencyID, models.UserID) error) *MockServiceRequestCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockServiceRequestCall) DoAndReturn(f func(context.Context, models.EmergencyID, models.UserID) error) *MockServiceRequestCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Revoke mocks base method.
+func (m *MockService) Revoke(ctx context.Context, id models.EmergencyID, userID models.UserID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockServiceMockRecorder) Revoke(ctx, id, userID any) *MockServiceRevokeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockService)(nil).Revoke), ctx, id, userID)
+	return &MockServiceRevokeCall{Call: call}
+}
+
+// MockServiceRevokeCall wrap *gomock.Call
+type MockServiceRevokeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockServiceRevokeCall) Return(arg0 error) *MockServiceRevokeCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockServiceRevokeCall) Do(f func(context.Context, models.EmergencyID, models.UserID) error) *MockServiceRevokeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockServiceRevokeCall) DoAndReturn(f func(context.Context, models.EmergencyID, models.UserID) error) *MockServiceRevokeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockSecrets is a mock of Secrets interface.
+type MockSecrets struct {
+	ctrl     *gomock.Controller
+	recorder *MockSecretsMockRecorder
+	isgomock struct{}
+}
+
+// MockSecretsMockRecorder is the mock recorder for MockSecrets.
+type MockSecretsMockRecorder struct {
+	mock *MockSecrets
+}
+
+// NewMockSecrets creates a new mock instance.
+func NewMockSecrets(ctrl *gomock.Controller) *MockSecrets {
+	mock := &MockSecrets{ctrl: ctrl}
+	mock.recorder = &MockSecretsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSecrets) EXPECT() *MockSecretsMockRecorder {
+	return m.recorder
+}
+
+// Escrow mocks base method.
+func (m *MockSecrets) Escrow(ctx context.Context, ownerID models.UserID, passphrase string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Escrow", ctx, ownerID, passphrase)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Escrow indicates an expected call of Escrow.
+func (mr *MockSecretsMockRecorder) Escrow(ctx, ownerID, passphrase any) *MockSecretsEscrowCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Escrow", reflect.TypeOf((*MockSecrets)(nil).Escrow), ctx, ownerID, passphrase)
+	return &MockSecretsEscrowCall{Call: call}
+}
+
+// MockSecretsEscrowCall wrap *gomock.Call
+type MockSecretsEscrowCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSecretsEscrowCall) Return(arg0 []byte, arg1 error) *MockSecretsEscrowCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSecretsEscrowCall) Do(f func(context.Context, models.UserID, string) ([]byte, error)) *MockSecretsEscrowCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSecretsEscrowCall) DoAndReturn(f func(context.Context, models.UserID, string) ([]byte, error)) *MockSecretsEscrowCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetEscrowed mocks base method.
+func (m *MockSecrets) GetEscrowed(ctx context.Context, id models.SecretID, ownerID models.UserID, escrowKey []byte) (*models.Secret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEscrowed", ctx, id, ownerID, escrowKey)
+	ret0, _ := ret[0].(*models.Secret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEscrowed indicates an expected call of GetEscrowed.
+func (mr *MockSecretsMockRecorder) GetEscrowed(ctx, id, ownerID, escrowKey any) *MockSecretsGetEscrowedCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEscrowed", reflect.TypeOf((*MockSecrets)(nil).GetEscrowed), ctx, id, ownerID, escrowKey)
+	return &MockSecretsGetEscrowedCall{Call: call}
+}
+
+// MockSecretsGetEscrowedCall wrap *gomock.Call
+type MockSecretsGetEscrowedCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSecretsGetEscrowedCall) Return(arg0 *models.Secret, arg1 error) *MockSecretsGetEscrowedCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSecretsGetEscrowedCall) Do(f func(context.Context, models.SecretID, models.UserID, []byte) (*models.Secret, error)) *MockSecretsGetEscrowedCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSecretsGetEscrowedCall) DoAndReturn(f func(context.Context, models.SecretID, models.UserID, []byte) (*models.Secret, error)) *MockSecretsGetEscrowedCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetPage mocks base method.
+func (m *MockSecrets) GetPage(ctx context.Context, ownerID models.UserID, limit, offset uint64) (*secrets.Page[models.Secret], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPage", ctx, ownerID, limit, offset)
+	ret0, _ := ret[0].(*secrets.Page[models.Secret])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPage indicates an expected call of GetPage.
+func (mr *MockSecretsMockRecorder) GetPage(ctx, ownerID, limit, offset any) *MockSecretsGetPageCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPage", reflect.TypeOf((*MockSecrets)(nil).GetPage), ctx, ownerID, limit, offset)
+	return &MockSecretsGetPageCall{Call: call}
+}
+
+// MockSecretsGetPageCall wrap *gomock.Call
+type MockSecretsGetPageCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSecretsGetPageCall) Return(arg0 *secrets.Page[models.Secret], arg1 error) *MockSecretsGetPageCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSecretsGetPageCall) Do(f func(context.Context, models.UserID, uint64, uint64) (*secrets.Page[models.Secret], error)) *MockSecretsGetPageCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSecretsGetPageCall) DoAndReturn(f func(context.Context, models.UserID, uint64, uint64) (*secrets.Page[models.Secret], error)) *MockSecretsGetPageCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockHasher is a mock of Hasher interface.
+type MockHasher struct {
+	ctrl     *gomock.Controller
+	recorder *MockHasherMockRecorder
+	isgomock struct{}
+}
+
+// MockHasherMockRecorder is the mock recorder for MockHasher.
+type MockHasherMockRecorder struct {
+	mock *MockHasher
+}
+
+// NewMockHasher creates a new mock instance.
+func NewMockHasher(ctrl *gomock.Controller) *MockHasher {
+	mock := &MockHasher{ctrl: ctrl}
+	mock.recorder = &MockHasherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHasher) EXPECT() *MockHasherMockRecorder {
+	return m.recorder
+}
+
+// Compare mocks base method.
+func (m *MockHasher) Compare(hash, v string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Compare", hash, v)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Compare indicates an expected call of Compare.
+func (mr *MockHasherMockRecorder) Compare(hash, v any) *MockHasherCompareCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Compare", reflect.TypeOf((*MockHasher)(nil).Compare), hash, v)
+	return &MockHasherCompareCall{Call: call}
+}
+
+// MockHasherCompareCall wrap *gomock.Call
+type MockHasherCompareCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockHasherCompareCall) Return(arg0 bool, arg1 error) *MockHasherCompareCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockHasherCompareCall) Do(f func(string, string) (bool, error)) *MockHasherCompareCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockHasherCompareCall) DoAndReturn(f func(string, string) (bool, error)) *MockHasherCompareCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockEncryptor is a mock of Encryptor interface.
+type MockEncryptor struct {
+	ctrl     *gomock.Controller
+	recorder *MockEncryptorMockRecorder
+	isgomock struct{}
+}
+
+// MockEncryptorMockRecorder is the mock recorder for MockEncryptor.
+type MockEncryptorMockRecorder struct {
+	mock *MockEncryptor
+}
+
+// NewMockEncryptor creates a new mock instance.
+func NewMockEncryptor(ctrl *gomock.Controller) *MockEncryptor {
+	mock := &MockEncryptor{ctrl: ctrl}
+	mock.recorder = &MockEncryptorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEncryptor) EXPECT() *MockEncryptorMockRecorder {
+	return m.recorder
+}
+
+// Decrypt mocks base method.
+func (m *MockEncryptor) Decrypt(passphrase, v []byte) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Decrypt", passphrase, v)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Decrypt indicates an expected call of Decrypt.
+func (mr *MockEncryptorMockRecorder) Decrypt(passphrase, v any) *MockEncryptorDecryptCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Decrypt", reflect.TypeOf((*MockEncryptor)(nil).Decrypt), passphrase, v)
+	return &MockEncryptorDecryptCall{Call: call}
+}
+
+// MockEncryptorDecryptCall wrap *gomock.Call
+type MockEncryptorDecryptCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockEncryptorDecryptCall) Return(arg0 []byte, arg1 error) *MockEncryptorDecryptCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockEncryptorDecryptCall) Do(f func([]byte, []byte) ([]byte, error)) *MockEncryptorDecryptCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockEncryptorDecryptCall) DoAndReturn(f func([]byte, []byte) ([]byte, error)) *MockEncryptorDecryptCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
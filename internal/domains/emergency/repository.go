@@ -0,0 +1,28 @@
+package emergency
+
+import (
+	"context"
+
+	"github.com/novoseltcev/passkeeper/internal/models"
+)
+
+//go:generate mockgen -destination=mocks/repository_mock.go -package=mocks -source=repository.go -typed
+
+type Repository interface {
+	GetUser(ctx context.Context, id models.UserID) (*models.User, error)
+	GetUserByLogin(ctx context.Context, login string) (*models.User, error)
+	// Save creates the access or replaces the wait period and the key of the existing one and makes it idle.
+	Save(ctx context.Context, access *models.EmergencyAccess) (models.EmergencyID, error)
+	// Get returns the access with the logins of its parties.
+	Get(ctx context.Context, id models.EmergencyID) (*models.EmergencyAccess, error)
+	// GetByGrantor returns the trusted contacts of the user.
+	GetByGrantor(ctx context.Context, userID models.UserID) ([]models.EmergencyAccess, error)
+	// GetByGrantee returns the accesses, where the user is a trusted contact.
+	GetByGrantee(ctx context.Context, userID models.UserID) ([]models.EmergencyAccess, error)
+	// SetStatus changes the status of the access, the request time is set for requested accesses
+	// and cleared for idle ones.
+	SetStatus(ctx context.Context, id models.EmergencyID, status models.EmergencyStatus) error
+	Delete(ctx context.Context, id models.EmergencyID) error
+	// GrantExpired grants the requests, whose wait period is over, and returns their count.
+	GrantExpired(ctx context.Context) (int64, error)
+}
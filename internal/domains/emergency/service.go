@@ -0,0 +1,388 @@
+// Package emergency provides a domain for trusted contacts, who get a read-only access to a vault in an emergency.
+package emergency
+
+import (
+	"context"
+	"errors"
+
+	"github.com/novoseltcev/passkeeper/internal/domains/secrets"
+	"github.com/novoseltcev/passkeeper/internal/models"
+	"github.com/novoseltcev/passkeeper/pkg/sealbox"
+)
+
+//go:generate mockgen -destination=./mocks/service_mocks.go -package=mocks -source=service.go -typed
+
+// MaxWaitDays is the longest wait period of a request.
+const MaxWaitDays = 90
+
+// Service is a domain service for emergency accesses.
+type Service interface {
+	// Invite makes the user with the login a trusted contact of the user.
+	//
+	// The escrow key of the personal vault is sealed to the public key of the contact, so the passphrase
+	// of the user is required. The escrow key only reads the personal secrets, the passphrase is not escrowed.
+	// The existing access to the contact is replaced and made idle.
+	// Domain errors:
+	// - ErrInvalidWaitPeriod
+	// - ErrInvalidPassphrase
+	// - ErrUserNotFound
+	// - ErrInvalidContact if the contact is the user
+	// - ErrNoPublicKey if the contact has not generated the key pair yet
+	Invite(
+		ctx context.Context,
+		userID models.UserID,
+		passphrase string,
+		login string,
+		waitDays int,
+	) (models.EmergencyID, error)
+
+	// GetContacts returns the trusted contacts of the user.
+	GetContacts(ctx context.Context, userID models.UserID) ([]models.EmergencyAccess, error)
+
+	// GetGrantors returns the accesses, where the user is a trusted contact.
+	GetGrantors(ctx context.Context, userID models.UserID) ([]models.EmergencyAccess, error)
+
+	// Request requests the access to the vault of the grantor.
+	//
+	// The access is granted once the grantor approves it or the wait period is over.
+	// Domain errors:
+	// - ErrAccessNotFound
+	// - ErrForbidden if the user is not the contact
+	// - ErrInvalidStatus if the access is not idle
+	Request(ctx context.Context, id models.EmergencyID, userID models.UserID) error
+
+	// Approve grants the requested access before the wait period is over.
+	//
+	// Domain errors:
+	// - ErrAccessNotFound
+	// - ErrForbidden if the user is not the grantor
+	// - ErrInvalidStatus if the access is not requested
+	Approve(ctx context.Context, id models.EmergencyID, userID models.UserID) error
+
+	// Reject rejects the request or takes the granted access back, the contact may request it again.
+	//
+	// Domain errors:
+	// - ErrAccessNotFound
+	// - ErrForbidden if the user is not the grantor
+	// - ErrInvalidStatus if the access is idle
+	Reject(ctx context.Context, id models.EmergencyID, userID models.UserID) error
+
+	// Revoke deletes the access, both the grantor and the contact revoke it.
+	//
+	// Domain errors:
+	// - ErrAccessNotFound
+	Revoke(ctx context.Context, id models.EmergencyID, userID models.UserID) error
+
+	// GetVault returns a page of the personal secrets of the grantor without the data.
+	//
+	// Domain errors:
+	// - ErrAccessNotFound
+	// - ErrForbidden if the user is not the contact
+	// - ErrInvalidStatus if the access is not granted
+	GetVault(
+		ctx context.Context,
+		id models.EmergencyID,
+		userID models.UserID,
+		limit, offset uint64,
+	) (*secrets.Page[models.Secret], error)
+
+	// GetSecret returns the decrypted personal secret of the grantor, the secret is not marked as accessed.
+	//
+	// The escrowed key is opened with the private key of the contact, so the passphrase of the contact is required.
+	// Domain errors:
+	// - ErrAccessNotFound
+	// - ErrForbidden if the user is not the contact
+	// - ErrInvalidStatus if the access is not granted
+	// - ErrInvalidPassphrase
+	// - ErrSecretNotInVault
+	// - secrets.ErrSecretNotFound
+	GetSecret(
+		ctx context.Context,
+		id models.EmergencyID,
+		userID models.UserID,
+		passphrase string,
+		secretID models.SecretID,
+	) (*models.Secret, error)
+
+	// GrantExpired grants the requests, whose wait period is over, and returns their count.
+	GrantExpired(ctx context.Context) (int64, error)
+}
+
+// Secrets escrows the vaults of the grantors and reads their secrets.
+type Secrets interface {
+	Escrow(ctx context.Context, ownerID models.UserID, passphrase string) ([]byte, error)
+	GetEscrowed(ctx context.Context, id models.SecretID, ownerID models.UserID, escrowKey []byte) (*models.Secret, error)
+	GetPage(ctx context.Context, ownerID models.UserID, limit, offset uint64) (*secrets.Page[models.Secret], error)
+}
+
+type Hasher interface {
+	Compare(hash, v string) (bool, error)
+}
+
+type Encryptor interface {
+	Decrypt(passphrase, v []byte) ([]byte, error)
+}
+
+type service struct {
+	repo    Repository
+	secrets Secrets
+	hasher  Hasher
+	enc     Encryptor
+}
+
+var _ Service = (*service)(nil)
+
+func NewService(repo Repository, secrets Secrets, hasher Hasher, enc Encryptor) *service { // nolint: revive
+	return &service{repo: repo, secrets: secrets, hasher: hasher, enc: enc}
+}
+
+func (s *service) Invite(
+	ctx context.Context,
+	userID models.UserID,
+	passphrase string,
+	login string,
+	waitDays int,
+) (models.EmergencyID, error) {
+	if waitDays < 1 || waitDays > MaxWaitDays {
+		return "", ErrInvalidWaitPeriod
+	}
+
+	grantor, err := s.repo.GetUser(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.checkPassphrase(grantor, passphrase); err != nil {
+		return "", err
+	}
+
+	grantee, err := s.repo.GetUserByLogin(ctx, login)
+	if err != nil {
+		return "", err
+	}
+
+	if grantee.ID == grantor.ID {
+		return "", ErrInvalidContact
+	}
+
+	if len(grantee.PublicKey) == 0 {
+		return "", ErrNoPublicKey
+	}
+
+	escrowKey, err := s.secrets.Escrow(ctx, grantor.ID, passphrase)
+	if err != nil {
+		return "", err
+	}
+
+	sealed, err := sealbox.Seal(grantee.PublicKey, escrowKey)
+	if err != nil {
+		return "", err
+	}
+
+	return s.repo.Save(ctx, &models.EmergencyAccess{
+		Grantor:  grantor,
+		Grantee:  grantee,
+		Status:   models.EmergencyStatusInvited,
+		WaitDays: waitDays,
+		Key:      sealed,
+	})
+}
+
+func (s *service) GetContacts(ctx context.Context, userID models.UserID) ([]models.EmergencyAccess, error) {
+	return s.repo.GetByGrantor(ctx, userID)
+}
+
+func (s *service) GetGrantors(ctx context.Context, userID models.UserID) ([]models.EmergencyAccess, error) {
+	return s.repo.GetByGrantee(ctx, userID)
+}
+
+func (s *service) Request(ctx context.Context, id models.EmergencyID, userID models.UserID) error {
+	access, err := s.getAsGrantee(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+
+	if access.Status != models.EmergencyStatusInvited {
+		return ErrInvalidStatus
+	}
+
+	return s.repo.SetStatus(ctx, id, models.EmergencyStatusRequested)
+}
+
+func (s *service) Approve(ctx context.Context, id models.EmergencyID, userID models.UserID) error {
+	access, err := s.getAsGrantor(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+
+	if access.Status != models.EmergencyStatusRequested {
+		return ErrInvalidStatus
+	}
+
+	return s.repo.SetStatus(ctx, id, models.EmergencyStatusGranted)
+}
+
+func (s *service) Reject(ctx context.Context, id models.EmergencyID, userID models.UserID) error {
+	access, err := s.getAsGrantor(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+
+	if access.Status == models.EmergencyStatusInvited {
+		return ErrInvalidStatus
+	}
+
+	return s.repo.SetStatus(ctx, id, models.EmergencyStatusInvited)
+}
+
+func (s *service) Revoke(ctx context.Context, id models.EmergencyID, userID models.UserID) error {
+	if _, err := s.get(ctx, id, userID); err != nil {
+		return err
+	}
+
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *service) GetVault(
+	ctx context.Context,
+	id models.EmergencyID,
+	userID models.UserID,
+	limit, offset uint64,
+) (*secrets.Page[models.Secret], error) {
+	access, err := s.getGranted(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.secrets.GetPage(ctx, access.Grantor.ID, limit, offset)
+}
+
+func (s *service) GetSecret(
+	ctx context.Context,
+	id models.EmergencyID,
+	userID models.UserID,
+	passphrase string,
+	secretID models.SecretID,
+) (*models.Secret, error) {
+	access, err := s.getGranted(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	grantee, err := s.repo.GetUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkPassphrase(grantee, passphrase); err != nil {
+		return nil, err
+	}
+
+	private, err := s.enc.Decrypt([]byte(passphrase), grantee.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := sealbox.Open(private, access.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := s.secrets.GetEscrowed(ctx, secretID, access.Grantor.ID, key)
+	if err != nil {
+		// Secrets shared with the grantor and secrets of organizations are not in the personal vault.
+		if errors.Is(err, secrets.ErrAnotherOwner) || errors.Is(err, secrets.ErrNotEscrowed) {
+			return nil, ErrSecretNotInVault
+		}
+
+		return nil, err
+	}
+
+	return secret, nil
+}
+
+func (s *service) GrantExpired(ctx context.Context) (int64, error) {
+	return s.repo.GrantExpired(ctx)
+}
+
+// get returns an access of the user, the accesses of others are not found.
+func (s *service) get(
+	ctx context.Context,
+	id models.EmergencyID,
+	userID models.UserID,
+) (*models.EmergencyAccess, error) {
+	access, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if access.Grantor.ID != userID && access.Grantee.ID != userID {
+		return nil, ErrAccessNotFound
+	}
+
+	return access, nil
+}
+
+func (s *service) getAsGrantor(
+	ctx context.Context,
+	id models.EmergencyID,
+	userID models.UserID,
+) (*models.EmergencyAccess, error) {
+	access, err := s.get(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if access.Grantor.ID != userID {
+		return nil, ErrForbidden
+	}
+
+	return access, nil
+}
+
+func (s *service) getAsGrantee(
+	ctx context.Context,
+	id models.EmergencyID,
+	userID models.UserID,
+) (*models.EmergencyAccess, error) {
+	access, err := s.get(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if access.Grantee.ID != userID {
+		return nil, ErrForbidden
+	}
+
+	return access, nil
+}
+
+func (s *service) getGranted(
+	ctx context.Context,
+	id models.EmergencyID,
+	userID models.UserID,
+) (*models.EmergencyAccess, error) {
+	access, err := s.getAsGrantee(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if access.Status != models.EmergencyStatusGranted {
+		return nil, ErrInvalidStatus
+	}
+
+	return access, nil
+}
+
+func (s *service) checkPassphrase(user *models.User, passphrase string) error {
+	ok, err := s.hasher.Compare(user.PassphraseHash, passphrase)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return ErrInvalidPassphrase
+	}
+
+	return nil
+}
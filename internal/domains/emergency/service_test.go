@@ -0,0 +1,435 @@
+package emergency_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/novoseltcev/passkeeper/internal/domains/emergency"
+	"github.com/novoseltcev/passkeeper/internal/domains/emergency/mocks"
+	"github.com/novoseltcev/passkeeper/internal/domains/secrets"
+	"github.com/novoseltcev/passkeeper/internal/models"
+	"github.com/novoseltcev/passkeeper/pkg/aes"
+	"github.com/novoseltcev/passkeeper/pkg/sealbox"
+	"github.com/novoseltcev/passkeeper/pkg/testutils"
+)
+
+const (
+	testID         = models.EmergencyID("emergency-id")
+	testGrantorID  = models.UserID("grantor-id")
+	testGranteeID  = models.UserID("grantee-id")
+	testOtherID    = models.UserID("other-id")
+	testSecretID   = models.SecretID("secret-id")
+	testLogin      = "grantee"
+	testPassphrase = "test-passphrase"
+	testHash       = "hash"
+)
+
+// newUser returns a user with the key pair and the private key.
+func newUser(t *testing.T, id models.UserID) (*models.User, []byte) {
+	t.Helper()
+
+	public, private, err := sealbox.GenerateKey()
+	require.NoError(t, err)
+
+	encPrivate, err := aes.New(aes.AES256BitKeyLength).Encrypt([]byte(testPassphrase), private)
+	require.NoError(t, err)
+
+	return &models.User{ID: id, PassphraseHash: testHash, PublicKey: public, PrivateKey: encPrivate}, private
+}
+
+func newAccess(status models.EmergencyStatus) *models.EmergencyAccess {
+	return &models.EmergencyAccess{
+		ID:       testID,
+		Grantor:  &models.User{ID: testGrantorID},
+		Grantee:  &models.User{ID: testGranteeID},
+		Status:   status,
+		WaitDays: 7,
+	}
+}
+
+func TestService_Invite_Success(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	secretService := mocks.NewMockSecrets(ctrl)
+	hasher := mocks.NewMockHasher(ctrl)
+	service := emergency.NewService(repo, secretService, hasher, nil)
+
+	grantor := &models.User{ID: testGrantorID, PassphraseHash: testHash}
+	grantee, private := newUser(t, testGranteeID)
+	escrowKey := []byte("escrow-key")
+
+	repo.EXPECT().GetUser(gomock.Any(), testGrantorID).Return(grantor, nil)
+	hasher.EXPECT().Compare(testHash, testPassphrase).Return(true, nil)
+	repo.EXPECT().GetUserByLogin(gomock.Any(), testLogin).Return(grantee, nil)
+	secretService.EXPECT().Escrow(gomock.Any(), testGrantorID, testPassphrase).Return(escrowKey, nil)
+	repo.EXPECT().
+		Save(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, access *models.EmergencyAccess) (models.EmergencyID, error) {
+			assert.Equal(t, grantor, access.Grantor)
+			assert.Equal(t, grantee, access.Grantee)
+			assert.Equal(t, models.EmergencyStatusInvited, access.Status)
+			assert.Equal(t, 7, access.WaitDays)
+
+			key, err := sealbox.Open(private, access.Key)
+			require.NoError(t, err)
+			assert.Equal(t, escrowKey, key)
+			assert.NotContains(t, string(key), testPassphrase)
+
+			return testID, nil
+		})
+
+	id, err := service.Invite(context.Background(), testGrantorID, testPassphrase, testLogin, 7)
+	require.NoError(t, err)
+	assert.Equal(t, testID, id)
+}
+
+func TestService_Invite_Fails(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	grantor := &models.User{ID: testGrantorID, PassphraseHash: testHash}
+
+	t.Run("wait period", func(t *testing.T) {
+		t.Parallel()
+
+		service := emergency.NewService(nil, nil, nil, nil)
+
+		for _, days := range []int{0, emergency.MaxWaitDays + 1} {
+			_, err := service.Invite(context.Background(), testGrantorID, testPassphrase, testLogin, days)
+			assert.ErrorIs(t, err, emergency.ErrInvalidWaitPeriod)
+		}
+	})
+
+	t.Run("invalid passphrase", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mocks.NewMockRepository(ctrl)
+		hasher := mocks.NewMockHasher(ctrl)
+		service := emergency.NewService(repo, nil, hasher, nil)
+
+		repo.EXPECT().GetUser(gomock.Any(), testGrantorID).Return(grantor, nil)
+		hasher.EXPECT().Compare(testHash, testPassphrase).Return(false, nil)
+
+		_, err := service.Invite(context.Background(), testGrantorID, testPassphrase, testLogin, 1)
+		assert.ErrorIs(t, err, emergency.ErrInvalidPassphrase)
+	})
+
+	tests := []struct {
+		name    string
+		grantee *models.User
+		err     error
+	}{
+		{name: "self", grantee: grantor, err: emergency.ErrInvalidContact},
+		{name: "no public key", grantee: &models.User{ID: testGranteeID}, err: emergency.ErrNoPublicKey},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := mocks.NewMockRepository(ctrl)
+			hasher := mocks.NewMockHasher(ctrl)
+			service := emergency.NewService(repo, nil, hasher, nil)
+
+			repo.EXPECT().GetUser(gomock.Any(), testGrantorID).Return(grantor, nil)
+			hasher.EXPECT().Compare(testHash, testPassphrase).Return(true, nil)
+			repo.EXPECT().GetUserByLogin(gomock.Any(), testLogin).Return(tt.grantee, nil)
+
+			_, err := service.Invite(context.Background(), testGrantorID, testPassphrase, testLogin, 1)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestService_Transitions(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	type action func(s emergency.Service, id models.EmergencyID, userID models.UserID) error
+
+	request := func(s emergency.Service, id models.EmergencyID, userID models.UserID) error {
+		return s.Request(context.Background(), id, userID)
+	}
+	approve := func(s emergency.Service, id models.EmergencyID, userID models.UserID) error {
+		return s.Approve(context.Background(), id, userID)
+	}
+	reject := func(s emergency.Service, id models.EmergencyID, userID models.UserID) error {
+		return s.Reject(context.Background(), id, userID)
+	}
+
+	tests := []struct {
+		name   string
+		action action
+		userID models.UserID
+		from   models.EmergencyStatus
+		to     models.EmergencyStatus
+		err    error
+	}{
+		{
+			name:   "request",
+			action: request,
+			userID: testGranteeID,
+			from:   models.EmergencyStatusInvited,
+			to:     models.EmergencyStatusRequested,
+		},
+		{
+			name:   "request twice",
+			action: request,
+			userID: testGranteeID,
+			from:   models.EmergencyStatusRequested,
+			err:    emergency.ErrInvalidStatus,
+		},
+		{
+			name:   "request by grantor",
+			action: request,
+			userID: testGrantorID,
+			from:   models.EmergencyStatusInvited,
+			err:    emergency.ErrForbidden,
+		},
+		{
+			name:   "approve",
+			action: approve,
+			userID: testGrantorID,
+			from:   models.EmergencyStatusRequested,
+			to:     models.EmergencyStatusGranted,
+		},
+		{
+			name:   "approve not requested",
+			action: approve,
+			userID: testGrantorID,
+			from:   models.EmergencyStatusInvited,
+			err:    emergency.ErrInvalidStatus,
+		},
+		{
+			name:   "approve by grantee",
+			action: approve,
+			userID: testGranteeID,
+			from:   models.EmergencyStatusRequested,
+			err:    emergency.ErrForbidden,
+		},
+		{
+			name:   "reject request",
+			action: reject,
+			userID: testGrantorID,
+			from:   models.EmergencyStatusRequested,
+			to:     models.EmergencyStatusInvited,
+		},
+		{
+			name:   "reject granted",
+			action: reject,
+			userID: testGrantorID,
+			from:   models.EmergencyStatusGranted,
+			to:     models.EmergencyStatusInvited,
+		},
+		{
+			name:   "reject idle",
+			action: reject,
+			userID: testGrantorID,
+			from:   models.EmergencyStatusInvited,
+			err:    emergency.ErrInvalidStatus,
+		},
+		{
+			name:   "other user",
+			action: reject,
+			userID: testOtherID,
+			from:   models.EmergencyStatusRequested,
+			err:    emergency.ErrAccessNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := mocks.NewMockRepository(ctrl)
+			service := emergency.NewService(repo, nil, nil, nil)
+
+			repo.EXPECT().Get(gomock.Any(), testID).Return(newAccess(tt.from), nil)
+
+			if tt.err == nil {
+				repo.EXPECT().SetStatus(gomock.Any(), testID, tt.to).Return(nil)
+			}
+
+			err := tt.action(service, testID, tt.userID)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestService_Revoke(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	for _, userID := range []models.UserID{testGrantorID, testGranteeID} {
+		t.Run(string(userID), func(t *testing.T) {
+			t.Parallel()
+
+			repo := mocks.NewMockRepository(ctrl)
+			service := emergency.NewService(repo, nil, nil, nil)
+
+			repo.EXPECT().Get(gomock.Any(), testID).Return(newAccess(models.EmergencyStatusGranted), nil)
+			repo.EXPECT().Delete(gomock.Any(), testID).Return(nil)
+
+			require.NoError(t, service.Revoke(context.Background(), testID, userID))
+		})
+	}
+
+	t.Run("other user", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mocks.NewMockRepository(ctrl)
+		service := emergency.NewService(repo, nil, nil, nil)
+
+		repo.EXPECT().Get(gomock.Any(), testID).Return(newAccess(models.EmergencyStatusGranted), nil)
+
+		assert.ErrorIs(t, service.Revoke(context.Background(), testID, testOtherID), emergency.ErrAccessNotFound)
+	})
+}
+
+func TestService_GetVault(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	t.Run("granted", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mocks.NewMockRepository(ctrl)
+		secretService := mocks.NewMockSecrets(ctrl)
+		service := emergency.NewService(repo, secretService, nil, nil)
+
+		page := &secrets.Page[models.Secret]{Items: []models.Secret{{ID: testSecretID}}, Total: 1}
+
+		repo.EXPECT().Get(gomock.Any(), testID).Return(newAccess(models.EmergencyStatusGranted), nil)
+		secretService.EXPECT().GetPage(gomock.Any(), testGrantorID, uint64(10), uint64(0)).Return(page, nil)
+
+		got, err := service.GetVault(context.Background(), testID, testGranteeID, 10, 0)
+		require.NoError(t, err)
+		assert.Equal(t, page, got)
+	})
+
+	t.Run("requested", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mocks.NewMockRepository(ctrl)
+		service := emergency.NewService(repo, nil, nil, nil)
+
+		repo.EXPECT().Get(gomock.Any(), testID).Return(newAccess(models.EmergencyStatusRequested), nil)
+
+		_, err := service.GetVault(context.Background(), testID, testGranteeID, 10, 0)
+		assert.ErrorIs(t, err, emergency.ErrInvalidStatus)
+	})
+}
+
+func TestService_GetSecret(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	escrowKey := []byte("escrow-key")
+
+	grantee, _ := newUser(t, testGranteeID)
+	access := newAccess(models.EmergencyStatusGranted)
+
+	var err error
+	access.Key, err = sealbox.Seal(grantee.PublicKey, escrowKey)
+	require.NoError(t, err)
+
+	personal := &models.Secret{ID: testSecretID, Owner: &models.User{ID: testGrantorID}}
+
+	tests := []struct {
+		name      string
+		secret    *models.Secret
+		secretErr error
+		err       error
+	}{
+		{
+			name:   "personal",
+			secret: personal,
+		},
+		{
+			name:      "not in the vault",
+			secretErr: secrets.ErrAnotherOwner,
+			err:       emergency.ErrSecretNotInVault,
+		},
+		{
+			name:      "not escrowed",
+			secretErr: secrets.ErrNotEscrowed,
+			err:       emergency.ErrSecretNotInVault,
+		},
+		{
+			name:      "not found",
+			secretErr: secrets.ErrSecretNotFound,
+			err:       secrets.ErrSecretNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := mocks.NewMockRepository(ctrl)
+			secretService := mocks.NewMockSecrets(ctrl)
+			hasher := mocks.NewMockHasher(ctrl)
+			service := emergency.NewService(repo, secretService, hasher, aes.New(aes.AES256BitKeyLength))
+
+			repo.EXPECT().Get(gomock.Any(), testID).Return(access, nil)
+			repo.EXPECT().GetUser(gomock.Any(), testGranteeID).Return(grantee, nil)
+			hasher.EXPECT().Compare(testHash, testPassphrase).Return(true, nil)
+			secretService.EXPECT().
+				GetEscrowed(gomock.Any(), testSecretID, testGrantorID, escrowKey).
+				Return(tt.secret, tt.secretErr)
+
+			secret, err := service.GetSecret(context.Background(), testID, testGranteeID, testPassphrase, testSecretID)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.secret, secret)
+			}
+		})
+	}
+
+	t.Run("invalid passphrase", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mocks.NewMockRepository(ctrl)
+		hasher := mocks.NewMockHasher(ctrl)
+		service := emergency.NewService(repo, nil, hasher, nil)
+
+		repo.EXPECT().Get(gomock.Any(), testID).Return(access, nil)
+		repo.EXPECT().GetUser(gomock.Any(), testGranteeID).Return(grantee, nil)
+		hasher.EXPECT().Compare(testHash, testPassphrase).Return(false, nil)
+
+		_, err := service.GetSecret(context.Background(), testID, testGranteeID, testPassphrase, testSecretID)
+		assert.ErrorIs(t, err, emergency.ErrInvalidPassphrase)
+	})
+}
+
+func TestService_GrantExpired(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := emergency.NewService(repo, nil, nil, nil)
+
+	repo.EXPECT().GrantExpired(gomock.Any()).Return(int64(0), testutils.Err)
+
+	_, err := service.GrantExpired(context.Background())
+	assert.ErrorIs(t, err, testutils.Err)
+}
@@ -0,0 +1,15 @@
+package orgs
+
+import "errors"
+
+var (
+	ErrNotMember          = errors.New("not a member of the organization")
+	ErrForbidden          = errors.New("not allowed for the role")
+	ErrInvalidPassphrase  = errors.New("invalid passphrase")
+	ErrUserNotFound       = errors.New("user not found")
+	ErrNoPublicKey        = errors.New("user has no public key")
+	ErrAlreadyMember      = errors.New("already a member")
+	ErrInvitationNotFound = errors.New("invitation not found")
+	ErrInvalidRole        = errors.New("invalid role")
+	ErrMemberNotFound     = errors.New("member not found")
+)
@@ -0,0 +1,507 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository.go
+//
+// Generated by this command:
+//
+//	mockgen -destination=mocks/repository_mock.go -package=mocks -source=repository.go -typed
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/novoseltcev/passkeeper/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AcceptInvitation mocks base method.
+func (m *MockRepository) AcceptInvitation(ctx context.Context, invitation *models.Invitation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptInvitation", ctx, invitation)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AcceptInvitation indicates an expected call of AcceptInvitation.
+func (mr *MockRepositoryMockRecorder) AcceptInvitation(ctx, invitation any) *MockRepositoryAcceptInvitationCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptInvitation", reflect.TypeOf((*MockRepository)(nil).AcceptInvitation), ctx, invitation)
+	return &MockRepositoryAcceptInvitationCall{Call: call}
+}
+
+// MockRepositoryAcceptInvitationCall wrap *gomock.Call
+type MockRepositoryAcceptInvitationCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRepositoryAcceptInvitationCall) Return(arg0 error) *MockRepositoryAcceptInvitationCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRepositoryAcceptInvitationCall) Do(f func(context.Context, *models.Invitation) error) *MockRepositoryAcceptInvitationCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRepositoryAcceptInvitationCall) DoAndReturn(f func(context.Context, *models.Invitation) error) *MockRepositoryAcceptInvitationCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Create mocks base method.
+func (m *MockRepository) Create(ctx context.Context, org *models.Org, owner *models.Member) (models.OrgID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, org, owner)
+	ret0, _ := ret[0].(models.OrgID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRepositoryMockRecorder) Create(ctx, org, owner any) *MockRepositoryCreateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepository)(nil).Create), ctx, org, owner)
+	return &MockRepositoryCreateCall{Call: call}
+}
+
+// MockRepositoryCreateCall wrap *gomock.Call
+type MockRepositoryCreateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRepositoryCreateCall) Return(arg0 models.OrgID, arg1 error) *MockRepositoryCreateCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRepositoryCreateCall) Do(f func(context.Context, *models.Org, *models.Member) (models.OrgID, error)) *MockRepositoryCreateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRepositoryCreateCall) DoAndReturn(f func(context.Context, *models.Org, *models.Member) (models.OrgID, error)) *MockRepositoryCreateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteInvitation mocks base method.
+func (m *MockRepository) DeleteInvitation(ctx context.Context, id models.InvitationID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteInvitation", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteInvitation indicates an expected call of DeleteInvitation.
+func (mr *MockRepositoryMockRecorder) DeleteInvitation(ctx, id any) *MockRepositoryDeleteInvitationCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteInvitation", reflect.TypeOf((*MockRepository)(nil).DeleteInvitation), ctx, id)
+	return &MockRepositoryDeleteInvitationCall{Call: call}
+}
+
+// MockRepositoryDeleteInvitationCall wrap *gomock.Call
+type MockRepositoryDeleteInvitationCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRepositoryDeleteInvitationCall) Return(arg0 error) *MockRepositoryDeleteInvitationCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRepositoryDeleteInvitationCall) Do(f func(context.Context, models.InvitationID) error) *MockRepositoryDeleteInvitationCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRepositoryDeleteInvitationCall) DoAndReturn(f func(context.Context, models.InvitationID) error) *MockRepositoryDeleteInvitationCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteMember mocks base method.
+func (m *MockRepository) DeleteMember(ctx context.Context, id models.OrgID, userID models.UserID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMember", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMember indicates an expected call of DeleteMember.
+func (mr *MockRepositoryMockRecorder) DeleteMember(ctx, id, userID any) *MockRepositoryDeleteMemberCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMember", reflect.TypeOf((*MockRepository)(nil).DeleteMember), ctx, id, userID)
+	return &MockRepositoryDeleteMemberCall{Call: call}
+}
+
+// MockRepositoryDeleteMemberCall wrap *gomock.Call
+type MockRepositoryDeleteMemberCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRepositoryDeleteMemberCall) Return(arg0 error) *MockRepositoryDeleteMemberCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRepositoryDeleteMemberCall) Do(f func(context.Context, models.OrgID, models.UserID) error) *MockRepositoryDeleteMemberCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRepositoryDeleteMemberCall) DoAndReturn(f func(context.Context, models.OrgID, models.UserID) error) *MockRepositoryDeleteMemberCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetInvitation mocks base method.
+func (m *MockRepository) GetInvitation(ctx context.Context, id models.InvitationID) (*models.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvitation", ctx, id)
+	ret0, _ := ret[0].(*models.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvitation indicates an expected call of GetInvitation.
+func (mr *MockRepositoryMockRecorder) GetInvitation(ctx, id any) *MockRepositoryGetInvitationCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvitation", reflect.TypeOf((*MockRepository)(nil).GetInvitation), ctx, id)
+	return &MockRepositoryGetInvitationCall{Call: call}
+}
+
+// MockRepositoryGetInvitationCall wrap *gomock.Call
+type MockRepositoryGetInvitationCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRepositoryGetInvitationCall) Return(arg0 *models.Invitation, arg1 error) *MockRepositoryGetInvitationCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRepositoryGetInvitationCall) Do(f func(context.Context, models.InvitationID) (*models.Invitation, error)) *MockRepositoryGetInvitationCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRepositoryGetInvitationCall) DoAndReturn(f func(context.Context, models.InvitationID) (*models.Invitation, error)) *MockRepositoryGetInvitationCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetInvitations mocks base method.
+func (m *MockRepository) GetInvitations(ctx context.Context, userID models.UserID) ([]models.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvitations", ctx, userID)
+	ret0, _ := ret[0].([]models.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvitations indicates an expected call of GetInvitations.
+func (mr *MockRepositoryMockRecorder) GetInvitations(ctx, userID any) *MockRepositoryGetInvitationsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvitations", reflect.TypeOf((*MockRepository)(nil).GetInvitations), ctx, userID)
+	return &MockRepositoryGetInvitationsCall{Call: call}
+}
+
+// MockRepositoryGetInvitationsCall wrap *gomock.Call
+type MockRepositoryGetInvitationsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRepositoryGetInvitationsCall) Return(arg0 []models.Invitation, arg1 error) *MockRepositoryGetInvitationsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRepositoryGetInvitationsCall) Do(f func(context.Context, models.UserID) ([]models.Invitation, error)) *MockRepositoryGetInvitationsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRepositoryGetInvitationsCall) DoAndReturn(f func(context.Context, models.UserID) ([]models.Invitation, error)) *MockRepositoryGetInvitationsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetMember mocks base method.
+func (m *MockRepository) GetMember(ctx context.Context, id models.OrgID, userID models.UserID) (*models.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMember", ctx, id, userID)
+	ret0, _ := ret[0].(*models.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMember indicates an expected call of GetMember.
+func (mr *MockRepositoryMockRecorder) GetMember(ctx, id, userID any) *MockRepositoryGetMemberCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMember", reflect.TypeOf((*MockRepository)(nil).GetMember), ctx, id, userID)
+	return &MockRepositoryGetMemberCall{Call: call}
+}
+
+// MockRepositoryGetMemberCall wrap *gomock.Call
+type MockRepositoryGetMemberCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRepositoryGetMemberCall) Return(arg0 *models.Member, arg1 error) *MockRepositoryGetMemberCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRepositoryGetMemberCall) Do(f func(context.Context, models.OrgID, models.UserID) (*models.Member, error)) *MockRepositoryGetMemberCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRepositoryGetMemberCall) DoAndReturn(f func(context.Context, models.OrgID, models.UserID) (*models.Member, error)) *MockRepositoryGetMemberCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetMembers mocks base method.
+func (m *MockRepository) GetMembers(ctx context.Context, id models.OrgID) ([]models.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembers", ctx, id)
+	ret0, _ := ret[0].([]models.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMembers indicates an expected call of GetMembers.
+func (mr *MockRepositoryMockRecorder) GetMembers(ctx, id any) *MockRepositoryGetMembersCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembers", reflect.TypeOf((*MockRepository)(nil).GetMembers), ctx, id)
+	return &MockRepositoryGetMembersCall{Call: call}
+}
+
+// MockRepositoryGetMembersCall wrap *gomock.Call
+type MockRepositoryGetMembersCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRepositoryGetMembersCall) Return(arg0 []models.Member, arg1 error) *MockRepositoryGetMembersCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRepositoryGetMembersCall) Do(f func(context.Context, models.OrgID) ([]models.Member, error)) *MockRepositoryGetMembersCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRepositoryGetMembersCall) DoAndReturn(f func(context.Context, models.OrgID) ([]models.Member, error)) *MockRepositoryGetMembersCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetMemberships mocks base method.
+func (m *MockRepository) GetMemberships(ctx context.Context, userID models.UserID) ([]models.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMemberships", ctx, userID)
+	ret0, _ := ret[0].([]models.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMemberships indicates an expected call of GetMemberships.
+func (mr *MockRepositoryMockRecorder) GetMemberships(ctx, userID any) *MockRepositoryGetMembershipsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMemberships", reflect.TypeOf((*MockRepository)(nil).GetMemberships), ctx, userID)
+	return &MockRepositoryGetMembershipsCall{Call: call}
+}
+
+// MockRepositoryGetMembershipsCall wrap *gomock.Call
+type MockRepositoryGetMembershipsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRepositoryGetMembershipsCall) Return(arg0 []models.Member, arg1 error) *MockRepositoryGetMembershipsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRepositoryGetMembershipsCall) Do(f func(context.Context, models.UserID) ([]models.Member, error)) *MockRepositoryGetMembershipsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRepositoryGetMembershipsCall) DoAndReturn(f func(context.Context, models.UserID) ([]models.Member, error)) *MockRepositoryGetMembershipsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetUser mocks base method.
+func (m *MockRepository) GetUser(ctx context.Context, id models.UserID) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", ctx, id)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockRepositoryMockRecorder) GetUser(ctx, id any) *MockRepositoryGetUserCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockRepository)(nil).GetUser), ctx, id)
+	return &MockRepositoryGetUserCall{Call: call}
+}
+
+// MockRepositoryGetUserCall wrap *gomock.Call
+type MockRepositoryGetUserCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRepositoryGetUserCall) Return(arg0 *models.User, arg1 error) *MockRepositoryGetUserCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRepositoryGetUserCall) Do(f func(context.Context, models.UserID) (*models.User, error)) *MockRepositoryGetUserCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRepositoryGetUserCall) DoAndReturn(f func(context.Context, models.UserID) (*models.User, error)) *MockRepositoryGetUserCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetUserByLogin mocks base method.
+func (m *MockRepository) GetUserByLogin(ctx context.Context, login string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByLogin", ctx, login)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByLogin indicates an expected call of GetUserByLogin.
+func (mr *MockRepositoryMockRecorder) GetUserByLogin(ctx, login any) *MockRepositoryGetUserByLoginCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByLogin", reflect.TypeOf((*MockRepository)(nil).GetUserByLogin), ctx, login)
+	return &MockRepositoryGetUserByLoginCall{Call: call}
+}
+
+// MockRepositoryGetUserByLoginCall wrap *gomock.Call
+type MockRepositoryGetUserByLoginCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRepositoryGetUserByLoginCall) Return(arg0 *models.User, arg1 error) *MockRepositoryGetUserByLoginCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRepositoryGetUserByLoginCall) Do(f func(context.Context, string) (*models.User, error)) *MockRepositoryGetUserByLoginCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRepositoryGetUserByLoginCall) DoAndReturn(f func(context.Context, string) (*models.User, error)) *MockRepositoryGetUserByLoginCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// SaveInvitation mocks base method.
+func (m *MockRepository) SaveInvitation(ctx context.Context, invitation *models.Invitation) (models.InvitationID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveInvitation", ctx, invitation)
+	ret0, _ := ret[0].(models.InvitationID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveInvitation indicates an expected call of SaveInvitation.
+func (mr *MockRepositoryMockRecorder) SaveInvitation(ctx, invitation any) *MockRepositorySaveInvitationCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveInvitation", reflect.TypeOf((*MockRepository)(nil).SaveInvitation), ctx, invitation)
+	return &MockRepositorySaveInvitationCall{Call: call}
+}
+
+// MockRepositorySaveInvitationCall wrap *gomock.Call
+type MockRepositorySaveInvitationCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRepositorySaveInvitationCall) Return(arg0 models.InvitationID, arg1 error) *MockRepositorySaveInvitationCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRepositorySaveInvitationCall) Do(f func(context.Context, *models.Invitation) (models.InvitationID, error)) *MockRepositorySaveInvitationCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRepositorySaveInvitationCall) DoAndReturn(f func(context.Context, *models.Invitation) (models.InvitationID, error)) *MockRepositorySaveInvitationCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
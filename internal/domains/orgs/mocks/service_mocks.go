@@ -0,0 +1,477 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mocks/service_mocks.go -package=mocks -source=service.go -typed
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/novoseltcev/passkeeper/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// Accept mocks base method.
+func (m *MockService) Accept(ctx context.Context, id models.InvitationID, userID models.UserID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Accept", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Accept indicates an expected call of Accept.
+func (mr *MockServiceMockRecorder) Accept(ctx, id, userID any) *MockServiceAcceptCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Accept", reflect.TypeOf((*MockService)(nil).Accept), ctx, id, userID)
+	return &MockServiceAcceptCall{Call: call}
+}
+
+// MockServiceAcceptCall wrap *gomock.Call
+type MockServiceAcceptCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockServiceAcceptCall) Return(arg0 error) *MockServiceAcceptCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockServiceAcceptCall) Do(f func(context.Context, models.InvitationID, models.UserID) error) *MockServiceAcceptCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockServiceAcceptCall) DoAndReturn(f func(context.Context, models.InvitationID, models.UserID) error) *MockServiceAcceptCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Create mocks base method.
+func (m *MockService) Create(ctx context.Context, userID models.UserID, name string) (models.OrgID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, userID, name)
+	ret0, _ := ret[0].(models.OrgID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockServiceMockRecorder) Create(ctx, userID, name any) *MockServiceCreateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockService)(nil).Create), ctx, userID, name)
+	return &MockServiceCreateCall{Call: call}
+}
+
+// MockServiceCreateCall wrap *gomock.Call
+type MockServiceCreateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockServiceCreateCall) Return(arg0 models.OrgID, arg1 error) *MockServiceCreateCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockServiceCreateCall) Do(f func(context.Context, models.UserID, string) (models.OrgID, error)) *MockServiceCreateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockServiceCreateCall) DoAndReturn(f func(context.Context, models.UserID, string) (models.OrgID, error)) *MockServiceCreateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Decline mocks base method.
+func (m *MockService) Decline(ctx context.Context, id models.InvitationID, userID models.UserID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Decline", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Decline indicates an expected call of Decline.
+func (mr *MockServiceMockRecorder) Decline(ctx, id, userID any) *MockServiceDeclineCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Decline", reflect.TypeOf((*MockService)(nil).Decline), ctx, id, userID)
+	return &MockServiceDeclineCall{Call: call}
+}
+
+// MockServiceDeclineCall wrap *gomock.Call
+type MockServiceDeclineCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockServiceDeclineCall) Return(arg0 error) *MockServiceDeclineCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockServiceDeclineCall) Do(f func(context.Context, models.InvitationID, models.UserID) error) *MockServiceDeclineCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockServiceDeclineCall) DoAndReturn(f func(context.Context, models.InvitationID, models.UserID) error) *MockServiceDeclineCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetAll mocks base method.
+func (m *MockService) GetAll(ctx context.Context, userID models.UserID) ([]models.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, userID)
+	ret0, _ := ret[0].([]models.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockServiceMockRecorder) GetAll(ctx, userID any) *MockServiceGetAllCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockService)(nil).GetAll), ctx, userID)
+	return &MockServiceGetAllCall{Call: call}
+}
+
+// MockServiceGetAllCall wrap *gomock.Call
+type MockServiceGetAllCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockServiceGetAllCall) Return(arg0 []models.Member, arg1 error) *MockServiceGetAllCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockServiceGetAllCall) Do(f func(context.Context, models.UserID) ([]models.Member, error)) *MockServiceGetAllCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockServiceGetAllCall) DoAndReturn(f func(context.Context, models.UserID) ([]models.Member, error)) *MockServiceGetAllCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetInvitations mocks base method.
+func (m *MockService) GetInvitations(ctx context.Context, userID models.UserID) ([]models.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvitations", ctx, userID)
+	ret0, _ := ret[0].([]models.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvitations indicates an expected call of GetInvitations.
+func (mr *MockServiceMockRecorder) GetInvitations(ctx, userID any) *MockServiceGetInvitationsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvitations", reflect.TypeOf((*MockService)(nil).GetInvitations), ctx, userID)
+	return &MockServiceGetInvitationsCall{Call: call}
+}
+
+// MockServiceGetInvitationsCall wrap *gomock.Call
+type MockServiceGetInvitationsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockServiceGetInvitationsCall) Return(arg0 []models.Invitation, arg1 error) *MockServiceGetInvitationsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockServiceGetInvitationsCall) Do(f func(context.Context, models.UserID) ([]models.Invitation, error)) *MockServiceGetInvitationsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockServiceGetInvitationsCall) DoAndReturn(f func(context.Context, models.UserID) ([]models.Invitation, error)) *MockServiceGetInvitationsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetMembers mocks base method.
+func (m *MockService) GetMembers(ctx context.Context, id models.OrgID, userID models.UserID) ([]models.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembers", ctx, id, userID)
+	ret0, _ := ret[0].([]models.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMembers indicates an expected call of GetMembers.
+func (mr *MockServiceMockRecorder) GetMembers(ctx, id, userID any) *MockServiceGetMembersCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembers", reflect.TypeOf((*MockService)(nil).GetMembers), ctx, id, userID)
+	return &MockServiceGetMembersCall{Call: call}
+}
+
+// MockServiceGetMembersCall wrap *gomock.Call
+type MockServiceGetMembersCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockServiceGetMembersCall) Return(arg0 []models.Member, arg1 error) *MockServiceGetMembersCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockServiceGetMembersCall) Do(f func(context.Context, models.OrgID, models.UserID) ([]models.Member, error)) *MockServiceGetMembersCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockServiceGetMembersCall) DoAndReturn(f func(context.Context, models.OrgID, models.UserID) ([]models.Member, error)) *MockServiceGetMembersCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Invite mocks base method.
+func (m *MockService) Invite(ctx context.Context, id models.OrgID, userID models.UserID, passphrase, login string, role models.OrgRole) (models.InvitationID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Invite", ctx, id, userID, passphrase, login, role)
+	ret0, _ := ret[0].(models.InvitationID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Invite indicates an expected call of Invite.
+func (mr *MockServiceMockRecorder) Invite(ctx, id, userID, passphrase, login, role any) *MockServiceInviteCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invite", reflect.TypeOf((*MockService)(nil).Invite), ctx, id, userID, passphrase, login, role)
+	return &MockServiceInviteCall{Call: call}
+}
+
+// MockServiceInviteCall wrap *gomock.Call
+type MockServiceInviteCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockServiceInviteCall) Return(arg0 models.InvitationID, arg1 error) *MockServiceInviteCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockServiceInviteCall) Do(f func(context.Context, models.OrgID, models.UserID, string, string, models.OrgRole) (models.InvitationID, error)) *MockServiceInviteCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockServiceInviteCall) DoAndReturn(f func(context.Context, models.OrgID, models.UserID, string, string, models.OrgRole) (models.InvitationID, error)) *MockServiceInviteCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// RemoveMember mocks base method.
+func (m *MockService) RemoveMember(ctx context.Context, id models.OrgID, userID, memberID models.UserID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMember", ctx, id, userID, memberID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveMember indicates an expected call of RemoveMember.
+func (mr *MockServiceMockRecorder) RemoveMember(ctx, id, userID, memberID any) *MockServiceRemoveMemberCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*MockService)(nil).RemoveMember), ctx, id, userID, memberID)
+	return &MockServiceRemoveMemberCall{Call: call}
+}
+
+// MockServiceRemoveMemberCall wrap *gomock.Call
+type MockServiceRemoveMemberCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockServiceRemoveMemberCall) Return(arg0 error) *MockServiceRemoveMemberCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockServiceRemoveMemberCall) Do(f func(context.Context, models.OrgID, models.UserID, models.UserID) error) *MockServiceRemoveMemberCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockServiceRemoveMemberCall) DoAndReturn(f func(context.Context, models.OrgID, models.UserID, models.UserID) error) *MockServiceRemoveMemberCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockHasher is a mock of Hasher interface.
+type MockHasher struct {
+	ctrl     *gomock.Controller
+	recorder *MockHasherMockRecorder
+	isgomock struct{}
+}
+
+// MockHasherMockRecorder is the mock recorder for MockHasher.
+type MockHasherMockRecorder struct {
+	mock *MockHasher
+}
+
+// NewMockHasher creates a new mock instance.
+func NewMockHasher(ctrl *gomock.Controller) *MockHasher {
+	mock := &MockHasher{ctrl: ctrl}
+	mock.recorder = &MockHasherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHasher) EXPECT() *MockHasherMockRecorder {
+	return m.recorder
+}
+
+// Compare mocks base method.
+func (m *MockHasher) Compare(hash, v string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Compare", hash, v)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Compare indicates an expected call of Compare.
+func (mr *MockHasherMockRecorder) Compare(hash, v any) *MockHasherCompareCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Compare", reflect.TypeOf((*MockHasher)(nil).Compare), hash, v)
+	return &MockHasherCompareCall{Call: call}
+}
+
+// MockHasherCompareCall wrap *gomock.Call
+type MockHasherCompareCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockHasherCompareCall) Return(arg0 bool, arg1 error) *MockHasherCompareCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockHasherCompareCall) Do(f func(string, string) (bool, error)) *MockHasherCompareCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockHasherCompareCall) DoAndReturn(f func(string, string) (bool, error)) *MockHasherCompareCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockEncryptor is a mock of Encryptor interface.
+type MockEncryptor struct {
+	ctrl     *gomock.Controller
+	recorder *MockEncryptorMockRecorder
+	isgomock struct{}
+}
+
+// MockEncryptorMockRecorder is the mock recorder for MockEncryptor.
+type MockEncryptorMockRecorder struct {
+	mock *MockEncryptor
+}
+
+// NewMockEncryptor creates a new mock instance.
+func NewMockEncryptor(ctrl *gomock.Controller) *MockEncryptor {
+	mock := &MockEncryptor{ctrl: ctrl}
+	mock.recorder = &MockEncryptorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEncryptor) EXPECT() *MockEncryptorMockRecorder {
+	return m.recorder
+}
+
+// Decrypt mocks base method.
+func (m *MockEncryptor) Decrypt(passphrase, v []byte) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Decrypt", passphrase, v)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Decrypt indicates an expected call of Decrypt.
+func (mr *MockEncryptorMockRecorder) Decrypt(passphrase, v any) *MockEncryptorDecryptCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Decrypt", reflect.TypeOf((*MockEncryptor)(nil).Decrypt), passphrase, v)
+	return &MockEncryptorDecryptCall{Call: call}
+}
+
+// MockEncryptorDecryptCall wrap *gomock.Call
+type MockEncryptorDecryptCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockEncryptorDecryptCall) Return(arg0 []byte, arg1 error) *MockEncryptorDecryptCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockEncryptorDecryptCall) Do(f func([]byte, []byte) ([]byte, error)) *MockEncryptorDecryptCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockEncryptorDecryptCall) DoAndReturn(f func([]byte, []byte) ([]byte, error)) *MockEncryptorDecryptCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
@@ -0,0 +1,31 @@
+package orgs
+
+import (
+	"context"
+
+	"github.com/novoseltcev/passkeeper/internal/models"
+)
+
+//go:generate mockgen -destination=mocks/repository_mock.go -package=mocks -source=repository.go -typed
+
+type Repository interface {
+	GetUser(ctx context.Context, id models.UserID) (*models.User, error)
+	GetUserByLogin(ctx context.Context, login string) (*models.User, error)
+	// Create creates the organization with its first member.
+	Create(ctx context.Context, org *models.Org, owner *models.Member) (models.OrgID, error)
+	// GetMember returns the membership of the user in the organization with the user's keys.
+	GetMember(ctx context.Context, id models.OrgID, userID models.UserID) (*models.Member, error)
+	// GetMembers returns the members of the organization with their logins.
+	GetMembers(ctx context.Context, id models.OrgID) ([]models.Member, error)
+	// GetMemberships returns the memberships of the user with the organizations.
+	GetMemberships(ctx context.Context, userID models.UserID) ([]models.Member, error)
+	DeleteMember(ctx context.Context, id models.OrgID, userID models.UserID) error
+	// SaveInvitation creates the invitation or replaces the role and the key of the existing one.
+	SaveInvitation(ctx context.Context, invitation *models.Invitation) (models.InvitationID, error)
+	GetInvitation(ctx context.Context, id models.InvitationID) (*models.Invitation, error)
+	// GetInvitations returns the invitations of the user with the organizations and the inviters.
+	GetInvitations(ctx context.Context, userID models.UserID) ([]models.Invitation, error)
+	// AcceptInvitation adds the invitee to the organization and deletes the invitation at once.
+	AcceptInvitation(ctx context.Context, invitation *models.Invitation) error
+	DeleteInvitation(ctx context.Context, id models.InvitationID) error
+}
@@ -0,0 +1,299 @@
+// Package orgs provides a domain for organizations, which share a vault of secrets between their members.
+package orgs
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+
+	"github.com/novoseltcev/passkeeper/internal/models"
+	"github.com/novoseltcev/passkeeper/pkg/sealbox"
+)
+
+//go:generate mockgen -destination=./mocks/service_mocks.go -package=mocks -source=service.go -typed
+
+// Service is a domain service for organizations.
+type Service interface {
+	// Create creates an organization with the user as its owner.
+	//
+	// The organization key is generated and sealed to the public key of the owner.
+	// Domain errors:
+	// - ErrNoPublicKey if the user has not generated the key pair yet
+	Create(ctx context.Context, userID models.UserID, name string) (models.OrgID, error)
+
+	// GetAll returns the memberships of the user.
+	GetAll(ctx context.Context, userID models.UserID) ([]models.Member, error)
+
+	// GetMembers returns the members of the organization.
+	//
+	// Domain errors:
+	// - ErrNotMember
+	GetMembers(ctx context.Context, id models.OrgID, userID models.UserID) ([]models.Member, error)
+
+	// Invite invites the user with the login to the organization.
+	//
+	// The organization key is sealed to the public key of the invitee, so the passphrase of the inviter is required.
+	// Owners and admins invite, only owners invite owners. The existing invitation is replaced.
+	// Domain errors:
+	// - ErrNotMember
+	// - ErrForbidden
+	// - ErrInvalidRole
+	// - ErrInvalidPassphrase
+	// - ErrUserNotFound
+	// - ErrNoPublicKey if the invitee has not generated the key pair yet
+	// - ErrAlreadyMember
+	Invite(
+		ctx context.Context,
+		id models.OrgID,
+		userID models.UserID,
+		passphrase string,
+		login string,
+		role models.OrgRole,
+	) (models.InvitationID, error)
+
+	// GetInvitations returns the invitations of the user.
+	GetInvitations(ctx context.Context, userID models.UserID) ([]models.Invitation, error)
+
+	// Accept makes the user a member of the organization the user is invited to.
+	//
+	// Domain errors:
+	// - ErrInvitationNotFound
+	Accept(ctx context.Context, id models.InvitationID, userID models.UserID) error
+
+	// Decline deletes the invitation of the user.
+	//
+	// Domain errors:
+	// - ErrInvitationNotFound
+	Decline(ctx context.Context, id models.InvitationID, userID models.UserID) error
+
+	// RemoveMember removes the member from the organization.
+	//
+	// Members leave by removing themselves, except owners.
+	// Owners and admins remove other members, only owners remove owners.
+	// The organization key is not rotated, so the removed member keeps the access to the secrets already read.
+	// Domain errors:
+	// - ErrNotMember
+	// - ErrMemberNotFound
+	// - ErrForbidden
+	RemoveMember(ctx context.Context, id models.OrgID, userID models.UserID, memberID models.UserID) error
+}
+
+type Hasher interface {
+	Compare(hash, v string) (bool, error)
+}
+
+type Encryptor interface {
+	Decrypt(passphrase, v []byte) ([]byte, error)
+}
+
+type service struct {
+	repo   Repository
+	hasher Hasher
+	enc    Encryptor
+}
+
+var _ Service = (*service)(nil)
+
+func NewService(repo Repository, hasher Hasher, enc Encryptor) *service { // nolint: revive
+	return &service{repo: repo, hasher: hasher, enc: enc}
+}
+
+func (s *service) Create(ctx context.Context, userID models.UserID, name string) (models.OrgID, error) {
+	user, err := s.repo.GetUser(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+
+	if len(user.PublicKey) == 0 {
+		return "", ErrNoPublicKey
+	}
+
+	key := make([]byte, sealbox.KeySize)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+
+	sealed, err := sealbox.Seal(user.PublicKey, key)
+	if err != nil {
+		return "", err
+	}
+
+	org := &models.Org{Name: name}
+
+	return s.repo.Create(ctx, org, &models.Member{Org: org, User: user, Role: models.OrgRoleOwner, Key: sealed})
+}
+
+func (s *service) GetAll(ctx context.Context, userID models.UserID) ([]models.Member, error) {
+	return s.repo.GetMemberships(ctx, userID)
+}
+
+func (s *service) GetMembers(ctx context.Context, id models.OrgID, userID models.UserID) ([]models.Member, error) {
+	if _, err := s.repo.GetMember(ctx, id, userID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetMembers(ctx, id)
+}
+
+func (s *service) Invite(
+	ctx context.Context,
+	id models.OrgID,
+	userID models.UserID,
+	passphrase string,
+	login string,
+	role models.OrgRole,
+) (models.InvitationID, error) {
+	if !validRole(role) {
+		return "", ErrInvalidRole
+	}
+
+	inviter, err := s.repo.GetMember(ctx, id, userID)
+	if err != nil {
+		return "", err
+	}
+
+	if !inviter.Role.CanManage() || (role == models.OrgRoleOwner && inviter.Role != models.OrgRoleOwner) {
+		return "", ErrForbidden
+	}
+
+	key, err := s.unlock(inviter, passphrase)
+	if err != nil {
+		return "", err
+	}
+
+	invitee, err := s.repo.GetUserByLogin(ctx, login)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := s.repo.GetMember(ctx, id, invitee.ID); err == nil {
+		return "", ErrAlreadyMember
+	} else if !errors.Is(err, ErrNotMember) {
+		return "", err
+	}
+
+	if len(invitee.PublicKey) == 0 {
+		return "", ErrNoPublicKey
+	}
+
+	sealed, err := sealbox.Seal(invitee.PublicKey, key)
+	if err != nil {
+		return "", err
+	}
+
+	return s.repo.SaveInvitation(ctx, &models.Invitation{
+		Org:     inviter.Org,
+		Invitee: invitee,
+		Inviter: inviter.User,
+		Role:    role,
+		Key:     sealed,
+	})
+}
+
+func (s *service) GetInvitations(ctx context.Context, userID models.UserID) ([]models.Invitation, error) {
+	return s.repo.GetInvitations(ctx, userID)
+}
+
+func (s *service) Accept(ctx context.Context, id models.InvitationID, userID models.UserID) error {
+	invitation, err := s.getMyInvitation(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.AcceptInvitation(ctx, invitation)
+}
+
+func (s *service) Decline(ctx context.Context, id models.InvitationID, userID models.UserID) error {
+	if _, err := s.getMyInvitation(ctx, id, userID); err != nil {
+		return err
+	}
+
+	return s.repo.DeleteInvitation(ctx, id)
+}
+
+func (s *service) RemoveMember(
+	ctx context.Context,
+	id models.OrgID,
+	userID models.UserID,
+	memberID models.UserID,
+) error {
+	remover, err := s.repo.GetMember(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+
+	member := remover
+	if memberID != userID {
+		if member, err = s.repo.GetMember(ctx, id, memberID); err != nil {
+			if errors.Is(err, ErrNotMember) {
+				return ErrMemberNotFound
+			}
+
+			return err
+		}
+	}
+
+	if !canRemove(remover, member) {
+		return ErrForbidden
+	}
+
+	return s.repo.DeleteMember(ctx, id, memberID)
+}
+
+// getMyInvitation returns an invitation of the user, the invitations of others are not found.
+func (s *service) getMyInvitation(
+	ctx context.Context,
+	id models.InvitationID,
+	userID models.UserID,
+) (*models.Invitation, error) {
+	invitation, err := s.repo.GetInvitation(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if invitation.Invitee.ID != userID {
+		return nil, ErrInvitationNotFound
+	}
+
+	return invitation, nil
+}
+
+// unlock checks the passphrase of the member and returns the organization key.
+func (s *service) unlock(member *models.Member, passphrase string) ([]byte, error) {
+	ok, err := s.hasher.Compare(member.User.PassphraseHash, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return nil, ErrInvalidPassphrase
+	}
+
+	private, err := s.enc.Decrypt([]byte(passphrase), member.User.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return sealbox.Open(private, member.Key)
+}
+
+func canRemove(remover, member *models.Member) bool {
+	if remover.User.ID == member.User.ID {
+		return member.Role != models.OrgRoleOwner
+	}
+
+	if member.Role == models.OrgRoleOwner {
+		return remover.Role == models.OrgRoleOwner
+	}
+
+	return remover.Role.CanManage()
+}
+
+func validRole(role models.OrgRole) bool {
+	switch role {
+	case models.OrgRoleOwner, models.OrgRoleAdmin, models.OrgRoleEditor, models.OrgRoleViewer:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,347 @@
+package orgs_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/novoseltcev/passkeeper/internal/domains/orgs"
+	"github.com/novoseltcev/passkeeper/internal/domains/orgs/mocks"
+	"github.com/novoseltcev/passkeeper/internal/models"
+	"github.com/novoseltcev/passkeeper/pkg/aes"
+	"github.com/novoseltcev/passkeeper/pkg/sealbox"
+	"github.com/novoseltcev/passkeeper/pkg/testutils"
+)
+
+const (
+	testOrgID        = models.OrgID("org-id")
+	testInvitationID = models.InvitationID("invitation-id")
+	testUserID       = models.UserID("user-id")
+	testInviteeID    = models.UserID("invitee-id")
+	testLogin        = "invitee"
+	testPassphrase   = "test-passphrase"
+	testHash         = "hash"
+	testName         = "test-org"
+)
+
+// newUser returns a user with the key pair and the private key.
+func newUser(t *testing.T, id models.UserID) (*models.User, []byte) {
+	t.Helper()
+
+	public, private, err := sealbox.GenerateKey()
+	require.NoError(t, err)
+
+	encPrivate, err := aes.New(aes.AES256BitKeyLength).Encrypt([]byte(testPassphrase), private)
+	require.NoError(t, err)
+
+	return &models.User{ID: id, PassphraseHash: testHash, PublicKey: public, PrivateKey: encPrivate}, private
+}
+
+func newMember(t *testing.T, role models.OrgRole, key []byte) *models.Member {
+	t.Helper()
+
+	user, _ := newUser(t, testUserID)
+
+	sealed, err := sealbox.Seal(user.PublicKey, key)
+	require.NoError(t, err)
+
+	return &models.Member{Org: &models.Org{ID: testOrgID}, User: user, Role: role, Key: sealed}
+}
+
+func TestService_Create_Success(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := orgs.NewService(repo, nil, nil)
+
+	user, private := newUser(t, testUserID)
+	repo.EXPECT().GetUser(gomock.Any(), testUserID).Return(user, nil)
+	repo.EXPECT().
+		Create(gomock.Any(), &models.Org{Name: testName}, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *models.Org, owner *models.Member) (models.OrgID, error) {
+			assert.Equal(t, user, owner.User)
+			assert.Equal(t, models.OrgRoleOwner, owner.Role)
+
+			key, err := sealbox.Open(private, owner.Key)
+			require.NoError(t, err)
+			assert.Len(t, key, sealbox.KeySize)
+
+			return testOrgID, nil
+		})
+
+	id, err := service.Create(context.Background(), testUserID, testName)
+	require.NoError(t, err)
+	assert.Equal(t, testOrgID, id)
+}
+
+func TestService_Create_Fails_NoPublicKey(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := orgs.NewService(repo, nil, nil)
+
+	repo.EXPECT().GetUser(gomock.Any(), testUserID).Return(&models.User{ID: testUserID}, nil)
+
+	_, err := service.Create(context.Background(), testUserID, testName)
+	assert.ErrorIs(t, err, orgs.ErrNoPublicKey)
+}
+
+func TestService_GetMembers_Fails_NotMember(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := orgs.NewService(repo, nil, nil)
+
+	repo.EXPECT().GetMember(gomock.Any(), testOrgID, testUserID).Return(nil, orgs.ErrNotMember)
+
+	_, err := service.GetMembers(context.Background(), testOrgID, testUserID)
+	assert.ErrorIs(t, err, orgs.ErrNotMember)
+}
+
+func TestService_Invite_Success(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	hasher := mocks.NewMockHasher(ctrl)
+	service := orgs.NewService(repo, hasher, aes.New(aes.AES256BitKeyLength))
+
+	key := []byte("0123456789abcdef0123456789abcdef")
+	inviter := newMember(t, models.OrgRoleAdmin, key)
+	invitee, private := newUser(t, testInviteeID)
+
+	repo.EXPECT().GetMember(gomock.Any(), testOrgID, testUserID).Return(inviter, nil)
+	hasher.EXPECT().Compare(testHash, testPassphrase).Return(true, nil)
+	repo.EXPECT().GetUserByLogin(gomock.Any(), testLogin).Return(invitee, nil)
+	repo.EXPECT().GetMember(gomock.Any(), testOrgID, testInviteeID).Return(nil, orgs.ErrNotMember)
+	repo.EXPECT().
+		SaveInvitation(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, invitation *models.Invitation) (models.InvitationID, error) {
+			assert.Equal(t, inviter.Org, invitation.Org)
+			assert.Equal(t, invitee, invitation.Invitee)
+			assert.Equal(t, inviter.User, invitation.Inviter)
+			assert.Equal(t, models.OrgRoleEditor, invitation.Role)
+
+			got, err := sealbox.Open(private, invitation.Key)
+			require.NoError(t, err)
+			assert.Equal(t, key, got)
+
+			return testInvitationID, nil
+		})
+
+	id, err := service.Invite(
+		context.Background(), testOrgID, testUserID, testPassphrase, testLogin, models.OrgRoleEditor,
+	)
+	require.NoError(t, err)
+	assert.Equal(t, testInvitationID, id)
+}
+
+func TestService_Invite_Fails_Forbidden(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	tests := []struct {
+		name string
+		role models.OrgRole
+		as   models.OrgRole
+	}{
+		{name: "editor invites", role: models.OrgRoleViewer, as: models.OrgRoleEditor},
+		{name: "viewer invites", role: models.OrgRoleViewer, as: models.OrgRoleViewer},
+		{name: "admin invites owner", role: models.OrgRoleOwner, as: models.OrgRoleAdmin},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := mocks.NewMockRepository(ctrl)
+			service := orgs.NewService(repo, nil, nil)
+
+			repo.EXPECT().
+				GetMember(gomock.Any(), testOrgID, testUserID).
+				Return(&models.Member{Role: tt.as}, nil)
+
+			_, err := service.Invite(context.Background(), testOrgID, testUserID, testPassphrase, testLogin, tt.role)
+			assert.ErrorIs(t, err, orgs.ErrForbidden)
+		})
+	}
+}
+
+func TestService_Invite_Fails_InvalidRole(t *testing.T) {
+	t.Parallel()
+
+	service := orgs.NewService(nil, nil, nil)
+
+	_, err := service.Invite(context.Background(), testOrgID, testUserID, testPassphrase, testLogin, "root")
+	assert.ErrorIs(t, err, orgs.ErrInvalidRole)
+}
+
+func TestService_Invite_Fails_InvalidPassphrase(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	hasher := mocks.NewMockHasher(ctrl)
+	service := orgs.NewService(repo, hasher, nil)
+
+	repo.EXPECT().
+		GetMember(gomock.Any(), testOrgID, testUserID).
+		Return(&models.Member{User: &models.User{PassphraseHash: testHash}, Role: models.OrgRoleOwner}, nil)
+	hasher.EXPECT().Compare(testHash, testPassphrase).Return(false, nil)
+
+	_, err := service.Invite(
+		context.Background(), testOrgID, testUserID, testPassphrase, testLogin, models.OrgRoleViewer,
+	)
+	assert.ErrorIs(t, err, orgs.ErrInvalidPassphrase)
+}
+
+func TestService_Invite_Fails_AlreadyMember(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	hasher := mocks.NewMockHasher(ctrl)
+	service := orgs.NewService(repo, hasher, aes.New(aes.AES256BitKeyLength))
+
+	inviter := newMember(t, models.OrgRoleOwner, make([]byte, sealbox.KeySize))
+
+	repo.EXPECT().GetMember(gomock.Any(), testOrgID, testUserID).Return(inviter, nil)
+	hasher.EXPECT().Compare(testHash, testPassphrase).Return(true, nil)
+	repo.EXPECT().GetUserByLogin(gomock.Any(), testLogin).Return(&models.User{ID: testInviteeID}, nil)
+	repo.EXPECT().GetMember(gomock.Any(), testOrgID, testInviteeID).Return(&models.Member{}, nil)
+
+	_, err := service.Invite(
+		context.Background(), testOrgID, testUserID, testPassphrase, testLogin, models.OrgRoleViewer,
+	)
+	assert.ErrorIs(t, err, orgs.ErrAlreadyMember)
+}
+
+func TestService_Accept_Success(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := orgs.NewService(repo, nil, nil)
+
+	invitation := &models.Invitation{ID: testInvitationID, Invitee: &models.User{ID: testInviteeID}}
+	repo.EXPECT().GetInvitation(gomock.Any(), testInvitationID).Return(invitation, nil)
+	repo.EXPECT().AcceptInvitation(gomock.Any(), invitation).Return(nil)
+
+	require.NoError(t, service.Accept(context.Background(), testInvitationID, testInviteeID))
+}
+
+func TestService_Accept_Fails_AnotherInvitee(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := orgs.NewService(repo, nil, nil)
+
+	repo.EXPECT().
+		GetInvitation(gomock.Any(), testInvitationID).
+		Return(&models.Invitation{ID: testInvitationID, Invitee: &models.User{ID: testutils.UNKNOWN}}, nil)
+
+	err := service.Accept(context.Background(), testInvitationID, testInviteeID)
+	assert.ErrorIs(t, err, orgs.ErrInvitationNotFound)
+}
+
+func TestService_Decline_Success(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := orgs.NewService(repo, nil, nil)
+
+	repo.EXPECT().
+		GetInvitation(gomock.Any(), testInvitationID).
+		Return(&models.Invitation{ID: testInvitationID, Invitee: &models.User{ID: testInviteeID}}, nil)
+	repo.EXPECT().DeleteInvitation(gomock.Any(), testInvitationID).Return(nil)
+
+	require.NoError(t, service.Decline(context.Background(), testInvitationID, testInviteeID))
+}
+
+func TestService_RemoveMember(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	tests := []struct {
+		name   string
+		as     models.OrgRole
+		member models.OrgRole // empty to leave
+		err    error
+	}{
+		{name: "editor leaves", as: models.OrgRoleEditor},
+		{name: "owner leaves", as: models.OrgRoleOwner, err: orgs.ErrForbidden},
+		{name: "admin removes editor", as: models.OrgRoleAdmin, member: models.OrgRoleEditor},
+		{name: "admin removes owner", as: models.OrgRoleAdmin, member: models.OrgRoleOwner, err: orgs.ErrForbidden},
+		{name: "owner removes owner", as: models.OrgRoleOwner, member: models.OrgRoleOwner},
+		{name: "editor removes viewer", as: models.OrgRoleEditor, member: models.OrgRoleViewer, err: orgs.ErrForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := mocks.NewMockRepository(ctrl)
+			service := orgs.NewService(repo, nil, nil)
+
+			repo.EXPECT().
+				GetMember(gomock.Any(), testOrgID, testUserID).
+				Return(&models.Member{User: &models.User{ID: testUserID}, Role: tt.as}, nil)
+
+			memberID := testUserID
+			if tt.member != "" {
+				memberID = testInviteeID
+
+				repo.EXPECT().
+					GetMember(gomock.Any(), testOrgID, testInviteeID).
+					Return(&models.Member{User: &models.User{ID: testInviteeID}, Role: tt.member}, nil)
+			}
+
+			if tt.err == nil {
+				repo.EXPECT().DeleteMember(gomock.Any(), testOrgID, memberID).Return(nil)
+			}
+
+			err := service.RemoveMember(context.Background(), testOrgID, testUserID, memberID)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestService_RemoveMember_Fails_MemberNotFound(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := orgs.NewService(repo, nil, nil)
+
+	repo.EXPECT().
+		GetMember(gomock.Any(), testOrgID, testUserID).
+		Return(&models.Member{User: &models.User{ID: testUserID}, Role: models.OrgRoleOwner}, nil)
+	repo.EXPECT().GetMember(gomock.Any(), testOrgID, testInviteeID).Return(nil, orgs.ErrNotMember)
+
+	err := service.RemoveMember(context.Background(), testOrgID, testUserID, testInviteeID)
+	assert.ErrorIs(t, err, orgs.ErrMemberNotFound)
+}
@@ -0,0 +1,207 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+
+	"github.com/novoseltcev/passkeeper/internal/models"
+	"github.com/novoseltcev/passkeeper/pkg/sealbox"
+)
+
+// grant is the access of a user to a secret.
+//
+// The share is set for secrets shared with the user, the member is set for secrets of organizations,
+// both are nil for the owner of a personal secret.
+type grant struct {
+	share  *models.Share
+	member *models.Member
+}
+
+// user returns the user whose passphrase unlocks the secret.
+func (g grant) user(secret *models.Secret) *models.User {
+	switch {
+	case g.member != nil:
+		return g.member.User
+	case g.share != nil:
+		return g.share.Recipient
+	default:
+		return secret.Owner
+	}
+}
+
+func (g grant) canWrite() bool {
+	switch {
+	case g.member != nil:
+		return g.member.Role.CanWrite()
+	case g.share != nil:
+		return g.share.Access == models.ShareAccessWrite
+	default:
+		return true
+	}
+}
+
+// getAccessible returns a secret of the user, shared with the user or of the user's organization.
+func (s *service) getAccessible(
+	ctx context.Context,
+	id models.SecretID,
+	userID models.UserID,
+) (*models.Secret, grant, error) {
+	secret, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return nil, grant{}, err
+	}
+
+	if secret.Org != nil {
+		member, err := s.getMember(ctx, secret.Org.ID, userID)
+		if err != nil {
+			return nil, grant{}, err
+		}
+
+		return secret, grant{member: member}, nil
+	}
+
+	if secret.Owner.ID == userID {
+		return secret, grant{}, nil
+	}
+
+	share, err := s.repo.GetShare(ctx, id, userID)
+	if err != nil {
+		if errors.Is(err, ErrShareNotFound) {
+			return nil, grant{}, ErrAnotherOwner
+		}
+
+		return nil, grant{}, err
+	}
+
+	return secret, grant{share: share}, nil
+}
+
+// getMember returns the membership of the user in the organization.
+func (s *service) getMember(ctx context.Context, orgID models.OrgID, userID models.UserID) (*models.Member, error) {
+	member, err := s.repo.GetMember(ctx, orgID, userID)
+	if err != nil {
+		if errors.Is(err, ErrMemberNotFound) {
+			return nil, ErrAnotherOwner
+		}
+
+		return nil, err
+	}
+
+	return member, nil
+}
+
+// unlockAccessible returns a secret accessible to the user with the key of its data.
+func (s *service) unlockAccessible(
+	ctx context.Context,
+	id models.SecretID,
+	userID models.UserID,
+	passphrase string,
+) (*models.Secret, grant, []byte, error) {
+	secret, g, err := s.getAccessible(ctx, id, userID)
+	if err != nil {
+		return nil, grant{}, nil, err
+	}
+
+	key, err := s.unlockAs(secret, g, passphrase)
+	if err != nil {
+		return nil, grant{}, nil, err
+	}
+
+	return secret, g, key, nil
+}
+
+// unlockAs checks the passphrase of the granted user and returns the key of the secret data.
+func (s *service) unlockAs(secret *models.Secret, g grant, passphrase string) ([]byte, error) {
+	if err := s.checkPassphrase(g.user(secret), passphrase); err != nil {
+		return nil, err
+	}
+
+	return s.unlock(secret, g, passphrase)
+}
+
+// unlock returns the key of the secret data.
+//
+// A personal secret is encrypted with the passphrase of the owner until it is shared,
+// after that with the data key wrapped with the passphrase or sealed to the recipient.
+// A secret of an organization is encrypted with the organization key sealed to the member.
+func (s *service) unlock(secret *models.Secret, g grant, passphrase string) ([]byte, error) {
+	switch {
+	case g.member != nil:
+		return s.openSealed(g.member.User, g.member.Key, passphrase)
+	case g.share != nil:
+		return s.openSealed(g.share.Recipient, g.share.Key, passphrase)
+	case len(secret.Key) == 0:
+		return []byte(passphrase), nil
+	default:
+		return s.enc.Decrypt([]byte(passphrase), secret.Key)
+	}
+}
+
+// openSealed opens the key sealed to the user with the user's private key.
+func (s *service) openSealed(user *models.User, sealed []byte, passphrase string) ([]byte, error) {
+	private, err := s.enc.Decrypt([]byte(passphrase), user.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return sealbox.Open(private, sealed)
+}
+
+// vaultKey is the key to encrypt a new secret of a vault.
+type vaultKey struct {
+	key []byte
+	// wrapped is the key encrypted with the passphrase and escrowed is the key encrypted with the escrow key,
+	// they are only set in the personal vaults with an escrow key, where each secret has its own data key.
+	wrapped, escrowed []byte
+}
+
+// apply sets the keys of the new secret.
+func (k *vaultKey) apply(secret *models.Secret) {
+	secret.Key, secret.EscrowKey = k.wrapped, k.escrowed
+}
+
+// unlockVault checks the passphrase of the user and returns the key to encrypt new secrets of the vault.
+//
+// The vault is personal if orgID is empty.
+func (s *service) unlockVault(
+	ctx context.Context,
+	userID models.UserID,
+	orgID models.OrgID,
+	passphrase string,
+) (*models.User, *vaultKey, error) {
+	if orgID == "" {
+		owner, err := s.loadAndCheckOwner(ctx, userID, passphrase)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if len(owner.EscrowKey) == 0 {
+			return owner, &vaultKey{key: []byte(passphrase)}, nil
+		}
+
+		key, err := s.newEscrowedKey(owner, passphrase)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return owner, key, nil
+	}
+
+	member, err := s.getMember(ctx, orgID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !member.Role.CanWrite() {
+		return nil, nil, ErrReadOnly
+	}
+
+	g := grant{member: member}
+
+	key, err := s.unlockAs(nil, g, passphrase)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return member.User, &vaultKey{key: key}, nil
+}
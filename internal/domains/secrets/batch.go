@@ -0,0 +1,221 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/novoseltcev/passkeeper/internal/models"
+)
+
+type BatchOp string
+
+const (
+	BatchOpCreate BatchOp = "create"
+	BatchOpUpdate BatchOp = "update"
+	BatchOpDelete BatchOp = "delete"
+	// BatchOpMove moves a secret to another vault, the moved secret gets a new ID.
+	BatchOpMove BatchOp = "move"
+)
+
+// BatchItem is an operation of a batch.
+type BatchItem struct {
+	Op BatchOp
+	// ID is the secret to update, delete or move.
+	ID   models.SecretID
+	Name string
+	Data ISecretData
+	// Attrs are the attributes of the created or updated secret,
+	// OrgID is the vault to move the secret to, it is empty for the personal vault.
+	Attrs Attrs
+}
+
+// BatchResult is the result of an operation of a batch.
+type BatchResult struct {
+	// ID is the created or moved secret, the secret of the operation otherwise.
+	ID       models.SecretID
+	Warnings []Warning
+	Err      error
+}
+
+func (s *service) Batch(
+	ctx context.Context, userID models.UserID, passphrase string, items []BatchItem,
+) ([]BatchResult, error) {
+	user, err := s.loadAndCheckOwner(ctx, userID, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]BatchResult, len(items))
+	blobs := &batchBlobs{BlobStore: s.blobs}
+
+	err = s.repo.Atomic(ctx, func(repo Repository) error {
+		tx := *s
+		tx.repo = repo
+		tx.hasher = checkedHasher{Hasher: s.hasher, hash: user.PassphraseHash, passphrase: passphrase}
+
+		if s.blobs != nil {
+			tx.blobs = blobs
+		}
+
+		for i, item := range items {
+			results[i] = tx.runBatchItem(ctx, userID, passphrase, item)
+			if results[i].Err == nil {
+				continue
+			}
+
+			for j := i + 1; j < len(items); j++ {
+				results[j] = BatchResult{ID: items[j].ID, Err: ErrBatchAborted}
+			}
+
+			return ErrBatchFailed
+		}
+
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, ErrBatchFailed) {
+			return results, err
+		}
+
+		return nil, err
+	}
+
+	for _, key := range blobs.deleted {
+		s.dropBlob(ctx, key)
+	}
+
+	return results, nil
+}
+
+func (s *service) runBatchItem(
+	ctx context.Context, userID models.UserID, passphrase string, item BatchItem,
+) BatchResult {
+	result := BatchResult{ID: item.ID}
+
+	switch item.Op {
+	case BatchOpCreate:
+		result.ID, result.Warnings, result.Err = s.Create(ctx, userID, passphrase, item.Name, item.Data, item.Attrs)
+	case BatchOpUpdate:
+		result.Warnings, result.Err = s.Update(ctx, item.ID, userID, passphrase, item.Name, item.Data, item.Attrs)
+	case BatchOpDelete:
+		result.Err = s.Delete(ctx, item.ID, userID)
+	case BatchOpMove:
+		result.ID, result.Err = s.move(ctx, item.ID, userID, passphrase, item.Attrs.OrgID)
+	default:
+		result.Err = fmt.Errorf("unknown batch operation %q", item.Op)
+	}
+
+	return result
+}
+
+// move re-encrypts the secret with the key of the vault, creates it there and deletes the original.
+//
+// The shares of the original are deleted with it, the user becomes the owner of the moved secret.
+func (s *service) move(
+	ctx context.Context, id models.SecretID, userID models.UserID, passphrase string, orgID models.OrgID,
+) (models.SecretID, error) {
+	secret, g, key, err := s.unlockAccessible(ctx, id, userID, passphrase)
+	if err != nil {
+		return "", err
+	}
+
+	// the recipients of shared secrets can't move them as they can't delete them
+	if g.share != nil {
+		return "", ErrAnotherOwner
+	}
+
+	if !g.canWrite() {
+		return "", ErrReadOnly
+	}
+
+	if secret.Org == nil && orgID == "" || secret.Org != nil && secret.Org.ID == orgID {
+		return "", ErrSameVault
+	}
+
+	encData, err := s.encData(ctx, secret)
+	if err != nil {
+		return "", err
+	}
+
+	plain, err := s.enc.Decrypt(key, encData)
+	if err != nil {
+		return "", err
+	}
+
+	owner, vaultKey, err := s.unlockVault(ctx, userID, orgID, passphrase)
+	if err != nil {
+		return "", err
+	}
+
+	if encData, err = s.enc.Encrypt(vaultKey.key, plain); err != nil {
+		return "", err
+	}
+
+	var replaced *models.Secret
+	if secret.Owner.ID == userID {
+		replaced = secret
+	}
+
+	moved := models.NewSecret(secret.Name, secret.Type, nil, owner)
+	moved.Fingerprint = secret.Fingerprint
+	moved.Preview = secret.Preview
+	moved.URITokens = s.uriTokensOf(userID, secret.Type, plain)
+	moved.ExpiresAt, moved.RotateEvery = secret.ExpiresAt, secret.RotateEvery
+	// the content key is a part of the data, so the moved secret reads the same content blob
+	moved.ContentBlobKey, moved.ContentSize = secret.ContentBlobKey, secret.ContentSize
+	vaultKey.apply(moved)
+
+	if orgID != "" {
+		moved.Org = &models.Org{ID: orgID}
+	}
+
+	var movedID models.SecretID
+
+	err = s.withinQuota(ctx, userID, int64(len(encData))+secret.ContentSize, replaced, func(repo Repository) error {
+		return s.writeData(ctx, moved, encData, func() (err error) {
+			movedID, err = repo.Create(ctx, moved)
+
+			return err
+		})
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return "", err
+	}
+
+	s.dropBlob(ctx, secret.BlobKey)
+
+	return movedID, nil
+}
+
+// checkedHasher compares the passphrase checked for the batch without hashing it again.
+type checkedHasher struct {
+	Hasher
+	hash       string
+	passphrase string
+}
+
+func (h checkedHasher) Compare(hash, v string) (bool, error) {
+	if hash == h.hash && v == h.passphrase {
+		return true, nil
+	}
+
+	return h.Hasher.Compare(hash, v)
+}
+
+// batchBlobs defers the deletion of blobs until the batch is committed,
+// the rows of a rolled back batch still refer to them.
+type batchBlobs struct {
+	BlobStore
+	deleted []string
+}
+
+func (b *batchBlobs) Delete(_ context.Context, key string) error {
+	b.deleted = append(b.deleted, key)
+
+	return nil
+}
@@ -0,0 +1,204 @@
+package secrets_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/novoseltcev/passkeeper/internal/domains/secrets"
+	"github.com/novoseltcev/passkeeper/internal/domains/secrets/mocks"
+	"github.com/novoseltcev/passkeeper/internal/models"
+	"github.com/novoseltcev/passkeeper/pkg/aes"
+	"github.com/novoseltcev/passkeeper/pkg/testutils"
+)
+
+// expectAtomic runs the function of the transaction with the same repository.
+func expectAtomic(repo *mocks.MockRepository) *mocks.MockRepositoryAtomicCall {
+	return repo.EXPECT().
+		Atomic(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, fn func(secrets.Repository) error) error {
+			return fn(repo)
+		})
+}
+
+func TestService_Batch(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	hasher := mocks.NewMockHasher(ctrl)
+	enc := mocks.NewMockEncryptor(ctrl)
+	service := secrets.NewService(repo, hasher, enc)
+
+	owner := &models.User{ID: testOwnerID, PassphraseHash: testHash}
+	repo.EXPECT().GetOwner(gomock.Any(), testOwnerID).Return(owner, nil).Times(2)
+	hasher.EXPECT().Compare(testHash, testPassphrase).Return(true, nil).Times(1)
+	expectAtomic(repo)
+
+	enc.EXPECT().Encrypt([]byte(testPassphrase), gomock.Any()).Return(testContent, nil)
+	repo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(testID, nil)
+	repo.EXPECT().
+		Get(gomock.Any(), models.SecretID("other-id")).
+		Return(&models.Secret{Owner: owner}, nil)
+	repo.EXPECT().Delete(gomock.Any(), models.SecretID("other-id")).Return(nil)
+
+	results, err := service.Batch(context.Background(), testOwnerID, testPassphrase, []secrets.BatchItem{
+		{Op: secrets.BatchOpCreate, Name: testName, Data: testNote},
+		{Op: secrets.BatchOpDelete, ID: "other-id"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []secrets.BatchResult{{ID: testID}, {ID: "other-id"}}, results)
+}
+
+func TestService_Batch_Fails_Item(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	hasher := mocks.NewMockHasher(ctrl)
+	service := secrets.NewService(repo, hasher, nil)
+
+	owner := &models.User{ID: testOwnerID, PassphraseHash: testHash}
+	repo.EXPECT().GetOwner(gomock.Any(), testOwnerID).Return(owner, nil)
+	hasher.EXPECT().Compare(testHash, testPassphrase).Return(true, nil)
+	expectAtomic(repo)
+
+	repo.EXPECT().
+		Get(gomock.Any(), testID).
+		Return(&models.Secret{Owner: &models.User{ID: "another-id"}}, nil)
+	repo.EXPECT().GetShare(gomock.Any(), testID, testOwnerID).Return(nil, secrets.ErrShareNotFound)
+
+	results, err := service.Batch(context.Background(), testOwnerID, testPassphrase, []secrets.BatchItem{
+		{Op: secrets.BatchOpDelete, ID: testID},
+		{Op: secrets.BatchOpDelete, ID: "other-id"},
+	})
+	require.ErrorIs(t, err, secrets.ErrBatchFailed)
+	require.Len(t, results, 2)
+	require.ErrorIs(t, results[0].Err, secrets.ErrAnotherOwner)
+	require.ErrorIs(t, results[1].Err, secrets.ErrBatchAborted)
+}
+
+func TestService_Batch_Fails_Passphrase(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	hasher := mocks.NewMockHasher(ctrl)
+	service := secrets.NewService(repo, hasher, nil)
+
+	repo.EXPECT().
+		GetOwner(gomock.Any(), testOwnerID).
+		Return(&models.User{ID: testOwnerID, PassphraseHash: testHash}, nil)
+	hasher.EXPECT().Compare(testHash, testPassphrase).Return(false, nil)
+
+	_, err := service.Batch(context.Background(), testOwnerID, testPassphrase, []secrets.BatchItem{
+		{Op: secrets.BatchOpDelete, ID: testID},
+	})
+	require.ErrorIs(t, err, secrets.ErrInvalidPassphrase)
+}
+
+func TestService_Batch_Fails_Commit(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	hasher := mocks.NewMockHasher(ctrl)
+	service := secrets.NewService(repo, hasher, nil)
+
+	repo.EXPECT().
+		GetOwner(gomock.Any(), testOwnerID).
+		Return(&models.User{ID: testOwnerID, PassphraseHash: testHash}, nil)
+	hasher.EXPECT().Compare(testHash, testPassphrase).Return(true, nil)
+	repo.EXPECT().Atomic(gomock.Any(), gomock.Any()).Return(testutils.Err)
+
+	_, err := service.Batch(context.Background(), testOwnerID, testPassphrase, nil)
+	require.ErrorIs(t, err, testutils.Err)
+}
+
+func TestService_Batch_DefersBlobs(t *testing.T) {
+	t.Parallel()
+	repo, _, blobs, service := setupBlobs(t)
+
+	owner := &models.User{ID: testOwnerID, PassphraseHash: testHash}
+	repo.EXPECT().GetOwner(gomock.Any(), testOwnerID).Return(owner, nil)
+	repo.EXPECT().Get(gomock.Any(), testID).Return(&models.Secret{BlobKey: testBlobKey, Owner: owner}, nil)
+	repo.EXPECT().
+		Atomic(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, fn func(secrets.Repository) error) error {
+			require.NoError(t, fn(repo))
+
+			return testutils.Err
+		})
+	repo.EXPECT().Delete(gomock.Any(), testID).Return(nil)
+	blobs.EXPECT().Delete(gomock.Any(), gomock.Any()).Times(0)
+
+	_, err := service.Batch(context.Background(), testOwnerID, testPassphrase, []secrets.BatchItem{
+		{Op: secrets.BatchOpDelete, ID: testID},
+	})
+	require.ErrorIs(t, err, testutils.Err)
+}
+
+func TestService_Batch_Move(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	hasher := mocks.NewMockHasher(ctrl)
+	enc := aes.New(aes.AES256BitKeyLength)
+	service := secrets.NewService(repo, hasher, enc)
+
+	member := newOrgMember(t, enc, models.OrgRoleEditor)
+	repo.EXPECT().GetOwner(gomock.Any(), testRecipientID).Return(member.User, nil).Times(2)
+	hasher.EXPECT().Compare(testRecipientHash, testRecipientPassphrase).Return(true, nil).Times(1)
+	expectAtomic(repo)
+
+	repo.EXPECT().Get(gomock.Any(), testID).Return(newOrgSecret(t, enc), nil)
+	repo.EXPECT().GetMember(gomock.Any(), testOrgID, testRecipientID).Return(member, nil)
+	repo.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, secret *models.Secret) (models.SecretID, error) {
+			assert.Nil(t, secret.Org)
+			assert.Equal(t, testRecipientID, secret.Owner.ID)
+
+			data, err := enc.Decrypt([]byte(testRecipientPassphrase), secret.Data)
+			require.NoError(t, err)
+			assert.Equal(t, testContent, data)
+
+			return "moved-id", nil
+		})
+	repo.EXPECT().Delete(gomock.Any(), testID).Return(nil)
+
+	results, err := service.Batch(context.Background(), testRecipientID, testRecipientPassphrase,
+		[]secrets.BatchItem{{Op: secrets.BatchOpMove, ID: testID}})
+	require.NoError(t, err)
+	assert.Equal(t, []secrets.BatchResult{{ID: "moved-id"}}, results)
+}
+
+func TestService_Batch_Move_Fails_SameVault(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	hasher := mocks.NewMockHasher(ctrl)
+	service := secrets.NewService(repo, hasher, nil)
+
+	owner := &models.User{ID: testOwnerID, PassphraseHash: testHash}
+	repo.EXPECT().GetOwner(gomock.Any(), testOwnerID).Return(owner, nil)
+	hasher.EXPECT().Compare(testHash, testPassphrase).Return(true, nil)
+	expectAtomic(repo)
+	repo.EXPECT().Get(gomock.Any(), testID).Return(&models.Secret{Owner: owner}, nil)
+
+	results, err := service.Batch(context.Background(), testOwnerID, testPassphrase,
+		[]secrets.BatchItem{{Op: secrets.BatchOpMove, ID: testID}})
+	require.ErrorIs(t, err, secrets.ErrBatchFailed)
+	require.ErrorIs(t, results[0].Err, secrets.ErrSameVault)
+}
@@ -0,0 +1,124 @@
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/novoseltcev/passkeeper/internal/models"
+)
+
+var errNoBlobStore = errors.New("secret data is in a blob store, which is not configured")
+
+func (s *service) CollectBlobs(ctx context.Context, age time.Duration) (int, error) {
+	if s.blobs == nil {
+		return 0, nil
+	}
+
+	infos, err := s.blobs.List(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	deadline := time.Now().Add(-age)
+	keys := make([]string, 0, len(infos))
+
+	for _, info := range infos {
+		if info.ModTime.Before(deadline) {
+			keys = append(keys, info.Key)
+		}
+	}
+
+	if len(keys) == 0 {
+		return 0, nil
+	}
+
+	used, err := s.repo.GetUsedBlobs(ctx, keys)
+	if err != nil {
+		return 0, err
+	}
+
+	inUse := make(map[string]struct{}, len(used))
+	for _, key := range used {
+		inUse[key] = struct{}{}
+	}
+
+	collected := 0
+
+	for _, key := range keys {
+		if _, ok := inUse[key]; ok {
+			continue
+		}
+
+		if err := s.blobs.Delete(ctx, key); err != nil {
+			return collected, err
+		}
+
+		collected++
+	}
+
+	return collected, nil
+}
+
+// encData returns the encrypted data of the secret from its row or from its blob.
+func (s *service) encData(ctx context.Context, secret *models.Secret) ([]byte, error) {
+	if secret.BlobKey == "" {
+		return secret.Data, nil
+	}
+
+	if s.blobs == nil {
+		return nil, errNoBlobStore
+	}
+
+	return s.blobs.Get(ctx, secret.BlobKey)
+}
+
+// writeData sets the encrypted data of the secret and saves the secret with write.
+//
+// The data of file secrets with the content in it is put to a new blob,
+// so the saved one is intact until the secret refers to the new one.
+// The blob, which is not referred to after the write, is deleted.
+func (s *service) writeData(ctx context.Context, secret *models.Secret, encData []byte, write func() error) error {
+	old := secret.BlobKey
+	secret.Size = int64(len(encData)) + secret.ContentSize
+
+	// the data of the files streamed to content blobs is small, so it is kept in the row
+	if s.blobs == nil || secret.Type != models.SecretTypeFile || secret.ContentBlobKey != "" {
+		secret.Data, secret.BlobKey = encData, ""
+	} else {
+		key := uuid.NewString()
+		if err := s.blobs.Put(ctx, key, bytes.NewReader(encData)); err != nil {
+			return err
+		}
+
+		secret.Data, secret.BlobKey = []byte{}, key
+	}
+
+	if err := write(); err != nil {
+		if secret.BlobKey != old {
+			s.dropBlob(ctx, secret.BlobKey)
+		}
+
+		secret.BlobKey = old
+
+		return err
+	}
+
+	if old != secret.BlobKey {
+		s.dropBlob(ctx, old)
+	}
+
+	return nil
+}
+
+// dropBlob deletes the blob if any, the blobs failed to delete are left to CollectBlobs.
+func (s *service) dropBlob(ctx context.Context, key string) {
+	if key == "" || s.blobs == nil {
+		return
+	}
+
+	_ = s.blobs.Delete(ctx, key)
+}
@@ -0,0 +1,268 @@
+package secrets_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/novoseltcev/passkeeper/internal/domains/secrets"
+	"github.com/novoseltcev/passkeeper/internal/domains/secrets/mocks"
+	"github.com/novoseltcev/passkeeper/internal/models"
+	"github.com/novoseltcev/passkeeper/pkg/blobstore"
+	"github.com/novoseltcev/passkeeper/pkg/testutils"
+)
+
+const testBlobKey = "blob-key"
+
+var testFileData = &secrets.FileData{Filename: "file.txt", Content: "74657374", Meta: map[string]any{}}
+
+// readerOf matches the reader of the data, the reader is read by the match.
+func readerOf(data []byte) gomock.Matcher {
+	return gomock.Cond(func(r io.Reader) bool {
+		got, err := io.ReadAll(r)
+
+		return err == nil && bytes.Equal(data, got)
+	})
+}
+
+func setupBlobs(t *testing.T) (*mocks.MockRepository, *mocks.MockEncryptor, *mocks.MockBlobStore, secrets.Service) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	hasher := mocks.NewMockHasher(ctrl)
+	enc := mocks.NewMockEncryptor(ctrl)
+	blobs := mocks.NewMockBlobStore(ctrl)
+
+	hasher.EXPECT().Compare(testHash, testPassphrase).Return(true, nil).AnyTimes()
+
+	return repo, enc, blobs, secrets.NewService(repo, hasher, enc, secrets.WithBlobStore(blobs))
+}
+
+func TestService_Create_File_Blob(t *testing.T) {
+	t.Parallel()
+	repo, enc, blobs, service := setupBlobs(t)
+
+	owner := &models.User{ID: testOwnerID, PassphraseHash: testHash}
+	repo.EXPECT().GetOwner(gomock.Any(), testOwnerID).Return(owner, nil)
+	enc.EXPECT().Encrypt([]byte(testPassphrase), gomock.Any()).Return(testContent, nil)
+
+	var key string
+
+	blobs.EXPECT().
+		Put(gomock.Any(), gomock.Any(), readerOf(testContent)).
+		DoAndReturn(func(_ context.Context, k string, _ io.Reader) error {
+			key = k
+
+			return nil
+		})
+
+	repo.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, secret *models.Secret) (models.SecretID, error) {
+			assert.Empty(t, secret.Data)
+			assert.Equal(t, key, secret.BlobKey)
+			assert.Equal(t, map[string]string{secrets.PreviewFilename: "file.txt"}, secret.Preview)
+
+			return testID, nil
+		})
+
+	id, _, err := service.Create(context.Background(), testOwnerID, testPassphrase, testName,
+		testFileData, secrets.Attrs{})
+	require.NoError(t, err)
+	assert.Equal(t, testID, id)
+	assert.NotEmpty(t, key)
+}
+
+func TestService_Create_File_Blob_Fails_Create(t *testing.T) {
+	t.Parallel()
+	repo, enc, blobs, service := setupBlobs(t)
+
+	owner := &models.User{ID: testOwnerID, PassphraseHash: testHash}
+	repo.EXPECT().GetOwner(gomock.Any(), testOwnerID).Return(owner, nil)
+	enc.EXPECT().Encrypt([]byte(testPassphrase), gomock.Any()).Return(testContent, nil)
+
+	var key string
+
+	blobs.EXPECT().
+		Put(gomock.Any(), gomock.Any(), readerOf(testContent)).
+		DoAndReturn(func(_ context.Context, k string, _ io.Reader) error {
+			key = k
+
+			return nil
+		})
+	repo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(models.SecretID(""), testutils.Err)
+	blobs.EXPECT().
+		Delete(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, k string) error {
+			assert.Equal(t, key, k)
+
+			return nil
+		})
+
+	_, _, err := service.Create(context.Background(), testOwnerID, testPassphrase, testName, testFileData, secrets.Attrs{})
+	require.ErrorIs(t, err, testutils.Err)
+}
+
+func TestService_Create_Password_InRow(t *testing.T) {
+	t.Parallel()
+	repo, enc, _, service := setupBlobs(t)
+
+	owner := &models.User{ID: testOwnerID, PassphraseHash: testHash}
+	repo.EXPECT().GetOwner(gomock.Any(), testOwnerID).Return(owner, nil)
+	enc.EXPECT().Encrypt([]byte(testPassphrase), gomock.Any()).Return(testContent, nil)
+	repo.EXPECT().
+		Create(gomock.Any(), &models.Secret{
+			Name:    testName,
+			Type:    models.SecretTypePwd,
+			Data:    testContent,
+			Size:    int64(len(testContent)),
+			Preview: map[string]string{secrets.PreviewLogin: "login"},
+			Owner:   owner,
+		}).
+		Return(testID, nil)
+
+	_, _, err := service.Create(context.Background(), testOwnerID, testPassphrase, testName,
+		&secrets.PasswordData{Login: "login", Password: "password", Meta: map[string]any{}}, secrets.Attrs{})
+	require.NoError(t, err)
+}
+
+func TestService_Get_Blob(t *testing.T) {
+	t.Parallel()
+	repo, enc, blobs, service := setupBlobs(t)
+
+	repo.EXPECT().
+		Get(gomock.Any(), testID).
+		Return(&models.Secret{
+			Type:    models.SecretTypeFile,
+			Data:    []byte{},
+			BlobKey: testBlobKey,
+			Owner:   &models.User{ID: testOwnerID, PassphraseHash: testHash},
+		}, nil)
+	blobs.EXPECT().Get(gomock.Any(), testBlobKey).Return(testContent, nil)
+	enc.EXPECT().Decrypt([]byte(testPassphrase), testContent).Return([]byte(testutils.STRING), nil)
+	repo.EXPECT().Touch(gomock.Any(), testID).Return(nil)
+
+	secret, err := service.Get(context.Background(), testID, testOwnerID, testPassphrase)
+	require.NoError(t, err)
+	assert.Equal(t, []byte(testutils.STRING), []byte(secret.Data))
+}
+
+func TestService_Get_Blob_Fails_NoStore(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	hasher := mocks.NewMockHasher(ctrl)
+	service := secrets.NewService(repo, hasher, nil)
+
+	repo.EXPECT().
+		Get(gomock.Any(), testID).
+		Return(&models.Secret{BlobKey: testBlobKey, Owner: &models.User{ID: testOwnerID, PassphraseHash: testHash}}, nil)
+	hasher.EXPECT().Compare(testHash, testPassphrase).Return(true, nil)
+
+	_, err := service.Get(context.Background(), testID, testOwnerID, testPassphrase)
+	require.Error(t, err)
+}
+
+func TestService_Update_File_Blob_ReplacesBlob(t *testing.T) {
+	t.Parallel()
+	repo, enc, blobs, service := setupBlobs(t)
+
+	repo.EXPECT().
+		Get(gomock.Any(), testID).
+		Return(&models.Secret{
+			Type:    models.SecretTypeFile,
+			BlobKey: testBlobKey,
+			Owner:   &models.User{ID: testOwnerID, PassphraseHash: testHash},
+		}, nil)
+	enc.EXPECT().Encrypt([]byte(testPassphrase), gomock.Any()).Return(testContent, nil)
+
+	var key string
+
+	gomock.InOrder(
+		blobs.EXPECT().
+			Put(gomock.Any(), gomock.Any(), readerOf(testContent)).
+			DoAndReturn(func(_ context.Context, k string, _ io.Reader) error {
+				key = k
+
+				return nil
+			}),
+		repo.EXPECT().
+			Update(gomock.Any(), testID, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ models.SecretID, secret *models.Secret) error {
+				assert.Equal(t, key, secret.BlobKey)
+				assert.NotEqual(t, testBlobKey, secret.BlobKey)
+
+				return nil
+			}),
+		blobs.EXPECT().Delete(gomock.Any(), testBlobKey).Return(nil),
+	)
+
+	_, err := service.Update(context.Background(), testID, testOwnerID, testPassphrase, testName,
+		testFileData, secrets.Attrs{})
+	require.NoError(t, err)
+}
+
+func TestService_Delete_Blob(t *testing.T) {
+	t.Parallel()
+	repo, _, blobs, service := setupBlobs(t)
+
+	repo.EXPECT().
+		Get(gomock.Any(), testID).
+		Return(&models.Secret{BlobKey: testBlobKey, Owner: &models.User{ID: testOwnerID}}, nil)
+
+	gomock.InOrder(
+		repo.EXPECT().Delete(gomock.Any(), testID).Return(nil),
+		blobs.EXPECT().Delete(gomock.Any(), testBlobKey).Return(testutils.Err),
+	)
+
+	require.NoError(t, service.Delete(context.Background(), testID, testOwnerID))
+}
+
+func TestService_CollectBlobs(t *testing.T) {
+	t.Parallel()
+	repo, _, blobs, service := setupBlobs(t)
+
+	old := time.Now().Add(-2 * time.Hour)
+
+	blobs.EXPECT().
+		List(gomock.Any()).
+		Return([]blobstore.Info{
+			{Key: "used", ModTime: old},
+			{Key: "orphan", ModTime: old},
+			{Key: "young", ModTime: time.Now()},
+		}, nil)
+	repo.EXPECT().GetUsedBlobs(gomock.Any(), []string{"used", "orphan"}).Return([]string{"used"}, nil)
+	blobs.EXPECT().Delete(gomock.Any(), "orphan").Return(nil)
+
+	collected, err := service.CollectBlobs(context.Background(), time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, 1, collected)
+}
+
+func TestService_CollectBlobs_Fails(t *testing.T) {
+	t.Parallel()
+	_, _, blobs, service := setupBlobs(t)
+
+	blobs.EXPECT().List(gomock.Any()).Return(nil, testutils.Err)
+
+	_, err := service.CollectBlobs(context.Background(), time.Hour)
+	require.ErrorIs(t, err, testutils.Err)
+}
+
+func TestService_CollectBlobs_NoStore(t *testing.T) {
+	t.Parallel()
+
+	collected, err := secrets.NewService(nil, nil, nil).CollectBlobs(context.Background(), time.Hour)
+	require.NoError(t, err)
+	assert.Zero(t, collected)
+}
@@ -1,17 +1,77 @@
 package secrets
 
-import "github.com/novoseltcev/passkeeper/internal/models"
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/novoseltcev/passkeeper/internal/models"
+	"github.com/novoseltcev/passkeeper/pkg/card"
+	"github.com/novoseltcev/passkeeper/pkg/otp"
+	"github.com/novoseltcev/passkeeper/pkg/sshkey"
+	"github.com/novoseltcev/passkeeper/pkg/urimatch"
+)
+
+func init() { // nolint: gochecknoinits
+	RegisterData(func() ISecretData { return &PasswordData{} })
+	RegisterData(func() ISecretData { return &CardData{} })
+	RegisterData(func() ISecretData { return &TextData{} })
+	RegisterData(func() ISecretData { return &FileData{} })
+	RegisterData(func() ISecretData { return &OTPData{} })
+	RegisterData(func() ISecretData { return &SSHKeyData{} })
+	RegisterData(func() ISecretData { return &CustomData{} })
+}
 
 type PasswordData struct {
 	Login    string         `json:"login"`
 	Password string         `json:"password"`
+	URIs     []URI          `json:"uris,omitempty"`
 	Meta     map[string]any `json:"meta"`
 }
 
+// URI is a website of the credentials with the way it matches the URLs.
+type URI struct {
+	URI   string            `json:"uri"`
+	Match urimatch.Strategy `json:"match,omitempty"`
+}
+
 func (p PasswordData) SecretType() models.SecretType {
 	return models.SecretTypePwd
 }
 
+// Preview returns the login, it is shown in the list with the name.
+func (p PasswordData) Preview() map[string]string {
+	if p.Login == "" {
+		return nil
+	}
+
+	return map[string]string{PreviewLogin: p.Login}
+}
+
+func (p PasswordData) Validate() error {
+	for _, uri := range p.URIs {
+		if err := urimatch.Validate(uri.URI, uri.Match); err != nil {
+			return fmt.Errorf("uri %q: %w", uri.URI, err)
+		}
+	}
+
+	return nil
+}
+
+// MatchURL reports whether any of the URIs matches the URL.
+func (p PasswordData) MatchURL(rawURL string) bool {
+	for _, uri := range p.URIs {
+		if urimatch.Match(uri.URI, uri.Match, rawURL) {
+			return true
+		}
+	}
+
+	return false
+}
+
 type CardData struct {
 	Number string         `json:"number"`
 	Holder string         `json:"holder"`
@@ -24,6 +84,36 @@ func (c CardData) SecretType() models.SecretType {
 	return models.SecretTypeCard
 }
 
+// ExpiresAt returns the moment the card expires, that is the start of the month after Exp.
+func (c CardData) ExpiresAt() (time.Time, error) {
+	return card.ParseExpiry(c.Exp)
+}
+
+// Validate checks the number, the CVV of its brand and the format of the expiration.
+//
+// The expired cards are valid, so they are kept and reported.
+func (c CardData) Validate() error {
+	if err := card.CheckNumber(c.Number); err != nil {
+		return err
+	}
+
+	if _, err := card.ParseExpiry(c.Exp); err != nil {
+		return err
+	}
+
+	return card.CheckCVV(card.DetectBrand(c.Number), c.CVV)
+}
+
+// Preview returns the brand and the last four digits of the number.
+func (c CardData) Preview() map[string]string {
+	preview := map[string]string{PreviewLast4: card.Last4(c.Number)}
+	if brand := card.DetectBrand(c.Number); brand != card.Unknown {
+		preview[PreviewBrand] = string(brand)
+	}
+
+	return preview
+}
+
 type TextData struct {
 	Content string         `json:"content"`
 	Meta    map[string]any `json:"meta"`
@@ -34,11 +124,153 @@ func (t TextData) SecretType() models.SecretType {
 }
 
 type FileData struct {
-	Filename string         `json:"filename"`
-	Content  string         `json:"content"`
-	Meta     map[string]any `json:"meta"`
+	Filename string `json:"filename"`
+	// Content is the hex encoded content of the files kept in the data.
+	Content string         `json:"content"`
+	Meta    map[string]any `json:"meta"`
+	// ContentKey encrypts the content streamed to the content blob of the secret, the Content is empty then.
+	ContentKey []byte `json:"content_key,omitempty"`
+	// Size is the size of the streamed content.
+	Size int64 `json:"size,omitempty"`
 }
 
 func (f FileData) SecretType() models.SecretType {
 	return models.SecretTypeFile
 }
+
+// Preview returns the filename.
+func (f FileData) Preview() map[string]string {
+	if f.Filename == "" {
+		return nil
+	}
+
+	return map[string]string{PreviewFilename: f.Filename}
+}
+
+type OTPData struct {
+	Kind      string         `json:"kind"`
+	Secret    string         `json:"secret"`
+	Algorithm string         `json:"algorithm"`
+	Digits    int            `json:"digits"`
+	Period    int            `json:"period"`
+	Counter   uint64         `json:"counter"`
+	Issuer    string         `json:"issuer"`
+	Account   string         `json:"account"`
+	Meta      map[string]any `json:"meta"`
+}
+
+func (o OTPData) SecretType() models.SecretType {
+	return models.SecretTypeOTP
+}
+
+func (o OTPData) Validate() error {
+	return o.Key().Validate()
+}
+
+// Key returns the OTP key described by the data.
+func (o OTPData) Key() *otp.Key {
+	return &otp.Key{
+		Kind:      o.Kind,
+		Issuer:    o.Issuer,
+		Account:   o.Account,
+		Secret:    o.Secret,
+		Algorithm: o.Algorithm,
+		Digits:    o.Digits,
+		Period:    o.Period,
+		Counter:   o.Counter,
+	}
+}
+
+type SSHKeyData struct {
+	PrivateKey string         `json:"private_key"`
+	PublicKey  string         `json:"public_key"`
+	Passphrase string         `json:"passphrase"`
+	Comment    string         `json:"comment"`
+	Meta       map[string]any `json:"meta"`
+}
+
+func (k SSHKeyData) SecretType() models.SecretType {
+	return models.SecretTypeSSHKey
+}
+
+func (k SSHKeyData) Validate() error {
+	return sshkey.Check(k.PrivateKey, k.PublicKey, k.Passphrase)
+}
+
+func (k SSHKeyData) Fingerprint() string {
+	fingerprint, _ := sshkey.Fingerprint(k.PublicKey)
+
+	return fingerprint
+}
+
+// CustomData is a secret with the fields described by a user-defined template.
+type CustomData struct {
+	Template models.TemplateID `json:"template"`
+	Fields   map[string]string `json:"fields"`
+	Meta     map[string]any    `json:"meta"`
+}
+
+func (c CustomData) SecretType() models.SecretType {
+	return models.SecretTypeCustom
+}
+
+// Match checks that the fields are declared by the template and their values match the field types.
+func (c CustomData) Match(template *models.Template) error {
+	declared := make(map[string]struct{}, len(template.Fields))
+
+	for _, field := range template.Fields {
+		declared[field.Name] = struct{}{}
+
+		value := c.Fields[field.Name]
+		if value == "" {
+			if field.Required {
+				return fmt.Errorf("field %q is required", field.Name)
+			}
+
+			continue
+		}
+
+		if err := checkFieldValue(field.Type, value); err != nil {
+			return fmt.Errorf("field %q: %w", field.Name, err)
+		}
+	}
+
+	for name := range c.Fields {
+		if _, ok := declared[name]; !ok {
+			return fmt.Errorf("field %q is not declared by the template", name)
+		}
+	}
+
+	return nil
+}
+
+func checkFieldValue(fieldType models.FieldType, value string) error {
+	switch fieldType {
+	case models.FieldTypeURL:
+		u, err := url.ParseRequestURI(value)
+		if err != nil {
+			return err
+		}
+
+		if u.Scheme == "" || u.Host == "" {
+			return errors.New("url must be absolute")
+		}
+	case models.FieldTypeEmail:
+		if _, err := mail.ParseAddress(value); err != nil {
+			return err
+		}
+	case models.FieldTypeDate:
+		if _, err := time.Parse(time.DateOnly, value); err != nil {
+			return err
+		}
+	case models.FieldTypeNumber:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return err
+		}
+	case models.FieldTypeString, models.FieldTypeConcealed, models.FieldTypeMultiline:
+	default:
+		return fmt.Errorf("unknown field type %q", fieldType)
+	}
+
+	return nil
+}
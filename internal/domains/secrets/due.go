@@ -0,0 +1,105 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/novoseltcev/passkeeper/internal/models"
+)
+
+// Attrs are the attributes of a secret stored unencrypted next to its data.
+type Attrs struct {
+	// ExpiresAt is zero if the secret does not expire.
+	//
+	// It is derived from the expiration of cards.
+	ExpiresAt time.Time
+	// RotateEvery is zero if the secret does not need rotation.
+	RotateEvery time.Duration
+	// OrgID is the organization vault of a new secret, it is empty for the personal vault.
+	//
+	// It is ignored on update, secrets are moved between vaults by batches only.
+	OrgID models.OrgID
+	// Revision is the revision of the secret an update is based on, zero skips the check.
+	//
+	// It is ignored on create.
+	Revision int64
+	// CreatedAt is the creation time of an imported secret, zero is the current time.
+	//
+	// It is ignored on update.
+	CreatedAt time.Time
+}
+
+func attrsOf(secret *models.Secret) Attrs {
+	return Attrs{ExpiresAt: secret.ExpiresAt, RotateEvery: secret.RotateEvery}
+}
+
+type DueReason string
+
+const (
+	DueReasonExpired  DueReason = "expired"
+	DueReasonRotation DueReason = "rotation"
+)
+
+// DueSecret is a secret that is expired or due for rotation.
+type DueSecret struct {
+	Secret models.Secret
+	Reason DueReason
+	DueAt  time.Time
+}
+
+func (s *service) GetDue(ctx context.Context, ownerID models.UserID) ([]DueSecret, error) {
+	secrets, err := s.repo.GetDue(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]DueSecret, len(secrets))
+	for i, secret := range secrets {
+		result[i] = dueOf(secret)
+	}
+
+	return result, nil
+}
+
+func (s *service) NotifyDue(ctx context.Context, notifier Notifier) (int, error) {
+	secrets, err := s.repo.GetDueToNotify(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		sent int
+		errs []error
+	)
+
+	for _, secret := range secrets {
+		if err := notifier.Notify(ctx, dueOf(secret)); err != nil {
+			errs = append(errs, err)
+
+			continue
+		}
+
+		if err := s.repo.MarkNotified(ctx, secret.ID); err != nil {
+			return sent, err
+		}
+
+		sent++
+	}
+
+	return sent, errors.Join(errs...)
+}
+
+// dueOf returns the earliest reason the secret is due.
+func dueOf(secret models.Secret) DueSecret {
+	var rotateAt time.Time
+	if secret.RotateEvery > 0 {
+		rotateAt = secret.UpdatedAt.Add(secret.RotateEvery)
+	}
+
+	if !secret.ExpiresAt.IsZero() && (rotateAt.IsZero() || !secret.ExpiresAt.After(rotateAt)) {
+		return DueSecret{Secret: secret, Reason: DueReasonExpired, DueAt: secret.ExpiresAt}
+	}
+
+	return DueSecret{Secret: secret, Reason: DueReasonRotation, DueAt: rotateAt}
+}
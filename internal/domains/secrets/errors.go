@@ -7,4 +7,25 @@ var (
 	ErrAnotherOwner      = errors.New("another owner")
 	ErrInvalidPassphrase = errors.New("invalid passphrase")
 	ErrInvalidSecretType = errors.New("invalid secret type")
+	ErrInvalidSecretData = errors.New("invalid secret data")
+	ErrTemplateNotFound  = errors.New("template not found")
+	ErrReadOnly          = errors.New("read-only access")
+	ErrShareNotFound     = errors.New("share not found")
+	ErrRecipientNotFound = errors.New("recipient not found")
+	ErrInvalidRecipient  = errors.New("invalid recipient")
+	ErrNoPublicKey       = errors.New("recipient has no public key")
+	ErrMemberNotFound    = errors.New("member not found")
+	ErrQuotaExceeded     = errors.New("quota exceeded")
+	ErrSecretTooLarge    = errors.New("secret is too large")
+	ErrSameVault         = errors.New("secret is already in the vault")
+	ErrRevisionMismatch  = errors.New("secret was changed since the revision")
+	ErrBatchFailed       = errors.New("batch failed")
+	ErrBatchAborted      = errors.New("batch aborted")
+	ErrInvalidURL        = errors.New("invalid url")
+	ErrMatchDisabled     = errors.New("lookup by url is not configured")
+	ErrNotEscrowed       = errors.New("secret is not escrowed")
+
+	ErrInvalidArchive        = errors.New("invalid archive")
+	ErrInvalidExportPassword = errors.New("invalid export password")
+	ErrImportFailed          = errors.New("import failed")
 )
@@ -0,0 +1,168 @@
+package secrets
+
+import (
+	"context"
+	"crypto/rand"
+
+	"github.com/novoseltcev/passkeeper/internal/models"
+	"github.com/novoseltcev/passkeeper/pkg/sealbox"
+)
+
+func (s *service) Escrow(ctx context.Context, ownerID models.UserID, passphrase string) ([]byte, error) {
+	owner, err := s.loadAndCheckOwner(ctx, ownerID, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	all, err := s.repo.GetAll(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	var escrowKey []byte
+
+	err = s.repo.Atomic(ctx, func(repo Repository) error {
+		if len(owner.EscrowKey) > 0 {
+			if escrowKey, err = s.enc.Decrypt([]byte(passphrase), owner.EscrowKey); err != nil {
+				return err
+			}
+		} else {
+			escrowKey = make([]byte, sealbox.KeySize)
+			if _, err := rand.Read(escrowKey); err != nil {
+				return err
+			}
+
+			wrapped, err := s.enc.Encrypt([]byte(passphrase), escrowKey)
+			if err != nil {
+				return err
+			}
+
+			if err := repo.SetOwnerEscrowKey(ctx, ownerID, wrapped); err != nil {
+				return err
+			}
+		}
+
+		for i := range all {
+			if len(all[i].EscrowKey) == 0 {
+				if err := s.escrow(ctx, repo, &all[i], passphrase, escrowKey); err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return escrowKey, nil
+}
+
+func (s *service) GetEscrowed(
+	ctx context.Context, id models.SecretID, ownerID models.UserID, escrowKey []byte,
+) (*models.Secret, error) {
+	secret, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// the ownership is checked before anything is decrypted
+	if secret.Owner.ID != ownerID || secret.Org != nil {
+		return nil, ErrAnotherOwner
+	}
+
+	if len(secret.EscrowKey) == 0 {
+		return nil, ErrNotEscrowed
+	}
+
+	key, err := s.enc.Decrypt(escrowKey, secret.EscrowKey)
+	if err != nil {
+		return nil, err
+	}
+
+	encData, err := s.encData(ctx, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	if secret.Data, err = s.enc.Decrypt(key, encData); err != nil {
+		return nil, err
+	}
+
+	// the secret is read as a whole, so is the streamed content of a file
+	if secret.ContentBlobKey != "" {
+		if secret.Data, err = s.inlineContent(ctx, secret, secret.Data); err != nil {
+			return nil, err
+		}
+	}
+
+	return secret, nil
+}
+
+// escrow encrypts the data key of the personal secret with the escrow key.
+//
+// The secret encrypted with the passphrase itself is moved to a data key first.
+func (s *service) escrow(
+	ctx context.Context, repo Repository, secret *models.Secret, passphrase string, escrowKey []byte,
+) error {
+	var (
+		key []byte
+		err error
+	)
+
+	if len(secret.Key) == 0 {
+		key, err = s.rotateKey(ctx, repo, secret, passphrase, []byte(passphrase), nil)
+	} else {
+		key, err = s.enc.Decrypt([]byte(passphrase), secret.Key)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if secret.EscrowKey, err = s.enc.Encrypt(escrowKey, key); err != nil {
+		return err
+	}
+
+	return repo.SetEscrowKey(ctx, secret.ID, secret.EscrowKey)
+}
+
+// ownerEscrowKey returns the escrow key of the owner.
+func (s *service) ownerEscrowKey(
+	ctx context.Context, repo Repository, ownerID models.UserID, passphrase string,
+) ([]byte, error) {
+	owner, err := repo.GetOwner(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(owner.EscrowKey) == 0 {
+		return nil, ErrNotEscrowed
+	}
+
+	return s.enc.Decrypt([]byte(passphrase), owner.EscrowKey)
+}
+
+// newEscrowedKey returns a new data key of a secret of the owner with an escrow key.
+func (s *service) newEscrowedKey(owner *models.User, passphrase string) (*vaultKey, error) {
+	escrowKey, err := s.enc.Decrypt([]byte(passphrase), owner.EscrowKey)
+	if err != nil {
+		return nil, err
+	}
+
+	key := &vaultKey{key: make([]byte, sealbox.KeySize)}
+	if _, err := rand.Read(key.key); err != nil {
+		return nil, err
+	}
+
+	if key.wrapped, err = s.enc.Encrypt([]byte(passphrase), key.key); err != nil {
+		return nil, err
+	}
+
+	if key.escrowed, err = s.enc.Encrypt(escrowKey, key.key); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
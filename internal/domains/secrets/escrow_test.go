@@ -0,0 +1,258 @@
+package secrets_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/novoseltcev/passkeeper/internal/domains/secrets"
+	"github.com/novoseltcev/passkeeper/internal/domains/secrets/mocks"
+	"github.com/novoseltcev/passkeeper/internal/models"
+	"github.com/novoseltcev/passkeeper/pkg/aes"
+)
+
+// openEscrowed decrypts the data of the secret with its escrowed data key.
+func openEscrowed(t *testing.T, enc secrets.Encryptor, escrowKey []byte, secret *models.Secret) []byte {
+	t.Helper()
+
+	key, err := enc.Decrypt(escrowKey, secret.EscrowKey)
+	require.NoError(t, err)
+
+	data, err := enc.Decrypt(key, secret.Data)
+	require.NoError(t, err)
+
+	return data
+}
+
+func TestService_Escrow(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	hasher := mocks.NewMockHasher(ctrl)
+	enc := aes.New(aes.AES256BitKeyLength)
+	service := secrets.NewService(repo, hasher, enc)
+
+	owner := &models.User{ID: testOwnerID, PassphraseHash: testHash}
+
+	plain, err := enc.Encrypt([]byte(testPassphrase), testContent)
+	require.NoError(t, err)
+
+	dataKey := []byte("0123456789abcdef0123456789abcdef")
+	keyed, err := enc.Encrypt(dataKey, testContent)
+	require.NoError(t, err)
+	wrappedKey, err := enc.Encrypt([]byte(testPassphrase), dataKey)
+	require.NoError(t, err)
+
+	all := []models.Secret{
+		{ID: "plain", Owner: owner, Data: plain},
+		{ID: "keyed", Owner: owner, Data: keyed, Key: wrappedKey},
+	}
+
+	var wrappedEscrow []byte
+
+	escrowed := make(map[models.SecretID][]byte)
+	rekeyed := make(map[models.SecretID]*models.Secret)
+
+	repo.EXPECT().GetOwner(gomock.Any(), testOwnerID).Return(owner, nil)
+	hasher.EXPECT().Compare(testHash, testPassphrase).Return(true, nil)
+	repo.EXPECT().GetAll(gomock.Any(), testOwnerID).Return(all, nil)
+	expectAtomic(repo)
+	repo.EXPECT().
+		SetOwnerEscrowKey(gomock.Any(), testOwnerID, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ models.UserID, key []byte) error {
+			wrappedEscrow = key
+
+			return nil
+		})
+	repo.EXPECT().
+		Rekey(gomock.Any(), gomock.Any(), gomock.Len(0)).
+		DoAndReturn(func(_ context.Context, secret *models.Secret, _ []models.Share) error {
+			rekeyed[secret.ID] = secret
+
+			return nil
+		})
+	repo.EXPECT().
+		SetEscrowKey(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, id models.SecretID, key []byte) error {
+			escrowed[id] = key
+
+			return nil
+		}).
+		Times(2)
+
+	escrowKey, err := service.Escrow(context.Background(), testOwnerID, testPassphrase)
+	require.NoError(t, err)
+	assert.NotEqual(t, []byte(testPassphrase), escrowKey)
+
+	unwrapped, err := enc.Decrypt([]byte(testPassphrase), wrappedEscrow)
+	require.NoError(t, err)
+	assert.Equal(t, escrowKey, unwrapped)
+
+	// the secret encrypted with the passphrase is moved to a data key
+	require.Contains(t, rekeyed, models.SecretID("plain"))
+	assert.NotEmpty(t, rekeyed["plain"].Key)
+
+	for _, secret := range []*models.Secret{rekeyed["plain"], &all[1]} {
+		assert.Equal(t, escrowed[secret.ID], secret.EscrowKey)
+		assert.Equal(t, testContent, openEscrowed(t, enc, escrowKey, secret))
+	}
+}
+
+func TestService_Escrow_Existing(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	hasher := mocks.NewMockHasher(ctrl)
+	enc := aes.New(aes.AES256BitKeyLength)
+	service := secrets.NewService(repo, hasher, enc)
+
+	escrowKey := []byte("0123456789abcdef0123456789abcdef")
+	wrapped, err := enc.Encrypt([]byte(testPassphrase), escrowKey)
+	require.NoError(t, err)
+
+	owner := &models.User{ID: testOwnerID, PassphraseHash: testHash, EscrowKey: wrapped}
+
+	repo.EXPECT().GetOwner(gomock.Any(), testOwnerID).Return(owner, nil)
+	hasher.EXPECT().Compare(testHash, testPassphrase).Return(true, nil)
+	repo.EXPECT().
+		GetAll(gomock.Any(), testOwnerID).
+		Return([]models.Secret{{ID: testID, Owner: owner, EscrowKey: []byte("escrowed")}}, nil)
+	expectAtomic(repo)
+
+	key, err := service.Escrow(context.Background(), testOwnerID, testPassphrase)
+	require.NoError(t, err)
+	assert.Equal(t, escrowKey, key)
+}
+
+func TestService_Escrow_Fails_InvalidPassphrase(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	hasher := mocks.NewMockHasher(ctrl)
+	service := secrets.NewService(repo, hasher, nil)
+
+	repo.EXPECT().GetOwner(gomock.Any(), testOwnerID).Return(&models.User{PassphraseHash: testHash}, nil)
+	hasher.EXPECT().Compare(testHash, testPassphrase).Return(false, nil)
+
+	_, err := service.Escrow(context.Background(), testOwnerID, testPassphrase)
+	assert.ErrorIs(t, err, secrets.ErrInvalidPassphrase)
+}
+
+func TestService_GetEscrowed(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	enc := aes.New(aes.AES256BitKeyLength)
+	escrowKey := []byte("0123456789abcdef0123456789abcdef")
+	dataKey := []byte("fedcba9876543210fedcba9876543210")
+
+	data, err := enc.Encrypt(dataKey, testContent)
+	require.NoError(t, err)
+	escrowed, err := enc.Encrypt(escrowKey, dataKey)
+	require.NoError(t, err)
+
+	newSecret := func() *models.Secret {
+		return &models.Secret{ID: testID, Owner: &models.User{ID: testOwnerID}, Data: data, EscrowKey: escrowed}
+	}
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mocks.NewMockRepository(ctrl)
+		service := secrets.NewService(repo, nil, enc)
+
+		// the escrowed reads are not marked as accessed, so Touch is not expected
+		repo.EXPECT().Get(gomock.Any(), testID).Return(newSecret(), nil)
+
+		secret, err := service.GetEscrowed(context.Background(), testID, testOwnerID, escrowKey)
+		require.NoError(t, err)
+		assert.Equal(t, testContent, []byte(secret.Data))
+	})
+
+	tests := []struct {
+		name   string
+		modify func(secret *models.Secret)
+		err    error
+	}{
+		{
+			name:   "another owner",
+			modify: func(secret *models.Secret) { secret.Owner.ID = "other-id" },
+			err:    secrets.ErrAnotherOwner,
+		},
+		{
+			name:   "organization",
+			modify: func(secret *models.Secret) { secret.Org = &models.Org{ID: "org-id"} },
+			err:    secrets.ErrAnotherOwner,
+		},
+		{
+			name:   "not escrowed",
+			modify: func(secret *models.Secret) { secret.EscrowKey = nil },
+			err:    secrets.ErrNotEscrowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := mocks.NewMockRepository(ctrl)
+			// nothing is decrypted before the ownership is checked
+			service := secrets.NewService(repo, nil, mocks.NewMockEncryptor(ctrl))
+
+			secret := newSecret()
+			tt.modify(secret)
+			repo.EXPECT().Get(gomock.Any(), testID).Return(secret, nil)
+
+			_, err := service.GetEscrowed(context.Background(), testID, testOwnerID, escrowKey)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestService_Create_Escrowed(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	hasher := mocks.NewMockHasher(ctrl)
+	enc := aes.New(aes.AES256BitKeyLength)
+	service := secrets.NewService(repo, hasher, enc)
+
+	escrowKey := []byte("0123456789abcdef0123456789abcdef")
+	wrapped, err := enc.Encrypt([]byte(testPassphrase), escrowKey)
+	require.NoError(t, err)
+
+	owner := &models.User{ID: testOwnerID, PassphraseHash: testHash, EscrowKey: wrapped}
+
+	repo.EXPECT().GetOwner(gomock.Any(), testOwnerID).Return(owner, nil)
+	hasher.EXPECT().Compare(testHash, testPassphrase).Return(true, nil)
+	repo.EXPECT().GetUsage(gomock.Any(), testOwnerID).Return(&secrets.Usage{}, nil).AnyTimes()
+	repo.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, secret *models.Secret) (models.SecretID, error) {
+			key, err := enc.Decrypt([]byte(testPassphrase), secret.Key)
+			require.NoError(t, err)
+
+			data, err := enc.Decrypt(key, secret.Data)
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"content":"text","meta":null}`, string(data))
+			assert.Equal(t, data, openEscrowed(t, enc, escrowKey, secret))
+
+			return testID, nil
+		})
+
+	_, _, err = service.Create(context.Background(), testOwnerID, testPassphrase, testName,
+		&secrets.TextData{Content: "text"}, secrets.Attrs{})
+	require.NoError(t, err)
+}
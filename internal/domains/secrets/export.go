@@ -0,0 +1,254 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/novoseltcev/passkeeper/internal/models"
+	"github.com/novoseltcev/passkeeper/pkg/vaultexport"
+)
+
+type ImportStatus string
+
+const (
+	// ImportCreated is the status of a created secret, or of the one to create on a dry run.
+	ImportCreated ImportStatus = "created"
+	// ImportDuplicate is the status of a secret, which is already in the vault or earlier in the archive.
+	ImportDuplicate ImportStatus = "duplicate"
+	// ImportInvalid is the status of a secret with unknown type or invalid data.
+	ImportInvalid ImportStatus = "invalid"
+	// ImportFailed is the status of a secret, which failed to save or was not saved as another one failed.
+	ImportFailed ImportStatus = "failed"
+)
+
+// ImportResult is the result of a secret of an archive.
+type ImportResult struct {
+	Name   string
+	Type   models.SecretType
+	Status ImportStatus
+	// ID is the created secret, it is empty on dry runs.
+	ID  models.SecretID
+	Err error
+}
+
+func (s *service) Export(ctx context.Context, ownerID models.UserID, passphrase, password string) ([]byte, error) {
+	if _, err := s.loadAndCheckOwner(ctx, ownerID, passphrase); err != nil {
+		return nil, err
+	}
+
+	secrets, err := s.repo.GetAll(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	vault := &vaultexport.Vault{ExportedAt: time.Now().UTC(), Items: make([]vaultexport.Item, len(secrets))}
+
+	for i := range secrets {
+		secret := &secrets[i]
+
+		plain, err := s.open(ctx, secret, passphrase)
+		if err != nil {
+			return nil, err
+		}
+
+		item := vaultexport.Item{
+			Name:        secret.Name,
+			Type:        int(secret.Type),
+			Data:        plain,
+			CreatedAt:   secret.CreatedAt,
+			UpdatedAt:   secret.UpdatedAt,
+			RotateEvery: int64(secret.RotateEvery / time.Second),
+		}
+		if !secret.ExpiresAt.IsZero() {
+			item.ExpiresAt = &secret.ExpiresAt
+		}
+
+		vault.Items[i] = item
+	}
+
+	return vaultexport.Seal(password, vault)
+}
+
+func (s *service) Import(
+	ctx context.Context, ownerID models.UserID, passphrase, password string, archive []byte, dryRun bool,
+) ([]ImportResult, error) {
+	if _, err := s.loadAndCheckOwner(ctx, ownerID, passphrase); err != nil {
+		return nil, err
+	}
+
+	vault, err := vaultexport.Open(password, archive)
+	if err != nil {
+		if errors.Is(err, vaultexport.ErrInvalidPassword) {
+			return nil, ErrInvalidExportPassword
+		}
+
+		return nil, fmt.Errorf("%w: %w", ErrInvalidArchive, err)
+	}
+
+	seen, err := s.importedKeys(ctx, ownerID, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]ImportResult, len(vault.Items))
+	items := make([]BatchItem, 0, len(vault.Items))
+	// indexes are the results of the items of the batch
+	indexes := make([]int, 0, len(vault.Items))
+	valid := true
+
+	for i, item := range vault.Items {
+		results[i] = ImportResult{Name: item.Name, Type: models.SecretType(item.Type), Status: ImportCreated}
+
+		data, err := decodeData(results[i].Type, item.Data)
+		if err != nil {
+			results[i].Status, results[i].Err, valid = ImportInvalid, err, false
+
+			continue
+		}
+
+		key, err := importKey(passphrase, item.Name, data)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := seen[key]; ok {
+			results[i].Status = ImportDuplicate
+
+			continue
+		}
+
+		seen[key] = struct{}{}
+
+		attrs := Attrs{RotateEvery: time.Duration(item.RotateEvery) * time.Second, CreatedAt: item.CreatedAt}
+		if item.ExpiresAt != nil {
+			attrs.ExpiresAt = *item.ExpiresAt
+		}
+
+		items = append(items, BatchItem{Op: BatchOpCreate, Name: item.Name, Data: data, Attrs: attrs})
+		indexes = append(indexes, i)
+	}
+
+	if !valid {
+		// a dry run tells what would be created after the invalid secrets are fixed
+		if !dryRun {
+			for _, i := range indexes {
+				results[i].Status, results[i].Err = ImportFailed, ErrBatchAborted
+			}
+		}
+
+		return results, ErrImportFailed
+	}
+
+	if dryRun || len(items) == 0 {
+		return results, nil
+	}
+
+	batch, err := s.Batch(ctx, ownerID, passphrase, items)
+	if err != nil && !errors.Is(err, ErrBatchFailed) {
+		return nil, err
+	}
+
+	for j, result := range batch {
+		i := indexes[j]
+		results[i].ID, results[i].Err = result.ID, result.Err
+
+		if result.Err != nil {
+			results[i].Status, results[i].ID = ImportFailed, ""
+		}
+	}
+
+	if err != nil {
+		return results, ErrImportFailed
+	}
+
+	return results, nil
+}
+
+// importedKeys returns the keys of the owner's personal secrets to detect the duplicates.
+func (s *service) importedKeys(
+	ctx context.Context, ownerID models.UserID, passphrase string,
+) (map[string]struct{}, error) {
+	secrets, err := s.repo.GetAll(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]struct{}, len(secrets))
+
+	for i := range secrets {
+		secret := &secrets[i]
+
+		plain, err := s.open(ctx, secret, passphrase)
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := decodeData(secret.Type, plain)
+		if err != nil {
+			// the stored data of unknown types can't be a duplicate of an imported one
+			continue
+		}
+
+		key, err := importKey(passphrase, secret.Name, data)
+		if err != nil {
+			return nil, err
+		}
+
+		keys[key] = struct{}{}
+	}
+
+	return keys, nil
+}
+
+// open decrypts the data of the owner's secret with the streamed content of a file.
+func (s *service) open(ctx context.Context, secret *models.Secret, passphrase string) ([]byte, error) {
+	key, err := s.unlock(secret, grant{}, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	encData, err := s.encData(ctx, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	plain, err := s.enc.Decrypt(key, encData)
+	if err != nil || secret.ContentBlobKey == "" {
+		return plain, err
+	}
+
+	return s.inlineContent(ctx, secret, plain)
+}
+
+// importKey is the keyed hash of the name, the type and the data, the secrets with the same key are duplicates.
+//
+// The data is marshaled again, so the same data has the same key regardless of its formatting.
+func importKey(passphrase, name string, data ISecretData) (string, error) {
+	plain, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+
+	return keyedHash(passphrase, fmt.Sprintf("%d\x00%s\x00%s", data.SecretType(), name, plain)), nil
+}
+
+// decodeData unmarshals and validates the decrypted data of the type.
+func decodeData(secretType models.SecretType, plain []byte) (ISecretData, error) {
+	data, ok := NewData(secretType)
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidSecretType, secretType)
+	}
+
+	if err := json.Unmarshal(plain, data); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSecretData, err)
+	}
+
+	if err := validate(data); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
@@ -0,0 +1,154 @@
+package secrets_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/novoseltcev/passkeeper/internal/domains/secrets"
+	"github.com/novoseltcev/passkeeper/internal/domains/secrets/mocks"
+	"github.com/novoseltcev/passkeeper/internal/models"
+	"github.com/novoseltcev/passkeeper/pkg/aes"
+	"github.com/novoseltcev/passkeeper/pkg/vaultexport"
+)
+
+const testExportPassword = "export-password"
+
+// The tests seal the archives with Argon2id, which is heavy, so they don't run in parallel.
+
+func setupExport(t *testing.T) (*mocks.MockRepository, *aes.AES, secrets.Service) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	hasher := mocks.NewMockHasher(ctrl)
+	enc := aes.New(aes.AES256BitKeyLength)
+
+	repo.EXPECT().
+		GetOwner(gomock.Any(), testOwnerID).
+		Return(&models.User{ID: testOwnerID, PassphraseHash: testHash}, nil).
+		AnyTimes()
+	hasher.EXPECT().Compare(testHash, testPassphrase).Return(true, nil).AnyTimes()
+
+	return repo, enc, secrets.NewService(repo, hasher, enc)
+}
+
+func newExportedSecret(t *testing.T, enc *aes.AES, name string, data secrets.ISecretData) models.Secret {
+	t.Helper()
+
+	plain, err := json.Marshal(data)
+	require.NoError(t, err)
+
+	encData, err := enc.Encrypt([]byte(testPassphrase), plain)
+	require.NoError(t, err)
+
+	return models.Secret{
+		ID:        testID,
+		Name:      name,
+		Type:      data.SecretType(),
+		Data:      encData,
+		CreatedAt: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		UpdatedAt: time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestService_Export(t *testing.T) {
+	repo, enc, service := setupExport(t)
+
+	note := newExportedSecret(t, enc, testName, &secrets.TextData{Content: "note", Meta: map[string]any{}})
+	note.RotateEvery = 24 * time.Hour
+	repo.EXPECT().GetAll(gomock.Any(), testOwnerID).Return([]models.Secret{note}, nil)
+
+	archive, err := service.Export(context.Background(), testOwnerID, testPassphrase, testExportPassword)
+	require.NoError(t, err)
+	assert.NotContains(t, string(archive), "note")
+
+	vault, err := vaultexport.Open(testExportPassword, archive)
+	require.NoError(t, err)
+	assert.Equal(t, []vaultexport.Item{{
+		Name:        testName,
+		Type:        int(models.SecretTypeTxt),
+		Data:        json.RawMessage(`{"content":"note","meta":{}}`),
+		CreatedAt:   note.CreatedAt,
+		UpdatedAt:   note.UpdatedAt,
+		RotateEvery: 86400,
+	}}, vault.Items)
+}
+
+func TestService_Import(t *testing.T) {
+	repo, enc, service := setupExport(t)
+
+	existing := newExportedSecret(t, enc, "existing", &secrets.TextData{Content: "existing", Meta: map[string]any{}})
+	repo.EXPECT().GetAll(gomock.Any(), testOwnerID).Return([]models.Secret{existing}, nil).Times(2)
+
+	createdAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	archive, err := vaultexport.Seal(testExportPassword, &vaultexport.Vault{Items: []vaultexport.Item{
+		{Name: "existing", Type: int(models.SecretTypeTxt), Data: json.RawMessage(`{"meta":{},"content":"existing"}`)},
+		{Name: "new", Type: int(models.SecretTypeTxt), Data: json.RawMessage(`{"content":"new"}`), CreatedAt: createdAt},
+		{Name: "new", Type: int(models.SecretTypeTxt), Data: json.RawMessage(`{"content":"new"}`)},
+	}})
+	require.NoError(t, err)
+
+	results, err := service.Import(context.Background(), testOwnerID, testPassphrase, testExportPassword, archive, true)
+	require.NoError(t, err)
+	assert.Equal(t, []secrets.ImportResult{
+		{Name: "existing", Type: models.SecretTypeTxt, Status: secrets.ImportDuplicate},
+		{Name: "new", Type: models.SecretTypeTxt, Status: secrets.ImportCreated},
+		{Name: "new", Type: models.SecretTypeTxt, Status: secrets.ImportDuplicate},
+	}, results)
+
+	expectAtomic(repo)
+	repo.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, secret *models.Secret) (models.SecretID, error) {
+			assert.Equal(t, "new", secret.Name)
+			assert.Equal(t, createdAt, secret.CreatedAt)
+
+			return testID, nil
+		})
+
+	results, err = service.Import(context.Background(), testOwnerID, testPassphrase, testExportPassword, archive, false)
+	require.NoError(t, err)
+	assert.Equal(t, secrets.ImportCreated, results[1].Status)
+	assert.Equal(t, testID, results[1].ID)
+}
+
+func TestService_Import_Fails_Archive(t *testing.T) {
+	_, _, service := setupExport(t)
+
+	archive, err := vaultexport.Seal(testExportPassword, &vaultexport.Vault{})
+	require.NoError(t, err)
+
+	_, err = service.Import(context.Background(), testOwnerID, testPassphrase, "other", archive, true)
+	require.ErrorIs(t, err, secrets.ErrInvalidExportPassword)
+
+	_, err = service.Import(context.Background(), testOwnerID, testPassphrase, testExportPassword, []byte("{}"), true)
+	require.ErrorIs(t, err, secrets.ErrInvalidArchive)
+}
+
+func TestService_Import_Fails_Invalid(t *testing.T) {
+	repo, _, service := setupExport(t)
+
+	repo.EXPECT().GetAll(gomock.Any(), testOwnerID).Return(nil, nil)
+
+	archive, err := vaultexport.Seal(testExportPassword, &vaultexport.Vault{Items: []vaultexport.Item{
+		{Name: "unknown", Type: 100, Data: json.RawMessage(`{}`)},
+		{Name: "card", Type: int(models.SecretTypeCard), Data: json.RawMessage(`{"number":"1234"}`)},
+		{Name: "note", Type: int(models.SecretTypeTxt), Data: json.RawMessage(`{"content":"note"}`)},
+	}})
+	require.NoError(t, err)
+
+	results, err := service.Import(context.Background(), testOwnerID, testPassphrase, testExportPassword, archive, false)
+	require.ErrorIs(t, err, secrets.ErrImportFailed)
+	require.Len(t, results, 3)
+	require.ErrorIs(t, results[0].Err, secrets.ErrInvalidSecretType)
+	require.ErrorIs(t, results[1].Err, secrets.ErrInvalidSecretData)
+	assert.Equal(t, secrets.ImportFailed, results[2].Status)
+	require.ErrorIs(t, results[2].Err, secrets.ErrBatchAborted)
+}
@@ -0,0 +1,253 @@
+package secrets
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/novoseltcev/passkeeper/internal/models"
+	"github.com/novoseltcev/passkeeper/pkg/sealbox"
+)
+
+// contentBlob is the blob with the streamed content of a file, it is empty for the content kept in the data.
+type contentBlob struct {
+	key  string
+	size int64
+}
+
+// putContent streams the content of a file to a new content blob.
+type putContent func() (contentBlob, error)
+
+func (put putContent) run() (contentBlob, error) {
+	if put == nil {
+		return contentBlob{}, nil
+	}
+
+	return put()
+}
+
+func (s *service) CreateFile(
+	ctx context.Context,
+	ownerID models.UserID,
+	passphrase string,
+	name string,
+	data *FileData,
+	content io.Reader,
+	attrs Attrs,
+) (models.SecretID, []Warning, error) {
+	return s.create(ctx, ownerID, passphrase, name, data, attrs, func() (contentBlob, error) {
+		return s.putContent(ctx, data, content)
+	})
+}
+
+func (s *service) UpdateFile(
+	ctx context.Context,
+	id models.SecretID,
+	userID models.UserID,
+	passphrase string,
+	name string,
+	data *FileData,
+	content io.Reader,
+	attrs Attrs,
+) ([]Warning, error) {
+	return s.update(ctx, id, userID, passphrase, name, data, attrs, func() (contentBlob, error) {
+		return s.putContent(ctx, data, content)
+	})
+}
+
+func (s *service) OpenFile(
+	ctx context.Context, id models.SecretID, userID models.UserID, passphrase string,
+) (*FileData, io.ReadCloser, error) {
+	secret, _, key, err := s.unlockAccessible(ctx, id, userID, passphrase)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if secret.Type != models.SecretTypeFile {
+		return nil, nil, ErrInvalidSecretType
+	}
+
+	encData, err := s.encData(ctx, secret)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	plain, err := s.enc.Decrypt(key, encData)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var file FileData
+	if err := json.Unmarshal(plain, &file); err != nil {
+		return nil, nil, err
+	}
+
+	content, err := s.openContent(ctx, secret, &file)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := s.repo.Touch(ctx, id); err != nil {
+		content.Close()
+
+		return nil, nil, err
+	}
+
+	// the content key stays on the server, the content is read with the reader
+	file.Content, file.ContentKey = "", nil
+
+	return &file, content, nil
+}
+
+// putContent encrypts the content of the file with a new content key and streams it to a new content blob.
+//
+// Without a blob store the content is kept in the file data.
+func (s *service) putContent(ctx context.Context, file *FileData, content io.Reader) (contentBlob, error) {
+	if s.quota.MaxSecretBytes > 0 {
+		content = &limitedReader{r: content, n: s.quota.MaxSecretBytes}
+	}
+
+	if s.blobs == nil {
+		plain, err := io.ReadAll(content)
+		if err != nil {
+			return contentBlob{}, err
+		}
+
+		file.Content, file.ContentKey, file.Size = hex.EncodeToString(plain), nil, 0
+
+		return contentBlob{}, nil
+	}
+
+	key := make([]byte, sealbox.KeySize)
+	if _, err := rand.Read(key); err != nil {
+		return contentBlob{}, err
+	}
+
+	plain := &countingReader{r: content}
+	encrypted, pipe := io.Pipe()
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		pipe.CloseWithError(s.encryptContent(key, pipe, plain))
+	}()
+
+	blob := &countingReader{r: encrypted}
+	blobKey := uuid.NewString()
+
+	if err := s.blobs.Put(ctx, blobKey, blob); err != nil {
+		encrypted.CloseWithError(err)
+		<-done
+
+		return contentBlob{}, err
+	}
+
+	<-done
+
+	file.Content, file.ContentKey, file.Size = "", key, plain.n
+
+	return contentBlob{key: blobKey, size: blob.n}, nil
+}
+
+func (s *service) encryptContent(key []byte, dst io.Writer, src io.Reader) error {
+	w, err := s.enc.NewWriter(key, dst)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(w, src); err != nil {
+		return err
+	}
+
+	return w.Close()
+}
+
+// openContent returns a reader of the decrypted content of the file, which is streamed from its content blob.
+//
+// The size of the file is set for the content kept in the data.
+func (s *service) openContent(ctx context.Context, secret *models.Secret, file *FileData) (io.ReadCloser, error) {
+	if len(file.ContentKey) == 0 {
+		file.Size = int64(hex.DecodedLen(len(file.Content)))
+
+		return io.NopCloser(hex.NewDecoder(strings.NewReader(file.Content))), nil
+	}
+
+	if s.blobs == nil {
+		return nil, errNoBlobStore
+	}
+
+	blob, err := s.blobs.Open(ctx, secret.ContentBlobKey)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := s.enc.NewReader(file.ContentKey, blob)
+	if err != nil {
+		blob.Close()
+
+		return nil, err
+	}
+
+	return struct {
+		io.Reader
+		io.Closer
+	}{content, blob}, nil
+}
+
+// inlineContent reads the streamed content of the file into its data, which is decrypted whole,
+// such as the exported one.
+func (s *service) inlineContent(ctx context.Context, secret *models.Secret, plain []byte) ([]byte, error) {
+	var file FileData
+	if err := json.Unmarshal(plain, &file); err != nil {
+		return nil, err
+	}
+
+	content, err := s.openContent(ctx, secret, &file)
+	if err != nil {
+		return nil, err
+	}
+	defer content.Close()
+
+	raw, err := io.ReadAll(content)
+	if err != nil {
+		return nil, err
+	}
+
+	file.Content, file.ContentKey, file.Size = hex.EncodeToString(raw), nil, 0
+
+	return json.Marshal(&file)
+}
+
+// limitedReader fails with ErrSecretTooLarge once more than n bytes are read.
+type limitedReader struct {
+	r io.Reader
+	n int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	if l.n -= int64(n); l.n < 0 {
+		return n, ErrSecretTooLarge
+	}
+
+	return n, err
+}
+
+// countingReader counts the read bytes.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+
+	return n, err
+}
@@ -0,0 +1,257 @@
+package secrets_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/novoseltcev/passkeeper/internal/domains/secrets"
+	"github.com/novoseltcev/passkeeper/internal/domains/secrets/mocks"
+	"github.com/novoseltcev/passkeeper/internal/models"
+	"github.com/novoseltcev/passkeeper/pkg/aes"
+	"github.com/novoseltcev/passkeeper/pkg/blobstore"
+	"github.com/novoseltcev/passkeeper/pkg/vaultexport"
+)
+
+// largeContent spans several chunks of the stream.
+var largeContent = bytes.Repeat([]byte("content "), 3*aes.ChunkSize/8+5)
+
+func setupFiles(
+	t *testing.T, opts ...secrets.Option,
+) (*mocks.MockRepository, *blobstore.FS, string, secrets.Service) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	hasher := mocks.NewMockHasher(ctrl)
+	hasher.EXPECT().Compare(testHash, testPassphrase).Return(true, nil).AnyTimes()
+
+	dir := t.TempDir()
+	blobs, err := blobstore.NewFS(dir)
+	require.NoError(t, err)
+
+	opts = append(opts, secrets.WithBlobStore(blobs))
+
+	return repo, blobs, dir, secrets.NewService(repo, hasher, aes.New(aes.AES256BitKeyLength), opts...)
+}
+
+// createFile creates the file secret with the content and returns the created row.
+func createFile(
+	t *testing.T, repo *mocks.MockRepository, service secrets.Service, content []byte,
+) *models.Secret {
+	t.Helper()
+
+	owner := &models.User{ID: testOwnerID, PassphraseHash: testHash}
+	repo.EXPECT().GetOwner(gomock.Any(), testOwnerID).Return(owner, nil)
+
+	var created *models.Secret
+
+	repo.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, secret *models.Secret) (models.SecretID, error) {
+			created = secret
+
+			return testID, nil
+		})
+
+	_, _, err := service.CreateFile(context.Background(), testOwnerID, testPassphrase, testName,
+		&secrets.FileData{Filename: "file.bin", Meta: map[string]any{}}, bytes.NewReader(content), secrets.Attrs{})
+	require.NoError(t, err)
+
+	return created
+}
+
+func TestService_CreateFile_OpenFile(t *testing.T) {
+	t.Parallel()
+	repo, blobs, _, service := setupFiles(t)
+
+	secret := createFile(t, repo, service, largeContent)
+
+	require.NotEmpty(t, secret.ContentBlobKey)
+	assert.Equal(t, map[string]string{secrets.PreviewFilename: "file.bin"}, secret.Preview)
+	assert.Greater(t, secret.ContentSize, int64(len(largeContent)))
+	assert.Equal(t, secret.ContentSize+int64(len(secret.Data)), secret.Size)
+
+	// the content is kept encrypted in its blob, the data refers to it with the content key only
+	stored, err := blobs.Get(context.Background(), secret.ContentBlobKey)
+	require.NoError(t, err)
+	assert.Len(t, stored, int(secret.ContentSize))
+	assert.NotContains(t, string(stored), "content content")
+
+	repo.EXPECT().Get(gomock.Any(), testID).Return(secret, nil)
+	repo.EXPECT().Touch(gomock.Any(), testID).Return(nil)
+
+	file, content, err := service.OpenFile(context.Background(), testID, testOwnerID, testPassphrase)
+	require.NoError(t, err)
+	t.Cleanup(func() { content.Close() })
+
+	assert.Equal(t, "file.bin", file.Filename)
+	assert.Equal(t, int64(len(largeContent)), file.Size)
+	assert.Empty(t, file.ContentKey)
+
+	got, err := io.ReadAll(content)
+	require.NoError(t, err)
+	assert.Equal(t, largeContent, got)
+}
+
+func TestService_Get_InlinesContent(t *testing.T) {
+	t.Parallel()
+	repo, _, _, service := setupFiles(t)
+
+	secret := createFile(t, repo, service, []byte("test"))
+
+	repo.EXPECT().Get(gomock.Any(), testID).Return(secret, nil)
+	repo.EXPECT().Touch(gomock.Any(), testID).Return(nil)
+
+	got, err := service.Get(context.Background(), testID, testOwnerID, testPassphrase)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"filename":"file.bin","content":"74657374","meta":{}}`, string(got.Data))
+}
+
+func TestService_CreateFile_NoStore(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	hasher := mocks.NewMockHasher(ctrl)
+	hasher.EXPECT().Compare(testHash, testPassphrase).Return(true, nil).AnyTimes()
+	service := secrets.NewService(repo, hasher, aes.New(aes.AES256BitKeyLength))
+
+	secret := createFile(t, repo, service, []byte("test"))
+	assert.Empty(t, secret.ContentBlobKey)
+	assert.Empty(t, secret.BlobKey)
+
+	repo.EXPECT().Get(gomock.Any(), testID).Return(secret, nil)
+	repo.EXPECT().Touch(gomock.Any(), testID).Return(nil)
+
+	file, content, err := service.OpenFile(context.Background(), testID, testOwnerID, testPassphrase)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), file.Size)
+
+	got, err := io.ReadAll(content)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("test"), got)
+}
+
+func TestService_CreateFile_Fails_TooLarge(t *testing.T) {
+	t.Parallel()
+	repo, _, dir, service := setupFiles(t, secrets.WithQuota(secrets.Quota{MaxSecretBytes: aes.ChunkSize}))
+
+	repo.EXPECT().GetOwner(gomock.Any(), testOwnerID).Return(&models.User{PassphraseHash: testHash}, nil)
+
+	_, _, err := service.CreateFile(context.Background(), testOwnerID, testPassphrase, testName,
+		&secrets.FileData{Filename: "file.bin"}, bytes.NewReader(largeContent), secrets.Attrs{})
+	require.ErrorIs(t, err, secrets.ErrSecretTooLarge)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestService_CreateFile_Fails_Create(t *testing.T) {
+	t.Parallel()
+	repo, blobs, _, service := setupFiles(t)
+
+	repo.EXPECT().GetOwner(gomock.Any(), testOwnerID).Return(&models.User{PassphraseHash: testHash}, nil)
+	repo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(models.SecretID(""), assert.AnError)
+
+	_, _, err := service.CreateFile(context.Background(), testOwnerID, testPassphrase, testName,
+		&secrets.FileData{Filename: "file.bin"}, bytes.NewReader(largeContent), secrets.Attrs{})
+	require.ErrorIs(t, err, assert.AnError)
+
+	infos, err := blobs.List(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, infos)
+}
+
+func TestService_UpdateFile_ReplacesContent(t *testing.T) {
+	t.Parallel()
+	repo, blobs, _, service := setupFiles(t)
+
+	secret := createFile(t, repo, service, largeContent)
+	old := secret.ContentBlobKey
+
+	repo.EXPECT().Get(gomock.Any(), testID).Return(secret, nil)
+	repo.EXPECT().
+		Update(gomock.Any(), testID, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ models.SecretID, updated *models.Secret) error {
+			assert.NotEqual(t, old, updated.ContentBlobKey)
+
+			return nil
+		})
+
+	_, err := service.UpdateFile(context.Background(), testID, testOwnerID, testPassphrase, testName,
+		&secrets.FileData{Filename: "file.bin"}, bytes.NewReader([]byte("new")), secrets.Attrs{})
+	require.NoError(t, err)
+
+	_, err = blobs.Get(context.Background(), old)
+	require.ErrorIs(t, err, blobstore.ErrNotFound)
+
+	repo.EXPECT().Get(gomock.Any(), testID).Return(secret, nil)
+	repo.EXPECT().Touch(gomock.Any(), testID).Return(nil)
+
+	_, content, err := service.OpenFile(context.Background(), testID, testOwnerID, testPassphrase)
+	require.NoError(t, err)
+
+	got, err := io.ReadAll(content)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("new"), got)
+}
+
+func TestService_OpenFile_Fails_NotFile(t *testing.T) {
+	t.Parallel()
+	repo, _, _, service := setupFiles(t)
+
+	owner := &models.User{ID: testOwnerID, PassphraseHash: testHash}
+	repo.EXPECT().Get(gomock.Any(), testID).Return(&models.Secret{Type: models.SecretTypeTxt, Owner: owner}, nil)
+
+	_, _, err := service.OpenFile(context.Background(), testID, testOwnerID, testPassphrase)
+	require.ErrorIs(t, err, secrets.ErrInvalidSecretType)
+}
+
+func TestService_Export_InlinesContent(t *testing.T) {
+	t.Parallel()
+	repo, _, _, service := setupFiles(t)
+
+	secret := createFile(t, repo, service, []byte("test"))
+
+	repo.EXPECT().GetOwner(gomock.Any(), testOwnerID).Return(&models.User{PassphraseHash: testHash}, nil)
+	repo.EXPECT().GetAll(gomock.Any(), testOwnerID).Return([]models.Secret{*secret}, nil)
+
+	archive, err := service.Export(context.Background(), testOwnerID, testPassphrase, testExportPassword)
+	require.NoError(t, err)
+
+	vault, err := vaultexport.Open(testExportPassword, archive)
+	require.NoError(t, err)
+	require.Len(t, vault.Items, 1)
+	assert.JSONEq(t, `{"filename":"file.bin","content":"74657374","meta":{}}`, string(vault.Items[0].Data))
+}
+
+func TestService_CollectBlobs_Partial(t *testing.T) {
+	t.Parallel()
+	repo, _, dir, service := setupFiles(t)
+
+	// the temporary files of the interrupted writes are collected once old
+	old := time.Now().Add(-2 * time.Hour)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".tmp-old-1"), []byte("partial"), 0o600))
+	require.NoError(t, os.Chtimes(filepath.Join(dir, ".tmp-old-1"), old, old))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".tmp-young-1"), []byte("partial"), 0o600))
+
+	repo.EXPECT().GetUsedBlobs(gomock.Any(), []string{".tmp-old-1"}).Return(nil, nil)
+
+	collected, err := service.CollectBlobs(context.Background(), time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, 1, collected)
+	assert.NoFileExists(t, filepath.Join(dir, ".tmp-old-1"))
+	assert.FileExists(t, filepath.Join(dir, ".tmp-young-1"))
+}
@@ -42,6 +42,44 @@ func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
 	return m.recorder
 }
 
+// Atomic mocks base method.
+func (m *MockRepository) Atomic(ctx context.Context, fn func(secrets.Repository) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Atomic", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Atomic indicates an expected call of Atomic.
+func (mr *MockRepositoryMockRecorder) Atomic(ctx, fn any) *MockRepositoryAtomicCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Atomic", reflect.TypeOf((*MockRepository)(nil).Atomic), ctx, fn)
+	return &MockRepositoryAtomicCall{Call: call}
+}
+
+// MockRepositoryAtomicCall wrap *gomock.Call
+type MockRepositoryAtomicCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRepositoryAtomicCall) Return(arg0 error) *MockRepositoryAtomicCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRepositoryAtomicCall) Do(f func(context.Context, func(secrets.Repository) error) error) *MockRepositoryAtomicCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRepositoryAtomicCall) DoAndReturn(f func(context.Context, func(secrets.Repository) error) error) *MockRepositoryAtomicCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // Create mocks base method.
 func (m *MockRepository) Create(ctx context.Context, data *models.Secret) (models.SecretID, error) {
 	m.ctrl.T.Helper()
@@ -119,6 +157,44 @@ func (c *MockRepositoryDeleteCall) DoAndReturn(f func(context.Context, models.Se
 	return c
 }
 
+// DeleteShare mocks base method.
+func (m *MockRepository) DeleteShare(ctx context.Context, id models.SecretID, recipientID models.UserID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteShare", ctx, id, recipientID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteShare indicates an expected call of DeleteShare.
+func (mr *MockRepositoryMockRecorder) DeleteShare(ctx, id, recipientID any) *MockRepositoryDeleteShareCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteShare", reflect.TypeOf((*MockRepository)(nil).DeleteShare), ctx, id, recipientID)
+	return &MockRepositoryDeleteShareCall{Call: call}
+}
+
+// MockRepositoryDeleteShareCall wrap *gomock.Call
+type MockRepositoryDeleteShareCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRepositoryDeleteShareCall) Return(arg0 error) *MockRepositoryDeleteShareCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRepositoryDeleteShareCall) Do(f func(context.Context, models.SecretID, models.UserID) error) *MockRepositoryDeleteShareCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRepositoryDeleteShareCall) DoAndReturn(f func(context.Context, models.SecretID, models.UserID) error) *MockRepositoryDeleteShareCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // Get mocks base method.
 func (m *MockRepository) Get(ctx context.Context, id models.SecretID) (*models.Secret, error) {
 	m.ctrl.T.Helper()
@@ -158,6 +234,240 @@ func (c *MockRepositoryGetCall) DoAndReturn(f func(context.Context, models.Secre
 	return c
 }
 
+// GetAll mocks base method.
+func (m *MockRepository) GetAll(ctx context.Context, ownerID models.UserID) ([]models.Secret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, ownerID)
+	ret0, _ := ret[0].([]models.Secret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockRepositoryMockRecorder) GetAll(ctx, ownerID any) *MockRepositoryGetAllCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockRepository)(nil).GetAll), ctx, ownerID)
+	return &MockRepositoryGetAllCall{Call: call}
+}
+
+// MockRepositoryGetAllCall wrap *gomock.Call
+type MockRepositoryGetAllCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRepositoryGetAllCall) Return(arg0 []models.Secret, arg1 error) *MockRepositoryGetAllCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRepositoryGetAllCall) Do(f func(context.Context, models.UserID) ([]models.Secret, error)) *MockRepositoryGetAllCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRepositoryGetAllCall) DoAndReturn(f func(context.Context, models.UserID) ([]models.Secret, error)) *MockRepositoryGetAllCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetByURITokens mocks base method.
+func (m *MockRepository) GetByURITokens(ctx context.Context, ownerID models.UserID, tokens []string) ([]models.Secret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByURITokens", ctx, ownerID, tokens)
+	ret0, _ := ret[0].([]models.Secret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByURITokens indicates an expected call of GetByURITokens.
+func (mr *MockRepositoryMockRecorder) GetByURITokens(ctx, ownerID, tokens any) *MockRepositoryGetByURITokensCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByURITokens", reflect.TypeOf((*MockRepository)(nil).GetByURITokens), ctx, ownerID, tokens)
+	return &MockRepositoryGetByURITokensCall{Call: call}
+}
+
+// MockRepositoryGetByURITokensCall wrap *gomock.Call
+type MockRepositoryGetByURITokensCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRepositoryGetByURITokensCall) Return(arg0 []models.Secret, arg1 error) *MockRepositoryGetByURITokensCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRepositoryGetByURITokensCall) Do(f func(context.Context, models.UserID, []string) ([]models.Secret, error)) *MockRepositoryGetByURITokensCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRepositoryGetByURITokensCall) DoAndReturn(f func(context.Context, models.UserID, []string) ([]models.Secret, error)) *MockRepositoryGetByURITokensCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetDue mocks base method.
+func (m *MockRepository) GetDue(ctx context.Context, ownerID models.UserID) ([]models.Secret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDue", ctx, ownerID)
+	ret0, _ := ret[0].([]models.Secret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDue indicates an expected call of GetDue.
+func (mr *MockRepositoryMockRecorder) GetDue(ctx, ownerID any) *MockRepositoryGetDueCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDue", reflect.TypeOf((*MockRepository)(nil).GetDue), ctx, ownerID)
+	return &MockRepositoryGetDueCall{Call: call}
+}
+
+// MockRepositoryGetDueCall wrap *gomock.Call
+type MockRepositoryGetDueCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRepositoryGetDueCall) Return(arg0 []models.Secret, arg1 error) *MockRepositoryGetDueCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRepositoryGetDueCall) Do(f func(context.Context, models.UserID) ([]models.Secret, error)) *MockRepositoryGetDueCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRepositoryGetDueCall) DoAndReturn(f func(context.Context, models.UserID) ([]models.Secret, error)) *MockRepositoryGetDueCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetDueToNotify mocks base method.
+func (m *MockRepository) GetDueToNotify(ctx context.Context) ([]models.Secret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueToNotify", ctx)
+	ret0, _ := ret[0].([]models.Secret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueToNotify indicates an expected call of GetDueToNotify.
+func (mr *MockRepositoryMockRecorder) GetDueToNotify(ctx any) *MockRepositoryGetDueToNotifyCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueToNotify", reflect.TypeOf((*MockRepository)(nil).GetDueToNotify), ctx)
+	return &MockRepositoryGetDueToNotifyCall{Call: call}
+}
+
+// MockRepositoryGetDueToNotifyCall wrap *gomock.Call
+type MockRepositoryGetDueToNotifyCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRepositoryGetDueToNotifyCall) Return(arg0 []models.Secret, arg1 error) *MockRepositoryGetDueToNotifyCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRepositoryGetDueToNotifyCall) Do(f func(context.Context) ([]models.Secret, error)) *MockRepositoryGetDueToNotifyCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRepositoryGetDueToNotifyCall) DoAndReturn(f func(context.Context) ([]models.Secret, error)) *MockRepositoryGetDueToNotifyCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetMember mocks base method.
+func (m *MockRepository) GetMember(ctx context.Context, orgID models.OrgID, userID models.UserID) (*models.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMember", ctx, orgID, userID)
+	ret0, _ := ret[0].(*models.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMember indicates an expected call of GetMember.
+func (mr *MockRepositoryMockRecorder) GetMember(ctx, orgID, userID any) *MockRepositoryGetMemberCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMember", reflect.TypeOf((*MockRepository)(nil).GetMember), ctx, orgID, userID)
+	return &MockRepositoryGetMemberCall{Call: call}
+}
+
+// MockRepositoryGetMemberCall wrap *gomock.Call
+type MockRepositoryGetMemberCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRepositoryGetMemberCall) Return(arg0 *models.Member, arg1 error) *MockRepositoryGetMemberCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRepositoryGetMemberCall) Do(f func(context.Context, models.OrgID, models.UserID) (*models.Member, error)) *MockRepositoryGetMemberCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRepositoryGetMemberCall) DoAndReturn(f func(context.Context, models.OrgID, models.UserID) (*models.Member, error)) *MockRepositoryGetMemberCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetOrgPage mocks base method.
+func (m *MockRepository) GetOrgPage(ctx context.Context, orgID models.OrgID, limit, offset uint64) (*secrets.Page[models.Secret], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrgPage", ctx, orgID, limit, offset)
+	ret0, _ := ret[0].(*secrets.Page[models.Secret])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrgPage indicates an expected call of GetOrgPage.
+func (mr *MockRepositoryMockRecorder) GetOrgPage(ctx, orgID, limit, offset any) *MockRepositoryGetOrgPageCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrgPage", reflect.TypeOf((*MockRepository)(nil).GetOrgPage), ctx, orgID, limit, offset)
+	return &MockRepositoryGetOrgPageCall{Call: call}
+}
+
+// MockRepositoryGetOrgPageCall wrap *gomock.Call
+type MockRepositoryGetOrgPageCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRepositoryGetOrgPageCall) Return(arg0 *secrets.Page[models.Secret], arg1 error) *MockRepositoryGetOrgPageCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRepositoryGetOrgPageCall) Do(f func(context.Context, models.OrgID, uint64, uint64) (*secrets.Page[models.Secret], error)) *MockRepositoryGetOrgPageCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRepositoryGetOrgPageCall) DoAndReturn(f func(context.Context, models.OrgID, uint64, uint64) (*secrets.Page[models.Secret], error)) *MockRepositoryGetOrgPageCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // GetOwner mocks base method.
 func (m *MockRepository) GetOwner(ctx context.Context, ownerID models.UserID) (*models.User, error) {
 	m.ctrl.T.Helper()
@@ -236,6 +546,545 @@ func (c *MockRepositoryGetPageCall) DoAndReturn(f func(context.Context, models.U
 	return c
 }
 
+// GetShare mocks base method.
+func (m *MockRepository) GetShare(ctx context.Context, id models.SecretID, recipientID models.UserID) (*models.Share, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShare", ctx, id, recipientID)
+	ret0, _ := ret[0].(*models.Share)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShare indicates an expected call of GetShare.
+func (mr *MockRepositoryMockRecorder) GetShare(ctx, id, recipientID any) *MockRepositoryGetShareCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShare", reflect.TypeOf((*MockRepository)(nil).GetShare), ctx, id, recipientID)
+	return &MockRepositoryGetShareCall{Call: call}
+}
+
+// MockRepositoryGetShareCall wrap *gomock.Call
+type MockRepositoryGetShareCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRepositoryGetShareCall) Return(arg0 *models.Share, arg1 error) *MockRepositoryGetShareCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRepositoryGetShareCall) Do(f func(context.Context, models.SecretID, models.UserID) (*models.Share, error)) *MockRepositoryGetShareCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRepositoryGetShareCall) DoAndReturn(f func(context.Context, models.SecretID, models.UserID) (*models.Share, error)) *MockRepositoryGetShareCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetSharedWith mocks base method.
+func (m *MockRepository) GetSharedWith(ctx context.Context, recipientID models.UserID) ([]models.Share, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSharedWith", ctx, recipientID)
+	ret0, _ := ret[0].([]models.Share)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSharedWith indicates an expected call of GetSharedWith.
+func (mr *MockRepositoryMockRecorder) GetSharedWith(ctx, recipientID any) *MockRepositoryGetSharedWithCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSharedWith", reflect.TypeOf((*MockRepository)(nil).GetSharedWith), ctx, recipientID)
+	return &MockRepositoryGetSharedWithCall{Call: call}
+}
+
+// MockRepositoryGetSharedWithCall wrap *gomock.Call
+type MockRepositoryGetSharedWithCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRepositoryGetSharedWithCall) Return(arg0 []models.Share, arg1 error) *MockRepositoryGetSharedWithCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRepositoryGetSharedWithCall) Do(f func(context.Context, models.UserID) ([]models.Share, error)) *MockRepositoryGetSharedWithCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRepositoryGetSharedWithCall) DoAndReturn(f func(context.Context, models.UserID) ([]models.Share, error)) *MockRepositoryGetSharedWithCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetShares mocks base method.
+func (m *MockRepository) GetShares(ctx context.Context, id models.SecretID) ([]models.Share, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShares", ctx, id)
+	ret0, _ := ret[0].([]models.Share)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShares indicates an expected call of GetShares.
+func (mr *MockRepositoryMockRecorder) GetShares(ctx, id any) *MockRepositoryGetSharesCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShares", reflect.TypeOf((*MockRepository)(nil).GetShares), ctx, id)
+	return &MockRepositoryGetSharesCall{Call: call}
+}
+
+// MockRepositoryGetSharesCall wrap *gomock.Call
+type MockRepositoryGetSharesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRepositoryGetSharesCall) Return(arg0 []models.Share, arg1 error) *MockRepositoryGetSharesCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRepositoryGetSharesCall) Do(f func(context.Context, models.SecretID) ([]models.Share, error)) *MockRepositoryGetSharesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRepositoryGetSharesCall) DoAndReturn(f func(context.Context, models.SecretID) ([]models.Share, error)) *MockRepositoryGetSharesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetTemplate mocks base method.
+func (m *MockRepository) GetTemplate(ctx context.Context, id models.TemplateID) (*models.Template, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplate", ctx, id)
+	ret0, _ := ret[0].(*models.Template)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTemplate indicates an expected call of GetTemplate.
+func (mr *MockRepositoryMockRecorder) GetTemplate(ctx, id any) *MockRepositoryGetTemplateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplate", reflect.TypeOf((*MockRepository)(nil).GetTemplate), ctx, id)
+	return &MockRepositoryGetTemplateCall{Call: call}
+}
+
+// MockRepositoryGetTemplateCall wrap *gomock.Call
+type MockRepositoryGetTemplateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRepositoryGetTemplateCall) Return(arg0 *models.Template, arg1 error) *MockRepositoryGetTemplateCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRepositoryGetTemplateCall) Do(f func(context.Context, models.TemplateID) (*models.Template, error)) *MockRepositoryGetTemplateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRepositoryGetTemplateCall) DoAndReturn(f func(context.Context, models.TemplateID) (*models.Template, error)) *MockRepositoryGetTemplateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetUsage mocks base method.
+func (m *MockRepository) GetUsage(ctx context.Context, ownerID models.UserID) (*secrets.Usage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsage", ctx, ownerID)
+	ret0, _ := ret[0].(*secrets.Usage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsage indicates an expected call of GetUsage.
+func (mr *MockRepositoryMockRecorder) GetUsage(ctx, ownerID any) *MockRepositoryGetUsageCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsage", reflect.TypeOf((*MockRepository)(nil).GetUsage), ctx, ownerID)
+	return &MockRepositoryGetUsageCall{Call: call}
+}
+
+// MockRepositoryGetUsageCall wrap *gomock.Call
+type MockRepositoryGetUsageCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRepositoryGetUsageCall) Return(arg0 *secrets.Usage, arg1 error) *MockRepositoryGetUsageCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRepositoryGetUsageCall) Do(f func(context.Context, models.UserID) (*secrets.Usage, error)) *MockRepositoryGetUsageCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRepositoryGetUsageCall) DoAndReturn(f func(context.Context, models.UserID) (*secrets.Usage, error)) *MockRepositoryGetUsageCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetUsedBlobs mocks base method.
+func (m *MockRepository) GetUsedBlobs(ctx context.Context, keys []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsedBlobs", ctx, keys)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsedBlobs indicates an expected call of GetUsedBlobs.
+func (mr *MockRepositoryMockRecorder) GetUsedBlobs(ctx, keys any) *MockRepositoryGetUsedBlobsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsedBlobs", reflect.TypeOf((*MockRepository)(nil).GetUsedBlobs), ctx, keys)
+	return &MockRepositoryGetUsedBlobsCall{Call: call}
+}
+
+// MockRepositoryGetUsedBlobsCall wrap *gomock.Call
+type MockRepositoryGetUsedBlobsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRepositoryGetUsedBlobsCall) Return(arg0 []string, arg1 error) *MockRepositoryGetUsedBlobsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRepositoryGetUsedBlobsCall) Do(f func(context.Context, []string) ([]string, error)) *MockRepositoryGetUsedBlobsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRepositoryGetUsedBlobsCall) DoAndReturn(f func(context.Context, []string) ([]string, error)) *MockRepositoryGetUsedBlobsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetUserByLogin mocks base method.
+func (m *MockRepository) GetUserByLogin(ctx context.Context, login string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByLogin", ctx, login)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByLogin indicates an expected call of GetUserByLogin.
+func (mr *MockRepositoryMockRecorder) GetUserByLogin(ctx, login any) *MockRepositoryGetUserByLoginCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByLogin", reflect.TypeOf((*MockRepository)(nil).GetUserByLogin), ctx, login)
+	return &MockRepositoryGetUserByLoginCall{Call: call}
+}
+
+// MockRepositoryGetUserByLoginCall wrap *gomock.Call
+type MockRepositoryGetUserByLoginCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRepositoryGetUserByLoginCall) Return(arg0 *models.User, arg1 error) *MockRepositoryGetUserByLoginCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRepositoryGetUserByLoginCall) Do(f func(context.Context, string) (*models.User, error)) *MockRepositoryGetUserByLoginCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRepositoryGetUserByLoginCall) DoAndReturn(f func(context.Context, string) (*models.User, error)) *MockRepositoryGetUserByLoginCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// LockOwner mocks base method.
+func (m *MockRepository) LockOwner(ctx context.Context, ownerID models.UserID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockOwner", ctx, ownerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockOwner indicates an expected call of LockOwner.
+func (mr *MockRepositoryMockRecorder) LockOwner(ctx, ownerID any) *MockRepositoryLockOwnerCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockOwner", reflect.TypeOf((*MockRepository)(nil).LockOwner), ctx, ownerID)
+	return &MockRepositoryLockOwnerCall{Call: call}
+}
+
+// MockRepositoryLockOwnerCall wrap *gomock.Call
+type MockRepositoryLockOwnerCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRepositoryLockOwnerCall) Return(arg0 error) *MockRepositoryLockOwnerCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRepositoryLockOwnerCall) Do(f func(context.Context, models.UserID) error) *MockRepositoryLockOwnerCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRepositoryLockOwnerCall) DoAndReturn(f func(context.Context, models.UserID) error) *MockRepositoryLockOwnerCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MarkNotified mocks base method.
+func (m *MockRepository) MarkNotified(ctx context.Context, id models.SecretID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkNotified", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkNotified indicates an expected call of MarkNotified.
+func (mr *MockRepositoryMockRecorder) MarkNotified(ctx, id any) *MockRepositoryMarkNotifiedCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotified", reflect.TypeOf((*MockRepository)(nil).MarkNotified), ctx, id)
+	return &MockRepositoryMarkNotifiedCall{Call: call}
+}
+
+// MockRepositoryMarkNotifiedCall wrap *gomock.Call
+type MockRepositoryMarkNotifiedCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRepositoryMarkNotifiedCall) Return(arg0 error) *MockRepositoryMarkNotifiedCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRepositoryMarkNotifiedCall) Do(f func(context.Context, models.SecretID) error) *MockRepositoryMarkNotifiedCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRepositoryMarkNotifiedCall) DoAndReturn(f func(context.Context, models.SecretID) error) *MockRepositoryMarkNotifiedCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Rekey mocks base method.
+func (m *MockRepository) Rekey(ctx context.Context, secret *models.Secret, shares []models.Share) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rekey", ctx, secret, shares)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Rekey indicates an expected call of Rekey.
+func (mr *MockRepositoryMockRecorder) Rekey(ctx, secret, shares any) *MockRepositoryRekeyCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rekey", reflect.TypeOf((*MockRepository)(nil).Rekey), ctx, secret, shares)
+	return &MockRepositoryRekeyCall{Call: call}
+}
+
+// MockRepositoryRekeyCall wrap *gomock.Call
+type MockRepositoryRekeyCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRepositoryRekeyCall) Return(arg0 error) *MockRepositoryRekeyCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRepositoryRekeyCall) Do(f func(context.Context, *models.Secret, []models.Share) error) *MockRepositoryRekeyCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRepositoryRekeyCall) DoAndReturn(f func(context.Context, *models.Secret, []models.Share) error) *MockRepositoryRekeyCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// SaveShare mocks base method.
+func (m *MockRepository) SaveShare(ctx context.Context, share *models.Share) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveShare", ctx, share)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveShare indicates an expected call of SaveShare.
+func (mr *MockRepositoryMockRecorder) SaveShare(ctx, share any) *MockRepositorySaveShareCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveShare", reflect.TypeOf((*MockRepository)(nil).SaveShare), ctx, share)
+	return &MockRepositorySaveShareCall{Call: call}
+}
+
+// MockRepositorySaveShareCall wrap *gomock.Call
+type MockRepositorySaveShareCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRepositorySaveShareCall) Return(arg0 error) *MockRepositorySaveShareCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRepositorySaveShareCall) Do(f func(context.Context, *models.Share) error) *MockRepositorySaveShareCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRepositorySaveShareCall) DoAndReturn(f func(context.Context, *models.Share) error) *MockRepositorySaveShareCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// SetEscrowKey mocks base method.
+func (m *MockRepository) SetEscrowKey(ctx context.Context, id models.SecretID, key []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetEscrowKey", ctx, id, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetEscrowKey indicates an expected call of SetEscrowKey.
+func (mr *MockRepositoryMockRecorder) SetEscrowKey(ctx, id, key any) *MockRepositorySetEscrowKeyCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetEscrowKey", reflect.TypeOf((*MockRepository)(nil).SetEscrowKey), ctx, id, key)
+	return &MockRepositorySetEscrowKeyCall{Call: call}
+}
+
+// MockRepositorySetEscrowKeyCall wrap *gomock.Call
+type MockRepositorySetEscrowKeyCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRepositorySetEscrowKeyCall) Return(arg0 error) *MockRepositorySetEscrowKeyCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRepositorySetEscrowKeyCall) Do(f func(context.Context, models.SecretID, []byte) error) *MockRepositorySetEscrowKeyCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRepositorySetEscrowKeyCall) DoAndReturn(f func(context.Context, models.SecretID, []byte) error) *MockRepositorySetEscrowKeyCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// SetOwnerEscrowKey mocks base method.
+func (m *MockRepository) SetOwnerEscrowKey(ctx context.Context, ownerID models.UserID, key []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetOwnerEscrowKey", ctx, ownerID, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetOwnerEscrowKey indicates an expected call of SetOwnerEscrowKey.
+func (mr *MockRepositoryMockRecorder) SetOwnerEscrowKey(ctx, ownerID, key any) *MockRepositorySetOwnerEscrowKeyCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOwnerEscrowKey", reflect.TypeOf((*MockRepository)(nil).SetOwnerEscrowKey), ctx, ownerID, key)
+	return &MockRepositorySetOwnerEscrowKeyCall{Call: call}
+}
+
+// MockRepositorySetOwnerEscrowKeyCall wrap *gomock.Call
+type MockRepositorySetOwnerEscrowKeyCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRepositorySetOwnerEscrowKeyCall) Return(arg0 error) *MockRepositorySetOwnerEscrowKeyCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRepositorySetOwnerEscrowKeyCall) Do(f func(context.Context, models.UserID, []byte) error) *MockRepositorySetOwnerEscrowKeyCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRepositorySetOwnerEscrowKeyCall) DoAndReturn(f func(context.Context, models.UserID, []byte) error) *MockRepositorySetOwnerEscrowKeyCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Touch mocks base method.
+func (m *MockRepository) Touch(ctx context.Context, id models.SecretID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Touch", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Touch indicates an expected call of Touch.
+func (mr *MockRepositoryMockRecorder) Touch(ctx, id any) *MockRepositoryTouchCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Touch", reflect.TypeOf((*MockRepository)(nil).Touch), ctx, id)
+	return &MockRepositoryTouchCall{Call: call}
+}
+
+// MockRepositoryTouchCall wrap *gomock.Call
+type MockRepositoryTouchCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRepositoryTouchCall) Return(arg0 error) *MockRepositoryTouchCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRepositoryTouchCall) Do(f func(context.Context, models.SecretID) error) *MockRepositoryTouchCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRepositoryTouchCall) DoAndReturn(f func(context.Context, models.SecretID) error) *MockRepositoryTouchCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // Update mocks base method.
 func (m *MockRepository) Update(ctx context.Context, id models.SecretID, data *models.Secret) error {
 	m.ctrl.T.Helper()
@@ -11,10 +11,13 @@ package mocks
 
 import (
 	context "context"
+	io "io"
 	reflect "reflect"
+	time "time"
 
 	secrets "github.com/novoseltcev/passkeeper/internal/domains/secrets"
 	models "github.com/novoseltcev/passkeeper/internal/models"
+	blobstore "github.com/novoseltcev/passkeeper/pkg/blobstore"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -80,197 +83,1468 @@ func (c *MockISecretDataSecretTypeCall) DoAndReturn(f func() models.SecretType)
 	return c
 }
 
-// MockService is a mock of Service interface.
-type MockService struct {
+// MockValidator is a mock of Validator interface.
+type MockValidator struct {
 	ctrl     *gomock.Controller
-	recorder *MockServiceMockRecorder
+	recorder *MockValidatorMockRecorder
+	isgomock struct{}
+}
+
+// MockValidatorMockRecorder is the mock recorder for MockValidator.
+type MockValidatorMockRecorder struct {
+	mock *MockValidator
+}
+
+// NewMockValidator creates a new mock instance.
+func NewMockValidator(ctrl *gomock.Controller) *MockValidator {
+	mock := &MockValidator{ctrl: ctrl}
+	mock.recorder = &MockValidatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockValidator) EXPECT() *MockValidatorMockRecorder {
+	return m.recorder
+}
+
+// Validate mocks base method.
+func (m *MockValidator) Validate() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Validate")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Validate indicates an expected call of Validate.
+func (mr *MockValidatorMockRecorder) Validate() *MockValidatorValidateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validate", reflect.TypeOf((*MockValidator)(nil).Validate))
+	return &MockValidatorValidateCall{Call: call}
+}
+
+// MockValidatorValidateCall wrap *gomock.Call
+type MockValidatorValidateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockValidatorValidateCall) Return(arg0 error) *MockValidatorValidateCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockValidatorValidateCall) Do(f func() error) *MockValidatorValidateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockValidatorValidateCall) DoAndReturn(f func() error) *MockValidatorValidateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockFingerprinter is a mock of Fingerprinter interface.
+type MockFingerprinter struct {
+	ctrl     *gomock.Controller
+	recorder *MockFingerprinterMockRecorder
+	isgomock struct{}
+}
+
+// MockFingerprinterMockRecorder is the mock recorder for MockFingerprinter.
+type MockFingerprinterMockRecorder struct {
+	mock *MockFingerprinter
+}
+
+// NewMockFingerprinter creates a new mock instance.
+func NewMockFingerprinter(ctrl *gomock.Controller) *MockFingerprinter {
+	mock := &MockFingerprinter{ctrl: ctrl}
+	mock.recorder = &MockFingerprinterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFingerprinter) EXPECT() *MockFingerprinterMockRecorder {
+	return m.recorder
+}
+
+// Fingerprint mocks base method.
+func (m *MockFingerprinter) Fingerprint() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Fingerprint")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Fingerprint indicates an expected call of Fingerprint.
+func (mr *MockFingerprinterMockRecorder) Fingerprint() *MockFingerprinterFingerprintCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fingerprint", reflect.TypeOf((*MockFingerprinter)(nil).Fingerprint))
+	return &MockFingerprinterFingerprintCall{Call: call}
+}
+
+// MockFingerprinterFingerprintCall wrap *gomock.Call
+type MockFingerprinterFingerprintCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockFingerprinterFingerprintCall) Return(arg0 string) *MockFingerprinterFingerprintCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockFingerprinterFingerprintCall) Do(f func() string) *MockFingerprinterFingerprintCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockFingerprinterFingerprintCall) DoAndReturn(f func() string) *MockFingerprinterFingerprintCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockPreviewer is a mock of Previewer interface.
+type MockPreviewer struct {
+	ctrl     *gomock.Controller
+	recorder *MockPreviewerMockRecorder
+	isgomock struct{}
+}
+
+// MockPreviewerMockRecorder is the mock recorder for MockPreviewer.
+type MockPreviewerMockRecorder struct {
+	mock *MockPreviewer
+}
+
+// NewMockPreviewer creates a new mock instance.
+func NewMockPreviewer(ctrl *gomock.Controller) *MockPreviewer {
+	mock := &MockPreviewer{ctrl: ctrl}
+	mock.recorder = &MockPreviewerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPreviewer) EXPECT() *MockPreviewerMockRecorder {
+	return m.recorder
+}
+
+// Preview mocks base method.
+func (m *MockPreviewer) Preview() map[string]string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Preview")
+	ret0, _ := ret[0].(map[string]string)
+	return ret0
+}
+
+// Preview indicates an expected call of Preview.
+func (mr *MockPreviewerMockRecorder) Preview() *MockPreviewerPreviewCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Preview", reflect.TypeOf((*MockPreviewer)(nil).Preview))
+	return &MockPreviewerPreviewCall{Call: call}
+}
+
+// MockPreviewerPreviewCall wrap *gomock.Call
+type MockPreviewerPreviewCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockPreviewerPreviewCall) Return(arg0 map[string]string) *MockPreviewerPreviewCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockPreviewerPreviewCall) Do(f func() map[string]string) *MockPreviewerPreviewCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockPreviewerPreviewCall) DoAndReturn(f func() map[string]string) *MockPreviewerPreviewCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockBreachChecker is a mock of BreachChecker interface.
+type MockBreachChecker struct {
+	ctrl     *gomock.Controller
+	recorder *MockBreachCheckerMockRecorder
+	isgomock struct{}
+}
+
+// MockBreachCheckerMockRecorder is the mock recorder for MockBreachChecker.
+type MockBreachCheckerMockRecorder struct {
+	mock *MockBreachChecker
+}
+
+// NewMockBreachChecker creates a new mock instance.
+func NewMockBreachChecker(ctrl *gomock.Controller) *MockBreachChecker {
+	mock := &MockBreachChecker{ctrl: ctrl}
+	mock.recorder = &MockBreachCheckerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBreachChecker) EXPECT() *MockBreachCheckerMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockBreachChecker) Count(password string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", password)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockBreachCheckerMockRecorder) Count(password any) *MockBreachCheckerCountCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockBreachChecker)(nil).Count), password)
+	return &MockBreachCheckerCountCall{Call: call}
+}
+
+// MockBreachCheckerCountCall wrap *gomock.Call
+type MockBreachCheckerCountCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockBreachCheckerCountCall) Return(arg0 int, arg1 error) *MockBreachCheckerCountCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockBreachCheckerCountCall) Do(f func(string) (int, error)) *MockBreachCheckerCountCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockBreachCheckerCountCall) DoAndReturn(f func(string) (int, error)) *MockBreachCheckerCountCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockNotifier is a mock of Notifier interface.
+type MockNotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotifierMockRecorder
+	isgomock struct{}
+}
+
+// MockNotifierMockRecorder is the mock recorder for MockNotifier.
+type MockNotifierMockRecorder struct {
+	mock *MockNotifier
+}
+
+// NewMockNotifier creates a new mock instance.
+func NewMockNotifier(ctrl *gomock.Controller) *MockNotifier {
+	mock := &MockNotifier{ctrl: ctrl}
+	mock.recorder = &MockNotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotifier) EXPECT() *MockNotifierMockRecorder {
+	return m.recorder
+}
+
+// Notify mocks base method.
+func (m *MockNotifier) Notify(ctx context.Context, due secrets.DueSecret) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Notify", ctx, due)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Notify indicates an expected call of Notify.
+func (mr *MockNotifierMockRecorder) Notify(ctx, due any) *MockNotifierNotifyCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notify", reflect.TypeOf((*MockNotifier)(nil).Notify), ctx, due)
+	return &MockNotifierNotifyCall{Call: call}
+}
+
+// MockNotifierNotifyCall wrap *gomock.Call
+type MockNotifierNotifyCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockNotifierNotifyCall) Return(arg0 error) *MockNotifierNotifyCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockNotifierNotifyCall) Do(f func(context.Context, secrets.DueSecret) error) *MockNotifierNotifyCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockNotifierNotifyCall) DoAndReturn(f func(context.Context, secrets.DueSecret) error) *MockNotifierNotifyCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockBlobStore is a mock of BlobStore interface.
+type MockBlobStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockBlobStoreMockRecorder
 	isgomock struct{}
 }
 
-// MockServiceMockRecorder is the mock recorder for MockService.
-type MockServiceMockRecorder struct {
-	mock *MockService
+// MockBlobStoreMockRecorder is the mock recorder for MockBlobStore.
+type MockBlobStoreMockRecorder struct {
+	mock *MockBlobStore
+}
+
+// NewMockBlobStore creates a new mock instance.
+func NewMockBlobStore(ctrl *gomock.Controller) *MockBlobStore {
+	mock := &MockBlobStore{ctrl: ctrl}
+	mock.recorder = &MockBlobStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBlobStore) EXPECT() *MockBlobStoreMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockBlobStore) Delete(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockBlobStoreMockRecorder) Delete(ctx, key any) *MockBlobStoreDeleteCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockBlobStore)(nil).Delete), ctx, key)
+	return &MockBlobStoreDeleteCall{Call: call}
+}
+
+// MockBlobStoreDeleteCall wrap *gomock.Call
+type MockBlobStoreDeleteCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockBlobStoreDeleteCall) Return(arg0 error) *MockBlobStoreDeleteCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockBlobStoreDeleteCall) Do(f func(context.Context, string) error) *MockBlobStoreDeleteCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockBlobStoreDeleteCall) DoAndReturn(f func(context.Context, string) error) *MockBlobStoreDeleteCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Get mocks base method.
+func (m *MockBlobStore) Get(ctx context.Context, key string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, key)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockBlobStoreMockRecorder) Get(ctx, key any) *MockBlobStoreGetCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockBlobStore)(nil).Get), ctx, key)
+	return &MockBlobStoreGetCall{Call: call}
+}
+
+// MockBlobStoreGetCall wrap *gomock.Call
+type MockBlobStoreGetCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockBlobStoreGetCall) Return(arg0 []byte, arg1 error) *MockBlobStoreGetCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockBlobStoreGetCall) Do(f func(context.Context, string) ([]byte, error)) *MockBlobStoreGetCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockBlobStoreGetCall) DoAndReturn(f func(context.Context, string) ([]byte, error)) *MockBlobStoreGetCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// List mocks base method.
+func (m *MockBlobStore) List(ctx context.Context) ([]blobstore.Info, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]blobstore.Info)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockBlobStoreMockRecorder) List(ctx any) *MockBlobStoreListCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockBlobStore)(nil).List), ctx)
+	return &MockBlobStoreListCall{Call: call}
+}
+
+// MockBlobStoreListCall wrap *gomock.Call
+type MockBlobStoreListCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockBlobStoreListCall) Return(arg0 []blobstore.Info, arg1 error) *MockBlobStoreListCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockBlobStoreListCall) Do(f func(context.Context) ([]blobstore.Info, error)) *MockBlobStoreListCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockBlobStoreListCall) DoAndReturn(f func(context.Context) ([]blobstore.Info, error)) *MockBlobStoreListCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Open mocks base method.
+func (m *MockBlobStore) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Open", ctx, key)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Open indicates an expected call of Open.
+func (mr *MockBlobStoreMockRecorder) Open(ctx, key any) *MockBlobStoreOpenCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Open", reflect.TypeOf((*MockBlobStore)(nil).Open), ctx, key)
+	return &MockBlobStoreOpenCall{Call: call}
+}
+
+// MockBlobStoreOpenCall wrap *gomock.Call
+type MockBlobStoreOpenCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockBlobStoreOpenCall) Return(arg0 io.ReadCloser, arg1 error) *MockBlobStoreOpenCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockBlobStoreOpenCall) Do(f func(context.Context, string) (io.ReadCloser, error)) *MockBlobStoreOpenCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockBlobStoreOpenCall) DoAndReturn(f func(context.Context, string) (io.ReadCloser, error)) *MockBlobStoreOpenCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Put mocks base method.
+func (m *MockBlobStore) Put(ctx context.Context, key string, r io.Reader) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Put", ctx, key, r)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Put indicates an expected call of Put.
+func (mr *MockBlobStoreMockRecorder) Put(ctx, key, r any) *MockBlobStorePutCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockBlobStore)(nil).Put), ctx, key, r)
+	return &MockBlobStorePutCall{Call: call}
+}
+
+// MockBlobStorePutCall wrap *gomock.Call
+type MockBlobStorePutCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockBlobStorePutCall) Return(arg0 error) *MockBlobStorePutCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockBlobStorePutCall) Do(f func(context.Context, string, io.Reader) error) *MockBlobStorePutCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockBlobStorePutCall) DoAndReturn(f func(context.Context, string, io.Reader) error) *MockBlobStorePutCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// Batch mocks base method.
+func (m *MockService) Batch(ctx context.Context, userID models.UserID, passphrase string, items []secrets.BatchItem) ([]secrets.BatchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Batch", ctx, userID, passphrase, items)
+	ret0, _ := ret[0].([]secrets.BatchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Batch indicates an expected call of Batch.
+func (mr *MockServiceMockRecorder) Batch(ctx, userID, passphrase, items any) *MockServiceBatchCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Batch", reflect.TypeOf((*MockService)(nil).Batch), ctx, userID, passphrase, items)
+	return &MockServiceBatchCall{Call: call}
+}
+
+// MockServiceBatchCall wrap *gomock.Call
+type MockServiceBatchCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockServiceBatchCall) Return(arg0 []secrets.BatchResult, arg1 error) *MockServiceBatchCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockServiceBatchCall) Do(f func(context.Context, models.UserID, string, []secrets.BatchItem) ([]secrets.BatchResult, error)) *MockServiceBatchCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockServiceBatchCall) DoAndReturn(f func(context.Context, models.UserID, string, []secrets.BatchItem) ([]secrets.BatchResult, error)) *MockServiceBatchCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CollectBlobs mocks base method.
+func (m *MockService) CollectBlobs(ctx context.Context, age time.Duration) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CollectBlobs", ctx, age)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CollectBlobs indicates an expected call of CollectBlobs.
+func (mr *MockServiceMockRecorder) CollectBlobs(ctx, age any) *MockServiceCollectBlobsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CollectBlobs", reflect.TypeOf((*MockService)(nil).CollectBlobs), ctx, age)
+	return &MockServiceCollectBlobsCall{Call: call}
+}
+
+// MockServiceCollectBlobsCall wrap *gomock.Call
+type MockServiceCollectBlobsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockServiceCollectBlobsCall) Return(arg0 int, arg1 error) *MockServiceCollectBlobsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockServiceCollectBlobsCall) Do(f func(context.Context, time.Duration) (int, error)) *MockServiceCollectBlobsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockServiceCollectBlobsCall) DoAndReturn(f func(context.Context, time.Duration) (int, error)) *MockServiceCollectBlobsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Create mocks base method.
+func (m *MockService) Create(ctx context.Context, ownerID models.UserID, passphrase, name string, data secrets.ISecretData, attrs secrets.Attrs) (models.SecretID, []secrets.Warning, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, ownerID, passphrase, name, data, attrs)
+	ret0, _ := ret[0].(models.SecretID)
+	ret1, _ := ret[1].([]secrets.Warning)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockServiceMockRecorder) Create(ctx, ownerID, passphrase, name, data, attrs any) *MockServiceCreateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockService)(nil).Create), ctx, ownerID, passphrase, name, data, attrs)
+	return &MockServiceCreateCall{Call: call}
+}
+
+// MockServiceCreateCall wrap *gomock.Call
+type MockServiceCreateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockServiceCreateCall) Return(arg0 models.SecretID, arg1 []secrets.Warning, arg2 error) *MockServiceCreateCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockServiceCreateCall) Do(f func(context.Context, models.UserID, string, string, secrets.ISecretData, secrets.Attrs) (models.SecretID, []secrets.Warning, error)) *MockServiceCreateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockServiceCreateCall) DoAndReturn(f func(context.Context, models.UserID, string, string, secrets.ISecretData, secrets.Attrs) (models.SecretID, []secrets.Warning, error)) *MockServiceCreateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CreateFile mocks base method.
+func (m *MockService) CreateFile(ctx context.Context, ownerID models.UserID, passphrase, name string, data *secrets.FileData, content io.Reader, attrs secrets.Attrs) (models.SecretID, []secrets.Warning, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFile", ctx, ownerID, passphrase, name, data, content, attrs)
+	ret0, _ := ret[0].(models.SecretID)
+	ret1, _ := ret[1].([]secrets.Warning)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateFile indicates an expected call of CreateFile.
+func (mr *MockServiceMockRecorder) CreateFile(ctx, ownerID, passphrase, name, data, content, attrs any) *MockServiceCreateFileCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFile", reflect.TypeOf((*MockService)(nil).CreateFile), ctx, ownerID, passphrase, name, data, content, attrs)
+	return &MockServiceCreateFileCall{Call: call}
+}
+
+// MockServiceCreateFileCall wrap *gomock.Call
+type MockServiceCreateFileCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockServiceCreateFileCall) Return(arg0 models.SecretID, arg1 []secrets.Warning, arg2 error) *MockServiceCreateFileCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockServiceCreateFileCall) Do(f func(context.Context, models.UserID, string, string, *secrets.FileData, io.Reader, secrets.Attrs) (models.SecretID, []secrets.Warning, error)) *MockServiceCreateFileCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockServiceCreateFileCall) DoAndReturn(f func(context.Context, models.UserID, string, string, *secrets.FileData, io.Reader, secrets.Attrs) (models.SecretID, []secrets.Warning, error)) *MockServiceCreateFileCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Delete mocks base method.
+func (m *MockService) Delete(ctx context.Context, id models.SecretID, userID models.UserID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockServiceMockRecorder) Delete(ctx, id, userID any) *MockServiceDeleteCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockService)(nil).Delete), ctx, id, userID)
+	return &MockServiceDeleteCall{Call: call}
+}
+
+// MockServiceDeleteCall wrap *gomock.Call
+type MockServiceDeleteCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockServiceDeleteCall) Return(arg0 error) *MockServiceDeleteCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockServiceDeleteCall) Do(f func(context.Context, models.SecretID, models.UserID) error) *MockServiceDeleteCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockServiceDeleteCall) DoAndReturn(f func(context.Context, models.SecretID, models.UserID) error) *MockServiceDeleteCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Escrow mocks base method.
+func (m *MockService) Escrow(ctx context.Context, ownerID models.UserID, passphrase string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Escrow", ctx, ownerID, passphrase)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Escrow indicates an expected call of Escrow.
+func (mr *MockServiceMockRecorder) Escrow(ctx, ownerID, passphrase any) *MockServiceEscrowCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Escrow", reflect.TypeOf((*MockService)(nil).Escrow), ctx, ownerID, passphrase)
+	return &MockServiceEscrowCall{Call: call}
+}
+
+// MockServiceEscrowCall wrap *gomock.Call
+type MockServiceEscrowCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockServiceEscrowCall) Return(arg0 []byte, arg1 error) *MockServiceEscrowCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockServiceEscrowCall) Do(f func(context.Context, models.UserID, string) ([]byte, error)) *MockServiceEscrowCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockServiceEscrowCall) DoAndReturn(f func(context.Context, models.UserID, string) ([]byte, error)) *MockServiceEscrowCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Export mocks base method.
+func (m *MockService) Export(ctx context.Context, ownerID models.UserID, passphrase, password string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, ownerID, passphrase, password)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockServiceMockRecorder) Export(ctx, ownerID, passphrase, password any) *MockServiceExportCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockService)(nil).Export), ctx, ownerID, passphrase, password)
+	return &MockServiceExportCall{Call: call}
+}
+
+// MockServiceExportCall wrap *gomock.Call
+type MockServiceExportCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockServiceExportCall) Return(arg0 []byte, arg1 error) *MockServiceExportCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockServiceExportCall) Do(f func(context.Context, models.UserID, string, string) ([]byte, error)) *MockServiceExportCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockServiceExportCall) DoAndReturn(f func(context.Context, models.UserID, string, string) ([]byte, error)) *MockServiceExportCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GenerateOTP mocks base method.
+func (m *MockService) GenerateOTP(ctx context.Context, id models.SecretID, userID models.UserID, passphrase string) (*secrets.OTPCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateOTP", ctx, id, userID, passphrase)
+	ret0, _ := ret[0].(*secrets.OTPCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateOTP indicates an expected call of GenerateOTP.
+func (mr *MockServiceMockRecorder) GenerateOTP(ctx, id, userID, passphrase any) *MockServiceGenerateOTPCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateOTP", reflect.TypeOf((*MockService)(nil).GenerateOTP), ctx, id, userID, passphrase)
+	return &MockServiceGenerateOTPCall{Call: call}
+}
+
+// MockServiceGenerateOTPCall wrap *gomock.Call
+type MockServiceGenerateOTPCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockServiceGenerateOTPCall) Return(arg0 *secrets.OTPCode, arg1 error) *MockServiceGenerateOTPCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockServiceGenerateOTPCall) Do(f func(context.Context, models.SecretID, models.UserID, string) (*secrets.OTPCode, error)) *MockServiceGenerateOTPCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockServiceGenerateOTPCall) DoAndReturn(f func(context.Context, models.SecretID, models.UserID, string) (*secrets.OTPCode, error)) *MockServiceGenerateOTPCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Get mocks base method.
+func (m *MockService) Get(ctx context.Context, id models.SecretID, userID models.UserID, passphrase string) (*models.Secret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id, userID, passphrase)
+	ret0, _ := ret[0].(*models.Secret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockServiceMockRecorder) Get(ctx, id, userID, passphrase any) *MockServiceGetCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockService)(nil).Get), ctx, id, userID, passphrase)
+	return &MockServiceGetCall{Call: call}
+}
+
+// MockServiceGetCall wrap *gomock.Call
+type MockServiceGetCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockServiceGetCall) Return(arg0 *models.Secret, arg1 error) *MockServiceGetCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockServiceGetCall) Do(f func(context.Context, models.SecretID, models.UserID, string) (*models.Secret, error)) *MockServiceGetCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockServiceGetCall) DoAndReturn(f func(context.Context, models.SecretID, models.UserID, string) (*models.Secret, error)) *MockServiceGetCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetDue mocks base method.
+func (m *MockService) GetDue(ctx context.Context, ownerID models.UserID) ([]secrets.DueSecret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDue", ctx, ownerID)
+	ret0, _ := ret[0].([]secrets.DueSecret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDue indicates an expected call of GetDue.
+func (mr *MockServiceMockRecorder) GetDue(ctx, ownerID any) *MockServiceGetDueCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDue", reflect.TypeOf((*MockService)(nil).GetDue), ctx, ownerID)
+	return &MockServiceGetDueCall{Call: call}
+}
+
+// MockServiceGetDueCall wrap *gomock.Call
+type MockServiceGetDueCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockServiceGetDueCall) Return(arg0 []secrets.DueSecret, arg1 error) *MockServiceGetDueCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockServiceGetDueCall) Do(f func(context.Context, models.UserID) ([]secrets.DueSecret, error)) *MockServiceGetDueCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockServiceGetDueCall) DoAndReturn(f func(context.Context, models.UserID) ([]secrets.DueSecret, error)) *MockServiceGetDueCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetEscrowed mocks base method.
+func (m *MockService) GetEscrowed(ctx context.Context, id models.SecretID, ownerID models.UserID, escrowKey []byte) (*models.Secret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEscrowed", ctx, id, ownerID, escrowKey)
+	ret0, _ := ret[0].(*models.Secret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEscrowed indicates an expected call of GetEscrowed.
+func (mr *MockServiceMockRecorder) GetEscrowed(ctx, id, ownerID, escrowKey any) *MockServiceGetEscrowedCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEscrowed", reflect.TypeOf((*MockService)(nil).GetEscrowed), ctx, id, ownerID, escrowKey)
+	return &MockServiceGetEscrowedCall{Call: call}
+}
+
+// MockServiceGetEscrowedCall wrap *gomock.Call
+type MockServiceGetEscrowedCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockServiceGetEscrowedCall) Return(arg0 *models.Secret, arg1 error) *MockServiceGetEscrowedCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockServiceGetEscrowedCall) Do(f func(context.Context, models.SecretID, models.UserID, []byte) (*models.Secret, error)) *MockServiceGetEscrowedCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockServiceGetEscrowedCall) DoAndReturn(f func(context.Context, models.SecretID, models.UserID, []byte) (*models.Secret, error)) *MockServiceGetEscrowedCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetOrgPage mocks base method.
+func (m *MockService) GetOrgPage(ctx context.Context, orgID models.OrgID, userID models.UserID, limit, offset uint64) (*secrets.Page[models.Secret], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrgPage", ctx, orgID, userID, limit, offset)
+	ret0, _ := ret[0].(*secrets.Page[models.Secret])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrgPage indicates an expected call of GetOrgPage.
+func (mr *MockServiceMockRecorder) GetOrgPage(ctx, orgID, userID, limit, offset any) *MockServiceGetOrgPageCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrgPage", reflect.TypeOf((*MockService)(nil).GetOrgPage), ctx, orgID, userID, limit, offset)
+	return &MockServiceGetOrgPageCall{Call: call}
+}
+
+// MockServiceGetOrgPageCall wrap *gomock.Call
+type MockServiceGetOrgPageCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockServiceGetOrgPageCall) Return(arg0 *secrets.Page[models.Secret], arg1 error) *MockServiceGetOrgPageCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockServiceGetOrgPageCall) Do(f func(context.Context, models.OrgID, models.UserID, uint64, uint64) (*secrets.Page[models.Secret], error)) *MockServiceGetOrgPageCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockServiceGetOrgPageCall) DoAndReturn(f func(context.Context, models.OrgID, models.UserID, uint64, uint64) (*secrets.Page[models.Secret], error)) *MockServiceGetOrgPageCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetPage mocks base method.
+func (m *MockService) GetPage(ctx context.Context, ownerID models.UserID, limit, offset uint64) (*secrets.Page[models.Secret], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPage", ctx, ownerID, limit, offset)
+	ret0, _ := ret[0].(*secrets.Page[models.Secret])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPage indicates an expected call of GetPage.
+func (mr *MockServiceMockRecorder) GetPage(ctx, ownerID, limit, offset any) *MockServiceGetPageCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPage", reflect.TypeOf((*MockService)(nil).GetPage), ctx, ownerID, limit, offset)
+	return &MockServiceGetPageCall{Call: call}
+}
+
+// MockServiceGetPageCall wrap *gomock.Call
+type MockServiceGetPageCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockServiceGetPageCall) Return(arg0 *secrets.Page[models.Secret], arg1 error) *MockServiceGetPageCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockServiceGetPageCall) Do(f func(context.Context, models.UserID, uint64, uint64) (*secrets.Page[models.Secret], error)) *MockServiceGetPageCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockServiceGetPageCall) DoAndReturn(f func(context.Context, models.UserID, uint64, uint64) (*secrets.Page[models.Secret], error)) *MockServiceGetPageCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetSharedWithMe mocks base method.
+func (m *MockService) GetSharedWithMe(ctx context.Context, userID models.UserID) ([]models.Share, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSharedWithMe", ctx, userID)
+	ret0, _ := ret[0].([]models.Share)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSharedWithMe indicates an expected call of GetSharedWithMe.
+func (mr *MockServiceMockRecorder) GetSharedWithMe(ctx, userID any) *MockServiceGetSharedWithMeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSharedWithMe", reflect.TypeOf((*MockService)(nil).GetSharedWithMe), ctx, userID)
+	return &MockServiceGetSharedWithMeCall{Call: call}
+}
+
+// MockServiceGetSharedWithMeCall wrap *gomock.Call
+type MockServiceGetSharedWithMeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockServiceGetSharedWithMeCall) Return(arg0 []models.Share, arg1 error) *MockServiceGetSharedWithMeCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockServiceGetSharedWithMeCall) Do(f func(context.Context, models.UserID) ([]models.Share, error)) *MockServiceGetSharedWithMeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockServiceGetSharedWithMeCall) DoAndReturn(f func(context.Context, models.UserID) ([]models.Share, error)) *MockServiceGetSharedWithMeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetShares mocks base method.
+func (m *MockService) GetShares(ctx context.Context, id models.SecretID, ownerID models.UserID) ([]models.Share, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShares", ctx, id, ownerID)
+	ret0, _ := ret[0].([]models.Share)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// NewMockService creates a new mock instance.
-func NewMockService(ctrl *gomock.Controller) *MockService {
-	mock := &MockService{ctrl: ctrl}
-	mock.recorder = &MockServiceMockRecorder{mock}
-	return mock
+// GetShares indicates an expected call of GetShares.
+func (mr *MockServiceMockRecorder) GetShares(ctx, id, ownerID any) *MockServiceGetSharesCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShares", reflect.TypeOf((*MockService)(nil).GetShares), ctx, id, ownerID)
+	return &MockServiceGetSharesCall{Call: call}
 }
 
-// EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockService) EXPECT() *MockServiceMockRecorder {
-	return m.recorder
+// MockServiceGetSharesCall wrap *gomock.Call
+type MockServiceGetSharesCall struct {
+	*gomock.Call
 }
 
-// Create mocks base method.
-func (m *MockService) Create(ctx context.Context, ownerID models.UserID, passphrase, name string, data secrets.ISecretData) (models.SecretID, error) {
+// Return rewrite *gomock.Call.Return
+func (c *MockServiceGetSharesCall) Return(arg0 []models.Share, arg1 error) *MockServiceGetSharesCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockServiceGetSharesCall) Do(f func(context.Context, models.SecretID, models.UserID) ([]models.Share, error)) *MockServiceGetSharesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockServiceGetSharesCall) DoAndReturn(f func(context.Context, models.SecretID, models.UserID) ([]models.Share, error)) *MockServiceGetSharesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetUsage mocks base method.
+func (m *MockService) GetUsage(ctx context.Context, ownerID models.UserID) (*secrets.Usage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", ctx, ownerID, passphrase, name, data)
-	ret0, _ := ret[0].(models.SecretID)
+	ret := m.ctrl.Call(m, "GetUsage", ctx, ownerID)
+	ret0, _ := ret[0].(*secrets.Usage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Create indicates an expected call of Create.
-func (mr *MockServiceMockRecorder) Create(ctx, ownerID, passphrase, name, data any) *MockServiceCreateCall {
+// GetUsage indicates an expected call of GetUsage.
+func (mr *MockServiceMockRecorder) GetUsage(ctx, ownerID any) *MockServiceGetUsageCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockService)(nil).Create), ctx, ownerID, passphrase, name, data)
-	return &MockServiceCreateCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsage", reflect.TypeOf((*MockService)(nil).GetUsage), ctx, ownerID)
+	return &MockServiceGetUsageCall{Call: call}
 }
 
-// MockServiceCreateCall wrap *gomock.Call
-type MockServiceCreateCall struct {
+// MockServiceGetUsageCall wrap *gomock.Call
+type MockServiceGetUsageCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *MockServiceCreateCall) Return(arg0 models.SecretID, arg1 error) *MockServiceCreateCall {
+func (c *MockServiceGetUsageCall) Return(arg0 *secrets.Usage, arg1 error) *MockServiceGetUsageCall {
 	c.Call = c.Call.Return(arg0, arg1)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *MockServiceCreateCall) Do(f func(context.Context, models.UserID, string, string, secrets.ISecretData) (models.SecretID, error)) *MockServiceCreateCall {
+func (c *MockServiceGetUsageCall) Do(f func(context.Context, models.UserID) (*secrets.Usage, error)) *MockServiceGetUsageCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *MockServiceCreateCall) DoAndReturn(f func(context.Context, models.UserID, string, string, secrets.ISecretData) (models.SecretID, error)) *MockServiceCreateCall {
+func (c *MockServiceGetUsageCall) DoAndReturn(f func(context.Context, models.UserID) (*secrets.Usage, error)) *MockServiceGetUsageCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// Delete mocks base method.
-func (m *MockService) Delete(ctx context.Context, id models.SecretID, ownerID models.UserID) error {
+// Import mocks base method.
+func (m *MockService) Import(ctx context.Context, ownerID models.UserID, passphrase, password string, archive []byte, dryRun bool) ([]secrets.ImportResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", ctx, id, ownerID)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "Import", ctx, ownerID, passphrase, password, archive, dryRun)
+	ret0, _ := ret[0].([]secrets.ImportResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// Delete indicates an expected call of Delete.
-func (mr *MockServiceMockRecorder) Delete(ctx, id, ownerID any) *MockServiceDeleteCall {
+// Import indicates an expected call of Import.
+func (mr *MockServiceMockRecorder) Import(ctx, ownerID, passphrase, password, archive, dryRun any) *MockServiceImportCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockService)(nil).Delete), ctx, id, ownerID)
-	return &MockServiceDeleteCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockService)(nil).Import), ctx, ownerID, passphrase, password, archive, dryRun)
+	return &MockServiceImportCall{Call: call}
 }
 
-// MockServiceDeleteCall wrap *gomock.Call
-type MockServiceDeleteCall struct {
+// MockServiceImportCall wrap *gomock.Call
+type MockServiceImportCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *MockServiceDeleteCall) Return(arg0 error) *MockServiceDeleteCall {
-	c.Call = c.Call.Return(arg0)
+func (c *MockServiceImportCall) Return(arg0 []secrets.ImportResult, arg1 error) *MockServiceImportCall {
+	c.Call = c.Call.Return(arg0, arg1)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *MockServiceDeleteCall) Do(f func(context.Context, models.SecretID, models.UserID) error) *MockServiceDeleteCall {
+func (c *MockServiceImportCall) Do(f func(context.Context, models.UserID, string, string, []byte, bool) ([]secrets.ImportResult, error)) *MockServiceImportCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *MockServiceDeleteCall) DoAndReturn(f func(context.Context, models.SecretID, models.UserID) error) *MockServiceDeleteCall {
+func (c *MockServiceImportCall) DoAndReturn(f func(context.Context, models.UserID, string, string, []byte, bool) ([]secrets.ImportResult, error)) *MockServiceImportCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// Get mocks base method.
-func (m *MockService) Get(ctx context.Context, id models.SecretID, ownerID models.UserID, passphrase string) (*models.Secret, error) {
+// Match mocks base method.
+func (m *MockService) Match(ctx context.Context, ownerID models.UserID, rawURL string) ([]models.Secret, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", ctx, id, ownerID, passphrase)
-	ret0, _ := ret[0].(*models.Secret)
+	ret := m.ctrl.Call(m, "Match", ctx, ownerID, rawURL)
+	ret0, _ := ret[0].([]models.Secret)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Get indicates an expected call of Get.
-func (mr *MockServiceMockRecorder) Get(ctx, id, ownerID, passphrase any) *MockServiceGetCall {
+// Match indicates an expected call of Match.
+func (mr *MockServiceMockRecorder) Match(ctx, ownerID, rawURL any) *MockServiceMatchCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockService)(nil).Get), ctx, id, ownerID, passphrase)
-	return &MockServiceGetCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Match", reflect.TypeOf((*MockService)(nil).Match), ctx, ownerID, rawURL)
+	return &MockServiceMatchCall{Call: call}
 }
 
-// MockServiceGetCall wrap *gomock.Call
-type MockServiceGetCall struct {
+// MockServiceMatchCall wrap *gomock.Call
+type MockServiceMatchCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *MockServiceGetCall) Return(arg0 *models.Secret, arg1 error) *MockServiceGetCall {
+func (c *MockServiceMatchCall) Return(arg0 []models.Secret, arg1 error) *MockServiceMatchCall {
 	c.Call = c.Call.Return(arg0, arg1)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *MockServiceGetCall) Do(f func(context.Context, models.SecretID, models.UserID, string) (*models.Secret, error)) *MockServiceGetCall {
+func (c *MockServiceMatchCall) Do(f func(context.Context, models.UserID, string) ([]models.Secret, error)) *MockServiceMatchCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *MockServiceGetCall) DoAndReturn(f func(context.Context, models.SecretID, models.UserID, string) (*models.Secret, error)) *MockServiceGetCall {
+func (c *MockServiceMatchCall) DoAndReturn(f func(context.Context, models.UserID, string) ([]models.Secret, error)) *MockServiceMatchCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// GetPage mocks base method.
-func (m *MockService) GetPage(ctx context.Context, ownerID models.UserID, limit, offset uint64) (*secrets.Page[models.Secret], error) {
+// NotifyDue mocks base method.
+func (m *MockService) NotifyDue(ctx context.Context, notifier secrets.Notifier) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPage", ctx, ownerID, limit, offset)
-	ret0, _ := ret[0].(*secrets.Page[models.Secret])
+	ret := m.ctrl.Call(m, "NotifyDue", ctx, notifier)
+	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetPage indicates an expected call of GetPage.
-func (mr *MockServiceMockRecorder) GetPage(ctx, ownerID, limit, offset any) *MockServiceGetPageCall {
+// NotifyDue indicates an expected call of NotifyDue.
+func (mr *MockServiceMockRecorder) NotifyDue(ctx, notifier any) *MockServiceNotifyDueCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPage", reflect.TypeOf((*MockService)(nil).GetPage), ctx, ownerID, limit, offset)
-	return &MockServiceGetPageCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyDue", reflect.TypeOf((*MockService)(nil).NotifyDue), ctx, notifier)
+	return &MockServiceNotifyDueCall{Call: call}
 }
 
-// MockServiceGetPageCall wrap *gomock.Call
-type MockServiceGetPageCall struct {
+// MockServiceNotifyDueCall wrap *gomock.Call
+type MockServiceNotifyDueCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *MockServiceGetPageCall) Return(arg0 *secrets.Page[models.Secret], arg1 error) *MockServiceGetPageCall {
+func (c *MockServiceNotifyDueCall) Return(arg0 int, arg1 error) *MockServiceNotifyDueCall {
 	c.Call = c.Call.Return(arg0, arg1)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *MockServiceGetPageCall) Do(f func(context.Context, models.UserID, uint64, uint64) (*secrets.Page[models.Secret], error)) *MockServiceGetPageCall {
+func (c *MockServiceNotifyDueCall) Do(f func(context.Context, secrets.Notifier) (int, error)) *MockServiceNotifyDueCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *MockServiceGetPageCall) DoAndReturn(f func(context.Context, models.UserID, uint64, uint64) (*secrets.Page[models.Secret], error)) *MockServiceGetPageCall {
+func (c *MockServiceNotifyDueCall) DoAndReturn(f func(context.Context, secrets.Notifier) (int, error)) *MockServiceNotifyDueCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// Update mocks base method.
-func (m *MockService) Update(ctx context.Context, id models.SecretID, ownerID models.UserID, passphrase, name string, data secrets.ISecretData) error {
+// OpenFile mocks base method.
+func (m *MockService) OpenFile(ctx context.Context, id models.SecretID, userID models.UserID, passphrase string) (*secrets.FileData, io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenFile", ctx, id, userID, passphrase)
+	ret0, _ := ret[0].(*secrets.FileData)
+	ret1, _ := ret[1].(io.ReadCloser)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// OpenFile indicates an expected call of OpenFile.
+func (mr *MockServiceMockRecorder) OpenFile(ctx, id, userID, passphrase any) *MockServiceOpenFileCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenFile", reflect.TypeOf((*MockService)(nil).OpenFile), ctx, id, userID, passphrase)
+	return &MockServiceOpenFileCall{Call: call}
+}
+
+// MockServiceOpenFileCall wrap *gomock.Call
+type MockServiceOpenFileCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockServiceOpenFileCall) Return(arg0 *secrets.FileData, arg1 io.ReadCloser, arg2 error) *MockServiceOpenFileCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockServiceOpenFileCall) Do(f func(context.Context, models.SecretID, models.UserID, string) (*secrets.FileData, io.ReadCloser, error)) *MockServiceOpenFileCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockServiceOpenFileCall) DoAndReturn(f func(context.Context, models.SecretID, models.UserID, string) (*secrets.FileData, io.ReadCloser, error)) *MockServiceOpenFileCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Report mocks base method.
+func (m *MockService) Report(ctx context.Context, ownerID models.UserID, passphrase string, opts secrets.ReportOptions) (*secrets.Report, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Report", ctx, ownerID, passphrase, opts)
+	ret0, _ := ret[0].(*secrets.Report)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Report indicates an expected call of Report.
+func (mr *MockServiceMockRecorder) Report(ctx, ownerID, passphrase, opts any) *MockServiceReportCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Report", reflect.TypeOf((*MockService)(nil).Report), ctx, ownerID, passphrase, opts)
+	return &MockServiceReportCall{Call: call}
+}
+
+// MockServiceReportCall wrap *gomock.Call
+type MockServiceReportCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockServiceReportCall) Return(arg0 *secrets.Report, arg1 error) *MockServiceReportCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockServiceReportCall) Do(f func(context.Context, models.UserID, string, secrets.ReportOptions) (*secrets.Report, error)) *MockServiceReportCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockServiceReportCall) DoAndReturn(f func(context.Context, models.UserID, string, secrets.ReportOptions) (*secrets.Report, error)) *MockServiceReportCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Revoke mocks base method.
+func (m *MockService) Revoke(ctx context.Context, id models.SecretID, ownerID models.UserID, passphrase string, recipientID models.UserID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, id, ownerID, passphrase, recipientID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockServiceMockRecorder) Revoke(ctx, id, ownerID, passphrase, recipientID any) *MockServiceRevokeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockService)(nil).Revoke), ctx, id, ownerID, passphrase, recipientID)
+	return &MockServiceRevokeCall{Call: call}
+}
+
+// MockServiceRevokeCall wrap *gomock.Call
+type MockServiceRevokeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockServiceRevokeCall) Return(arg0 error) *MockServiceRevokeCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockServiceRevokeCall) Do(f func(context.Context, models.SecretID, models.UserID, string, models.UserID) error) *MockServiceRevokeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockServiceRevokeCall) DoAndReturn(f func(context.Context, models.SecretID, models.UserID, string, models.UserID) error) *MockServiceRevokeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Share mocks base method.
+func (m *MockService) Share(ctx context.Context, id models.SecretID, ownerID models.UserID, passphrase, login string, access models.ShareAccess) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", ctx, id, ownerID, passphrase, name, data)
+	ret := m.ctrl.Call(m, "Share", ctx, id, ownerID, passphrase, login, access)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
+// Share indicates an expected call of Share.
+func (mr *MockServiceMockRecorder) Share(ctx, id, ownerID, passphrase, login, access any) *MockServiceShareCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Share", reflect.TypeOf((*MockService)(nil).Share), ctx, id, ownerID, passphrase, login, access)
+	return &MockServiceShareCall{Call: call}
+}
+
+// MockServiceShareCall wrap *gomock.Call
+type MockServiceShareCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockServiceShareCall) Return(arg0 error) *MockServiceShareCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockServiceShareCall) Do(f func(context.Context, models.SecretID, models.UserID, string, string, models.ShareAccess) error) *MockServiceShareCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockServiceShareCall) DoAndReturn(f func(context.Context, models.SecretID, models.UserID, string, string, models.ShareAccess) error) *MockServiceShareCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Update mocks base method.
+func (m *MockService) Update(ctx context.Context, id models.SecretID, userID models.UserID, passphrase, name string, data secrets.ISecretData, attrs secrets.Attrs) ([]secrets.Warning, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, id, userID, passphrase, name, data, attrs)
+	ret0, _ := ret[0].([]secrets.Warning)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // Update indicates an expected call of Update.
-func (mr *MockServiceMockRecorder) Update(ctx, id, ownerID, passphrase, name, data any) *MockServiceUpdateCall {
+func (mr *MockServiceMockRecorder) Update(ctx, id, userID, passphrase, name, data, attrs any) *MockServiceUpdateCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockService)(nil).Update), ctx, id, ownerID, passphrase, name, data)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockService)(nil).Update), ctx, id, userID, passphrase, name, data, attrs)
 	return &MockServiceUpdateCall{Call: call}
 }
 
@@ -280,19 +1554,58 @@ type MockServiceUpdateCall struct {
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *MockServiceUpdateCall) Return(arg0 error) *MockServiceUpdateCall {
-	c.Call = c.Call.Return(arg0)
+func (c *MockServiceUpdateCall) Return(arg0 []secrets.Warning, arg1 error) *MockServiceUpdateCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockServiceUpdateCall) Do(f func(context.Context, models.SecretID, models.UserID, string, string, secrets.ISecretData, secrets.Attrs) ([]secrets.Warning, error)) *MockServiceUpdateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockServiceUpdateCall) DoAndReturn(f func(context.Context, models.SecretID, models.UserID, string, string, secrets.ISecretData, secrets.Attrs) ([]secrets.Warning, error)) *MockServiceUpdateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// UpdateFile mocks base method.
+func (m *MockService) UpdateFile(ctx context.Context, id models.SecretID, userID models.UserID, passphrase, name string, data *secrets.FileData, content io.Reader, attrs secrets.Attrs) ([]secrets.Warning, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFile", ctx, id, userID, passphrase, name, data, content, attrs)
+	ret0, _ := ret[0].([]secrets.Warning)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateFile indicates an expected call of UpdateFile.
+func (mr *MockServiceMockRecorder) UpdateFile(ctx, id, userID, passphrase, name, data, content, attrs any) *MockServiceUpdateFileCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFile", reflect.TypeOf((*MockService)(nil).UpdateFile), ctx, id, userID, passphrase, name, data, content, attrs)
+	return &MockServiceUpdateFileCall{Call: call}
+}
+
+// MockServiceUpdateFileCall wrap *gomock.Call
+type MockServiceUpdateFileCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockServiceUpdateFileCall) Return(arg0 []secrets.Warning, arg1 error) *MockServiceUpdateFileCall {
+	c.Call = c.Call.Return(arg0, arg1)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *MockServiceUpdateCall) Do(f func(context.Context, models.SecretID, models.UserID, string, string, secrets.ISecretData) error) *MockServiceUpdateCall {
+func (c *MockServiceUpdateFileCall) Do(f func(context.Context, models.SecretID, models.UserID, string, string, *secrets.FileData, io.Reader, secrets.Attrs) ([]secrets.Warning, error)) *MockServiceUpdateFileCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *MockServiceUpdateCall) DoAndReturn(f func(context.Context, models.SecretID, models.UserID, string, string, secrets.ISecretData) error) *MockServiceUpdateCall {
+func (c *MockServiceUpdateFileCall) DoAndReturn(f func(context.Context, models.SecretID, models.UserID, string, string, *secrets.FileData, io.Reader, secrets.Attrs) ([]secrets.Warning, error)) *MockServiceUpdateFileCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
@@ -461,3 +1774,81 @@ func (c *MockEncryptorEncryptCall) DoAndReturn(f func([]byte, []byte) ([]byte, e
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
+
+// NewReader mocks base method.
+func (m *MockEncryptor) NewReader(key []byte, r io.Reader) (io.Reader, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewReader", key, r)
+	ret0, _ := ret[0].(io.Reader)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewReader indicates an expected call of NewReader.
+func (mr *MockEncryptorMockRecorder) NewReader(key, r any) *MockEncryptorNewReaderCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewReader", reflect.TypeOf((*MockEncryptor)(nil).NewReader), key, r)
+	return &MockEncryptorNewReaderCall{Call: call}
+}
+
+// MockEncryptorNewReaderCall wrap *gomock.Call
+type MockEncryptorNewReaderCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockEncryptorNewReaderCall) Return(arg0 io.Reader, arg1 error) *MockEncryptorNewReaderCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockEncryptorNewReaderCall) Do(f func([]byte, io.Reader) (io.Reader, error)) *MockEncryptorNewReaderCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockEncryptorNewReaderCall) DoAndReturn(f func([]byte, io.Reader) (io.Reader, error)) *MockEncryptorNewReaderCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// NewWriter mocks base method.
+func (m *MockEncryptor) NewWriter(key []byte, w io.Writer) (io.WriteCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewWriter", key, w)
+	ret0, _ := ret[0].(io.WriteCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewWriter indicates an expected call of NewWriter.
+func (mr *MockEncryptorMockRecorder) NewWriter(key, w any) *MockEncryptorNewWriterCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewWriter", reflect.TypeOf((*MockEncryptor)(nil).NewWriter), key, w)
+	return &MockEncryptorNewWriterCall{Call: call}
+}
+
+// MockEncryptorNewWriterCall wrap *gomock.Call
+type MockEncryptorNewWriterCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockEncryptorNewWriterCall) Return(arg0 io.WriteCloser, arg1 error) *MockEncryptorNewWriterCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockEncryptorNewWriterCall) Do(f func([]byte, io.Writer) (io.WriteCloser, error)) *MockEncryptorNewWriterCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockEncryptorNewWriterCall) DoAndReturn(f func([]byte, io.Writer) (io.WriteCloser, error)) *MockEncryptorNewWriterCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
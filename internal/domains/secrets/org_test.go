@@ -0,0 +1,285 @@
+package secrets_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/novoseltcev/passkeeper/internal/domains/secrets"
+	"github.com/novoseltcev/passkeeper/internal/domains/secrets/mocks"
+	"github.com/novoseltcev/passkeeper/internal/models"
+	"github.com/novoseltcev/passkeeper/pkg/aes"
+	"github.com/novoseltcev/passkeeper/pkg/sealbox"
+)
+
+const testOrgID = models.OrgID("org-id")
+
+var testOrgKey = []byte("0123456789abcdef0123456789abcdef")
+
+// newOrgMember returns a member of the test organization with the organization key sealed to the member.
+func newOrgMember(t *testing.T, enc secrets.Encryptor, role models.OrgRole) *models.Member {
+	t.Helper()
+
+	user := newRecipient(t, enc)
+
+	sealed, err := sealbox.Seal(user.PublicKey, testOrgKey)
+	require.NoError(t, err)
+
+	return &models.Member{Org: &models.Org{ID: testOrgID}, User: user, Role: role, Key: sealed}
+}
+
+func newOrgSecret(t *testing.T, enc secrets.Encryptor) *models.Secret {
+	t.Helper()
+
+	data, err := enc.Encrypt(testOrgKey, testContent)
+	require.NoError(t, err)
+
+	return &models.Secret{
+		ID:    testID,
+		Type:  models.SecretTypeTxt,
+		Data:  data,
+		Owner: &models.User{ID: testOwnerID},
+		Org:   &models.Org{ID: testOrgID},
+	}
+}
+
+func TestService_Get_Org_Success(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	hasher := mocks.NewMockHasher(ctrl)
+	enc := aes.New(aes.AES256BitKeyLength)
+	service := secrets.NewService(repo, hasher, enc)
+
+	repo.EXPECT().Get(gomock.Any(), testID).Return(newOrgSecret(t, enc), nil)
+	repo.EXPECT().
+		GetMember(gomock.Any(), testOrgID, testRecipientID).
+		Return(newOrgMember(t, enc, models.OrgRoleViewer), nil)
+	hasher.EXPECT().Compare(testRecipientHash, testRecipientPassphrase).Return(true, nil)
+	repo.EXPECT().Touch(gomock.Any(), testID).Return(nil)
+
+	got, err := service.Get(context.Background(), testID, testRecipientID, testRecipientPassphrase)
+	require.NoError(t, err)
+	assert.Equal(t, testContent, []byte(got.Data))
+}
+
+func TestService_Get_Org_Fails_NotMember(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := secrets.NewService(repo, nil, nil)
+
+	repo.EXPECT().
+		Get(gomock.Any(), testID).
+		Return(&models.Secret{Owner: &models.User{ID: testRecipientID}, Org: &models.Org{ID: testOrgID}}, nil)
+	repo.EXPECT().
+		GetMember(gomock.Any(), testOrgID, testRecipientID).
+		Return(nil, secrets.ErrMemberNotFound)
+
+	_, err := service.Get(context.Background(), testID, testRecipientID, testRecipientPassphrase)
+	assert.ErrorIs(t, err, secrets.ErrAnotherOwner)
+}
+
+func TestService_Update_Org_Fails_Viewer(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := secrets.NewService(repo, nil, nil)
+
+	repo.EXPECT().
+		Get(gomock.Any(), testID).
+		Return(&models.Secret{Type: models.SecretTypeTxt, Org: &models.Org{ID: testOrgID}}, nil)
+	repo.EXPECT().
+		GetMember(gomock.Any(), testOrgID, testRecipientID).
+		Return(&models.Member{Role: models.OrgRoleViewer}, nil)
+
+	_, err := service.Update(
+		context.Background(),
+		testID, testRecipientID,
+		testRecipientPassphrase,
+		testName, &secrets.TextData{Content: "text"},
+		secrets.Attrs{},
+	)
+	assert.ErrorIs(t, err, secrets.ErrReadOnly)
+}
+
+func TestService_Delete_Org(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	tests := []struct {
+		role models.OrgRole
+		err  error
+	}{
+		{role: models.OrgRoleOwner},
+		{role: models.OrgRoleAdmin},
+		{role: models.OrgRoleEditor},
+		{role: models.OrgRoleViewer, err: secrets.ErrReadOnly},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.role), func(t *testing.T) {
+			t.Parallel()
+
+			repo := mocks.NewMockRepository(ctrl)
+			service := secrets.NewService(repo, nil, nil)
+
+			repo.EXPECT().
+				Get(gomock.Any(), testID).
+				Return(&models.Secret{Owner: &models.User{ID: testOwnerID}, Org: &models.Org{ID: testOrgID}}, nil)
+			repo.EXPECT().
+				GetMember(gomock.Any(), testOrgID, testRecipientID).
+				Return(&models.Member{Role: tt.role}, nil)
+
+			if tt.err == nil {
+				repo.EXPECT().Delete(gomock.Any(), testID).Return(nil)
+			}
+
+			err := service.Delete(context.Background(), testID, testRecipientID)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestService_Delete_Fails_Shared(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := secrets.NewService(repo, nil, nil)
+
+	repo.EXPECT().
+		Get(gomock.Any(), testID).
+		Return(&models.Secret{Owner: &models.User{ID: testOwnerID}}, nil)
+	repo.EXPECT().
+		GetShare(gomock.Any(), testID, testRecipientID).
+		Return(&models.Share{Access: models.ShareAccessWrite}, nil)
+
+	err := service.Delete(context.Background(), testID, testRecipientID)
+	assert.ErrorIs(t, err, secrets.ErrAnotherOwner)
+}
+
+func TestService_Create_Org_Success(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	hasher := mocks.NewMockHasher(ctrl)
+	enc := aes.New(aes.AES256BitKeyLength)
+	service := secrets.NewService(repo, hasher, enc)
+
+	member := newOrgMember(t, enc, models.OrgRoleEditor)
+
+	repo.EXPECT().GetMember(gomock.Any(), testOrgID, testRecipientID).Return(member, nil)
+	hasher.EXPECT().Compare(testRecipientHash, testRecipientPassphrase).Return(true, nil)
+	repo.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, secret *models.Secret) (models.SecretID, error) {
+			assert.Equal(t, &models.Org{ID: testOrgID}, secret.Org)
+			assert.Equal(t, member.User, secret.Owner)
+
+			data, err := enc.Decrypt(testOrgKey, secret.Data)
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"content":"text","meta":null}`, string(data))
+
+			return testID, nil
+		})
+
+	id, _, err := service.Create(
+		context.Background(),
+		testRecipientID,
+		testRecipientPassphrase,
+		testName,
+		&secrets.TextData{Content: "text"},
+		secrets.Attrs{OrgID: testOrgID},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, testID, id)
+}
+
+func TestService_Create_Org_Fails(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	tests := []struct {
+		name   string
+		member *models.Member
+		err    error
+		want   error
+	}{
+		{name: "not member", err: secrets.ErrMemberNotFound, want: secrets.ErrAnotherOwner},
+		{name: "viewer", member: &models.Member{Role: models.OrgRoleViewer}, want: secrets.ErrReadOnly},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := mocks.NewMockRepository(ctrl)
+			service := secrets.NewService(repo, nil, nil)
+
+			repo.EXPECT().GetMember(gomock.Any(), testOrgID, testRecipientID).Return(tt.member, tt.err)
+
+			_, _, err := service.Create(
+				context.Background(),
+				testRecipientID,
+				testRecipientPassphrase,
+				testName,
+				&secrets.TextData{Content: "text"},
+				secrets.Attrs{OrgID: testOrgID},
+			)
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+}
+
+func TestService_Share_Fails_Org(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := secrets.NewService(repo, nil, nil)
+
+	repo.EXPECT().
+		Get(gomock.Any(), testID).
+		Return(&models.Secret{Owner: &models.User{ID: testOwnerID}, Org: &models.Org{ID: testOrgID}}, nil)
+
+	err := service.Share(
+		context.Background(), testID, testOwnerID, testPassphrase, testRecipientLogin, models.ShareAccessRead,
+	)
+	assert.ErrorIs(t, err, secrets.ErrAnotherOwner)
+}
+
+func TestService_GetOrgPage_Fails_NotMember(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := secrets.NewService(repo, nil, nil)
+
+	repo.EXPECT().
+		GetMember(gomock.Any(), testOrgID, testOwnerID).
+		Return(nil, secrets.ErrMemberNotFound)
+
+	_, err := service.GetOrgPage(context.Background(), testOrgID, testOwnerID, 10, 0)
+	assert.ErrorIs(t, err, secrets.ErrAnotherOwner)
+}
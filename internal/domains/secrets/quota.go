@@ -0,0 +1,82 @@
+package secrets
+
+import (
+	"context"
+
+	"github.com/novoseltcev/passkeeper/internal/models"
+)
+
+// Quota limits the secrets of each account by the count, the total size and the size of a secret.
+//
+// The sizes are of the encrypted data in bytes, zero values are unlimited.
+type Quota struct {
+	MaxSecrets     int
+	MaxBytes       int64
+	MaxSecretBytes int64
+}
+
+// Usage is the storage used by the secrets of an account against the quota.
+type Usage struct {
+	Secrets int
+	Bytes   int64
+	Quota   Quota
+}
+
+func (s *service) GetUsage(ctx context.Context, ownerID models.UserID) (*Usage, error) {
+	usage, err := s.repo.GetUsage(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	usage.Quota = s.quota
+
+	return usage, nil
+}
+
+// withinQuota runs write, which saves the secret of the size, within the quota of the owner,
+// the replaced secret is nil on create.
+//
+// The usage is checked and the secret is written in one transaction, which locks the owner,
+// so the concurrent writes of the owner can't exceed the quota together.
+// Updates, which do not grow the secret, are allowed over the quota, so the owner can shrink the vault.
+func (s *service) withinQuota(
+	ctx context.Context,
+	ownerID models.UserID,
+	size int64,
+	replaced *models.Secret,
+	write func(repo Repository) error,
+) error {
+	if s.quota.MaxSecretBytes > 0 && size > s.quota.MaxSecretBytes {
+		return ErrSecretTooLarge
+	}
+
+	if s.quota.MaxSecrets <= 0 && s.quota.MaxBytes <= 0 || replaced != nil && size <= replaced.Size {
+		return write(s.repo)
+	}
+
+	return s.repo.Atomic(ctx, func(repo Repository) error {
+		if err := repo.LockOwner(ctx, ownerID); err != nil {
+			return err
+		}
+
+		usage, err := repo.GetUsage(ctx, ownerID)
+		if err != nil {
+			return err
+		}
+
+		count, bytes := usage.Secrets+1, usage.Bytes+size
+		if replaced != nil {
+			count, bytes = usage.Secrets, bytes-replaced.Size
+		}
+
+		if s.quota.MaxSecrets > 0 && count > s.quota.MaxSecrets {
+			return ErrQuotaExceeded
+		}
+
+		if s.quota.MaxBytes > 0 && bytes > s.quota.MaxBytes {
+			return ErrQuotaExceeded
+		}
+
+		return write(repo)
+	})
+}
@@ -0,0 +1,205 @@
+package secrets_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/novoseltcev/passkeeper/internal/domains/secrets"
+	"github.com/novoseltcev/passkeeper/internal/domains/secrets/mocks"
+	"github.com/novoseltcev/passkeeper/internal/models"
+	"github.com/novoseltcev/passkeeper/pkg/testutils"
+)
+
+var testNote = &secrets.TextData{Content: "content", Meta: map[string]any{}}
+
+func setupQuota(t *testing.T, quota secrets.Quota) (*mocks.MockRepository, secrets.Service) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	hasher := mocks.NewMockHasher(ctrl)
+	enc := mocks.NewMockEncryptor(ctrl)
+
+	hasher.EXPECT().Compare(testHash, testPassphrase).Return(true, nil).AnyTimes()
+	enc.EXPECT().Encrypt([]byte(testPassphrase), gomock.Any()).Return(testContent, nil).AnyTimes()
+
+	return repo, secrets.NewService(repo, hasher, enc, secrets.WithQuota(quota))
+}
+
+// expectUsage expects the usage of the owner to be read after the owner is locked in the transaction.
+func expectUsage(repo *mocks.MockRepository, usage *secrets.Usage, err error) {
+	expectAtomic(repo)
+	gomock.InOrder(
+		repo.EXPECT().LockOwner(gomock.Any(), testOwnerID).Return(nil),
+		repo.EXPECT().GetUsage(gomock.Any(), testOwnerID).Return(usage, err),
+	)
+}
+
+func TestService_Create_Quota(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		quota secrets.Quota
+		usage *secrets.Usage
+		err   error
+	}{
+		{
+			name:  "unlimited",
+			quota: secrets.Quota{},
+		},
+		{
+			name:  "within",
+			quota: secrets.Quota{MaxSecrets: 2, MaxBytes: 10, MaxSecretBytes: 7},
+			usage: &secrets.Usage{Secrets: 1, Bytes: 3},
+		},
+		{
+			name:  "too large",
+			quota: secrets.Quota{MaxSecretBytes: 6},
+			err:   secrets.ErrSecretTooLarge,
+		},
+		{
+			name:  "too many",
+			quota: secrets.Quota{MaxSecrets: 1},
+			usage: &secrets.Usage{Secrets: 1},
+			err:   secrets.ErrQuotaExceeded,
+		},
+		{
+			name:  "too many bytes",
+			quota: secrets.Quota{MaxBytes: 10},
+			usage: &secrets.Usage{Secrets: 1, Bytes: 4},
+			err:   secrets.ErrQuotaExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			repo, service := setupQuota(t, tt.quota)
+
+			repo.EXPECT().
+				GetOwner(gomock.Any(), testOwnerID).
+				Return(&models.User{ID: testOwnerID, PassphraseHash: testHash}, nil)
+
+			if tt.usage != nil {
+				expectUsage(repo, tt.usage, nil)
+			}
+
+			if tt.err == nil {
+				repo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(testID, nil)
+			}
+
+			_, _, err := service.Create(context.Background(), testOwnerID, testPassphrase, testName, testNote,
+				secrets.Attrs{})
+			require.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestService_Create_Quota_Fails_GetUsage(t *testing.T) {
+	t.Parallel()
+	repo, service := setupQuota(t, secrets.Quota{MaxSecrets: 1})
+
+	repo.EXPECT().
+		GetOwner(gomock.Any(), testOwnerID).
+		Return(&models.User{ID: testOwnerID, PassphraseHash: testHash}, nil)
+	expectUsage(repo, nil, testutils.Err)
+
+	_, _, err := service.Create(context.Background(), testOwnerID, testPassphrase, testName, testNote, secrets.Attrs{})
+	require.ErrorIs(t, err, testutils.Err)
+}
+
+func TestService_Create_Quota_Fails_LockOwner(t *testing.T) {
+	t.Parallel()
+	repo, service := setupQuota(t, secrets.Quota{MaxSecrets: 1})
+
+	repo.EXPECT().
+		GetOwner(gomock.Any(), testOwnerID).
+		Return(&models.User{ID: testOwnerID, PassphraseHash: testHash}, nil)
+	expectAtomic(repo)
+	repo.EXPECT().LockOwner(gomock.Any(), testOwnerID).Return(testutils.Err)
+
+	_, _, err := service.Create(context.Background(), testOwnerID, testPassphrase, testName, testNote, secrets.Attrs{})
+	require.ErrorIs(t, err, testutils.Err)
+}
+
+func TestService_Update_Quota(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		size  int64
+		usage *secrets.Usage
+		err   error
+	}{
+		{
+			name: "not grown over quota",
+			size: 7,
+		},
+		{
+			name:  "grown within quota",
+			size:  5,
+			usage: &secrets.Usage{Secrets: 3, Bytes: 8},
+		},
+		{
+			name:  "grown over quota",
+			size:  5,
+			usage: &secrets.Usage{Secrets: 3, Bytes: 9},
+			err:   secrets.ErrQuotaExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			repo, service := setupQuota(t, secrets.Quota{MaxSecrets: 3, MaxBytes: 10})
+
+			repo.EXPECT().
+				Get(gomock.Any(), testID).
+				Return(&models.Secret{
+					Type:  models.SecretTypeTxt,
+					Size:  tt.size,
+					Owner: &models.User{ID: testOwnerID, PassphraseHash: testHash},
+				}, nil)
+
+			if tt.usage != nil {
+				expectUsage(repo, tt.usage, nil)
+			}
+
+			if tt.err == nil {
+				repo.EXPECT().Update(gomock.Any(), testID, gomock.Any()).Return(nil)
+			}
+
+			_, err := service.Update(context.Background(), testID, testOwnerID, testPassphrase, testName, testNote,
+				secrets.Attrs{})
+			require.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestService_GetUsage(t *testing.T) {
+	t.Parallel()
+	quota := secrets.Quota{MaxSecrets: 10, MaxBytes: 100, MaxSecretBytes: 50}
+	repo, service := setupQuota(t, quota)
+
+	repo.EXPECT().GetUsage(gomock.Any(), testOwnerID).Return(&secrets.Usage{Secrets: 2, Bytes: 14}, nil)
+
+	usage, err := service.GetUsage(context.Background(), testOwnerID)
+	require.NoError(t, err)
+	assert.Equal(t, &secrets.Usage{Secrets: 2, Bytes: 14, Quota: quota}, usage)
+}
+
+func TestService_GetUsage_Fails(t *testing.T) {
+	t.Parallel()
+	repo, service := setupQuota(t, secrets.Quota{})
+
+	repo.EXPECT().GetUsage(gomock.Any(), testOwnerID).Return(nil, testutils.Err)
+
+	_, err := service.GetUsage(context.Background(), testOwnerID)
+	require.ErrorIs(t, err, testutils.Err)
+}
@@ -0,0 +1,161 @@
+package secrets
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/json"
+	"time"
+
+	"github.com/novoseltcev/passkeeper/internal/models"
+	"github.com/novoseltcev/passkeeper/pkg/pwgen"
+)
+
+// ReportOptions are thresholds of the vault health report.
+type ReportOptions struct {
+	// MinEntropy is the entropy in bits below which a password is weak.
+	MinEntropy float64
+	// StaleAfter is the age of the last update after which a password is stale.
+	StaleAfter time.Duration
+	// ExpiringWithin is the period before the card expiration in which a card is expiring.
+	ExpiringWithin time.Duration
+}
+
+// Report is the vault health report.
+type Report struct {
+	Weak []WeakPassword
+	// Breached is filled only if BreachChecked, since the breach data is optional.
+	Breached      []BreachedPassword
+	BreachChecked bool
+	// Reused are the groups of secrets with the same password.
+	Reused [][]SecretRef
+	Stale  []StaleSecret
+	Cards  []CardExpiration
+}
+
+type SecretRef struct {
+	ID   models.SecretID
+	Name string
+}
+
+type WeakPassword struct {
+	SecretRef
+	Entropy float64
+}
+
+type BreachedPassword struct {
+	SecretRef
+	Count int
+}
+
+type StaleSecret struct {
+	SecretRef
+	UpdatedAt time.Time
+}
+
+type CardExpiration struct {
+	SecretRef
+	ExpiresAt time.Time
+	Expired   bool
+}
+
+func (s *service) Report(
+	ctx context.Context, ownerID models.UserID, passphrase string, opts ReportOptions,
+) (*Report, error) {
+	if _, err := s.loadAndCheckOwner(ctx, ownerID, passphrase); err != nil {
+		return nil, err
+	}
+
+	secrets, err := s.repo.GetAll(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	report := &Report{BreachChecked: s.breaches != nil}
+	groups := make(map[string][]SecretRef)
+	order := make([]string, 0)
+
+	for _, secret := range secrets {
+		ref := SecretRef{ID: secret.ID, Name: secret.Name}
+
+		switch secret.Type {
+		case models.SecretTypePwd:
+			var data PasswordData
+			if err := s.decrypt(passphrase, &secret, &data); err != nil {
+				return nil, err
+			}
+
+			if entropy := pwgen.Entropy(data.Password); entropy < opts.MinEntropy {
+				report.Weak = append(report.Weak, WeakPassword{SecretRef: ref, Entropy: entropy})
+			}
+
+			if count, ok := s.breachCount(data.Password); ok && count > 0 {
+				report.Breached = append(report.Breached, BreachedPassword{SecretRef: ref, Count: count})
+			} else if !ok {
+				report.BreachChecked = false
+			}
+
+			key := keyedHash(passphrase, data.Password)
+			if _, ok := groups[key]; !ok {
+				order = append(order, key)
+			}
+
+			groups[key] = append(groups[key], ref)
+
+			if now.Sub(secret.UpdatedAt) > opts.StaleAfter {
+				report.Stale = append(report.Stale, StaleSecret{SecretRef: ref, UpdatedAt: secret.UpdatedAt})
+			}
+		case models.SecretTypeCard:
+			var data CardData
+			if err := s.decrypt(passphrase, &secret, &data); err != nil {
+				return nil, err
+			}
+
+			expiresAt, err := data.ExpiresAt()
+			if err != nil {
+				continue
+			}
+
+			if expiresAt.Sub(now) <= opts.ExpiringWithin {
+				report.Cards = append(report.Cards, CardExpiration{
+					SecretRef: ref,
+					ExpiresAt: expiresAt,
+					Expired:   !now.Before(expiresAt),
+				})
+			}
+		}
+	}
+
+	for _, key := range order {
+		if len(groups[key]) > 1 {
+			report.Reused = append(report.Reused, groups[key])
+		}
+	}
+
+	return report, nil
+}
+
+// decrypt decrypts the owner's secret data into v.
+func (s *service) decrypt(passphrase string, secret *models.Secret, v any) error {
+	key, err := s.unlock(secret, grant{}, passphrase)
+	if err != nil {
+		return err
+	}
+
+	data, err := s.enc.Decrypt(key, secret.Data)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+// keyedHash hashes the password with the passphrase as a key,
+// so equal passwords can be grouped without keeping them in plaintext.
+func keyedHash(passphrase, password string) string {
+	mac := hmac.New(sha256.New, []byte(passphrase))
+	mac.Write([]byte(password))
+
+	return string(mac.Sum(nil))
+}
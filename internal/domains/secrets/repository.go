@@ -11,8 +11,49 @@ import (
 type Repository interface {
 	GetOwner(ctx context.Context, ownerID models.UserID) (*models.User, error)
 	Get(ctx context.Context, id models.SecretID) (*models.Secret, error)
+	// GetPage returns a page of the owner's personal secrets.
 	GetPage(ctx context.Context, ownerID models.UserID, limit, offset uint64) (*Page[models.Secret], error)
+	GetOrgPage(ctx context.Context, orgID models.OrgID, limit, offset uint64) (*Page[models.Secret], error)
+	// GetMember returns the membership of the user in the organization with the user's keys.
+	GetMember(ctx context.Context, orgID models.OrgID, userID models.UserID) (*models.Member, error)
+	// GetAll returns all the owner's personal secrets.
+	GetAll(ctx context.Context, ownerID models.UserID) ([]models.Secret, error)
+	// GetByURITokens returns the owner's personal secrets with any of the tokens of the URI index.
+	GetByURITokens(ctx context.Context, ownerID models.UserID, tokens []string) ([]models.Secret, error)
+	// GetDue returns the owner's secrets that are expired or due for rotation.
+	GetDue(ctx context.Context, ownerID models.UserID) ([]models.Secret, error)
+	// GetDueToNotify returns due secrets of all owners with their logins, which were not notified since they became due.
+	GetDueToNotify(ctx context.Context) ([]models.Secret, error)
+	MarkNotified(ctx context.Context, id models.SecretID) error
+	// Touch sets the time the secret was last accessed to now.
+	Touch(ctx context.Context, id models.SecretID) error
 	Create(ctx context.Context, data *models.Secret) (models.SecretID, error)
+	// Update updates the secret of the data revision and sets the next one,
+	// it returns ErrRevisionMismatch if the secret has another revision.
 	Update(ctx context.Context, id models.SecretID, data *models.Secret) error
 	Delete(ctx context.Context, id models.SecretID) error
+	// SetOwnerEscrowKey sets the escrow key of the owner encrypted with the owner's passphrase.
+	SetOwnerEscrowKey(ctx context.Context, ownerID models.UserID, key []byte) error
+	// SetEscrowKey sets the data key of the secret encrypted with the escrow key of its owner.
+	SetEscrowKey(ctx context.Context, id models.SecretID, key []byte) error
+	// LockOwner locks the owner until the end of the transaction, so the quota checks of the owner run one by one.
+	LockOwner(ctx context.Context, ownerID models.UserID) error
+	// GetUsage returns the count and the total size of the secrets created by the owner.
+	GetUsage(ctx context.Context, ownerID models.UserID) (*Usage, error)
+	// GetUsedBlobs returns the keys, which the secrets refer to.
+	GetUsedBlobs(ctx context.Context, keys []string) ([]string, error)
+	GetTemplate(ctx context.Context, id models.TemplateID) (*models.Template, error)
+	GetUserByLogin(ctx context.Context, login string) (*models.User, error)
+	GetShare(ctx context.Context, id models.SecretID, recipientID models.UserID) (*models.Share, error)
+	// GetShares returns the shares of the secret with their recipients.
+	GetShares(ctx context.Context, id models.SecretID) ([]models.Share, error)
+	// GetSharedWith returns the shares of the recipient with their secrets and owners.
+	GetSharedWith(ctx context.Context, recipientID models.UserID) ([]models.Share, error)
+	// SaveShare creates the share or replaces the key and the access of the existing one.
+	SaveShare(ctx context.Context, share *models.Share) error
+	DeleteShare(ctx context.Context, id models.SecretID, recipientID models.UserID) error
+	// Atomic runs fn with the repository bound to a transaction, which is committed if fn succeeds.
+	Atomic(ctx context.Context, fn func(repo Repository) error) error
+	// Rekey replaces the data and the keys of the secret and the keys of the shares at once.
+	Rekey(ctx context.Context, secret *models.Secret, shares []models.Share) error
 }
@@ -4,8 +4,13 @@ package secrets
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"io"
+	"time"
 
 	"github.com/novoseltcev/passkeeper/internal/models"
+	"github.com/novoseltcev/passkeeper/pkg/blobstore"
+	"github.com/novoseltcev/passkeeper/pkg/otp"
 )
 
 //go:generate mockgen -destination=./mocks/service_mocks.go -package=mocks -source=service.go -typed
@@ -22,57 +27,341 @@ type ISecretData interface {
 	SecretType() models.SecretType
 }
 
+// Validator is implemented by secret data that can check its own consistency.
+type Validator interface {
+	Validate() error
+}
+
+// Fingerprinter is implemented by secret data with a public fingerprint.
+//
+// The fingerprint is stored unencrypted, so it can be listed without the passphrase.
+type Fingerprinter interface {
+	Fingerprint() string
+}
+
+// Previewer is implemented by secret data with non-secret fields to display,
+// such as the login of a password, the last digits of a card or the filename of a file.
+//
+// The preview is stored unencrypted, so it can be listed without the passphrase.
+type Previewer interface {
+	Preview() map[string]string
+}
+
+// The keys of the preview fields.
+const (
+	PreviewLogin    = "login"
+	PreviewBrand    = "brand"
+	PreviewLast4    = "last4"
+	PreviewFilename = "filename"
+)
+
+// BreachChecker looks passwords up in a dataset of known breaches.
+type BreachChecker interface {
+	// Count returns how many times the password was seen in breaches.
+	Count(password string) (int, error)
+}
+
+// Notifier delivers reminders about due secrets to their owners.
+type Notifier interface {
+	Notify(ctx context.Context, due DueSecret) error
+}
+
+// BlobStore keeps the encrypted data of large secrets outside of the database.
+type BlobStore interface {
+	// Put streams the blob from the reader, the blob is visible once it is complete.
+	Put(ctx context.Context, key string, r io.Reader) error
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Open returns a reader of the blob, which must be closed.
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete deletes the blob, a missing blob is not an error.
+	Delete(ctx context.Context, key string) error
+	List(ctx context.Context) ([]blobstore.Info, error)
+}
+
+const (
+	WarningBreached = "breached"
+	WarningExpired  = "expired"
+)
+
+// Warning is a non-fatal finding about saved secret data.
+type Warning struct {
+	Code    string
+	Message string
+}
+
+// OTPCode is a generated one-time code.
+//
+// ExpiresAt is zero for counter-based codes.
+type OTPCode struct {
+	Code      string
+	ExpiresAt time.Time
+}
+
 // Service is a domain service for secrets.
 type Service interface {
-	// Get returns a secret by its ID with checking the owner by ownerID.
+	// Get returns a secret by its ID with checking the access by userID.
 	//
-	// Its check owner by ownerID to grant private access.
+	// The secret is accessible to its owner, the users it is shared with and the members of its organization,
+	// the passphrase is the passphrase of the user. The secret is marked as accessed.
 	// Domain errors:
 	// - ErrSecretNotFound
 	// - ErrAnotherOwner
-	Get(ctx context.Context, id models.SecretID, ownerID models.UserID, passphrase string) (*models.Secret, error)
+	// - ErrInvalidPassphrase
+	Get(ctx context.Context, id models.SecretID, userID models.UserID, passphrase string) (*models.Secret, error)
+
+	// Escrow returns the escrow key of the owner's personal vault, which reads its secrets without the passphrase.
+	//
+	// The key is generated on the first call, the data keys of the personal secrets are encrypted with it,
+	// so are the keys of the secrets created later. The key does not unlock anything else of the owner.
+	// Domain errors:
+	// - ErrInvalidPassphrase
+	Escrow(ctx context.Context, ownerID models.UserID, passphrase string) ([]byte, error)
+
+	// GetEscrowed returns the personal secret of the owner decrypted with the escrow key of the owner.
+	//
+	// The secret is not marked as accessed.
+	// Domain errors:
+	// - ErrSecretNotFound
+	// - ErrAnotherOwner if the secret is not in the personal vault of the owner
+	// - ErrNotEscrowed
+	GetEscrowed(ctx context.Context, id models.SecretID, ownerID models.UserID, escrowKey []byte) (*models.Secret, error)
 
 	// GetPage returns a page of owner's secrets with pagination.
 	// If the owner is not found, an error will be returned.
+	// The secrets of organizations are not included.
 	GetPage(ctx context.Context, ownerID models.UserID, limit, offset uint64) (*Page[models.Secret], error)
 
-	// Delete deletes a secret by its ID.
+	// GetOrgPage returns a page of secrets of the organization with pagination.
+	//
+	// Domain errors:
+	// - ErrAnotherOwner if the user is not a member of the organization
+	GetOrgPage(
+		ctx context.Context,
+		orgID models.OrgID,
+		userID models.UserID,
+		limit, offset uint64,
+	) (*Page[models.Secret], error)
+
+	// Delete deletes a secret by its ID with the blob of its data.
 	//
-	// Its check owner by ownerID to grant private access.
+	// The secret is deletable by its owner and the members of its organization with the write access.
 	// Domain errors:
 	// - ErrSecretNotFound
 	// - ErrAnotherOwner
-	Delete(ctx context.Context, id models.SecretID, ownerID models.UserID) error
+	// - ErrReadOnly
+	Delete(ctx context.Context, id models.SecretID, userID models.UserID) error
 
 	// CreateText creates a new text secret.
 	//
 	// Its validate passphrase and encrypt data.
+	// The secret is created in the organization vault if attrs.OrgID is set.
+	// Breached passwords and expired cards are saved, but reported as warnings.
 	// Domain errors:
 	// - ErrInvalidPassphrase
+	// - ErrInvalidSecretData
+	// - ErrTemplateNotFound
+	// - ErrAnotherOwner if the owner is not a member of the organization
+	// - ErrReadOnly if the owner can't write to the organization vault
+	// - ErrSecretTooLarge
+	// - ErrQuotaExceeded
 	Create(
 		ctx context.Context,
 		ownerID models.UserID,
 		passphrase string,
 		name string,
 		data ISecretData,
-	) (models.SecretID, error)
+		attrs Attrs,
+	) (models.SecretID, []Warning, error)
 
 	// Update update a secret.
 	//
 	// Its validate passphrase and encrypt data.
+	// The secret is updatable by its owner and the users and the members with the write access.
+	// Breached passwords and expired cards are saved, but reported as warnings.
 	// Domain errors:
 	// - ErrSecretNotFound
 	// - ErrAnotherOwner
+	// - ErrReadOnly
 	// - ErrInvalidPassphrase
 	// - ErrInvalidSecretType
+	// - ErrInvalidSecretData
+	// - ErrTemplateNotFound
+	// - ErrSecretTooLarge
+	// - ErrQuotaExceeded of the secret owner
+	// - ErrRevisionMismatch if the secret was changed since attrs.Revision or during the update
 	Update(
 		ctx context.Context,
 		id models.SecretID,
-		ownerID models.UserID,
+		userID models.UserID,
 		passphrase string,
 		name string,
 		data ISecretData,
+		attrs Attrs,
+	) ([]Warning, error)
+
+	// CreateFile creates a new file secret with the content read from the reader.
+	//
+	// The content is encrypted with a key of its own, which is kept in the file data, and streamed to a blob,
+	// so it is never held in memory whole. Without a blob store the content is kept in the file data.
+	// Domain errors are the ones of Create.
+	CreateFile(
+		ctx context.Context,
+		ownerID models.UserID,
+		passphrase string,
+		name string,
+		data *FileData,
+		content io.Reader,
+		attrs Attrs,
+	) (models.SecretID, []Warning, error)
+
+	// UpdateFile updates a file secret with the content read from the reader as CreateFile does.
+	//
+	// Domain errors are the ones of Update.
+	UpdateFile(
+		ctx context.Context,
+		id models.SecretID,
+		userID models.UserID,
+		passphrase string,
+		name string,
+		data *FileData,
+		content io.Reader,
+		attrs Attrs,
+	) ([]Warning, error)
+
+	// OpenFile returns the data of a file secret and a reader of its decrypted content, which must be closed.
+	//
+	// The secret is accessible as by Get and is marked as accessed.
+	// Domain errors:
+	// - ErrSecretNotFound
+	// - ErrAnotherOwner
+	// - ErrInvalidPassphrase
+	// - ErrInvalidSecretType
+	OpenFile(
+		ctx context.Context, id models.SecretID, userID models.UserID, passphrase string,
+	) (*FileData, io.ReadCloser, error)
+
+	// GenerateOTP generates the current code of an OTP secret.
+	//
+	// For counter-based secrets the counter is advanced and stored, so they need the write access.
+	// Domain errors:
+	// - ErrSecretNotFound
+	// - ErrAnotherOwner
+	// - ErrReadOnly
+	// - ErrInvalidPassphrase
+	// - ErrInvalidSecretType
+	GenerateOTP(ctx context.Context, id models.SecretID, userID models.UserID, passphrase string) (*OTPCode, error)
+
+	// Match returns the owner's personal secrets, whose URIs may match the URL, without decrypting them.
+	//
+	// The candidates are found by the index of the URIs, the client decrypts them to match the URIs exactly.
+	// The secrets saved before the index was configured are found after their update.
+	// Domain errors:
+	// - ErrInvalidURL
+	// - ErrMatchDisabled if the index is not configured
+	Match(ctx context.Context, ownerID models.UserID, rawURL string) ([]models.Secret, error)
+
+	// GetDue returns the owner's secrets that are expired or due for rotation.
+	GetDue(ctx context.Context, ownerID models.UserID) ([]DueSecret, error)
+
+	// NotifyDue notifies owners about secrets that became due since the last notification.
+	//
+	// It returns the number of sent notifications. Failed notifications are retried on the next call.
+	NotifyDue(ctx context.Context, notifier Notifier) (int, error)
+
+	// Batch runs the operations one by one in one transaction with the passphrase checked once.
+	//
+	// The operations run as the single ones with the same errors. The batch stops at the first failed operation
+	// and saves nothing, the following operations have ErrBatchAborted results.
+	// A secret is moved by the user, who can delete it, the moved one is owned by the user.
+	// Domain errors:
+	// - ErrInvalidPassphrase
+	// - ErrBatchFailed with the results of the operations
+	Batch(ctx context.Context, userID models.UserID, passphrase string, items []BatchItem) ([]BatchResult, error)
+
+	// Export decrypts the owner's personal secrets and seals them with the export password into an archive.
+	//
+	// Domain errors:
+	// - ErrInvalidPassphrase
+	Export(ctx context.Context, ownerID models.UserID, passphrase, password string) ([]byte, error)
+
+	// Import creates the secrets of the archive sealed with the export password in the owner's personal vault.
+	//
+	// The secrets with the same name, type and data as the ones in the vault or earlier in the archive are skipped,
+	// the import is all or nothing as a batch. A dry run checks the archive and returns the results only.
+	// Domain errors:
+	// - ErrInvalidPassphrase
+	// - ErrInvalidExportPassword
+	// - ErrInvalidArchive
+	// - ErrImportFailed with the results of the secrets
+	Import(
+		ctx context.Context,
+		ownerID models.UserID,
+		passphrase, password string,
+		archive []byte,
+		dryRun bool,
+	) ([]ImportResult, error)
+
+	// GetUsage returns the storage used by the owner's secrets with the quota.
+	//
+	// The secrets of organizations are counted for the users, who created them.
+	GetUsage(ctx context.Context, ownerID models.UserID) (*Usage, error)
+
+	// CollectBlobs deletes the blobs older than the age, which no secret refers to, and returns their count.
+	//
+	// Such blobs are left by failed writes and by the secrets deleted with their owners,
+	// the age keeps the blobs of the secrets being saved.
+	CollectBlobs(ctx context.Context, age time.Duration) (int, error)
+
+	// Report decrypts the owner's passwords and cards and returns the vault health findings.
+	//
+	// Domain errors:
+	// - ErrInvalidPassphrase
+	Report(ctx context.Context, ownerID models.UserID, passphrase string, opts ReportOptions) (*Report, error)
+
+	// Share gives the user with the login access to the owner's secret.
+	//
+	// The data key is sealed to the public key of the recipient, the existing share is replaced.
+	// Domain errors:
+	// - ErrSecretNotFound
+	// - ErrAnotherOwner
+	// - ErrInvalidPassphrase
+	// - ErrRecipientNotFound
+	// - ErrInvalidRecipient if the recipient is the owner
+	// - ErrNoPublicKey if the recipient has not generated the key pair yet
+	Share(
+		ctx context.Context,
+		id models.SecretID,
+		ownerID models.UserID,
+		passphrase string,
+		login string,
+		access models.ShareAccess,
+	) error
+
+	// Revoke takes the access to the owner's secret away from the recipient.
+	//
+	// The data is encrypted with a new data key, which is given to the remaining recipients.
+	// Domain errors:
+	// - ErrSecretNotFound
+	// - ErrAnotherOwner
+	// - ErrInvalidPassphrase
+	// - ErrShareNotFound
+	Revoke(
+		ctx context.Context,
+		id models.SecretID,
+		ownerID models.UserID,
+		passphrase string,
+		recipientID models.UserID,
 	) error
+
+	// GetShares returns the shares of the owner's secret.
+	//
+	// Domain errors:
+	// - ErrSecretNotFound
+	// - ErrAnotherOwner
+	GetShares(ctx context.Context, id models.SecretID, ownerID models.UserID) ([]models.Share, error)
+
+	// GetSharedWithMe returns the shares of other users' secrets with the user.
+	GetSharedWithMe(ctx context.Context, userID models.UserID) ([]models.Share, error)
 }
 
 type Hasher interface {
@@ -82,37 +371,88 @@ type Hasher interface {
 type Encryptor interface {
 	Encrypt(passphrase, v []byte) ([]byte, error)
 	Decrypt(passphrase, v []byte) ([]byte, error)
+	// NewWriter returns a writer, which encrypts the stream written to it with the key, it must be closed.
+	NewWriter(key []byte, w io.Writer) (io.WriteCloser, error)
+	// NewReader returns a reader, which decrypts the stream written by NewWriter with the key.
+	NewReader(key []byte, r io.Reader) (io.Reader, error)
 }
 
 type service struct {
-	repo   Repository
-	hasher Hasher
-	enc    Encryptor
+	repo     Repository
+	hasher   Hasher
+	enc      Encryptor
+	breaches BreachChecker
+	blobs    BlobStore
+	quota    Quota
+	uriKey   []byte
 }
 
 var _ Service = (*service)(nil)
 
+type Option func(*service)
+
+// WithBreachChecker enables the check of passwords against known breaches.
+func WithBreachChecker(checker BreachChecker) Option {
+	return func(s *service) {
+		s.breaches = checker
+	}
+}
+
+// WithBlobStore keeps the encrypted data of file secrets in the blob store, the rows refer to the blobs only.
+//
+// The data saved before stays in the rows until the secret is updated.
+func WithBlobStore(store BlobStore) Option {
+	return func(s *service) {
+		s.blobs = store
+	}
+}
+
+// WithQuota limits the secrets of each account.
+func WithQuota(quota Quota) Option {
+	return func(s *service) {
+		s.quota = quota
+	}
+}
+
 func NewService(
 	repo Repository,
 	hasher Hasher,
 	enc Encryptor,
+	opts ...Option,
 ) *service { // nolint: revive
-	return &service{repo: repo, hasher: hasher, enc: enc}
+	s := &service{repo: repo, hasher: hasher, enc: enc}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
 }
 
 func (s *service) Get(
-	ctx context.Context, id models.SecretID, ownerID models.UserID, passphrase string,
+	ctx context.Context, id models.SecretID, userID models.UserID, passphrase string,
 ) (*models.Secret, error) {
-	secret, err := s.getMySecret(ctx, id, ownerID)
+	secret, _, key, err := s.unlockAccessible(ctx, id, userID, passphrase)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := s.checkPassphrase(secret.Owner, passphrase); err != nil {
+	encData, err := s.encData(ctx, secret)
+	if err != nil {
 		return nil, err
 	}
 
-	if secret.Data, err = s.enc.Decrypt([]byte(passphrase), secret.Data); err != nil {
+	if secret.Data, err = s.enc.Decrypt(key, encData); err != nil {
+		return nil, err
+	}
+
+	// the secret is read as a whole, so is the streamed content of a file
+	if secret.ContentBlobKey != "" {
+		if secret.Data, err = s.inlineContent(ctx, secret, secret.Data); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.repo.Touch(ctx, id); err != nil {
 		return nil, err
 	}
 
@@ -125,69 +465,278 @@ func (s *service) GetPage(ctx context.Context,
 	return s.repo.GetPage(ctx, ownerID, limit, offset)
 }
 
+func (s *service) GetOrgPage(ctx context.Context,
+	orgID models.OrgID, userID models.UserID, limit, offset uint64,
+) (*Page[models.Secret], error) {
+	if _, err := s.getMember(ctx, orgID, userID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetOrgPage(ctx, orgID, limit, offset)
+}
+
 func (s *service) Create(
-	ctx context.Context, ownerID models.UserID, passphrase string, name string, data ISecretData,
-) (models.SecretID, error) {
-	owner, err := s.loadAndCheckOwner(ctx, ownerID, passphrase)
+	ctx context.Context, ownerID models.UserID, passphrase string, name string, data ISecretData, attrs Attrs,
+) (models.SecretID, []Warning, error) {
+	return s.create(ctx, ownerID, passphrase, name, data, attrs, nil)
+}
+
+func (s *service) Update(
+	ctx context.Context,
+	id models.SecretID, userID models.UserID,
+	passphrase string,
+	name string, data ISecretData,
+	attrs Attrs,
+) ([]Warning, error) {
+	return s.update(ctx, id, userID, passphrase, name, data, attrs, nil)
+}
+
+// create creates the secret, the content is streamed by put once the passphrase is checked, if put is set.
+func (s *service) create(
+	ctx context.Context,
+	ownerID models.UserID,
+	passphrase string,
+	name string,
+	data ISecretData,
+	attrs Attrs,
+	put putContent,
+) (models.SecretID, []Warning, error) {
+	if err := validate(data); err != nil {
+		return "", nil, err
+	}
+
+	if err := s.matchTemplate(ctx, ownerID, data); err != nil {
+		return "", nil, err
+	}
+
+	owner, key, err := s.unlockVault(ctx, ownerID, attrs.OrgID, passphrase)
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 
-	jsonData, err := json.Marshal(data)
+	content, err := put.run()
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 
-	encryptedData, err := s.enc.Encrypt([]byte(passphrase), jsonData)
+	encryptedData, err := s.seal(key.key, data)
 	if err != nil {
-		return "", err
+		s.dropBlob(ctx, content.key)
+
+		return "", nil, err
+	}
+
+	secret := models.NewSecret(name, data.SecretType(), nil, owner)
+	secret.Fingerprint = fingerprint(data)
+	secret.Preview = preview(data)
+	secret.URITokens = s.uriTokens(ownerID, data)
+	secret.ContentBlobKey, secret.ContentSize = content.key, content.size
+	key.apply(secret)
+	if attrs.OrgID != "" {
+		secret.Org = &models.Org{ID: attrs.OrgID}
 	}
+	setAttrs(secret, data, attrs)
 
-	return s.repo.Create(ctx, models.NewSecret(name, data.SecretType(), encryptedData, owner))
+	var id models.SecretID
+
+	err = s.withinQuota(ctx, ownerID, int64(len(encryptedData))+content.size, nil, func(repo Repository) error {
+		return s.writeData(ctx, secret, encryptedData, func() (err error) {
+			id, err = repo.Create(ctx, secret)
+
+			return err
+		})
+	})
+	if err != nil {
+		s.dropBlob(ctx, content.key)
+
+		return "", nil, err
+	}
+
+	return id, s.warnings(data), nil
 }
 
-func (s *service) Update(
+// update updates the secret, the content is streamed by put once the passphrase is checked, if put is set.
+//
+// The content blob of the secret is replaced with the streamed one, the secret updated without one has none.
+func (s *service) update(
 	ctx context.Context,
-	id models.SecretID, ownerID models.UserID,
+	id models.SecretID, userID models.UserID,
 	passphrase string,
 	name string, data ISecretData,
-) error {
-	secret, err := s.getMySecret(ctx, id, ownerID)
+	attrs Attrs,
+	put putContent,
+) ([]Warning, error) {
+	secret, g, err := s.getAccessible(ctx, id, userID)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	if !g.canWrite() {
+		return nil, ErrReadOnly
 	}
 
 	if data.SecretType() != secret.Type {
-		return ErrInvalidSecretType
+		return nil, ErrInvalidSecretType
 	}
 
-	if err := s.checkPassphrase(secret.Owner, passphrase); err != nil {
-		return err
+	if attrs.Revision != 0 && attrs.Revision != secret.Revision {
+		return nil, ErrRevisionMismatch
 	}
 
-	jsonData, err := json.Marshal(data)
+	if err := validate(data); err != nil {
+		return nil, err
+	}
+
+	if err := s.matchTemplate(ctx, secret.Owner.ID, data); err != nil {
+		return nil, err
+	}
+
+	key, err := s.unlockAs(secret, g, passphrase)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	encData, err := s.enc.Encrypt([]byte(passphrase), jsonData)
+	content, err := put.run()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	secret.Data = encData
-	secret.Name = name
+	encData, err := s.seal(key, data)
+	if err != nil {
+		s.dropBlob(ctx, content.key)
+
+		return nil, err
+	}
+
+	// the replaced secret is compared before it is changed
+	replaced := *secret
+	old := secret.ContentBlobKey
+	secret.ContentBlobKey, secret.ContentSize = content.key, content.size
+
+	err = s.withinQuota(ctx, secret.Owner.ID, int64(len(encData))+content.size, &replaced, func(repo Repository) error {
+		return s.save(ctx, repo, id, secret, encData, name, data, attrs)
+	})
+	if err != nil {
+		s.dropBlob(ctx, content.key)
+
+		return nil, err
+	}
+
+	s.dropBlob(ctx, old)
+
+	return s.warnings(data), nil
+}
+
+func (s *service) GenerateOTP(
+	ctx context.Context, id models.SecretID, userID models.UserID, passphrase string,
+) (*OTPCode, error) {
+	secret, g, key, err := s.unlockAccessible(ctx, id, userID, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	if secret.Type != models.SecretTypeOTP {
+		return nil, ErrInvalidSecretType
+	}
+
+	plain, err := s.enc.Decrypt(key, secret.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	var data OTPData
+	if err := json.Unmarshal(plain, &data); err != nil {
+		return nil, err
+	}
+
+	otpKey := data.Key()
+	if otpKey.Kind == otp.KindTOTP {
+		code, expiresAt, err := otpKey.TOTP(time.Now())
+		if err != nil {
+			return nil, err
+		}
+
+		return &OTPCode{Code: code, ExpiresAt: expiresAt}, nil
+	}
+
+	code, err := otpKey.HOTP(data.Counter)
+	if err != nil {
+		return nil, err
+	}
+
+	if !g.canWrite() {
+		return nil, ErrReadOnly
+	}
+
+	data.Counter++
+
+	encData, err := s.seal(key, &data)
+	if err != nil {
+		return nil, err
+	}
 
-	return s.repo.Update(ctx, id, secret)
+	if err := s.save(ctx, s.repo, id, secret, encData, secret.Name, &data, attrsOf(secret)); err != nil {
+		return nil, err
+	}
+
+	return &OTPCode{Code: code}, nil
 }
 
-func (s *service) Delete(ctx context.Context, id models.SecretID, ownerID models.UserID) error {
-	_, err := s.getMySecret(ctx, id, ownerID)
+func (s *service) Delete(ctx context.Context, id models.SecretID, userID models.UserID) error {
+	secret, g, err := s.getAccessible(ctx, id, userID)
 	if err != nil {
 		return err
 	}
 
-	return s.repo.Delete(ctx, id)
+	// the recipients of shared secrets can't delete them even with the write access
+	if g.share != nil {
+		return ErrAnotherOwner
+	}
+
+	if !g.canWrite() {
+		return ErrReadOnly
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	s.dropBlob(ctx, secret.BlobKey)
+	s.dropBlob(ctx, secret.ContentBlobKey)
+
+	return nil
+}
+
+// seal encrypts the secret data with the key.
+func (s *service) seal(key []byte, data ISecretData) ([]byte, error) {
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.enc.Encrypt(key, jsonData)
+}
+
+// save updates the secret with the repository.
+func (s *service) save(
+	ctx context.Context,
+	repo Repository,
+	id models.SecretID,
+	secret *models.Secret,
+	encData []byte,
+	name string,
+	data ISecretData,
+	attrs Attrs,
+) error {
+	secret.Name = name
+	secret.Fingerprint = fingerprint(data)
+	secret.Preview = preview(data)
+	secret.URITokens = s.uriTokens(secret.Owner.ID, data)
+	setAttrs(secret, data, attrs)
+
+	return s.writeData(ctx, secret, encData, func() error {
+		return repo.Update(ctx, id, secret)
+	})
 }
 
 func (s *service) getMySecret(
@@ -200,7 +749,8 @@ func (s *service) getMySecret(
 		return nil, err
 	}
 
-	if secret.Owner.ID != ownerID {
+	// the secrets of organizations are accessible through the membership only
+	if secret.Owner.ID != ownerID || secret.Org != nil {
 		return nil, ErrAnotherOwner
 	}
 
@@ -236,3 +786,103 @@ func (s *service) loadAndCheckOwner(
 
 	return owner, nil
 }
+
+// matchTemplate checks custom data against its template, which must belong to the owner.
+func (s *service) matchTemplate(ctx context.Context, ownerID models.UserID, data ISecretData) error {
+	custom, ok := data.(*CustomData)
+	if !ok {
+		return nil
+	}
+
+	template, err := s.repo.GetTemplate(ctx, custom.Template)
+	if err != nil {
+		return err
+	}
+
+	if template.Owner.ID != ownerID {
+		return ErrTemplateNotFound
+	}
+
+	if err := custom.Match(template); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidSecretData, err)
+	}
+
+	return nil
+}
+
+func validate(data ISecretData) error {
+	v, ok := data.(Validator)
+	if !ok {
+		return nil
+	}
+
+	if err := v.Validate(); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidSecretData, err)
+	}
+
+	return nil
+}
+
+// breachCount returns how many times the password was seen in breaches.
+//
+// The breach data is optional, so it is not checked when missing or unreadable.
+func (s *service) breachCount(password string) (int, bool) {
+	if s.breaches == nil {
+		return 0, false
+	}
+
+	count, err := s.breaches.Count(password)
+	if err != nil {
+		return 0, false
+	}
+
+	return count, true
+}
+
+// warnings returns the findings about the saved data, such as a breached password or an expired card.
+func (s *service) warnings(data ISecretData) []Warning {
+	switch data := data.(type) {
+	case *PasswordData:
+		if count, ok := s.breachCount(data.Password); ok && count > 0 {
+			return []Warning{{
+				Code:    WarningBreached,
+				Message: fmt.Sprintf("the password has been seen %d times in data breaches", count),
+			}}
+		}
+	case *CardData:
+		if expiresAt, err := data.ExpiresAt(); err == nil && !expiresAt.After(time.Now()) {
+			return []Warning{{Code: WarningExpired, Message: "the card is expired"}}
+		}
+	}
+
+	return nil
+}
+
+// setAttrs sets the attributes of the secret, the expiration of cards is taken from the card.
+func setAttrs(secret *models.Secret, data ISecretData, attrs Attrs) {
+	if card, ok := data.(*CardData); ok {
+		if expiresAt, err := card.ExpiresAt(); err == nil {
+			attrs.ExpiresAt = expiresAt
+		}
+	}
+
+	secret.ExpiresAt = attrs.ExpiresAt
+	secret.RotateEvery = attrs.RotateEvery
+	secret.CreatedAt = attrs.CreatedAt
+}
+
+func fingerprint(data ISecretData) string {
+	if f, ok := data.(Fingerprinter); ok {
+		return f.Fingerprint()
+	}
+
+	return ""
+}
+
+func preview(data ISecretData) map[string]string {
+	if p, ok := data.(Previewer); ok {
+		return p.Preview()
+	}
+
+	return nil
+}
@@ -2,7 +2,9 @@ package secrets_test
 
 import (
 	"context"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -11,6 +13,8 @@ import (
 	"github.com/novoseltcev/passkeeper/internal/domains/secrets"
 	"github.com/novoseltcev/passkeeper/internal/domains/secrets/mocks"
 	"github.com/novoseltcev/passkeeper/internal/models"
+	"github.com/novoseltcev/passkeeper/pkg/otp"
+	"github.com/novoseltcev/passkeeper/pkg/sshkey"
 	"github.com/novoseltcev/passkeeper/pkg/testutils"
 )
 
@@ -47,6 +51,10 @@ func TestService_Get_Success(t *testing.T) {
 		Decrypt([]byte(testPassphrase), got.Data).
 		Return([]byte(testutils.STRING), nil)
 
+	repo.EXPECT().
+		Touch(gomock.Any(), testID).
+		Return(nil)
+
 	secret, err := service.Get(context.Background(), testID, testOwnerID, testPassphrase)
 	require.NoError(t, err)
 	assert.Equal(t, &models.Secret{
@@ -102,6 +110,10 @@ func TestService_Get_Fails_AnotherOwner(t *testing.T) {
 		Get(gomock.Any(), testID).
 		Return(&models.Secret{Owner: &models.User{ID: testutils.UNKNOWN}}, nil)
 
+	repo.EXPECT().
+		GetShare(gomock.Any(), testID, testOwnerID).
+		Return(nil, secrets.ErrShareNotFound)
+
 	_, err := service.Get(context.Background(), testID, testOwnerID, testPassphrase)
 	assert.ErrorIs(t, err, secrets.ErrAnotherOwner)
 }
@@ -175,6 +187,37 @@ func TestService_Get_Fails_Decrypt(t *testing.T) {
 	assert.ErrorIs(t, err, testutils.Err)
 }
 
+func TestService_Get_Fails_TouchErr(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	hasher := mocks.NewMockHasher(ctrl)
+	enc := mocks.NewMockEncryptor(ctrl)
+	service := secrets.NewService(repo, hasher, enc)
+
+	got := &models.Secret{Data: testContent, Owner: &models.User{ID: testOwnerID, PassphraseHash: testHash}}
+	repo.EXPECT().
+		Get(gomock.Any(), testID).
+		Return(got, nil)
+
+	hasher.EXPECT().
+		Compare(testHash, testPassphrase).
+		Return(true, nil)
+
+	enc.EXPECT().
+		Decrypt([]byte(testPassphrase), got.Data).
+		Return([]byte(testutils.STRING), nil)
+
+	repo.EXPECT().
+		Touch(gomock.Any(), testID).
+		Return(testutils.Err)
+
+	_, err := service.Get(context.Background(), testID, testOwnerID, testPassphrase)
+	assert.ErrorIs(t, err, testutils.Err)
+}
+
 func TestService_GetPage_Success(t *testing.T) {
 	t.Parallel()
 	ctrl := gomock.NewController(t)
@@ -247,11 +290,12 @@ func TestService_Create_Success(t *testing.T) {
 			Name:  testName,
 			Type:  models.SecretTypePwd,
 			Data:  testContent,
+			Size:  int64(len(testContent)),
 			Owner: owner,
 		}).
 		Return(testID, nil)
 
-	id, err := service.Create(context.Background(), testOwnerID, testPassphrase, testName, data)
+	id, _, err := service.Create(context.Background(), testOwnerID, testPassphrase, testName, data, secrets.Attrs{})
 	require.NoError(t, err)
 	assert.Equal(t, testID, id)
 }
@@ -268,7 +312,7 @@ func TestService_Create_Fails_Get(t *testing.T) {
 		GetOwner(gomock.Any(), testOwnerID).
 		Return(nil, testutils.Err)
 
-	_, err := service.Create(context.Background(), testOwnerID, testPassphrase, testName, nil)
+	_, _, err := service.Create(context.Background(), testOwnerID, testPassphrase, testName, nil, secrets.Attrs{})
 	assert.ErrorIs(t, err, testutils.Err)
 }
 
@@ -289,7 +333,7 @@ func TestService_Create_Fails_HashErr(t *testing.T) {
 		Compare(testHash, testPassphrase).
 		Return(false, testutils.Err)
 
-	_, err := service.Create(context.Background(), testOwnerID, testPassphrase, testName, nil)
+	_, _, err := service.Create(context.Background(), testOwnerID, testPassphrase, testName, nil, secrets.Attrs{})
 	assert.ErrorIs(t, err, testutils.Err)
 }
 
@@ -310,7 +354,7 @@ func TestService_Create_Fails_Compare(t *testing.T) {
 		Compare(testHash, testPassphrase).
 		Return(false, nil)
 
-	_, err := service.Create(context.Background(), testOwnerID, testPassphrase, testName, nil)
+	_, _, err := service.Create(context.Background(), testOwnerID, testPassphrase, testName, nil, secrets.Attrs{})
 	assert.ErrorIs(t, err, secrets.ErrInvalidPassphrase)
 }
 
@@ -337,7 +381,7 @@ func TestService_Create_Fails_Encrypt(t *testing.T) {
 		Encrypt([]byte(testPassphrase), []byte("{}")).
 		Return(nil, testutils.Err)
 
-	_, err := service.Create(context.Background(), testOwnerID, testPassphrase, testName, data)
+	_, _, err := service.Create(context.Background(), testOwnerID, testPassphrase, testName, data, secrets.Attrs{})
 	assert.ErrorIs(t, err, testutils.Err)
 }
 
@@ -372,11 +416,12 @@ func TestService_Create_Fails_Create(t *testing.T) {
 			Name:  testName,
 			Type:  models.SecretTypePwd,
 			Data:  testContent,
+			Size:  int64(len(testContent)),
 			Owner: owner,
 		}).
 		Return("", testutils.Err)
 
-	_, err := service.Create(context.Background(), testOwnerID, testPassphrase, testName, data)
+	_, _, err := service.Create(context.Background(), testOwnerID, testPassphrase, testName, data, secrets.Attrs{})
 	assert.ErrorIs(t, err, testutils.Err)
 }
 
@@ -416,11 +461,12 @@ func TestService_Update_Success(t *testing.T) {
 			Name:  testName,
 			Type:  secret.Type,
 			Data:  testContent,
+			Size:  int64(len(testContent)),
 			Owner: secret.Owner,
 		}).
 		Return(nil)
 
-	err := service.Update(context.Background(), testID, testOwnerID, testPassphrase, testName, data)
+	_, err := service.Update(context.Background(), testID, testOwnerID, testPassphrase, testName, data, secrets.Attrs{})
 	require.NoError(t, err)
 }
 
@@ -436,7 +482,7 @@ func TestService_Update_Fails_Get(t *testing.T) {
 		Get(gomock.Any(), testID).
 		Return(nil, testutils.Err)
 
-	err := service.Update(context.Background(), testID, testOwnerID, testPassphrase, testName, nil)
+	_, err := service.Update(context.Background(), testID, testOwnerID, testPassphrase, testName, nil, secrets.Attrs{})
 	assert.ErrorIs(t, err, testutils.Err)
 }
 
@@ -460,10 +506,27 @@ func TestService_Update_Fails_CheckType(t *testing.T) {
 	data := mocks.NewMockISecretData(ctrl)
 	data.EXPECT().SecretType().Return(0)
 
-	err := service.Update(context.Background(), testID, testOwnerID, testPassphrase, testName, data)
+	_, err := service.Update(context.Background(), testID, testOwnerID, testPassphrase, testName, data, secrets.Attrs{})
 	assert.ErrorIs(t, err, secrets.ErrInvalidSecretType)
 }
 
+func TestService_Update_Fails_Revision(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := secrets.NewService(repo, nil, nil)
+
+	repo.EXPECT().
+		Get(gomock.Any(), testID).
+		Return(&models.Secret{Type: models.SecretTypeTxt, Revision: 3, Owner: &models.User{ID: testOwnerID}}, nil)
+
+	_, err := service.Update(context.Background(), testID, testOwnerID, testPassphrase, testName, testNote,
+		secrets.Attrs{Revision: 2})
+	assert.ErrorIs(t, err, secrets.ErrRevisionMismatch)
+}
+
 func TestService_Update_Fails_CheckPassphrase(t *testing.T) {
 	t.Parallel()
 	ctrl := gomock.NewController(t)
@@ -488,7 +551,7 @@ func TestService_Update_Fails_CheckPassphrase(t *testing.T) {
 		Compare(secret.Owner.PassphraseHash, testPassphrase).
 		Return(false, nil)
 
-	err := service.Update(context.Background(), testID, testOwnerID, testPassphrase, testName, data)
+	_, err := service.Update(context.Background(), testID, testOwnerID, testPassphrase, testName, data, secrets.Attrs{})
 	assert.ErrorIs(t, err, secrets.ErrInvalidPassphrase)
 }
 
@@ -523,7 +586,7 @@ func TestService_Update_Fails_Encrypt(t *testing.T) {
 		Encrypt([]byte(testPassphrase), []byte("{}")).
 		Return(nil, testutils.Err)
 
-	err := service.Update(context.Background(), testID, testOwnerID, testPassphrase, testName, data)
+	_, err := service.Update(context.Background(), testID, testOwnerID, testPassphrase, testName, data, secrets.Attrs{})
 	assert.ErrorIs(t, err, testutils.Err)
 }
 
@@ -563,11 +626,12 @@ func TestService_Update_Fails_Update(t *testing.T) {
 			Name:  testName,
 			Type:  secret.Type,
 			Data:  testContent,
+			Size:  int64(len(testContent)),
 			Owner: secret.Owner,
 		}).
 		Return(testutils.Err)
 
-	err := service.Update(context.Background(), testID, testOwnerID, testPassphrase, testName, data)
+	_, err := service.Update(context.Background(), testID, testOwnerID, testPassphrase, testName, data, secrets.Attrs{})
 	assert.ErrorIs(t, err, testutils.Err)
 }
 
@@ -619,6 +683,10 @@ func TestService_Delete_Fails_AnotherOwner(t *testing.T) {
 		Get(gomock.Any(), testID).
 		Return(&models.Secret{Owner: &models.User{ID: testutils.UNKNOWN}}, nil)
 
+	repo.EXPECT().
+		GetShare(gomock.Any(), testID, testOwnerID).
+		Return(nil, secrets.ErrShareNotFound)
+
 	err := service.Delete(context.Background(), testID, testOwnerID)
 	assert.ErrorIs(t, err, secrets.ErrAnotherOwner)
 }
@@ -642,3 +710,652 @@ func TestService_Delete_Fails_Delete(t *testing.T) {
 	err := service.Delete(context.Background(), testID, testOwnerID)
 	assert.ErrorIs(t, err, testutils.Err)
 }
+
+func TestService_Create_Fails_Validate(t *testing.T) {
+	t.Parallel()
+
+	service := secrets.NewService(nil, nil, nil)
+
+	_, _, err := service.Create(
+		context.Background(), testOwnerID, testPassphrase, testName, &secrets.OTPData{}, secrets.Attrs{},
+	)
+	assert.ErrorIs(t, err, secrets.ErrInvalidSecretData)
+}
+
+func TestService_GenerateOTP_TOTP(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	hasher := mocks.NewMockHasher(ctrl)
+	enc := mocks.NewMockEncryptor(ctrl)
+	service := secrets.NewService(repo, hasher, enc)
+
+	repo.EXPECT().
+		Get(gomock.Any(), testID).
+		Return(&models.Secret{
+			Type:  models.SecretTypeOTP,
+			Data:  testContent,
+			Owner: &models.User{ID: testOwnerID, PassphraseHash: testHash},
+		}, nil)
+
+	hasher.EXPECT().
+		Compare(testHash, testPassphrase).
+		Return(true, nil)
+
+	enc.EXPECT().
+		Decrypt([]byte(testPassphrase), testContent).
+		Return([]byte(testOTPData), nil)
+
+	code, err := service.GenerateOTP(context.Background(), testID, testOwnerID, testPassphrase)
+	require.NoError(t, err)
+
+	want, _, err := testOTPKey().TOTP(code.ExpiresAt.Add(-time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, want, code.Code)
+	assert.WithinDuration(t, time.Now(), code.ExpiresAt, 30*time.Second)
+}
+
+func TestService_GenerateOTP_HOTP(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	hasher := mocks.NewMockHasher(ctrl)
+	enc := mocks.NewMockEncryptor(ctrl)
+	service := secrets.NewService(repo, hasher, enc)
+
+	owner := &models.User{ID: testOwnerID, PassphraseHash: testHash}
+	repo.EXPECT().
+		Get(gomock.Any(), testID).
+		Return(&models.Secret{Name: testName, Type: models.SecretTypeOTP, Data: testContent, Owner: owner}, nil)
+
+	hasher.EXPECT().
+		Compare(testHash, testPassphrase).
+		Return(true, nil)
+
+	enc.EXPECT().
+		Decrypt([]byte(testPassphrase), testContent).
+		Return([]byte(strings.Replace(testOTPData, otp.KindTOTP, otp.KindHOTP, 1)), nil)
+
+	enc.EXPECT().
+		Encrypt([]byte(testPassphrase), gomock.Any()).
+		DoAndReturn(func(_, data []byte) ([]byte, error) {
+			assert.Contains(t, string(data), `"counter":1`)
+
+			return testContent, nil
+		})
+
+	repo.EXPECT().
+		Update(gomock.Any(), testID, &models.Secret{
+			Name:  testName,
+			Type:  models.SecretTypeOTP,
+			Data:  testContent,
+			Size:  int64(len(testContent)),
+			Owner: owner,
+		}).
+		Return(nil)
+
+	code, err := service.GenerateOTP(context.Background(), testID, testOwnerID, testPassphrase)
+	require.NoError(t, err)
+
+	want, err := testOTPKey().HOTP(0)
+	require.NoError(t, err)
+	assert.Equal(t, &secrets.OTPCode{Code: want}, code)
+}
+
+func TestService_GenerateOTP_Fails_Get(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := secrets.NewService(repo, nil, nil)
+
+	repo.EXPECT().
+		Get(gomock.Any(), testID).
+		Return(nil, secrets.ErrSecretNotFound)
+
+	_, err := service.GenerateOTP(context.Background(), testID, testOwnerID, testPassphrase)
+	assert.ErrorIs(t, err, secrets.ErrSecretNotFound)
+}
+
+func TestService_GenerateOTP_Fails_SecretType(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	hasher := mocks.NewMockHasher(ctrl)
+	enc := mocks.NewMockEncryptor(ctrl)
+	service := secrets.NewService(repo, hasher, enc)
+
+	repo.EXPECT().
+		Get(gomock.Any(), testID).
+		Return(&models.Secret{
+			Type:  models.SecretTypePwd,
+			Data:  testContent,
+			Owner: &models.User{ID: testOwnerID, PassphraseHash: testHash},
+		}, nil)
+
+	hasher.EXPECT().
+		Compare(testHash, testPassphrase).
+		Return(true, nil)
+
+	_, err := service.GenerateOTP(context.Background(), testID, testOwnerID, testPassphrase)
+	assert.ErrorIs(t, err, secrets.ErrInvalidSecretType)
+}
+
+const testOTPData = `{
+	"kind":"totp",
+	"secret":"JBSWY3DPEHPK3PXP",
+	"algorithm":"SHA1",
+	"digits":6,
+	"period":30,
+	"counter":0,
+	"meta":{}
+}`
+
+func testOTPKey() *otp.Key {
+	return otp.NewKey("JBSWY3DPEHPK3PXP")
+}
+
+func TestService_Create_SSHKey(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	hasher := mocks.NewMockHasher(ctrl)
+	enc := mocks.NewMockEncryptor(ctrl)
+	service := secrets.NewService(repo, hasher, enc)
+
+	pair, err := sshkey.Generate(sshkey.AlgorithmEd25519, 0, "", "")
+	require.NoError(t, err)
+
+	fingerprint, err := sshkey.Fingerprint(pair.PublicKey)
+	require.NoError(t, err)
+
+	owner := &models.User{PassphraseHash: testHash}
+	repo.EXPECT().
+		GetOwner(gomock.Any(), testOwnerID).
+		Return(owner, nil)
+
+	hasher.EXPECT().
+		Compare(owner.PassphraseHash, testPassphrase).
+		Return(true, nil)
+
+	enc.EXPECT().
+		Encrypt([]byte(testPassphrase), gomock.Any()).
+		Return(testContent, nil)
+
+	repo.EXPECT().
+		Create(gomock.Any(), &models.Secret{
+			Name:        testName,
+			Type:        models.SecretTypeSSHKey,
+			Data:        testContent,
+			Size:        int64(len(testContent)),
+			Fingerprint: fingerprint,
+			Owner:       owner,
+		}).
+		Return(testID, nil)
+
+	id, _, err := service.Create(context.Background(), testOwnerID, testPassphrase, testName, &secrets.SSHKeyData{
+		PrivateKey: pair.PrivateKey,
+		PublicKey:  pair.PublicKey,
+	}, secrets.Attrs{})
+	require.NoError(t, err)
+	assert.Equal(t, testID, id)
+}
+
+func TestService_Create_SSHKey_Fails_Mismatch(t *testing.T) {
+	t.Parallel()
+
+	pair, err := sshkey.Generate(sshkey.AlgorithmEd25519, 0, "", "")
+	require.NoError(t, err)
+
+	other, err := sshkey.Generate(sshkey.AlgorithmEd25519, 0, "", "")
+	require.NoError(t, err)
+
+	service := secrets.NewService(nil, nil, nil)
+
+	_, _, err = service.Create(context.Background(), testOwnerID, testPassphrase, testName, &secrets.SSHKeyData{
+		PrivateKey: pair.PrivateKey,
+		PublicKey:  other.PublicKey,
+	}, secrets.Attrs{})
+	require.ErrorIs(t, err, secrets.ErrInvalidSecretData)
+	assert.ErrorIs(t, err, sshkey.ErrKeyMismatch)
+}
+
+const testTemplateID = models.TemplateID("template-id")
+
+func testTemplate(ownerID models.UserID) *models.Template {
+	return &models.Template{
+		ID:   testTemplateID,
+		Name: "wifi",
+		Fields: []models.TemplateField{
+			{Name: "ssid", Type: models.FieldTypeString, Required: true},
+			{Name: "router", Type: models.FieldTypeURL},
+			{Name: "admin", Type: models.FieldTypeEmail},
+			{Name: "since", Type: models.FieldTypeDate},
+			{Name: "channel", Type: models.FieldTypeNumber},
+		},
+		Owner: &models.User{ID: ownerID},
+	}
+}
+
+func TestService_Create_Custom(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	hasher := mocks.NewMockHasher(ctrl)
+	enc := mocks.NewMockEncryptor(ctrl)
+	service := secrets.NewService(repo, hasher, enc)
+
+	repo.EXPECT().
+		GetTemplate(gomock.Any(), testTemplateID).
+		Return(testTemplate(testOwnerID), nil)
+
+	owner := &models.User{PassphraseHash: testHash}
+	repo.EXPECT().
+		GetOwner(gomock.Any(), testOwnerID).
+		Return(owner, nil)
+
+	hasher.EXPECT().
+		Compare(owner.PassphraseHash, testPassphrase).
+		Return(true, nil)
+
+	enc.EXPECT().
+		Encrypt([]byte(testPassphrase), gomock.Any()).
+		Return(testContent, nil)
+
+	repo.EXPECT().
+		Create(gomock.Any(), &models.Secret{
+			Name:  testName,
+			Type:  models.SecretTypeCustom,
+			Data:  testContent,
+			Size:  int64(len(testContent)),
+			Owner: owner,
+		}).
+		Return(testID, nil)
+
+	id, _, err := service.Create(context.Background(), testOwnerID, testPassphrase, testName, &secrets.CustomData{
+		Template: testTemplateID,
+		Fields: map[string]string{
+			"ssid":    "home",
+			"router":  "http://192.168.0.1",
+			"admin":   "admin@example.com",
+			"since":   "2024-01-31",
+			"channel": "11",
+		},
+	}, secrets.Attrs{})
+	require.NoError(t, err)
+	assert.Equal(t, testID, id)
+}
+
+func TestService_Create_Custom_Fails_Match(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		fields map[string]string
+	}{
+		{name: "required", fields: map[string]string{"router": "http://192.168.0.1"}},
+		{name: "undeclared", fields: map[string]string{"ssid": "home", "password": "secret"}},
+		{name: "url", fields: map[string]string{"ssid": "home", "router": "192.168.0.1"}},
+		{name: "email", fields: map[string]string{"ssid": "home", "admin": "admin"}},
+		{name: "date", fields: map[string]string{"ssid": "home", "since": "31.01.2024"}},
+		{name: "number", fields: map[string]string{"ssid": "home", "channel": "eleven"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			repo := mocks.NewMockRepository(ctrl)
+			service := secrets.NewService(repo, nil, nil)
+
+			repo.EXPECT().
+				GetTemplate(gomock.Any(), testTemplateID).
+				Return(testTemplate(testOwnerID), nil)
+
+			_, _, err := service.Create(context.Background(), testOwnerID, testPassphrase, testName, &secrets.CustomData{
+				Template: testTemplateID,
+				Fields:   tt.fields,
+			}, secrets.Attrs{})
+			assert.ErrorIs(t, err, secrets.ErrInvalidSecretData)
+		})
+	}
+}
+
+func TestService_Create_Custom_Fails_AnotherOwner(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := secrets.NewService(repo, nil, nil)
+
+	repo.EXPECT().
+		GetTemplate(gomock.Any(), testTemplateID).
+		Return(testTemplate(models.UserID("another")), nil)
+
+	_, _, err := service.Create(context.Background(), testOwnerID, testPassphrase, testName, &secrets.CustomData{
+		Template: testTemplateID,
+		Fields:   map[string]string{"ssid": "home"},
+	}, secrets.Attrs{})
+	assert.ErrorIs(t, err, secrets.ErrTemplateNotFound)
+}
+
+func TestService_Report(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	hasher := mocks.NewMockHasher(ctrl)
+	enc := mocks.NewMockEncryptor(ctrl)
+	breaches := mocks.NewMockBreachChecker(ctrl)
+	service := secrets.NewService(repo, hasher, enc, secrets.WithBreachChecker(breaches))
+
+	breaches.EXPECT().Count("abc").Return(10, nil)
+	breaches.EXPECT().Count("Str0ng-P@ssw0rd-42!").Return(0, nil).Times(2)
+
+	now := time.Now()
+	expired := now.AddDate(0, -2, 0).Format("01/06")
+	valid := now.AddDate(2, 0, 0).Format("01/06")
+
+	repo.EXPECT().
+		GetOwner(gomock.Any(), testOwnerID).
+		Return(&models.User{ID: testOwnerID, PassphraseHash: testHash}, nil)
+
+	hasher.EXPECT().
+		Compare(testHash, testPassphrase).
+		Return(true, nil)
+
+	repo.EXPECT().
+		GetAll(gomock.Any(), testOwnerID).
+		Return([]models.Secret{
+			{ID: "weak", Name: "weak", Type: models.SecretTypePwd, Data: []byte(`{"password":"abc"}`), UpdatedAt: now},
+			{ID: "a", Name: "a", Type: models.SecretTypePwd, Data: []byte(`{"password":"Str0ng-P@ssw0rd-42!"}`), UpdatedAt: now},
+			{
+				ID: "b", Name: "b", Type: models.SecretTypePwd,
+				Data:      []byte(`{"password":"Str0ng-P@ssw0rd-42!"}`),
+				UpdatedAt: now.AddDate(-2, 0, 0),
+			},
+			{ID: "expired", Name: "expired", Type: models.SecretTypeCard, Data: []byte(`{"exp":"` + expired + `"}`)},
+			{ID: "valid", Name: "valid", Type: models.SecretTypeCard, Data: []byte(`{"exp":"` + valid + `"}`)},
+			{ID: "text", Name: "text", Type: models.SecretTypeTxt, Data: []byte(`{"content":"abc"}`)},
+		}, nil)
+
+	enc.EXPECT().
+		Decrypt([]byte(testPassphrase), gomock.Any()).
+		DoAndReturn(func(_, data []byte) ([]byte, error) { return data, nil }).
+		Times(5)
+
+	report, err := service.Report(context.Background(), testOwnerID, testPassphrase, secrets.ReportOptions{
+		MinEntropy:     60,
+		StaleAfter:     365 * 24 * time.Hour,
+		ExpiringWithin: 30 * 24 * time.Hour,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, report.Weak, 1)
+	assert.Equal(t, models.SecretID("weak"), report.Weak[0].ID)
+
+	assert.True(t, report.BreachChecked)
+	assert.Equal(t, []secrets.BreachedPassword{
+		{SecretRef: secrets.SecretRef{ID: "weak", Name: "weak"}, Count: 10},
+	}, report.Breached)
+
+	assert.Equal(t, [][]secrets.SecretRef{{{ID: "a", Name: "a"}, {ID: "b", Name: "b"}}}, report.Reused)
+
+	require.Len(t, report.Stale, 1)
+	assert.Equal(t, models.SecretID("b"), report.Stale[0].ID)
+
+	require.Len(t, report.Cards, 1)
+	assert.Equal(t, models.SecretID("expired"), report.Cards[0].ID)
+	assert.True(t, report.Cards[0].Expired)
+}
+
+func TestService_Report_Fails_Passphrase(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	hasher := mocks.NewMockHasher(ctrl)
+	enc := mocks.NewMockEncryptor(ctrl)
+	service := secrets.NewService(repo, hasher, enc)
+
+	repo.EXPECT().
+		GetOwner(gomock.Any(), testOwnerID).
+		Return(&models.User{ID: testOwnerID, PassphraseHash: testHash}, nil)
+
+	hasher.EXPECT().
+		Compare(testHash, testPassphrase).
+		Return(false, nil)
+
+	_, err := service.Report(context.Background(), testOwnerID, testPassphrase, secrets.ReportOptions{})
+	require.ErrorIs(t, err, secrets.ErrInvalidPassphrase)
+}
+
+func TestService_Create_BreachedPassword(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	tests := []struct {
+		name     string
+		count    int
+		err      error
+		warnings []secrets.Warning
+	}{
+		{
+			name:  "breached",
+			count: 42,
+			warnings: []secrets.Warning{{
+				Code:    secrets.WarningBreached,
+				Message: "the password has been seen 42 times in data breaches",
+			}},
+		},
+		{
+			name: "not breached",
+		},
+		{
+			name: "no data",
+			err:  testutils.Err,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := mocks.NewMockRepository(ctrl)
+			hasher := mocks.NewMockHasher(ctrl)
+			enc := mocks.NewMockEncryptor(ctrl)
+			breaches := mocks.NewMockBreachChecker(ctrl)
+			service := secrets.NewService(repo, hasher, enc, secrets.WithBreachChecker(breaches))
+
+			repo.EXPECT().
+				GetOwner(gomock.Any(), testOwnerID).
+				Return(&models.User{ID: testOwnerID, PassphraseHash: testHash}, nil)
+
+			hasher.EXPECT().
+				Compare(testHash, testPassphrase).
+				Return(true, nil)
+
+			enc.EXPECT().
+				Encrypt([]byte(testPassphrase), gomock.Any()).
+				Return(testContent, nil)
+
+			repo.EXPECT().
+				Create(gomock.Any(), gomock.Any()).
+				Return(testID, nil)
+
+			breaches.EXPECT().
+				Count("password").
+				Return(tt.count, tt.err)
+
+			id, warnings, err := service.Create(
+				context.Background(), testOwnerID, testPassphrase, testName,
+				&secrets.PasswordData{Login: "login", Password: "password"},
+				secrets.Attrs{},
+			)
+			require.NoError(t, err)
+			assert.Equal(t, testID, id)
+			assert.Equal(t, tt.warnings, warnings)
+		})
+	}
+}
+
+func TestService_Create_CardExpiresAt(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	hasher := mocks.NewMockHasher(ctrl)
+	enc := mocks.NewMockEncryptor(ctrl)
+	service := secrets.NewService(repo, hasher, enc)
+
+	repo.EXPECT().
+		GetOwner(gomock.Any(), testOwnerID).
+		Return(&models.User{ID: testOwnerID, PassphraseHash: testHash}, nil)
+
+	hasher.EXPECT().
+		Compare(testHash, testPassphrase).
+		Return(true, nil)
+
+	enc.EXPECT().
+		Encrypt([]byte(testPassphrase), gomock.Any()).
+		Return(testContent, nil)
+
+	repo.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, secret *models.Secret) (models.SecretID, error) {
+			assert.Equal(t, time.Date(2031, 1, 1, 0, 0, 0, 0, time.UTC), secret.ExpiresAt)
+			assert.Equal(t, 24*time.Hour, secret.RotateEvery)
+			assert.Equal(t, map[string]string{secrets.PreviewBrand: "Visa", secrets.PreviewLast4: "1111"}, secret.Preview)
+
+			return testID, nil
+		})
+
+	_, _, err := service.Create(
+		context.Background(), testOwnerID, testPassphrase, testName,
+		&secrets.CardData{Number: "4111111111111111", Exp: "12/30", CVV: "123"},
+		secrets.Attrs{ExpiresAt: time.Now(), RotateEvery: 24 * time.Hour},
+	)
+	require.NoError(t, err)
+}
+
+func TestService_Create_Card_Expired(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	hasher := mocks.NewMockHasher(ctrl)
+	enc := mocks.NewMockEncryptor(ctrl)
+	service := secrets.NewService(repo, hasher, enc)
+
+	repo.EXPECT().
+		GetOwner(gomock.Any(), testOwnerID).
+		Return(&models.User{ID: testOwnerID, PassphraseHash: testHash}, nil)
+	hasher.EXPECT().Compare(testHash, testPassphrase).Return(true, nil)
+	enc.EXPECT().Encrypt([]byte(testPassphrase), gomock.Any()).Return(testContent, nil)
+	repo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(testID, nil)
+
+	id, warnings, err := service.Create(context.Background(), testOwnerID, testPassphrase, testName,
+		&secrets.CardData{Number: "4111111111111111", Exp: "01/20", CVV: "123"}, secrets.Attrs{})
+	require.NoError(t, err)
+	assert.Equal(t, testID, id)
+	assert.Equal(t, []secrets.Warning{{Code: secrets.WarningExpired, Message: "the card is expired"}}, warnings)
+}
+
+func TestService_Create_Card_Fails_Validate(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	service := secrets.NewService(mocks.NewMockRepository(ctrl), nil, nil)
+
+	for _, data := range []*secrets.CardData{
+		{Number: "4111111111111112", Exp: "12/30", CVV: "123"},
+		{Number: "4111111111111111", Exp: "30/12", CVV: "123"},
+		{Number: "378282246310005", Exp: "12/30", CVV: "123"},
+		{Number: "some", Exp: "12/30", CVV: "123"},
+		{Number: "4111111111111111", Exp: "12/30", CVV: "12a"},
+	} {
+		_, _, err := service.Create(context.Background(), testOwnerID, testPassphrase, testName, data, secrets.Attrs{})
+		require.ErrorIs(t, err, secrets.ErrInvalidSecretData, data.Number)
+	}
+}
+
+func TestService_GetDue(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := secrets.NewService(repo, nil, nil)
+
+	updatedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	expiresAt := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	expired := models.Secret{ID: "expired", ExpiresAt: expiresAt, RotateEvery: 90 * 24 * time.Hour, UpdatedAt: updatedAt}
+	rotation := models.Secret{ID: "rotation", ExpiresAt: expiresAt, RotateEvery: 30 * 24 * time.Hour, UpdatedAt: updatedAt}
+
+	repo.EXPECT().
+		GetDue(gomock.Any(), testOwnerID).
+		Return([]models.Secret{expired, rotation}, nil)
+
+	due, err := service.GetDue(context.Background(), testOwnerID)
+	require.NoError(t, err)
+	assert.Equal(t, []secrets.DueSecret{
+		{Secret: expired, Reason: secrets.DueReasonExpired, DueAt: expiresAt},
+		{Secret: rotation, Reason: secrets.DueReasonRotation, DueAt: updatedAt.Add(30 * 24 * time.Hour)},
+	}, due)
+}
+
+func TestService_NotifyDue(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	notifier := mocks.NewMockNotifier(ctrl)
+	service := secrets.NewService(repo, nil, nil)
+
+	expiresAt := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	repo.EXPECT().
+		GetDueToNotify(gomock.Any()).
+		Return([]models.Secret{{ID: "1", ExpiresAt: expiresAt}, {ID: "2", ExpiresAt: expiresAt}}, nil)
+
+	notifier.EXPECT().
+		Notify(gomock.Any(), secrets.DueSecret{
+			Secret: models.Secret{ID: "1", ExpiresAt: expiresAt},
+			Reason: secrets.DueReasonExpired,
+			DueAt:  expiresAt,
+		}).
+		Return(nil)
+
+	notifier.EXPECT().
+		Notify(gomock.Any(), gomock.Any()).
+		Return(testutils.Err)
+
+	repo.EXPECT().
+		MarkNotified(gomock.Any(), models.SecretID("1")).
+		Return(nil)
+
+	sent, err := service.NotifyDue(context.Background(), notifier)
+	require.ErrorIs(t, err, testutils.Err)
+	assert.Equal(t, 1, sent)
+}
@@ -0,0 +1,175 @@
+package secrets
+
+import (
+	"context"
+	"crypto/rand"
+
+	"github.com/novoseltcev/passkeeper/internal/models"
+	"github.com/novoseltcev/passkeeper/pkg/sealbox"
+)
+
+func (s *service) Share(
+	ctx context.Context,
+	id models.SecretID, ownerID models.UserID,
+	passphrase string,
+	login string, access models.ShareAccess,
+) error {
+	secret, err := s.getMySecret(ctx, id, ownerID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.checkPassphrase(secret.Owner, passphrase); err != nil {
+		return err
+	}
+
+	recipient, err := s.repo.GetUserByLogin(ctx, login)
+	if err != nil {
+		return err
+	}
+
+	if recipient.ID == ownerID {
+		return ErrInvalidRecipient
+	}
+
+	if len(recipient.PublicKey) == 0 {
+		return ErrNoPublicKey
+	}
+
+	key, err := s.unlock(secret, grant{}, passphrase)
+	if err != nil {
+		return err
+	}
+
+	// The data of a secret shared for the first time is encrypted with the passphrase,
+	// so it is moved to a data key, which can be given to recipients.
+	if len(secret.Key) == 0 {
+		if key, err = s.rotateKey(ctx, s.repo, secret, passphrase, key, nil); err != nil {
+			return err
+		}
+	}
+
+	sealed, err := sealbox.Seal(recipient.PublicKey, key)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.SaveShare(ctx, &models.Share{Secret: secret, Recipient: recipient, Key: sealed, Access: access})
+}
+
+func (s *service) Revoke(
+	ctx context.Context,
+	id models.SecretID, ownerID models.UserID,
+	passphrase string,
+	recipientID models.UserID,
+) error {
+	secret, err := s.getMySecret(ctx, id, ownerID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.checkPassphrase(secret.Owner, passphrase); err != nil {
+		return err
+	}
+
+	key, err := s.unlock(secret, grant{}, passphrase)
+	if err != nil {
+		return err
+	}
+
+	shares, err := s.repo.GetShares(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	remaining := make([]models.Share, 0, len(shares))
+	for _, share := range shares {
+		if share.Recipient.ID != recipientID {
+			remaining = append(remaining, share)
+		}
+	}
+
+	if len(remaining) == len(shares) {
+		return ErrShareNotFound
+	}
+
+	// The revoked recipient could keep the data key, so the data is encrypted with a new one.
+	// The share is deleted only with the rotation, not to leave the data under the revoked key.
+	return s.repo.Atomic(ctx, func(repo Repository) error {
+		if err := repo.DeleteShare(ctx, id, recipientID); err != nil {
+			return err
+		}
+
+		_, err := s.rotateKey(ctx, repo, secret, passphrase, key, remaining)
+
+		return err
+	})
+}
+
+func (s *service) GetShares(ctx context.Context, id models.SecretID, ownerID models.UserID) ([]models.Share, error) {
+	if _, err := s.getMySecret(ctx, id, ownerID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetShares(ctx, id)
+}
+
+func (s *service) GetSharedWithMe(ctx context.Context, userID models.UserID) ([]models.Share, error) {
+	return s.repo.GetSharedWith(ctx, userID)
+}
+
+// rotateKey encrypts the secret data with a new data key and gives it to the owner and the recipients.
+//
+// The new key is escrowed if the old one was.
+func (s *service) rotateKey(
+	ctx context.Context,
+	repo Repository,
+	secret *models.Secret,
+	passphrase string,
+	oldKey []byte,
+	shares []models.Share,
+) ([]byte, error) {
+	key := make([]byte, sealbox.KeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+
+	encData, err := s.encData(ctx, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := s.enc.Decrypt(oldKey, encData)
+	if err != nil {
+		return nil, err
+	}
+
+	if encData, err = s.enc.Encrypt(key, data); err != nil {
+		return nil, err
+	}
+
+	if secret.Key, err = s.enc.Encrypt([]byte(passphrase), key); err != nil {
+		return nil, err
+	}
+
+	if len(secret.EscrowKey) > 0 {
+		escrowKey, err := s.ownerEscrowKey(ctx, repo, secret.Owner.ID, passphrase)
+		if err != nil {
+			return nil, err
+		}
+
+		if secret.EscrowKey, err = s.enc.Encrypt(escrowKey, key); err != nil {
+			return nil, err
+		}
+	}
+
+	for i := range shares {
+		if shares[i].Key, err = sealbox.Seal(shares[i].Recipient.PublicKey, key); err != nil {
+			return nil, err
+		}
+	}
+
+	return key, s.writeData(ctx, secret, encData, func() error {
+		return repo.Rekey(ctx, secret, shares)
+	})
+}
@@ -0,0 +1,349 @@
+package secrets_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/novoseltcev/passkeeper/internal/domains/secrets"
+	"github.com/novoseltcev/passkeeper/internal/domains/secrets/mocks"
+	"github.com/novoseltcev/passkeeper/internal/models"
+	"github.com/novoseltcev/passkeeper/pkg/aes"
+	"github.com/novoseltcev/passkeeper/pkg/sealbox"
+	"github.com/novoseltcev/passkeeper/pkg/testutils"
+)
+
+const (
+	testRecipientID         = models.UserID("recipient-id")
+	testRecipientLogin      = "recipient"
+	testRecipientPassphrase = "recipient-passphrase"
+	testRecipientHash       = "recipient-hash"
+)
+
+func newRecipient(t *testing.T, enc secrets.Encryptor) *models.User {
+	t.Helper()
+
+	public, private, err := sealbox.GenerateKey()
+	require.NoError(t, err)
+
+	encPrivate, err := enc.Encrypt([]byte(testRecipientPassphrase), private)
+	require.NoError(t, err)
+
+	return &models.User{
+		ID:             testRecipientID,
+		Login:          testRecipientLogin,
+		PassphraseHash: testRecipientHash,
+		PublicKey:      public,
+		PrivateKey:     encPrivate,
+	}
+}
+
+func newSharedSecret(t *testing.T, enc secrets.Encryptor) *models.Secret {
+	t.Helper()
+
+	data, err := enc.Encrypt([]byte(testPassphrase), testContent)
+	require.NoError(t, err)
+
+	return &models.Secret{
+		ID:    testID,
+		Type:  models.SecretTypeTxt,
+		Data:  data,
+		Owner: &models.User{ID: testOwnerID, PassphraseHash: testHash},
+	}
+}
+
+func TestService_Share_Success(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	hasher := mocks.NewMockHasher(ctrl)
+	enc := aes.New(aes.AES256BitKeyLength)
+	service := secrets.NewService(repo, hasher, enc)
+
+	secret := newSharedSecret(t, enc)
+	recipient := newRecipient(t, enc)
+
+	repo.EXPECT().Get(gomock.Any(), testID).Return(secret, nil)
+	hasher.EXPECT().Compare(testHash, testPassphrase).Return(true, nil)
+	repo.EXPECT().GetUserByLogin(gomock.Any(), testRecipientLogin).Return(recipient, nil)
+	repo.EXPECT().Rekey(gomock.Any(), secret, []models.Share(nil)).Return(nil)
+
+	var share *models.Share
+	repo.EXPECT().
+		SaveShare(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, s *models.Share) error {
+			share = s
+
+			return nil
+		})
+
+	err := service.Share(
+		context.Background(), testID, testOwnerID, testPassphrase, testRecipientLogin, models.ShareAccessRead,
+	)
+	require.NoError(t, err)
+	assert.NotEmpty(t, secret.Key)
+	assert.Equal(t, models.ShareAccessRead, share.Access)
+	assert.Equal(t, recipient, share.Recipient)
+
+	// The recipient reads the secret with the own passphrase.
+	repo.EXPECT().Get(gomock.Any(), testID).Return(secret, nil)
+	repo.EXPECT().GetShare(gomock.Any(), testID, testRecipientID).Return(share, nil)
+	hasher.EXPECT().Compare(testRecipientHash, testRecipientPassphrase).Return(true, nil)
+	repo.EXPECT().Touch(gomock.Any(), testID).Return(nil)
+
+	got, err := service.Get(context.Background(), testID, testRecipientID, testRecipientPassphrase)
+	require.NoError(t, err)
+	assert.Equal(t, testContent, []byte(got.Data))
+}
+
+func TestService_Share_Fails_InvalidRecipient(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	hasher := mocks.NewMockHasher(ctrl)
+	service := secrets.NewService(repo, hasher, nil)
+
+	owner := &models.User{ID: testOwnerID, Login: testRecipientLogin, PassphraseHash: testHash}
+	repo.EXPECT().Get(gomock.Any(), testID).Return(&models.Secret{Owner: owner}, nil)
+	hasher.EXPECT().Compare(testHash, testPassphrase).Return(true, nil)
+	repo.EXPECT().GetUserByLogin(gomock.Any(), testRecipientLogin).Return(owner, nil)
+
+	err := service.Share(
+		context.Background(), testID, testOwnerID, testPassphrase, testRecipientLogin, models.ShareAccessRead,
+	)
+	assert.ErrorIs(t, err, secrets.ErrInvalidRecipient)
+}
+
+func TestService_Share_Fails_NoPublicKey(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	hasher := mocks.NewMockHasher(ctrl)
+	service := secrets.NewService(repo, hasher, nil)
+
+	repo.EXPECT().
+		Get(gomock.Any(), testID).
+		Return(&models.Secret{Owner: &models.User{ID: testOwnerID, PassphraseHash: testHash}}, nil)
+	hasher.EXPECT().Compare(testHash, testPassphrase).Return(true, nil)
+	repo.EXPECT().
+		GetUserByLogin(gomock.Any(), testRecipientLogin).
+		Return(&models.User{ID: testRecipientID}, nil)
+
+	err := service.Share(
+		context.Background(), testID, testOwnerID, testPassphrase, testRecipientLogin, models.ShareAccessRead,
+	)
+	assert.ErrorIs(t, err, secrets.ErrNoPublicKey)
+}
+
+func TestService_Share_Fails_AnotherOwner(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := secrets.NewService(repo, nil, nil)
+
+	repo.EXPECT().
+		Get(gomock.Any(), testID).
+		Return(&models.Secret{Owner: &models.User{ID: testRecipientID}}, nil)
+
+	err := service.Share(
+		context.Background(), testID, testOwnerID, testPassphrase, testRecipientLogin, models.ShareAccessRead,
+	)
+	assert.ErrorIs(t, err, secrets.ErrAnotherOwner)
+}
+
+func TestService_Update_Fails_ReadOnly(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := secrets.NewService(repo, nil, nil)
+
+	repo.EXPECT().
+		Get(gomock.Any(), testID).
+		Return(&models.Secret{Type: models.SecretTypeTxt, Owner: &models.User{ID: testOwnerID}}, nil)
+	repo.EXPECT().
+		GetShare(gomock.Any(), testID, testRecipientID).
+		Return(&models.Share{Access: models.ShareAccessRead}, nil)
+
+	_, err := service.Update(
+		context.Background(),
+		testID, testRecipientID,
+		testRecipientPassphrase,
+		testName, &secrets.TextData{Content: "text"},
+		secrets.Attrs{},
+	)
+	assert.ErrorIs(t, err, secrets.ErrReadOnly)
+}
+
+func TestService_Update_Shared_Success(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	hasher := mocks.NewMockHasher(ctrl)
+	enc := aes.New(aes.AES256BitKeyLength)
+	service := secrets.NewService(repo, hasher, enc)
+
+	secret := newSharedSecret(t, enc)
+	recipient := newRecipient(t, enc)
+
+	key := make([]byte, sealbox.KeySize)
+	sealed, err := sealbox.Seal(recipient.PublicKey, key)
+	require.NoError(t, err)
+
+	repo.EXPECT().Get(gomock.Any(), testID).Return(secret, nil)
+	repo.EXPECT().
+		GetShare(gomock.Any(), testID, testRecipientID).
+		Return(&models.Share{Secret: secret, Recipient: recipient, Key: sealed, Access: models.ShareAccessWrite}, nil)
+	hasher.EXPECT().Compare(testRecipientHash, testRecipientPassphrase).Return(true, nil)
+	repo.EXPECT().
+		Update(gomock.Any(), testID, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ models.SecretID, s *models.Secret) error {
+			data, err := enc.Decrypt(key, s.Data)
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"content":"text","meta":null}`, string(data))
+
+			return nil
+		})
+
+	_, err = service.Update(
+		context.Background(),
+		testID, testRecipientID,
+		testRecipientPassphrase,
+		testName, &secrets.TextData{Content: "text"},
+		secrets.Attrs{},
+	)
+	require.NoError(t, err)
+}
+
+func TestService_Revoke_Success(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	hasher := mocks.NewMockHasher(ctrl)
+	enc := aes.New(aes.AES256BitKeyLength)
+	service := secrets.NewService(repo, hasher, enc)
+
+	key := make([]byte, sealbox.KeySize)
+	secret := newSharedSecret(t, enc)
+	data, err := enc.Encrypt(key, testContent)
+	require.NoError(t, err)
+	secret.Data = data
+	secret.Key, err = enc.Encrypt([]byte(testPassphrase), key)
+	require.NoError(t, err)
+
+	remaining := newRecipient(t, enc)
+	remaining.ID = "remaining-id"
+	revoked := &models.User{ID: testRecipientID}
+
+	repo.EXPECT().Get(gomock.Any(), testID).Return(secret, nil)
+	hasher.EXPECT().Compare(testHash, testPassphrase).Return(true, nil)
+	repo.EXPECT().
+		GetShares(gomock.Any(), testID).
+		Return([]models.Share{{Recipient: revoked}, {Recipient: remaining}}, nil)
+	expectAtomic(repo)
+	repo.EXPECT().DeleteShare(gomock.Any(), testID, testRecipientID).Return(nil)
+	repo.EXPECT().
+		Rekey(gomock.Any(), secret, gomock.Any()).
+		DoAndReturn(func(_ context.Context, s *models.Secret, shares []models.Share) error {
+			require.Len(t, shares, 1)
+			assert.Equal(t, remaining, shares[0].Recipient)
+
+			private, err := enc.Decrypt([]byte(testRecipientPassphrase), remaining.PrivateKey)
+			require.NoError(t, err)
+			newKey, err := sealbox.Open(private, shares[0].Key)
+			require.NoError(t, err)
+			assert.NotEqual(t, key, newKey)
+
+			data, err := enc.Decrypt(newKey, s.Data)
+			require.NoError(t, err)
+			assert.Equal(t, testContent, data)
+
+			return nil
+		})
+
+	err = service.Revoke(context.Background(), testID, testOwnerID, testPassphrase, testRecipientID)
+	require.NoError(t, err)
+}
+
+func TestService_Revoke_Fails_Rekey(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	hasher := mocks.NewMockHasher(ctrl)
+	enc := aes.New(aes.AES256BitKeyLength)
+	service := secrets.NewService(repo, hasher, enc)
+
+	key := make([]byte, sealbox.KeySize)
+	secret := newSharedSecret(t, enc)
+	data, err := enc.Encrypt(key, testContent)
+	require.NoError(t, err)
+	secret.Data = data
+	secret.Key, err = enc.Encrypt([]byte(testPassphrase), key)
+	require.NoError(t, err)
+
+	repo.EXPECT().Get(gomock.Any(), testID).Return(secret, nil)
+	hasher.EXPECT().Compare(testHash, testPassphrase).Return(true, nil)
+	repo.EXPECT().
+		GetShares(gomock.Any(), testID).
+		Return([]models.Share{{Recipient: &models.User{ID: testRecipientID}}}, nil)
+	// the share is deleted in the transaction of the rotation, which is rolled back with it
+	expectAtomic(repo)
+	repo.EXPECT().DeleteShare(gomock.Any(), testID, testRecipientID).Return(nil)
+	repo.EXPECT().Rekey(gomock.Any(), secret, gomock.Any()).Return(testutils.Err)
+
+	err = service.Revoke(context.Background(), testID, testOwnerID, testPassphrase, testRecipientID)
+	require.ErrorIs(t, err, testutils.Err)
+}
+
+func TestService_Revoke_Fails_ShareNotFound(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	hasher := mocks.NewMockHasher(ctrl)
+	service := secrets.NewService(repo, hasher, nil)
+
+	repo.EXPECT().
+		Get(gomock.Any(), testID).
+		Return(&models.Secret{Owner: &models.User{ID: testOwnerID, PassphraseHash: testHash}}, nil)
+	hasher.EXPECT().Compare(testHash, testPassphrase).Return(true, nil)
+	repo.EXPECT().GetShares(gomock.Any(), testID).Return(nil, nil)
+
+	err := service.Revoke(context.Background(), testID, testOwnerID, testPassphrase, testRecipientID)
+	assert.ErrorIs(t, err, secrets.ErrShareNotFound)
+}
+
+func TestService_GetShares_Fails_AnotherOwner(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := secrets.NewService(repo, nil, nil)
+
+	repo.EXPECT().
+		Get(gomock.Any(), testID).
+		Return(&models.Secret{Owner: &models.User{ID: testRecipientID}}, nil)
+
+	_, err := service.GetShares(context.Background(), testID, testOwnerID)
+	assert.ErrorIs(t, err, secrets.ErrAnotherOwner)
+}
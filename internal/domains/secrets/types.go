@@ -0,0 +1,45 @@
+package secrets
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/novoseltcev/passkeeper/internal/models"
+)
+
+// dataTypes are the constructors of the decrypted data by the secret type.
+var dataTypes = make(map[models.SecretType]func() ISecretData)
+
+// RegisterData registers the constructor of a pointer to empty data, the type is taken from the data.
+//
+// It must be called on init, it panics if the type is already registered.
+func RegisterData(newData func() ISecretData) {
+	t := newData().SecretType()
+	if _, ok := dataTypes[t]; ok {
+		panic(fmt.Sprintf("secret data %d is already registered", t))
+	}
+
+	dataTypes[t] = newData
+}
+
+// NewData returns a pointer to empty data of the registered type.
+func NewData(t models.SecretType) (ISecretData, bool) {
+	newData, ok := dataTypes[t]
+	if !ok {
+		return nil, false
+	}
+
+	return newData(), true
+}
+
+// DataTypes returns the types of all registered data ordered by ID.
+func DataTypes() []models.SecretType {
+	result := make([]models.SecretType, 0, len(dataTypes))
+	for t := range dataTypes {
+		result = append(result, t)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+
+	return result
+}
@@ -0,0 +1,44 @@
+package secrets_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/novoseltcev/passkeeper/internal/domains/secrets"
+	"github.com/novoseltcev/passkeeper/internal/models"
+)
+
+func TestNewData(t *testing.T) {
+	t.Parallel()
+
+	for _, secretType := range []models.SecretType{
+		models.SecretTypePwd,
+		models.SecretTypeCard,
+		models.SecretTypeTxt,
+		models.SecretTypeFile,
+		models.SecretTypeOTP,
+		models.SecretTypeSSHKey,
+		models.SecretTypeCustom,
+	} {
+		data, ok := secrets.NewData(secretType)
+		require.True(t, ok, secretType)
+		assert.Equal(t, secretType, data.SecretType())
+
+		// each call returns new data
+		other, _ := secrets.NewData(secretType)
+		assert.NotSame(t, data, other)
+	}
+
+	_, ok := secrets.NewData(models.SecretType(0))
+	assert.False(t, ok)
+}
+
+func TestRegisterData_Fails_Duplicate(t *testing.T) {
+	t.Parallel()
+
+	assert.Panics(t, func() {
+		secrets.RegisterData(func() secrets.ISecretData { return &secrets.PasswordData{} })
+	})
+}
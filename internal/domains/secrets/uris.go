@@ -0,0 +1,89 @@
+package secrets
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"slices"
+
+	"github.com/novoseltcev/passkeeper/internal/models"
+	"github.com/novoseltcev/passkeeper/pkg/urimatch"
+)
+
+// WithURIIndex enables the lookup of passwords by URL with the index of their URIs keyed by the key.
+//
+// The index keeps HMACs of the hosts and the domains of the URIs with a key of each owner derived from the key,
+// so the URIs are not readable without it and the same URIs of different owners are not linkable.
+func WithURIIndex(key []byte) Option {
+	return func(s *service) {
+		s.uriKey = key
+	}
+}
+
+func (s *service) Match(ctx context.Context, ownerID models.UserID, rawURL string) ([]models.Secret, error) {
+	if s.uriKey == nil {
+		return nil, ErrMatchDisabled
+	}
+
+	keys, err := urimatch.LookupKeys(rawURL)
+	if err != nil {
+		return nil, ErrInvalidURL
+	}
+
+	return s.repo.GetByURITokens(ctx, ownerID, s.uriIndexTokens(ownerID, keys))
+}
+
+// uriTokens returns the tokens of the index of the password URIs, which the secret of the owner is found by.
+func (s *service) uriTokens(ownerID models.UserID, data ISecretData) []string {
+	pwd, ok := data.(*PasswordData)
+	if !ok || s.uriKey == nil {
+		return nil
+	}
+
+	var keys []string
+	for _, uri := range pwd.URIs {
+		keys = append(keys, urimatch.Keys(uri.URI, uri.Match)...)
+	}
+
+	slices.Sort(keys)
+
+	return s.uriIndexTokens(ownerID, slices.Compact(keys))
+}
+
+// uriTokensOf returns the tokens of the index of the decrypted data of the type.
+func (s *service) uriTokensOf(ownerID models.UserID, secretType models.SecretType, plain []byte) []string {
+	if secretType != models.SecretTypePwd || s.uriKey == nil {
+		return nil
+	}
+
+	var pwd PasswordData
+	if err := json.Unmarshal(plain, &pwd); err != nil {
+		return nil
+	}
+
+	return s.uriTokens(ownerID, &pwd)
+}
+
+func (s *service) uriIndexTokens(ownerID models.UserID, keys []string) []string {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	ownerKey := hmacSHA256(s.uriKey, []byte(ownerID))
+
+	tokens := make([]string, len(keys))
+	for i, key := range keys {
+		tokens[i] = hex.EncodeToString(hmacSHA256(ownerKey, []byte(key)))
+	}
+
+	return tokens
+}
+
+func hmacSHA256(key, data []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(data)
+
+	return mac.Sum(nil)
+}
@@ -0,0 +1,157 @@
+package secrets_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/novoseltcev/passkeeper/internal/domains/secrets"
+	"github.com/novoseltcev/passkeeper/internal/domains/secrets/mocks"
+	"github.com/novoseltcev/passkeeper/internal/models"
+	"github.com/novoseltcev/passkeeper/pkg/urimatch"
+)
+
+var testURIKey = []byte("uri-key")
+
+func setupURIs(t *testing.T, opts ...secrets.Option) (*mocks.MockRepository, *mocks.MockEncryptor, secrets.Service) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	hasher := mocks.NewMockHasher(ctrl)
+	enc := mocks.NewMockEncryptor(ctrl)
+
+	hasher.EXPECT().Compare(testHash, testPassphrase).Return(true, nil).AnyTimes()
+
+	return repo, enc, secrets.NewService(repo, hasher, enc, opts...)
+}
+
+// createIndexed creates the password with the URIs and returns the tokens of its index.
+func createIndexed(t *testing.T, ownerID models.UserID, uris ...secrets.URI) []string {
+	t.Helper()
+	repo, enc, service := setupURIs(t, secrets.WithURIIndex(testURIKey))
+
+	repo.EXPECT().GetOwner(gomock.Any(), ownerID).Return(&models.User{ID: ownerID, PassphraseHash: testHash}, nil)
+	enc.EXPECT().Encrypt([]byte(testPassphrase), gomock.Any()).Return(testContent, nil)
+
+	var tokens []string
+
+	repo.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, secret *models.Secret) (models.SecretID, error) {
+			tokens = secret.URITokens
+
+			return testID, nil
+		})
+
+	_, _, err := service.Create(context.Background(), ownerID, testPassphrase, testName,
+		&secrets.PasswordData{Login: "login", Password: "password", URIs: uris}, secrets.Attrs{})
+	require.NoError(t, err)
+
+	return tokens
+}
+
+func TestService_Create_URIs_Indexed(t *testing.T) {
+	t.Parallel()
+
+	tokens := createIndexed(t, testOwnerID,
+		secrets.URI{URI: "github.com"},
+		secrets.URI{URI: "https://gist.github.com", Match: urimatch.Domain},
+		secrets.URI{URI: "https://example.com/app", Match: urimatch.Prefix},
+	)
+	require.Len(t, tokens, 2, "the same domains have the same token")
+
+	for _, token := range tokens {
+		assert.NotContains(t, token, "github")
+		assert.NotContains(t, token, "example")
+	}
+
+	other := createIndexed(t, models.UserID("other-id"), secrets.URI{URI: "github.com"})
+	assert.NotContains(t, tokens, other[0], "the tokens of other owners differ")
+}
+
+func TestService_Create_URIs_NotIndexed(t *testing.T) {
+	t.Parallel()
+	repo, enc, service := setupURIs(t)
+
+	repo.EXPECT().GetOwner(gomock.Any(), testOwnerID).Return(&models.User{ID: testOwnerID, PassphraseHash: testHash}, nil)
+	enc.EXPECT().Encrypt([]byte(testPassphrase), gomock.Any()).Return(testContent, nil)
+	repo.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, secret *models.Secret) (models.SecretID, error) {
+			assert.Empty(t, secret.URITokens)
+
+			return testID, nil
+		})
+
+	_, _, err := service.Create(context.Background(), testOwnerID, testPassphrase, testName,
+		&secrets.PasswordData{URIs: []secrets.URI{{URI: "github.com"}}}, secrets.Attrs{})
+	require.NoError(t, err)
+}
+
+func TestService_Create_URIs_Fails_Invalid(t *testing.T) {
+	t.Parallel()
+	_, _, service := setupURIs(t, secrets.WithURIIndex(testURIKey))
+
+	for _, uri := range []secrets.URI{{URI: ""}, {URI: "(", Match: urimatch.Regex}, {URI: "github.com", Match: "exact"}} {
+		_, _, err := service.Create(context.Background(), testOwnerID, testPassphrase, testName,
+			&secrets.PasswordData{URIs: []secrets.URI{uri}}, secrets.Attrs{})
+		require.ErrorIs(t, err, secrets.ErrInvalidSecretData, uri.URI)
+	}
+}
+
+func TestService_Match(t *testing.T) {
+	t.Parallel()
+
+	tokens := createIndexed(t, testOwnerID, secrets.URI{URI: "github.com"})
+	regex := createIndexed(t, testOwnerID, secrets.URI{URI: ".*", Match: urimatch.Regex})
+	host := createIndexed(t, testOwnerID, secrets.URI{URI: "github.com", Match: urimatch.Host})
+
+	repo, _, service := setupURIs(t, secrets.WithURIIndex(testURIKey))
+
+	found := []models.Secret{{ID: testID}}
+
+	repo.EXPECT().
+		GetByURITokens(gomock.Any(), testOwnerID, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ models.UserID, lookup []string) ([]models.Secret, error) {
+			assert.Subset(t, lookup, tokens)
+			assert.Subset(t, lookup, regex)
+			assert.NotContains(t, lookup, host[0])
+
+			return found, nil
+		})
+
+	matched, err := service.Match(context.Background(), testOwnerID, "https://gist.github.com/login")
+	require.NoError(t, err)
+	assert.Equal(t, found, matched)
+}
+
+func TestService_Match_Fails(t *testing.T) {
+	t.Parallel()
+
+	_, _, service := setupURIs(t, secrets.WithURIIndex(testURIKey))
+	_, err := service.Match(context.Background(), testOwnerID, "https://")
+	require.ErrorIs(t, err, secrets.ErrInvalidURL)
+
+	_, _, service = setupURIs(t)
+	_, err = service.Match(context.Background(), testOwnerID, "https://github.com")
+	require.ErrorIs(t, err, secrets.ErrMatchDisabled)
+}
+
+func TestPasswordData_MatchURL(t *testing.T) {
+	t.Parallel()
+
+	data := secrets.PasswordData{URIs: []secrets.URI{
+		{URI: "github.com", Match: urimatch.Host},
+		{URI: "https://example.com/app", Match: urimatch.Prefix},
+	}}
+
+	assert.True(t, data.MatchURL("https://github.com/login"))
+	assert.True(t, data.MatchURL("https://example.com/app/login"))
+	assert.False(t, data.MatchURL("https://gist.github.com"))
+	assert.False(t, secrets.PasswordData{}.MatchURL("https://github.com"))
+}
@@ -0,0 +1,9 @@
+package sends
+
+import "errors"
+
+var (
+	ErrSendNotFound    = errors.New("send not found")
+	ErrInvalidPassword = errors.New("invalid access password")
+	ErrInvalidLink     = errors.New("invalid send link")
+)
@@ -0,0 +1,31 @@
+package sends
+
+import (
+	"encoding/base64"
+	"strings"
+)
+
+// NewLink returns the link to the send at the address.
+//
+// The key is put to the fragment of the link, which clients do not send to the server.
+func NewLink(address string, key []byte) string {
+	return address + "#" + base64.RawURLEncoding.EncodeToString(key)
+}
+
+// ParseLink returns the address of the send and its key.
+//
+// Domain errors:
+// - ErrInvalidLink
+func ParseLink(link string) (string, []byte, error) {
+	address, fragment, ok := strings.Cut(link, "#")
+	if !ok || address == "" {
+		return "", nil, ErrInvalidLink
+	}
+
+	key, err := base64.RawURLEncoding.DecodeString(fragment)
+	if err != nil || len(key) != KeySize {
+		return "", nil, ErrInvalidLink
+	}
+
+	return address, key, nil
+}
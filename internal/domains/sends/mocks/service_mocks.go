@@ -0,0 +1,428 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mocks/service_mocks.go -package=mocks -source=service.go -typed
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	sends "github.com/novoseltcev/passkeeper/internal/domains/sends"
+	models "github.com/novoseltcev/passkeeper/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockService) Create(ctx context.Context, userID models.UserID, passphrase string, secretID models.SecretID, text string, opts sends.Options) (*models.Send, []byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, userID, passphrase, secretID, text, opts)
+	ret0, _ := ret[0].(*models.Send)
+	ret1, _ := ret[1].([]byte)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockServiceMockRecorder) Create(ctx, userID, passphrase, secretID, text, opts any) *MockServiceCreateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockService)(nil).Create), ctx, userID, passphrase, secretID, text, opts)
+	return &MockServiceCreateCall{Call: call}
+}
+
+// MockServiceCreateCall wrap *gomock.Call
+type MockServiceCreateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockServiceCreateCall) Return(arg0 *models.Send, arg1 []byte, arg2 error) *MockServiceCreateCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockServiceCreateCall) Do(f func(context.Context, models.UserID, string, models.SecretID, string, sends.Options) (*models.Send, []byte, error)) *MockServiceCreateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockServiceCreateCall) DoAndReturn(f func(context.Context, models.UserID, string, models.SecretID, string, sends.Options) (*models.Send, []byte, error)) *MockServiceCreateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Get mocks base method.
+func (m *MockService) Get(ctx context.Context, id models.SendID, password string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id, password)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockServiceMockRecorder) Get(ctx, id, password any) *MockServiceGetCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockService)(nil).Get), ctx, id, password)
+	return &MockServiceGetCall{Call: call}
+}
+
+// MockServiceGetCall wrap *gomock.Call
+type MockServiceGetCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockServiceGetCall) Return(arg0 []byte, arg1 error) *MockServiceGetCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockServiceGetCall) Do(f func(context.Context, models.SendID, string) ([]byte, error)) *MockServiceGetCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockServiceGetCall) DoAndReturn(f func(context.Context, models.SendID, string) ([]byte, error)) *MockServiceGetCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Purge mocks base method.
+func (m *MockService) Purge(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockServiceMockRecorder) Purge(ctx any) *MockServicePurgeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockService)(nil).Purge), ctx)
+	return &MockServicePurgeCall{Call: call}
+}
+
+// MockServicePurgeCall wrap *gomock.Call
+type MockServicePurgeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockServicePurgeCall) Return(arg0 int64, arg1 error) *MockServicePurgeCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockServicePurgeCall) Do(f func(context.Context) (int64, error)) *MockServicePurgeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockServicePurgeCall) DoAndReturn(f func(context.Context) (int64, error)) *MockServicePurgeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockSecrets is a mock of Secrets interface.
+type MockSecrets struct {
+	ctrl     *gomock.Controller
+	recorder *MockSecretsMockRecorder
+	isgomock struct{}
+}
+
+// MockSecretsMockRecorder is the mock recorder for MockSecrets.
+type MockSecretsMockRecorder struct {
+	mock *MockSecrets
+}
+
+// NewMockSecrets creates a new mock instance.
+func NewMockSecrets(ctrl *gomock.Controller) *MockSecrets {
+	mock := &MockSecrets{ctrl: ctrl}
+	mock.recorder = &MockSecretsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSecrets) EXPECT() *MockSecretsMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockSecrets) Get(ctx context.Context, id models.SecretID, userID models.UserID, passphrase string) (*models.Secret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id, userID, passphrase)
+	ret0, _ := ret[0].(*models.Secret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockSecretsMockRecorder) Get(ctx, id, userID, passphrase any) *MockSecretsGetCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockSecrets)(nil).Get), ctx, id, userID, passphrase)
+	return &MockSecretsGetCall{Call: call}
+}
+
+// MockSecretsGetCall wrap *gomock.Call
+type MockSecretsGetCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSecretsGetCall) Return(arg0 *models.Secret, arg1 error) *MockSecretsGetCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSecretsGetCall) Do(f func(context.Context, models.SecretID, models.UserID, string) (*models.Secret, error)) *MockSecretsGetCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSecretsGetCall) DoAndReturn(f func(context.Context, models.SecretID, models.UserID, string) (*models.Secret, error)) *MockSecretsGetCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockHasher is a mock of Hasher interface.
+type MockHasher struct {
+	ctrl     *gomock.Controller
+	recorder *MockHasherMockRecorder
+	isgomock struct{}
+}
+
+// MockHasherMockRecorder is the mock recorder for MockHasher.
+type MockHasherMockRecorder struct {
+	mock *MockHasher
+}
+
+// NewMockHasher creates a new mock instance.
+func NewMockHasher(ctrl *gomock.Controller) *MockHasher {
+	mock := &MockHasher{ctrl: ctrl}
+	mock.recorder = &MockHasherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHasher) EXPECT() *MockHasherMockRecorder {
+	return m.recorder
+}
+
+// Compare mocks base method.
+func (m *MockHasher) Compare(hash, v string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Compare", hash, v)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Compare indicates an expected call of Compare.
+func (mr *MockHasherMockRecorder) Compare(hash, v any) *MockHasherCompareCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Compare", reflect.TypeOf((*MockHasher)(nil).Compare), hash, v)
+	return &MockHasherCompareCall{Call: call}
+}
+
+// MockHasherCompareCall wrap *gomock.Call
+type MockHasherCompareCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockHasherCompareCall) Return(arg0 bool, arg1 error) *MockHasherCompareCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockHasherCompareCall) Do(f func(string, string) (bool, error)) *MockHasherCompareCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockHasherCompareCall) DoAndReturn(f func(string, string) (bool, error)) *MockHasherCompareCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Generate mocks base method.
+func (m *MockHasher) Generate(v string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Generate", v)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Generate indicates an expected call of Generate.
+func (mr *MockHasherMockRecorder) Generate(v any) *MockHasherGenerateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Generate", reflect.TypeOf((*MockHasher)(nil).Generate), v)
+	return &MockHasherGenerateCall{Call: call}
+}
+
+// MockHasherGenerateCall wrap *gomock.Call
+type MockHasherGenerateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockHasherGenerateCall) Return(arg0 string, arg1 error) *MockHasherGenerateCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockHasherGenerateCall) Do(f func(string) (string, error)) *MockHasherGenerateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockHasherGenerateCall) DoAndReturn(f func(string) (string, error)) *MockHasherGenerateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockEncryptor is a mock of Encryptor interface.
+type MockEncryptor struct {
+	ctrl     *gomock.Controller
+	recorder *MockEncryptorMockRecorder
+	isgomock struct{}
+}
+
+// MockEncryptorMockRecorder is the mock recorder for MockEncryptor.
+type MockEncryptorMockRecorder struct {
+	mock *MockEncryptor
+}
+
+// NewMockEncryptor creates a new mock instance.
+func NewMockEncryptor(ctrl *gomock.Controller) *MockEncryptor {
+	mock := &MockEncryptor{ctrl: ctrl}
+	mock.recorder = &MockEncryptorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEncryptor) EXPECT() *MockEncryptorMockRecorder {
+	return m.recorder
+}
+
+// Decrypt mocks base method.
+func (m *MockEncryptor) Decrypt(key, v []byte) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Decrypt", key, v)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Decrypt indicates an expected call of Decrypt.
+func (mr *MockEncryptorMockRecorder) Decrypt(key, v any) *MockEncryptorDecryptCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Decrypt", reflect.TypeOf((*MockEncryptor)(nil).Decrypt), key, v)
+	return &MockEncryptorDecryptCall{Call: call}
+}
+
+// MockEncryptorDecryptCall wrap *gomock.Call
+type MockEncryptorDecryptCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockEncryptorDecryptCall) Return(arg0 []byte, arg1 error) *MockEncryptorDecryptCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockEncryptorDecryptCall) Do(f func([]byte, []byte) ([]byte, error)) *MockEncryptorDecryptCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockEncryptorDecryptCall) DoAndReturn(f func([]byte, []byte) ([]byte, error)) *MockEncryptorDecryptCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Encrypt mocks base method.
+func (m *MockEncryptor) Encrypt(key, v []byte) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Encrypt", key, v)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Encrypt indicates an expected call of Encrypt.
+func (mr *MockEncryptorMockRecorder) Encrypt(key, v any) *MockEncryptorEncryptCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Encrypt", reflect.TypeOf((*MockEncryptor)(nil).Encrypt), key, v)
+	return &MockEncryptorEncryptCall{Call: call}
+}
+
+// MockEncryptorEncryptCall wrap *gomock.Call
+type MockEncryptorEncryptCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockEncryptorEncryptCall) Return(arg0 []byte, arg1 error) *MockEncryptorEncryptCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockEncryptorEncryptCall) Do(f func([]byte, []byte) ([]byte, error)) *MockEncryptorEncryptCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockEncryptorEncryptCall) DoAndReturn(f func([]byte, []byte) ([]byte, error)) *MockEncryptorEncryptCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
@@ -0,0 +1,24 @@
+package sends
+
+import (
+	"context"
+
+	"github.com/novoseltcev/passkeeper/internal/models"
+)
+
+//go:generate mockgen -destination=mocks/repository_mock.go -package=mocks -source=repository.go -typed
+
+type Repository interface {
+	Create(ctx context.Context, send *models.Send) (models.SendID, error)
+	// Get returns the send, expired sends are not found.
+	Get(ctx context.Context, id models.SendID) (*models.Send, error)
+	// Consume takes a view of the send and returns the send with the views left.
+	//
+	// Sends without views left and expired sends are not found.
+	Consume(ctx context.Context, id models.SendID) (*models.Send, error)
+	// Fail counts a wrong access password of the send and returns the count of the failed attempts.
+	Fail(ctx context.Context, id models.SendID) (int, error)
+	Delete(ctx context.Context, id models.SendID) error
+	// DeleteExpired deletes expired sends and returns their count.
+	DeleteExpired(ctx context.Context) (int64, error)
+}
@@ -0,0 +1,230 @@
+// Package sends provides a domain for one-time links to secrets for people without an account.
+package sends
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"time"
+
+	"github.com/novoseltcev/passkeeper/internal/domains/secrets"
+	"github.com/novoseltcev/passkeeper/internal/models"
+)
+
+//go:generate mockgen -destination=./mocks/service_mocks.go -package=mocks -source=service.go -typed
+
+const (
+	// KeySize is the size of the keys of sends.
+	KeySize = 32
+	// MaxPasswordAttempts is the count of wrong access passwords, after which the send is deleted.
+	MaxPasswordAttempts = 5
+)
+
+// Service is a domain service for sends.
+type Service interface {
+	// Create creates a send of the secret, or of the text if the secret is not set.
+	//
+	// The payload is encrypted with a new key, which is returned with the send and not stored,
+	// so the send is only readable with its link.
+	// Domain errors of secrets.Service.Get are returned for the secret.
+	Create(
+		ctx context.Context,
+		userID models.UserID,
+		passphrase string,
+		secretID models.SecretID,
+		text string,
+		opts Options,
+	) (*models.Send, []byte, error)
+
+	// Get returns the encrypted payload of the send and takes a view of it.
+	//
+	// The send is deleted once it has no views left, or after MaxPasswordAttempts wrong access passwords,
+	// so the password can't be brute-forced.
+	// Domain errors:
+	// - ErrSendNotFound if the send does not exist, is expired or has no views left
+	// - ErrInvalidPassword
+	Get(ctx context.Context, id models.SendID, password string) ([]byte, error)
+
+	// Purge deletes expired sends and returns their count.
+	Purge(ctx context.Context) (int64, error)
+}
+
+// Options are the limits of a send.
+type Options struct {
+	MaxViews int
+	TTL      time.Duration
+	// Password is the optional access password of the send.
+	Password string
+}
+
+// Payload is the decrypted content of a send.
+type Payload struct {
+	Name string            `json:"name,omitempty"`
+	Type models.SecretType `json:"type"`
+	Data json.RawMessage   `json:"data"`
+}
+
+// Secrets reads the secrets to send.
+type Secrets interface {
+	Get(ctx context.Context, id models.SecretID, userID models.UserID, passphrase string) (*models.Secret, error)
+}
+
+type Hasher interface {
+	Generate(v string) (string, error)
+	Compare(hash, v string) (bool, error)
+}
+
+type Encryptor interface {
+	Encrypt(key, v []byte) ([]byte, error)
+	Decrypt(key, v []byte) ([]byte, error)
+}
+
+type service struct {
+	repo    Repository
+	secrets Secrets
+	hasher  Hasher
+	enc     Encryptor
+}
+
+var _ Service = (*service)(nil)
+
+func NewService(repo Repository, secrets Secrets, hasher Hasher, enc Encryptor) *service { // nolint: revive
+	return &service{repo: repo, secrets: secrets, hasher: hasher, enc: enc}
+}
+
+func (s *service) Create(
+	ctx context.Context,
+	userID models.UserID,
+	passphrase string,
+	secretID models.SecretID,
+	text string,
+	opts Options,
+) (*models.Send, []byte, error) {
+	payload, err := s.newPayload(ctx, userID, passphrase, secretID, text)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	key := make([]byte, KeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, nil, err
+	}
+
+	data, err := s.enc.Encrypt(key, payload)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	send := &models.Send{
+		Owner:     &models.User{ID: userID},
+		Data:      data,
+		ViewsLeft: opts.MaxViews,
+		ExpiresAt: time.Now().UTC().Add(opts.TTL),
+	}
+
+	if opts.Password != "" {
+		if send.PasswordHash, err = s.hasher.Generate(opts.Password); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if send.ID, err = s.repo.Create(ctx, send); err != nil {
+		return nil, nil, err
+	}
+
+	return send, key, nil
+}
+
+func (s *service) Get(ctx context.Context, id models.SendID, password string) ([]byte, error) {
+	send, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if send.PasswordHash != "" {
+		ok, err := s.hasher.Compare(send.PasswordHash, password)
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok {
+			return nil, s.fail(ctx, id)
+		}
+	}
+
+	// The views are taken atomically, so concurrent readers can't exceed the limit.
+	if send, err = s.repo.Consume(ctx, id); err != nil {
+		return nil, err
+	}
+
+	if send.ViewsLeft <= 0 {
+		if err := s.repo.Delete(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+
+	return send.Data, nil
+}
+
+// fail counts the wrong access password and deletes the send after too many of them.
+func (s *service) fail(ctx context.Context, id models.SendID) error {
+	attempts, err := s.repo.Fail(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if attempts >= MaxPasswordAttempts {
+		if err := s.repo.Delete(ctx, id); err != nil {
+			return err
+		}
+	}
+
+	return ErrInvalidPassword
+}
+
+func (s *service) Purge(ctx context.Context) (int64, error) {
+	return s.repo.DeleteExpired(ctx)
+}
+
+func (s *service) newPayload(
+	ctx context.Context,
+	userID models.UserID,
+	passphrase string,
+	secretID models.SecretID,
+	text string,
+) ([]byte, error) {
+	if secretID == "" {
+		data, err := json.Marshal(&secrets.TextData{Content: text})
+		if err != nil {
+			return nil, err
+		}
+
+		return json.Marshal(&Payload{Type: models.SecretTypeTxt, Data: data})
+	}
+
+	secret, err := s.secrets.Get(ctx, secretID, userID, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(&Payload{Name: secret.Name, Type: secret.Type, Data: json.RawMessage(secret.Data)})
+}
+
+// Open decrypts the payload of a send with the key of its link.
+func Open(enc Encryptor, key, data []byte) (*Payload, error) {
+	if len(key) != KeySize {
+		return nil, ErrInvalidLink
+	}
+
+	plain, err := enc.Decrypt(key, data)
+	if err != nil {
+		return nil, err
+	}
+
+	var payload Payload
+	if err := json.Unmarshal(plain, &payload); err != nil {
+		return nil, err
+	}
+
+	return &payload, nil
+}
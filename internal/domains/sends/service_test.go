@@ -0,0 +1,264 @@
+package sends_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/novoseltcev/passkeeper/internal/domains/secrets"
+	"github.com/novoseltcev/passkeeper/internal/domains/sends"
+	"github.com/novoseltcev/passkeeper/internal/domains/sends/mocks"
+	"github.com/novoseltcev/passkeeper/internal/models"
+	"github.com/novoseltcev/passkeeper/pkg/aes"
+	"github.com/novoseltcev/passkeeper/pkg/testutils"
+)
+
+const (
+	testID         = models.SendID("send-id")
+	testUserID     = models.UserID("user-id")
+	testSecretID   = models.SecretID("secret-id")
+	testPassphrase = "test-passphrase"
+	testPassword   = "test-password"
+	testHash       = "hash"
+)
+
+func TestService_Create_Text(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	enc := aes.New(aes.AES256BitKeyLength)
+	service := sends.NewService(repo, nil, nil, enc)
+
+	var created *models.Send
+
+	repo.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, send *models.Send) (models.SendID, error) {
+			created = send
+
+			return testID, nil
+		})
+
+	send, key, err := service.Create(
+		context.Background(), testUserID, "", "", "text", sends.Options{MaxViews: 2, TTL: time.Hour},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, testID, send.ID)
+	assert.Len(t, key, sends.KeySize)
+
+	assert.Equal(t, testUserID, created.Owner.ID)
+	assert.Equal(t, 2, created.ViewsLeft)
+	assert.Empty(t, created.PasswordHash)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), created.ExpiresAt, time.Minute)
+
+	payload, err := sends.Open(enc, key, created.Data)
+	require.NoError(t, err)
+	assert.Equal(t, models.SecretTypeTxt, payload.Type)
+	assert.JSONEq(t, `{"content":"text","meta":null}`, string(payload.Data))
+}
+
+func TestService_Create_Secret(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	secretService := mocks.NewMockSecrets(ctrl)
+	hasher := mocks.NewMockHasher(ctrl)
+	enc := aes.New(aes.AES256BitKeyLength)
+	service := sends.NewService(repo, secretService, hasher, enc)
+
+	secretService.EXPECT().
+		Get(gomock.Any(), testSecretID, testUserID, testPassphrase).
+		Return(&models.Secret{
+			Name: "db",
+			Type: models.SecretTypePwd,
+			Data: []byte(`{"login":"admin","password":"secret"}`),
+		}, nil)
+	hasher.EXPECT().Generate(testPassword).Return(testHash, nil)
+
+	var created *models.Send
+
+	repo.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, send *models.Send) (models.SendID, error) {
+			created = send
+
+			return testID, nil
+		})
+
+	_, key, err := service.Create(
+		context.Background(),
+		testUserID, testPassphrase,
+		testSecretID, "",
+		sends.Options{MaxViews: 1, TTL: time.Hour, Password: testPassword},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, testHash, created.PasswordHash)
+
+	payload, err := sends.Open(enc, key, created.Data)
+	require.NoError(t, err)
+	assert.Equal(t, &sends.Payload{
+		Name: "db",
+		Type: models.SecretTypePwd,
+		Data: []byte(`{"login":"admin","password":"secret"}`),
+	}, payload)
+}
+
+func TestService_Create_Fails_Secret(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	secretService := mocks.NewMockSecrets(ctrl)
+	service := sends.NewService(nil, secretService, nil, nil)
+
+	secretService.EXPECT().
+		Get(gomock.Any(), testSecretID, testUserID, testPassphrase).
+		Return(nil, secrets.ErrInvalidPassphrase)
+
+	_, _, err := service.Create(
+		context.Background(), testUserID, testPassphrase, testSecretID, "", sends.Options{MaxViews: 1, TTL: time.Hour},
+	)
+	assert.ErrorIs(t, err, secrets.ErrInvalidPassphrase)
+}
+
+func TestService_Get_Success(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	tests := []struct {
+		name      string
+		viewsLeft int
+	}{
+		{name: "views left", viewsLeft: 1},
+		{name: "last view", viewsLeft: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := mocks.NewMockRepository(ctrl)
+			hasher := mocks.NewMockHasher(ctrl)
+			service := sends.NewService(repo, nil, hasher, nil)
+
+			repo.EXPECT().Get(gomock.Any(), testID).Return(&models.Send{ID: testID, PasswordHash: testHash}, nil)
+			hasher.EXPECT().Compare(testHash, testPassword).Return(true, nil)
+			repo.EXPECT().
+				Consume(gomock.Any(), testID).
+				Return(&models.Send{ID: testID, Data: []byte("data"), ViewsLeft: tt.viewsLeft}, nil)
+
+			if tt.viewsLeft == 0 {
+				repo.EXPECT().Delete(gomock.Any(), testID).Return(nil)
+			}
+
+			data, err := service.Get(context.Background(), testID, testPassword)
+			require.NoError(t, err)
+			assert.Equal(t, []byte("data"), data)
+		})
+	}
+}
+
+func TestService_Get_Fails(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mocks.NewMockRepository(ctrl)
+		service := sends.NewService(repo, nil, nil, nil)
+
+		repo.EXPECT().Get(gomock.Any(), testID).Return(nil, sends.ErrSendNotFound)
+
+		_, err := service.Get(context.Background(), testID, "")
+		assert.ErrorIs(t, err, sends.ErrSendNotFound)
+	})
+
+	t.Run("invalid password", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mocks.NewMockRepository(ctrl)
+		hasher := mocks.NewMockHasher(ctrl)
+		service := sends.NewService(repo, nil, hasher, nil)
+
+		repo.EXPECT().Get(gomock.Any(), testID).Return(&models.Send{ID: testID, PasswordHash: testHash}, nil)
+		hasher.EXPECT().Compare(testHash, "").Return(false, nil)
+		repo.EXPECT().Fail(gomock.Any(), testID).Return(1, nil)
+
+		_, err := service.Get(context.Background(), testID, "")
+		assert.ErrorIs(t, err, sends.ErrInvalidPassword)
+	})
+
+	t.Run("too many invalid passwords", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mocks.NewMockRepository(ctrl)
+		hasher := mocks.NewMockHasher(ctrl)
+		service := sends.NewService(repo, nil, hasher, nil)
+
+		repo.EXPECT().Get(gomock.Any(), testID).Return(&models.Send{ID: testID, PasswordHash: testHash}, nil)
+		hasher.EXPECT().Compare(testHash, "").Return(false, nil)
+		repo.EXPECT().Fail(gomock.Any(), testID).Return(sends.MaxPasswordAttempts, nil)
+		repo.EXPECT().Delete(gomock.Any(), testID).Return(nil)
+
+		_, err := service.Get(context.Background(), testID, "")
+		assert.ErrorIs(t, err, sends.ErrInvalidPassword)
+	})
+
+	t.Run("consumed concurrently", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mocks.NewMockRepository(ctrl)
+		service := sends.NewService(repo, nil, nil, nil)
+
+		repo.EXPECT().Get(gomock.Any(), testID).Return(&models.Send{ID: testID}, nil)
+		repo.EXPECT().Consume(gomock.Any(), testID).Return(nil, sends.ErrSendNotFound)
+
+		_, err := service.Get(context.Background(), testID, "")
+		assert.ErrorIs(t, err, sends.ErrSendNotFound)
+	})
+
+	t.Run("hasher", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mocks.NewMockRepository(ctrl)
+		hasher := mocks.NewMockHasher(ctrl)
+		service := sends.NewService(repo, nil, hasher, nil)
+
+		repo.EXPECT().Get(gomock.Any(), testID).Return(&models.Send{ID: testID, PasswordHash: testHash}, nil)
+		hasher.EXPECT().Compare(testHash, testPassword).Return(false, testutils.Err)
+
+		_, err := service.Get(context.Background(), testID, testPassword)
+		assert.ErrorIs(t, err, testutils.Err)
+	})
+}
+
+func TestLink(t *testing.T) {
+	t.Parallel()
+
+	key := make([]byte, sends.KeySize)
+	key[0] = 0xff
+
+	link := sends.NewLink("http://localhost/api/v1/sends/id", key)
+	assert.NotContains(t, link[len("http://localhost/api/v1/sends/id#"):], "/")
+
+	address, got, err := sends.ParseLink(link)
+	require.NoError(t, err)
+	assert.Equal(t, "http://localhost/api/v1/sends/id", address)
+	assert.Equal(t, key, got)
+
+	for _, link := range []string{"http://localhost/api/v1/sends/id", "http://localhost/id#abc", "#" + link} {
+		_, _, err := sends.ParseLink(link)
+		assert.ErrorIs(t, err, sends.ErrInvalidLink, link)
+	}
+}
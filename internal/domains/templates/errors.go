@@ -0,0 +1,9 @@
+package templates
+
+import "errors"
+
+var (
+	ErrTemplateNotFound = errors.New("template not found")
+	ErrAnotherOwner     = errors.New("another owner")
+	ErrInvalidTemplate  = errors.New("invalid template")
+)
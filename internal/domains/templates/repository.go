@@ -0,0 +1,16 @@
+package templates
+
+import (
+	"context"
+
+	"github.com/novoseltcev/passkeeper/internal/models"
+)
+
+//go:generate mockgen -destination=mocks/repository_mock.go -package=mocks -source=repository.go -typed
+
+type Repository interface {
+	Get(ctx context.Context, id models.TemplateID) (*models.Template, error)
+	GetAll(ctx context.Context, ownerID models.UserID) ([]models.Template, error)
+	Create(ctx context.Context, data *models.Template) (models.TemplateID, error)
+	Delete(ctx context.Context, id models.TemplateID) error
+}
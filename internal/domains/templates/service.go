@@ -0,0 +1,120 @@
+// Package templates provides a domain for user-defined secret templates.
+package templates
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/novoseltcev/passkeeper/internal/models"
+)
+
+//go:generate mockgen -destination=./mocks/service_mocks.go -package=mocks -source=service.go -typed
+
+// Service is a domain service for templates.
+type Service interface {
+	// Get returns a template by its ID.
+	//
+	// Its check owner by ownerID to grant private access.
+	// Domain errors:
+	// - ErrTemplateNotFound
+	// - ErrAnotherOwner
+	Get(ctx context.Context, id models.TemplateID, ownerID models.UserID) (*models.Template, error)
+
+	// GetAll returns all owner's templates.
+	GetAll(ctx context.Context, ownerID models.UserID) ([]models.Template, error)
+
+	// Create creates a new template.
+	//
+	// Domain errors:
+	// - ErrInvalidTemplate
+	Create(
+		ctx context.Context,
+		ownerID models.UserID,
+		name string,
+		fields []models.TemplateField,
+	) (models.TemplateID, error)
+
+	// Delete deletes a template by its ID.
+	//
+	// Secrets created from the template are kept, but can't be updated anymore.
+	// Domain errors:
+	// - ErrTemplateNotFound
+	// - ErrAnotherOwner
+	Delete(ctx context.Context, id models.TemplateID, ownerID models.UserID) error
+}
+
+type service struct {
+	repo Repository
+}
+
+var _ Service = (*service)(nil)
+
+func NewService(repo Repository) *service { // nolint: revive
+	return &service{repo: repo}
+}
+
+func (s *service) Get(ctx context.Context, id models.TemplateID, ownerID models.UserID) (*models.Template, error) {
+	template, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if template.Owner.ID != ownerID {
+		return nil, ErrAnotherOwner
+	}
+
+	return template, nil
+}
+
+func (s *service) GetAll(ctx context.Context, ownerID models.UserID) ([]models.Template, error) {
+	return s.repo.GetAll(ctx, ownerID)
+}
+
+func (s *service) Create(
+	ctx context.Context,
+	ownerID models.UserID,
+	name string,
+	fields []models.TemplateField,
+) (models.TemplateID, error) {
+	if err := validateFields(fields); err != nil {
+		return "", err
+	}
+
+	return s.repo.Create(ctx, models.NewTemplate(name, fields, &models.User{ID: ownerID}))
+}
+
+func (s *service) Delete(ctx context.Context, id models.TemplateID, ownerID models.UserID) error {
+	if _, err := s.Get(ctx, id, ownerID); err != nil {
+		return err
+	}
+
+	return s.repo.Delete(ctx, id)
+}
+
+func validateFields(fields []models.TemplateField) error {
+	if len(fields) == 0 {
+		return fmt.Errorf("%w: no fields", ErrInvalidTemplate)
+	}
+
+	names := make(map[string]struct{}, len(fields))
+	for _, field := range fields {
+		if field.Name == "" {
+			return fmt.Errorf("%w: empty field name", ErrInvalidTemplate)
+		}
+
+		if _, ok := names[field.Name]; ok {
+			return fmt.Errorf("%w: duplicate field %q", ErrInvalidTemplate, field.Name)
+		}
+
+		names[field.Name] = struct{}{}
+
+		switch field.Type {
+		case models.FieldTypeString, models.FieldTypeConcealed, models.FieldTypeURL, models.FieldTypeEmail,
+			models.FieldTypeDate, models.FieldTypeNumber, models.FieldTypeMultiline:
+		default:
+			return fmt.Errorf("%w: field %q has unknown type %q", ErrInvalidTemplate, field.Name, field.Type)
+		}
+	}
+
+	return nil
+}
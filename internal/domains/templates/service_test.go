@@ -0,0 +1,164 @@
+package templates_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/novoseltcev/passkeeper/internal/domains/templates"
+	"github.com/novoseltcev/passkeeper/internal/domains/templates/mocks"
+	"github.com/novoseltcev/passkeeper/internal/models"
+	"github.com/novoseltcev/passkeeper/pkg/testutils"
+)
+
+const (
+	testID      = models.TemplateID("template-id")
+	testOwnerID = models.UserID("owner-id")
+	testName    = "wifi"
+)
+
+var testFields = []models.TemplateField{
+	{Name: "ssid", Type: models.FieldTypeString, Required: true},
+	{Name: "key", Type: models.FieldTypeConcealed},
+}
+
+func TestService_Get_Success(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := templates.NewService(repo)
+
+	template := &models.Template{ID: testID, Owner: &models.User{ID: testOwnerID}}
+	repo.EXPECT().Get(gomock.Any(), testID).Return(template, nil)
+
+	got, err := service.Get(context.Background(), testID, testOwnerID)
+	require.NoError(t, err)
+	assert.Equal(t, template, got)
+}
+
+func TestService_Get_Fails(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := templates.NewService(repo)
+
+	repo.EXPECT().Get(gomock.Any(), testID).Return(nil, testutils.Err)
+
+	_, err := service.Get(context.Background(), testID, testOwnerID)
+	assert.ErrorIs(t, err, testutils.Err)
+}
+
+func TestService_Get_Fails_AnotherOwner(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := templates.NewService(repo)
+
+	repo.EXPECT().
+		Get(gomock.Any(), testID).
+		Return(&models.Template{ID: testID, Owner: &models.User{ID: "another"}}, nil)
+
+	_, err := service.Get(context.Background(), testID, testOwnerID)
+	assert.ErrorIs(t, err, templates.ErrAnotherOwner)
+}
+
+func TestService_GetAll(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := templates.NewService(repo)
+
+	items := []models.Template{{ID: testID}}
+	repo.EXPECT().GetAll(gomock.Any(), testOwnerID).Return(items, nil)
+
+	got, err := service.GetAll(context.Background(), testOwnerID)
+	require.NoError(t, err)
+	assert.Equal(t, items, got)
+}
+
+func TestService_Create_Success(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := templates.NewService(repo)
+
+	repo.EXPECT().
+		Create(gomock.Any(), &models.Template{Name: testName, Fields: testFields, Owner: &models.User{ID: testOwnerID}}).
+		Return(testID, nil)
+
+	id, err := service.Create(context.Background(), testOwnerID, testName, testFields)
+	require.NoError(t, err)
+	assert.Equal(t, testID, id)
+}
+
+func TestService_Create_Fails_InvalidTemplate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		fields []models.TemplateField
+	}{
+		{name: "empty", fields: nil},
+		{name: "no name", fields: []models.TemplateField{{Type: models.FieldTypeString}}},
+		{name: "duplicate", fields: []models.TemplateField{
+			{Name: "ssid", Type: models.FieldTypeString},
+			{Name: "ssid", Type: models.FieldTypeConcealed},
+		}},
+		{name: "type", fields: []models.TemplateField{{Name: "ssid", Type: "binary"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			service := templates.NewService(nil)
+
+			_, err := service.Create(context.Background(), testOwnerID, testName, tt.fields)
+			assert.ErrorIs(t, err, templates.ErrInvalidTemplate)
+		})
+	}
+}
+
+func TestService_Delete_Success(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := templates.NewService(repo)
+
+	repo.EXPECT().
+		Get(gomock.Any(), testID).
+		Return(&models.Template{ID: testID, Owner: &models.User{ID: testOwnerID}}, nil)
+	repo.EXPECT().Delete(gomock.Any(), testID).Return(nil)
+
+	assert.NoError(t, service.Delete(context.Background(), testID, testOwnerID))
+}
+
+func TestService_Delete_Fails_AnotherOwner(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	service := templates.NewService(repo)
+
+	repo.EXPECT().
+		Get(gomock.Any(), testID).
+		Return(&models.Template{ID: testID, Owner: &models.User{ID: "another"}}, nil)
+
+	assert.ErrorIs(t, service.Delete(context.Background(), testID, testOwnerID), templates.ErrAnotherOwner)
+}
@@ -157,3 +157,41 @@ func (c *MockRepositoryGetByLoginCall) DoAndReturn(f func(context.Context, strin
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
+
+// SetKeys mocks base method.
+func (m *MockRepository) SetKeys(ctx context.Context, id models.UserID, public, private []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetKeys", ctx, id, public, private)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetKeys indicates an expected call of SetKeys.
+func (mr *MockRepositoryMockRecorder) SetKeys(ctx, id, public, private any) *MockRepositorySetKeysCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetKeys", reflect.TypeOf((*MockRepository)(nil).SetKeys), ctx, id, public, private)
+	return &MockRepositorySetKeysCall{Call: call}
+}
+
+// MockRepositorySetKeysCall wrap *gomock.Call
+type MockRepositorySetKeysCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRepositorySetKeysCall) Return(arg0 error) *MockRepositorySetKeysCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRepositorySetKeysCall) Do(f func(context.Context, models.UserID, []byte, []byte) error) *MockRepositorySetKeysCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRepositorySetKeysCall) DoAndReturn(f func(context.Context, models.UserID, []byte, []byte) error) *MockRepositorySetKeysCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
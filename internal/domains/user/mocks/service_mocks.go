@@ -258,3 +258,66 @@ func (c *MockHasherGenerateCall) DoAndReturn(f func(string) (string, error)) *Mo
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
+
+// MockEncryptor is a mock of Encryptor interface.
+type MockEncryptor struct {
+	ctrl     *gomock.Controller
+	recorder *MockEncryptorMockRecorder
+	isgomock struct{}
+}
+
+// MockEncryptorMockRecorder is the mock recorder for MockEncryptor.
+type MockEncryptorMockRecorder struct {
+	mock *MockEncryptor
+}
+
+// NewMockEncryptor creates a new mock instance.
+func NewMockEncryptor(ctrl *gomock.Controller) *MockEncryptor {
+	mock := &MockEncryptor{ctrl: ctrl}
+	mock.recorder = &MockEncryptorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEncryptor) EXPECT() *MockEncryptorMockRecorder {
+	return m.recorder
+}
+
+// Encrypt mocks base method.
+func (m *MockEncryptor) Encrypt(passphrase, v []byte) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Encrypt", passphrase, v)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Encrypt indicates an expected call of Encrypt.
+func (mr *MockEncryptorMockRecorder) Encrypt(passphrase, v any) *MockEncryptorEncryptCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Encrypt", reflect.TypeOf((*MockEncryptor)(nil).Encrypt), passphrase, v)
+	return &MockEncryptorEncryptCall{Call: call}
+}
+
+// MockEncryptorEncryptCall wrap *gomock.Call
+type MockEncryptorEncryptCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockEncryptorEncryptCall) Return(arg0 []byte, arg1 error) *MockEncryptorEncryptCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockEncryptorEncryptCall) Do(f func([]byte, []byte) ([]byte, error)) *MockEncryptorEncryptCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockEncryptorEncryptCall) DoAndReturn(f func([]byte, []byte) ([]byte, error)) *MockEncryptorEncryptCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
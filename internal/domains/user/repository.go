@@ -12,4 +12,5 @@ type Repository interface {
 	GetByLogin(ctx context.Context, login string) (*models.User, error)
 	GetByID(ctx context.Context, id models.UserID) (*models.User, error)
 	CreateAccount(ctx context.Context, data *models.User) (models.UserID, error)
+	SetKeys(ctx context.Context, id models.UserID, public, private []byte) error
 }
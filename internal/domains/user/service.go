@@ -6,6 +6,7 @@ import (
 	"errors"
 
 	"github.com/novoseltcev/passkeeper/internal/models"
+	"github.com/novoseltcev/passkeeper/pkg/sealbox"
 )
 
 //go:generate mockgen -destination=./mocks/service_mocks.go -package=mocks -source=service.go -typed
@@ -18,7 +19,7 @@ type Service interface {
 	// - ErrAutenticationFailed if the login or password is invalid.
 	Login(ctx context.Context, login, password string) (models.UserID, error)
 
-	// Register creates a new user.
+	// Register creates a new user with a key pair to share secrets.
 	//
 	// Errors:
 	// - ErrLoginIsBusy if the login is busy.
@@ -26,6 +27,7 @@ type Service interface {
 
 	// VerifyPassphrase verifies a owner's passphrase.
 	//
+	// The key pair of users registered before sharing is generated here.
 	// Errors:
 	// - ErrInvalidSecretType
 	VerifyPassphrase(ctx context.Context, ownerID models.UserID, passphrase string) error
@@ -36,15 +38,20 @@ type Hasher interface {
 	Compare(hash, v string) (bool, error)
 }
 
+type Encryptor interface {
+	Encrypt(passphrase, v []byte) ([]byte, error)
+}
+
 type service struct {
 	repo   Repository
 	hasher Hasher
+	enc    Encryptor
 }
 
 var _ Service = (*service)(nil)
 
-func NewService(repo Repository, hasher Hasher) *service { // nolint: revive
-	return &service{repo: repo, hasher: hasher}
+func NewService(repo Repository, hasher Hasher, enc Encryptor) *service { // nolint: revive
+	return &service{repo: repo, hasher: hasher, enc: enc}
 }
 
 func (s *service) Login(ctx context.Context, login, password string) (models.UserID, error) {
@@ -89,7 +96,12 @@ func (s *service) Register(ctx context.Context, login, password, passphrase stri
 		return "", err
 	}
 
-	return s.repo.CreateAccount(ctx, models.NewUser(login, hashedPwd, hashedPassphrase))
+	user = models.NewUser(login, hashedPwd, hashedPassphrase)
+	if user.PublicKey, user.PrivateKey, err = s.generateKey(passphrase); err != nil {
+		return "", err
+	}
+
+	return s.repo.CreateAccount(ctx, user)
 }
 
 func (s *service) VerifyPassphrase(ctx context.Context, ownerID models.UserID, passphrase string) error {
@@ -107,5 +119,29 @@ func (s *service) VerifyPassphrase(ctx context.Context, ownerID models.UserID, p
 		return ErrInvalidPassphrase
 	}
 
-	return nil
+	if len(owner.PublicKey) > 0 {
+		return nil
+	}
+
+	public, private, err := s.generateKey(passphrase)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.SetKeys(ctx, ownerID, public, private)
+}
+
+// generateKey generates a key pair with the private key encrypted by the passphrase.
+func (s *service) generateKey(passphrase string) ([]byte, []byte, error) {
+	public, private, err := sealbox.GenerateKey()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	encPrivate, err := s.enc.Encrypt([]byte(passphrase), private)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return public, encPrivate, nil
 }
@@ -1,6 +1,7 @@
 package user_test
 
 import (
+	"bytes"
 	"context"
 	"testing"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/novoseltcev/passkeeper/internal/domains/user"
 	"github.com/novoseltcev/passkeeper/internal/domains/user/mocks"
 	"github.com/novoseltcev/passkeeper/internal/models"
+	"github.com/novoseltcev/passkeeper/pkg/sealbox"
 	"github.com/novoseltcev/passkeeper/pkg/testutils"
 )
 
@@ -23,6 +25,8 @@ const (
 	testPassphraseHash = "passphrase-hash"
 )
 
+var testPrivateKey = []byte("encrypted-private-key")
+
 func TestService_Login_Success(t *testing.T) {
 	t.Parallel()
 	ctrl := gomock.NewController(t)
@@ -30,7 +34,8 @@ func TestService_Login_Success(t *testing.T) {
 
 	repo := mocks.NewMockRepository(ctrl)
 	hasher := mocks.NewMockHasher(ctrl)
-	service := user.NewService(repo, hasher)
+	enc := mocks.NewMockEncryptor(ctrl)
+	service := user.NewService(repo, hasher, enc)
 
 	repo.EXPECT().
 		GetByLogin(gomock.Any(), testLogin).
@@ -74,7 +79,7 @@ func TestService_Login_Fails_Get(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			repo := mocks.NewMockRepository(ctrl)
-			service := user.NewService(repo, nil)
+			service := user.NewService(repo, nil, nil)
 
 			repo.EXPECT().
 				GetByLogin(gomock.Any(), testLogin).
@@ -93,7 +98,8 @@ func TestService_Login_Fails_HashErr(t *testing.T) {
 
 	repo := mocks.NewMockRepository(ctrl)
 	hasher := mocks.NewMockHasher(ctrl)
-	service := user.NewService(repo, hasher)
+	enc := mocks.NewMockEncryptor(ctrl)
+	service := user.NewService(repo, hasher, enc)
 
 	repo.EXPECT().
 		GetByLogin(gomock.Any(), testLogin).
@@ -114,7 +120,8 @@ func TestService_Login_Fails_CheckPassword(t *testing.T) {
 
 	repo := mocks.NewMockRepository(ctrl)
 	hasher := mocks.NewMockHasher(ctrl)
-	service := user.NewService(repo, hasher)
+	enc := mocks.NewMockEncryptor(ctrl)
+	service := user.NewService(repo, hasher, enc)
 
 	repo.EXPECT().
 		GetByLogin(gomock.Any(), testLogin).
@@ -138,7 +145,8 @@ func TestService_Register_Success(t *testing.T) {
 
 	repo := mocks.NewMockRepository(ctrl)
 	hasher := mocks.NewMockHasher(ctrl)
-	service := user.NewService(repo, hasher)
+	enc := mocks.NewMockEncryptor(ctrl)
+	service := user.NewService(repo, hasher, enc)
 
 	repo.EXPECT().
 		GetByLogin(gomock.Any(), testLogin).
@@ -152,12 +160,18 @@ func TestService_Register_Success(t *testing.T) {
 		Generate(testPassphrase).
 		Return(testPassphraseHash, nil)
 
+	enc.EXPECT().
+		Encrypt([]byte(testPassphrase), gomock.Any()).
+		Return(testPrivateKey, nil)
+
 	repo.EXPECT().
-		CreateAccount(gomock.Any(), &models.User{
-			Login:          testLogin,
-			PasswordHash:   testPasswordHash,
-			PassphraseHash: testPassphraseHash,
-		}).
+		CreateAccount(gomock.Any(), gomock.Cond(func(u *models.User) bool {
+			return u.Login == testLogin &&
+				u.PasswordHash == testPasswordHash &&
+				u.PassphraseHash == testPassphraseHash &&
+				len(u.PublicKey) == sealbox.KeySize &&
+				bytes.Equal(u.PrivateKey, testPrivateKey)
+		})).
 		Return(testID, nil)
 
 	id, err := service.Register(context.Background(), testLogin, testPassword, testPassphrase)
@@ -171,7 +185,7 @@ func TestService_Register_Fails_Get(t *testing.T) {
 	t.Cleanup(ctrl.Finish)
 
 	repo := mocks.NewMockRepository(ctrl)
-	service := user.NewService(repo, nil)
+	service := user.NewService(repo, nil, nil)
 
 	repo.EXPECT().
 		GetByLogin(gomock.Any(), testLogin).
@@ -187,7 +201,7 @@ func TestService_Register_Fails_LoginIsBusy(t *testing.T) {
 	t.Cleanup(ctrl.Finish)
 
 	repo := mocks.NewMockRepository(ctrl)
-	service := user.NewService(repo, nil)
+	service := user.NewService(repo, nil, nil)
 
 	repo.EXPECT().
 		GetByLogin(gomock.Any(), testLogin).
@@ -204,7 +218,8 @@ func TestService_Register_Fails_HashPassword(t *testing.T) {
 
 	repo := mocks.NewMockRepository(ctrl)
 	hasher := mocks.NewMockHasher(ctrl)
-	service := user.NewService(repo, hasher)
+	enc := mocks.NewMockEncryptor(ctrl)
+	service := user.NewService(repo, hasher, enc)
 
 	repo.EXPECT().
 		GetByLogin(gomock.Any(), testLogin).
@@ -225,7 +240,8 @@ func TestService_Register_Fails_HashPassphrase(t *testing.T) {
 
 	repo := mocks.NewMockRepository(ctrl)
 	hasher := mocks.NewMockHasher(ctrl)
-	service := user.NewService(repo, hasher)
+	enc := mocks.NewMockEncryptor(ctrl)
+	service := user.NewService(repo, hasher, enc)
 
 	repo.EXPECT().
 		GetByLogin(gomock.Any(), testLogin).
@@ -250,7 +266,8 @@ func TestService_Register_Fails_Create(t *testing.T) {
 
 	repo := mocks.NewMockRepository(ctrl)
 	hasher := mocks.NewMockHasher(ctrl)
-	service := user.NewService(repo, hasher)
+	enc := mocks.NewMockEncryptor(ctrl)
+	service := user.NewService(repo, hasher, enc)
 
 	repo.EXPECT().
 		GetByLogin(gomock.Any(), testLogin).
@@ -264,12 +281,18 @@ func TestService_Register_Fails_Create(t *testing.T) {
 		Generate(testPassphrase).
 		Return(testPassphraseHash, nil)
 
+	enc.EXPECT().
+		Encrypt([]byte(testPassphrase), gomock.Any()).
+		Return(testPrivateKey, nil)
+
 	repo.EXPECT().
-		CreateAccount(gomock.Any(), &models.User{
-			Login:          testLogin,
-			PasswordHash:   testPasswordHash,
-			PassphraseHash: testPassphraseHash,
-		}).
+		CreateAccount(gomock.Any(), gomock.Cond(func(u *models.User) bool {
+			return u.Login == testLogin &&
+				u.PasswordHash == testPasswordHash &&
+				u.PassphraseHash == testPassphraseHash &&
+				len(u.PublicKey) == sealbox.KeySize &&
+				bytes.Equal(u.PrivateKey, testPrivateKey)
+		})).
 		Return("", testutils.Err)
 
 	_, err := service.Register(context.Background(), testLogin, testPassword, testPassphrase)
@@ -283,13 +306,15 @@ func TestService_VerifySecret_Success(t *testing.T) {
 
 	repo := mocks.NewMockRepository(ctrl)
 	hasher := mocks.NewMockHasher(ctrl)
-	service := user.NewService(repo, hasher)
+	enc := mocks.NewMockEncryptor(ctrl)
+	service := user.NewService(repo, hasher, enc)
 
 	repo.EXPECT().
 		GetByID(gomock.Any(), testID).
 		Return(&models.User{
 			ID:             testID,
 			PassphraseHash: testPassphraseHash,
+			PublicKey:      []byte("public-key"),
 		}, nil)
 
 	hasher.EXPECT().
@@ -300,13 +325,43 @@ func TestService_VerifySecret_Success(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestService_VerifySecret_GenerateKey(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockRepository(ctrl)
+	hasher := mocks.NewMockHasher(ctrl)
+	enc := mocks.NewMockEncryptor(ctrl)
+	service := user.NewService(repo, hasher, enc)
+
+	repo.EXPECT().
+		GetByID(gomock.Any(), testID).
+		Return(&models.User{ID: testID, PassphraseHash: testPassphraseHash}, nil)
+
+	hasher.EXPECT().
+		Compare(testPassphraseHash, testPassphrase).
+		Return(true, nil)
+
+	enc.EXPECT().
+		Encrypt([]byte(testPassphrase), gomock.Any()).
+		Return(testPrivateKey, nil)
+
+	repo.EXPECT().
+		SetKeys(gomock.Any(), testID, gomock.Len(sealbox.KeySize), testPrivateKey).
+		Return(nil)
+
+	err := service.VerifyPassphrase(context.Background(), testID, testPassphrase)
+	require.NoError(t, err)
+}
+
 func TestService_VerifySecret_Fails_Get(t *testing.T) {
 	t.Parallel()
 	ctrl := gomock.NewController(t)
 	t.Cleanup(ctrl.Finish)
 
 	repo := mocks.NewMockRepository(ctrl)
-	service := user.NewService(repo, nil)
+	service := user.NewService(repo, nil, nil)
 
 	repo.EXPECT().
 		GetByID(gomock.Any(), testID).
@@ -323,7 +378,8 @@ func TestService_VerifySecret_Fails_HashErr(t *testing.T) {
 
 	repo := mocks.NewMockRepository(ctrl)
 	hasher := mocks.NewMockHasher(ctrl)
-	service := user.NewService(repo, hasher)
+	enc := mocks.NewMockEncryptor(ctrl)
+	service := user.NewService(repo, hasher, enc)
 
 	repo.EXPECT().
 		GetByID(gomock.Any(), testID).
@@ -344,7 +400,8 @@ func TestService_VerifySecret_Fails_Compare(t *testing.T) {
 
 	repo := mocks.NewMockRepository(ctrl)
 	hasher := mocks.NewMockHasher(ctrl)
-	service := user.NewService(repo, hasher)
+	enc := mocks.NewMockEncryptor(ctrl)
+	service := user.NewService(repo, hasher, enc)
 
 	repo.EXPECT().
 		GetByID(gomock.Any(), testID).
@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+type (
+	EmergencyID     string
+	EmergencyStatus string
+)
+
+const (
+	// EmergencyStatusInvited is the idle status of an emergency access, the contact may request the access.
+	EmergencyStatusInvited EmergencyStatus = "invited"
+	// EmergencyStatusRequested is the status of a requested access waiting for the grantor or the wait period.
+	EmergencyStatusRequested EmergencyStatus = "requested"
+	// EmergencyStatusGranted is the status of an access, which lets the contact read the vault of the grantor.
+	EmergencyStatusGranted EmergencyStatus = "granted"
+)
+
+// EmergencyAccess is a trusted contact of a user, who may request a read-only access to the user's vault.
+//
+// The access is granted once the grantor approves the request or does not reject it within the wait period.
+type EmergencyAccess struct {
+	ID      EmergencyID
+	Grantor *User
+	Grantee *User
+	Status  EmergencyStatus
+	// WaitDays is the number of days after a request, when the access is granted without an approval.
+	WaitDays int
+	// RequestedAt is the time of the last request, it is zero for idle accesses.
+	RequestedAt time.Time
+	// Key is the escrowed key of the grantor's vault sealed to the grantee's public key.
+	Key []byte
+}
@@ -0,0 +1,50 @@
+package models
+
+type (
+	OrgID        string
+	InvitationID string
+	OrgRole      string
+)
+
+const (
+	OrgRoleOwner  OrgRole = "owner"
+	OrgRoleAdmin  OrgRole = "admin"
+	OrgRoleEditor OrgRole = "editor"
+	OrgRoleViewer OrgRole = "viewer"
+)
+
+// CanWrite reports whether the role can create, update and delete secrets of the organization.
+func (r OrgRole) CanWrite() bool {
+	return r == OrgRoleOwner || r == OrgRoleAdmin || r == OrgRoleEditor
+}
+
+// CanManage reports whether the role can invite and remove members of the organization.
+func (r OrgRole) CanManage() bool {
+	return r == OrgRoleOwner || r == OrgRoleAdmin
+}
+
+// Org is an organization, which owns a vault of secrets shared by its members.
+type Org struct {
+	ID   OrgID
+	Name string
+}
+
+// Member is a user of an organization.
+type Member struct {
+	Org  *Org
+	User *User
+	Role OrgRole
+	// Key is the key of the organization vault sealed to the member's public key.
+	Key []byte
+}
+
+// Invitation is an invitation of a user to an organization, which becomes a membership once accepted.
+type Invitation struct {
+	ID      InvitationID
+	Org     *Org
+	Invitee *User
+	Inviter *User
+	Role    OrgRole
+	// Key is the key of the organization vault sealed to the invitee's public key.
+	Key []byte
+}
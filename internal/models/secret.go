@@ -1,40 +1,108 @@
 package models
 
+import (
+	"fmt"
+	"time"
+)
+
 type (
 	SecretID   string
 	SecretType int
 )
 
+// Secret types are stored by their IDs, so the order must not change.
 const (
 	SecretTypePwd SecretType = iota + 1
 	SecretTypeCard
 	SecretTypeTxt
 	SecretTypeFile
+	SecretTypeOTP
+	SecretTypeSSHKey
+	SecretTypeCustom
 )
 
+var secretTypeNames = make(map[SecretType]string)
+
+// RegisterSecretType registers the name of a secret type.
+//
+// It panics if the type or the name is already registered.
+func RegisterSecretType(t SecretType, name string) {
+	if _, ok := secretTypeNames[t]; ok {
+		panic(fmt.Sprintf("secret type %d is already registered", t))
+	}
+
+	if _, ok := ParseSecretType(name); ok {
+		panic(fmt.Sprintf("secret type %q is already registered", name))
+	}
+
+	secretTypeNames[t] = name
+}
+
+// ParseSecretType returns the registered type with the name.
+func ParseSecretType(name string) (SecretType, bool) {
+	for t, n := range secretTypeNames {
+		if n == name {
+			return t, true
+		}
+	}
+
+	return 0, false
+}
+
 func (t SecretType) String() string {
-	switch t {
-	case SecretTypePwd:
-		return "password"
-	case SecretTypeCard:
-		return "card"
-	case SecretTypeTxt:
-		return "text"
-	case SecretTypeFile:
-		return "file"
-	default:
-		return "unknown"
+	if name, ok := secretTypeNames[t]; ok {
+		return name
 	}
+
+	return "unknown"
 }
 
 type EncdData []byte
 
 type Secret struct {
-	ID    SecretID
-	Name  string
-	Type  SecretType
-	Data  EncdData
-	Owner *User
+	ID   SecretID
+	Name string
+	Type SecretType
+	Data EncdData
+	// BlobKey refers to the blob with the encrypted data, which is kept outside of the database then.
+	//
+	// It is empty for the data kept in the row.
+	BlobKey string
+	// Size is the size of the encrypted data in bytes, wherever it is kept, with the content blob.
+	Size int64
+	// ContentBlobKey refers to the blob with the content of a file streamed to it, which is encrypted
+	// with the content key of the file data. It is empty for the files with the content in the data.
+	ContentBlobKey string
+	// ContentSize is the size of the encrypted content in the content blob.
+	ContentSize int64
+	// Key is the data key encrypted with the owner's passphrase.
+	//
+	// It is empty while the data is encrypted with the passphrase itself,
+	// the data key is introduced when the secret is shared.
+	// It is always empty for secrets of organizations, which are encrypted with the organization key.
+	Key []byte
+	// EscrowKey is the data key encrypted with the escrow key of the owner, which reads the secret
+	// without the passphrase. It is set for the personal secrets of the owners with an escrow key.
+	EscrowKey   []byte
+	Fingerprint string
+	// Preview are the non-secret fields of the data to display, they are stored unencrypted.
+	Preview map[string]string
+	// URITokens are the tokens of the index of the password URIs, they are written, but not read.
+	URITokens []string
+	Owner     *User
+	// Org is nil for secrets of the personal vault.
+	Org *Org
+	// Revision is increased on each update, so the updates based on an older revision are detected.
+	Revision  int64
+	CreatedAt time.Time
+	// UpdatedAt is the time of the last update, it is CreatedAt if the secret was not updated.
+	UpdatedAt time.Time
+	// AccessedAt is the time the secret was last decrypted, it is zero if it was never decrypted.
+	AccessedAt time.Time
+	// ExpiresAt is zero if the secret does not expire.
+	ExpiresAt time.Time
+	// RotateEvery is zero if the secret does not need rotation.
+	RotateEvery time.Duration
 }
 
 func NewSecret(
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+type SendID string
+
+// Send is a one-time copy of a secret payload for a person without an account.
+//
+// The payload is encrypted with a key, which is only known to the holders of the send link.
+type Send struct {
+	ID    SendID
+	Owner *User
+	Data  []byte
+	// PasswordHash is the hash of the access password, it is empty for sends without one.
+	PasswordHash string
+	ViewsLeft    int
+	ExpiresAt    time.Time
+}
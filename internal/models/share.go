@@ -0,0 +1,17 @@
+package models
+
+type ShareAccess string
+
+const (
+	ShareAccessRead  ShareAccess = "read"
+	ShareAccessWrite ShareAccess = "write"
+)
+
+// Share grants a user access to another user's secret.
+type Share struct {
+	Secret    *Secret
+	Recipient *User
+	// Key is the data key of the secret sealed to the recipient's public key.
+	Key    []byte
+	Access ShareAccess
+}
@@ -0,0 +1,38 @@
+package models
+
+type (
+	TemplateID string
+	FieldType  string
+)
+
+const (
+	FieldTypeString    FieldType = "string"
+	FieldTypeConcealed FieldType = "concealed"
+	FieldTypeURL       FieldType = "url"
+	FieldTypeEmail     FieldType = "email"
+	FieldTypeDate      FieldType = "date"
+	FieldTypeNumber    FieldType = "number"
+	FieldTypeMultiline FieldType = "multiline"
+)
+
+type TemplateField struct {
+	Name     string    `json:"name"`
+	Type     FieldType `json:"type"`
+	Required bool      `json:"required"`
+}
+
+// Template describes the fields of a user-defined secret.
+type Template struct {
+	ID     TemplateID
+	Name   string
+	Fields []TemplateField
+	Owner  *User
+}
+
+func NewTemplate(name string, fields []TemplateField, owner *User) *Template {
+	return &Template{
+		Name:   name,
+		Fields: fields,
+		Owner:  owner,
+	}
+}
@@ -7,6 +7,13 @@ type (
 		Login          string
 		PasswordHash   string
 		PassphraseHash string
+		// PublicKey is the X25519 key to share secrets with the user, it is empty until generated.
+		PublicKey []byte
+		// PrivateKey is encrypted with the passphrase.
+		PrivateKey []byte
+		// EscrowKey is the key escrowed to the trusted contacts encrypted with the passphrase,
+		// it is empty until the user invites one.
+		EscrowKey []byte
 	}
 )
 
@@ -0,0 +1,31 @@
+package notify
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/novoseltcev/passkeeper/internal/domains/secrets"
+)
+
+// Log writes notifications to the log.
+type Log struct {
+	log *zap.Logger
+}
+
+var _ secrets.Notifier = (*Log)(nil)
+
+func NewLog(log *zap.Logger) *Log {
+	return &Log{log: log}
+}
+
+func (n *Log) Notify(_ context.Context, due secrets.DueSecret) error {
+	n.log.Info(subject(due),
+		zap.String("owner", string(due.Secret.Owner.ID)),
+		zap.String("secret", string(due.Secret.ID)),
+		zap.String("reason", string(due.Reason)),
+		zap.Time("due_at", due.DueAt),
+	)
+
+	return nil
+}
@@ -0,0 +1,16 @@
+// Package notify provides notifiers about due secrets.
+package notify
+
+import (
+	"fmt"
+
+	"github.com/novoseltcev/passkeeper/internal/domains/secrets"
+)
+
+func subject(due secrets.DueSecret) string {
+	if due.Reason == secrets.DueReasonExpired {
+		return fmt.Sprintf("Secret %q expired", due.Secret.Name)
+	}
+
+	return fmt.Sprintf("Secret %q became due for rotation", due.Secret.Name)
+}
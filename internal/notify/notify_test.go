@@ -0,0 +1,113 @@
+package notify_test
+
+import (
+	"context"
+	"net"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/novoseltcev/passkeeper/internal/domains/secrets"
+	"github.com/novoseltcev/passkeeper/internal/models"
+	"github.com/novoseltcev/passkeeper/internal/notify"
+)
+
+func testDue(login string) secrets.DueSecret {
+	return secrets.DueSecret{
+		Secret: models.Secret{ID: "secret-id", Name: "bank", Owner: &models.User{ID: "owner-id", Login: login}},
+		Reason: secrets.DueReasonExpired,
+		DueAt:  time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestLog_Notify(t *testing.T) {
+	t.Parallel()
+
+	core, logs := observer.New(zap.InfoLevel)
+
+	require.NoError(t, notify.NewLog(zap.New(core)).Notify(context.Background(), testDue("login")))
+
+	entries := logs.All()
+	require.Len(t, entries, 1)
+	assert.Equal(t, `Secret "bank" expired`, entries[0].Message)
+	assert.Equal(t, "owner-id", entries[0].ContextMap()["owner"])
+	assert.Equal(t, "expired", entries[0].ContextMap()["reason"])
+}
+
+func TestSMTP_Notify(t *testing.T) {
+	t.Parallel()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+
+	received := make(chan string, 1)
+	go serveSMTP(ln, received)
+
+	addr := ln.Addr().(*net.TCPAddr) // nolint: forcetypeassert
+	n := notify.NewSMTP("127.0.0.1", addr.Port, "", "", "passkeeper@example.com", nil)
+
+	require.NoError(t, n.Notify(context.Background(), testDue("owner@example.com")))
+
+	msg := <-received
+	assert.Contains(t, msg, "To: owner@example.com")
+	assert.Contains(t, msg, `Subject: Secret "bank" expired`)
+	assert.Contains(t, msg, "on 2024-02-01")
+}
+
+func TestSMTP_Notify_Fallback(t *testing.T) {
+	t.Parallel()
+
+	core, logs := observer.New(zap.InfoLevel)
+	n := notify.NewSMTP("127.0.0.1", 25, "", "", "passkeeper@example.com", notify.NewLog(zap.New(core)))
+
+	require.NoError(t, n.Notify(context.Background(), testDue("login")))
+	assert.Equal(t, 1, logs.Len())
+}
+
+// serveSMTP accepts a single mail and sends its data to received.
+func serveSMTP(ln net.Listener, received chan<- string) {
+	conn, err := ln.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	tp := textproto.NewConn(conn)
+	_ = tp.PrintfLine("220 localhost")
+
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+
+		switch cmd := strings.ToUpper(strings.Fields(line + " ")[0]); cmd {
+		case "EHLO", "HELO", "MAIL", "RCPT":
+			_ = tp.PrintfLine("250 OK")
+		case "DATA":
+			_ = tp.PrintfLine("354 go ahead")
+
+			data, err := tp.ReadDotLines()
+			if err != nil {
+				return
+			}
+
+			received <- strings.Join(data, "\n")
+			_ = tp.PrintfLine("250 OK")
+		case "QUIT":
+			_ = tp.PrintfLine("221 bye")
+
+			return
+		default:
+			_ = tp.PrintfLine("502 " + strconv.Quote(cmd))
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"time"
+
+	"github.com/novoseltcev/passkeeper/internal/domains/secrets"
+)
+
+// SMTP mails notifications to the owners, whose logins are email addresses.
+//
+// Notifications of other owners are passed to the fallback.
+type SMTP struct {
+	addr     string
+	from     string
+	auth     smtp.Auth
+	fallback secrets.Notifier
+}
+
+var _ secrets.Notifier = (*SMTP)(nil)
+
+// NewSMTP returns a notifier sending mails through the server.
+//
+// The authentication is skipped without the username.
+func NewSMTP(host string, port int, username, password, from string, fallback secrets.Notifier) *SMTP {
+	n := &SMTP{
+		addr:     net.JoinHostPort(host, strconv.Itoa(port)),
+		from:     from,
+		fallback: fallback,
+	}
+
+	if username != "" {
+		n.auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return n
+}
+
+func (n *SMTP) Notify(ctx context.Context, due secrets.DueSecret) error {
+	to, err := mail.ParseAddress(due.Secret.Owner.Login)
+	if err != nil {
+		return n.fallback.Notify(ctx, due)
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", n.from)
+	fmt.Fprintf(&msg, "To: %s\r\n", to.Address)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", subject(due))
+	fmt.Fprintf(&msg, "Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	fmt.Fprintf(&msg, "%s on %s.\r\n", subject(due), due.DueAt.Format(time.DateOnly))
+
+	if due.Reason == secrets.DueReasonExpired {
+		msg.WriteString("Replace it and update the secret in Passkeeper.\r\n")
+	} else {
+		msg.WriteString("Change it and update the secret in Passkeeper.\r\n")
+	}
+
+	return smtp.SendMail(n.addr, n.auth, n.from, []string{to.Address}, msg.Bytes())
+}
@@ -0,0 +1,366 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/novoseltcev/passkeeper/pkg/blobstore"
+	"github.com/novoseltcev/passkeeper/pkg/dbbackup"
+)
+
+// BackupTables are the backed up tables, the referenced tables go before the ones referring to them.
+var BackupTables = []string{
+	"accounts",
+	"sessions",
+	"templates",
+	"orgs",
+	"org_members",
+	"org_invitations",
+	"secrets",
+	"secret_shares",
+	"sends",
+	"emergency_access",
+}
+
+// blobKeysQuery selects the keys of the blobs the secrets refer to, the data and the contents of the files.
+const blobKeysQuery = `
+	SELECT blob_key FROM secrets WHERE blob_key IS NOT NULL
+	UNION
+	SELECT content_blob_key FROM secrets WHERE content_blob_key IS NOT NULL
+	ORDER BY 1
+`
+
+var (
+	ErrDirtySchema    = errors.New("database schema is dirty")
+	ErrSchemaMismatch = errors.New("backup schema version does not match the database")
+	ErrNotEmpty       = errors.New("database is not empty")
+	ErrUnknownTable   = errors.New("unknown backup table")
+	ErrNoBlobStore    = errors.New("secrets refer to blobs, but the blob store is not configured")
+	ErrMissingBlob    = errors.New("blob of a secret is missing")
+	ErrDuplicateBlob  = errors.New("duplicate backup blob")
+)
+
+// BlobStore keeps the blobs the secrets refer to, such as the encrypted contents of the files.
+type BlobStore interface {
+	// Put streams the blob from the reader, the blob is visible once it is complete.
+	Put(ctx context.Context, key string, r io.Reader) error
+	// Open returns a reader of the blob, which must be closed.
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete deletes the blob, a missing blob is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+// BackupCounts are the numbers of the rows of each table and of the blobs of a backup.
+type BackupCounts struct {
+	Rows  map[string]int64
+	Blobs int64
+}
+
+// Backup writes the rows of the backed up tables and the blobs the secrets refer to to w.
+//
+// The rows are read in a single read only transaction, so the backup is a consistent snapshot.
+// The secrets are backed up as they are stored, their data and their blobs stay encrypted.
+// The blobs may be nil, if no secret refers to a blob.
+func Backup(ctx context.Context, db *sqlx.DB, blobs BlobStore, w io.Writer) (*BackupCounts, error) {
+	tx, err := db.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback() // nolint: errcheck
+
+	version, err := schemaVersion(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	writer, err := dbbackup.NewWriter(w, version)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, table := range BackupTables {
+		if err := backupTable(ctx, tx, writer, table); err != nil {
+			return nil, fmt.Errorf("failed to back up %s: %w", table, err)
+		}
+	}
+
+	var keys []string
+	if err := tx.SelectContext(ctx, &keys, blobKeysQuery); err != nil {
+		return nil, err
+	}
+
+	if len(keys) > 0 && blobs == nil {
+		return nil, ErrNoBlobStore
+	}
+
+	// the blobs are not changed in place, the writes put new blobs, so the blobs of the snapshot are kept
+	// until the collection of the blobs no secret refers to
+	for _, key := range keys {
+		if err := backupBlob(ctx, blobs, writer, key); err != nil {
+			return nil, fmt.Errorf("failed to back up blob %s: %w", key, err)
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	return &BackupCounts{Rows: writer.Rows(), Blobs: int64(len(keys))}, tx.Commit()
+}
+
+// Restore restores the backup read from r into the empty database of the same schema version.
+//
+// The rows are inserted in a single transaction, which is committed only if the checksum of the backup matches
+// and every blob the secrets refer to is restored or is already in the store.
+// The restored blobs are deleted if the restore fails. The blobs may be nil, if the backup has no blobs.
+func Restore(ctx context.Context, db *sqlx.DB, blobs BlobStore, r io.Reader) (counts *BackupCounts, err error) {
+	reader, err := dbbackup.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback() // nolint: errcheck
+
+	version, err := schemaVersion(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if reader.Header().SchemaVersion != version {
+		return nil, fmt.Errorf("%w: %d, the database is %d", ErrSchemaMismatch, reader.Header().SchemaVersion, version)
+	}
+
+	for _, table := range BackupTables {
+		var exists bool
+		if err := tx.GetContext(ctx, &exists, `SELECT EXISTS (SELECT 1 FROM `+table+`)`); err != nil {
+			return nil, err
+		}
+
+		if exists {
+			return nil, fmt.Errorf("%w: %s has rows", ErrNotEmpty, table)
+		}
+	}
+
+	restorer := &blobRestorer{store: blobs, restored: make(map[string]bool)}
+	defer func() {
+		if err != nil {
+			restorer.abort(context.WithoutCancel(ctx), err)
+		}
+	}()
+
+	counts = &BackupCounts{Rows: make(map[string]int64)}
+
+	for {
+		entry, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if entry.Blob != "" {
+			if err := restorer.write(ctx, entry.Blob, entry.Data); err != nil {
+				return nil, fmt.Errorf("failed to restore blob %s: %w", entry.Blob, err)
+			}
+
+			continue
+		}
+
+		// the name of the table is a part of the query, so it must be one of the known tables
+		if !slices.Contains(BackupTables, entry.Table) {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownTable, entry.Table)
+		}
+
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO `+entry.Table+`
+			SELECT * FROM json_populate_record(NULL::`+entry.Table+`, $1::text::json)
+		`, string(entry.Row)); err != nil {
+			return nil, fmt.Errorf("failed to restore %s: %w", entry.Table, err)
+		}
+
+		counts.Rows[entry.Table]++
+	}
+
+	if err := restorer.close(); err != nil {
+		return nil, err
+	}
+
+	counts.Blobs = int64(len(restorer.restored))
+
+	if err := checkBlobs(ctx, tx, blobs, restorer.restored); err != nil {
+		return nil, err
+	}
+
+	return counts, tx.Commit()
+}
+
+// checkBlobs checks that the blobs the restored secrets refer to are restored or are in the store.
+func checkBlobs(ctx context.Context, tx *sqlx.Tx, blobs BlobStore, restored map[string]bool) error {
+	var keys []string
+	if err := tx.SelectContext(ctx, &keys, blobKeysQuery); err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		if restored[key] {
+			continue
+		}
+
+		if blobs == nil {
+			return fmt.Errorf("%w: %s", ErrMissingBlob, key)
+		}
+
+		blob, err := blobs.Open(ctx, key)
+		if errors.Is(err, blobstore.ErrNotFound) {
+			return fmt.Errorf("%w: %s", ErrMissingBlob, key)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if err := blob.Close(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// schemaVersion returns the version of the applied migrations.
+func schemaVersion(ctx context.Context, tx *sqlx.Tx) (uint, error) {
+	var migration struct {
+		Version uint `db:"version"`
+		Dirty   bool `db:"dirty"`
+	}
+
+	if err := tx.GetContext(ctx, &migration, `SELECT version, dirty FROM schema_migrations`); err != nil {
+		return 0, fmt.Errorf("failed to get schema version: %w", err)
+	}
+
+	if migration.Dirty {
+		return 0, fmt.Errorf("%w: version %d", ErrDirtySchema, migration.Version)
+	}
+
+	return migration.Version, nil
+}
+
+func backupBlob(ctx context.Context, blobs BlobStore, writer *dbbackup.Writer, key string) error {
+	blob, err := blobs.Open(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	if err := writer.WriteBlob(key, blob); err != nil {
+		blob.Close()
+
+		return err
+	}
+
+	return blob.Close()
+}
+
+// blobRestorer streams the chunks of the blobs of a backup to the store, the chunks of a blob follow each other.
+type blobRestorer struct {
+	store    BlobStore
+	restored map[string]bool
+	// key is the blob being written to w, the result of the put is sent to done
+	key  string
+	w    *io.PipeWriter
+	done chan error
+}
+
+func (r *blobRestorer) write(ctx context.Context, key string, data []byte) error {
+	if key != r.key {
+		if err := r.close(); err != nil {
+			return err
+		}
+
+		if r.store == nil {
+			return ErrNoBlobStore
+		}
+
+		if r.restored[key] {
+			return ErrDuplicateBlob
+		}
+
+		reader, writer := io.Pipe()
+		done := make(chan error, 1)
+		r.key, r.w, r.done = key, writer, done
+		r.restored[key] = true
+
+		go func() {
+			err := r.store.Put(ctx, key, reader)
+			// the put may stop before the end of the blob, the next write must not block then
+			reader.CloseWithError(err)
+			done <- err
+		}()
+	}
+
+	if len(data) == 0 {
+		return nil
+	}
+
+	_, err := r.w.Write(data)
+
+	return err
+}
+
+// close completes the put of the current blob.
+func (r *blobRestorer) close() error {
+	if r.w == nil {
+		return nil
+	}
+
+	r.w.Close()
+	err := <-r.done
+	r.key, r.w = "", nil
+
+	return err
+}
+
+// abort stops the put of the current blob and deletes the restored blobs.
+func (r *blobRestorer) abort(ctx context.Context, cause error) {
+	if r.w != nil {
+		r.w.CloseWithError(cause)
+		<-r.done
+	}
+
+	for key := range r.restored {
+		_ = r.store.Delete(ctx, key)
+	}
+}
+
+func backupTable(ctx context.Context, tx *sqlx.Tx, writer *dbbackup.Writer, table string) error {
+	rows, err := tx.QueryContext(ctx, `SELECT row_to_json(t) FROM `+table+` t`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row []byte
+		if err := rows.Scan(&row); err != nil {
+			return err
+		}
+
+		if err := writer.WriteRow(table, json.RawMessage(row)); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
@@ -0,0 +1,209 @@
+package repo_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/novoseltcev/passkeeper/internal/repo"
+	"github.com/novoseltcev/passkeeper/pkg/blobstore"
+	"github.com/novoseltcev/passkeeper/pkg/dbbackup"
+	"github.com/novoseltcev/passkeeper/pkg/testutils/helpers"
+)
+
+var backupScripts = []string{
+	"base.sql", "due.sql", "orgs.sql", "shares.sql", "sends.sql", "emergency.sql", "blobs.sql",
+}
+
+// readBackup returns the rows of the backup by their tables and the blobs by their keys.
+func readBackup(t *testing.T, data []byte) (map[string][]string, map[string]string) {
+	t.Helper()
+
+	reader, err := dbbackup.NewReader(bytes.NewReader(data))
+	require.NoError(t, err)
+
+	rows, blobs := make(map[string][]string), make(map[string]string)
+
+	for {
+		entry, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return rows, blobs
+		}
+
+		require.NoError(t, err)
+
+		if entry.Blob != "" {
+			blobs[entry.Blob] += string(entry.Data)
+		} else {
+			rows[entry.Table] = append(rows[entry.Table], string(entry.Row))
+		}
+	}
+}
+
+// newBlobStore returns a store in a temporary directory with the blobs.
+func newBlobStore(t *testing.T, blobs map[string]string) *blobstore.FS {
+	t.Helper()
+
+	store, err := blobstore.NewFS(t.TempDir())
+	require.NoError(t, err)
+
+	for key, data := range blobs {
+		require.NoError(t, store.Put(context.Background(), key, strings.NewReader(data)))
+	}
+
+	return store
+}
+
+// listBlobs returns the keys of the blobs of the store.
+func listBlobs(t *testing.T, store *blobstore.FS) []string {
+	t.Helper()
+
+	infos, err := store.List(context.Background())
+	require.NoError(t, err)
+
+	keys := make([]string, 0, len(infos))
+	for _, info := range infos {
+		keys = append(keys, info.Key)
+	}
+
+	return keys
+}
+
+var testBlobs = map[string]string{"data-blob": "data", "content-blob": "abc", "orphan-blob": "orphan"}
+
+func TestBackupRestore(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(context.Background(), ctxTimeout)
+	t.Cleanup(cancel)
+	src := helpers.SetupDB(ctx, t, migrationsDir, backupScripts...)
+	dst := helpers.SetupDB(ctx, t, migrationsDir)
+	srcBlobs, dstBlobs := newBlobStore(t, testBlobs), newBlobStore(t, nil)
+
+	var backup bytes.Buffer
+
+	counts, err := repo.Backup(ctx, src, srcBlobs, &backup)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), counts.Rows["accounts"])
+	assert.Positive(t, counts.Rows["secrets"])
+	// the blobs no secret refers to are not backed up
+	assert.Equal(t, int64(2), counts.Blobs)
+
+	restored, err := repo.Restore(ctx, dst, dstBlobs, bytes.NewReader(backup.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, counts, restored)
+	assert.ElementsMatch(t, []string{"content-blob", "data-blob"}, listBlobs(t, dstBlobs))
+
+	var again bytes.Buffer
+
+	_, err = repo.Backup(ctx, dst, dstBlobs, &again)
+	require.NoError(t, err)
+
+	wantRows, wantBlobs := readBackup(t, backup.Bytes())
+	gotRows, gotBlobs := readBackup(t, again.Bytes())
+
+	for _, table := range repo.BackupTables {
+		assert.ElementsMatch(t, wantRows[table], gotRows[table], table)
+	}
+
+	assert.Equal(t, map[string]string{"data-blob": "data", "content-blob": "abc"}, wantBlobs)
+	assert.Equal(t, wantBlobs, gotBlobs)
+}
+
+func TestBackup_Blobs_Fails(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(context.Background(), ctxTimeout)
+	t.Cleanup(cancel)
+	db := helpers.SetupDB(ctx, t, migrationsDir, "base.sql", "blobs.sql")
+
+	_, err := repo.Backup(ctx, db, nil, io.Discard)
+	require.ErrorIs(t, err, repo.ErrNoBlobStore)
+
+	_, err = repo.Backup(ctx, db, newBlobStore(t, map[string]string{"data-blob": "data"}), io.Discard)
+	require.ErrorIs(t, err, blobstore.ErrNotFound)
+}
+
+func TestRestore_Blobs_Fails(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(context.Background(), ctxTimeout)
+	t.Cleanup(cancel)
+	src := helpers.SetupDB(ctx, t, migrationsDir, "base.sql", "blobs.sql")
+	dst := helpers.SetupDB(ctx, t, migrationsDir)
+
+	var backup bytes.Buffer
+
+	_, err := repo.Backup(ctx, src, newBlobStore(t, testBlobs), &backup)
+	require.NoError(t, err)
+
+	_, err = repo.Restore(ctx, dst, nil, bytes.NewReader(backup.Bytes()))
+	require.ErrorIs(t, err, repo.ErrNoBlobStore)
+
+	// the restored blobs are deleted, if the backup is corrupted after them
+	dstBlobs := newBlobStore(t, nil)
+	corrupted := append(bytes.Clone(backup.Bytes()[:backup.Len()-8]), 0, 0, 0, 0, 0, 0, 0, 0)
+
+	_, err = repo.Restore(ctx, dst, dstBlobs, bytes.NewReader(corrupted))
+	require.ErrorIs(t, err, dbbackup.ErrInvalidArchive)
+	assert.Empty(t, listBlobs(t, dstBlobs))
+
+	// the blobs missing from a backup must be in the store
+	var rowsOnly bytes.Buffer
+
+	w, err := dbbackup.NewWriter(&rowsOnly, backupSchemaVersion(t, backup.Bytes()))
+	require.NoError(t, err)
+
+	rows, _ := readBackup(t, backup.Bytes())
+	for _, table := range repo.BackupTables {
+		for _, row := range rows[table] {
+			require.NoError(t, w.WriteRow(table, json.RawMessage(row)))
+		}
+	}
+
+	require.NoError(t, w.Close())
+
+	_, err = repo.Restore(ctx, dst, dstBlobs, bytes.NewReader(rowsOnly.Bytes()))
+	require.ErrorIs(t, err, repo.ErrMissingBlob)
+
+	restored, err := repo.Restore(ctx, dst, newBlobStore(t, testBlobs), bytes.NewReader(rowsOnly.Bytes()))
+	require.NoError(t, err)
+	assert.Zero(t, restored.Blobs)
+}
+
+func backupSchemaVersion(t *testing.T, data []byte) uint {
+	t.Helper()
+
+	reader, err := dbbackup.NewReader(bytes.NewReader(data))
+	require.NoError(t, err)
+
+	return reader.Header().SchemaVersion
+}
+
+func TestRestore_Fails(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(context.Background(), ctxTimeout)
+	t.Cleanup(cancel)
+	db := helpers.SetupDB(ctx, t, migrationsDir, "base.sql")
+
+	var backup bytes.Buffer
+
+	_, err := repo.Backup(ctx, db, nil, &backup)
+	require.NoError(t, err)
+
+	_, err = repo.Restore(ctx, db, nil, bytes.NewReader(backup.Bytes()))
+	require.ErrorIs(t, err, repo.ErrNotEmpty)
+
+	var other bytes.Buffer
+
+	w, err := dbbackup.NewWriter(&other, 1)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	_, err = repo.Restore(ctx, db, nil, bytes.NewReader(other.Bytes()))
+	require.ErrorIs(t, err, repo.ErrSchemaMismatch)
+}
@@ -0,0 +1,193 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/jmoiron/sqlx"
+
+	domain "github.com/novoseltcev/passkeeper/internal/domains/emergency"
+	"github.com/novoseltcev/passkeeper/internal/models"
+)
+
+type emergencyRepository struct {
+	db *sqlx.DB
+}
+
+type emergencyInDB struct {
+	UUID         string       `db:"uuid"`
+	GrantorID    string       `db:"grantor_uuid"`
+	GrantorLogin string       `db:"grantor_login"`
+	GranteeID    string       `db:"grantee_uuid"`
+	GranteeLogin string       `db:"grantee_login"`
+	Status       string       `db:"status"`
+	WaitDays     int          `db:"wait_days"`
+	RequestedAt  sql.NullTime `db:"requested_at"`
+	Key          []byte       `db:"key"`
+}
+
+func (e emergencyInDB) ToDomain() *models.EmergencyAccess {
+	return &models.EmergencyAccess{
+		ID:          models.EmergencyID(e.UUID),
+		Grantor:     &models.User{ID: models.UserID(e.GrantorID), Login: e.GrantorLogin},
+		Grantee:     &models.User{ID: models.UserID(e.GranteeID), Login: e.GranteeLogin},
+		Status:      models.EmergencyStatus(e.Status),
+		WaitDays:    e.WaitDays,
+		RequestedAt: e.RequestedAt.Time,
+		Key:         e.Key,
+	}
+}
+
+// emergencyAttrs are the selected columns of an emergency access joined with the accounts of its parties.
+const emergencyAttrs = `
+	emergency_access.uuid, grantor_uuid, grantors.login AS grantor_login, grantee_uuid, grantees.login AS grantee_login,
+	status, wait_days, requested_at, key
+	FROM emergency_access
+		JOIN accounts grantors ON emergency_access.grantor_uuid = grantors.uuid
+		JOIN accounts grantees ON emergency_access.grantee_uuid = grantees.uuid`
+
+var _ domain.Repository = (*emergencyRepository)(nil)
+
+func NewEmergencyRepository(db *sqlx.DB) *emergencyRepository { // nolint: revive
+	return &emergencyRepository{db: db}
+}
+
+func (r *emergencyRepository) GetUser(ctx context.Context, id models.UserID) (*models.User, error) {
+	var user userInDB
+
+	err := r.db.GetContext(ctx, &user, `
+		SELECT uuid, login, password_hash, passphrase_hash, public_key, private_key
+		FROM accounts
+			WHERE uuid = $1
+	`, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrUserNotFound
+		}
+
+		return nil, err
+	}
+
+	return user.ToDomain(), nil
+}
+
+func (r *emergencyRepository) GetUserByLogin(ctx context.Context, login string) (*models.User, error) {
+	var user userInDB
+
+	err := r.db.GetContext(ctx, &user, `
+		SELECT uuid, login, password_hash, passphrase_hash, public_key, private_key
+		FROM accounts
+			WHERE login = $1
+	`, login)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrUserNotFound
+		}
+
+		return nil, err
+	}
+
+	return user.ToDomain(), nil
+}
+
+func (r *emergencyRepository) Save(ctx context.Context, access *models.EmergencyAccess) (models.EmergencyID, error) {
+	var id string
+
+	err := r.db.GetContext(ctx, &id, `
+		INSERT INTO emergency_access (grantor_uuid, grantee_uuid, status, wait_days, key)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (grantor_uuid, grantee_uuid) DO UPDATE
+			SET status = EXCLUDED.status, wait_days = EXCLUDED.wait_days, key = EXCLUDED.key, requested_at = NULL
+		RETURNING uuid
+	`, access.Grantor.ID, access.Grantee.ID, access.Status, access.WaitDays, access.Key)
+	if err != nil {
+		return "", err
+	}
+
+	return models.EmergencyID(id), nil
+}
+
+func (r *emergencyRepository) Get(ctx context.Context, id models.EmergencyID) (*models.EmergencyAccess, error) {
+	var access emergencyInDB
+
+	err := r.db.GetContext(ctx, &access, `SELECT`+emergencyAttrs+` WHERE emergency_access.uuid = $1`, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrAccessNotFound
+		}
+
+		return nil, err
+	}
+
+	return access.ToDomain(), nil
+}
+
+func (r *emergencyRepository) GetByGrantor(
+	ctx context.Context,
+	userID models.UserID,
+) ([]models.EmergencyAccess, error) {
+	return r.selectAll(ctx, `SELECT`+emergencyAttrs+` WHERE grantor_uuid = $1 ORDER BY grantees.login`, userID)
+}
+
+func (r *emergencyRepository) GetByGrantee(
+	ctx context.Context,
+	userID models.UserID,
+) ([]models.EmergencyAccess, error) {
+	return r.selectAll(ctx, `SELECT`+emergencyAttrs+` WHERE grantee_uuid = $1 ORDER BY grantors.login`, userID)
+}
+
+func (r *emergencyRepository) SetStatus(
+	ctx context.Context,
+	id models.EmergencyID,
+	status models.EmergencyStatus,
+) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE emergency_access
+			SET status = $2, requested_at = CASE $2::VARCHAR
+				WHEN 'requested' THEN NOW()
+				WHEN 'invited' THEN NULL
+				ELSE requested_at
+			END
+			WHERE uuid = $1
+	`, id, status)
+
+	return err
+}
+
+func (r *emergencyRepository) Delete(ctx context.Context, id models.EmergencyID) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM emergency_access WHERE uuid = $1`, id)
+
+	return err
+}
+
+func (r *emergencyRepository) GrantExpired(ctx context.Context) (int64, error) {
+	res, err := r.db.ExecContext(ctx, `
+		UPDATE emergency_access SET status = 'granted'
+			WHERE status = 'requested' AND requested_at + wait_days * INTERVAL '1 day' <= NOW()
+	`)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+func (r *emergencyRepository) selectAll(
+	ctx context.Context,
+	query string,
+	userID models.UserID,
+) ([]models.EmergencyAccess, error) {
+	var accesses []emergencyInDB
+
+	if err := r.db.SelectContext(ctx, &accesses, query, userID); err != nil {
+		return nil, err
+	}
+
+	items := make([]models.EmergencyAccess, len(accesses))
+	for i, access := range accesses {
+		items[i] = *access.ToDomain()
+	}
+
+	return items, nil
+}
@@ -0,0 +1,101 @@
+package repo_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domain "github.com/novoseltcev/passkeeper/internal/domains/emergency"
+	"github.com/novoseltcev/passkeeper/internal/models"
+	"github.com/novoseltcev/passkeeper/internal/repo"
+	"github.com/novoseltcev/passkeeper/pkg/testutils/helpers"
+)
+
+const (
+	emergencyUUID        = "7d3e2f1a-6b5c-4d8e-9f0a-1b2c3d4e5f60"
+	waitingEmergencyUUID = "8e4f3a2b-7c6d-4e9f-8a1b-2c3d4e5f6a71"
+)
+
+func TestEmergencyRepository_Save(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(context.Background(), ctxTimeout)
+	t.Cleanup(cancel)
+	repo := repo.NewEmergencyRepository(helpers.SetupDB(ctx, t, migrationsDir, "base.sql", "emergency.sql"))
+
+	id, err := repo.Save(ctx, &models.EmergencyAccess{
+		Grantor:  &models.User{ID: accountUUID},
+		Grantee:  &models.User{ID: recipientUUID},
+		Status:   models.EmergencyStatusInvited,
+		WaitDays: 3,
+		Key:      []byte{0x01},
+	})
+	require.NoError(t, err)
+	require.NoError(t, uuid.Validate(string(id)))
+	assert.Equal(t, models.EmergencyID(emergencyUUID), id)
+
+	access, err := repo.Get(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, &models.EmergencyAccess{
+		ID:       emergencyUUID,
+		Grantor:  &models.User{ID: accountUUID, Login: "test@example.com"},
+		Grantee:  &models.User{ID: recipientUUID, Login: "test@test.com"},
+		Status:   models.EmergencyStatusInvited,
+		WaitDays: 3,
+		Key:      []byte{0x01},
+	}, access)
+}
+
+func TestEmergencyRepository_GetBy(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(context.Background(), ctxTimeout)
+	t.Cleanup(cancel)
+	repo := repo.NewEmergencyRepository(helpers.SetupDB(ctx, t, migrationsDir, "base.sql", "emergency.sql"))
+
+	contacts, err := repo.GetByGrantor(ctx, accountUUID)
+	require.NoError(t, err)
+	require.Len(t, contacts, 1)
+	assert.Equal(t, models.EmergencyID(emergencyUUID), contacts[0].ID)
+	assert.False(t, contacts[0].RequestedAt.IsZero())
+
+	grantors, err := repo.GetByGrantee(ctx, accountUUID)
+	require.NoError(t, err)
+	require.Len(t, grantors, 1)
+	assert.Equal(t, models.EmergencyID(waitingEmergencyUUID), grantors[0].ID)
+
+	_, err = repo.Get(ctx, secretUUID1)
+	assert.ErrorIs(t, err, domain.ErrAccessNotFound)
+}
+
+func TestEmergencyRepository_Status(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(context.Background(), ctxTimeout)
+	t.Cleanup(cancel)
+	repo := repo.NewEmergencyRepository(helpers.SetupDB(ctx, t, migrationsDir, "base.sql", "emergency.sql"))
+
+	granted, err := repo.GrantExpired(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), granted)
+
+	access, err := repo.Get(ctx, emergencyUUID)
+	require.NoError(t, err)
+	assert.Equal(t, models.EmergencyStatusGranted, access.Status)
+
+	access, err = repo.Get(ctx, waitingEmergencyUUID)
+	require.NoError(t, err)
+	assert.Equal(t, models.EmergencyStatusRequested, access.Status)
+
+	require.NoError(t, repo.SetStatus(ctx, emergencyUUID, models.EmergencyStatusInvited))
+
+	access, err = repo.Get(ctx, emergencyUUID)
+	require.NoError(t, err)
+	assert.Equal(t, models.EmergencyStatusInvited, access.Status)
+	assert.True(t, access.RequestedAt.IsZero())
+
+	require.NoError(t, repo.Delete(ctx, emergencyUUID))
+
+	_, err = repo.Get(ctx, emergencyUUID)
+	assert.ErrorIs(t, err, domain.ErrAccessNotFound)
+}
@@ -0,0 +1,309 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/jmoiron/sqlx"
+
+	domain "github.com/novoseltcev/passkeeper/internal/domains/orgs"
+	"github.com/novoseltcev/passkeeper/internal/models"
+)
+
+type orgRepository struct {
+	db *sqlx.DB
+}
+
+type memberInDB struct {
+	OrgID   string `db:"org_uuid"`
+	OrgName string `db:"org_name"`
+	Role    string `db:"role"`
+	Key     []byte `db:"member_key"`
+	userInDB
+}
+
+func (m memberInDB) ToDomain() *models.Member {
+	return &models.Member{
+		Org:  &models.Org{ID: models.OrgID(m.OrgID), Name: m.OrgName},
+		User: m.userInDB.ToDomain(),
+		Role: models.OrgRole(m.Role),
+		Key:  m.Key,
+	}
+}
+
+type invitationInDB struct {
+	ID           string `db:"invitation_uuid"`
+	OrgID        string `db:"org_uuid"`
+	OrgName      string `db:"org_name"`
+	InviteeID    string `db:"invitee_uuid"`
+	InviterID    string `db:"inviter_uuid"`
+	InviterLogin string `db:"inviter_login"`
+	Role         string `db:"role"`
+	Key          []byte `db:"invitation_key"`
+}
+
+func (i invitationInDB) ToDomain() *models.Invitation {
+	return &models.Invitation{
+		ID:      models.InvitationID(i.ID),
+		Org:     &models.Org{ID: models.OrgID(i.OrgID), Name: i.OrgName},
+		Invitee: &models.User{ID: models.UserID(i.InviteeID)},
+		Inviter: &models.User{ID: models.UserID(i.InviterID), Login: i.InviterLogin},
+		Role:    models.OrgRole(i.Role),
+		Key:     i.Key,
+	}
+}
+
+// memberAttrs are the selected columns of a membership joined with its organization and account.
+const memberAttrs = `
+	org_members.org_uuid, orgs.name AS org_name, role, org_members.key AS member_key,
+	accounts.uuid, login, password_hash, passphrase_hash, public_key, private_key`
+
+// invitationAttrs are the selected columns of an invitation joined with its organization and inviter.
+const invitationAttrs = `
+	org_invitations.uuid AS invitation_uuid, org_uuid, orgs.name AS org_name, invitee_uuid, inviter_uuid,
+	accounts.login AS inviter_login, role, org_invitations.key AS invitation_key`
+
+var _ domain.Repository = (*orgRepository)(nil)
+
+func NewOrgRepository(db *sqlx.DB) *orgRepository { // nolint: revive
+	return &orgRepository{db: db}
+}
+
+func (r *orgRepository) GetUser(ctx context.Context, id models.UserID) (*models.User, error) {
+	var user userInDB
+
+	err := r.db.GetContext(ctx, &user, `
+		SELECT uuid, login, password_hash, passphrase_hash, public_key, private_key
+		FROM accounts
+			WHERE uuid = $1
+	`, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrUserNotFound
+		}
+
+		return nil, err
+	}
+
+	return user.ToDomain(), nil
+}
+
+func (r *orgRepository) GetUserByLogin(ctx context.Context, login string) (*models.User, error) {
+	var user userInDB
+
+	err := r.db.GetContext(ctx, &user, `
+		SELECT uuid, login, password_hash, passphrase_hash, public_key, private_key
+		FROM accounts
+			WHERE login = $1
+	`, login)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrUserNotFound
+		}
+
+		return nil, err
+	}
+
+	return user.ToDomain(), nil
+}
+
+func (r *orgRepository) Create(ctx context.Context, org *models.Org, owner *models.Member) (models.OrgID, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback() // nolint: errcheck
+
+	var id string
+	if err = tx.GetContext(ctx, &id, `INSERT INTO orgs (name) VALUES ($1) RETURNING uuid`, org.Name); err != nil {
+		return "", err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO org_members (org_uuid, account_uuid, role, key)
+		VALUES ($1, $2, $3, $4)
+	`, id, owner.User.ID, owner.Role, owner.Key)
+	if err != nil {
+		return "", err
+	}
+
+	return models.OrgID(id), tx.Commit()
+}
+
+func (r *orgRepository) GetMember(ctx context.Context, id models.OrgID, userID models.UserID) (*models.Member, error) {
+	member, err := getMember(ctx, r.db, id, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotMember
+		}
+
+		return nil, err
+	}
+
+	return member, nil
+}
+
+func (r *orgRepository) GetMembers(ctx context.Context, id models.OrgID) ([]models.Member, error) {
+	var members []memberInDB
+
+	err := r.db.SelectContext(ctx, &members, `
+		SELECT`+memberAttrs+`
+		FROM org_members
+			JOIN orgs ON org_members.org_uuid = orgs.uuid
+			JOIN accounts ON org_members.account_uuid = accounts.uuid
+				WHERE org_members.org_uuid = $1
+					ORDER BY login
+	`, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return toDomainMembers(members), nil
+}
+
+func (r *orgRepository) GetMemberships(ctx context.Context, userID models.UserID) ([]models.Member, error) {
+	var members []memberInDB
+
+	err := r.db.SelectContext(ctx, &members, `
+		SELECT`+memberAttrs+`
+		FROM org_members
+			JOIN orgs ON org_members.org_uuid = orgs.uuid
+			JOIN accounts ON org_members.account_uuid = accounts.uuid
+				WHERE org_members.account_uuid = $1
+					ORDER BY orgs.name
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toDomainMembers(members), nil
+}
+
+func (r *orgRepository) DeleteMember(ctx context.Context, id models.OrgID, userID models.UserID) error {
+	_, err := r.db.ExecContext(ctx, `
+		DELETE FROM org_members WHERE org_uuid = $1 AND account_uuid = $2
+	`, id, userID)
+
+	return err
+}
+
+func (r *orgRepository) SaveInvitation(
+	ctx context.Context,
+	invitation *models.Invitation,
+) (models.InvitationID, error) {
+	var id string
+
+	err := r.db.GetContext(ctx, &id, `
+		INSERT INTO org_invitations (org_uuid, invitee_uuid, inviter_uuid, role, key)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (org_uuid, invitee_uuid) DO UPDATE
+			SET inviter_uuid = EXCLUDED.inviter_uuid, role = EXCLUDED.role, key = EXCLUDED.key, created_at = NOW()
+		RETURNING uuid
+	`, invitation.Org.ID, invitation.Invitee.ID, invitation.Inviter.ID, invitation.Role, invitation.Key)
+	if err != nil {
+		return "", err
+	}
+
+	return models.InvitationID(id), nil
+}
+
+func (r *orgRepository) GetInvitation(ctx context.Context, id models.InvitationID) (*models.Invitation, error) {
+	var invitation invitationInDB
+
+	err := r.db.GetContext(ctx, &invitation, `
+		SELECT`+invitationAttrs+`
+		FROM org_invitations
+			JOIN orgs ON org_invitations.org_uuid = orgs.uuid
+			JOIN accounts ON org_invitations.inviter_uuid = accounts.uuid
+				WHERE org_invitations.uuid = $1
+	`, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrInvitationNotFound
+		}
+
+		return nil, err
+	}
+
+	return invitation.ToDomain(), nil
+}
+
+func (r *orgRepository) GetInvitations(ctx context.Context, userID models.UserID) ([]models.Invitation, error) {
+	var invitations []invitationInDB
+
+	err := r.db.SelectContext(ctx, &invitations, `
+		SELECT`+invitationAttrs+`
+		FROM org_invitations
+			JOIN orgs ON org_invitations.org_uuid = orgs.uuid
+			JOIN accounts ON org_invitations.inviter_uuid = accounts.uuid
+				WHERE invitee_uuid = $1
+					ORDER BY org_invitations.created_at DESC
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]models.Invitation, len(invitations))
+	for i, invitation := range invitations {
+		items[i] = *invitation.ToDomain()
+	}
+
+	return items, nil
+}
+
+func (r *orgRepository) AcceptInvitation(ctx context.Context, invitation *models.Invitation) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() // nolint: errcheck
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO org_members (org_uuid, account_uuid, role, key)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (org_uuid, account_uuid) DO UPDATE
+			SET role = EXCLUDED.role, key = EXCLUDED.key
+	`, invitation.Org.ID, invitation.Invitee.ID, invitation.Role, invitation.Key)
+	if err != nil {
+		return err
+	}
+
+	if _, err = tx.ExecContext(ctx, `DELETE FROM org_invitations WHERE uuid = $1`, invitation.ID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *orgRepository) DeleteInvitation(ctx context.Context, id models.InvitationID) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM org_invitations WHERE uuid = $1`, id)
+
+	return err
+}
+
+func getMember(ctx context.Context, db conn, id models.OrgID, userID models.UserID) (*models.Member, error) {
+	var member memberInDB
+
+	err := db.GetContext(ctx, &member, `
+		SELECT`+memberAttrs+`
+		FROM org_members
+			JOIN orgs ON org_members.org_uuid = orgs.uuid
+			JOIN accounts ON org_members.account_uuid = accounts.uuid
+				WHERE org_members.org_uuid = $1 AND org_members.account_uuid = $2
+	`, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return member.ToDomain(), nil
+}
+
+func toDomainMembers(members []memberInDB) []models.Member {
+	items := make([]models.Member, len(members))
+	for i, member := range members {
+		items[i] = *member.ToDomain()
+	}
+
+	return items
+}
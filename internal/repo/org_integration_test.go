@@ -0,0 +1,156 @@
+package repo_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domain "github.com/novoseltcev/passkeeper/internal/domains/orgs"
+	secrets "github.com/novoseltcev/passkeeper/internal/domains/secrets"
+	"github.com/novoseltcev/passkeeper/internal/models"
+	"github.com/novoseltcev/passkeeper/internal/repo"
+	"github.com/novoseltcev/passkeeper/pkg/testutils/helpers"
+)
+
+const (
+	orgUUID        = "5c2f9f8e-3d1a-4b7e-9f0c-6a1d2e3f4a5b"
+	invitationUUID = "c1d2e3f4-a5b6-4c7d-8e9f-0a1b2c3d4e5f"
+)
+
+func TestOrgRepository_Create(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(context.Background(), ctxTimeout)
+	t.Cleanup(cancel)
+	repo := repo.NewOrgRepository(helpers.SetupDB(ctx, t, migrationsDir, "base.sql"))
+
+	id, err := repo.Create(ctx, &models.Org{Name: "acme"}, &models.Member{
+		User: &models.User{ID: accountUUID},
+		Role: models.OrgRoleOwner,
+		Key:  []byte{0x01},
+	})
+	require.NoError(t, err)
+	require.NoError(t, uuid.Validate(string(id)))
+
+	member, err := repo.GetMember(ctx, id, accountUUID)
+	require.NoError(t, err)
+	assert.Equal(t, &models.Org{ID: id, Name: "acme"}, member.Org)
+	assert.Equal(t, models.OrgRoleOwner, member.Role)
+	assert.Equal(t, []byte{0x01}, member.Key)
+}
+
+func TestOrgRepository_GetMember(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(context.Background(), ctxTimeout)
+	t.Cleanup(cancel)
+	repo := repo.NewOrgRepository(helpers.SetupDB(ctx, t, migrationsDir, "base.sql", "orgs.sql"))
+
+	t.Run("Success", func(t *testing.T) {
+		t.Parallel()
+
+		member, err := repo.GetMember(ctx, orgUUID, accountUUID)
+		require.NoError(t, err)
+		assert.Equal(t, &models.Member{
+			Org: &models.Org{ID: orgUUID, Name: "acme"},
+			User: &models.User{
+				ID:             accountUUID,
+				Login:          "test@example.com",
+				PasswordHash:   "1234",
+				PassphraseHash: "4567",
+			},
+			Role: models.OrgRoleOwner,
+			Key:  []byte{0xdd},
+		}, member)
+	})
+
+	t.Run("Fails_NotMember", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := repo.GetMember(ctx, orgUUID, recipientUUID)
+		assert.ErrorIs(t, err, domain.ErrNotMember)
+	})
+}
+
+func TestOrgRepository_GetMemberships(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(context.Background(), ctxTimeout)
+	t.Cleanup(cancel)
+	repo := repo.NewOrgRepository(helpers.SetupDB(ctx, t, migrationsDir, "base.sql", "orgs.sql"))
+
+	members, err := repo.GetMemberships(ctx, accountUUID)
+	require.NoError(t, err)
+	require.Len(t, members, 1)
+	assert.Equal(t, &models.Org{ID: orgUUID, Name: "acme"}, members[0].Org)
+
+	members, err = repo.GetMemberships(ctx, recipientUUID)
+	require.NoError(t, err)
+	assert.Empty(t, members)
+}
+
+func TestOrgRepository_Invitations(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(context.Background(), ctxTimeout)
+	t.Cleanup(cancel)
+	repo := repo.NewOrgRepository(helpers.SetupDB(ctx, t, migrationsDir, "base.sql", "orgs.sql"))
+
+	invitations, err := repo.GetInvitations(ctx, recipientUUID)
+	require.NoError(t, err)
+	require.Len(t, invitations, 1)
+	assert.Equal(t, models.Invitation{
+		ID:      invitationUUID,
+		Org:     &models.Org{ID: orgUUID, Name: "acme"},
+		Invitee: &models.User{ID: recipientUUID},
+		Inviter: &models.User{ID: accountUUID, Login: "test@example.com"},
+		Role:    models.OrgRoleViewer,
+		Key:     []byte{0xee},
+	}, invitations[0])
+
+	invitation := invitations[0]
+	invitation.Role = models.OrgRoleEditor
+
+	id, err := repo.SaveInvitation(ctx, &invitation)
+	require.NoError(t, err)
+	assert.Equal(t, models.InvitationID(invitationUUID), id)
+
+	require.NoError(t, repo.AcceptInvitation(ctx, &invitation))
+
+	_, err = repo.GetInvitation(ctx, invitationUUID)
+	require.ErrorIs(t, err, domain.ErrInvitationNotFound)
+
+	member, err := repo.GetMember(ctx, orgUUID, recipientUUID)
+	require.NoError(t, err)
+	assert.Equal(t, models.OrgRoleEditor, member.Role)
+	assert.Equal(t, []byte{0xee}, member.Key)
+
+	members, err := repo.GetMembers(ctx, orgUUID)
+	require.NoError(t, err)
+	assert.Len(t, members, 2)
+
+	require.NoError(t, repo.DeleteMember(ctx, orgUUID, recipientUUID))
+
+	_, err = repo.GetMember(ctx, orgUUID, recipientUUID)
+	assert.ErrorIs(t, err, domain.ErrNotMember)
+}
+
+func TestSecretRepository_GetOrgPage(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(context.Background(), ctxTimeout)
+	t.Cleanup(cancel)
+	repo := repo.NewSecretRepository(helpers.SetupDB(ctx, t, migrationsDir, "base.sql", "orgs.sql"))
+
+	page, err := repo.GetOrgPage(ctx, orgUUID, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), page.Total)
+	require.Len(t, page.Items, 1)
+	assert.Equal(t, models.SecretID(secretUUID2), page.Items[0].ID)
+	assert.Equal(t, &models.Org{ID: orgUUID}, page.Items[0].Org)
+
+	personal, err := repo.GetPage(ctx, accountUUID, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), personal.Total)
+
+	_, err = repo.GetMember(ctx, orgUUID, recipientUUID)
+	assert.ErrorIs(t, err, secrets.ErrMemberNotFound)
+}
@@ -3,7 +3,9 @@ package repo
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 
@@ -11,40 +13,150 @@ import (
 	"github.com/novoseltcev/passkeeper/internal/models"
 )
 
+// conn is the database or the transaction, which the queries run in.
+type conn interface {
+	GetContext(ctx context.Context, dest any, query string, args ...any) error
+	SelectContext(ctx context.Context, dest any, query string, args ...any) error
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
 type secretRepository struct {
 	db *sqlx.DB
+	// tx is the transaction of the repository bound by Atomic, the queries run in it.
+	tx *sqlx.Tx
 }
 
 type secretInDB struct {
-	UUID           string `db:"uuid"`
-	Name           string `db:"name"`
-	Type           int    `db:"type"`
-	EncryptedData  []byte `db:"encrypted_data"`
-	Owner          string `db:"owner_uuid"`
-	PassphraseHash string `db:"passphrase_hash"`
+	UUID           string         `db:"uuid"`
+	Name           string         `db:"name"`
+	Type           int            `db:"type"`
+	EncryptedData  []byte         `db:"encrypted_data"`
+	BlobKey        sql.NullString `db:"blob_key"`
+	Size           int64          `db:"size"`
+	ContentBlobKey sql.NullString `db:"content_blob_key"`
+	ContentSize    int64          `db:"content_size"`
+	Revision       int64          `db:"revision"`
+	Key            []byte         `db:"key"`
+	EscrowKey      []byte         `db:"escrow_key"`
+	Org            sql.NullString `db:"org_uuid"`
+	Fingerprint    sql.NullString `db:"fingerprint"`
+	Preview        []byte         `db:"preview"`
+	Owner          string         `db:"owner_uuid"`
+	PassphraseHash string         `db:"passphrase_hash"`
+	Login          sql.NullString `db:"login"`
+	CreatedAt      time.Time      `db:"created_at"`
+	UpdatedAt      sql.NullTime   `db:"updated_at"`
+	AccessedAt     sql.NullTime   `db:"accessed_at"`
+	ExpiresAt      sql.NullTime   `db:"expires_at"`
+	RotateEvery    sql.NullInt64  `db:"rotate_every"` // in seconds
 }
 
 func (s secretInDB) ToDomain() *models.Secret {
-	return &models.Secret{
-		ID:    models.SecretID(s.UUID),
-		Name:  s.Name,
-		Type:  models.SecretType(s.Type),
-		Data:  s.EncryptedData,
-		Owner: &models.User{ID: models.UserID(s.Owner), PassphraseHash: s.PassphraseHash},
+	secret := &models.Secret{
+		ID:             models.SecretID(s.UUID),
+		Name:           s.Name,
+		Type:           models.SecretType(s.Type),
+		Data:           s.EncryptedData,
+		BlobKey:        s.BlobKey.String,
+		Size:           s.Size,
+		Revision:       s.Revision,
+		ContentBlobKey: s.ContentBlobKey.String,
+		ContentSize:    s.ContentSize,
+		Key:            s.Key,
+		EscrowKey:      s.EscrowKey,
+		Fingerprint:    s.Fingerprint.String,
+		Owner: &models.User{
+			ID:             models.UserID(s.Owner),
+			Login:          s.Login.String,
+			PassphraseHash: s.PassphraseHash,
+		},
+		CreatedAt:   s.CreatedAt,
+		UpdatedAt:   s.UpdatedAt.Time,
+		AccessedAt:  s.AccessedAt.Time,
+		ExpiresAt:   s.ExpiresAt.Time,
+		RotateEvery: time.Duration(s.RotateEvery.Int64) * time.Second,
+	}
+
+	if s.Org.Valid {
+		secret.Org = &models.Org{ID: models.OrgID(s.Org.String)}
+	}
+
+	// the preview is written by the repository, a broken one is not displayed
+	if len(s.Preview) > 0 {
+		_ = json.Unmarshal(s.Preview, &secret.Preview)
 	}
+
+	return secret
 }
 
+// secretAttrs are the selected columns of the data keys, the preview, blob, size and revision, the organization,
+// the timestamps and the unencrypted secret attributes.
+const secretAttrs = `
+	secrets.key,
+	secrets.escrow_key,
+	secrets.preview,
+	secrets.blob_key,
+	secrets.size,
+	secrets.content_blob_key,
+	secrets.content_size,
+	secrets.revision,
+	secrets.org_uuid,
+	secrets.created_at,
+	COALESCE(secrets.updated_at, secrets.created_at) AS updated_at,
+	secrets.accessed_at,
+	expires_at,
+	EXTRACT(EPOCH FROM rotate_every)::BIGINT AS rotate_every`
+
+// secretDue is the moment a secret becomes due, it is NULL for secrets without expiration and rotation.
+const secretDue = `LEAST(expires_at, COALESCE(secrets.updated_at, secrets.created_at) + rotate_every)`
+
 var _ domain.Repository = (*secretRepository)(nil)
 
 func NewSecretRepository(db *sqlx.DB) *secretRepository { // nolint: revive
 	return &secretRepository{db: db}
 }
 
+func (r *secretRepository) conn() conn {
+	if r.tx != nil {
+		return r.tx
+	}
+
+	return r.db
+}
+
+// Atomic runs fn with the repository bound to a transaction, which is committed if fn succeeds.
+//
+// The bound repository runs Atomic in the same transaction.
+func (r *secretRepository) Atomic(ctx context.Context, fn func(repo domain.Repository) error) error {
+	return r.atomic(ctx, func(tx *sqlx.Tx) error {
+		return fn(&secretRepository{db: r.db, tx: tx})
+	})
+}
+
+// atomic runs fn in the transaction of the repository or in a new one.
+func (r *secretRepository) atomic(ctx context.Context, fn func(tx *sqlx.Tx) error) error {
+	if r.tx != nil {
+		return fn(r.tx)
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() // nolint: errcheck
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
 func (r *secretRepository) GetOwner(ctx context.Context, ownerID models.UserID) (*models.User, error) {
 	var owner userInDB
 
-	err := r.db.GetContext(ctx, &owner, `
-		SELECT uuid, login, password_hash, passphrase_hash
+	err := r.conn().GetContext(ctx, &owner, `
+		SELECT uuid, login, password_hash, passphrase_hash, public_key, private_key, escrow_key
 		FROM accounts
 			WHERE uuid = $1
 	`, ownerID)
@@ -52,19 +164,14 @@ func (r *secretRepository) GetOwner(ctx context.Context, ownerID models.UserID)
 		return nil, err
 	}
 
-	return &models.User{
-		ID:             models.UserID(owner.ID),
-		Login:          owner.Login,
-		PasswordHash:   owner.PasswordHash,
-		PassphraseHash: owner.PassphraseHash,
-	}, nil
+	return owner.ToDomain(), nil
 }
 
 func (r *secretRepository) Get(ctx context.Context, id models.SecretID) (*models.Secret, error) {
 	var secret secretInDB
 
-	err := r.db.GetContext(ctx, &secret, `
-		SELECT secrets.uuid, owner_uuid, name, type, encrypted_data, passphrase_hash
+	err := r.conn().GetContext(ctx, &secret, `
+		SELECT secrets.uuid, owner_uuid, name, type, encrypted_data, fingerprint, passphrase_hash,`+secretAttrs+`
 		FROM secrets
 			JOIN accounts ON secrets.owner_uuid = accounts.uuid
 				WHERE secrets.uuid = $1
@@ -87,10 +194,10 @@ func (r *secretRepository) GetPage(
 ) (*domain.Page[models.Secret], error) {
 	var secrets []secretInDB
 
-	err := r.db.SelectContext(ctx, &secrets, `
-		SELECT uuid, owner_uuid, name, type, encrypted_data
-		FROM secrets 
-			WHERE owner_uuid = $1
+	err := r.conn().SelectContext(ctx, &secrets, `
+		SELECT uuid, owner_uuid, name, type, encrypted_data, fingerprint,`+secretAttrs+`
+		FROM secrets
+			WHERE owner_uuid = $1 AND org_uuid IS NULL
 				ORDER BY created_at DESC
 					OFFSET $2 LIMIT $3
 	`, ownerID, offset, limit)
@@ -99,26 +206,172 @@ func (r *secretRepository) GetPage(
 	}
 
 	var total uint64
-	if err = r.db.GetContext(ctx, &total, "SELECT COUNT(uuid) FROM secrets WHERE owner_uuid = $1", ownerID); err != nil {
+
+	err = r.conn().GetContext(ctx, &total, `
+		SELECT COUNT(uuid) FROM secrets WHERE owner_uuid = $1 AND org_uuid IS NULL
+	`, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.NewPage(toDomainSecrets(secrets), total), nil
+}
+
+func (r *secretRepository) GetOrgPage(
+	ctx context.Context,
+	orgID models.OrgID,
+	limit, offset uint64,
+) (*domain.Page[models.Secret], error) {
+	var secrets []secretInDB
+
+	err := r.conn().SelectContext(ctx, &secrets, `
+		SELECT uuid, owner_uuid, name, type, encrypted_data, fingerprint,`+secretAttrs+`
+		FROM secrets
+			WHERE org_uuid = $1
+				ORDER BY created_at DESC
+					OFFSET $2 LIMIT $3
+	`, orgID, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	var total uint64
+	if err = r.conn().GetContext(ctx, &total, "SELECT COUNT(uuid) FROM secrets WHERE org_uuid = $1", orgID); err != nil {
 		return nil, err
 	}
 
+	return domain.NewPage(toDomainSecrets(secrets), total), nil
+}
+
+func (r *secretRepository) GetMember(
+	ctx context.Context,
+	orgID models.OrgID,
+	userID models.UserID,
+) (*models.Member, error) {
+	member, err := getMember(ctx, r.conn(), orgID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrMemberNotFound
+		}
+
+		return nil, err
+	}
+
+	return member, nil
+}
+
+func (r *secretRepository) GetAll(ctx context.Context, ownerID models.UserID) ([]models.Secret, error) {
+	var secrets []secretInDB
+
+	err := r.conn().SelectContext(ctx, &secrets, `
+		SELECT uuid, owner_uuid, name, type, encrypted_data, fingerprint,`+secretAttrs+`
+		FROM secrets
+			WHERE owner_uuid = $1 AND org_uuid IS NULL
+				ORDER BY created_at DESC
+	`, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toDomainSecrets(secrets), nil
+}
+
+func (r *secretRepository) GetByURITokens(
+	ctx context.Context,
+	ownerID models.UserID,
+	tokens []string,
+) ([]models.Secret, error) {
+	var secrets []secretInDB
+
+	err := r.conn().SelectContext(ctx, &secrets, `
+		SELECT uuid, owner_uuid, name, type, encrypted_data, fingerprint,`+secretAttrs+`
+		FROM secrets
+			WHERE owner_uuid = $1 AND org_uuid IS NULL AND uri_tokens && $2::TEXT[]
+				ORDER BY name
+	`, ownerID, tokens)
+	if err != nil {
+		return nil, err
+	}
+
+	return toDomainSecrets(secrets), nil
+}
+
+func (r *secretRepository) GetDue(ctx context.Context, ownerID models.UserID) ([]models.Secret, error) {
+	var secrets []secretInDB
+
+	err := r.conn().SelectContext(ctx, &secrets, `
+		SELECT uuid, owner_uuid, name, type, encrypted_data, fingerprint,`+secretAttrs+`
+		FROM secrets
+			WHERE owner_uuid = $1 AND `+secretDue+` <= NOW()
+				ORDER BY `+secretDue+`
+	`, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toDomainSecrets(secrets), nil
+}
+
+func (r *secretRepository) GetDueToNotify(ctx context.Context) ([]models.Secret, error) {
+	var secrets []secretInDB
+
+	err := r.conn().SelectContext(ctx, &secrets, `
+		SELECT secrets.uuid, owner_uuid, name, type, encrypted_data, fingerprint, login,`+secretAttrs+`
+		FROM secrets
+			JOIN accounts ON secrets.owner_uuid = accounts.uuid
+				WHERE `+secretDue+` <= NOW() AND (notified_at IS NULL OR notified_at < `+secretDue+`)
+					ORDER BY `+secretDue+`
+	`)
+	if err != nil {
+		return nil, err
+	}
+
+	return toDomainSecrets(secrets), nil
+}
+
+func (r *secretRepository) MarkNotified(ctx context.Context, id models.SecretID) error {
+	_, err := r.conn().ExecContext(ctx, `UPDATE secrets SET notified_at = NOW() WHERE uuid = $1`, id)
+
+	return err
+}
+
+func (r *secretRepository) Touch(ctx context.Context, id models.SecretID) error {
+	_, err := r.conn().ExecContext(ctx, `UPDATE secrets SET accessed_at = NOW() WHERE uuid = $1`, id)
+
+	return err
+}
+
+func toDomainSecrets(secrets []secretInDB) []models.Secret {
 	items := make([]models.Secret, len(secrets))
 	for i, secret := range secrets {
 		items[i] = *secret.ToDomain()
 	}
 
-	return domain.NewPage(items, total), nil
+	return items
 }
 
 func (r *secretRepository) Create(ctx context.Context, data *models.Secret) (models.SecretID, error) {
+	preview, err := previewJSON(data.Preview)
+	if err != nil {
+		return "", err
+	}
+
 	var id string
 
-	err := r.db.GetContext(ctx, &id, `
-		INSERT INTO secrets (name, type, encrypted_data, owner_uuid, created_at)
-		VALUES ($1, $2, $3, $4, NOW())
+	err = r.conn().GetContext(ctx, &id, `
+		INSERT INTO secrets (
+			name, type, encrypted_data, fingerprint, owner_uuid, org_uuid, created_at, expires_at, rotate_every,
+			blob_key, size, uri_tokens, preview, key, escrow_key, content_blob_key, content_size
+		)
+		VALUES (
+			$1, $2, $3, NULLIF($4, ''), $5, NULLIF($6, '')::UUID, COALESCE($13, NOW()), $7,
+			make_interval(secs => NULLIF($8::BIGINT, 0)), NULLIF($9, ''), $10, COALESCE($11::TEXT[], '{}'), $12,
+			$14, $15, NULLIF($16, ''), $17
+		)
 		RETURNING uuid
-	`, data.Name, data.Type, data.Data, data.Owner.ID)
+	`, data.Name, data.Type, data.Data, data.Fingerprint, data.Owner.ID, orgID(data.Org),
+		nullTime(data.ExpiresAt), int64(data.RotateEvery.Seconds()), data.BlobKey, data.Size, data.URITokens, preview,
+		nullTime(data.CreatedAt), data.Key, data.EscrowKey, data.ContentBlobKey, data.ContentSize)
 	if err != nil {
 		return "", err
 	}
@@ -127,17 +380,113 @@ func (r *secretRepository) Create(ctx context.Context, data *models.Secret) (mod
 }
 
 func (r *secretRepository) Update(ctx context.Context, id models.SecretID, data *models.Secret) error {
-	_, err := r.db.ExecContext(ctx, `
+	preview, err := previewJSON(data.Preview)
+	if err != nil {
+		return err
+	}
+
+	err = r.conn().GetContext(ctx, &data.Revision, `
 		UPDATE secrets
-		SET name = $2, encrypted_data = $3, updated_at = NOW()
-		WHERE uuid = $1
-	`, id, data.Name, data.Data)
+		SET name = $2, encrypted_data = $3, fingerprint = NULLIF($4, ''), updated_at = NOW(),
+			expires_at = $5, rotate_every = make_interval(secs => NULLIF($6::BIGINT, 0)), blob_key = NULLIF($7, ''),
+			size = $8, uri_tokens = COALESCE($10::TEXT[], '{}'), preview = $11, revision = revision + 1,
+			content_blob_key = NULLIF($12, ''), content_size = $13
+		WHERE uuid = $1 AND revision = $9
+		RETURNING revision
+	`, id, data.Name, data.Data, data.Fingerprint, nullTime(data.ExpiresAt), int64(data.RotateEvery.Seconds()),
+		data.BlobKey, data.Size, data.Revision, data.URITokens, preview, data.ContentBlobKey, data.ContentSize)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ErrRevisionMismatch
+	}
+
+	return err
+}
+
+func (r *secretRepository) SetOwnerEscrowKey(ctx context.Context, ownerID models.UserID, key []byte) error {
+	_, err := r.conn().ExecContext(ctx, `UPDATE accounts SET escrow_key = $2 WHERE uuid = $1`, ownerID, key)
+
+	return err
+}
+
+func (r *secretRepository) SetEscrowKey(ctx context.Context, id models.SecretID, key []byte) error {
+	_, err := r.conn().ExecContext(ctx, `UPDATE secrets SET escrow_key = $2 WHERE uuid = $1`, id, key)
 
 	return err
 }
 
 func (r *secretRepository) Delete(ctx context.Context, id models.SecretID) error {
-	_, err := r.db.ExecContext(ctx, `DELETE FROM secrets WHERE uuid = $1`, id)
+	_, err := r.conn().ExecContext(ctx, `DELETE FROM secrets WHERE uuid = $1`, id)
+
+	return err
+}
+
+func (r *secretRepository) LockOwner(ctx context.Context, ownerID models.UserID) error {
+	_, err := r.conn().ExecContext(ctx, `SELECT 1 FROM accounts WHERE uuid = $1 FOR UPDATE`, ownerID)
 
 	return err
 }
+
+func (r *secretRepository) GetUsage(ctx context.Context, ownerID models.UserID) (*domain.Usage, error) {
+	var usage struct {
+		Secrets int   `db:"secrets"`
+		Bytes   int64 `db:"bytes"`
+	}
+
+	err := r.conn().GetContext(ctx, &usage, `
+		SELECT COUNT(uuid) AS secrets, COALESCE(SUM(size), 0) AS bytes FROM secrets WHERE owner_uuid = $1
+	`, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.Usage{Secrets: usage.Secrets, Bytes: usage.Bytes}, nil
+}
+
+func (r *secretRepository) GetUsedBlobs(ctx context.Context, keys []string) ([]string, error) {
+	var used []string
+
+	err := r.conn().SelectContext(ctx, &used, `
+		SELECT blob_key FROM secrets WHERE blob_key = ANY($1)
+		UNION
+		SELECT content_blob_key FROM secrets WHERE content_blob_key = ANY($1)
+	`, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	return used, nil
+}
+
+func (r *secretRepository) GetTemplate(ctx context.Context, id models.TemplateID) (*models.Template, error) {
+	template, err := getTemplate(ctx, r.conn(), id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrTemplateNotFound
+		}
+
+		return nil, err
+	}
+
+	return template, nil
+}
+
+func orgID(org *models.Org) models.OrgID {
+	if org == nil {
+		return ""
+	}
+
+	return org.ID
+}
+
+// previewJSON returns the JSON of the preview, it is nil for the empty one.
+func previewJSON(preview map[string]string) ([]byte, error) {
+	if len(preview) == 0 {
+		return nil, nil // nolint: nilnil
+	}
+
+	return json.Marshal(preview)
+}
+
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
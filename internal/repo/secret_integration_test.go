@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -73,6 +74,11 @@ func TestSecretRepository_Get(t *testing.T) {
 
 		secret, err := repo.Get(ctx, models.SecretID(secretUUID1))
 		require.NoError(t, err)
+		assert.False(t, secret.CreatedAt.IsZero())
+		assert.False(t, secret.UpdatedAt.IsZero())
+		assert.True(t, secret.AccessedAt.IsZero())
+
+		secret.CreatedAt, secret.UpdatedAt = time.Time{}, time.Time{}
 		assert.Equal(t, &models.Secret{
 			ID:   models.SecretID(secretUUID1),
 			Name: "some",
@@ -115,6 +121,13 @@ func TestSecretRepository_GetPage(t *testing.T) {
 		page, err := repo.GetPage(ctx, models.UserID(accountUUID), 2, 0)
 		require.NoError(t, err)
 		assert.Equal(t, uint64(3), page.Total)
+
+		for i := range page.Items {
+			assert.False(t, page.Items[i].UpdatedAt.IsZero())
+
+			page.Items[i].CreatedAt, page.Items[i].UpdatedAt = time.Time{}, time.Time{}
+		}
+
 		assert.Equal(t, []models.Secret{
 			{
 				ID:    models.SecretID(secretUUID1),
@@ -154,6 +167,58 @@ func TestSecretRepository_GetPage(t *testing.T) {
 	})
 }
 
+func TestSecretRepository_GetAll(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(context.Background(), ctxTimeout)
+	t.Cleanup(cancel)
+	repo := repo.NewSecretRepository(helpers.SetupDB(ctx, t, migrationsDir, "base.sql"))
+
+	secrets, err := repo.GetAll(ctx, models.UserID(accountUUID))
+	require.NoError(t, err)
+	require.Len(t, secrets, 3)
+
+	for _, secret := range secrets {
+		assert.Equal(t, models.UserID(accountUUID), secret.Owner.ID)
+		assert.False(t, secret.UpdatedAt.IsZero())
+	}
+}
+
+func TestSecretRepository_GetDue(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(context.Background(), ctxTimeout)
+	t.Cleanup(cancel)
+	repo := repo.NewSecretRepository(helpers.SetupDB(ctx, t, migrationsDir, "base.sql", "due.sql"))
+
+	secrets, err := repo.GetDue(ctx, models.UserID(accountUUID))
+	require.NoError(t, err)
+	require.Len(t, secrets, 2)
+	assert.Equal(t, "notified", secrets[0].Name)
+	assert.Equal(t, "expired", secrets[1].Name)
+	assert.False(t, secrets[1].ExpiresAt.IsZero())
+}
+
+func TestSecretRepository_GetDueToNotify(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(context.Background(), ctxTimeout)
+	t.Cleanup(cancel)
+	repo := repo.NewSecretRepository(helpers.SetupDB(ctx, t, migrationsDir, "base.sql", "due.sql"))
+
+	secrets, err := repo.GetDueToNotify(ctx)
+	require.NoError(t, err)
+	require.Len(t, secrets, 2)
+	assert.Equal(t, "rotation", secrets[0].Name)
+	assert.Equal(t, "test@test.com", secrets[0].Owner.Login)
+	assert.Equal(t, 30*24*time.Hour, secrets[0].RotateEvery)
+	assert.Equal(t, "expired", secrets[1].Name)
+
+	require.NoError(t, repo.MarkNotified(ctx, secrets[0].ID))
+
+	secrets, err = repo.GetDueToNotify(ctx)
+	require.NoError(t, err)
+	require.Len(t, secrets, 1)
+	assert.Equal(t, "expired", secrets[0].Name)
+}
+
 func TestSecretRepository_Create(t *testing.T) {
 	t.Parallel()
 	ctx, cancel := context.WithTimeout(context.Background(), ctxTimeout)
@@ -163,14 +228,23 @@ func TestSecretRepository_Create(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		t.Parallel()
 
+		expiresAt := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
 		id, err := repo.Create(ctx, &models.Secret{
-			Name:  "some",
-			Type:  models.SecretTypePwd,
-			Data:  []byte("some-data"),
-			Owner: &models.User{ID: models.UserID(accountUUID)},
+			Name:        "some",
+			Type:        models.SecretTypePwd,
+			Data:        []byte("some-data"),
+			Owner:       &models.User{ID: models.UserID(accountUUID)},
+			ExpiresAt:   expiresAt,
+			RotateEvery: time.Hour,
 		})
 		require.NoError(t, err)
-		assert.NoError(t, uuid.Validate(string(id)))
+		require.NoError(t, uuid.Validate(string(id)))
+
+		secret, err := repo.Get(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, expiresAt, secret.ExpiresAt)
+		assert.Equal(t, time.Hour, secret.RotateEvery)
 	})
 
 	t.Run("Fails_FKConstraint", func(t *testing.T) {
@@ -204,23 +278,35 @@ func TestSecretRepository_Update(t *testing.T) {
 		require.NotEqual(t, "brand new updated", before.Name)
 		require.NotEqual(t, []byte("new-data"), before.Data)
 
-		require.NoError(t, repo.Update(ctx, models.SecretID(secretUUID1), &models.Secret{
-			Name: "brand new updated",
-			Data: []byte("new-data"),
-		}))
+		updated := &models.Secret{
+			Name:        "brand new updated",
+			Data:        []byte("new-data"),
+			Fingerprint: "SHA256:fingerprint",
+			Revision:    before.Revision,
+		}
+		require.NoError(t, repo.Update(ctx, models.SecretID(secretUUID1), updated))
+		assert.Equal(t, before.Revision+1, updated.Revision)
+
+		require.ErrorIs(t, repo.Update(ctx, models.SecretID(secretUUID1), &models.Secret{
+			Name:     "stale update",
+			Data:     []byte("stale-data"),
+			Revision: before.Revision,
+		}), domain.ErrRevisionMismatch)
 
 		after, err := repo.Get(ctx, models.SecretID(secretUUID1))
 		require.NoError(t, err)
+		assert.Equal(t, updated.Revision, after.Revision)
 		assert.Equal(t, "brand new updated", after.Name)
 		assert.Equal(t, []byte("new-data"), []byte(after.Data))
+		assert.Equal(t, "SHA256:fingerprint", after.Fingerprint)
 	})
 
-	t.Run("Success_NotFound", func(t *testing.T) {
+	t.Run("Fails_NotFound", func(t *testing.T) {
 		t.Parallel()
 		_, err := repo.Get(ctx, models.SecretID(uuid.NewString()))
 		require.ErrorIs(t, err, domain.ErrSecretNotFound)
 
-		assert.NoError(t, repo.Update(ctx, models.SecretID(uuid.NewString()), &models.Secret{}))
+		assert.ErrorIs(t, repo.Update(ctx, models.SecretID(uuid.NewString()), &models.Secret{}), domain.ErrRevisionMismatch)
 	})
 
 	t.Run("Fails_UUIDSyntaxError", func(t *testing.T) {
@@ -267,3 +353,244 @@ func TestSecretRepository_Delete(t *testing.T) {
 		assert.Equal(t, "22P02", pgErr.Code)
 	})
 }
+
+func TestSecretRepository_Blobs(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(context.Background(), ctxTimeout)
+	t.Cleanup(cancel)
+	repo := repo.NewSecretRepository(helpers.SetupDB(ctx, t, migrationsDir, "base.sql"))
+
+	id, err := repo.Create(ctx, &models.Secret{
+		Name:    "file",
+		Type:    models.SecretTypeFile,
+		Data:    []byte{},
+		BlobKey: "first",
+		Owner:   &models.User{ID: models.UserID(accountUUID)},
+	})
+	require.NoError(t, err)
+
+	secret, err := repo.Get(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, "first", secret.BlobKey)
+	assert.Empty(t, secret.Data)
+
+	used, err := repo.GetUsedBlobs(ctx, []string{"first", "second"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"first"}, used)
+
+	require.NoError(t, repo.Update(ctx, id, &models.Secret{Name: "file", Data: []byte{}, BlobKey: "second", Revision: 1}))
+
+	used, err = repo.GetUsedBlobs(ctx, []string{"first", "second"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"second"}, used)
+
+	require.NoError(t, repo.Update(ctx, id, &models.Secret{Name: "file", Data: []byte("in-row"), Revision: 2}))
+
+	secret, err = repo.Get(ctx, id)
+	require.NoError(t, err)
+	assert.Empty(t, secret.BlobKey)
+
+	used, err = repo.GetUsedBlobs(ctx, []string{"first", "second"})
+	require.NoError(t, err)
+	assert.Empty(t, used)
+}
+
+func TestSecretRepository_ContentBlob(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(context.Background(), ctxTimeout)
+	t.Cleanup(cancel)
+	repo := repo.NewSecretRepository(helpers.SetupDB(ctx, t, migrationsDir, "base.sql"))
+
+	id, err := repo.Create(ctx, &models.Secret{
+		Name:           "streamed",
+		Type:           models.SecretTypeFile,
+		Data:           []byte("data"),
+		ContentBlobKey: "content",
+		ContentSize:    100,
+		Size:           104,
+		Owner:          &models.User{ID: models.UserID(accountUUID)},
+	})
+	require.NoError(t, err)
+
+	secret, err := repo.Get(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, "content", secret.ContentBlobKey)
+	assert.Equal(t, int64(100), secret.ContentSize)
+
+	used, err := repo.GetUsedBlobs(ctx, []string{"content", "other"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"content"}, used)
+
+	require.NoError(t, repo.Update(ctx, id, &models.Secret{Name: "streamed", Data: []byte("data"), Revision: 1}))
+
+	used, err = repo.GetUsedBlobs(ctx, []string{"content", "other"})
+	require.NoError(t, err)
+	assert.Empty(t, used)
+}
+
+func TestSecretRepository_GetUsage(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(context.Background(), ctxTimeout)
+	t.Cleanup(cancel)
+	repo := repo.NewSecretRepository(helpers.SetupDB(ctx, t, migrationsDir, "base.sql"))
+
+	before, err := repo.GetUsage(ctx, models.UserID(accountUUID))
+	require.NoError(t, err)
+
+	id, err := repo.Create(ctx, &models.Secret{
+		Name:  "sized",
+		Type:  models.SecretTypeTxt,
+		Data:  []byte("data"),
+		Size:  4,
+		Owner: &models.User{ID: models.UserID(accountUUID)},
+	})
+	require.NoError(t, err)
+
+	usage, err := repo.GetUsage(ctx, models.UserID(accountUUID))
+	require.NoError(t, err)
+	assert.Equal(t, before.Secrets+1, usage.Secrets)
+	assert.Equal(t, before.Bytes+4, usage.Bytes)
+
+	require.NoError(t, repo.Update(ctx, id, &models.Secret{
+		Name:     "sized",
+		Data:     []byte("longer data"),
+		Size:     11,
+		Revision: 1,
+	}))
+
+	usage, err = repo.GetUsage(ctx, models.UserID(accountUUID))
+	require.NoError(t, err)
+	assert.Equal(t, before.Bytes+11, usage.Bytes)
+
+	secret, err := repo.Get(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, int64(11), secret.Size)
+}
+
+func TestSecretRepository_GetByURITokens(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(context.Background(), ctxTimeout)
+	t.Cleanup(cancel)
+	repo := repo.NewSecretRepository(helpers.SetupDB(ctx, t, migrationsDir, "base.sql"))
+
+	id, err := repo.Create(ctx, &models.Secret{
+		Name:      "indexed",
+		Type:      models.SecretTypePwd,
+		Data:      []byte("data"),
+		URITokens: []string{"first", "second"},
+		Owner:     &models.User{ID: models.UserID(accountUUID)},
+	})
+	require.NoError(t, err)
+
+	found, err := repo.GetByURITokens(ctx, models.UserID(accountUUID), []string{"other", "second"})
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, id, found[0].ID)
+
+	require.NoError(t, repo.Update(ctx, id, &models.Secret{Name: "indexed", Data: []byte("data"), Revision: 1}))
+
+	found, err = repo.GetByURITokens(ctx, models.UserID(accountUUID), []string{"first", "second"})
+	require.NoError(t, err)
+	assert.Empty(t, found)
+}
+
+func TestSecretRepository_Preview(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(context.Background(), ctxTimeout)
+	t.Cleanup(cancel)
+	repo := repo.NewSecretRepository(helpers.SetupDB(ctx, t, migrationsDir, "base.sql"))
+
+	preview := map[string]string{domain.PreviewBrand: "Visa", domain.PreviewLast4: "4242"}
+
+	id, err := repo.Create(ctx, &models.Secret{
+		Name:    "card",
+		Type:    models.SecretTypeCard,
+		Data:    []byte("data"),
+		Preview: preview,
+		Owner:   &models.User{ID: models.UserID(accountUUID)},
+	})
+	require.NoError(t, err)
+
+	secret, err := repo.Get(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, preview, secret.Preview)
+
+	require.NoError(t, repo.Update(ctx, id, &models.Secret{Name: "card", Data: []byte("data"), Revision: 1}))
+
+	secret, err = repo.Get(ctx, id)
+	require.NoError(t, err)
+	assert.Nil(t, secret.Preview)
+}
+
+func TestSecretRepository_Touch(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(context.Background(), ctxTimeout)
+	t.Cleanup(cancel)
+	repo := repo.NewSecretRepository(helpers.SetupDB(ctx, t, migrationsDir, "base.sql"))
+
+	require.NoError(t, repo.Touch(ctx, models.SecretID(secretUUID1)))
+
+	secret, err := repo.Get(ctx, models.SecretID(secretUUID1))
+	require.NoError(t, err)
+	assert.False(t, secret.AccessedAt.IsZero())
+}
+
+func TestSecretRepository_EscrowKeys(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(context.Background(), ctxTimeout)
+	t.Cleanup(cancel)
+	repo := repo.NewSecretRepository(helpers.SetupDB(ctx, t, migrationsDir, "base.sql"))
+
+	require.NoError(t, repo.SetOwnerEscrowKey(ctx, accountUUID, []byte{0x01}))
+	require.NoError(t, repo.SetEscrowKey(ctx, models.SecretID(secretUUID1), []byte{0x02}))
+
+	owner, err := repo.GetOwner(ctx, accountUUID)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x01}, owner.EscrowKey)
+
+	secret, err := repo.Get(ctx, models.SecretID(secretUUID1))
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x02}, secret.EscrowKey)
+}
+
+func TestSecretRepository_Atomic(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(context.Background(), ctxTimeout)
+	t.Cleanup(cancel)
+	secrets := repo.NewSecretRepository(helpers.SetupDB(ctx, t, migrationsDir, "base.sql"))
+
+	newSecret := func(name string) *models.Secret {
+		return &models.Secret{
+			Name:  name,
+			Type:  models.SecretTypeTxt,
+			Data:  []byte("data"),
+			Owner: &models.User{ID: models.UserID(accountUUID)},
+		}
+	}
+
+	var rolledBack models.SecretID
+
+	err := secrets.Atomic(ctx, func(tx domain.Repository) (err error) {
+		rolledBack, err = tx.Create(ctx, newSecret("rolled back"))
+		require.NoError(t, err)
+
+		return testutils.Err
+	})
+	require.ErrorIs(t, err, testutils.Err)
+
+	_, err = secrets.Get(ctx, rolledBack)
+	require.ErrorIs(t, err, domain.ErrSecretNotFound)
+
+	var committed models.SecretID
+
+	err = secrets.Atomic(ctx, func(tx domain.Repository) (err error) {
+		committed, err = tx.Create(ctx, newSecret("committed"))
+
+		return err
+	})
+	require.NoError(t, err)
+
+	secret, err := secrets.Get(ctx, committed)
+	require.NoError(t, err)
+	assert.Equal(t, "committed", secret.Name)
+}
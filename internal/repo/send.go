@@ -0,0 +1,129 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/jmoiron/sqlx"
+
+	domain "github.com/novoseltcev/passkeeper/internal/domains/sends"
+	"github.com/novoseltcev/passkeeper/internal/models"
+)
+
+type sendRepository struct {
+	db *sqlx.DB
+}
+
+type sendInDB struct {
+	UUID          string         `db:"uuid"`
+	Owner         string         `db:"owner_uuid"`
+	EncryptedData []byte         `db:"encrypted_data"`
+	PasswordHash  sql.NullString `db:"password_hash"`
+	ViewsLeft     int            `db:"views_left"`
+	ExpiresAt     sql.NullTime   `db:"expires_at"`
+}
+
+func (s sendInDB) ToDomain() *models.Send {
+	return &models.Send{
+		ID:           models.SendID(s.UUID),
+		Owner:        &models.User{ID: models.UserID(s.Owner)},
+		Data:         s.EncryptedData,
+		PasswordHash: s.PasswordHash.String,
+		ViewsLeft:    s.ViewsLeft,
+		ExpiresAt:    s.ExpiresAt.Time,
+	}
+}
+
+var _ domain.Repository = (*sendRepository)(nil)
+
+func NewSendRepository(db *sqlx.DB) *sendRepository { // nolint: revive
+	return &sendRepository{db: db}
+}
+
+func (r *sendRepository) Create(ctx context.Context, send *models.Send) (models.SendID, error) {
+	var id string
+
+	err := r.db.GetContext(ctx, &id, `
+		INSERT INTO sends (owner_uuid, encrypted_data, password_hash, views_left, expires_at)
+		VALUES ($1, $2, NULLIF($3, ''), $4, $5)
+		RETURNING uuid
+	`, send.Owner.ID, send.Data, send.PasswordHash, send.ViewsLeft, send.ExpiresAt)
+	if err != nil {
+		return "", err
+	}
+
+	return models.SendID(id), nil
+}
+
+func (r *sendRepository) Get(ctx context.Context, id models.SendID) (*models.Send, error) {
+	var send sendInDB
+
+	err := r.db.GetContext(ctx, &send, `
+		SELECT uuid, owner_uuid, encrypted_data, password_hash, views_left, expires_at
+		FROM sends
+			WHERE uuid = $1 AND views_left > 0 AND expires_at > NOW()
+	`, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrSendNotFound
+		}
+
+		return nil, err
+	}
+
+	return send.ToDomain(), nil
+}
+
+func (r *sendRepository) Consume(ctx context.Context, id models.SendID) (*models.Send, error) {
+	var send sendInDB
+
+	err := r.db.GetContext(ctx, &send, `
+		UPDATE sends SET views_left = views_left - 1
+			WHERE uuid = $1 AND views_left > 0 AND expires_at > NOW()
+		RETURNING uuid, owner_uuid, encrypted_data, password_hash, views_left, expires_at
+	`, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrSendNotFound
+		}
+
+		return nil, err
+	}
+
+	return send.ToDomain(), nil
+}
+
+func (r *sendRepository) Fail(ctx context.Context, id models.SendID) (int, error) {
+	var attempts int
+
+	err := r.db.GetContext(ctx, &attempts, `
+		UPDATE sends SET failed_attempts = failed_attempts + 1
+			WHERE uuid = $1
+		RETURNING failed_attempts
+	`, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, domain.ErrSendNotFound
+		}
+
+		return 0, err
+	}
+
+	return attempts, nil
+}
+
+func (r *sendRepository) Delete(ctx context.Context, id models.SendID) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM sends WHERE uuid = $1`, id)
+
+	return err
+}
+
+func (r *sendRepository) DeleteExpired(ctx context.Context) (int64, error) {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM sends WHERE expires_at <= NOW()`)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
@@ -0,0 +1,118 @@
+package repo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domain "github.com/novoseltcev/passkeeper/internal/domains/sends"
+	"github.com/novoseltcev/passkeeper/internal/models"
+	"github.com/novoseltcev/passkeeper/internal/repo"
+	"github.com/novoseltcev/passkeeper/pkg/testutils/helpers"
+)
+
+const (
+	sendUUID        = "0f5a3c2e-8d4b-4e6f-9a1b-2c3d4e5f6a7b"
+	expiredSendUUID = "1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d"
+)
+
+func TestSendRepository_Create(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(context.Background(), ctxTimeout)
+	t.Cleanup(cancel)
+	repo := repo.NewSendRepository(helpers.SetupDB(ctx, t, migrationsDir, "base.sql"))
+
+	id, err := repo.Create(ctx, &models.Send{
+		Owner:     &models.User{ID: accountUUID},
+		Data:      []byte{0x01},
+		ViewsLeft: 3,
+		ExpiresAt: time.Now().UTC().Add(time.Hour),
+	})
+	require.NoError(t, err)
+	require.NoError(t, uuid.Validate(string(id)))
+
+	send, err := repo.Get(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x01}, send.Data)
+	assert.Equal(t, 3, send.ViewsLeft)
+	assert.Empty(t, send.PasswordHash)
+}
+
+func TestSendRepository_Get(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(context.Background(), ctxTimeout)
+	t.Cleanup(cancel)
+	repo := repo.NewSendRepository(helpers.SetupDB(ctx, t, migrationsDir, "base.sql", "sends.sql"))
+
+	t.Run("Success", func(t *testing.T) {
+		t.Parallel()
+
+		send, err := repo.Get(ctx, sendUUID)
+		require.NoError(t, err)
+		assert.Equal(t, "hash", send.PasswordHash)
+		assert.Equal(t, 2, send.ViewsLeft)
+	})
+
+	t.Run("Fails_Expired", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := repo.Get(ctx, expiredSendUUID)
+		assert.ErrorIs(t, err, domain.ErrSendNotFound)
+	})
+}
+
+func TestSendRepository_Consume(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(context.Background(), ctxTimeout)
+	t.Cleanup(cancel)
+	repo := repo.NewSendRepository(helpers.SetupDB(ctx, t, migrationsDir, "base.sql", "sends.sql"))
+
+	for _, left := range []int{1, 0} {
+		send, err := repo.Consume(ctx, sendUUID)
+		require.NoError(t, err)
+		assert.Equal(t, left, send.ViewsLeft)
+		assert.Equal(t, []byte{0xab, 0xcd}, send.Data)
+	}
+
+	_, err := repo.Consume(ctx, sendUUID)
+	require.ErrorIs(t, err, domain.ErrSendNotFound)
+
+	_, err = repo.Consume(ctx, expiredSendUUID)
+	assert.ErrorIs(t, err, domain.ErrSendNotFound)
+}
+
+func TestSendRepository_Fail(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(context.Background(), ctxTimeout)
+	t.Cleanup(cancel)
+	repo := repo.NewSendRepository(helpers.SetupDB(ctx, t, migrationsDir, "base.sql", "sends.sql"))
+
+	for want := 1; want <= 2; want++ {
+		attempts, err := repo.Fail(ctx, sendUUID)
+		require.NoError(t, err)
+		assert.Equal(t, want, attempts)
+	}
+
+	_, err := repo.Fail(ctx, "00000000-0000-0000-0000-000000000000")
+	assert.ErrorIs(t, err, domain.ErrSendNotFound)
+}
+
+func TestSendRepository_DeleteExpired(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(context.Background(), ctxTimeout)
+	t.Cleanup(cancel)
+	repo := repo.NewSendRepository(helpers.SetupDB(ctx, t, migrationsDir, "base.sql", "sends.sql"))
+
+	count, err := repo.DeleteExpired(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	require.NoError(t, repo.Delete(ctx, sendUUID))
+
+	_, err = repo.Get(ctx, sendUUID)
+	assert.ErrorIs(t, err, domain.ErrSendNotFound)
+}
@@ -0,0 +1,184 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/jmoiron/sqlx"
+
+	domain "github.com/novoseltcev/passkeeper/internal/domains/secrets"
+	"github.com/novoseltcev/passkeeper/internal/models"
+)
+
+type shareInDB struct {
+	Key    []byte `db:"share_key"`
+	Access string `db:"access"`
+	userInDB
+}
+
+func (s shareInDB) ToDomain(secret *models.Secret) *models.Share {
+	return &models.Share{
+		Secret:    secret,
+		Recipient: s.userInDB.ToDomain(),
+		Key:       s.Key,
+		Access:    models.ShareAccess(s.Access),
+	}
+}
+
+type sharedSecretInDB struct {
+	Key    []byte `db:"share_key"`
+	Access string `db:"access"`
+	secretInDB
+}
+
+func (r *secretRepository) GetUserByLogin(ctx context.Context, login string) (*models.User, error) {
+	var user userInDB
+
+	err := r.conn().GetContext(ctx, &user, `
+		SELECT uuid, login, password_hash, passphrase_hash, public_key, private_key
+		FROM accounts
+			WHERE login = $1
+	`, login)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrRecipientNotFound
+		}
+
+		return nil, err
+	}
+
+	return user.ToDomain(), nil
+}
+
+func (r *secretRepository) GetShare(
+	ctx context.Context,
+	id models.SecretID,
+	recipientID models.UserID,
+) (*models.Share, error) {
+	var share shareInDB
+
+	err := r.conn().GetContext(ctx, &share, `
+		SELECT secret_shares.key AS share_key, access,
+			uuid, login, password_hash, passphrase_hash, public_key, private_key
+		FROM secret_shares
+			JOIN accounts ON secret_shares.recipient_uuid = accounts.uuid
+				WHERE secret_uuid = $1 AND recipient_uuid = $2
+	`, id, recipientID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrShareNotFound
+		}
+
+		return nil, err
+	}
+
+	return share.ToDomain(&models.Secret{ID: id}), nil
+}
+
+func (r *secretRepository) GetShares(ctx context.Context, id models.SecretID) ([]models.Share, error) {
+	var shares []shareInDB
+
+	err := r.conn().SelectContext(ctx, &shares, `
+		SELECT secret_shares.key AS share_key, access,
+			uuid, login, password_hash, passphrase_hash, public_key, private_key
+		FROM secret_shares
+			JOIN accounts ON secret_shares.recipient_uuid = accounts.uuid
+				WHERE secret_uuid = $1
+					ORDER BY login
+	`, id)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]models.Share, len(shares))
+	for i, share := range shares {
+		items[i] = *share.ToDomain(&models.Secret{ID: id})
+	}
+
+	return items, nil
+}
+
+func (r *secretRepository) GetSharedWith(ctx context.Context, recipientID models.UserID) ([]models.Share, error) {
+	var shares []sharedSecretInDB
+
+	err := r.conn().SelectContext(ctx, &shares, `
+		SELECT secret_shares.key AS share_key, access,
+			secrets.uuid, owner_uuid, name, type, encrypted_data, fingerprint, login,`+secretAttrs+`
+		FROM secret_shares
+			JOIN secrets ON secret_shares.secret_uuid = secrets.uuid
+			JOIN accounts ON secrets.owner_uuid = accounts.uuid
+				WHERE recipient_uuid = $1
+					ORDER BY secret_shares.created_at DESC
+	`, recipientID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]models.Share, len(shares))
+	for i, share := range shares {
+		items[i] = models.Share{
+			Secret:    share.secretInDB.ToDomain(),
+			Recipient: &models.User{ID: recipientID},
+			Key:       share.Key,
+			Access:    models.ShareAccess(share.Access),
+		}
+	}
+
+	return items, nil
+}
+
+func (r *secretRepository) SaveShare(ctx context.Context, share *models.Share) error {
+	_, err := r.conn().ExecContext(ctx, `
+		INSERT INTO secret_shares (secret_uuid, recipient_uuid, key, access, created_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		ON CONFLICT (secret_uuid, recipient_uuid) DO UPDATE
+			SET key = EXCLUDED.key, access = EXCLUDED.access
+	`, share.Secret.ID, share.Recipient.ID, share.Key, share.Access)
+
+	return err
+}
+
+func (r *secretRepository) DeleteShare(ctx context.Context, id models.SecretID, recipientID models.UserID) error {
+	res, err := r.conn().ExecContext(ctx, `
+		DELETE FROM secret_shares WHERE secret_uuid = $1 AND recipient_uuid = $2
+	`, id, recipientID)
+	if err != nil {
+		return err
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return domain.ErrShareNotFound
+	}
+
+	return nil
+}
+
+func (r *secretRepository) Rekey(ctx context.Context, secret *models.Secret, shares []models.Share) error {
+	return r.atomic(ctx, func(tx *sqlx.Tx) error {
+		_, err := tx.ExecContext(ctx, `
+			UPDATE secrets
+			SET encrypted_data = $2, key = $3, blob_key = NULLIF($4, ''), size = $5, escrow_key = $6
+			WHERE uuid = $1
+		`, secret.ID, secret.Data, secret.Key, secret.BlobKey, secret.Size, secret.EscrowKey)
+		if err != nil {
+			return err
+		}
+
+		for _, share := range shares {
+			_, err = tx.ExecContext(ctx, `
+				UPDATE secret_shares SET key = $3 WHERE secret_uuid = $1 AND recipient_uuid = $2
+			`, secret.ID, share.Recipient.ID, share.Key)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
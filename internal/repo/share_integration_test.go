@@ -0,0 +1,144 @@
+package repo_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domain "github.com/novoseltcev/passkeeper/internal/domains/secrets"
+	"github.com/novoseltcev/passkeeper/internal/models"
+	"github.com/novoseltcev/passkeeper/internal/repo"
+	"github.com/novoseltcev/passkeeper/pkg/testutils/helpers"
+)
+
+const recipientUUID = "08108e22-a2d8-4ce7-abbb-13d91dacc758"
+
+func TestSecretRepository_GetUserByLogin(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(context.Background(), ctxTimeout)
+	t.Cleanup(cancel)
+	repo := repo.NewSecretRepository(helpers.SetupDB(ctx, t, migrationsDir, "base.sql", "shares.sql"))
+
+	t.Run("Success", func(t *testing.T) {
+		t.Parallel()
+
+		user, err := repo.GetUserByLogin(ctx, "test@test.com")
+		require.NoError(t, err)
+		assert.Equal(t, models.UserID(recipientUUID), user.ID)
+		assert.Equal(t, []byte{0xaa}, user.PublicKey)
+	})
+
+	t.Run("Fails_NotFound", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := repo.GetUserByLogin(ctx, "unknown@test.com")
+		assert.ErrorIs(t, err, domain.ErrRecipientNotFound)
+	})
+}
+
+func TestSecretRepository_GetShare(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(context.Background(), ctxTimeout)
+	t.Cleanup(cancel)
+	repo := repo.NewSecretRepository(helpers.SetupDB(ctx, t, migrationsDir, "base.sql", "shares.sql"))
+
+	t.Run("Success", func(t *testing.T) {
+		t.Parallel()
+
+		share, err := repo.GetShare(ctx, secretUUID1, recipientUUID)
+		require.NoError(t, err)
+		assert.Equal(t, &models.Share{
+			Secret: &models.Secret{ID: secretUUID1},
+			Recipient: &models.User{
+				ID:             recipientUUID,
+				Login:          "test@test.com",
+				PasswordHash:   "4321",
+				PassphraseHash: "7654",
+				PublicKey:      []byte{0xaa},
+				PrivateKey:     []byte{0xbb},
+			},
+			Key:    []byte{0xcc},
+			Access: models.ShareAccessRead,
+		}, share)
+	})
+
+	t.Run("Fails_NotFound", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := repo.GetShare(ctx, secretUUID2, recipientUUID)
+		assert.ErrorIs(t, err, domain.ErrShareNotFound)
+	})
+}
+
+func TestSecretRepository_GetSharedWith(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(context.Background(), ctxTimeout)
+	t.Cleanup(cancel)
+	repo := repo.NewSecretRepository(helpers.SetupDB(ctx, t, migrationsDir, "base.sql", "shares.sql"))
+
+	shares, err := repo.GetSharedWith(ctx, recipientUUID)
+	require.NoError(t, err)
+	require.Len(t, shares, 1)
+	assert.Equal(t, models.SecretID(secretUUID1), shares[0].Secret.ID)
+	assert.Equal(t, "test@example.com", shares[0].Secret.Owner.Login)
+	assert.Equal(t, models.ShareAccessRead, shares[0].Access)
+
+	shares, err = repo.GetSharedWith(ctx, accountUUID)
+	require.NoError(t, err)
+	assert.Empty(t, shares)
+}
+
+func TestSecretRepository_SaveShare(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(context.Background(), ctxTimeout)
+	t.Cleanup(cancel)
+	repo := repo.NewSecretRepository(helpers.SetupDB(ctx, t, migrationsDir, "base.sql", "shares.sql"))
+
+	err := repo.SaveShare(ctx, &models.Share{
+		Secret:    &models.Secret{ID: secretUUID1},
+		Recipient: &models.User{ID: recipientUUID},
+		Key:       []byte{0xdd},
+		Access:    models.ShareAccessWrite,
+	})
+	require.NoError(t, err)
+
+	shares, err := repo.GetShares(ctx, secretUUID1)
+	require.NoError(t, err)
+	require.Len(t, shares, 1)
+	assert.Equal(t, []byte{0xdd}, shares[0].Key)
+	assert.Equal(t, models.ShareAccessWrite, shares[0].Access)
+}
+
+func TestSecretRepository_DeleteShare(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(context.Background(), ctxTimeout)
+	t.Cleanup(cancel)
+	repo := repo.NewSecretRepository(helpers.SetupDB(ctx, t, migrationsDir, "base.sql", "shares.sql"))
+
+	require.NoError(t, repo.DeleteShare(ctx, secretUUID1, recipientUUID))
+	assert.ErrorIs(t, repo.DeleteShare(ctx, secretUUID1, recipientUUID), domain.ErrShareNotFound)
+}
+
+func TestSecretRepository_Rekey(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(context.Background(), ctxTimeout)
+	t.Cleanup(cancel)
+	repo := repo.NewSecretRepository(helpers.SetupDB(ctx, t, migrationsDir, "base.sql", "shares.sql"))
+
+	err := repo.Rekey(ctx,
+		&models.Secret{ID: secretUUID1, Data: []byte{0x01}, Key: []byte{0x02}},
+		[]models.Share{{Recipient: &models.User{ID: recipientUUID}, Key: []byte{0x03}}},
+	)
+	require.NoError(t, err)
+
+	secret, err := repo.Get(ctx, secretUUID1)
+	require.NoError(t, err)
+	assert.Equal(t, models.EncdData{0x01}, secret.Data)
+	assert.Equal(t, []byte{0x02}, secret.Key)
+
+	share, err := repo.GetShare(ctx, secretUUID1, recipientUUID)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x03}, share.Key)
+}
@@ -0,0 +1,125 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"github.com/jmoiron/sqlx"
+
+	domain "github.com/novoseltcev/passkeeper/internal/domains/templates"
+	"github.com/novoseltcev/passkeeper/internal/models"
+)
+
+type templateRepository struct {
+	db *sqlx.DB
+}
+
+type templateInDB struct {
+	UUID   string `db:"uuid"`
+	Name   string `db:"name"`
+	Fields []byte `db:"fields"`
+	Owner  string `db:"owner_uuid"`
+}
+
+func (t templateInDB) ToDomain() (*models.Template, error) {
+	var fields []models.TemplateField
+	if err := json.Unmarshal(t.Fields, &fields); err != nil {
+		return nil, err
+	}
+
+	return &models.Template{
+		ID:     models.TemplateID(t.UUID),
+		Name:   t.Name,
+		Fields: fields,
+		Owner:  &models.User{ID: models.UserID(t.Owner)},
+	}, nil
+}
+
+var _ domain.Repository = (*templateRepository)(nil)
+
+func NewTemplateRepository(db *sqlx.DB) *templateRepository { // nolint: revive
+	return &templateRepository{db: db}
+}
+
+func (r *templateRepository) Get(ctx context.Context, id models.TemplateID) (*models.Template, error) {
+	template, err := getTemplate(ctx, r.db, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrTemplateNotFound
+		}
+
+		return nil, err
+	}
+
+	return template, nil
+}
+
+func (r *templateRepository) GetAll(ctx context.Context, ownerID models.UserID) ([]models.Template, error) {
+	var templates []templateInDB
+
+	err := r.db.SelectContext(ctx, &templates, `
+		SELECT uuid, owner_uuid, name, fields
+		FROM templates
+			WHERE owner_uuid = $1
+				ORDER BY created_at DESC
+	`, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]models.Template, len(templates))
+
+	for i, template := range templates {
+		item, err := template.ToDomain()
+		if err != nil {
+			return nil, err
+		}
+
+		items[i] = *item
+	}
+
+	return items, nil
+}
+
+func (r *templateRepository) Create(ctx context.Context, data *models.Template) (models.TemplateID, error) {
+	fields, err := json.Marshal(data.Fields)
+	if err != nil {
+		return "", err
+	}
+
+	var id string
+
+	err = r.db.GetContext(ctx, &id, `
+		INSERT INTO templates (name, fields, owner_uuid, created_at)
+		VALUES ($1, $2, $3, NOW())
+		RETURNING uuid
+	`, data.Name, fields, data.Owner.ID)
+	if err != nil {
+		return "", err
+	}
+
+	return models.TemplateID(id), nil
+}
+
+func (r *templateRepository) Delete(ctx context.Context, id models.TemplateID) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM templates WHERE uuid = $1`, id)
+
+	return err
+}
+
+func getTemplate(ctx context.Context, db conn, id models.TemplateID) (*models.Template, error) {
+	var template templateInDB
+
+	err := db.GetContext(ctx, &template, `
+		SELECT uuid, owner_uuid, name, fields
+		FROM templates
+			WHERE uuid = $1
+	`, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return template.ToDomain()
+}
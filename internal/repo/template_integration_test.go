@@ -0,0 +1,99 @@
+package repo_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domain "github.com/novoseltcev/passkeeper/internal/domains/templates"
+	"github.com/novoseltcev/passkeeper/internal/models"
+	"github.com/novoseltcev/passkeeper/internal/repo"
+	"github.com/novoseltcev/passkeeper/pkg/testutils/helpers"
+)
+
+const templateUUID1 = "3f0b5a52-0a4c-4c1e-9a55-2f6d3b8a9c11"
+
+var templateFields1 = []models.TemplateField{
+	{Name: "ssid", Type: models.FieldTypeString, Required: true},
+	{Name: "key", Type: models.FieldTypeConcealed},
+}
+
+func TestTemplateRepository_Get(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(context.Background(), ctxTimeout)
+	t.Cleanup(cancel)
+	repo := repo.NewTemplateRepository(helpers.SetupDB(ctx, t, migrationsDir, "base.sql"))
+
+	t.Run("Success", func(t *testing.T) {
+		t.Parallel()
+
+		template, err := repo.Get(ctx, models.TemplateID(templateUUID1))
+		require.NoError(t, err)
+		assert.Equal(t, &models.Template{
+			ID:     models.TemplateID(templateUUID1),
+			Name:   "wifi",
+			Fields: templateFields1,
+			Owner:  &models.User{ID: models.UserID(accountUUID)},
+		}, template)
+	})
+
+	t.Run("Fails_NotFound", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := repo.Get(ctx, models.TemplateID(uuid.NewString()))
+		assert.ErrorIs(t, err, domain.ErrTemplateNotFound)
+	})
+}
+
+func TestTemplateRepository_GetAll(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(context.Background(), ctxTimeout)
+	t.Cleanup(cancel)
+	repo := repo.NewTemplateRepository(helpers.SetupDB(ctx, t, migrationsDir, "base.sql"))
+
+	templates, err := repo.GetAll(ctx, models.UserID(accountUUID))
+	require.NoError(t, err)
+	assert.Equal(t, []models.Template{{
+		ID:     models.TemplateID(templateUUID1),
+		Name:   "wifi",
+		Fields: templateFields1,
+		Owner:  &models.User{ID: models.UserID(accountUUID)},
+	}}, templates)
+}
+
+func TestTemplateRepository_Create_and_Delete(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(context.Background(), ctxTimeout)
+	t.Cleanup(cancel)
+	repo := repo.NewTemplateRepository(helpers.SetupDB(ctx, t, migrationsDir, "base.sql"))
+
+	id, err := repo.Create(ctx, models.NewTemplate("bank", templateFields1, &models.User{ID: models.UserID(accountUUID)}))
+	require.NoError(t, err)
+
+	template, err := repo.Get(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, templateFields1, template.Fields)
+
+	require.NoError(t, repo.Delete(ctx, id))
+
+	_, err = repo.Get(ctx, id)
+	assert.ErrorIs(t, err, domain.ErrTemplateNotFound)
+}
+
+func TestTemplateRepository_Create_Fails_FKConstraint(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(context.Background(), ctxTimeout)
+	t.Cleanup(cancel)
+	repo := repo.NewTemplateRepository(helpers.SetupDB(ctx, t, migrationsDir, "base.sql"))
+
+	_, err := repo.Create(ctx, models.NewTemplate("bank", templateFields1, &models.User{ID: models.UserID(uuid.NewString())}))
+	require.Error(t, err)
+
+	var pgErr *pgconn.PgError
+	require.ErrorAs(t, err, &pgErr)
+	assert.Equal(t, "23503", pgErr.Code)
+}
@@ -11,3 +11,7 @@ INSERT INTO secrets (uuid, owner_uuid, name, type, encrypted_data, created_at) V
     ('fd537d2d-a926-4027-b76f-0148a384a7b1', '62822284-5a2a-4a5d-b66e-12d09e0fe79c', 'some1', 1, decode('abc1', 'hex'), now()),
     ('87c7b7f3-fb64-4206-849c-a40f98665961', '62822284-5a2a-4a5d-b66e-12d09e0fe79c', 'some', 3, decode('1234', 'hex'), now()),
     ('e58bbd83-6068-4bfd-a769-36b2962c759a', '08108e22-a2d8-4ce7-abbb-13d91dacc758', 'some', 4, decode('0001', 'hex'), now());
+
+INSERT INTO templates (uuid, owner_uuid, name, fields, created_at) VALUES
+    ('3f0b5a52-0a4c-4c1e-9a55-2f6d3b8a9c11', '62822284-5a2a-4a5d-b66e-12d09e0fe79c', 'wifi', '[{"name":"ssid","type":"string","required":true},{"name":"key","type":"concealed","required":false}]', now()),
+    ('9b1d2c3e-4f5a-4b6c-8d7e-0f1a2b3c4d5e', '08108e22-a2d8-4ce7-abbb-13d91dacc758', 'license', '[{"name":"number","type":"string","required":true}]', now());
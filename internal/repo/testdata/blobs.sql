@@ -0,0 +1,7 @@
+INSERT INTO secrets (uuid, owner_uuid, name, type, encrypted_data, created_at, blob_key, size) VALUES
+    ('5d1e2f3a-4b5c-4d6e-8f70-8192a3b4c5d6', '62822284-5a2a-4a5d-b66e-12d09e0fe79c', 'scan', 4, '', now(), 'data-blob', 4);
+
+-- the moved copy refers to the same content as the original
+INSERT INTO secrets (uuid, owner_uuid, name, type, encrypted_data, created_at, content_blob_key, content_size) VALUES
+    ('6e2f3a4b-5c6d-4e7f-9081-92a3b4c5d6e7', '62822284-5a2a-4a5d-b66e-12d09e0fe79c', 'video', 4, decode('01', 'hex'), now(), 'content-blob', 3),
+    ('7f3a4b5c-6d7e-4f80-a192-a3b4c5d6e7f8', '08108e22-a2d8-4ce7-abbb-13d91dacc758', 'video', 4, decode('02', 'hex'), now(), 'content-blob', 3);
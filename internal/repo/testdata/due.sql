@@ -0,0 +1,5 @@
+INSERT INTO secrets (uuid, owner_uuid, name, type, encrypted_data, created_at, expires_at, rotate_every, notified_at) VALUES
+    ('0c4d7a1e-5b8f-4c2a-9e3d-6f1a2b3c4d01', '62822284-5a2a-4a5d-b66e-12d09e0fe79c', 'expired', 2, decode('01', 'hex'), now(), now() - interval '1 day', NULL, NULL),
+    ('0c4d7a1e-5b8f-4c2a-9e3d-6f1a2b3c4d02', '08108e22-a2d8-4ce7-abbb-13d91dacc758', 'rotation', 1, decode('02', 'hex'), now() - interval '40 days', NULL, interval '30 days', NULL),
+    ('0c4d7a1e-5b8f-4c2a-9e3d-6f1a2b3c4d03', '62822284-5a2a-4a5d-b66e-12d09e0fe79c', 'valid', 2, decode('03', 'hex'), now(), now() + interval '1 day', interval '30 days', NULL),
+    ('0c4d7a1e-5b8f-4c2a-9e3d-6f1a2b3c4d04', '62822284-5a2a-4a5d-b66e-12d09e0fe79c', 'notified', 2, decode('04', 'hex'), now(), now() - interval '2 days', NULL, now() - interval '1 day');
@@ -0,0 +1,6 @@
+UPDATE accounts SET public_key = decode('aa', 'hex'), private_key = decode('bb', 'hex')
+    WHERE uuid = '08108e22-a2d8-4ce7-abbb-13d91dacc758';
+
+INSERT INTO emergency_access (uuid, grantor_uuid, grantee_uuid, status, wait_days, requested_at, key) VALUES
+    ('7d3e2f1a-6b5c-4d8e-9f0a-1b2c3d4e5f60', '62822284-5a2a-4a5d-b66e-12d09e0fe79c', '08108e22-a2d8-4ce7-abbb-13d91dacc758', 'requested', 7, now() - interval '8 days', decode('cc', 'hex')),
+    ('8e4f3a2b-7c6d-4e9f-8a1b-2c3d4e5f6a71', '08108e22-a2d8-4ce7-abbb-13d91dacc758', '62822284-5a2a-4a5d-b66e-12d09e0fe79c', 'requested', 7, now() - interval '1 day', decode('dd', 'hex'));
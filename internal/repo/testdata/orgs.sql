@@ -0,0 +1,14 @@
+UPDATE accounts SET public_key = decode('aa', 'hex'), private_key = decode('bb', 'hex')
+    WHERE uuid = '08108e22-a2d8-4ce7-abbb-13d91dacc758';
+
+INSERT INTO orgs (uuid, name, created_at) VALUES
+    ('5c2f9f8e-3d1a-4b7e-9f0c-6a1d2e3f4a5b', 'acme', now());
+
+INSERT INTO org_members (org_uuid, account_uuid, role, key, created_at) VALUES
+    ('5c2f9f8e-3d1a-4b7e-9f0c-6a1d2e3f4a5b', '62822284-5a2a-4a5d-b66e-12d09e0fe79c', 'owner', decode('dd', 'hex'), now());
+
+INSERT INTO org_invitations (uuid, org_uuid, invitee_uuid, inviter_uuid, role, key, created_at) VALUES
+    ('c1d2e3f4-a5b6-4c7d-8e9f-0a1b2c3d4e5f', '5c2f9f8e-3d1a-4b7e-9f0c-6a1d2e3f4a5b', '08108e22-a2d8-4ce7-abbb-13d91dacc758', '62822284-5a2a-4a5d-b66e-12d09e0fe79c', 'viewer', decode('ee', 'hex'), now());
+
+UPDATE secrets SET org_uuid = '5c2f9f8e-3d1a-4b7e-9f0c-6a1d2e3f4a5b'
+    WHERE uuid = 'fd537d2d-a926-4027-b76f-0148a384a7b1';
@@ -0,0 +1,3 @@
+INSERT INTO sends (uuid, owner_uuid, encrypted_data, password_hash, views_left, expires_at) VALUES
+    ('0f5a3c2e-8d4b-4e6f-9a1b-2c3d4e5f6a7b', '62822284-5a2a-4a5d-b66e-12d09e0fe79c', decode('abcd', 'hex'), 'hash', 2, now() + interval '1 hour'),
+    ('1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d', '62822284-5a2a-4a5d-b66e-12d09e0fe79c', decode('ef01', 'hex'), NULL, 1, now() - interval '1 hour');
@@ -0,0 +1,5 @@
+UPDATE accounts SET public_key = decode('aa', 'hex'), private_key = decode('bb', 'hex')
+    WHERE uuid = '08108e22-a2d8-4ce7-abbb-13d91dacc758';
+
+INSERT INTO secret_shares (secret_uuid, recipient_uuid, key, access, created_at) VALUES
+    ('a6a3097b-7b03-4f3c-9686-7264a163b34d', '08108e22-a2d8-4ce7-abbb-13d91dacc758', decode('cc', 'hex'), 'read', now());
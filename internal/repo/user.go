@@ -20,6 +20,21 @@ type userInDB struct {
 	Login          string `db:"login"`
 	PasswordHash   string `db:"password_hash"`
 	PassphraseHash string `db:"passphrase_hash"`
+	PublicKey      []byte `db:"public_key"`
+	PrivateKey     []byte `db:"private_key"`
+	EscrowKey      []byte `db:"escrow_key"`
+}
+
+func (u userInDB) ToDomain() *models.User {
+	return &models.User{
+		ID:             models.UserID(u.ID),
+		Login:          u.Login,
+		PasswordHash:   u.PasswordHash,
+		PassphraseHash: u.PassphraseHash,
+		PublicKey:      u.PublicKey,
+		PrivateKey:     u.PrivateKey,
+		EscrowKey:      u.EscrowKey,
+	}
 }
 
 var _ domain.Repository = (*userRepository)(nil)
@@ -32,7 +47,7 @@ func (r *userRepository) GetByID(ctx context.Context, id models.UserID) (*models
 	var user userInDB
 
 	err := r.db.GetContext(ctx, &user, `
-		SELECT uuid, login, password_hash, passphrase_hash
+		SELECT uuid, login, password_hash, passphrase_hash, public_key, private_key
 		FROM accounts
 			WHERE uuid = $1
 	`, id)
@@ -44,19 +59,14 @@ func (r *userRepository) GetByID(ctx context.Context, id models.UserID) (*models
 		return nil, err
 	}
 
-	return &models.User{
-		ID:             models.UserID(user.ID),
-		Login:          user.Login,
-		PasswordHash:   user.PasswordHash,
-		PassphraseHash: user.PassphraseHash,
-	}, nil
+	return user.ToDomain(), nil
 }
 
 func (r *userRepository) GetByLogin(ctx context.Context, login string) (*models.User, error) {
 	var user userInDB
 
 	err := r.db.GetContext(ctx, &user, `
-		SELECT uuid, login, password_hash, passphrase_hash
+		SELECT uuid, login, password_hash, passphrase_hash, public_key, private_key
 		FROM accounts
 			WHERE login = $1
 	`, login)
@@ -68,25 +78,30 @@ func (r *userRepository) GetByLogin(ctx context.Context, login string) (*models.
 		return nil, err
 	}
 
-	return &models.User{
-		ID:             models.UserID(user.ID),
-		Login:          user.Login,
-		PasswordHash:   user.PasswordHash,
-		PassphraseHash: user.PassphraseHash,
-	}, nil
+	return user.ToDomain(), nil
 }
 
 func (r *userRepository) CreateAccount(ctx context.Context, data *models.User) (models.UserID, error) {
 	var id string
 
 	err := r.db.GetContext(ctx, &id, `
-		INSERT INTO accounts (login, password_hash, passphrase_hash, created_at)
-		VALUES ($1, $2, $3, NOW())
+		INSERT INTO accounts (login, password_hash, passphrase_hash, public_key, private_key, created_at)
+		VALUES ($1, $2, $3, $4, $5, NOW())
 		RETURNING uuid
-	`, data.Login, data.PasswordHash, data.PassphraseHash)
+	`, data.Login, data.PasswordHash, data.PassphraseHash, data.PublicKey, data.PrivateKey)
 	if err != nil {
 		return "", err
 	}
 
 	return models.UserID(id), nil
 }
+
+func (r *userRepository) SetKeys(ctx context.Context, id models.UserID, public, private []byte) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE accounts
+		SET public_key = $2, private_key = $3, updated_at = NOW()
+		WHERE uuid = $1
+	`, id, public, private)
+
+	return err
+}
@@ -9,15 +9,22 @@ import (
 	"github.com/novoseltcev/passkeeper/internal/tui/utils"
 )
 
-func NewLayout(api adapters.API) *tview.Pages {
+func NewLayout(app *tview.Application, api adapters.API) *tview.Pages {
 	state := make(map[string]string) // TODO@novoseltcev: load auth data from file
 	pages := tview.NewPages()
 	pages.AddPage(utils.PageSignIn, auth.NewSignInForm(pages, state, api), true, false)
 	pages.AddPage(utils.PageSignUp, auth.NewSignUpForm(pages, state, api), true, false)
 	pages.AddPage(utils.PagePassphrase, auth.NewPassphraseForm(pages, state, api), true, false)
 	pages.AddPage(utils.PageList, secrets.NewListView(pages, state, api), true, false)
-	pages.AddPage(utils.PageCard, secrets.NewCardView(pages, state, api), true, false)
+	pages.AddPage(utils.PageCard, secrets.NewCardView(app, pages, state, api), true, false)
 	pages.AddPage(utils.PageAdd, secrets.NewAddView(pages, state, api), true, false)
+	pages.AddPage(utils.PageReport, secrets.NewReportView(pages, state, api), true, false)
+	pages.AddPage(utils.PageShare, secrets.NewShareView(pages, state, api), true, false)
+	pages.AddPage(utils.PageShared, secrets.NewSharedView(pages, state, api), true, false)
+	pages.AddPage(utils.PageVaults, secrets.NewVaultsView(pages, state, api), true, false)
+	pages.AddPage(utils.PageSend, secrets.NewSendView(pages, state, api), true, false)
+	pages.AddPage(utils.PageEmergency, secrets.NewEmergencyView(app, pages, state, api), true, false)
+	pages.AddPage(utils.PageMatch, secrets.NewMatchView(app, pages, state, api), true, false)
 
 	isAuth := state[utils.StateToken] != ""
 	if !isAuth {
@@ -1,4 +1,3 @@
-// nolint: mnd
 package secrets
 
 import (
@@ -11,11 +10,25 @@ import (
 	"github.com/novoseltcev/passkeeper/internal/tui/utils"
 )
 
-func NewAddView(pages *tview.Pages, state map[string]string, api adapters.API) *tview.Form { // nolint: funlen
+// addAction adds a type specific button to the add form.
+//
+// The collect returns the form values by the keys of the request body.
+type addAction func(
+	form *tview.Form,
+	collect func() map[string]any,
+	state map[string]string,
+	api adapters.API,
+	done func(),
+)
+
+// addActions are the type specific buttons of the add form by the type name.
+var addActions = map[string]addAction{}
+
+func NewAddView(pages *tview.Pages, state map[string]string, api adapters.API) *tview.Form {
 	name := ""
 	form := tview.NewForm().
 		AddInputField("Name", "", 0, nil, func(text string) { name = text }).
-		AddDropDown("Type", []string{"password", "card", "text", "file"}, 0, nil).
+		AddDropDown("Type", secrets.TypeNames(), -1, nil).
 		SetCancelFunc(func() {
 			pages.SwitchToPage(utils.PageList)
 		})
@@ -24,86 +37,57 @@ func NewAddView(pages *tview.Pages, state map[string]string, api adapters.API) *
 
 	typeFld := utils.Must[*tview.DropDown](form.GetFormItem(1))
 
-	typeFld.SetSelectedFunc(func(text string, index int) {
-		switch text {
-		case "password": // nolint: dupl
-			data := &secrets.PasswordSecretData{Meta: make(map[string]any)}
+	typeFld.SetSelectedFunc(func(text string, _ int) {
+		t, ok := secrets.Lookup(text)
+		if !ok {
+			return
+		}
 
-			clearNewFields(form, 2)
-			form.AddInputField("Login", "", 0, nil, func(text string) { data.Login = text })
-			form.AddInputField("Password", "", 0, nil, func(text string) { data.Password = text })
-			form.AddTextArea("Meta", "", 0, 0, 256, func(text string) { data.Meta["k"] = text }) // nolint: mnd
-			form.AddButton("Add", func() {
-				data.Name = name
-				data.Passphrase = state[utils.StatePassphrase]
+		values := make(map[string]any)
+		meta := make(map[string]any)
 
-				_, err := api.Add(context.TODO(), state[utils.StateToken], data)
-				if err != nil {
-					panic(err) // TODO@novoseltcev: handle error
-				}
+		collect := func() map[string]any {
+			values["name"] = name
+			values["passphrase"] = state[utils.StatePassphrase]
+			values["meta"] = meta
 
-				pages.SwitchToPage(utils.PageList)
-				clearNewFields(form, 2)
-			})
-		case "card": // nolint: dupl
-			data := &secrets.CardSecretData{Meta: make(map[string]any)}
+			if org := state[utils.StateOrg]; org != "" {
+				values["org_id"] = org
+			}
+
+			return values
+		}
 
+		finish := func() {
+			pages.SwitchToPage(utils.PageList)
 			clearNewFields(form, 2)
-			form.AddInputField("Number", "", 0, nil, func(text string) { data.Number = text })
-			form.AddInputField("Holder", "", 0, nil, func(text string) { data.Holder = text })
-			form.AddInputField("CVV", "", 0, nil, func(text string) { data.CVV = text })
-			form.AddInputField("Expiration", "", 0, nil, func(text string) { data.Exp = text })
-			form.AddTextArea("Meta", "", 0, 0, 256, func(text string) { data.Meta["k"] = text }) // nolint: mnd
-			form.AddButton("Add", func() {
-				data.Name = name
-				data.Passphrase = state[utils.StatePassphrase]
+		}
 
-				_, err := api.Add(context.TODO(), state[utils.StateToken], data)
+		clearNewFields(form, 2)
+		addFields(form, t.Fields, values, state, api, func() {}, func() {
+			addMetaField(form, meta, func() {})
+			form.AddButton("Add", func() {
+				body, err := newPayload(t, collect())
 				if err != nil {
 					panic(err) // TODO@novoseltcev: handle error
 				}
 
-				pages.SwitchToPage(utils.PageList)
-				clearNewFields(form, 2)
-			})
-		case "text":
-			data := &secrets.TextSecretData{Meta: make(map[string]any)}
-
-			clearNewFields(form, 2)
-			form.AddInputField("Content", "", 0, nil, func(text string) { data.Content = text })
-			form.AddTextArea("Meta", "", 0, 0, 256, func(text string) { data.Meta["k"] = text }) // nolint: mnd
-			form.AddButton("Add", func() {
-				data.Name = name
-				data.Passphrase = state[utils.StatePassphrase]
-
-				_, err := api.Add(context.TODO(), state[utils.StateToken], data)
+				_, warnings, err := api.Add(context.TODO(), state[utils.StateToken], body)
 				if err != nil {
 					panic(err) // TODO@novoseltcev: handle error
 				}
 
-				pages.SwitchToPage(utils.PageList)
-				clearNewFields(form, 2)
+				showWarnings(pages, warnings, finish)
 			})
-		case "file": // nolint: dupl
-			data := &secrets.FileSecretData{Meta: make(map[string]any)}
 
-			clearNewFields(form, 2)
-			form.AddInputField("Filename", "", 0, nil, func(text string) { data.Filename = text })
-			form.AddInputField("Content", "", 0, nil, func(text string) { data.Content = text })
-			form.AddTextArea("Meta", "", 0, 0, 256, func(text string) { data.Meta["k"] = text }) // nolint: mnd
-			form.AddButton("Add", func() {
-				data.Name = name
-				data.Passphrase = state[utils.StatePassphrase]
+			if action, ok := formActions[t.Name]; ok {
+				action(form, state, api)
+			}
 
-				_, err := api.Add(context.TODO(), state[utils.StateToken], data)
-				if err != nil {
-					panic(err) // TODO@novoseltcev: handle error
-				}
-
-				pages.SwitchToPage(utils.PageList)
-				clearNewFields(form, 2)
-			})
-		}
+			if action, ok := addActions[t.Name]; ok {
+				action(form, collect, state, api, finish)
+			}
+		})
 	})
 
 	return form
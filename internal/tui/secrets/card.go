@@ -1,9 +1,8 @@
-// nolint: forcetypeassert
 package secrets
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
@@ -15,11 +14,32 @@ import (
 	"github.com/novoseltcev/passkeeper/internal/tui/utils"
 )
 
-func NewCardView(pages *tview.Pages, state map[string]string, api adapters.API) *tview.Flex { // nolint: funlen
+// cardWidget returns a type specific widget of the decrypted secret, which must stop when ctx is done.
+//
+// It returns nil if the secret has nothing to show.
+type cardWidget func(
+	ctx context.Context,
+	app *tview.Application,
+	secret *secrets.SecretSchema,
+	state map[string]string,
+	api adapters.API,
+) tview.Primitive
+
+// cardWidgets are the type specific widgets of the card by the type name.
+var cardWidgets = map[string]cardWidget{}
+
+func NewCardView( // nolint: funlen
+	app *tview.Application,
+	pages *tview.Pages,
+	state map[string]string,
+	api adapters.API,
+) *tview.Flex {
 	var (
-		cancel context.CancelFunc
-		init   bool
-		form   *tview.Form
+		cancel     context.CancelFunc
+		stopWidget context.CancelFunc
+		init       bool
+		form       *tview.Form
+		widget     tview.Primitive
 	)
 
 	view := tview.NewFlex().SetDirection(tview.FlexRow)
@@ -28,28 +48,50 @@ func NewCardView(pages *tview.Pages, state map[string]string, api adapters.API)
 	loader := tview.NewTextView()
 	view.AddItem(loader, 1, 1, false)
 
+	// reset clears the card of the loaded secret
+	reset := func() {
+		init = false
+
+		cancel()
+		loader.Clear()
+		view.RemoveItem(form)
+
+		if widget != nil {
+			stopWidget()
+			view.RemoveItem(widget)
+			widget = nil
+		}
+	}
+
+	var load func()
+
+	// reload loads the current revision of the secret again
+	reload := func() {
+		reset()
+		load()
+		app.SetFocus(form)
+	}
+
 	view.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
 		if event.Key() == tcell.KeyEscape {
-			init = false
-
-			cancel()
+			reset()
 			delete(state, utils.StateID)
 			pages.SwitchToPage(utils.PageList)
-			loader.Clear()
-			view.RemoveItem(form)
 		}
 
 		return event
 	})
 
 	view.SetFocusFunc(func() {
-		if init {
-			return
+		if !init {
+			load()
 		}
+	})
 
+	load = func() {
 		var ctx context.Context
 		ctx, cancel = context.WithTimeout(context.Background(), time.Second)
-		id, token, passphrase := state[utils.StateID], state[utils.StateToken], state[utils.StatePassphrase]
+		id := state[utils.StateID]
 
 		loader.SetText("Loading...")
 
@@ -68,164 +110,129 @@ func NewCardView(pages *tview.Pages, state map[string]string, api adapters.API)
 		loader.Clear()
 		view.SetTitle(fmt.Sprintf("Card <%s> - %s", secret.Type, id))
 
-		switch secret.Type {
-		case "password":
-			form = NewUpdateForm(&secrets.PasswordSecretData{
-				Passphrase: passphrase,
-				Name:       secret.Name,
-				Login:      secret.Data["login"].(string),
-				Password:   secret.Data["password"].(string),
-				Meta:       secret.Data["meta"].(map[string]any),
-			}, id, token, passphrase, api)
-		case "card":
-			form = NewUpdateForm(&secrets.CardSecretData{
-				Passphrase: passphrase,
-				Name:       secret.Name,
-				Number:     secret.Data["number"].(string),
-				Holder:     secret.Data["holder"].(string),
-				CVV:        secret.Data["cvv"].(string),
-				Exp:        secret.Data["exp"].(string),
-				Meta:       secret.Data["meta"].(map[string]any),
-			}, id, token, passphrase, api)
-		case "text":
-			form = NewUpdateForm(&secrets.TextSecretData{
-				Passphrase: passphrase,
-				Name:       secret.Name,
-				Content:    secret.Data["content"].(string),
-				Meta:       secret.Data["meta"].(map[string]any),
-			}, id, token, passphrase, api)
-		case "file":
-			form = NewUpdateForm(&secrets.FileSecretData{
-				Passphrase: passphrase,
-				Name:       secret.Name,
-				Filename:   secret.Data["filename"].(string),
-				Content:    secret.Data["content"].(string),
-				Meta:       secret.Data["meta"].(map[string]any),
-			}, id, token, passphrase, api)
+		t, ok := secrets.Lookup(secret.Type)
+		if !ok {
+			loader.SetText("Unsupported secret type")
+
+			return
+		}
+
+		form = NewUpdateForm(pages, t, secret, state, api, reload)
+
+		if newWidget, ok := cardWidgets[t.Name]; ok {
+			var widgetCtx context.Context
+			widgetCtx, stopWidget = context.WithCancel(context.Background())
+			if widget = newWidget(widgetCtx, app, secret, state, api); widget != nil {
+				view.AddItem(widget, 1, 1, false)
+			} else {
+				stopWidget()
+			}
 		}
 
 		view.AddItem(form, 0, 10, false) // nolint: mnd
-	})
+	}
 
 	return view
 }
 
-func NewUpdateForm[ // nolint: funlen
-	T secrets.PasswordSecretData |
-		secrets.CardSecretData |
-		secrets.TextSecretData |
-		secrets.FileSecretData](
-	data *T,
-	id, token, passphrase string,
+// updateAction adds a type specific button to the update form of the secret.
+type updateAction func(form *tview.Form, secret *secrets.SecretSchema, state map[string]string, api adapters.API)
+
+// updateActions are the type specific buttons of the update form by the type name.
+var updateActions = map[string]updateAction{}
+
+// NewUpdateForm returns the form of the type filled with the decrypted secret.
+//
+// The secret is saved only if it is not changed since it was loaded, otherwise the form asks
+// whether to reload it or to overwrite it. reload is called to load it again after that.
+func NewUpdateForm( // nolint: funlen
+	pages *tview.Pages,
+	t *secrets.Type,
+	secret *secrets.SecretSchema,
+	state map[string]string,
 	api adapters.API,
+	reload func(),
 ) *tview.Form {
-	form := tview.NewForm().AddButton("Save", func() {
-		if err := api.Update(context.TODO(), token, id, data); err != nil {
-			panic(err) // TODO@novoseltcev: handle error
-		}
-	})
+	values := map[string]any{"name": secret.Name}
 
-	btn := form.GetButton(0)
-	btn.SetDisabled(true)
+	// the attributes are not editable yet, but must be kept on update
+	if secret.ExpiresAt != nil {
+		values["expires_at"] = secret.ExpiresAt
+	}
 
-	switch data := any(data).(type) {
-	case *secrets.PasswordSecretData:
-		meta, err := json.Marshal(data.Meta)
-		if err != nil {
-			panic(err) // TODO@novoseltcev: handle error
+	if secret.RotateEveryDays > 0 {
+		values["rotate_every_days"] = secret.RotateEveryDays
+	}
+
+	for _, field := range t.Fields {
+		if field.CreateOnly {
+			continue
 		}
 
-		return form.
-			AddInputField("Name", data.Name, 0, nil, func(text string) {
-				data.Name = text
-				btn.SetDisabled(false)
-			}).
-			AddInputField("Login", data.Login, 0, nil, func(text string) {
-				data.Login = text
-				btn.SetDisabled(true)
-			}).
-			AddInputField("Password", data.Password, 0, nil, func(text string) {
-				data.Password = text
-				btn.SetDisabled(false)
-			}).
-			AddTextArea("Meta", string(meta), 0, 0, 256, func(text string) { // nolint: mnd
-				btn.SetDisabled(false)
-				json.Unmarshal([]byte(text), &data.Meta)
-			})
-	case *secrets.CardSecretData:
-		meta, err := json.Marshal(data.Meta)
-		if err != nil {
-			panic(err) // TODO@novoseltcev: handle error
+		if value, ok := secret.Data[field.SourceKey()]; ok {
+			values[field.Key] = value
+		}
+	}
+
+	if fields, ok := secret.Data["fields"].(map[string]any); ok {
+		fieldValues := make(map[string]string, len(fields))
+		for name, value := range fields {
+			fieldValues[name] = stringValue(value)
 		}
 
-		return form.
-			AddInputField("Name", data.Name, 0, nil, func(text string) {
-				data.Name = text
-				btn.SetDisabled(false)
-			}).
-			AddInputField("Number", data.Number, 0, nil, func(text string) {
-				data.Number = text
-				btn.SetDisabled(false)
-			}).
-			AddInputField("Holder", data.Holder, 0, nil, func(text string) {
-				data.Holder = text
-				btn.SetDisabled(false)
-			}).
-			AddInputField("CVV", data.CVV, 0, nil, func(text string) {
-				data.CVV = text
-				btn.SetDisabled(false)
-			}).
-			AddInputField("Exp", data.Exp, 0, nil, func(text string) {
-				data.Exp = text
-				btn.SetDisabled(false)
-			}).
-			AddTextArea("Meta", string(meta), 0, 0, 256, func(text string) { // nolint: mnd
-				btn.SetDisabled(false)
-				json.Unmarshal([]byte(text), &data.Meta)
-			})
-	case *secrets.TextSecretData:
-		meta, err := json.Marshal(data.Meta)
+		values["fields"] = fieldValues
+	}
+
+	meta, _ := secret.Data["meta"].(map[string]any)
+	if meta == nil {
+		meta = make(map[string]any)
+	}
+
+	var save func(etag string)
+
+	save = func(etag string) {
+		values["passphrase"] = state[utils.StatePassphrase]
+		values["meta"] = meta
+
+		body, err := newPayload(t, values)
 		if err != nil {
 			panic(err) // TODO@novoseltcev: handle error
 		}
 
-		return form.
-			AddInputField("Name", data.Name, 0, nil, func(text string) {
-				data.Name = text
-				btn.SetDisabled(false)
-			}).
-			AddInputField("Content", data.Content, 0, nil, func(text string) {
-				data.Content = text
-				btn.SetDisabled(false)
-			}).
-			AddTextArea("Meta", string(meta), 0, 0, 256, func(text string) { // nolint: mnd
-				btn.SetDisabled(false)
-				json.Unmarshal([]byte(text), &data.Meta)
-			})
-	case *secrets.FileSecretData:
-		meta, err := json.Marshal(data.Meta)
-		if err != nil {
+		warnings, err := api.Update(context.TODO(), state[utils.StateToken], secret.ID, etag, body)
+		if errors.Is(err, adapters.ErrPreconditionFailed) {
+			showConflict(pages, reload, func() { save("*") })
+
+			return
+		} else if err != nil {
 			panic(err) // TODO@novoseltcev: handle error
 		}
 
-		return form.
-			AddInputField("Name", data.Name, 0, nil, func(text string) {
-				data.Name = text
-				btn.SetDisabled(false)
-			}).
-			AddInputField("Filename", data.Filename, 0, nil, func(text string) {
-				data.Filename = text
-				btn.SetDisabled(false)
-			}).
-			AddInputField("Content", data.Content, 0, nil, func(text string) {
-				data.Content = text
-				btn.SetDisabled(false)
-			}).
-			AddTextArea("Meta", string(meta), 0, 0, 256, func(text string) { // nolint: mnd
-				btn.SetDisabled(false)
-				json.Unmarshal([]byte(text), &data.Meta)
-			})
-	default:
-		panic("unreachable")
+		showWarnings(pages, warnings, reload)
 	}
+
+	form := tview.NewForm().AddButton("Save", func() { save(secret.ETag) })
+
+	btn := form.GetButton(0)
+	btn.SetDisabled(true)
+
+	changed := func() { btn.SetDisabled(false) }
+
+	form.AddInputField("Name", secret.Name, 0, nil, func(text string) {
+		values["name"] = text
+		changed()
+	})
+	addFields(form, t.Fields, values, state, api, changed, func() {
+		addMetaField(form, meta, changed)
+	})
+
+	if action, ok := formActions[t.Name]; ok {
+		action(form, state, api)
+	}
+
+	if action, ok := updateActions[t.Name]; ok {
+		action(form, secret, state, api)
+	}
+
+	return form
 }
@@ -0,0 +1,27 @@
+package secrets
+
+import (
+	"github.com/rivo/tview"
+
+	"github.com/novoseltcev/passkeeper/internal/tui/utils"
+)
+
+// showConflict asks what to do with the secret changed by another client since it was loaded.
+func showConflict(pages *tview.Pages, reload, overwrite func()) {
+	modal := tview.NewModal().
+		SetText("The secret was changed by another client since it was loaded.\n\n" +
+			"Reload it and lose your changes, or overwrite it with them?").
+		AddButtons([]string{"Reload", "Overwrite", "Cancel"}).
+		SetDoneFunc(func(_ int, label string) {
+			pages.RemovePage(utils.PageConflict)
+
+			switch label {
+			case "Reload":
+				reload()
+			case "Overwrite":
+				overwrite()
+			}
+		})
+
+	pages.AddPage(utils.PageConflict, modal, true, true)
+}
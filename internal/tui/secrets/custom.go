@@ -0,0 +1,43 @@
+package secrets
+
+import (
+	"github.com/rivo/tview"
+
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/v1/templates"
+)
+
+// addTemplateFields adds an input for each template field, picked by the field type.
+func addTemplateFields(
+	form *tview.Form,
+	fields []templates.TemplateFieldSchema,
+	values map[string]string,
+	changed func(),
+) {
+	for _, field := range fields {
+		label := field.Name
+		if field.Required {
+			label += "*"
+		}
+
+		onChange := func(text string) {
+			if text == "" {
+				delete(values, field.Name)
+			} else {
+				values[field.Name] = text
+			}
+
+			changed()
+		}
+
+		switch field.Type {
+		case "concealed":
+			form.AddPasswordField(label, values[field.Name], 0, '*', onChange)
+		case "multiline":
+			form.AddTextArea(label, values[field.Name], 0, 0, 0, onChange)
+		case "number":
+			form.AddInputField(label, values[field.Name], 0, tview.InputFieldFloat, onChange)
+		default:
+			form.AddInputField(label, values[field.Name], 0, nil, onChange)
+		}
+	}
+}
@@ -0,0 +1,230 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/novoseltcev/passkeeper/internal/adapters"
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/v1/emergency"
+	"github.com/novoseltcev/passkeeper/internal/tui/utils"
+)
+
+const defaultWaitDays = "7"
+
+// NewEmergencyView returns the form to invite trusted contacts and the list of emergency accesses.
+//
+// Selecting a request of a contact approves it, 'r' rejects it and 'x' revokes the selected access.
+// Selecting an idle access of a grantor requests it, selecting a granted one lists the grantor's vault,
+// where selecting a secret decrypts it. Tab moves between the form, the accesses and the vault.
+func NewEmergencyView(
+	app *tview.Application,
+	pages *tview.Pages,
+	state map[string]string,
+	api adapters.API,
+) *tview.Flex {
+	login, waitDays := "", defaultWaitDays
+	form := tview.NewForm().
+		AddInputField("Login", "", 0, nil, func(text string) { login = text }).
+		AddInputField("Wait days", defaultWaitDays, 3, tview.InputFieldInteger, func(text string) { waitDays = text })
+
+	list := tview.NewList().SetSelectedFocusOnly(true).SetWrapAround(false)
+	vault := tview.NewList().SetSelectedFocusOnly(true).SetWrapAround(false)
+	status := tview.NewTextView().SetDynamicColors(true).SetWrap(true)
+
+	var accesses []emergency.AccessSchema
+
+	run := func(action func() error, done string) {
+		if err := action(); err != nil {
+			status.SetText("[red]" + err.Error())
+
+			return
+		}
+
+		status.SetText("[green]" + done)
+	}
+
+	openVault := func(access emergency.AccessSchema) {
+		vault.Clear()
+
+		items, _, err := api.GetEmergencyVault(context.TODO(), state[utils.StateToken], access.ID,
+			&emergency.PaginationRequest{Limit: 100}) // nolint: mnd
+		if err != nil {
+			status.SetText("[red]" + err.Error())
+
+			return
+		}
+
+		for _, item := range items {
+			vault.AddItem(item.Name+" <"+item.Type+">", "", rune(vault.GetItemCount()+1), func() {
+				secret, err := api.DecryptEmergencySecret(context.TODO(), state[utils.StateToken], access.ID, item.ID,
+					&emergency.DecryptData{Passphrase: state[utils.StatePassphrase]})
+				if err != nil {
+					status.SetText("[red]" + err.Error())
+
+					return
+				}
+
+				data, err := json.MarshalIndent(secret.Data, "", "  ")
+				if err != nil {
+					status.SetText("[red]" + err.Error())
+
+					return
+				}
+
+				status.SetText(tview.Escape(string(data)))
+			})
+		}
+
+		vault.SetTitle("Vault of " + access.Grantor)
+		app.SetFocus(vault)
+	}
+
+	var load func()
+	load = func() {
+		list.Clear()
+
+		contacts, err := api.GetEmergencyContacts(context.TODO(), state[utils.StateToken])
+		if err != nil {
+			status.SetText("[red]" + err.Error())
+
+			return
+		}
+
+		grantors, err := api.GetEmergencyGrantors(context.TODO(), state[utils.StateToken])
+		if err != nil {
+			status.SetText("[red]" + err.Error())
+
+			return
+		}
+
+		accesses = append(contacts, grantors...)
+
+		for _, access := range contacts {
+			secondary := fmt.Sprintf("trusted contact, %s, waits %d days", access.Status, access.WaitDays)
+			if access.RequestedAt != nil {
+				secondary += ", requested at " + access.RequestedAt.Local().Format("2006-01-02 15:04")
+			}
+
+			list.AddItem(access.Grantee, secondary, rune(list.GetItemCount()+1), func() {
+				run(func() error {
+					return api.ApproveEmergencyAccess(context.TODO(), state[utils.StateToken], access.ID)
+				}, "Approved "+access.Grantee)
+				load()
+			})
+		}
+
+		for _, access := range grantors {
+			list.AddItem("Vault of "+access.Grantor, access.Status, rune(list.GetItemCount()+1), func() {
+				if access.Status == "granted" {
+					openVault(access)
+
+					return
+				}
+
+				run(func() error {
+					return api.RequestEmergencyAccess(context.TODO(), state[utils.StateToken], access.ID)
+				}, "Requested the vault of "+access.Grantor)
+				load()
+			})
+		}
+	}
+
+	form.AddButton("Invite", func() {
+		days, err := strconv.Atoi(waitDays)
+		if err != nil {
+			status.SetText("[red]" + err.Error())
+
+			return
+		}
+
+		run(func() error {
+			_, err := api.InviteEmergencyContact(context.TODO(), state[utils.StateToken], &emergency.InviteData{
+				Passphrase: state[utils.StatePassphrase],
+				Login:      login,
+				WaitDays:   days,
+			})
+
+			return err
+		}, "Invited "+login)
+		load()
+	}).AddButton("Accesses", func() {
+		app.SetFocus(list)
+	}).SetCancelFunc(func() {
+		pages.SwitchToPage(utils.PageList)
+	})
+
+	list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() { // nolint: exhaustive
+		case tcell.KeyEscape:
+			pages.SwitchToPage(utils.PageList)
+
+			return nil
+		case tcell.KeyTab:
+			app.SetFocus(vault)
+
+			return nil
+		}
+
+		index := list.GetCurrentItem()
+		if index < 0 || index >= len(accesses) {
+			return event
+		}
+
+		access := accesses[index]
+
+		switch event.Rune() {
+		case 'r':
+			run(func() error {
+				return api.RejectEmergencyAccess(context.TODO(), state[utils.StateToken], access.ID)
+			}, "Rejected")
+			load()
+		case 'x':
+			run(func() error {
+				return api.RevokeEmergencyAccess(context.TODO(), state[utils.StateToken], access.ID)
+			}, "Revoked")
+			load()
+		default:
+			return event
+		}
+
+		return nil
+	})
+
+	list.SetBorder(true).SetTitle("Accesses")
+	vault.SetBorder(true)
+	vault.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() { // nolint: exhaustive
+		case tcell.KeyEscape:
+			pages.SwitchToPage(utils.PageList)
+		case tcell.KeyTab:
+			app.SetFocus(form)
+
+			return nil
+		}
+
+		return event
+	})
+
+	lists := tview.NewFlex().
+		AddItem(list, 0, 1, false).
+		AddItem(vault, 0, 1, false)
+
+	view := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(form, 7, 1, true). // nolint: mnd
+		AddItem(lists, 0, 1, false).
+		AddItem(status, 3, 1, false) // nolint: mnd
+	view.SetBorder(true).SetTitle("Emergency access")
+
+	view.SetFocusFunc(func() {
+		status.Clear()
+		vault.Clear().SetTitle("")
+		load()
+	})
+
+	return view
+}
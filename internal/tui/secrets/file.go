@@ -0,0 +1,66 @@
+package secrets
+
+import (
+	"context"
+
+	"github.com/rivo/tview"
+
+	"github.com/novoseltcev/passkeeper/internal/adapters"
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/v1/secrets"
+	"github.com/novoseltcev/passkeeper/internal/tui/utils"
+)
+
+func init() { // nolint: gochecknoinits
+	addActions["file"] = addUploadFile
+	updateActions["file"] = addSaveFile
+}
+
+// addUploadFile adds a button to upload the file at the path instead of pasting its content.
+func addUploadFile(
+	form *tview.Form,
+	collect func() map[string]any,
+	state map[string]string,
+	api adapters.API,
+	done func(),
+) {
+	path := ""
+
+	form.AddInputField("Upload from", "", 0, nil, func(text string) { path = text })
+	form.AddButton("Upload", func() {
+		values := collect()
+		meta, _ := values["meta"].(map[string]any)
+
+		_, _, err := api.UploadFile(context.TODO(), state[utils.StateToken], path, &secrets.UploadFileData{
+			Passphrase: stringValue(values["passphrase"]),
+			Name:       stringValue(values["name"]),
+			Filename:   stringValue(values["filename"]),
+			Meta:       meta,
+			AttrsData:  secrets.AttrsData{OrgID: stringValue(values["org_id"])},
+		})
+		if err != nil {
+			panic(err) // TODO@novoseltcev: handle error
+		}
+
+		done()
+	})
+}
+
+// addSaveFile adds a button to save the decrypted file to the path, a directory keeps the original filename.
+func addSaveFile(form *tview.Form, secret *secrets.SecretSchema, state map[string]string, api adapters.API) {
+	path := "."
+
+	form.AddInputField("Save to", path, 0, nil, func(text string) { path = text })
+
+	pathFld := utils.Must[*tview.InputField](form.GetFormItem(form.GetFormItemCount() - 1))
+
+	form.AddButton("Save to disk", func() {
+		saved, err := api.DownloadFile(context.TODO(), state[utils.StateToken], secret.ID, &secrets.DecryptByIDData{
+			Passphrase: state[utils.StatePassphrase],
+		}, path)
+		if err != nil {
+			panic(err) // TODO@novoseltcev: handle error
+		}
+
+		pathFld.SetText(saved)
+	})
+}
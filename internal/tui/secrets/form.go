@@ -0,0 +1,172 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strconv"
+
+	"github.com/rivo/tview"
+
+	"github.com/novoseltcev/passkeeper/internal/adapters"
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/v1/secrets"
+	"github.com/novoseltcev/passkeeper/internal/tui/utils"
+)
+
+// formAction adds a type specific button to both the add and the update forms.
+type formAction func(form *tview.Form, state map[string]string, api adapters.API)
+
+// formActions are the type specific buttons of the forms by the type name.
+var formActions = map[string]formAction{}
+
+// addFields adds the inputs of the type fields to the form and calls done after the last one.
+//
+// Values are stored by the field keys in the request body. A template field must be the last one:
+// on add it is a choice of the user templates, which replaces the items after it with
+// the fields of the chosen template and calls done again.
+func addFields( // nolint: funlen
+	form *tview.Form,
+	fields []secrets.Field,
+	values map[string]any,
+	state map[string]string,
+	api adapters.API,
+	changed func(),
+	done func(),
+) {
+	for _, field := range fields {
+		onChange := func(text string) {
+			values[field.Key] = text
+			changed()
+		}
+
+		switch field.Kind {
+		case secrets.FieldKindConcealed:
+			form.AddPasswordField(field.Label, stringValue(values[field.Key]), 0, '*', onChange)
+		case secrets.FieldKindMultiline:
+			form.AddTextArea(field.Label, stringValue(values[field.Key]), 0, 0, 0, onChange)
+		case secrets.FieldKindNumber:
+			form.AddInputField(field.Label, numberValue(values[field.Key]), 0, tview.InputFieldFloat, func(text string) {
+				if value, err := strconv.ParseFloat(text, 64); err == nil {
+					values[field.Key] = value
+				} else {
+					delete(values, field.Key)
+				}
+
+				changed()
+			})
+		case secrets.FieldKindSelect:
+			initial := max(slices.Index(field.Options, stringValue(values[field.Key])), 0)
+			form.AddDropDown(field.Label, field.Options, initial, func(option string, _ int) {
+				values[field.Key] = option
+				changed()
+			})
+		case secrets.FieldKindURIs:
+			form.AddTextArea(field.Label, formatURIs(values[field.Key]), 0, 3, 0, func(text string) { // nolint: mnd
+				values[field.Key] = parseURIs(text)
+				changed()
+			})
+		case secrets.FieldKindTemplate:
+			addTemplateField(form, field, values, state, api, changed, done)
+
+			return
+		default:
+			form.AddInputField(field.Label, stringValue(values[field.Key]), 0, nil, onChange)
+		}
+	}
+
+	done()
+}
+
+func addTemplateField(
+	form *tview.Form,
+	field secrets.Field,
+	values map[string]any,
+	state map[string]string,
+	api adapters.API,
+	changed func(),
+	done func(),
+) {
+	fieldValues, _ := values["fields"].(map[string]string)
+	if fieldValues == nil {
+		fieldValues = make(map[string]string)
+		values["fields"] = fieldValues
+	}
+
+	if id, ok := values[field.Key].(string); ok {
+		template, err := api.GetTemplate(context.TODO(), state[utils.StateToken], id)
+		if err != nil {
+			panic(err) // TODO@novoseltcev: handle error
+		}
+
+		addTemplateFields(form, template.Fields, fieldValues, changed)
+		done()
+
+		return
+	}
+
+	items, err := api.GetTemplates(context.TODO(), state[utils.StateToken])
+	if err != nil {
+		panic(err) // TODO@novoseltcev: handle error
+	}
+
+	names := make([]string, len(items))
+	for i, item := range items {
+		names[i] = item.Name
+	}
+
+	index := form.GetFormItemCount()
+	form.AddDropDown(field.Label, names, -1, func(_ string, option int) {
+		if option < 0 {
+			return
+		}
+
+		clear(fieldValues)
+		values[field.Key] = items[option].ID
+
+		clearNewFields(form, index+1)
+		addTemplateFields(form, items[option].Fields, fieldValues, changed)
+		done()
+	})
+}
+
+// newPayload builds the request body of the type from the form values.
+func newPayload(t *secrets.Type, values map[string]any) (secrets.Payload, error) {
+	raw, err := json.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+
+	body := t.New()
+	if err := json.Unmarshal(raw, body); err != nil {
+		return nil, err
+	}
+
+	return body, nil
+}
+
+func addMetaField(form *tview.Form, meta map[string]any, changed func()) {
+	text, err := json.Marshal(meta)
+	if err != nil {
+		panic(err) // TODO@novoseltcev: handle error
+	}
+
+	form.AddTextArea("Meta", string(text), 0, 0, 256, func(text string) { // nolint: mnd
+		json.Unmarshal([]byte(text), &meta)
+		changed()
+	})
+}
+
+func stringValue(v any) string {
+	s, _ := v.(string)
+
+	return s
+}
+
+func numberValue(v any) string {
+	f, ok := v.(float64)
+	if !ok {
+		return ""
+	}
+
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -3,12 +3,14 @@ package secrets
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 
 	"github.com/novoseltcev/passkeeper/internal/adapters"
 	"github.com/novoseltcev/passkeeper/internal/controllers/http/v1/secrets"
+	domain "github.com/novoseltcev/passkeeper/internal/domains/secrets"
 	"github.com/novoseltcev/passkeeper/internal/tui/utils"
 )
 
@@ -16,15 +18,17 @@ func NewListView(pages *tview.Pages, state map[string]string, api adapters.API)
 	list := tview.NewList().SetSelectedFocusOnly(true).SetWrapAround(false)
 	list.SetBorder(true).SetTitle("Secrets")
 
-	init := false
+	init, vault := false, ""
 
 	list.SetFocusFunc(func() {
-		if !init {
+		if !init || vault != state[utils.StateOrg] {
+			list.Clear()
+
 			if err := send(context.TODO(), list, pages, api, state, 0); err != nil {
 				panic(err) // TODO@novoseltcev: handle error
 			}
 
-			init = true
+			init, vault = true, state[utils.StateOrg]
 		}
 	}).SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
 		if event.Rune() == 's' {
@@ -35,6 +39,26 @@ func NewListView(pages *tview.Pages, state map[string]string, api adapters.API)
 
 			list.Clear()
 			pages.SwitchToPage(utils.PageAdd)
+		} else if event.Rune() == 'r' {
+			pages.SwitchToPage(utils.PageReport)
+		} else if event.Rune() == 'h' && list.GetItemCount() > 0 {
+			_, uuid := list.GetItemText(list.GetCurrentItem())
+			state[utils.StateID] = uuid
+
+			pages.SwitchToPage(utils.PageShare)
+		} else if event.Rune() == 'l' && list.GetItemCount() > 0 {
+			_, uuid := list.GetItemText(list.GetCurrentItem())
+			state[utils.StateID] = uuid
+
+			pages.SwitchToPage(utils.PageSend)
+		} else if event.Rune() == 'm' {
+			pages.SwitchToPage(utils.PageShared)
+		} else if event.Rune() == 'v' {
+			pages.SwitchToPage(utils.PageVaults)
+		} else if event.Rune() == 'e' {
+			pages.SwitchToPage(utils.PageEmergency)
+		} else if event.Rune() == 'u' {
+			pages.SwitchToPage(utils.PageMatch)
 		} else if event.Rune() == 'd' {
 			index := list.GetCurrentItem()
 			_, uuid := list.GetItemText(index)
@@ -63,15 +87,29 @@ func send(
 	items, total, err := api.GetSecretsPage(
 		ctx,
 		state[utils.StateToken],
-		&secrets.PaginationRequest{Limit: 50, Offset: offset}, // nolint: mnd
+		&secrets.PaginationRequest{Limit: 50, Offset: offset, OrgID: state[utils.StateOrg]}, // nolint: mnd
 	)
 	if err != nil {
 		return err
 	}
 
 	for _, item := range items {
+		text := item.Name + " <" + item.Type + ">"
+		if item.Fingerprint != "" {
+			text += " " + item.Fingerprint
+		}
+
+		if preview := previewText(item.Preview); preview != "" {
+			text += " " + preview
+		}
+
+		text += " · updated " + item.UpdatedAt.Local().Format(time.DateTime)
+		if item.AccessedAt != nil {
+			text += " · accessed " + item.AccessedAt.Local().Format(time.DateTime)
+		}
+
 		list.AddItem(
-			item.Name+" <"+item.Type+">",
+			text,
 			item.ID,
 			rune(list.GetItemCount()+1),
 			func() {
@@ -87,3 +125,25 @@ func send(
 
 	return nil
 }
+
+// previewText formats the non-secret preview of the item, e.g. "Visa •••• 4242" for cards.
+func previewText(preview map[string]string) string {
+	if login, ok := preview[domain.PreviewLogin]; ok {
+		return login
+	}
+
+	if filename, ok := preview[domain.PreviewFilename]; ok {
+		return filename
+	}
+
+	last4, ok := preview[domain.PreviewLast4]
+	if !ok {
+		return ""
+	}
+
+	if brand := preview[domain.PreviewBrand]; brand != "" {
+		return brand + " •••• " + last4
+	}
+
+	return "•••• " + last4
+}
@@ -0,0 +1,114 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/rivo/tview"
+
+	"github.com/novoseltcev/passkeeper/internal/adapters"
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/v1/secrets"
+	domain "github.com/novoseltcev/passkeeper/internal/domains/secrets"
+	"github.com/novoseltcev/passkeeper/internal/tui/utils"
+	"github.com/novoseltcev/passkeeper/pkg/otp"
+)
+
+func init() { // nolint: gochecknoinits
+	cardWidgets["otp"] = newOTPWidget
+	updateActions["otp"] = addNextOTP
+}
+
+// otpKey returns the key of the decrypted secret.
+func otpKey(secret *secrets.SecretSchema) (*otp.Key, error) {
+	raw, err := json.Marshal(secret.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	var data domain.OTPData
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, err
+	}
+
+	return data.Key(), nil
+}
+
+// newOTPWidget shows the time-based code of the decrypted key with a live countdown.
+//
+// Counter-based codes advance the saved counter, so they are generated only with the button of the form.
+func newOTPWidget(
+	ctx context.Context,
+	app *tview.Application,
+	secret *secrets.SecretSchema,
+	_ map[string]string,
+	_ adapters.API,
+) tview.Primitive {
+	key, err := otpKey(secret)
+	if err != nil {
+		return tview.NewTextView().SetText("OTP: " + err.Error())
+	}
+
+	if key.Kind == otp.KindHOTP {
+		return nil
+	}
+
+	view := tview.NewTextView()
+
+	go watchTOTP(ctx, app, view, key)
+
+	return view
+}
+
+// watchTOTP shows the current code of the key with a live countdown until ctx is done.
+//
+// The codes are computed locally, so nothing is requested or saved.
+func watchTOTP(ctx context.Context, app *tview.Application, view *tview.TextView, key *otp.Key) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		code, expiresAt, err := key.TOTP(time.Now())
+		if err != nil {
+			app.QueueUpdateDraw(func() { view.SetText("OTP: " + err.Error()) })
+
+			return
+		}
+
+		remaining := int64(math.Ceil(time.Until(expiresAt).Seconds()))
+		text := fmt.Sprintf("OTP: %s (expires in %ds)", code, remaining)
+		app.QueueUpdateDraw(func() { view.SetText(text) })
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// addNextOTP adds a button to generate the next counter-based code, which advances the saved counter.
+func addNextOTP(form *tview.Form, secret *secrets.SecretSchema, state map[string]string, api adapters.API) {
+	if key, err := otpKey(secret); err != nil || key.Kind != otp.KindHOTP {
+		return
+	}
+
+	form.AddTextView("OTP", "", 0, 1, false, false)
+
+	codeView := utils.Must[*tview.TextView](form.GetFormItem(form.GetFormItemCount() - 1))
+
+	form.AddButton("Next code", func() {
+		code, err := api.GenerateOTP(context.TODO(), state[utils.StateToken], secret.ID, &secrets.DecryptByIDData{
+			Passphrase: state[utils.StatePassphrase],
+		})
+		if err != nil {
+			codeView.SetText(err.Error())
+
+			return
+		}
+
+		codeView.SetText(code.Code)
+	})
+}
@@ -0,0 +1,44 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rivo/tview"
+
+	"github.com/novoseltcev/passkeeper/internal/adapters"
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/v1/generate"
+	"github.com/novoseltcev/passkeeper/internal/tui/utils"
+	"github.com/novoseltcev/passkeeper/pkg/pwgen"
+)
+
+func init() { // nolint: gochecknoinits
+	formActions["password"] = addGeneratePassword
+}
+
+// addGeneratePassword adds a button to fill the password with a generated one of the default policy.
+func addGeneratePassword(form *tview.Form, state map[string]string, api adapters.API) {
+	policy := pwgen.DefaultPolicy()
+
+	var button *tview.Button
+
+	form.AddButton("Generate", func() {
+		result, err := api.GeneratePassword(context.TODO(), state[utils.StateToken], &generate.GeneratePasswordData{
+			Length:  policy.Length,
+			Lower:   policy.Lower,
+			Upper:   policy.Upper,
+			Digits:  policy.Digits,
+			Symbols: policy.Symbols,
+		})
+		if err != nil {
+			panic(err) // TODO@novoseltcev: handle error
+		}
+
+		field := utils.Must[*tview.InputField](form.GetFormItemByLabel("Password"))
+		field.SetText(result.Password)
+
+		button.SetLabel(fmt.Sprintf("Generate (%.0f bits)", result.Entropy))
+	})
+
+	button = form.GetButton(form.GetButtonCount() - 1)
+}
@@ -0,0 +1,121 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/novoseltcev/passkeeper/internal/adapters"
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/v1/secrets"
+	"github.com/novoseltcev/passkeeper/internal/tui/utils"
+)
+
+const dateLayout = "2006-01-02"
+
+func NewReportView(pages *tview.Pages, state map[string]string, api adapters.API) *tview.TextView {
+	view := tview.NewTextView().SetDynamicColors(true).SetScrollable(true)
+	view.SetBorder(true).SetTitle("Report")
+
+	view.SetFocusFunc(func() {
+		view.Clear()
+
+		report, err := api.Report(context.TODO(), state[utils.StateToken], &secrets.ReportData{
+			Passphrase: state[utils.StatePassphrase],
+		})
+		if err != nil {
+			view.SetText("[red]" + err.Error())
+
+			return
+		}
+
+		text := formatReport(report)
+		if usage, err := api.GetUsage(context.TODO(), state[utils.StateToken]); err == nil {
+			text = formatUsage(usage) + text
+		}
+
+		view.SetText(text)
+	}).SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			pages.SwitchToPage(utils.PageList)
+		}
+
+		return event
+	})
+
+	return view
+}
+
+// formatUsage formats the used storage with the limits, if they are set.
+func formatUsage(usage *secrets.UsageSchema) string {
+	var b strings.Builder
+
+	b.WriteString("[yellow]Usage[-]\n")
+	fmt.Fprintf(&b, "  secrets: %d", usage.Secrets)
+
+	if usage.MaxSecrets > 0 {
+		fmt.Fprintf(&b, " of %d", usage.MaxSecrets)
+	}
+
+	fmt.Fprintf(&b, "\n  bytes: %d", usage.Bytes)
+
+	if usage.MaxBytes > 0 {
+		fmt.Fprintf(&b, " of %d", usage.MaxBytes)
+	}
+
+	b.WriteString("\n\n")
+
+	return b.String()
+}
+
+func formatReport(report *secrets.ReportSchema) string {
+	var b strings.Builder
+
+	b.WriteString("[yellow]Weak passwords[-]\n")
+
+	for _, weak := range report.Weak {
+		fmt.Fprintf(&b, "  %s (%.0f bits)\n", weak.Name, weak.Entropy)
+	}
+
+	b.WriteString("\n[yellow]Breached passwords[-]\n")
+
+	if !report.BreachChecked {
+		b.WriteString("  breach data is not available\n")
+	}
+
+	for _, breached := range report.Breached {
+		fmt.Fprintf(&b, "  %s (seen %d times)\n", breached.Name, breached.Count)
+	}
+
+	b.WriteString("\n[yellow]Reused passwords[-]\n")
+
+	for _, group := range report.Reused {
+		names := make([]string, len(group))
+		for i, ref := range group {
+			names[i] = ref.Name
+		}
+
+		fmt.Fprintf(&b, "  %s\n", strings.Join(names, ", "))
+	}
+
+	b.WriteString("\n[yellow]Stale passwords[-]\n")
+
+	for _, stale := range report.Stale {
+		fmt.Fprintf(&b, "  %s (updated %s)\n", stale.Name, stale.UpdatedAt.Format(dateLayout))
+	}
+
+	b.WriteString("\n[yellow]Cards[-]\n")
+
+	for _, card := range report.Cards {
+		status := "expires"
+		if card.Expired {
+			status = "expired"
+		}
+
+		fmt.Fprintf(&b, "  %s (%s %s)\n", card.Name, status, card.ExpiresAt.Format(dateLayout))
+	}
+
+	return b.String()
+}
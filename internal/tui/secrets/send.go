@@ -0,0 +1,58 @@
+package secrets
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/rivo/tview"
+
+	"github.com/novoseltcev/passkeeper/internal/adapters"
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/v1/sends"
+	"github.com/novoseltcev/passkeeper/internal/tui/utils"
+)
+
+// NewSendView returns the form to create a one-time link to the selected secret.
+func NewSendView(pages *tview.Pages, state map[string]string, api adapters.API) *tview.Flex {
+	maxViews, hours, password := 1, 24, "" // nolint: mnd
+
+	form := tview.NewForm().
+		AddInputField("Max views", strconv.Itoa(maxViews), 0, tview.InputFieldInteger, func(text string) {
+			maxViews, _ = strconv.Atoi(text)
+		}).
+		AddInputField("Expires in hours", strconv.Itoa(hours), 0, tview.InputFieldInteger, func(text string) {
+			hours, _ = strconv.Atoi(text)
+		}).
+		AddPasswordField("Access password", "", 0, '*', func(text string) { password = text })
+
+	status := tview.NewTextView().SetDynamicColors(true).SetWordWrap(true)
+
+	form.AddButton("Create link", func() {
+		send, err := api.CreateSend(context.TODO(), state[utils.StateToken], &sends.CreateSendData{
+			SecretID:       state[utils.StateID],
+			Passphrase:     state[utils.StatePassphrase],
+			MaxViews:       maxViews,
+			ExpiresInHours: hours,
+			Password:       password,
+		})
+		if err != nil {
+			status.SetText("[red]" + err.Error())
+
+			return
+		}
+
+		status.SetText("[green]" + send.Link + "[-]\nexpires at " + send.ExpiresAt.Local().Format("2006-01-02 15:04"))
+	}).SetCancelFunc(func() {
+		pages.SwitchToPage(utils.PageList)
+	})
+
+	view := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(form, 0, 1, true).
+		AddItem(status, 3, 1, false) // nolint: mnd
+	view.SetBorder(true).SetTitle("Send link")
+
+	view.SetFocusFunc(func() {
+		status.Clear()
+	})
+
+	return view
+}
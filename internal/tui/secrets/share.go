@@ -0,0 +1,131 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/novoseltcev/passkeeper/internal/adapters"
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/v1/secrets"
+	"github.com/novoseltcev/passkeeper/internal/tui/utils"
+)
+
+var shareAccesses = []string{"read", "write"}
+
+// NewShareView returns the form to share the selected secret and to revoke its recipients.
+func NewShareView(pages *tview.Pages, state map[string]string, api adapters.API) *tview.Flex {
+	var shares []secrets.ShareSchema
+
+	login, access, revoked := "", shareAccesses[0], -1
+	form := tview.NewForm().
+		AddInputField("Login", "", 0, nil, func(text string) { login = text }).
+		AddDropDown("Access", shareAccesses, 0, func(text string, _ int) { access = text }).
+		AddDropDown("Shared with", nil, -1, func(_ string, index int) { revoked = index })
+
+	status := tview.NewTextView().SetDynamicColors(true)
+	recipients := utils.Must[*tview.DropDown](form.GetFormItem(2))
+
+	load := func() {
+		var err error
+
+		shares, err = api.GetShares(context.TODO(), state[utils.StateToken], state[utils.StateID])
+		if err != nil {
+			status.SetText("[red]" + err.Error())
+
+			return
+		}
+
+		options := make([]string, len(shares))
+		for i, share := range shares {
+			options[i] = share.Login + " <" + share.Access + ">"
+		}
+
+		revoked = -1
+		recipients.SetOptions(options, func(_ string, index int) { revoked = index })
+	}
+
+	form.AddButton("Share", func() {
+		err := api.ShareSecret(context.TODO(), state[utils.StateToken], state[utils.StateID], &secrets.ShareData{
+			Passphrase: state[utils.StatePassphrase],
+			Login:      login,
+			Access:     access,
+		})
+		if err != nil {
+			status.SetText("[red]" + err.Error())
+
+			return
+		}
+
+		status.SetText(fmt.Sprintf("[green]Shared with %s", login))
+		load()
+	}).AddButton("Revoke", func() {
+		if revoked < 0 || revoked >= len(shares) {
+			return
+		}
+
+		share := shares[revoked]
+
+		err := api.RevokeShare(context.TODO(), state[utils.StateToken], state[utils.StateID], share.UserID,
+			&secrets.RevokeData{Passphrase: state[utils.StatePassphrase]})
+		if err != nil {
+			status.SetText("[red]" + err.Error())
+
+			return
+		}
+
+		status.SetText(fmt.Sprintf("[green]Revoked from %s", share.Login))
+		load()
+	}).SetCancelFunc(func() {
+		pages.SwitchToPage(utils.PageList)
+	})
+
+	view := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(form, 0, 1, true).
+		AddItem(status, 1, 1, false)
+	view.SetBorder(true).SetTitle("Share")
+
+	view.SetFocusFunc(func() {
+		status.Clear()
+		load()
+	})
+
+	return view
+}
+
+// NewSharedView returns the list of secrets shared with the user.
+func NewSharedView(pages *tview.Pages, state map[string]string, api adapters.API) *tview.List {
+	list := tview.NewList().SetSelectedFocusOnly(true).SetWrapAround(false)
+	list.SetBorder(true).SetTitle("Shared with me")
+
+	list.SetFocusFunc(func() {
+		list.Clear()
+
+		shares, err := api.GetSharedWithMe(context.TODO(), state[utils.StateToken])
+		if err != nil {
+			panic(err) // TODO@novoseltcev: handle error
+		}
+
+		for _, share := range shares {
+			list.AddItem(
+				share.Name+" <"+share.Type+">",
+				fmt.Sprintf("%s, %s", share.Owner, share.Access),
+				rune(list.GetItemCount()+1),
+				func() {
+					state[utils.StateID] = share.ID
+
+					pages.SwitchToPage(utils.PageCard)
+				},
+			)
+		}
+	}).SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			pages.SwitchToPage(utils.PageList)
+		}
+
+		return event
+	})
+
+	return list
+}
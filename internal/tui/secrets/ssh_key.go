@@ -0,0 +1,48 @@
+package secrets
+
+import (
+	"context"
+
+	"github.com/rivo/tview"
+
+	"github.com/novoseltcev/passkeeper/internal/adapters"
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/v1/secrets"
+	"github.com/novoseltcev/passkeeper/internal/tui/utils"
+	"github.com/novoseltcev/passkeeper/pkg/sshkey"
+)
+
+func init() { // nolint: gochecknoinits
+	addActions["ssh_key"] = addGenerateSSHKey
+}
+
+// addGenerateSSHKey adds a button to generate the key pair on the server instead of pasting it.
+func addGenerateSSHKey(
+	form *tview.Form,
+	collect func() map[string]any,
+	state map[string]string,
+	api adapters.API,
+	done func(),
+) {
+	algorithm := sshkey.AlgorithmEd25519
+
+	form.AddDropDown("Generate as", []string{sshkey.AlgorithmEd25519, sshkey.AlgorithmRSA}, 0,
+		func(text string, _ int) { algorithm = text })
+	form.AddButton("Generate", func() {
+		values := collect()
+		meta, _ := values["meta"].(map[string]any)
+
+		_, err := api.GenerateSSHKey(context.TODO(), state[utils.StateToken], &secrets.GenerateSSHKeyData{
+			Passphrase:    stringValue(values["passphrase"]),
+			Name:          stringValue(values["name"]),
+			Algorithm:     algorithm,
+			KeyPassphrase: stringValue(values["key_passphrase"]),
+			Comment:       stringValue(values["comment"]),
+			Meta:          meta,
+		})
+		if err != nil {
+			panic(err) // TODO@novoseltcev: handle error
+		}
+
+		done()
+	})
+}
@@ -0,0 +1,107 @@
+package secrets
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/novoseltcev/passkeeper/internal/adapters"
+	"github.com/novoseltcev/passkeeper/internal/tui/utils"
+)
+
+// formatURIs formats the URIs of the decrypted data one per line, the match strategy follows the URI.
+func formatURIs(v any) string {
+	items, _ := v.([]any)
+
+	lines := make([]string, 0, len(items))
+	for _, item := range items {
+		uri, _ := item.(map[string]any)
+		line := stringValue(uri["uri"])
+
+		if match := stringValue(uri["match"]); match != "" {
+			line += " " + match
+		}
+
+		lines = append(lines, line)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// parseURIs parses the URIs of formatURIs into the request body ones.
+func parseURIs(text string) []any {
+	uris := make([]any, 0)
+
+	for _, line := range strings.Split(text, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		uri := map[string]any{"uri": fields[0]}
+		if len(fields) > 1 {
+			uri["match"] = fields[1]
+		}
+
+		uris = append(uris, uri)
+	}
+
+	return uris
+}
+
+// NewMatchView returns the lookup of the passwords by the URL of a website.
+//
+// The found passwords are focused, Escape returns to the URL.
+func NewMatchView(app *tview.Application, pages *tview.Pages, state map[string]string, api adapters.API) *tview.Flex {
+	input := tview.NewInputField().SetLabel("URL ")
+	list := tview.NewList().SetSelectedFocusOnly(true).SetWrapAround(false)
+	status := tview.NewTextView().SetDynamicColors(true)
+
+	view := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(input, 1, 1, true).
+		AddItem(list, 0, 1, false).
+		AddItem(status, 1, 1, false)
+	view.SetBorder(true).SetTitle("Find by URL")
+
+	input.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEscape {
+			pages.SwitchToPage(utils.PageList)
+
+			return
+		}
+
+		list.Clear()
+		status.Clear()
+
+		found, err := adapters.FindByURL(
+			context.TODO(), api, state[utils.StateToken], state[utils.StatePassphrase], input.GetText(),
+		)
+		if err != nil {
+			status.SetText("[red]" + err.Error())
+
+			return
+		}
+
+		if len(found) == 0 {
+			status.SetText("No passwords for the URL")
+
+			return
+		}
+
+		for _, secret := range found {
+			list.AddItem(secret.Name, stringValue(secret.Data["login"]), rune(list.GetItemCount()+1), func() {
+				state[utils.StateID] = secret.ID
+
+				pages.SwitchToPage(utils.PageCard)
+			})
+		}
+
+		app.SetFocus(list)
+	})
+
+	list.SetDoneFunc(func() { app.SetFocus(input) })
+
+	return view
+}
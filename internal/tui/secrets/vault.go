@@ -0,0 +1,79 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/novoseltcev/passkeeper/internal/adapters"
+	"github.com/novoseltcev/passkeeper/internal/tui/utils"
+)
+
+// NewVaultsView returns the list to switch between the personal vault and the organization vaults.
+//
+// The pending invitations are listed after the vaults, selecting an invitation accepts it.
+func NewVaultsView(pages *tview.Pages, state map[string]string, api adapters.API) *tview.List {
+	list := tview.NewList().SetSelectedFocusOnly(true).SetWrapAround(false)
+	list.SetBorder(true).SetTitle("Vaults")
+
+	open := func(org string) func() {
+		return func() {
+			state[utils.StateOrg] = org
+
+			pages.SwitchToPage(utils.PageList)
+		}
+	}
+
+	var load func()
+	load = func() {
+		list.Clear()
+		list.AddItem("Personal", "", rune(list.GetItemCount()+1), open(""))
+
+		orgs, err := api.GetOrgs(context.TODO(), state[utils.StateToken])
+		if err != nil {
+			panic(err) // TODO@novoseltcev: handle error
+		}
+
+		for _, org := range orgs {
+			list.AddItem(org.Name, org.Role, rune(list.GetItemCount()+1), open(org.ID))
+		}
+
+		invitations, err := api.GetInvitations(context.TODO(), state[utils.StateToken])
+		if err != nil {
+			panic(err) // TODO@novoseltcev: handle error
+		}
+
+		for _, invitation := range invitations {
+			list.AddItem(
+				fmt.Sprintf("Join %s <%s>", invitation.OrgName, invitation.Role),
+				"invited by "+invitation.Inviter,
+				rune(list.GetItemCount()+1),
+				func() {
+					if err := api.AcceptInvitation(context.TODO(), state[utils.StateToken], invitation.ID); err != nil {
+						panic(err) // TODO@novoseltcev: handle error
+					}
+
+					load()
+				},
+			)
+		}
+
+		for i, org := range orgs {
+			if org.ID == state[utils.StateOrg] {
+				list.SetCurrentItem(i + 1)
+			}
+		}
+	}
+
+	list.SetFocusFunc(load).SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			pages.SwitchToPage(utils.PageList)
+		}
+
+		return event
+	})
+
+	return list
+}
@@ -0,0 +1,34 @@
+package secrets
+
+import (
+	"strings"
+
+	"github.com/rivo/tview"
+
+	"github.com/novoseltcev/passkeeper/internal/controllers/http/common/response"
+	"github.com/novoseltcev/passkeeper/internal/tui/utils"
+)
+
+// showWarnings shows the warnings of a saved secret in a modal and calls next when it is closed.
+func showWarnings(pages *tview.Pages, warnings []response.Warning, next func()) {
+	if len(warnings) == 0 {
+		next()
+
+		return
+	}
+
+	messages := make([]string, len(warnings))
+	for i, w := range warnings {
+		messages[i] = w.Message
+	}
+
+	modal := tview.NewModal().
+		SetText("Saved with warnings:\n\n" + strings.Join(messages, "\n")).
+		AddButtons([]string{"OK"}).
+		SetDoneFunc(func(_ int, _ string) {
+			pages.RemovePage(utils.PageWarnings)
+			next()
+		})
+
+	pages.AddPage(utils.PageWarnings, modal, true, true)
+}
@@ -7,4 +7,13 @@ const (
 	PageList
 	PageCard
 	PageAdd
+	PageReport
+	PageWarnings
+	PageShare
+	PageShared
+	PageVaults
+	PageSend
+	PageEmergency
+	PageConflict
+	PageMatch
 )
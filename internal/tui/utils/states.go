@@ -6,4 +6,5 @@ const (
 	StateID         = "id"
 	StateTotal      = "total"
 	StateOffset     = "offset"
+	StateOrg        = "org" // selected organization vault, empty for the personal one
 )
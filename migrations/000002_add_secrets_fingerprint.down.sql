@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE secrets DROP COLUMN IF EXISTS fingerprint;
+
+COMMIT;
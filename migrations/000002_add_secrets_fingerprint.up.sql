@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE secrets ADD COLUMN IF NOT EXISTS fingerprint VARCHAR NULL;
+
+COMMIT;
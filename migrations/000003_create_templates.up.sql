@@ -0,0 +1,13 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS templates (
+    uuid UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner_uuid UUID NOT NULL REFERENCES accounts(uuid) ON DELETE CASCADE,
+    name VARCHAR NOT NULL,
+    fields JSONB NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP NULL
+);
+CREATE INDEX IF NOT EXISTS templates_owner_id ON templates (owner_uuid);
+
+COMMIT;
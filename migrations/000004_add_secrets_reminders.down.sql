@@ -0,0 +1,8 @@
+BEGIN;
+
+ALTER TABLE secrets
+    DROP COLUMN IF EXISTS expires_at,
+    DROP COLUMN IF EXISTS rotate_every,
+    DROP COLUMN IF EXISTS notified_at;
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+
+ALTER TABLE secrets
+    ADD COLUMN IF NOT EXISTS expires_at TIMESTAMP NULL,
+    ADD COLUMN IF NOT EXISTS rotate_every INTERVAL NULL,
+    ADD COLUMN IF NOT EXISTS notified_at TIMESTAMP NULL;
+
+COMMIT;
@@ -0,0 +1,12 @@
+BEGIN;
+
+DROP TABLE IF EXISTS secret_shares;
+
+ALTER TABLE secrets
+    DROP COLUMN IF EXISTS key;
+
+ALTER TABLE accounts
+    DROP COLUMN IF EXISTS public_key,
+    DROP COLUMN IF EXISTS private_key;
+
+COMMIT;
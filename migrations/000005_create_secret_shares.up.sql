@@ -0,0 +1,20 @@
+BEGIN;
+
+ALTER TABLE accounts
+    ADD COLUMN IF NOT EXISTS public_key bytea NULL,
+    ADD COLUMN IF NOT EXISTS private_key bytea NULL;
+
+ALTER TABLE secrets
+    ADD COLUMN IF NOT EXISTS key bytea NULL;
+
+CREATE TABLE IF NOT EXISTS secret_shares (
+    secret_uuid UUID NOT NULL REFERENCES secrets(uuid) ON DELETE CASCADE,
+    recipient_uuid UUID NOT NULL REFERENCES accounts(uuid) ON DELETE CASCADE,
+    key bytea NOT NULL,
+    access VARCHAR NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    PRIMARY KEY (secret_uuid, recipient_uuid)
+);
+CREATE INDEX IF NOT EXISTS secret_shares_recipient_uuid ON secret_shares (recipient_uuid);
+
+COMMIT;
@@ -0,0 +1,10 @@
+BEGIN;
+
+ALTER TABLE secrets
+    DROP COLUMN IF EXISTS org_uuid;
+
+DROP TABLE IF EXISTS org_invitations;
+DROP TABLE IF EXISTS org_members;
+DROP TABLE IF EXISTS orgs;
+
+COMMIT;
@@ -0,0 +1,35 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS orgs (
+    uuid UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS org_members (
+    org_uuid UUID NOT NULL REFERENCES orgs(uuid) ON DELETE CASCADE,
+    account_uuid UUID NOT NULL REFERENCES accounts(uuid) ON DELETE CASCADE,
+    role VARCHAR NOT NULL,
+    key bytea NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    PRIMARY KEY (org_uuid, account_uuid)
+);
+CREATE INDEX IF NOT EXISTS org_members_account_uuid ON org_members (account_uuid);
+
+CREATE TABLE IF NOT EXISTS org_invitations (
+    uuid UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    org_uuid UUID NOT NULL REFERENCES orgs(uuid) ON DELETE CASCADE,
+    invitee_uuid UUID NOT NULL REFERENCES accounts(uuid) ON DELETE CASCADE,
+    inviter_uuid UUID NOT NULL REFERENCES accounts(uuid) ON DELETE CASCADE,
+    role VARCHAR NOT NULL,
+    key bytea NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    UNIQUE (org_uuid, invitee_uuid)
+);
+CREATE INDEX IF NOT EXISTS org_invitations_invitee_uuid ON org_invitations (invitee_uuid);
+
+ALTER TABLE secrets
+    ADD COLUMN IF NOT EXISTS org_uuid UUID NULL REFERENCES orgs(uuid) ON DELETE CASCADE;
+CREATE INDEX IF NOT EXISTS secrets_org_uuid ON secrets (org_uuid);
+
+COMMIT;
@@ -0,0 +1,14 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS sends (
+    uuid UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner_uuid UUID NOT NULL REFERENCES accounts(uuid) ON DELETE CASCADE,
+    encrypted_data bytea NOT NULL,
+    password_hash VARCHAR NULL,
+    views_left INT NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS sends_expires_at ON sends (expires_at);
+
+COMMIT;
@@ -0,0 +1,17 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS emergency_access (
+    uuid UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    grantor_uuid UUID NOT NULL REFERENCES accounts(uuid) ON DELETE CASCADE,
+    grantee_uuid UUID NOT NULL REFERENCES accounts(uuid) ON DELETE CASCADE,
+    status VARCHAR NOT NULL,
+    wait_days INT NOT NULL,
+    requested_at TIMESTAMP NULL,
+    key bytea NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    UNIQUE (grantor_uuid, grantee_uuid)
+);
+CREATE INDEX IF NOT EXISTS emergency_access_grantee_uuid ON emergency_access (grantee_uuid);
+CREATE INDEX IF NOT EXISTS emergency_access_requested_at ON emergency_access (requested_at) WHERE status = 'requested';
+
+COMMIT;
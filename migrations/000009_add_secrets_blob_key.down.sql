@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP INDEX IF EXISTS secrets_blob_key;
+
+ALTER TABLE secrets DROP COLUMN IF EXISTS blob_key;
+
+COMMIT;
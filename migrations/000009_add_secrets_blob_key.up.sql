@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE secrets ADD COLUMN IF NOT EXISTS blob_key VARCHAR NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS secrets_blob_key ON secrets (blob_key) WHERE blob_key IS NOT NULL;
+
+COMMIT;
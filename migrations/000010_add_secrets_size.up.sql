@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE secrets ADD COLUMN IF NOT EXISTS size BIGINT NOT NULL DEFAULT 0;
+
+UPDATE secrets SET size = octet_length(encrypted_data) WHERE blob_key IS NULL;
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE secrets ADD COLUMN IF NOT EXISTS revision BIGINT NOT NULL DEFAULT 1;
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP INDEX IF EXISTS secrets_uri_tokens;
+
+ALTER TABLE secrets DROP COLUMN IF EXISTS uri_tokens;
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE secrets ADD COLUMN IF NOT EXISTS uri_tokens TEXT[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS secrets_uri_tokens ON secrets USING GIN (uri_tokens);
+
+COMMIT;
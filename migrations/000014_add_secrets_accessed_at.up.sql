@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE secrets ADD COLUMN IF NOT EXISTS accessed_at TIMESTAMP NULL;
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE sends ADD COLUMN IF NOT EXISTS failed_attempts INT NOT NULL DEFAULT 0;
+
+COMMIT;
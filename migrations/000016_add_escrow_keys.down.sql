@@ -0,0 +1,8 @@
+BEGIN;
+
+DELETE FROM emergency_access;
+
+ALTER TABLE secrets DROP COLUMN IF EXISTS escrow_key;
+ALTER TABLE accounts DROP COLUMN IF EXISTS escrow_key;
+
+COMMIT;
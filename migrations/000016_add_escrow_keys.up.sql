@@ -0,0 +1,9 @@
+BEGIN;
+
+ALTER TABLE accounts ADD COLUMN IF NOT EXISTS escrow_key bytea NULL;
+ALTER TABLE secrets ADD COLUMN IF NOT EXISTS escrow_key bytea NULL;
+
+-- the accesses escrowed the passphrases of the grantors, the contacts are to be invited again
+DELETE FROM emergency_access;
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+
+DROP INDEX IF EXISTS secrets_content_blob_key;
+
+ALTER TABLE secrets DROP COLUMN IF EXISTS content_size;
+ALTER TABLE secrets DROP COLUMN IF EXISTS content_blob_key;
+
+COMMIT;
@@ -0,0 +1,9 @@
+BEGIN;
+
+ALTER TABLE secrets ADD COLUMN IF NOT EXISTS content_blob_key VARCHAR NULL;
+ALTER TABLE secrets ADD COLUMN IF NOT EXISTS content_size BIGINT NOT NULL DEFAULT 0;
+
+-- a moved secret refers to the same content as the original one until the original is deleted
+CREATE INDEX IF NOT EXISTS secrets_content_blob_key ON secrets (content_blob_key) WHERE content_blob_key IS NOT NULL;
+
+COMMIT;
@@ -0,0 +1,218 @@
+package aes
+
+import (
+	"bufio"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+// ChunkSize is the size of the plain chunks of a stream, each chunk is sealed separately.
+const ChunkSize = 64 << 10
+
+// counterSize and flagSize are the bytes of the nonce of a chunk after the random prefix of the stream.
+const (
+	counterSize = 4
+	flagSize    = 1
+)
+
+var ErrTruncatedStream = errors.New("truncated stream")
+
+// NewWriter returns a writer, which encrypts the stream written to it with AES-GCM in chunks and writes it to w.
+//
+// The random prefix of the nonces is written first. The nonce of a chunk is the prefix, the number of the chunk
+// and the flag of the last chunk, so reordered, dropped and truncated chunks fail to decrypt.
+// The writer must be closed to seal the last chunk, it does not close w.
+func (a *AES) NewWriter(key []byte, w io.Writer) (io.WriteCloser, error) {
+	gcm, err := a.newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	prefix := nonce[:len(nonce)-counterSize-flagSize]
+
+	if _, err := io.ReadFull(rand.Reader, prefix); err != nil {
+		return nil, err
+	}
+
+	if _, err := w.Write(prefix); err != nil {
+		return nil, err
+	}
+
+	return &streamWriter{
+		gcm:   gcm,
+		w:     w,
+		nonce: nonce,
+		buf:   make([]byte, 0, ChunkSize),
+		out:   make([]byte, 0, ChunkSize+gcm.Overhead()),
+	}, nil
+}
+
+// NewReader returns a reader of the stream encrypted by NewWriter with the key.
+//
+// The chunks are authenticated one by one, the content read before an error must be discarded.
+func (a *AES) NewReader(key []byte, r io.Reader) (io.Reader, error) {
+	gcm, err := a.newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(r, nonce[:len(nonce)-counterSize-flagSize]); err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, ErrTruncatedStream
+		}
+
+		return nil, err
+	}
+
+	return &streamReader{
+		gcm:    gcm,
+		r:      bufio.NewReader(r),
+		nonce:  nonce,
+		sealed: make([]byte, ChunkSize+gcm.Overhead()),
+	}, nil
+}
+
+func (a *AES) newGCM(key []byte) (cipher.AEAD, error) {
+	if len(key) < a.keyLength {
+		var err error
+		if key, err = a.expandKey(key); err != nil {
+			return nil, err
+		}
+	}
+
+	c, err := aes.NewCipher(key[:a.keyLength])
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(c)
+}
+
+type streamWriter struct {
+	gcm     cipher.AEAD
+	w       io.Writer
+	nonce   []byte
+	counter uint32
+	buf     []byte
+	out     []byte
+	closed  bool
+}
+
+func (s *streamWriter) Write(p []byte) (int, error) {
+	if s.closed {
+		return 0, io.ErrClosedPipe
+	}
+
+	n := 0
+
+	for len(p) > 0 {
+		// the full chunk is sealed once more data comes, so the last one is sealed by Close
+		if len(s.buf) == ChunkSize {
+			if err := s.seal(false); err != nil {
+				return n, err
+			}
+		}
+
+		m := copy(s.buf[len(s.buf):ChunkSize], p)
+		s.buf = s.buf[:len(s.buf)+m]
+		p = p[m:]
+		n += m
+	}
+
+	return n, nil
+}
+
+func (s *streamWriter) Close() error {
+	if s.closed {
+		return nil
+	}
+
+	s.closed = true
+
+	return s.seal(true)
+}
+
+func (s *streamWriter) seal(last bool) error {
+	setNonce(s.nonce, s.counter, last)
+	s.counter++
+
+	s.out = s.gcm.Seal(s.out[:0], s.nonce, s.buf, nil)
+	s.buf = s.buf[:0]
+
+	_, err := s.w.Write(s.out)
+
+	return err
+}
+
+type streamReader struct {
+	gcm     cipher.AEAD
+	r       *bufio.Reader
+	nonce   []byte
+	counter uint32
+	sealed  []byte
+	plain   []byte
+	done    bool
+}
+
+func (s *streamReader) Read(p []byte) (int, error) {
+	for len(s.plain) == 0 {
+		if s.done {
+			return 0, io.EOF
+		}
+
+		if err := s.open(); err != nil {
+			return 0, err
+		}
+	}
+
+	n := copy(p, s.plain)
+	s.plain = s.plain[n:]
+
+	return n, nil
+}
+
+// open reads and decrypts the next chunk, the chunk is the last one if nothing follows it.
+func (s *streamReader) open() error {
+	n, err := io.ReadFull(s.r, s.sealed)
+	if errors.Is(err, io.EOF) {
+		return ErrTruncatedStream
+	} else if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return err
+	}
+
+	last := err != nil
+	if !last {
+		if _, err := s.r.Peek(1); errors.Is(err, io.EOF) {
+			last = true
+		} else if err != nil {
+			return err
+		}
+	}
+
+	setNonce(s.nonce, s.counter, last)
+	s.counter++
+
+	if s.plain, err = s.gcm.Open(s.sealed[:0], s.nonce, s.sealed[:n], nil); err != nil {
+		return err
+	}
+
+	s.done = last
+
+	return nil
+}
+
+func setNonce(nonce []byte, counter uint32, last bool) {
+	tail := nonce[len(nonce)-counterSize-flagSize:]
+	binary.BigEndian.PutUint32(tail, counter)
+
+	tail[counterSize] = 0
+	if last {
+		tail[counterSize] = 1
+	}
+}
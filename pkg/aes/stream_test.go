@@ -0,0 +1,98 @@
+package aes_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	aes "github.com/novoseltcev/passkeeper/pkg/aes"
+)
+
+var streamKey = []byte("0123456789abcdef0123456789abcdef")
+
+func encryptStream(t *testing.T, data []byte) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	w, err := aes.New(aes.AES256BitKeyLength).NewWriter(streamKey, &buf)
+	require.NoError(t, err)
+
+	// odd writes cross the chunk boundaries
+	for len(data) > 0 {
+		n := min(len(data), 1000)
+		_, err := w.Write(data[:n])
+		require.NoError(t, err)
+
+		data = data[n:]
+	}
+
+	require.NoError(t, w.Close())
+
+	return buf.Bytes()
+}
+
+func decryptStream(key, sealed []byte) ([]byte, error) {
+	r, err := aes.New(aes.AES256BitKeyLength).NewReader(key, bytes.NewReader(sealed))
+	if err != nil {
+		return nil, err
+	}
+
+	return io.ReadAll(r)
+}
+
+func TestAES_Stream(t *testing.T) {
+	t.Parallel()
+
+	for _, size := range []int{0, 1, aes.ChunkSize - 1, aes.ChunkSize, aes.ChunkSize + 1, 3*aes.ChunkSize + 7} {
+		data := make([]byte, size)
+		_, err := rand.Read(data)
+		require.NoError(t, err)
+
+		sealed := encryptStream(t, data)
+
+		got, err := decryptStream(streamKey, sealed)
+		require.NoError(t, err, size)
+		assert.Equal(t, data, got, size)
+	}
+}
+
+func TestAES_Stream_Fails(t *testing.T) {
+	t.Parallel()
+
+	data := make([]byte, 2*aes.ChunkSize+10)
+	sealed := encryptStream(t, data)
+	// the prefix of the nonces and the first full chunk with its tag
+	firstChunk := 7 + aes.ChunkSize + 16
+
+	tests := []struct {
+		name   string
+		key    []byte
+		sealed []byte
+	}{
+		{name: "wrong key", key: []byte("fedcba9876543210fedcba9876543210"), sealed: sealed},
+		{name: "tampered", key: streamKey, sealed: func() []byte {
+			tampered := bytes.Clone(sealed)
+			tampered[len(tampered)-1] ^= 1
+
+			return tampered
+		}()},
+		{name: "truncated at a chunk", key: streamKey, sealed: sealed[:firstChunk]},
+		{name: "truncated in a chunk", key: streamKey, sealed: sealed[:firstChunk+100]},
+		{name: "no chunks", key: streamKey, sealed: sealed[:7]},
+		{name: "no prefix", key: streamKey, sealed: sealed[:3]},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := decryptStream(tt.key, tt.sealed)
+			assert.Error(t, err)
+		})
+	}
+}
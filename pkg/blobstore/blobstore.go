@@ -0,0 +1,38 @@
+// Package blobstore keeps opaque blobs by keys in a local directory or in an S3-compatible bucket.
+//
+// Keys are flat names of letters, digits, dots, dashes and underscores, so they are safe as file names
+// and as object names without escaping.
+package blobstore
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrNotFound   = errors.New("blob not found")
+	ErrInvalidKey = errors.New("invalid blob key")
+	ErrNoPrefix   = errors.New("blobs are not listed without a prefix")
+)
+
+// Info describes a stored blob.
+type Info struct {
+	Key     string
+	ModTime time.Time
+}
+
+func checkKey(key string) error {
+	if key == "" || key == "." || key == ".." {
+		return ErrInvalidKey
+	}
+
+	for _, r := range key {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-', r == '_':
+		default:
+			return ErrInvalidKey
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,129 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// tmpPrefix marks blobs being written, they are renamed once complete.
+const tmpPrefix = ".tmp-"
+
+// FS keeps blobs as files of a local directory.
+type FS struct {
+	dir string
+}
+
+// NewFS returns the store in the directory, which is created readable by the user only if missing.
+func NewFS(dir string) (*FS, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil { // nolint: mnd
+		return nil, err
+	}
+
+	return &FS{dir: dir}, nil
+}
+
+// Put streams the blob to a temporary file and renames it, so readers never see a partial blob.
+func (s *FS) Put(_ context.Context, key string, r io.Reader) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(s.dir, tmpPrefix+key+"-*")
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(file, r); err != nil {
+		file.Close()
+		os.Remove(file.Name())
+
+		return err
+	}
+
+	if err := file.Close(); err != nil {
+		os.Remove(file.Name())
+
+		return err
+	}
+
+	if err := os.Rename(file.Name(), filepath.Join(s.dir, key)); err != nil {
+		os.Remove(file.Name())
+
+		return err
+	}
+
+	return nil
+}
+
+func (s *FS) Get(_ context.Context, key string) ([]byte, error) {
+	if err := checkKey(key); err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(s.dir, key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+
+	return data, err
+}
+
+// Open returns a reader of the blob, which must be closed.
+func (s *FS) Open(_ context.Context, key string) (io.ReadCloser, error) {
+	if err := checkKey(key); err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(filepath.Join(s.dir, key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+
+	return file, err
+}
+
+// Delete deletes the blob, a missing blob is not an error.
+func (s *FS) Delete(_ context.Context, key string) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+
+	if err := os.Remove(filepath.Join(s.dir, key)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+// List returns all the blobs with the temporary files of the blobs being written.
+//
+// No secret refers to a temporary file, so the ones left by interrupted writes are collected once old.
+func (s *FS) List(_ context.Context) ([]Info, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]Info, 0, len(entries))
+
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+
+		info, err := entry.Info()
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		infos = append(infos, Info{Key: entry.Name(), ModTime: info.ModTime()})
+	}
+
+	return infos, nil
+}
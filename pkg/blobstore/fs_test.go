@@ -0,0 +1,107 @@
+package blobstore_test
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/novoseltcev/passkeeper/pkg/blobstore"
+)
+
+func TestFS(t *testing.T) {
+	t.Parallel()
+
+	dir := filepath.Join(t.TempDir(), "blobs")
+	store, err := blobstore.NewFS(dir)
+	require.NoError(t, err)
+
+	testStore(t, store)
+
+	info, err := os.Stat(dir)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o700), info.Mode().Perm())
+}
+
+func TestFS_List_Partial(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	store, err := blobstore.NewFS(dir)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".tmp-key-1"), []byte("partial"), 0o600))
+	require.NoError(t, store.Put(context.Background(), "key", strings.NewReader("data")))
+
+	infos, err := store.List(context.Background())
+	require.NoError(t, err)
+	require.Len(t, infos, 2)
+	assert.Equal(t, ".tmp-key-1", infos[0].Key)
+	assert.Equal(t, "key", infos[1].Key)
+
+	require.NoError(t, store.Delete(context.Background(), infos[0].Key))
+	assert.NoFileExists(t, filepath.Join(dir, ".tmp-key-1"))
+}
+
+type store interface {
+	Put(ctx context.Context, key string, r io.Reader) error
+	Get(ctx context.Context, key string) ([]byte, error)
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+	List(ctx context.Context) ([]blobstore.Info, error)
+}
+
+// testStore checks the behavior common to all the stores.
+func testStore(t *testing.T, s store) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	_, err := s.Get(ctx, "missing")
+	require.ErrorIs(t, err, blobstore.ErrNotFound)
+
+	require.NoError(t, s.Put(ctx, "first", strings.NewReader("one")))
+	require.NoError(t, s.Put(ctx, "second", strings.NewReader("two")))
+	require.NoError(t, s.Put(ctx, "third", strings.NewReader("")))
+	require.NoError(t, s.Put(ctx, "first", strings.NewReader("replaced")))
+
+	data, err := s.Get(ctx, "first")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("replaced"), data)
+
+	body, err := s.Open(ctx, "second")
+	require.NoError(t, err)
+	data, err = io.ReadAll(body)
+	require.NoError(t, err)
+	require.NoError(t, body.Close())
+	assert.Equal(t, []byte("two"), data)
+
+	_, err = s.Open(ctx, "missing")
+	require.ErrorIs(t, err, blobstore.ErrNotFound)
+
+	infos, err := s.List(ctx)
+	require.NoError(t, err)
+
+	keys := make([]string, len(infos))
+	for i, info := range infos {
+		keys[i] = info.Key
+		assert.False(t, info.ModTime.IsZero())
+	}
+
+	assert.ElementsMatch(t, []string{"first", "second", "third"}, keys)
+
+	require.NoError(t, s.Delete(ctx, "second"))
+	require.NoError(t, s.Delete(ctx, "second"))
+
+	_, err = s.Get(ctx, "second")
+	require.ErrorIs(t, err, blobstore.ErrNotFound)
+
+	for _, key := range []string{"", "..", "a/b", "../etc"} {
+		require.ErrorIs(t, s.Put(ctx, key, strings.NewReader("")), blobstore.ErrInvalidKey, key)
+	}
+}
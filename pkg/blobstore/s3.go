@@ -0,0 +1,337 @@
+package blobstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	amzDateFormat = "20060102T150405Z"
+	amzDayFormat  = "20060102"
+	amzAlgorithm  = "AWS4-HMAC-SHA256"
+	amzService    = "s3"
+)
+
+// S3Config locates the bucket of an S3-compatible storage, such as AWS S3 or MinIO.
+//
+// Objects are addressed path-style as Endpoint/Bucket/Prefix+key. The prefix separates the blobs
+// from other objects of a shared bucket, only the objects under it are listed and none without it.
+type S3Config struct {
+	Endpoint  string
+	Region    string
+	Bucket    string
+	Prefix    string
+	AccessKey string
+	SecretKey string
+}
+
+// S3 keeps blobs as objects of an S3-compatible bucket, the requests are signed with AWS Signature Version 4.
+type S3 struct {
+	client   *http.Client
+	endpoint *url.URL
+	cfg      S3Config
+	now      func() time.Time
+}
+
+func NewS3(client *http.Client, cfg S3Config) (*S3, error) {
+	endpoint, err := url.Parse(cfg.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	if endpoint.Scheme == "" || endpoint.Host == "" || cfg.Bucket == "" {
+		return nil, fmt.Errorf("invalid S3 endpoint %q or bucket %q", cfg.Endpoint, cfg.Bucket)
+	}
+
+	if cfg.Region == "" {
+		cfg.Region = "us-east-1"
+	}
+
+	return &S3{client: client, endpoint: endpoint, cfg: cfg, now: time.Now}, nil
+}
+
+// Put spools the blob to a temporary file to sign its hash and then streams it to the bucket.
+func (s *S3) Put(ctx context.Context, key string, r io.Reader) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp("", "passkeeper-blob-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	hash := sha256.New()
+
+	size, err := io.Copy(io.MultiWriter(file, hash), r)
+	if err != nil {
+		return err
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	resp, err := s.do(ctx, http.MethodPut, s.cfg.Prefix+key, nil, &payload{r: file, size: size, hash: hash.Sum(nil)})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return expect(resp, http.StatusOK)
+}
+
+func (s *S3) Get(ctx context.Context, key string) ([]byte, error) {
+	body, err := s.Open(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	return io.ReadAll(body)
+}
+
+// Open returns a reader of the body of the object, which must be closed.
+func (s *S3) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	if err := checkKey(key); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.do(ctx, http.MethodGet, s.cfg.Prefix+key, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+
+		return nil, ErrNotFound
+	}
+
+	if err := expect(resp, http.StatusOK); err != nil {
+		resp.Body.Close()
+
+		return nil, err
+	}
+
+	return resp.Body, nil
+}
+
+// Delete deletes the object, a missing object is not an error.
+func (s *S3) Delete(ctx context.Context, key string) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+
+	resp, err := s.do(ctx, http.MethodDelete, s.cfg.Prefix+key, nil, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+
+	return expect(resp, http.StatusNoContent, http.StatusOK)
+}
+
+// listResult is the body of the ListObjectsV2 response.
+type listResult struct {
+	Contents []struct {
+		Key          string    `xml:"Key"`
+		LastModified time.Time `xml:"LastModified"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+// List returns all the objects under the prefix, page by page.
+//
+// The bucket may be shared, so it is not listed without the prefix.
+func (s *S3) List(ctx context.Context) ([]Info, error) {
+	if s.cfg.Prefix == "" {
+		return nil, ErrNoPrefix
+	}
+
+	var (
+		infos []Info
+		token string
+	)
+
+	for {
+		query := url.Values{"list-type": {"2"}, "prefix": {s.cfg.Prefix}}
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+
+		page, err := s.list(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, object := range page.Contents {
+			infos = append(infos, Info{Key: strings.TrimPrefix(object.Key, s.cfg.Prefix), ModTime: object.LastModified})
+		}
+
+		if !page.IsTruncated || page.NextContinuationToken == "" {
+			return infos, nil
+		}
+
+		token = page.NextContinuationToken
+	}
+}
+
+func (s *S3) list(ctx context.Context, query url.Values) (*listResult, error) {
+	resp, err := s.do(ctx, http.MethodGet, "", query, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := expect(resp, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	var result listResult
+	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// payload is the body of a request with its size and its SHA-256 hash, which is signed.
+type payload struct {
+	r    io.Reader
+	size int64
+	hash []byte
+}
+
+// emptyPayload is the body of the requests without one.
+var emptyPayload = func() *payload {
+	sum := sha256.Sum256(nil)
+
+	return &payload{r: http.NoBody, hash: sum[:]}
+}()
+
+// do sends the signed request to the object or to the bucket itself with the empty name.
+func (s *S3) do(ctx context.Context, method, name string, query url.Values, body *payload) (*http.Response, error) {
+	if body == nil {
+		body = emptyPayload
+	}
+
+	target := *s.endpoint
+	target.Path = strings.TrimSuffix(target.Path, "/") + "/" + s.cfg.Bucket
+	if name != "" {
+		target.Path += "/" + name
+	}
+
+	target.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, method, target.String(), body.r)
+	if err != nil {
+		return nil, err
+	}
+
+	req.ContentLength = body.size
+	s.sign(req, hex.EncodeToString(body.hash))
+
+	return s.client.Do(req)
+}
+
+// sign adds the Authorization header of AWS Signature Version 4 with the host and all the request headers signed.
+func (s *S3) sign(req *http.Request, payloadHash string) {
+	now := s.now().UTC()
+
+	req.Header.Set("X-Amz-Date", now.Format(amzDateFormat))
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.Join(values, ",")
+	}
+
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(headers[name]) + "\n")
+	}
+
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := strings.Join([]string{now.Format(amzDayFormat), s.cfg.Region, amzService, "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{
+		amzAlgorithm,
+		now.Format(amzDateFormat),
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := []byte("AWS4" + s.cfg.SecretKey)
+	for _, part := range []string{now.Format(amzDayFormat), s.cfg.Region, amzService, "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		amzAlgorithm, s.cfg.AccessKey, scope, signedHeaders, hex.EncodeToString(hmacSHA256(key, stringToSign))))
+}
+
+// canonicalQuery encodes the query sorted by names with spaces as %20, as the signature requires.
+func canonicalQuery(query url.Values) string {
+	return strings.ReplaceAll(query.Encode(), "+", "%20")
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+
+	return mac.Sum(nil)
+}
+
+func expect(resp *http.Response, codes ...int) error {
+	for _, code := range codes {
+		if resp.StatusCode == code {
+			return nil
+		}
+	}
+
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<10)) // nolint: mnd
+
+	return fmt.Errorf("unexpected S3 response %s: %s", resp.Status, bytes.TrimSpace(msg))
+}
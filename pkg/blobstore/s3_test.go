@@ -0,0 +1,192 @@
+package blobstore_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/novoseltcev/passkeeper/pkg/blobstore"
+)
+
+const (
+	testBucket    = "blobs"
+	testAccessKey = "access"
+	testRegion    = "eu-central-1"
+	pageSize      = 2
+)
+
+// fakeS3 is a MinIO-style stand-in, which keeps the objects of one bucket in memory.
+//
+// It checks the presence of the signature and the hash of the payload, but not the signature itself.
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	t       *testing.T
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+
+		return
+	}
+
+	sum := sha256.Sum256(body)
+	if r.Header.Get("X-Amz-Content-Sha256") != hex.EncodeToString(sum[:]) ||
+		!strings.HasPrefix(r.Header.Get("Authorization"),
+			"AWS4-HMAC-SHA256 Credential="+testAccessKey+"/"+time.Now().UTC().Format("20060102")+"/"+testRegion+"/s3/") {
+		w.WriteHeader(http.StatusForbidden)
+
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	name, ok := strings.CutPrefix(r.URL.Path, "/"+testBucket+"/")
+	if !ok {
+		f.list(w, r)
+
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		f.objects[name] = body
+	case http.MethodGet:
+		data, ok := f.objects[name]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		_, _ = w.Write(data)
+	case http.MethodDelete:
+		delete(f.objects, name)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// list responds with pages of the objects under the prefix.
+func (f *fakeS3) list(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	assert.Equal(f.t, "2", query.Get("list-type"))
+
+	keys := make([]string, 0, len(f.objects))
+	for key := range f.objects {
+		if strings.HasPrefix(key, query.Get("prefix")) && key > query.Get("continuation-token") {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+
+	type object struct {
+		Key          string
+		LastModified time.Time
+	}
+
+	var result struct {
+		XMLName               xml.Name `xml:"ListBucketResult"`
+		Contents              []object
+		IsTruncated           bool
+		NextContinuationToken string `xml:",omitempty"`
+	}
+
+	for i, key := range keys {
+		if i == pageSize {
+			result.IsTruncated = true
+			result.NextContinuationToken = keys[i-1]
+
+			break
+		}
+
+		result.Contents = append(result.Contents, object{Key: key, LastModified: time.Now().UTC()})
+	}
+
+	assert.NoError(f.t, xml.NewEncoder(w).Encode(result))
+}
+
+func TestS3(t *testing.T) {
+	t.Parallel()
+
+	fake := &fakeS3{objects: map[string][]byte{"other": []byte("not a blob")}, t: t}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	store, err := blobstore.NewS3(server.Client(), blobstore.S3Config{
+		Endpoint:  server.URL,
+		Region:    testRegion,
+		Bucket:    testBucket,
+		Prefix:    "passkeeper/",
+		AccessKey: testAccessKey,
+		SecretKey: "secret",
+	})
+	require.NoError(t, err)
+
+	testStore(t, store)
+
+	assert.Equal(t, []byte("not a blob"), fake.objects["other"])
+	assert.Contains(t, fake.objects, "passkeeper/first")
+}
+
+func TestS3_Fails_Signature(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(&fakeS3{objects: map[string][]byte{}, t: t})
+	t.Cleanup(server.Close)
+
+	store, err := blobstore.NewS3(server.Client(), blobstore.S3Config{
+		Endpoint:  server.URL,
+		Region:    "us-east-1",
+		Bucket:    testBucket,
+		AccessKey: testAccessKey,
+	})
+	require.NoError(t, err)
+
+	require.ErrorContains(t, store.Put(context.Background(), "key", strings.NewReader("data")), "403 Forbidden")
+}
+
+func TestS3_List_Fails_NoPrefix(t *testing.T) {
+	t.Parallel()
+
+	fake := &fakeS3{objects: map[string][]byte{"other": []byte("not a blob")}, t: t}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	store, err := blobstore.NewS3(server.Client(), blobstore.S3Config{
+		Endpoint:  server.URL,
+		Region:    testRegion,
+		Bucket:    testBucket,
+		AccessKey: testAccessKey,
+		SecretKey: "secret",
+	})
+	require.NoError(t, err)
+
+	_, err = store.List(context.Background())
+	require.ErrorIs(t, err, blobstore.ErrNoPrefix)
+}
+
+func TestNewS3_Fails(t *testing.T) {
+	t.Parallel()
+
+	_, err := blobstore.NewS3(http.DefaultClient, blobstore.S3Config{Endpoint: "localhost:9000", Bucket: testBucket})
+	require.Error(t, err)
+
+	_, err = blobstore.NewS3(http.DefaultClient, blobstore.S3Config{Endpoint: "http://localhost:9000"})
+	require.Error(t, err)
+}
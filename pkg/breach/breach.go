@@ -0,0 +1,293 @@
+// Package breach provides an offline lookup of breached passwords.
+//
+// The source dataset is a directory of HIBP-style range files: every file is named
+// by the first 5 hex characters of SHA-1 hashes and contains "SUFFIX:COUNT" lines
+// with the other 35 characters and the number of breaches.
+//
+// Build converts such a directory into a single index file:
+//
+//	magic   [8]byte          "PKBRCH01"
+//	fanout  [65536]uint64    cumulative number of records by the first 2 hash bytes
+//	records [N]{[20]byte, uint32} sorted by the hash
+//
+// The index is searched on disk, only the fanout table is kept in memory.
+package breach
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1" // nolint: gosec
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	prefixLen  = 5
+	fanoutSize = 1 << 16
+	hashSize   = sha1.Size
+	recordSize = hashSize + 4
+	headerSize = len(magic) + fanoutSize*8
+)
+
+const magic = "PKBRCH01"
+
+var ErrMalformed = errors.New("malformed breach data")
+
+type record struct {
+	hash  [hashSize]byte
+	count uint32
+}
+
+// Build reads the range files from src and writes the index to dst.
+func Build(src, dst string) (int, error) {
+	prefixes, err := readPrefixes(src)
+	if err != nil {
+		return 0, err
+	}
+
+	tmp := dst + ".tmp"
+
+	f, err := os.Create(tmp)
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(tmp) // nolint: errcheck
+	defer f.Close()
+
+	if _, err := f.Seek(int64(headerSize), io.SeekStart); err != nil {
+		return 0, err
+	}
+
+	var (
+		fanout [fanoutSize]uint64
+		total  int
+	)
+
+	w := bufio.NewWriter(f)
+	buf := make([]byte, recordSize)
+
+	for _, p := range prefixes {
+		records, err := readRange(filepath.Join(src, p.file), p.prefix)
+		if err != nil {
+			return 0, err
+		}
+
+		for _, r := range records {
+			copy(buf, r.hash[:])
+			binary.BigEndian.PutUint32(buf[hashSize:], r.count)
+
+			if _, err := w.Write(buf); err != nil {
+				return 0, err
+			}
+
+			fanout[binary.BigEndian.Uint16(r.hash[:2])]++
+		}
+
+		total += len(records)
+	}
+
+	if err := w.Flush(); err != nil {
+		return 0, err
+	}
+
+	header := make([]byte, headerSize)
+	copy(header, magic)
+
+	var sum uint64
+	for i, n := range fanout {
+		sum += n
+		binary.BigEndian.PutUint64(header[len(magic)+i*8:], sum)
+	}
+
+	if _, err := f.WriteAt(header, 0); err != nil {
+		return 0, err
+	}
+
+	if err := f.Close(); err != nil {
+		return 0, err
+	}
+
+	return total, os.Rename(tmp, dst)
+}
+
+type rangeFile struct {
+	prefix string
+	file   string
+}
+
+func readPrefixes(src string) ([]rangeFile, error) {
+	entries, err := os.ReadDir(src)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]rangeFile, 0, len(entries))
+	seen := make(map[string]bool, len(entries))
+
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+
+		prefix := strings.ToUpper(strings.TrimSuffix(e.Name(), filepath.Ext(e.Name())))
+		if len(prefix) != prefixLen || !isHex(prefix) {
+			continue
+		}
+
+		if seen[prefix] {
+			return nil, fmt.Errorf("%w: duplicate range %s", ErrMalformed, prefix)
+		}
+
+		seen[prefix] = true
+		files = append(files, rangeFile{prefix: prefix, file: e.Name()})
+	}
+
+	slices.SortFunc(files, func(a, b rangeFile) int { return strings.Compare(a.prefix, b.prefix) })
+
+	return files, nil
+}
+
+func readRange(path, prefix string) ([]record, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	records := make([]record, 0)
+	scanner := bufio.NewScanner(f)
+
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		suffix, count, ok := strings.Cut(text, ":")
+		if !ok || len(suffix) != hashSize*2-prefixLen {
+			return nil, fmt.Errorf("%w: %s:%d", ErrMalformed, path, line)
+		}
+
+		var r record
+		if _, err := hex.Decode(r.hash[:], []byte(prefix+suffix)); err != nil {
+			return nil, fmt.Errorf("%w: %s:%d: %w", ErrMalformed, path, line, err)
+		}
+
+		n, err := strconv.ParseUint(strings.TrimSpace(count), 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s:%d: %w", ErrMalformed, path, line, err)
+		}
+
+		r.count = uint32(n)
+		records = append(records, r)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(records, func(a, b record) int { return bytes.Compare(a.hash[:], b.hash[:]) })
+
+	return records, nil
+}
+
+func isHex(s string) bool {
+	_, err := hex.DecodeString(s + "0")
+
+	return err == nil
+}
+
+// Index is an opened breach index.
+type Index struct {
+	f      *os.File
+	fanout [fanoutSize]uint64
+}
+
+// Open opens the index built by Build.
+func Open(path string) (*Index, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	idx := &Index{f: f}
+	if err := idx.readHeader(); err != nil {
+		f.Close()
+
+		return nil, err
+	}
+
+	return idx, nil
+}
+
+func (idx *Index) readHeader() error {
+	header := make([]byte, headerSize)
+	if _, err := io.ReadFull(idx.f, header); err != nil {
+		return fmt.Errorf("%w: %w", ErrMalformed, err)
+	}
+
+	if string(header[:len(magic)]) != magic {
+		return fmt.Errorf("%w: bad magic", ErrMalformed)
+	}
+
+	for i := range idx.fanout {
+		idx.fanout[i] = binary.BigEndian.Uint64(header[len(magic)+i*8:])
+	}
+
+	info, err := idx.f.Stat()
+	if err != nil {
+		return err
+	}
+
+	if info.Size() != int64(headerSize)+int64(idx.fanout[fanoutSize-1])*recordSize {
+		return fmt.Errorf("%w: unexpected size", ErrMalformed)
+	}
+
+	return nil
+}
+
+// Count returns how many times the password was seen in breaches.
+func (idx *Index) Count(password string) (int, error) {
+	hash := sha1.Sum([]byte(password)) // nolint: gosec
+
+	bucket := binary.BigEndian.Uint16(hash[:2])
+
+	var lo uint64
+	if bucket > 0 {
+		lo = idx.fanout[bucket-1]
+	}
+
+	hi := idx.fanout[bucket]
+	buf := make([]byte, recordSize)
+
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+
+		if _, err := idx.f.ReadAt(buf, int64(headerSize)+int64(mid)*recordSize); err != nil {
+			return 0, err
+		}
+
+		switch c := bytes.Compare(buf[:hashSize], hash[:]); {
+		case c == 0:
+			return int(binary.BigEndian.Uint32(buf[hashSize:])), nil
+		case c < 0:
+			lo = mid + 1
+		default:
+			hi = mid
+		}
+	}
+
+	return 0, nil
+}
+
+func (idx *Index) Close() error {
+	return idx.f.Close()
+}
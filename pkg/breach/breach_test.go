@@ -0,0 +1,85 @@
+package breach_test
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/novoseltcev/passkeeper/pkg/breach"
+)
+
+// SHA-1 of "password" is 5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8,
+// SHA-1 of "123456" is 7C4A8D09CA3762AF61E59520943DC26494F8941B.
+func writeRanges(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "5BAA6.txt"), []byte(
+		"1E4C9B93F3F0682250B6CF8331B7EE68FD8:9545824\r\n"+
+			"003D68EB55068C33ACE09247EE4C639306B:3\r\n",
+	), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "7c4a8"), []byte(
+		"D09CA3762AF61E59520943DC26494F8941B:37359195\n",
+	), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README"), []byte("not a range"), 0o600))
+
+	return dir
+}
+
+func TestBuildAndCount(t *testing.T) {
+	t.Parallel()
+
+	dst := filepath.Join(t.TempDir(), "breach.idx")
+
+	total, err := breach.Build(writeRanges(t), dst)
+	require.NoError(t, err)
+	assert.Equal(t, 3, total)
+
+	idx, err := breach.Open(dst)
+	require.NoError(t, err)
+	t.Cleanup(func() { idx.Close() })
+
+	tests := []struct {
+		password string
+		count    int
+	}{
+		{password: "password", count: 9545824},
+		{password: "123456", count: 37359195},
+		{password: "correct horse battery staple", count: 0},
+	}
+
+	for _, tt := range tests {
+		count, err := idx.Count(tt.password)
+		require.NoError(t, err)
+		assert.Equal(t, tt.count, count, tt.password)
+	}
+}
+
+func TestBuild_Fails_Malformed(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "5BAA6.txt"), []byte("garbage\n"), 0o600))
+
+	_, err := breach.Build(dir, filepath.Join(t.TempDir(), "breach.idx"))
+	require.ErrorIs(t, err, breach.ErrMalformed)
+}
+
+func TestOpen_Fails(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	_, err := breach.Open(filepath.Join(dir, "missing.idx"))
+	require.ErrorIs(t, err, fs.ErrNotExist)
+
+	path := filepath.Join(dir, "bad.idx")
+	require.NoError(t, os.WriteFile(path, []byte("PKBRCH01"), 0o600))
+
+	_, err = breach.Open(path)
+	require.ErrorIs(t, err, breach.ErrMalformed)
+}
@@ -0,0 +1,209 @@
+// Package card checks payment card details and detects the brand of a card by its BIN.
+package card
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidNumber = errors.New("invalid card number")
+	ErrInvalidLuhn   = errors.New("card number fails the luhn check")
+	ErrInvalidExpiry = errors.New("invalid card expiration, must be MM/YY")
+	ErrInvalidCVV    = errors.New("invalid card cvv")
+)
+
+// ExpiryLayout is the layout of the expiration month of a card.
+const ExpiryLayout = "01/06"
+
+// Brand is a payment system of cards, it is empty for unknown ones.
+type Brand string
+
+const (
+	Unknown    Brand = ""
+	Visa       Brand = "Visa"
+	Mastercard Brand = "Mastercard"
+	Amex       Brand = "American Express"
+	Mir        Brand = "Mir"
+	UnionPay   Brand = "UnionPay"
+	Discover   Brand = "Discover"
+	JCB        Brand = "JCB"
+	DinersClub Brand = "Diners Club"
+	Maestro    Brand = "Maestro"
+)
+
+// brandRule describes the numbers of a brand by the ranges of their prefixes.
+type brandRule struct {
+	brand   Brand
+	ranges  [][2]int // inclusive ranges of prefixes of the same number of digits
+	lengths []int
+	cvv     int
+}
+
+// rules are checked in order, so the narrower ranges go first.
+var rules = []brandRule{ // nolint: gochecknoglobals
+	{brand: Amex, ranges: [][2]int{{34, 34}, {37, 37}}, lengths: []int{15}, cvv: 4},
+	{brand: Mir, ranges: [][2]int{{2200, 2204}}, lengths: []int{16, 17, 18, 19}, cvv: 3},
+	{brand: Mastercard, ranges: [][2]int{{51, 55}, {2221, 2720}}, lengths: []int{16}, cvv: 3},
+	{
+		brand:   Maestro,
+		ranges:  [][2]int{{5018, 5018}, {5020, 5020}, {5038, 5038}, {5893, 5893}, {6304, 6304}, {6759, 6759}, {6761, 6763}},
+		lengths: []int{12, 13, 14, 15, 16, 17, 18, 19},
+		cvv:     3,
+	},
+	{brand: Visa, ranges: [][2]int{{4, 4}}, lengths: []int{13, 16, 19}, cvv: 3},
+	{brand: Discover, ranges: [][2]int{{6011, 6011}, {644, 649}, {65, 65}}, lengths: []int{16, 17, 18, 19}, cvv: 3},
+	{brand: UnionPay, ranges: [][2]int{{62, 62}}, lengths: []int{16, 17, 18, 19}, cvv: 3},
+	{brand: JCB, ranges: [][2]int{{3528, 3589}}, lengths: []int{16, 17, 18, 19}, cvv: 3},
+	{brand: DinersClub, ranges: [][2]int{{300, 305}, {36, 36}, {38, 39}}, lengths: []int{14, 15, 16, 17, 18, 19}, cvv: 3},
+}
+
+const (
+	minLength = 12
+	maxLength = 19
+)
+
+// Normalize removes the spaces and the dashes grouping the digits of the number.
+func Normalize(number string) string {
+	return strings.NewReplacer(" ", "", "-", "").Replace(number)
+}
+
+// DetectBrand returns the brand of the number by its BIN.
+func DetectBrand(number string) Brand {
+	if rule, ok := lookup(Normalize(number)); ok {
+		return rule.brand
+	}
+
+	return Unknown
+}
+
+// Luhn reports whether the digits of the number have the valid Luhn checksum.
+func Luhn(number string) bool {
+	if !isDigits(number) {
+		return false
+	}
+
+	sum := 0
+
+	for i := range len(number) {
+		digit := int(number[len(number)-1-i] - '0')
+		if i%2 == 1 {
+			if digit *= 2; digit > 9 { // nolint: mnd
+				digit -= 9
+			}
+		}
+
+		sum += digit
+	}
+
+	return sum%10 == 0
+}
+
+// CheckNumber checks the digits, the length of the brand and the checksum of the number.
+func CheckNumber(number string) error {
+	number = Normalize(number)
+	if !isDigits(number) {
+		return ErrInvalidNumber
+	}
+
+	var lengths []int
+	if rule, ok := lookup(number); ok {
+		lengths = rule.lengths
+	}
+
+	if !validLength(len(number), lengths) {
+		return ErrInvalidNumber
+	}
+
+	if !Luhn(number) {
+		return ErrInvalidLuhn
+	}
+
+	return nil
+}
+
+// ParseExpiry returns the moment the card of the MM/YY expiration expires, that is the start of the next month.
+func ParseExpiry(exp string) (time.Time, error) {
+	month, err := time.Parse(ExpiryLayout, exp)
+	if err != nil {
+		return time.Time{}, ErrInvalidExpiry
+	}
+
+	return month.AddDate(0, 1, 0), nil
+}
+
+// CheckCVV checks the digits of the CVV, the unknown brands may have 3 or 4 of them.
+func CheckCVV(brand Brand, cvv string) error {
+	if !isDigits(cvv) {
+		return ErrInvalidCVV
+	}
+
+	for _, rule := range rules {
+		if rule.brand == brand {
+			if len(cvv) != rule.cvv {
+				return ErrInvalidCVV
+			}
+
+			return nil
+		}
+	}
+
+	if len(cvv) < 3 || len(cvv) > 4 { // nolint: mnd
+		return ErrInvalidCVV
+	}
+
+	return nil
+}
+
+// Last4 returns the last four digits of the number, which are safe to display.
+func Last4(number string) string {
+	number = Normalize(number)
+	if len(number) < 4 { // nolint: mnd
+		return ""
+	}
+
+	return number[len(number)-4:]
+}
+
+func lookup(number string) (brandRule, bool) {
+	for _, rule := range rules {
+		for _, r := range rule.ranges {
+			digits := len(strconv.Itoa(r[0]))
+			if len(number) < digits {
+				continue
+			}
+
+			prefix, err := strconv.Atoi(number[:digits])
+			if err == nil && prefix >= r[0] && prefix <= r[1] {
+				return rule, true
+			}
+		}
+	}
+
+	return brandRule{}, false
+}
+
+func validLength(length int, lengths []int) bool {
+	if len(lengths) == 0 {
+		return length >= minLength && length <= maxLength
+	}
+
+	return slices.Contains(lengths, length)
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,94 @@
+package card_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/novoseltcev/passkeeper/pkg/card"
+)
+
+func TestDetectBrand(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		number string
+		want   card.Brand
+	}{
+		{number: "4242 4242 4242 4242", want: card.Visa},
+		{number: "5555555555554444", want: card.Mastercard},
+		{number: "2223003122003222", want: card.Mastercard},
+		{number: "378282246310005", want: card.Amex},
+		{number: "2200000000000004", want: card.Mir},
+		{number: "6200000000000005", want: card.UnionPay},
+		{number: "6011111111111117", want: card.Discover},
+		{number: "3530111333300000", want: card.JCB},
+		{number: "36227206271667", want: card.DinersClub},
+		{number: "6759649826438453", want: card.Maestro},
+		{number: "9999999999999995", want: card.Unknown},
+		{number: "", want: card.Unknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.number, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, card.DetectBrand(tt.number))
+		})
+	}
+}
+
+func TestCheckNumber(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, card.CheckNumber("4242-4242-4242-4242"))
+	require.NoError(t, card.CheckNumber("378282246310005"))
+	require.NoError(t, card.CheckNumber("9999999999999995"))
+
+	require.ErrorIs(t, card.CheckNumber("4242424242424241"), card.ErrInvalidLuhn)
+	require.ErrorIs(t, card.CheckNumber("42424242424242"), card.ErrInvalidNumber, "not a visa length")
+	require.ErrorIs(t, card.CheckNumber("4242abcd42424242"), card.ErrInvalidNumber)
+	require.ErrorIs(t, card.CheckNumber(""), card.ErrInvalidNumber)
+}
+
+func TestLuhn(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, card.Luhn("79927398713"))
+	assert.False(t, card.Luhn("79927398710"))
+	assert.False(t, card.Luhn("7992-7398-713"))
+}
+
+func TestParseExpiry(t *testing.T) {
+	t.Parallel()
+
+	for _, exp := range []string{"13/26", "2026-10", ""} {
+		_, err := card.ParseExpiry(exp)
+		require.ErrorIs(t, err, card.ErrInvalidExpiry, exp)
+	}
+
+	expiresAt, err := card.ParseExpiry("12/26")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC), expiresAt)
+}
+
+func TestCheckCVV(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, card.CheckCVV(card.Visa, "123"))
+	require.NoError(t, card.CheckCVV(card.Amex, "1234"))
+	require.NoError(t, card.CheckCVV(card.Unknown, "1234"))
+	require.ErrorIs(t, card.CheckCVV(card.Visa, "1234"), card.ErrInvalidCVV)
+	require.ErrorIs(t, card.CheckCVV(card.Amex, "123"), card.ErrInvalidCVV)
+	require.ErrorIs(t, card.CheckCVV(card.Unknown, "12"), card.ErrInvalidCVV)
+	require.ErrorIs(t, card.CheckCVV(card.Visa, "12a"), card.ErrInvalidCVV)
+}
+
+func TestLast4(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "4242", card.Last4("4242 4242 4242 4242"))
+	assert.Empty(t, card.Last4("42"))
+}
@@ -0,0 +1,297 @@
+// Package dbbackup writes and reads the backup archives of the database.
+//
+// An archive is a gzip compressed stream of JSON lines. The first line is the header:
+//
+//	{"format": "passkeeper-backup", "version": 1, "schema_version": 14, "created_at": "2024-01-01T00:00:00Z"}
+//
+// Every following line is a row of a table, the row is a JSON object with the columns as its keys:
+//
+//	{"table": "accounts", "row": {"uuid": "...", "login": "john", ...}}
+//
+// The rows are followed by the blobs. A blob is split into the base64 encoded chunks,
+// the chunks of a blob follow each other, an empty blob is a single line without the data:
+//
+//	{"blob": "<key>", "data": "<base64>"}
+//
+// The last line is the trailer with the number of rows of each table, the number of the blobs
+// and the hex encoded SHA-256 checksum of all the uncompressed lines before it:
+//
+//	{"rows": {"accounts": 1}, "blobs": 1, "checksum": "<hex>"}
+//
+// The schema version is the version of the migrations of the database the rows are taken from.
+package dbbackup
+
+import (
+	"bufio"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"maps"
+	"time"
+)
+
+const (
+	Format  = "passkeeper-backup"
+	Version = 1
+
+	// ChunkSize is the maximum size of the data of a blob line, the lines are read into the memory.
+	ChunkSize = 1 << 20
+)
+
+var (
+	ErrInvalidArchive     = errors.New("invalid backup archive")
+	ErrUnsupportedVersion = errors.New("unsupported backup version")
+	ErrChecksumMismatch   = errors.New("backup checksum mismatch")
+)
+
+// Header describes the archive.
+type Header struct {
+	Format        string    `json:"format"`
+	Version       int       `json:"version"`
+	SchemaVersion uint      `json:"schema_version"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// Entry is a row of a table or a chunk of a blob.
+type Entry struct {
+	Table string
+	Row   json.RawMessage
+	// Blob is the key of the blob, whose chunk the data is.
+	Blob string
+	Data []byte
+}
+
+// line is a row, a chunk of a blob or the trailer of the archive.
+type line struct {
+	Table    string           `json:"table,omitempty"`
+	Row      json.RawMessage  `json:"row,omitempty"`
+	Blob     string           `json:"blob,omitempty"`
+	Data     []byte           `json:"data,omitempty"`
+	Rows     map[string]int64 `json:"rows,omitempty"`
+	Blobs    int64            `json:"blobs,omitempty"`
+	Checksum string           `json:"checksum,omitempty"`
+}
+
+// Writer writes an archive, it must be closed to write the trailer.
+type Writer struct {
+	gz    *gzip.Writer
+	hash  hash.Hash
+	rows  map[string]int64
+	blobs int64
+}
+
+// NewWriter writes the header of the archive of the schema version to w.
+func NewWriter(w io.Writer, schemaVersion uint) (*Writer, error) {
+	writer := &Writer{gz: gzip.NewWriter(w), hash: sha256.New(), rows: make(map[string]int64)}
+
+	header := &Header{Format: Format, Version: Version, SchemaVersion: schemaVersion, CreatedAt: time.Now().UTC()}
+	if err := writer.write(header, true); err != nil {
+		return nil, err
+	}
+
+	return writer, nil
+}
+
+// WriteRow writes the row of the table, the row is a JSON object.
+func (w *Writer) WriteRow(table string, row json.RawMessage) error {
+	if w.blobs > 0 {
+		return fmt.Errorf("%w: row of %s after the blobs", ErrInvalidArchive, table)
+	}
+
+	if err := w.write(&line{Table: table, Row: row}, true); err != nil {
+		return err
+	}
+
+	w.rows[table]++
+
+	return nil
+}
+
+// WriteBlob writes the blob read from r in chunks, the blobs are written after the rows.
+func (w *Writer) WriteBlob(key string, r io.Reader) error {
+	chunk := make([]byte, ChunkSize)
+
+	for first := true; ; first = false {
+		n, err := io.ReadFull(r, chunk)
+		if errors.Is(err, io.EOF) && !first {
+			break
+		}
+
+		if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return err
+		}
+
+		if err := w.write(&line{Blob: key, Data: chunk[:n]}, true); err != nil {
+			return err
+		}
+
+		if n < ChunkSize {
+			break
+		}
+	}
+
+	w.blobs++
+
+	return nil
+}
+
+// Rows returns the number of the written rows of each table.
+func (w *Writer) Rows() map[string]int64 {
+	return maps.Clone(w.rows)
+}
+
+// Close writes the trailer and flushes the archive, it does not close the underlying writer.
+func (w *Writer) Close() error {
+	trailer := &line{Rows: w.rows, Blobs: w.blobs, Checksum: hex.EncodeToString(w.hash.Sum(nil))}
+	if err := w.write(trailer, false); err != nil {
+		return err
+	}
+
+	return w.gz.Close()
+}
+
+func (w *Writer) write(v any, checksummed bool) error {
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	encoded = append(encoded, '\n')
+
+	if checksummed {
+		w.hash.Write(encoded)
+	}
+
+	_, err = w.gz.Write(encoded)
+
+	return err
+}
+
+// Reader reads an archive.
+type Reader struct {
+	header Header
+	r      *bufio.Reader
+	hash   hash.Hash
+	rows   map[string]int64
+	blobs  int64
+	// blob is the key of the last read blob
+	blob string
+	done bool
+}
+
+// NewReader reads the header of the archive and checks its format and version.
+func NewReader(r io.Reader) (*Reader, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidArchive, err)
+	}
+
+	reader := &Reader{r: bufio.NewReader(gz), hash: sha256.New(), rows: make(map[string]int64)}
+
+	data, err := reader.readLine()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &reader.header); err != nil || reader.header.Format != Format {
+		return nil, ErrInvalidArchive
+	}
+
+	if reader.header.Version != Version {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, reader.header.Version)
+	}
+
+	reader.hash.Write(data)
+
+	return reader, nil
+}
+
+// Header returns the header of the archive.
+func (r *Reader) Header() Header {
+	return r.header
+}
+
+// Next returns the next row or chunk of a blob.
+//
+// It returns io.EOF after the trailer, if the checksum and the numbers of the rows and the blobs match the read ones.
+// The entries must not be used before that, the archive may be corrupted or truncated.
+func (r *Reader) Next() (*Entry, error) {
+	if r.done {
+		return nil, io.EOF
+	}
+
+	data, err := r.readLine()
+	if err != nil {
+		return nil, err
+	}
+
+	var l line
+	if err := json.Unmarshal(data, &l); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidArchive, err)
+	}
+
+	if l.Table == "" && l.Blob == "" {
+		if l.Checksum != hex.EncodeToString(r.hash.Sum(nil)) || !maps.Equal(l.Rows, r.rows) || l.Blobs != r.blobs {
+			return nil, ErrChecksumMismatch
+		}
+
+		// the end of the stream checks the CRC of gzip, there must be nothing after the trailer
+		n, err := io.Copy(io.Discard, r.r)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidArchive, err)
+		}
+
+		if n != 0 {
+			return nil, fmt.Errorf("%w: data after the trailer", ErrInvalidArchive)
+		}
+
+		r.done = true
+
+		return nil, io.EOF
+	}
+
+	r.hash.Write(data)
+
+	if l.Blob != "" {
+		if l.Table != "" || len(l.Data) > ChunkSize {
+			return nil, fmt.Errorf("%w: invalid chunk of %s", ErrInvalidArchive, l.Blob)
+		}
+
+		if l.Blob != r.blob {
+			r.blob = l.Blob
+			r.blobs++
+		}
+
+		return &Entry{Blob: l.Blob, Data: l.Data}, nil
+	}
+
+	if len(l.Row) == 0 {
+		return nil, fmt.Errorf("%w: no row of %s", ErrInvalidArchive, l.Table)
+	}
+
+	if r.blob != "" {
+		return nil, fmt.Errorf("%w: row of %s after the blobs", ErrInvalidArchive, l.Table)
+	}
+
+	r.rows[l.Table]++
+
+	return &Entry{Table: l.Table, Row: l.Row}, nil
+}
+
+func (r *Reader) readLine() ([]byte, error) {
+	data, err := r.r.ReadBytes('\n')
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%w: unexpected end of archive", ErrInvalidArchive)
+		}
+
+		return nil, fmt.Errorf("%w: %w", ErrInvalidArchive, err)
+	}
+
+	return data, nil
+}
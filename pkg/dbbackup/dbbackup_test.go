@@ -0,0 +1,292 @@
+package dbbackup_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/novoseltcev/passkeeper/pkg/dbbackup"
+)
+
+type testRow struct {
+	table string
+	row   json.RawMessage
+}
+
+var testRows = []testRow{
+	{"accounts", json.RawMessage(`{"uuid":"a","login":"john"}`)},
+	{"secrets", json.RawMessage(`{"uuid":"s1","data":"\\x0102"}`)},
+	{"secrets", json.RawMessage(`{"uuid":"s2","data":"\\x0304"}`)},
+}
+
+type testBlob struct {
+	key  string
+	data []byte
+}
+
+// testBlobs are an empty blob, a blob of a chunk and a blob of two chunks.
+var testBlobs = []testBlob{
+	{"empty", []byte{}},
+	{"small", []byte("content")},
+	{"large", bytes.Repeat([]byte{7}, dbbackup.ChunkSize+1)},
+}
+
+func writeArchive(t *testing.T) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	w, err := dbbackup.NewWriter(&buf, 14)
+	require.NoError(t, err)
+
+	for _, r := range testRows {
+		require.NoError(t, w.WriteRow(r.table, r.row))
+	}
+
+	for _, b := range testBlobs {
+		require.NoError(t, w.WriteBlob(b.key, bytes.NewReader(b.data)))
+	}
+
+	assert.Equal(t, map[string]int64{"accounts": 1, "secrets": 2}, w.Rows())
+	require.NoError(t, w.Close())
+
+	return buf.Bytes()
+}
+
+func readAll(data []byte) ([]testRow, []testBlob, error) {
+	r, err := dbbackup.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		rows  []testRow
+		blobs []testBlob
+	)
+
+	for {
+		entry, err := r.Next()
+		if errors.Is(err, io.EOF) {
+			return rows, blobs, nil
+		}
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		switch {
+		case entry.Table != "":
+			rows = append(rows, testRow{entry.Table, entry.Row})
+		case len(blobs) > 0 && blobs[len(blobs)-1].key == entry.Blob:
+			blobs[len(blobs)-1].data = append(blobs[len(blobs)-1].data, entry.Data...)
+		default:
+			blobs = append(blobs, testBlob{entry.Blob, append([]byte{}, entry.Data...)})
+		}
+	}
+}
+
+// gzipLines compresses the lines, the trailer with the checksum of the lines is added.
+func gzipLines(t *testing.T, lines ...string) []byte {
+	t.Helper()
+
+	hash := sha256.New()
+	for _, l := range lines {
+		hash.Write([]byte(l + "\n"))
+	}
+
+	var buf bytes.Buffer
+
+	w := gzip.NewWriter(&buf)
+	_, err := w.Write([]byte(strings.Join(lines, "\n") +
+		"\n" + `{"rows":{"accounts":1},"checksum":"` + hex.EncodeToString(hash.Sum(nil)) + `"}` + "\n"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	return buf.Bytes()
+}
+
+// rewrite decompresses the archive, modifies its lines and compresses them back.
+func rewrite(t *testing.T, data []byte, modify func(lines []string) []string) []byte {
+	t.Helper()
+
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	require.NoError(t, err)
+
+	plain, err := io.ReadAll(gz)
+	require.NoError(t, err)
+
+	lines := modify(strings.SplitAfter(string(plain), "\n"))
+
+	var buf bytes.Buffer
+
+	w := gzip.NewWriter(&buf)
+	_, err = w.Write([]byte(strings.Join(lines, "")))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	return buf.Bytes()
+}
+
+func TestWriterReader(t *testing.T) {
+	t.Parallel()
+
+	data := writeArchive(t)
+
+	r, err := dbbackup.NewReader(bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal(t, dbbackup.Format, r.Header().Format)
+	assert.Equal(t, dbbackup.Version, r.Header().Version)
+	assert.Equal(t, uint(14), r.Header().SchemaVersion)
+	assert.False(t, r.Header().CreatedAt.IsZero())
+
+	rows, blobs, err := readAll(data)
+	require.NoError(t, err)
+	assert.Equal(t, testRows, rows)
+	assert.Equal(t, testBlobs, blobs)
+}
+
+func TestWriter_RowAfterBlob(t *testing.T) {
+	t.Parallel()
+
+	w, err := dbbackup.NewWriter(io.Discard, 1)
+	require.NoError(t, err)
+	require.NoError(t, w.WriteBlob("key", strings.NewReader("data")))
+
+	require.ErrorIs(t, w.WriteRow("accounts", json.RawMessage(`{}`)), dbbackup.ErrInvalidArchive)
+}
+
+func TestWriterReader_Empty(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	w, err := dbbackup.NewWriter(&buf, 1)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	rows, blobs, err := readAll(buf.Bytes())
+	require.NoError(t, err)
+	assert.Empty(t, rows)
+	assert.Empty(t, blobs)
+}
+
+func TestReader_Fails(t *testing.T) {
+	t.Parallel()
+
+	data := writeArchive(t)
+
+	tests := []struct {
+		name string
+		data []byte
+		err  error
+	}{
+		{
+			name: "not compressed",
+			data: []byte(`{"format":"passkeeper-backup","version":1}`),
+			err:  dbbackup.ErrInvalidArchive,
+		},
+		{
+			name: "corrupted",
+			data: append(bytes.Clone(data[:len(data)-8]), 0, 0, 0, 0, 0, 0, 0, 0),
+			err:  dbbackup.ErrInvalidArchive,
+		},
+		{
+			name: "other format",
+			data: rewrite(t, data, func(lines []string) []string {
+				lines[0] = strings.Replace(lines[0], dbbackup.Format, "passkeeper-export", 1)
+
+				return lines
+			}),
+			err: dbbackup.ErrInvalidArchive,
+		},
+		{
+			name: "unsupported version",
+			data: rewrite(t, data, func(lines []string) []string {
+				lines[0] = strings.Replace(lines[0], `"version":1`, `"version":2`, 1)
+
+				return lines
+			}),
+			err: dbbackup.ErrUnsupportedVersion,
+		},
+		{
+			name: "modified row",
+			data: rewrite(t, data, func(lines []string) []string {
+				lines[2] = strings.Replace(lines[2], "0102", "0103", 1)
+
+				return lines
+			}),
+			err: dbbackup.ErrChecksumMismatch,
+		},
+		{
+			name: "removed row",
+			data: rewrite(t, data, func(lines []string) []string {
+				return append(lines[:2], lines[3:]...)
+			}),
+			err: dbbackup.ErrChecksumMismatch,
+		},
+		{
+			name: "modified blob",
+			data: rewrite(t, data, func(lines []string) []string {
+				lines[5] = strings.Replace(lines[5], `"small"`, `"smalL"`, 1)
+
+				return lines
+			}),
+			err: dbbackup.ErrChecksumMismatch,
+		},
+		{
+			name: "removed blob",
+			data: rewrite(t, data, func(lines []string) []string {
+				return append(lines[:4], lines[5:]...)
+			}),
+			err: dbbackup.ErrChecksumMismatch,
+		},
+		{
+			name: "removed chunk",
+			data: rewrite(t, data, func(lines []string) []string {
+				return append(lines[:7], lines[8:]...)
+			}),
+			err: dbbackup.ErrChecksumMismatch,
+		},
+		{
+			name: "row after blob",
+			data: rewrite(t, data, func(lines []string) []string {
+				return append([]string{lines[0], lines[4], lines[1]}, lines[2:]...)
+			}),
+			err: dbbackup.ErrInvalidArchive,
+		},
+		{
+			name: "chunk too large",
+			data: gzipLines(t,
+				`{"format":"passkeeper-backup","version":1,"schema_version":14,"created_at":"2024-01-01T00:00:00Z"}`,
+				`{"blob":"key","data":"`+base64.StdEncoding.EncodeToString(make([]byte, dbbackup.ChunkSize+1))+`"}`,
+			),
+			err: dbbackup.ErrInvalidArchive,
+		},
+		{
+			name: "truncated",
+			data: rewrite(t, data, func(lines []string) []string {
+				return lines[:len(lines)-2]
+			}),
+			err: dbbackup.ErrInvalidArchive,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, _, err := readAll(tt.data)
+			require.ErrorIs(t, err, tt.err)
+		})
+	}
+}
@@ -0,0 +1,324 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file of golang.org/x/crypto.
+
+package kdbx
+
+// The Argon2 implementation of golang.org/x/crypto/argon2 with the Argon2d mode and the secret key,
+// which KeePass uses, but the package doesn't expose.
+
+import (
+	"encoding/binary"
+	"hash"
+	"math/bits"
+	"sync"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+const argon2Version = 0x13
+
+const (
+	argon2d = iota
+	argon2i
+	argon2id
+)
+
+const (
+	blockLength = 128
+	syncPoints  = 4
+)
+
+type block [blockLength]uint64
+
+func argon2Key(
+	mode int, password, salt, secret, data []byte, time, memory uint32, threads uint8, keyLen uint32,
+) []byte {
+	h0 := initHash(password, salt, secret, data, time, memory, uint32(threads), keyLen, mode)
+
+	memory = memory / (syncPoints * uint32(threads)) * (syncPoints * uint32(threads))
+	if memory < 2*syncPoints*uint32(threads) {
+		memory = 2 * syncPoints * uint32(threads)
+	}
+
+	B := initBlocks(&h0, memory, uint32(threads))
+	processBlocks(B, time, memory, uint32(threads), mode)
+
+	return extractKey(B, memory, uint32(threads), keyLen)
+}
+
+func initHash(password, salt, key, data []byte, time, memory, threads, keyLen uint32, mode int) [blake2b.Size + 8]byte {
+	var (
+		h0     [blake2b.Size + 8]byte
+		params [24]byte
+		tmp    [4]byte
+	)
+
+	b2, _ := blake2b.New512(nil)
+	binary.LittleEndian.PutUint32(params[0:4], threads)
+	binary.LittleEndian.PutUint32(params[4:8], keyLen)
+	binary.LittleEndian.PutUint32(params[8:12], memory)
+	binary.LittleEndian.PutUint32(params[12:16], time)
+	binary.LittleEndian.PutUint32(params[16:20], uint32(argon2Version))
+	binary.LittleEndian.PutUint32(params[20:24], uint32(mode))
+	b2.Write(params[:])
+
+	for _, value := range [][]byte{password, salt, key, data} {
+		binary.LittleEndian.PutUint32(tmp[:], uint32(len(value)))
+		b2.Write(tmp[:])
+		b2.Write(value)
+	}
+
+	b2.Sum(h0[:0])
+
+	return h0
+}
+
+func initBlocks(h0 *[blake2b.Size + 8]byte, memory, threads uint32) []block {
+	var block0 [1024]byte
+
+	B := make([]block, memory)
+	for lane := uint32(0); lane < threads; lane++ {
+		j := lane * (memory / threads)
+		binary.LittleEndian.PutUint32(h0[blake2b.Size+4:], lane)
+
+		for k := uint32(0); k < 2; k++ {
+			binary.LittleEndian.PutUint32(h0[blake2b.Size:], k)
+			blake2bHash(block0[:], h0[:])
+
+			for i := range B[j+k] {
+				B[j+k][i] = binary.LittleEndian.Uint64(block0[i*8:])
+			}
+		}
+	}
+
+	return B
+}
+
+func processBlocks(B []block, time, memory, threads uint32, mode int) {
+	lanes := memory / threads
+	segments := lanes / syncPoints
+
+	processSegment := func(n, slice, lane uint32, wg *sync.WaitGroup) {
+		defer wg.Done()
+
+		var addresses, in, zero block
+
+		independent := mode == argon2i || (mode == argon2id && n == 0 && slice < syncPoints/2)
+		if independent {
+			in[0] = uint64(n)
+			in[1] = uint64(lane)
+			in[2] = uint64(slice)
+			in[3] = uint64(memory)
+			in[4] = uint64(time)
+			in[5] = uint64(mode)
+		}
+
+		index := uint32(0)
+		if n == 0 && slice == 0 {
+			index = 2 // the first two blocks are already generated
+			if independent {
+				in[6]++
+				processBlock(&addresses, &in, &zero, false)
+				processBlock(&addresses, &addresses, &zero, false)
+			}
+		}
+
+		offset := lane*lanes + slice*segments + index
+
+		var random uint64
+		for index < segments {
+			prev := offset - 1
+			if index == 0 && slice == 0 {
+				prev += lanes // the last block of the lane
+			}
+
+			if independent {
+				if index%blockLength == 0 {
+					in[6]++
+					processBlock(&addresses, &in, &zero, false)
+					processBlock(&addresses, &addresses, &zero, false)
+				}
+
+				random = addresses[index%blockLength]
+			} else {
+				random = B[prev][0]
+			}
+
+			newOffset := indexAlpha(random, lanes, segments, threads, n, slice, lane, index)
+			processBlock(&B[offset], &B[prev], &B[newOffset], true)
+			index, offset = index+1, offset+1
+		}
+	}
+
+	for n := uint32(0); n < time; n++ {
+		for slice := uint32(0); slice < syncPoints; slice++ {
+			var wg sync.WaitGroup
+
+			for lane := uint32(0); lane < threads; lane++ {
+				wg.Add(1)
+
+				go processSegment(n, slice, lane, &wg)
+			}
+
+			wg.Wait()
+		}
+	}
+}
+
+func extractKey(B []block, memory, threads, keyLen uint32) []byte {
+	lanes := memory / threads
+	for lane := uint32(0); lane < threads-1; lane++ {
+		for i, v := range B[(lane*lanes)+lanes-1] {
+			B[memory-1][i] ^= v
+		}
+	}
+
+	var last [1024]byte
+	for i, v := range B[memory-1] {
+		binary.LittleEndian.PutUint64(last[i*8:], v)
+	}
+
+	key := make([]byte, keyLen)
+	blake2bHash(key, last[:])
+
+	return key
+}
+
+func indexAlpha(rand uint64, lanes, segments, threads, n, slice, lane, index uint32) uint32 {
+	refLane := uint32(rand>>32) % threads
+	if n == 0 && slice == 0 {
+		refLane = lane
+	}
+
+	m, s := 3*segments, ((slice+1)%syncPoints)*segments
+	if lane == refLane {
+		m += index
+	}
+
+	if n == 0 {
+		m, s = slice*segments, 0
+		if slice == 0 || lane == refLane {
+			m += index
+		}
+	}
+
+	if index == 0 || lane == refLane {
+		m--
+	}
+
+	return phi(rand, uint64(m), uint64(s), refLane, lanes)
+}
+
+func phi(rand, m, s uint64, lane, lanes uint32) uint32 {
+	p := rand & 0xFFFFFFFF
+	p = (p * p) >> 32
+	p = (p * m) >> 32
+
+	return lane*lanes + uint32((s+m-(p+1))%uint64(lanes))
+}
+
+// blake2bHash computes an arbitrary long hash value of in and writes the hash to out.
+func blake2bHash(out []byte, in []byte) {
+	var b2 hash.Hash
+	if n := len(out); n < blake2b.Size {
+		b2, _ = blake2b.New(n, nil)
+	} else {
+		b2, _ = blake2b.New512(nil)
+	}
+
+	var buffer [blake2b.Size]byte
+	binary.LittleEndian.PutUint32(buffer[:4], uint32(len(out)))
+	b2.Write(buffer[:4])
+	b2.Write(in)
+
+	if len(out) <= blake2b.Size {
+		b2.Sum(out[:0])
+
+		return
+	}
+
+	outLen := len(out)
+	b2.Sum(buffer[:0])
+	b2.Reset()
+	copy(out, buffer[:32])
+	out = out[32:]
+
+	for len(out) > blake2b.Size {
+		b2.Write(buffer[:])
+		b2.Sum(buffer[:0])
+		copy(out, buffer[:32])
+		out = out[32:]
+		b2.Reset()
+	}
+
+	if outLen%blake2b.Size > 0 {
+		r := ((outLen + 31) / 32) - 2
+		b2, _ = blake2b.New(outLen-32*r, nil)
+	}
+
+	b2.Write(buffer[:])
+	b2.Sum(out[:0])
+}
+
+// processBlock is the compression function G of the blocks, it XORs the result to out if xor is set.
+func processBlock(out, in1, in2 *block, xor bool) {
+	var t block
+	for i := range t {
+		t[i] = in1[i] ^ in2[i]
+	}
+
+	var idx [16]int
+
+	for i := 0; i < blockLength; i += 16 {
+		for k := range idx {
+			idx[k] = i + k
+		}
+
+		blamka(&t, &idx)
+	}
+
+	for i := 0; i < blockLength/8; i += 2 {
+		for k := range idx {
+			idx[k] = 16*(k/2) + i + k%2
+		}
+
+		blamka(&t, &idx)
+	}
+
+	for i := range t {
+		if xor {
+			out[i] ^= in1[i] ^ in2[i] ^ t[i]
+		} else {
+			out[i] = in1[i] ^ in2[i] ^ t[i]
+		}
+	}
+}
+
+// blamka is the BlaMka round of the 16 words of t at the indexes.
+func blamka(t *block, idx *[16]int) {
+	mix := func(a, b, c, d int) {
+		a, b, c, d = idx[a], idx[b], idx[c], idx[d]
+		t[a] = fBlaMka(t[a], t[b])
+		t[d] = bits.RotateLeft64(t[d]^t[a], -32)
+		t[c] = fBlaMka(t[c], t[d])
+		t[b] = bits.RotateLeft64(t[b]^t[c], -24)
+		t[a] = fBlaMka(t[a], t[b])
+		t[d] = bits.RotateLeft64(t[d]^t[a], -16)
+		t[c] = fBlaMka(t[c], t[d])
+		t[b] = bits.RotateLeft64(t[b]^t[c], -63)
+	}
+
+	mix(0, 4, 8, 12)
+	mix(1, 5, 9, 13)
+	mix(2, 6, 10, 14)
+	mix(3, 7, 11, 15)
+	mix(0, 5, 10, 15)
+	mix(1, 6, 11, 12)
+	mix(2, 7, 8, 13)
+	mix(3, 4, 9, 14)
+}
+
+func fBlaMka(x, y uint64) uint64 {
+	return x + y + 2*uint64(uint32(x))*uint64(uint32(y))
+}
@@ -0,0 +1,64 @@
+package kdbx_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/argon2"
+
+	"github.com/novoseltcev/passkeeper/pkg/kdbx"
+)
+
+// The test vectors of RFC 9106 with the secret key and the associated data, which KeePass may set.
+func TestArgon2Key(t *testing.T) {
+	t.Parallel()
+
+	var (
+		password = bytes.Repeat([]byte{1}, 32)
+		salt     = bytes.Repeat([]byte{2}, 16)
+		secret   = bytes.Repeat([]byte{3}, 8)
+		data     = bytes.Repeat([]byte{4}, 12)
+	)
+
+	tests := []struct {
+		name string
+		mode int
+		want string
+	}{
+		{name: "argon2d", mode: kdbx.Argon2d, want: "512b391b6f1162975371d30919734294f868e3be3984f3c1a13a4db9fabe4acb"},
+		{name: "argon2id", mode: kdbx.Argon2id, want: "0d640df58d78766c08c037a34a8b53c9d01ef0452d75b65eb52520e96b01e659"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			key := kdbx.Argon2Key(tt.mode, password, salt, secret, data, 3, 32, 4, 32)
+			assert.Equal(t, tt.want, hex.EncodeToString(key))
+		})
+	}
+}
+
+// Argon2id without the secret key and the associated data is the one of golang.org/x/crypto.
+func TestArgon2Key_Argon2id(t *testing.T) {
+	t.Parallel()
+
+	password, salt := []byte("master-password"), bytes.Repeat([]byte{5}, 32)
+
+	for _, params := range []struct {
+		time, memory uint32
+		threads      uint8
+	}{
+		{time: 1, memory: 64, threads: 1},
+		{time: 2, memory: 1024, threads: 2},
+		{time: 3, memory: 100, threads: 4},
+	} {
+		assert.Equal(t,
+			argon2.IDKey(password, salt, params.time, params.memory, params.threads, 32),
+			kdbx.Argon2Key(kdbx.Argon2id, password, salt, nil, nil, params.time, params.memory, params.threads, 32),
+			params,
+		)
+	}
+}
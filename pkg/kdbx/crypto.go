@@ -0,0 +1,426 @@
+package kdbx
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"golang.org/x/crypto/chacha20"
+	"golang.org/x/crypto/salsa20"
+	"golang.org/x/crypto/twofish"
+)
+
+const keySize = 32
+
+var (
+	cipherAES      = []byte{0x31, 0xc1, 0xf2, 0xe6, 0xbf, 0x71, 0x43, 0x50, 0xbe, 0x58, 0x05, 0x21, 0x6a, 0xfc, 0x5a, 0xff}
+	cipherChaCha20 = []byte{0xd6, 0x03, 0x8a, 0x2b, 0x8b, 0x6f, 0x4c, 0xb5, 0xa5, 0x24, 0x33, 0x9a, 0x31, 0xdb, 0xb5, 0x9a}
+	cipherTwofish  = []byte{0xad, 0x68, 0xf2, 0x9f, 0x57, 0x6f, 0x4b, 0xb9, 0xa3, 0x6a, 0xd4, 0x7a, 0xf9, 0x65, 0x34, 0x6c}
+
+	kdfAES      = []byte{0xc9, 0xd9, 0xf3, 0x9a, 0x62, 0x8a, 0x44, 0x60, 0xbf, 0x74, 0x0d, 0x08, 0xc1, 0x8a, 0x4f, 0xea}
+	kdfArgon2d  = []byte{0xef, 0x63, 0x6d, 0xdf, 0x8c, 0x29, 0x44, 0x4b, 0x91, 0xf7, 0xa9, 0xa4, 0x03, 0xe3, 0x0a, 0x0c}
+	kdfArgon2id = []byte{0x9e, 0x29, 0x8b, 0x19, 0x56, 0xdb, 0x47, 0x73, 0xb2, 0x3d, 0xfc, 0x3e, 0xc6, 0xf0, 0xa1, 0xe6}
+
+	salsa20Nonce = []byte{0xe8, 0x30, 0x09, 0x4b, 0x97, 0x20, 0x5d, 0x2a}
+)
+
+// The inner random streams, which encrypt the protected values.
+const (
+	streamSalsa20  = 2
+	streamChaCha20 = 3
+)
+
+// maxArgon2Memory keeps the databases from exhausting the memory of the one who opens them.
+const maxArgon2Memory = 4 << 30
+
+// compositeKey is the hash of the hashes of the password and the key file.
+func (c *Credentials) compositeKey() ([]byte, error) {
+	composite := sha256.New()
+
+	if c.Password != "" || c.KeyFile == nil {
+		sum := sha256.Sum256([]byte(c.Password))
+		composite.Write(sum[:])
+	}
+
+	if c.KeyFile != nil {
+		key, err := parseKeyFile(c.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+
+		composite.Write(key)
+	}
+
+	return composite.Sum(nil), nil
+}
+
+type keyFileXML struct {
+	Version string `xml:"Meta>Version"`
+	Data    struct {
+		Hash  string `xml:"Hash,attr"`
+		Value string `xml:",chardata"`
+	} `xml:"Key>Data"`
+}
+
+// parseKeyFile returns the key of the key file.
+//
+// The XML key files of the version 1 have the base64 encoded key and of the version 2 the hex encoded key
+// with the first 4 bytes of its hash. The 32 bytes and the 64 hex chars files are the key as is,
+// the key of any other file is its hash.
+func parseKeyFile(data []byte) ([]byte, error) {
+	var keyFile keyFileXML
+	if err := xml.Unmarshal(data, &keyFile); err == nil && keyFile.Data.Value != "" {
+		value := strings.Join(strings.Fields(keyFile.Data.Value), "")
+
+		if strings.HasPrefix(keyFile.Version, "1.") {
+			key, err := base64.StdEncoding.DecodeString(value)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %w", ErrInvalidKeyFile, err)
+			}
+
+			return key, nil
+		}
+
+		key, err := hex.DecodeString(value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidKeyFile, err)
+		}
+
+		sum := sha256.Sum256(key)
+		if !strings.EqualFold(keyFile.Data.Hash, hex.EncodeToString(sum[:4])) {
+			return nil, fmt.Errorf("%w: hash mismatch", ErrInvalidKeyFile)
+		}
+
+		return key, nil
+	}
+
+	switch len(data) {
+	case keySize:
+		return data, nil
+	case 2 * keySize:
+		if key, err := hex.DecodeString(string(data)); err == nil {
+			return key, nil
+		}
+	}
+
+	sum := sha256.Sum256(data)
+
+	return sum[:], nil
+}
+
+// transformKey derives the key from the composite key with the KDF of the header.
+func (h *header) transformKey(composite []byte) ([]byte, error) {
+	if h.major == majorVersion3 {
+		return aesKDF(composite, h.transformSeed, h.rounds)
+	}
+
+	id, _ := h.kdf["$UUID"].([]byte)
+	salt, _ := h.kdf["S"].([]byte)
+
+	switch {
+	case bytes.Equal(id, kdfAES):
+		rounds, _ := h.kdf["R"].(uint64)
+
+		return aesKDF(composite, salt, rounds)
+	case bytes.Equal(id, kdfArgon2d), bytes.Equal(id, kdfArgon2id):
+		iterations, _ := h.kdf["I"].(uint64)
+		memory, _ := h.kdf["M"].(uint64)
+		parallelism, _ := h.kdf["P"].(uint64)
+		version, _ := h.kdf["V"].(uint64)
+		secret, _ := h.kdf["K"].([]byte)
+		data, _ := h.kdf["A"].([]byte)
+
+		if version != argon2Version || iterations == 0 || iterations > math.MaxUint32 ||
+			memory < 1024 || memory > maxArgon2Memory || parallelism == 0 || parallelism > math.MaxUint8 {
+			return nil, fmt.Errorf("%w: Argon2 parameters", ErrUnsupported)
+		}
+
+		mode := argon2d
+		if bytes.Equal(id, kdfArgon2id) {
+			mode = argon2id
+		}
+
+		return argon2Key(
+			mode, composite, salt, secret, data, uint32(iterations), uint32(memory/1024), uint8(parallelism), keySize,
+		), nil
+	default:
+		return nil, fmt.Errorf("%w: KDF %x", ErrUnsupported, id)
+	}
+}
+
+// aesKDF encrypts the key with AES-256 in ECB mode the number of rounds and returns its hash.
+func aesKDF(key, seed []byte, rounds uint64) ([]byte, error) {
+	block, err := aes.NewCipher(seed)
+	if err != nil {
+		return nil, fmt.Errorf("%w: AES-KDF seed", ErrInvalidFile)
+	}
+
+	transformed := bytes.Clone(key)
+	for range rounds {
+		block.Encrypt(transformed[:aes.BlockSize], transformed[:aes.BlockSize])
+		block.Encrypt(transformed[aes.BlockSize:], transformed[aes.BlockSize:])
+	}
+
+	sum := sha256.Sum256(transformed)
+
+	return sum[:], nil
+}
+
+// readPayload3 reads the KDBX 3.1 payload, the hashed blocks of the document encrypted after the header.
+func (h *header) readPayload3(r io.Reader, transformed []byte) ([]byte, error) {
+	encrypted, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := h.decrypt(sha256.Sum256(append(bytes.Clone(h.masterSeed), transformed...)), encrypted)
+	if err != nil || len(payload) < len(h.startBytes) || !bytes.Equal(payload[:len(h.startBytes)], h.startBytes) {
+		return nil, ErrInvalidCredentials
+	}
+
+	var (
+		blocks = bytes.NewReader(payload[len(h.startBytes):])
+		buf    bytes.Buffer
+	)
+
+	for {
+		var prefix struct {
+			Index uint32
+			Hash  [sha256.Size]byte
+			Size  uint32
+		}
+
+		if err := binary.Read(blocks, binary.LittleEndian, &prefix); err != nil {
+			return nil, ErrCorrupted
+		}
+
+		if prefix.Size == 0 {
+			break
+		}
+
+		data, err := readN(blocks, prefix.Size)
+		if err != nil || sha256.Sum256(data) != prefix.Hash {
+			return nil, ErrCorrupted
+		}
+
+		buf.Write(data)
+	}
+
+	return h.decompress(buf.Bytes())
+}
+
+// readPayload4 reads the KDBX 4 payload, the HMAC blocks of the inner header and the document.
+//
+// The header is followed by its hash and HMAC, the HMAC fails if the key is invalid.
+func (h *header) readPayload4(r io.Reader, rawHeader, transformed []byte) ([]byte, [][]byte, error) {
+	var check struct {
+		Hash, HMAC [sha256.Size]byte
+	}
+
+	if err := binary.Read(r, binary.LittleEndian, &check); err != nil || sha256.Sum256(rawHeader) != check.Hash {
+		return nil, nil, ErrCorrupted
+	}
+
+	hmacKey := sha512.Sum512(append(append(bytes.Clone(h.masterSeed), transformed...), 1))
+
+	mac := hmac.New(sha256.New, blockKey(hmacKey[:], math.MaxUint64))
+	mac.Write(rawHeader)
+
+	if !hmac.Equal(mac.Sum(nil), check.HMAC[:]) {
+		return nil, nil, ErrInvalidCredentials
+	}
+
+	var encrypted bytes.Buffer
+
+	for index := uint64(0); ; index++ {
+		var prefix struct {
+			HMAC [sha256.Size]byte
+			Size uint32
+		}
+
+		if err := binary.Read(r, binary.LittleEndian, &prefix); err != nil {
+			return nil, nil, ErrCorrupted
+		}
+
+		data, err := readN(r, prefix.Size)
+		if err != nil {
+			return nil, nil, ErrCorrupted
+		}
+
+		mac := hmac.New(sha256.New, blockKey(hmacKey[:], index))
+		mac.Write(binary.LittleEndian.AppendUint64(nil, index))
+		mac.Write(binary.LittleEndian.AppendUint32(nil, prefix.Size))
+		mac.Write(data)
+
+		if !hmac.Equal(mac.Sum(nil), prefix.HMAC[:]) {
+			return nil, nil, ErrCorrupted
+		}
+
+		if prefix.Size == 0 {
+			break
+		}
+
+		encrypted.Write(data)
+	}
+
+	payload, err := h.decrypt(sha256.Sum256(append(bytes.Clone(h.masterSeed), transformed...)), encrypted.Bytes())
+	if err != nil {
+		return nil, nil, ErrCorrupted
+	}
+
+	if payload, err = h.decompress(payload); err != nil {
+		return nil, nil, err
+	}
+
+	return h.readInnerHeader(bytes.NewReader(payload))
+}
+
+// blockKey is the HMAC key of the block with the index, the header is signed with the maximum index.
+func blockKey(hmacKey []byte, index uint64) []byte {
+	key := sha512.Sum512(append(binary.LittleEndian.AppendUint64(nil, index), hmacKey...))
+
+	return key[:]
+}
+
+// The fields of the KDBX 4 inner header.
+const (
+	innerEnd       = 0
+	innerStreamID  = 1
+	innerStreamKey = 2
+	innerBinary    = 3
+)
+
+// readInnerHeader reads the inner random stream and the attachments, the rest is the document.
+func (h *header) readInnerHeader(r *bytes.Reader) ([]byte, [][]byte, error) {
+	var binaries [][]byte
+
+	for {
+		id, value, err := h.readField(r)
+		if err != nil {
+			return nil, nil, ErrCorrupted
+		}
+
+		switch id {
+		case innerEnd:
+			content, _ := io.ReadAll(r)
+
+			return content, binaries, nil
+		case innerStreamID:
+			if len(value) == 4 { // nolint: mnd
+				h.streamID = binary.LittleEndian.Uint32(value)
+			}
+		case innerStreamKey:
+			h.streamKey = value
+		case innerBinary:
+			if len(value) == 0 {
+				return nil, nil, ErrCorrupted
+			}
+
+			// the first byte are the flags of the memory protection
+			binaries = append(binaries, value[1:])
+		}
+	}
+}
+
+// decrypt decrypts the payload with the cipher of the header.
+func (h *header) decrypt(key [keySize]byte, data []byte) ([]byte, error) {
+	switch {
+	case bytes.Equal(h.cipherID, cipherAES):
+		block, _ := aes.NewCipher(key[:])
+
+		return decryptCBC(block, h.iv, data)
+	case bytes.Equal(h.cipherID, cipherTwofish):
+		block, _ := twofish.NewCipher(key[:])
+
+		return decryptCBC(block, h.iv, data)
+	case bytes.Equal(h.cipherID, cipherChaCha20):
+		stream, err := chacha20.NewUnauthenticatedCipher(key[:], h.iv)
+		if err != nil {
+			return nil, fmt.Errorf("%w: ChaCha20 nonce", ErrInvalidFile)
+		}
+
+		plain := make([]byte, len(data))
+		stream.XORKeyStream(plain, data)
+
+		return plain, nil
+	default:
+		return nil, fmt.Errorf("%w: cipher %x", ErrUnsupported, h.cipherID)
+	}
+}
+
+// decryptCBC decrypts the data in the CBC mode and removes the PKCS #7 padding.
+func decryptCBC(block cipher.Block, iv, data []byte) ([]byte, error) {
+	size := block.BlockSize()
+	if len(iv) != size || len(data) == 0 || len(data)%size != 0 {
+		return nil, ErrCorrupted
+	}
+
+	plain := make([]byte, len(data))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(plain, data)
+
+	padding := int(plain[len(plain)-1])
+	if padding == 0 || padding > size || !bytes.Equal(
+		plain[len(plain)-padding:], bytes.Repeat([]byte{byte(padding)}, padding),
+	) {
+		return nil, ErrCorrupted
+	}
+
+	return plain[:len(plain)-padding], nil
+}
+
+func (h *header) decompress(data []byte) ([]byte, error) {
+	if !h.compressed {
+		return data, nil
+	}
+
+	return gunzip(data)
+}
+
+func gunzip(data []byte) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCorrupted, err)
+	}
+	defer r.Close()
+
+	plain, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCorrupted, err)
+	}
+
+	return plain, nil
+}
+
+// xorStream XORs the protected values in the order of the document with the inner random stream.
+func (h *header) xorStream(data []byte) error {
+	switch h.streamID {
+	case streamSalsa20:
+		key := sha256.Sum256(h.streamKey)
+		salsa20.XORKeyStream(data, data, salsa20Nonce, &key)
+	case streamChaCha20:
+		hash := sha512.Sum512(h.streamKey)
+
+		stream, err := chacha20.NewUnauthenticatedCipher(hash[:keySize], hash[keySize:keySize+chacha20.NonceSize])
+		if err != nil {
+			return err
+		}
+
+		stream.XORKeyStream(data, data)
+	default:
+		return fmt.Errorf("%w: inner stream %d", ErrUnsupported, h.streamID)
+	}
+
+	return nil
+}
@@ -0,0 +1,252 @@
+package kdbx
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+)
+
+// node is an element of the XML document.
+type node struct {
+	XMLName xml.Name
+	Attrs   []xml.Attr `xml:",any,attr"`
+	Content string     `xml:",chardata"`
+	Nodes   []*node    `xml:",any"`
+
+	// plain is the decrypted content of a protected value
+	plain []byte
+}
+
+func (n *node) child(name string) *node {
+	for _, child := range n.Nodes {
+		if child.XMLName.Local == name {
+			return child
+		}
+	}
+
+	return &node{}
+}
+
+func (n *node) attr(name string) string {
+	for _, attr := range n.Attrs {
+		if attr.Name.Local == name {
+			return attr.Value
+		}
+	}
+
+	return ""
+}
+
+func (n *node) protected() bool {
+	return n.attr("Protected") == "True"
+}
+
+// text returns the string value, the protected value is decrypted.
+func (n *node) text() string {
+	if n.protected() {
+		return string(n.plain)
+	}
+
+	return n.Content
+}
+
+// data returns the base64 encoded binary value, the protected value is decrypted.
+func (n *node) data() ([]byte, error) {
+	if n.protected() {
+		return n.plain, nil
+	}
+
+	return base64.StdEncoding.DecodeString(n.Content)
+}
+
+// walk calls fn for the node and its descendants in the order of the document.
+func (n *node) walk(fn func(*node) error) error {
+	if err := fn(n); err != nil {
+		return err
+	}
+
+	for _, child := range n.Nodes {
+		if err := child.walk(fn); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// parseDocument parses the XML document and decrypts its protected values.
+//
+// The binaries are the attachments of the KDBX 4 inner header, KDBX 3.1 stores them in the metadata.
+func (h *header) parseDocument(content []byte, binaries [][]byte) (*Database, error) {
+	var doc node
+	if err := xml.Unmarshal(content, &doc); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCorrupted, err)
+	}
+
+	if err := h.unprotect(&doc); err != nil {
+		return nil, err
+	}
+
+	meta := doc.child("Meta")
+
+	if h.major == majorVersion3 {
+		var err error
+		if binaries, err = readMetaBinaries(meta.child("Binaries")); err != nil {
+			return nil, err
+		}
+	}
+
+	p := parser{binaries: binaries, recycleBin: meta.child("RecycleBinUUID").Content}
+
+	root := doc.child("Root").child("Group")
+
+	group, err := p.group(root)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Database{Name: meta.child("DatabaseName").Content, Root: group}, nil
+}
+
+// unprotect decrypts the protected values, the inner random stream encrypts them one after another.
+func (h *header) unprotect(doc *node) error {
+	var (
+		protected []*node
+		stream    []byte
+	)
+
+	if err := doc.walk(func(n *node) error {
+		if !n.protected() {
+			return nil
+		}
+
+		data, err := base64.StdEncoding.DecodeString(n.Content)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrCorrupted, err)
+		}
+
+		n.plain = data
+		protected = append(protected, n)
+		stream = append(stream, data...)
+
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if len(protected) == 0 {
+		return nil
+	}
+
+	if err := h.xorStream(stream); err != nil {
+		return err
+	}
+
+	for _, n := range protected {
+		n.plain, stream = stream[:len(n.plain)], stream[len(n.plain):]
+	}
+
+	return nil
+}
+
+// readMetaBinaries reads the KDBX 3.1 attachments, they are referenced by their IDs.
+func readMetaBinaries(binaries *node) ([][]byte, error) {
+	var result [][]byte
+
+	for _, binary := range binaries.Nodes {
+		id, err := strconv.Atoi(binary.attr("ID"))
+		if err != nil || id < 0 || id > len(binaries.Nodes) {
+			return nil, fmt.Errorf("%w: binary ID %q", ErrCorrupted, binary.attr("ID"))
+		}
+
+		data, err := binary.data()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrCorrupted, err)
+		}
+
+		if binary.attr("Compressed") == "True" {
+			if data, err = gunzip(data); err != nil {
+				return nil, err
+			}
+		}
+
+		if id >= len(result) {
+			result = append(result, make([][]byte, id+1-len(result))...)
+		}
+
+		result[id] = data
+	}
+
+	return result, nil
+}
+
+type parser struct {
+	binaries [][]byte
+	// recycleBin is the base64 encoded UUID of the recycle bin group
+	recycleBin string
+}
+
+func (p *parser) group(n *node) (*Group, error) {
+	uuid := n.child("UUID").Content
+
+	group := &Group{
+		Name:       n.child("Name").Content,
+		RecycleBin: uuid != "" && uuid == p.recycleBin,
+	}
+
+	for _, child := range n.Nodes {
+		switch child.XMLName.Local {
+		case "Group":
+			subgroup, err := p.group(child)
+			if err != nil {
+				return nil, err
+			}
+
+			group.Groups = append(group.Groups, subgroup)
+		case "Entry":
+			entry, err := p.entry(child)
+			if err != nil {
+				return nil, err
+			}
+
+			group.Entries = append(group.Entries, entry)
+		}
+	}
+
+	return group, nil
+}
+
+func (p *parser) entry(n *node) (*Entry, error) {
+	entry := &Entry{Fields: make(map[string]string)}
+
+	for _, child := range n.Nodes {
+		switch child.XMLName.Local {
+		case "String":
+			entry.Fields[child.child("Key").Content] = child.child("Value").text()
+		case "Binary":
+			value := child.child("Value")
+
+			var data []byte
+
+			if ref := value.attr("Ref"); ref != "" {
+				id, err := strconv.Atoi(ref)
+				if err != nil || id < 0 || id >= len(p.binaries) {
+					return nil, fmt.Errorf("%w: binary reference %q", ErrCorrupted, ref)
+				}
+
+				data = bytes.Clone(p.binaries[id])
+			} else {
+				var err error
+				if data, err = value.data(); err != nil {
+					return nil, fmt.Errorf("%w: %w", ErrCorrupted, err)
+				}
+			}
+
+			entry.Attachments = append(entry.Attachments, Attachment{Name: child.child("Key").Content, Data: data})
+		}
+	}
+
+	return entry, nil
+}
@@ -0,0 +1,9 @@
+package kdbx
+
+// Argon2Key exposes the Argon2 implementation, which is not covered by golang.org/x/crypto, to the tests.
+var Argon2Key = argon2Key
+
+const (
+	Argon2d  = argon2d
+	Argon2id = argon2id
+)
@@ -0,0 +1,333 @@
+// Package kdbx reads KeePass databases of the KDBX 3.1 and 4 formats.
+//
+// A database is a header with the parameters of the encryption followed by the encrypted XML document:
+//
+//   - the key is derived from the master password and the key file with AES-KDF, Argon2d or Argon2id;
+//   - the document is encrypted with AES-256, ChaCha20 or Twofish and optionally compressed with gzip;
+//   - the protected values of the document, such as passwords, are encrypted again with Salsa20 or ChaCha20.
+//
+// KDBX 3.1 splits the document into blocks with SHA-256 hashes and stores the attachments in the document,
+// KDBX 4 authenticates the header and the blocks with HMAC-SHA-256 and stores the attachments in the inner header.
+package kdbx
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// The standard fields of the entries.
+const (
+	FieldTitle    = "Title"
+	FieldUserName = "UserName"
+	FieldPassword = "Password"
+	FieldURL      = "URL"
+	FieldNotes    = "Notes"
+)
+
+const (
+	signature1 = 0x9AA2D903
+	signature2 = 0xB54BFB67
+
+	majorVersion3 = 3
+	majorVersion4 = 4
+)
+
+var (
+	ErrInvalidFile        = errors.New("not a KDBX file")
+	ErrUnsupportedVersion = errors.New("unsupported KDBX version")
+	ErrUnsupported        = errors.New("unsupported KDBX parameters")
+	ErrInvalidKeyFile     = errors.New("invalid key file")
+	ErrInvalidCredentials = errors.New("invalid master password or key file")
+	ErrCorrupted          = errors.New("corrupted KDBX file")
+)
+
+// Credentials are the composite master key of the database.
+type Credentials struct {
+	// Password is the master password, it is not a part of the key if empty and a key file is set.
+	Password string
+	// KeyFile is the content of the key file, it is nil if the database has no key file.
+	KeyFile []byte
+}
+
+// Database is the decrypted content of a database.
+type Database struct {
+	Name string
+	Root *Group
+}
+
+// Group is a folder of entries and other groups.
+type Group struct {
+	Name    string
+	Groups  []*Group
+	Entries []*Entry
+	// RecycleBin is set for the group of the deleted entries.
+	RecycleBin bool
+}
+
+// Entry is a record of the database, the history of the entry is not read.
+type Entry struct {
+	// Fields are the standard and the custom string fields by their names.
+	Fields      map[string]string
+	Attachments []Attachment
+}
+
+// Attachment is a file attached to an entry.
+type Attachment struct {
+	Name string
+	Data []byte
+}
+
+// Get returns the value of the field or an empty string.
+func (e *Entry) Get(field string) string {
+	return e.Fields[field]
+}
+
+// Open decrypts the database with the credentials.
+func Open(data []byte, creds *Credentials) (*Database, error) {
+	r := bytes.NewReader(data)
+
+	h, err := readHeader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	composite, err := creds.compositeKey()
+	if err != nil {
+		return nil, err
+	}
+
+	transformed, err := h.transformKey(composite)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		content  []byte
+		binaries [][]byte
+	)
+
+	if h.major == majorVersion3 {
+		content, err = h.readPayload3(r, transformed)
+	} else {
+		content, binaries, err = h.readPayload4(r, data[:len(data)-r.Len()], transformed)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return h.parseDocument(content, binaries)
+}
+
+// header contains the fields of the outer header, which are used to decrypt the database.
+type header struct {
+	major         uint16
+	cipherID      []byte
+	compressed    bool
+	masterSeed    []byte
+	transformSeed []byte
+	rounds        uint64
+	iv            []byte
+	kdf           map[string]any
+
+	// the inner random stream, KDBX 4 moves it to the inner header
+	streamID  uint32
+	streamKey []byte
+	// startBytes are the first bytes of the KDBX 3.1 payload to check the key
+	startBytes []byte
+}
+
+// The fields of the outer header.
+const (
+	headerEnd           = 0
+	headerCipherID      = 2
+	headerCompression   = 3
+	headerMasterSeed    = 4
+	headerTransformSeed = 5
+	headerRounds        = 6
+	headerIV            = 7
+	headerStreamKey     = 8
+	headerStartBytes    = 9
+	headerStreamID      = 10
+	headerKDF           = 11
+)
+
+func readHeader(r io.Reader) (*header, error) {
+	var prefix struct {
+		Signature1, Signature2 uint32
+		Minor, Major           uint16
+	}
+
+	if err := binary.Read(r, binary.LittleEndian, &prefix); err != nil ||
+		prefix.Signature1 != signature1 || prefix.Signature2 != signature2 {
+		return nil, ErrInvalidFile
+	}
+
+	if prefix.Major != majorVersion3 && prefix.Major != majorVersion4 {
+		return nil, fmt.Errorf("%w: %d.%d", ErrUnsupportedVersion, prefix.Major, prefix.Minor)
+	}
+
+	h := &header{major: prefix.Major}
+
+	for {
+		id, value, err := h.readField(r)
+		if err != nil {
+			return nil, err
+		}
+
+		switch id {
+		case headerEnd:
+			return h, h.check()
+		case headerCipherID:
+			h.cipherID = value
+		case headerCompression:
+			h.compressed = len(value) == 4 && binary.LittleEndian.Uint32(value) == 1
+		case headerMasterSeed:
+			h.masterSeed = value
+		case headerTransformSeed:
+			h.transformSeed = value
+		case headerRounds:
+			if len(value) == 8 { // nolint: mnd
+				h.rounds = binary.LittleEndian.Uint64(value)
+			}
+		case headerIV:
+			h.iv = value
+		case headerStreamKey:
+			h.streamKey = value
+		case headerStartBytes:
+			h.startBytes = value
+		case headerStreamID:
+			if len(value) == 4 { // nolint: mnd
+				h.streamID = binary.LittleEndian.Uint32(value)
+			}
+		case headerKDF:
+			if h.kdf, err = readVariantDictionary(value); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// readField reads a field of the header, KDBX 3.1 stores the size in 2 bytes and KDBX 4 in 4 bytes.
+func (h *header) readField(r io.Reader) (byte, []byte, error) {
+	var id [1]byte
+	if _, err := io.ReadFull(r, id[:]); err != nil {
+		return 0, nil, ErrInvalidFile
+	}
+
+	var size uint32
+
+	if h.major == majorVersion3 {
+		var size16 uint16
+		if err := binary.Read(r, binary.LittleEndian, &size16); err != nil {
+			return 0, nil, ErrInvalidFile
+		}
+
+		size = uint32(size16)
+	} else if err := binary.Read(r, binary.LittleEndian, &size); err != nil {
+		return 0, nil, ErrInvalidFile
+	}
+
+	value, err := readN(r, size)
+	if err != nil {
+		return 0, nil, ErrInvalidFile
+	}
+
+	return id[0], value, nil
+}
+
+func (h *header) check() error {
+	if len(h.masterSeed) != 32 || h.cipherID == nil || h.iv == nil { // nolint: mnd
+		return fmt.Errorf("%w: missing header fields", ErrInvalidFile)
+	}
+
+	if h.major == majorVersion3 && (h.transformSeed == nil || h.streamKey == nil || len(h.startBytes) != 32) {
+		return fmt.Errorf("%w: missing header fields", ErrInvalidFile)
+	}
+
+	if h.major == majorVersion4 && h.kdf == nil {
+		return fmt.Errorf("%w: missing KDF parameters", ErrInvalidFile)
+	}
+
+	return nil
+}
+
+// The types of the values of the variant dictionary.
+const (
+	variantUInt32 = 0x04
+	variantUInt64 = 0x05
+	variantBool   = 0x08
+	variantInt32  = 0x0C
+	variantInt64  = 0x0D
+	variantString = 0x18
+	variantBytes  = 0x42
+)
+
+// readVariantDictionary reads the typed key-value pairs of the KDF parameters.
+func readVariantDictionary(data []byte) (map[string]any, error) {
+	r := bytes.NewReader(data)
+
+	var version uint16
+	if err := binary.Read(r, binary.LittleEndian, &version); err != nil || version>>8 != 1 {
+		return nil, fmt.Errorf("%w: variant dictionary version", ErrUnsupported)
+	}
+
+	dict := make(map[string]any)
+
+	for {
+		var kind [1]byte
+		if _, err := io.ReadFull(r, kind[:]); err != nil {
+			return nil, ErrInvalidFile
+		}
+
+		if kind[0] == 0 {
+			return dict, nil
+		}
+
+		key, err := readSized(r)
+		if err != nil {
+			return nil, ErrInvalidFile
+		}
+
+		value, err := readSized(r)
+		if err != nil {
+			return nil, ErrInvalidFile
+		}
+
+		switch {
+		case (kind[0] == variantUInt32 || kind[0] == variantInt32) && len(value) == 4:
+			dict[string(key)] = uint64(binary.LittleEndian.Uint32(value))
+		case (kind[0] == variantUInt64 || kind[0] == variantInt64) && len(value) == 8:
+			dict[string(key)] = binary.LittleEndian.Uint64(value)
+		case kind[0] == variantBool && len(value) == 1:
+			dict[string(key)] = value[0] != 0
+		case kind[0] == variantString, kind[0] == variantBytes:
+			dict[string(key)] = value
+		default:
+			return nil, fmt.Errorf("%w: variant %x of %q", ErrInvalidFile, kind[0], key)
+		}
+	}
+}
+
+// readSized reads a value prefixed with its 4 bytes size.
+func readSized(r io.Reader) ([]byte, error) {
+	var size uint32
+	if err := binary.Read(r, binary.LittleEndian, &size); err != nil {
+		return nil, err
+	}
+
+	return readN(r, size)
+}
+
+// readN reads n bytes, it doesn't allocate more than the reader has.
+func readN(r io.Reader, n uint32) ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := io.CopyN(&buf, r, int64(n)); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,568 @@
+package kdbx_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/chacha20"
+	"golang.org/x/crypto/salsa20"
+	"golang.org/x/crypto/twofish"
+
+	"github.com/novoseltcev/passkeeper/pkg/kdbx"
+)
+
+const testPassword = "master-password"
+
+var (
+	cipherAES      = []byte{0x31, 0xc1, 0xf2, 0xe6, 0xbf, 0x71, 0x43, 0x50, 0xbe, 0x58, 0x05, 0x21, 0x6a, 0xfc, 0x5a, 0xff}
+	cipherChaCha20 = []byte{0xd6, 0x03, 0x8a, 0x2b, 0x8b, 0x6f, 0x4c, 0xb5, 0xa5, 0x24, 0x33, 0x9a, 0x31, 0xdb, 0xb5, 0x9a}
+	cipherTwofish  = []byte{0xad, 0x68, 0xf2, 0x9f, 0x57, 0x6f, 0x4b, 0xb9, 0xa3, 0x6a, 0xd4, 0x7a, 0xf9, 0x65, 0x34, 0x6c}
+
+	kdfAES      = []byte{0xc9, 0xd9, 0xf3, 0x9a, 0x62, 0x8a, 0x44, 0x60, 0xbf, 0x74, 0x0d, 0x08, 0xc1, 0x8a, 0x4f, 0xea}
+	kdfArgon2d  = []byte{0xef, 0x63, 0x6d, 0xdf, 0x8c, 0x29, 0x44, 0x4b, 0x91, 0xf7, 0xa9, 0xa4, 0x03, 0xe3, 0x0a, 0x0c}
+	kdfArgon2id = []byte{0x9e, 0x29, 0x8b, 0x19, 0x56, 0xdb, 0x47, 0x73, 0xb2, 0x3d, 0xfc, 0x3e, 0xc6, 0xf0, 0xa1, 0xe6}
+
+	testKeyFile = []byte(`<?xml version="1.0" encoding="utf-8"?>
+<KeyFile>
+	<Meta><Version>2.0</Version></Meta>
+	<Key>
+		<Data Hash="0239231A">
+			9D5E4F5B 3B7A0C2E 1F6D8A4C 5E2B7D9A
+			3C1E8F6A 7B4D2C9E 0A5F3B8D 6E1C4A7F
+		</Data>
+	</Key>
+</KeyFile>`)
+
+	testDatabase = &kdbx.Database{
+		Name: "Test",
+		Root: &kdbx.Group{
+			Name: "Root",
+			Entries: []*kdbx.Entry{{
+				Fields: map[string]string{
+					kdbx.FieldTitle:    "GitHub",
+					kdbx.FieldUserName: "john",
+					kdbx.FieldPassword: "p@ssw0rd",
+					kdbx.FieldURL:      "https://github.com",
+					kdbx.FieldNotes:    "work",
+					"PIN":              "1234",
+				},
+				Attachments: []kdbx.Attachment{{Name: "codes.txt", Data: []byte("recovery codes")}},
+			}},
+			Groups: []*kdbx.Group{
+				{
+					Name:    "Email",
+					Entries: []*kdbx.Entry{{Fields: map[string]string{kdbx.FieldTitle: "Mail", kdbx.FieldNotes: "note"}}},
+				},
+				{
+					Name:       "Recycle Bin",
+					RecycleBin: true,
+					Entries:    []*kdbx.Entry{{Fields: map[string]string{kdbx.FieldTitle: "Old", kdbx.FieldPassword: "old"}}},
+				},
+			},
+		},
+	}
+)
+
+// The tests derive keys with Argon2, which is heavy, so they don't run in parallel.
+
+func TestOpen(t *testing.T) {
+	tests := []struct {
+		name  string
+		file  *testFile
+		creds *kdbx.Credentials
+	}{
+		{
+			name:  "kdbx 4 argon2d chacha20",
+			file:  &testFile{major: 4, cipher: cipherChaCha20, kdf: kdfArgon2d, compressed: true},
+			creds: &kdbx.Credentials{Password: testPassword},
+		},
+		{
+			name:  "kdbx 4 argon2id twofish with key file only",
+			file:  &testFile{major: 4, cipher: cipherTwofish, kdf: kdfArgon2id},
+			creds: &kdbx.Credentials{KeyFile: []byte(hex.EncodeToString(bytes.Repeat([]byte{7}, 32)))},
+		},
+		{
+			name:  "kdbx 4 aes-kdf aes with key file",
+			file:  &testFile{major: 4, cipher: cipherAES, kdf: kdfAES, compressed: true},
+			creds: &kdbx.Credentials{Password: testPassword, KeyFile: testKeyFile},
+		},
+		{
+			name:  "kdbx 3.1 aes",
+			file:  &testFile{major: 3, cipher: cipherAES, compressed: true},
+			creds: &kdbx.Credentials{Password: testPassword},
+		},
+		{
+			name:  "kdbx 3.1 twofish with key file",
+			file:  &testFile{major: 3, cipher: cipherTwofish},
+			creds: &kdbx.Credentials{Password: testPassword, KeyFile: []byte("any content")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, err := kdbx.Open(tt.file.build(t, tt.creds), tt.creds)
+			require.NoError(t, err)
+			assert.Equal(t, testDatabase, db)
+		})
+	}
+}
+
+// kdbx4.kdbx is laid out like a KeePassXC database, it is written by testdata/gen with golang.org/x/crypto.
+func TestOpen_Fixture(t *testing.T) {
+	data, err := os.ReadFile("testdata/kdbx4.kdbx")
+	require.NoError(t, err)
+
+	db, err := kdbx.Open(data, &kdbx.Credentials{Password: "correct horse battery staple"})
+	require.NoError(t, err)
+
+	// the history of the entry, the empty values and the deleted objects are not read as entries
+	assert.Equal(t, &kdbx.Database{
+		Name: "Personal",
+		Root: &kdbx.Group{
+			Name: "Root",
+			Entries: []*kdbx.Entry{{
+				Fields: map[string]string{
+					kdbx.FieldNotes:    "work account",
+					kdbx.FieldPassword: "gh-s3cr3t!",
+					kdbx.FieldTitle:    "GitHub",
+					kdbx.FieldURL:      "https://github.com/login",
+					kdbx.FieldUserName: "jane",
+				},
+				Attachments: []kdbx.Attachment{{Name: "recovery.txt", Data: []byte("github recovery codes\n")}},
+			}},
+			Groups: []*kdbx.Group{
+				{
+					Name: "Finance",
+					Entries: []*kdbx.Entry{{
+						Fields: map[string]string{
+							kdbx.FieldNotes:    "card PIN and a contact",
+							"PIN":              "4321",
+							kdbx.FieldPassword: "",
+							kdbx.FieldTitle:    "Bank",
+							kdbx.FieldURL:      "",
+							kdbx.FieldUserName: "",
+						},
+						Attachments: []kdbx.Attachment{{
+							Name: "contact.vcf",
+							Data: []byte("BEGIN:VCARD\nFN:Jane Doe\nEND:VCARD\n"),
+						}},
+					}},
+				},
+				{
+					Name:       "Recycle Bin",
+					RecycleBin: true,
+					Entries: []*kdbx.Entry{{
+						Fields: map[string]string{
+							kdbx.FieldNotes:    "",
+							kdbx.FieldPassword: "gone",
+							kdbx.FieldTitle:    "Old forum",
+							kdbx.FieldURL:      "forum.example.com",
+							kdbx.FieldUserName: "jane",
+						},
+					}},
+				},
+			},
+		},
+	}, db)
+
+	_, err = kdbx.Open(data, &kdbx.Credentials{Password: "correct horse battery"})
+	require.ErrorIs(t, err, kdbx.ErrInvalidCredentials)
+}
+
+func TestOpen_Fails(t *testing.T) {
+	creds := &kdbx.Credentials{Password: testPassword}
+	v4 := (&testFile{major: 4, cipher: cipherAES, kdf: kdfAES}).build(t, creds)
+	v3 := (&testFile{major: 3, cipher: cipherAES}).build(t, creds)
+
+	corrupted := bytes.Clone(v4)
+	corrupted[len(corrupted)-50] ^= 1
+
+	unsupported := bytes.Clone(v4)
+	unsupported[10] = 5
+
+	tests := []struct {
+		name  string
+		data  []byte
+		creds *kdbx.Credentials
+		err   error
+	}{
+		{name: "kdbx 4 password", data: v4, creds: &kdbx.Credentials{Password: "other"}, err: kdbx.ErrInvalidCredentials},
+		{name: "kdbx 3.1 password", data: v3, creds: &kdbx.Credentials{Password: "other"}, err: kdbx.ErrInvalidCredentials},
+		{
+			name:  "key file hash",
+			data:  v4,
+			creds: &kdbx.Credentials{KeyFile: bytes.Replace(testKeyFile, []byte("0239231A"), []byte("00000000"), 1)},
+			err:   kdbx.ErrInvalidKeyFile,
+		},
+		{name: "corrupted", data: corrupted, creds: creds, err: kdbx.ErrCorrupted},
+		{name: "version", data: unsupported, creds: creds, err: kdbx.ErrUnsupportedVersion},
+		{name: "signature", data: []byte("not a database"), creds: creds, err: kdbx.ErrInvalidFile},
+		{name: "truncated", data: v4[:100], creds: creds, err: kdbx.ErrInvalidFile},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := kdbx.Open(tt.data, tt.creds)
+			require.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+// testFile writes the databases like KeePass does.
+type testFile struct {
+	major      uint16
+	cipher     []byte
+	kdf        []byte
+	compressed bool
+}
+
+var (
+	masterSeed    = bytes.Repeat([]byte{1}, 32)
+	transformSeed = bytes.Repeat([]byte{2}, 32)
+	streamKey     = bytes.Repeat([]byte{3}, 64)
+	startBytes    = bytes.Repeat([]byte{4}, 32)
+	testSalt      = bytes.Repeat([]byte{5}, 32)
+)
+
+const testRounds = 100
+
+// testArgon2dKey is the Argon2d key of the test password with the parameters of the test files,
+// x/crypto doesn't implement Argon2d.
+var testArgon2dKey, _ = hex.DecodeString("cf38ecf675ad7b1e9e32caf9eb78148955cd68eb9ccd43cb0cd53c5b972651e1")
+
+func (f *testFile) build(t *testing.T, creds *kdbx.Credentials) []byte {
+	t.Helper()
+
+	iv := bytes.Repeat([]byte{6}, aes.BlockSize)
+	if bytes.Equal(f.cipher, cipherChaCha20) {
+		iv = iv[:chacha20.NonceSize]
+	}
+
+	var header bytes.Buffer
+	binary.Write(&header, binary.LittleEndian, []uint32{0x9AA2D903, 0xB54BFB67})
+	binary.Write(&header, binary.LittleEndian, []uint16{1, f.major})
+
+	compression := uint32(0)
+	if f.compressed {
+		compression = 1
+	}
+
+	f.field(&header, 2, f.cipher)
+	f.field(&header, 3, binary.LittleEndian.AppendUint32(nil, compression))
+	f.field(&header, 4, masterSeed)
+	f.field(&header, 7, iv)
+
+	if f.major == 3 {
+		f.field(&header, 5, transformSeed)
+		f.field(&header, 6, binary.LittleEndian.AppendUint64(nil, testRounds))
+		f.field(&header, 8, streamKey)
+		f.field(&header, 9, startBytes)
+		f.field(&header, 10, binary.LittleEndian.AppendUint32(nil, 2))
+	} else {
+		f.field(&header, 11, f.kdfParameters())
+	}
+
+	f.field(&header, 0, []byte("\r\n\r\n"))
+
+	transformed := f.transformKey(t, creds)
+	key := sha256.Sum256(append(bytes.Clone(masterSeed), transformed...))
+	document := f.document(t)
+
+	if f.major == 3 {
+		payload := f.compress(t, document)
+
+		var blocks bytes.Buffer
+		blocks.Write(startBytes)
+
+		hash := sha256.Sum256(payload)
+		binary.Write(&blocks, binary.LittleEndian, uint32(0))
+		blocks.Write(hash[:])
+		binary.Write(&blocks, binary.LittleEndian, uint32(len(payload)))
+		blocks.Write(payload)
+		binary.Write(&blocks, binary.LittleEndian, uint32(1))
+		blocks.Write(make([]byte, 32+4))
+
+		header.Write(f.encrypt(t, key[:], iv, blocks.Bytes()))
+
+		return header.Bytes()
+	}
+
+	var inner bytes.Buffer
+	f.field(&inner, 1, binary.LittleEndian.AppendUint32(nil, 3))
+	f.field(&inner, 2, streamKey)
+	f.field(&inner, 3, append([]byte{1}, "recovery codes"...))
+	f.field(&inner, 0, nil)
+	inner.Write(document)
+
+	encrypted := f.encrypt(t, key[:], iv, f.compress(t, inner.Bytes()))
+	hmacKey := sha512.Sum512(append(append(bytes.Clone(masterSeed), transformed...), 1))
+
+	headerHash := sha256.Sum256(header.Bytes())
+	headerHMAC := hmac.New(sha256.New, blockKey(hmacKey[:], math.MaxUint64))
+	headerHMAC.Write(header.Bytes())
+
+	result := bytes.NewBuffer(bytes.Clone(header.Bytes()))
+	result.Write(headerHash[:])
+	result.Write(headerHMAC.Sum(nil))
+
+	for index, data := range [][]byte{encrypted, nil} {
+		size := binary.LittleEndian.AppendUint32(nil, uint32(len(data)))
+
+		mac := hmac.New(sha256.New, blockKey(hmacKey[:], uint64(index)))
+		mac.Write(binary.LittleEndian.AppendUint64(nil, uint64(index)))
+		mac.Write(size)
+		mac.Write(data)
+
+		result.Write(mac.Sum(nil))
+		result.Write(size)
+		result.Write(data)
+	}
+
+	return result.Bytes()
+}
+
+func (f *testFile) field(w *bytes.Buffer, id byte, value []byte) {
+	w.WriteByte(id)
+
+	if f.major == 3 {
+		binary.Write(w, binary.LittleEndian, uint16(len(value)))
+	} else {
+		binary.Write(w, binary.LittleEndian, uint32(len(value)))
+	}
+
+	w.Write(value)
+}
+
+func (f *testFile) kdfParameters() []byte {
+	var dict bytes.Buffer
+	binary.Write(&dict, binary.LittleEndian, uint16(0x0100))
+
+	item := func(kind byte, key string, value []byte) {
+		dict.WriteByte(kind)
+		binary.Write(&dict, binary.LittleEndian, uint32(len(key)))
+		dict.WriteString(key)
+		binary.Write(&dict, binary.LittleEndian, uint32(len(value)))
+		dict.Write(value)
+	}
+
+	item(0x42, "$UUID", f.kdf)
+	item(0x42, "S", testSalt)
+
+	if bytes.Equal(f.kdf, kdfAES) {
+		item(0x05, "R", binary.LittleEndian.AppendUint64(nil, testRounds))
+	} else {
+		item(0x05, "I", binary.LittleEndian.AppendUint64(nil, 2))
+		item(0x05, "M", binary.LittleEndian.AppendUint64(nil, 1<<20))
+		item(0x04, "P", binary.LittleEndian.AppendUint32(nil, 2))
+		item(0x04, "V", binary.LittleEndian.AppendUint32(nil, 0x13))
+	}
+
+	dict.WriteByte(0)
+
+	return dict.Bytes()
+}
+
+// transformKey derives the key with the KDF, Argon2d is checked by the known keys of the credentials.
+func (f *testFile) transformKey(t *testing.T, creds *kdbx.Credentials) []byte {
+	t.Helper()
+
+	composite := sha256.New()
+
+	if creds.Password != "" {
+		sum := sha256.Sum256([]byte(creds.Password))
+		composite.Write(sum[:])
+	}
+
+	switch {
+	case bytes.Equal(creds.KeyFile, testKeyFile):
+		key, _ := hex.DecodeString("9D5E4F5B3B7A0C2E1F6D8A4C5E2B7D9A3C1E8F6A7B4D2C9E0A5F3B8D6E1C4A7F")
+		composite.Write(key)
+	case len(creds.KeyFile) == 64:
+		key, _ := hex.DecodeString(string(creds.KeyFile))
+		composite.Write(key)
+	case creds.KeyFile != nil:
+		sum := sha256.Sum256(creds.KeyFile)
+		composite.Write(sum[:])
+	}
+
+	seed := transformSeed
+	if f.major == 4 {
+		seed = testSalt
+	}
+
+	switch {
+	case f.major == 3 || bytes.Equal(f.kdf, kdfAES):
+		block, err := aes.NewCipher(seed)
+		require.NoError(t, err)
+
+		key := composite.Sum(nil)
+		for range testRounds {
+			block.Encrypt(key[:16], key[:16])
+			block.Encrypt(key[16:], key[16:])
+		}
+
+		sum := sha256.Sum256(key)
+
+		return sum[:]
+	case bytes.Equal(f.kdf, kdfArgon2d):
+		require.Equal(t, &kdbx.Credentials{Password: testPassword}, creds)
+
+		return testArgon2dKey
+	default:
+		return argon2.IDKey(composite.Sum(nil), testSalt, 2, 1024, 2, 32)
+	}
+}
+
+func (f *testFile) compress(t *testing.T, data []byte) []byte {
+	t.Helper()
+
+	if !f.compressed {
+		return data
+	}
+
+	var buf bytes.Buffer
+
+	w := gzip.NewWriter(&buf)
+	_, err := w.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	return buf.Bytes()
+}
+
+func (f *testFile) encrypt(t *testing.T, key, iv, data []byte) []byte {
+	t.Helper()
+
+	if bytes.Equal(f.cipher, cipherChaCha20) {
+		stream, err := chacha20.NewUnauthenticatedCipher(key, iv)
+		require.NoError(t, err)
+
+		encrypted := make([]byte, len(data))
+		stream.XORKeyStream(encrypted, data)
+
+		return encrypted
+	}
+
+	var (
+		block cipher.Block
+		err   error
+	)
+
+	if bytes.Equal(f.cipher, cipherTwofish) {
+		block, err = twofish.NewCipher(key)
+	} else {
+		block, err = aes.NewCipher(key)
+	}
+
+	require.NoError(t, err)
+
+	padding := block.BlockSize() - len(data)%block.BlockSize()
+	data = append(bytes.Clone(data), bytes.Repeat([]byte{byte(padding)}, padding)...)
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(data, data)
+
+	return data
+}
+
+// document is the XML document with the protected values encrypted in the order of the document.
+func (f *testFile) document(t *testing.T) []byte {
+	t.Helper()
+
+	var keystream [256]byte
+
+	if f.major == 3 {
+		key := sha256.Sum256(streamKey)
+		salsa20.XORKeyStream(keystream[:], keystream[:], []byte{0xe8, 0x30, 0x09, 0x4b, 0x97, 0x20, 0x5d, 0x2a}, &key)
+	} else {
+		hash := sha512.Sum512(streamKey)
+		stream, _ := chacha20.NewUnauthenticatedCipher(hash[:32], hash[32:44])
+		stream.XORKeyStream(keystream[:], keystream[:])
+	}
+
+	offset := 0
+	protect := func(value string) string {
+		encrypted := make([]byte, len(value))
+		for i := range value {
+			encrypted[i] = value[i] ^ keystream[offset+i]
+		}
+
+		offset += len(value)
+
+		return base64.StdEncoding.EncodeToString(encrypted)
+	}
+
+	binaries := ""
+	if f.major == 3 {
+		binaries = `<Binaries><Binary ID="0" Compressed="False">` +
+			base64.StdEncoding.EncodeToString([]byte("recovery codes")) + `</Binary></Binaries>`
+		if f.compressed {
+			binaries = `<Binaries><Binary ID="0" Compressed="True">` +
+				base64.StdEncoding.EncodeToString(f.compress(t, []byte("recovery codes"))) + `</Binary></Binaries>`
+		}
+	}
+
+	recycleBin := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{9}, 16))
+
+	return []byte(fmt.Sprintf(`<?xml version="1.0" encoding="utf-8" standalone="yes"?>
+<KeePassFile>
+	<Meta>
+		<DatabaseName>Test</DatabaseName>
+		<RecycleBinUUID>%[1]s</RecycleBinUUID>
+		%[2]s
+	</Meta>
+	<Root>
+		<Group>
+			<UUID>AAAAAAAAAAAAAAAAAAAAAQ==</UUID>
+			<Name>Root</Name>
+			<Entry>
+				<String><Key>Title</Key><Value>GitHub</Value></String>
+				<String><Key>UserName</Key><Value>john</Value></String>
+				<String><Key>Password</Key><Value Protected="True">%[3]s</Value></String>
+				<String><Key>URL</Key><Value>https://github.com</Value></String>
+				<String><Key>Notes</Key><Value>work</Value></String>
+				<Binary><Key>codes.txt</Key><Value Ref="0"/></Binary>
+				<History>
+					<Entry><String><Key>Password</Key><Value Protected="True">%[4]s</Value></String></Entry>
+				</History>
+				<String><Key>PIN</Key><Value Protected="True">%[5]s</Value></String>
+			</Entry>
+			<Group>
+				<UUID>AAAAAAAAAAAAAAAAAAAAAg==</UUID>
+				<Name>Email</Name>
+				<Entry>
+					<String><Key>Title</Key><Value>Mail</Value></String>
+					<String><Key>Notes</Key><Value>note</Value></String>
+				</Entry>
+			</Group>
+			<Group>
+				<UUID>%[1]s</UUID>
+				<Name>Recycle Bin</Name>
+				<Entry>
+					<String><Key>Title</Key><Value>Old</Value></String>
+					<String><Key>Password</Key><Value Protected="True">%[6]s</Value></String>
+				</Entry>
+			</Group>
+		</Group>
+	</Root>
+</KeePassFile>`, recycleBin, binaries, protect("p@ssw0rd"), protect("history"), protect("1234"), protect("old")))
+}
+
+func blockKey(hmacKey []byte, index uint64) []byte {
+	key := sha512.Sum512(append(binary.LittleEndian.AppendUint64(nil, index), hmacKey...))
+
+	return key[:]
+}
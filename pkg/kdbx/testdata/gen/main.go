@@ -0,0 +1,345 @@
+// Command gen writes the KDBX 4 fixture of the tests: go run ./pkg/kdbx/testdata/gen > pkg/kdbx/testdata/kdbx4.kdbx
+//
+// The fixture is laid out like a database saved by KeePassXC 2.7: AES-256, Argon2id, gzip and ChaCha20
+// for the protected values, the document has the metadata, the times, the auto-type, the history
+// and the deleted objects of KeePassXC. It is written with golang.org/x/crypto only, so it is independent
+// of the package it checks.
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/chacha20"
+)
+
+const (
+	password = "correct horse battery staple"
+
+	iterations  = 2
+	memory      = 4 << 20
+	parallelism = 2
+)
+
+var (
+	cipherAES   = []byte{0x31, 0xc1, 0xf2, 0xe6, 0xbf, 0x71, 0x43, 0x50, 0xbe, 0x58, 0x05, 0x21, 0x6a, 0xfc, 0x5a, 0xff}
+	kdfArgon2id = []byte{0x9e, 0x29, 0x8b, 0x19, 0x56, 0xdb, 0x47, 0x73, 0xb2, 0x3d, 0xfc, 0x3e, 0xc6, 0xf0, 0xa1, 0xe6}
+)
+
+func main() {
+	masterSeed, salt, iv, streamKey := random(32), random(32), random(aes.BlockSize), random(64)
+
+	var header bytes.Buffer
+	binary.Write(&header, binary.LittleEndian, []uint32{0x9AA2D903, 0xB54BFB67})
+	binary.Write(&header, binary.LittleEndian, []uint16{0, 4})
+	field(&header, 2, cipherAES)
+	field(&header, 3, binary.LittleEndian.AppendUint32(nil, 1))
+	field(&header, 4, masterSeed)
+	field(&header, 7, iv)
+	field(&header, 11, kdfParameters(salt))
+	field(&header, 0, []byte("\r\n\r\n"))
+
+	passwordHash := sha256.Sum256([]byte(password))
+	composite := sha256.Sum256(passwordHash[:])
+	transformed := argon2.IDKey(composite[:], salt, iterations, memory>>10, parallelism, 32)
+	key := sha256.Sum256(append(bytes.Clone(masterSeed), transformed...))
+	hmacKey := sha512.Sum512(append(append(bytes.Clone(masterSeed), transformed...), 1))
+
+	var inner bytes.Buffer
+	field(&inner, 1, binary.LittleEndian.AppendUint32(nil, 3))
+	field(&inner, 2, streamKey)
+	field(&inner, 3, append([]byte{1}, "github recovery codes\n"...))
+	field(&inner, 3, append([]byte{0}, "BEGIN:VCARD\nFN:Jane Doe\nEND:VCARD\n"...))
+	field(&inner, 0, nil)
+	inner.WriteString(document(streamKey))
+
+	var compressed bytes.Buffer
+
+	w := gzip.NewWriter(&compressed)
+	w.Write(inner.Bytes())
+	w.Close()
+
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	payload := compressed.Bytes()
+	padding := aes.BlockSize - len(payload)%aes.BlockSize
+	payload = append(payload, bytes.Repeat([]byte{byte(padding)}, padding)...)
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(payload, payload)
+
+	headerHash := sha256.Sum256(header.Bytes())
+	headerHMAC := hmac.New(sha256.New, blockKey(hmacKey[:], math.MaxUint64))
+	headerHMAC.Write(header.Bytes())
+
+	out := bytes.NewBuffer(header.Bytes())
+	out.Write(headerHash[:])
+	out.Write(headerHMAC.Sum(nil))
+
+	for index, data := range [][]byte{payload, nil} {
+		size := binary.LittleEndian.AppendUint32(nil, uint32(len(data)))
+
+		mac := hmac.New(sha256.New, blockKey(hmacKey[:], uint64(index)))
+		mac.Write(binary.LittleEndian.AppendUint64(nil, uint64(index)))
+		mac.Write(size)
+		mac.Write(data)
+
+		out.Write(mac.Sum(nil))
+		out.Write(size)
+		out.Write(data)
+	}
+
+	if _, err := os.Stdout.Write(out.Bytes()); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func random(n int) []byte {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		log.Fatal(err)
+	}
+
+	return b
+}
+
+func field(w *bytes.Buffer, id byte, value []byte) {
+	w.WriteByte(id)
+	binary.Write(w, binary.LittleEndian, uint32(len(value)))
+	w.Write(value)
+}
+
+func kdfParameters(salt []byte) []byte {
+	var dict bytes.Buffer
+	binary.Write(&dict, binary.LittleEndian, uint16(0x0100))
+
+	item := func(kind byte, key string, value []byte) {
+		dict.WriteByte(kind)
+		binary.Write(&dict, binary.LittleEndian, uint32(len(key)))
+		dict.WriteString(key)
+		binary.Write(&dict, binary.LittleEndian, uint32(len(value)))
+		dict.Write(value)
+	}
+
+	item(0x42, "$UUID", kdfArgon2id)
+	item(0x05, "I", binary.LittleEndian.AppendUint64(nil, iterations))
+	item(0x05, "M", binary.LittleEndian.AppendUint64(nil, memory))
+	item(0x04, "P", binary.LittleEndian.AppendUint32(nil, parallelism))
+	item(0x42, "S", salt)
+	item(0x04, "V", binary.LittleEndian.AppendUint32(nil, 0x13))
+	dict.WriteByte(0)
+
+	return dict.Bytes()
+}
+
+func blockKey(hmacKey []byte, index uint64) []byte {
+	key := sha512.Sum512(append(binary.LittleEndian.AppendUint64(nil, index), hmacKey...))
+
+	return key[:]
+}
+
+// document returns the XML document, the protected values are encrypted in the order of the document.
+func document(streamKey []byte) string {
+	hash := sha512.Sum512(streamKey)
+
+	stream, err := chacha20.NewUnauthenticatedCipher(hash[:32], hash[32:44])
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	protect := func(value string) string {
+		encrypted := make([]byte, len(value))
+		stream.XORKeyStream(encrypted, []byte(value))
+
+		return base64.StdEncoding.EncodeToString(encrypted)
+	}
+
+	uuid := func(n byte) string {
+		return base64.StdEncoding.EncodeToString(append(bytes.Repeat([]byte{0x5c}, 15), n))
+	}
+
+	times := `<Times>
+					<LastModificationTime>bU3y2w4AAAA=</LastModificationTime>
+					<CreationTime>bU3y2w4AAAA=</CreationTime>
+					<LastAccessTime>bU3y2w4AAAA=</LastAccessTime>
+					<ExpiryTime>bU3y2w4AAAA=</ExpiryTime>
+					<Expires>False</Expires>
+					<UsageCount>0</UsageCount>
+					<LocationChanged>bU3y2w4AAAA=</LocationChanged>
+				</Times>`
+	entry := func(id byte, fields ...string) string {
+		var b strings.Builder
+
+		fmt.Fprintf(&b, "<UUID>%s</UUID>\n<IconID>0</IconID>\n<ForegroundColor/>\n<BackgroundColor/>\n", uuid(id))
+		b.WriteString("<OverrideURL/>\n<Tags/>\n" + times + "\n")
+
+		for i := 0; i < len(fields); i += 2 {
+			fmt.Fprintf(&b, "<String><Key>%s</Key>%s</String>\n", fields[i], fields[i+1])
+		}
+
+		return b.String()
+	}
+	value := func(v string) string {
+		if v == "" {
+			return "<Value/>"
+		}
+
+		return "<Value>" + v + "</Value>"
+	}
+	protected := func(v string) string {
+		if v == "" {
+			return `<Value Protected="True"/>`
+		}
+
+		return `<Value Protected="True">` + protect(v) + "</Value>"
+	}
+
+	autoType := `<AutoType>
+					<Enabled>True</Enabled>
+					<DataTransferObfuscation>0</DataTransferObfuscation>
+					<Association><Window>GitHub*</Window><KeystrokeSequence/></Association>
+				</AutoType>`
+
+	// the values are protected in the order of the document, the history goes after the fields of its entry
+	github := entry(1,
+		"Notes", value("work account"),
+		"Password", protected("gh-s3cr3t!"),
+		"Title", value("GitHub"),
+		"URL", value("https://github.com/login"),
+		"UserName", value("jane"),
+	) + `<Binary><Key>recovery.txt</Key><Value Ref="0"/></Binary>` + autoType +
+		"<History><Entry>" + entry(1,
+		"Notes", value(""),
+		"Password", protected("old-s3cr3t"),
+		"Title", value("GitHub"),
+		"URL", value(""),
+		"UserName", value("jane"),
+	) + "</Entry></History>"
+	bank := entry(2,
+		"Notes", value("card PIN and a contact"),
+		"PIN", protected("4321"),
+		"Password", protected(""),
+		"Title", value("Bank"),
+		"URL", value(""),
+		"UserName", value(""),
+	) + `<Binary><Key>contact.vcf</Key><Value Ref="1"/></Binary>`
+	deleted := entry(3,
+		"Notes", value(""),
+		"Password", protected("gone"),
+		"Title", value("Old forum"),
+		"URL", value("forum.example.com"),
+		"UserName", value("jane"),
+	)
+
+	return fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<KeePassFile>
+	<Meta>
+		<Generator>KeePassXC</Generator>
+		<DatabaseName>Personal</DatabaseName>
+		<DatabaseNameChanged>bU3y2w4AAAA=</DatabaseNameChanged>
+		<DatabaseDescription/>
+		<DatabaseDescriptionChanged>bU3y2w4AAAA=</DatabaseDescriptionChanged>
+		<DefaultUserName/>
+		<DefaultUserNameChanged>bU3y2w4AAAA=</DefaultUserNameChanged>
+		<MaintenanceHistoryDays>365</MaintenanceHistoryDays>
+		<Color/>
+		<MasterKeyChanged>bU3y2w4AAAA=</MasterKeyChanged>
+		<MasterKeyChangeRec>-1</MasterKeyChangeRec>
+		<MasterKeyChangeForce>-1</MasterKeyChangeForce>
+		<MemoryProtection>
+			<ProtectTitle>False</ProtectTitle>
+			<ProtectUserName>False</ProtectUserName>
+			<ProtectPassword>True</ProtectPassword>
+			<ProtectURL>False</ProtectURL>
+			<ProtectNotes>False</ProtectNotes>
+		</MemoryProtection>
+		<CustomIcons/>
+		<RecycleBinEnabled>True</RecycleBinEnabled>
+		<RecycleBinUUID>%[1]s</RecycleBinUUID>
+		<RecycleBinChanged>bU3y2w4AAAA=</RecycleBinChanged>
+		<EntryTemplatesGroup>AAAAAAAAAAAAAAAAAAAAAA==</EntryTemplatesGroup>
+		<EntryTemplatesGroupChanged>bU3y2w4AAAA=</EntryTemplatesGroupChanged>
+		<LastSelectedGroup>%[2]s</LastSelectedGroup>
+		<LastTopVisibleGroup>%[2]s</LastTopVisibleGroup>
+		<HistoryMaxItems>10</HistoryMaxItems>
+		<HistoryMaxSize>6291456</HistoryMaxSize>
+		<SettingsChanged>bU3y2w4AAAA=</SettingsChanged>
+		<CustomData>
+			<Item>
+				<Key>KPXC_DECRYPTION_TIME_PREFERENCE</Key>
+				<Value>1000</Value>
+				<LastModificationTime>bU3y2w4AAAA=</LastModificationTime>
+			</Item>
+		</CustomData>
+	</Meta>
+	<Root>
+		<Group>
+			<UUID>%[2]s</UUID>
+			<Name>Root</Name>
+			<Notes/>
+			<IconID>48</IconID>
+			%[3]s
+			<IsExpanded>True</IsExpanded>
+			<DefaultAutoTypeSequence/>
+			<EnableAutoType>null</EnableAutoType>
+			<EnableSearching>null</EnableSearching>
+			<LastTopVisibleEntry>AAAAAAAAAAAAAAAAAAAAAA==</LastTopVisibleEntry>
+			<Entry>
+%[4]s
+			</Entry>
+			<Group>
+				<UUID>%[5]s</UUID>
+				<Name>Finance</Name>
+				<Notes/>
+				<IconID>48</IconID>
+				%[3]s
+				<IsExpanded>True</IsExpanded>
+				<DefaultAutoTypeSequence/>
+				<EnableAutoType>null</EnableAutoType>
+				<EnableSearching>null</EnableSearching>
+				<LastTopVisibleEntry>AAAAAAAAAAAAAAAAAAAAAA==</LastTopVisibleEntry>
+				<Entry>
+%[6]s
+				</Entry>
+			</Group>
+			<Group>
+				<UUID>%[1]s</UUID>
+				<Name>Recycle Bin</Name>
+				<Notes/>
+				<IconID>43</IconID>
+				%[3]s
+				<IsExpanded>False</IsExpanded>
+				<DefaultAutoTypeSequence/>
+				<EnableAutoType>false</EnableAutoType>
+				<EnableSearching>false</EnableSearching>
+				<LastTopVisibleEntry>AAAAAAAAAAAAAAAAAAAAAA==</LastTopVisibleEntry>
+				<Entry>
+%[7]s
+				</Entry>
+			</Group>
+		</Group>
+		<DeletedObjects>
+			<DeletedObject>
+				<UUID>%[8]s</UUID>
+				<DeletionTime>bU3y2w4AAAA=</DeletionTime>
+			</DeletedObject>
+		</DeletedObjects>
+	</Root>
+</KeePassFile>
+`, uuid(0xf0), uuid(0xa0), times, github, uuid(0xa1), bank, deleted, uuid(9))
+}
@@ -0,0 +1,219 @@
+// Package otp implements HOTP (RFC 4226) and TOTP (RFC 6238) one-time passwords.
+package otp
+
+import (
+	"crypto/hmac"
+	"crypto/sha1" // nolint: gosec
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidURI           = errors.New("invalid otpauth uri")
+	ErrInvalidSecret        = errors.New("invalid secret")
+	ErrUnsupportedKind      = errors.New("unsupported otp kind")
+	ErrUnsupportedAlgorithm = errors.New("unsupported algorithm")
+	ErrInvalidDigits        = errors.New("invalid digits")
+	ErrInvalidPeriod        = errors.New("invalid period")
+)
+
+const (
+	KindTOTP = "totp"
+	KindHOTP = "hotp"
+
+	AlgorithmSHA1   = "SHA1"
+	AlgorithmSHA256 = "SHA256"
+	AlgorithmSHA512 = "SHA512"
+
+	DefaultDigits = 6
+	DefaultPeriod = 30
+
+	minDigits = 6
+	maxDigits = 8
+)
+
+// Key is a shared OTP seed with its generation parameters.
+type Key struct {
+	Kind      string
+	Issuer    string
+	Account   string
+	Secret    string
+	Algorithm string
+	Digits    int
+	Period    int
+	Counter   uint64
+}
+
+// NewKey returns a TOTP key with default parameters for the given base32 secret.
+func NewKey(secret string) *Key {
+	return &Key{
+		Kind:      KindTOTP,
+		Secret:    secret,
+		Algorithm: AlgorithmSHA1,
+		Digits:    DefaultDigits,
+		Period:    DefaultPeriod,
+	}
+}
+
+// ParseURI parses a key from the otpauth:// URI format used by authenticator apps.
+//
+// Missing parameters take the defaults of the format: SHA1, 6 digits and a period of 30 seconds.
+func ParseURI(uri string) (*Key, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidURI, err)
+	}
+
+	if u.Scheme != "otpauth" {
+		return nil, fmt.Errorf("%w: scheme %q", ErrInvalidURI, u.Scheme)
+	}
+
+	query := u.Query()
+	key := NewKey(query.Get("secret"))
+	key.Kind = strings.ToLower(u.Host)
+
+	label := strings.TrimPrefix(u.Path, "/")
+	if issuer, account, ok := strings.Cut(label, ":"); ok {
+		key.Issuer, key.Account = issuer, strings.TrimSpace(account)
+	} else {
+		key.Account = label
+	}
+
+	if issuer := query.Get("issuer"); issuer != "" {
+		key.Issuer = issuer
+	}
+
+	if algorithm := query.Get("algorithm"); algorithm != "" {
+		key.Algorithm = strings.ToUpper(algorithm)
+	}
+
+	if digits := query.Get("digits"); digits != "" {
+		if key.Digits, err = strconv.Atoi(digits); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidDigits, err)
+		}
+	}
+
+	if period := query.Get("period"); period != "" {
+		if key.Period, err = strconv.Atoi(period); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidPeriod, err)
+		}
+	}
+
+	if counter := query.Get("counter"); counter != "" {
+		if key.Counter, err = strconv.ParseUint(counter, 10, 64); err != nil {
+			return nil, fmt.Errorf("%w: counter: %w", ErrInvalidURI, err)
+		}
+	}
+
+	if err := key.Validate(); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// Validate checks that the key can be used to generate codes.
+func (k *Key) Validate() error {
+	if k.Kind != KindTOTP && k.Kind != KindHOTP {
+		return fmt.Errorf("%w: %q", ErrUnsupportedKind, k.Kind)
+	}
+
+	if _, err := newHash(k.Algorithm); err != nil {
+		return err
+	}
+
+	if k.Digits < minDigits || k.Digits > maxDigits {
+		return fmt.Errorf("%w: %d", ErrInvalidDigits, k.Digits)
+	}
+
+	if k.Kind == KindTOTP && k.Period <= 0 {
+		return fmt.Errorf("%w: %d", ErrInvalidPeriod, k.Period)
+	}
+
+	_, err := decodeSecret(k.Secret)
+
+	return err
+}
+
+// HOTP generates the code for the given counter value.
+func (k *Key) HOTP(counter uint64) (string, error) {
+	secret, err := decodeSecret(k.Secret)
+	if err != nil {
+		return "", err
+	}
+
+	h, err := newHash(k.Algorithm)
+	if err != nil {
+		return "", err
+	}
+
+	msg := make([]byte, 8) // nolint: mnd
+	binary.BigEndian.PutUint64(msg, counter)
+
+	mac := hmac.New(h, secret)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for range k.Digits {
+		mod *= 10
+	}
+
+	return fmt.Sprintf("%0*d", k.Digits, value%mod), nil
+}
+
+// TOTP generates the code for the time step containing t.
+//
+// It returns the code and the moment the code expires.
+func (k *Key) TOTP(t time.Time) (string, time.Time, error) {
+	if k.Period <= 0 {
+		return "", time.Time{}, fmt.Errorf("%w: %d", ErrInvalidPeriod, k.Period)
+	}
+
+	period := uint64(k.Period)
+	step := uint64(t.Unix()) / period
+
+	code, err := k.HOTP(step)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return code, time.Unix(int64((step+1)*period), 0), nil // nolint: gosec
+}
+
+func decodeSecret(secret string) ([]byte, error) {
+	secret = strings.ToUpper(strings.ReplaceAll(secret, " ", ""))
+	secret = strings.TrimRight(secret, "=")
+
+	decoded, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+	if err != nil || len(decoded) == 0 {
+		return nil, ErrInvalidSecret
+	}
+
+	return decoded, nil
+}
+
+func newHash(algorithm string) (func() hash.Hash, error) {
+	switch algorithm {
+	case AlgorithmSHA1:
+		return sha1.New, nil
+	case AlgorithmSHA256:
+		return sha256.New, nil
+	case AlgorithmSHA512:
+		return sha512.New, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedAlgorithm, algorithm)
+	}
+}
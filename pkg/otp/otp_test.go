@@ -0,0 +1,137 @@
+package otp_test
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/novoseltcev/passkeeper/pkg/otp"
+)
+
+var (
+	rfcSecretSHA1   = base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+	rfcSecretSHA256 = base32.StdEncoding.EncodeToString([]byte("12345678901234567890123456789012"))
+	rfcSecretSHA512 = base32.StdEncoding.EncodeToString([]byte(strings.Repeat("1234567890", 6) + "1234"))
+)
+
+func TestKey_HOTP(t *testing.T) {
+	t.Parallel()
+
+	key := otp.NewKey(rfcSecretSHA1)
+	key.Kind = otp.KindHOTP
+
+	for counter, want := range []string{"755224", "287082", "359152", "969429", "338314"} {
+		code, err := key.HOTP(uint64(counter))
+		require.NoError(t, err)
+		assert.Equal(t, want, code)
+	}
+}
+
+func TestKey_TOTP(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		secret    string
+		algorithm string
+		unix      int64
+		want      string
+	}{
+		{name: "sha1", secret: rfcSecretSHA1, algorithm: otp.AlgorithmSHA1, unix: 59, want: "94287082"},
+		{name: "sha1 big", secret: rfcSecretSHA1, algorithm: otp.AlgorithmSHA1, unix: 1111111109, want: "07081804"},
+		{name: "sha256", secret: rfcSecretSHA256, algorithm: otp.AlgorithmSHA256, unix: 59, want: "46119246"},
+		{name: "sha512", secret: rfcSecretSHA512, algorithm: otp.AlgorithmSHA512, unix: 59, want: "90693936"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			key := otp.NewKey(tt.secret)
+			key.Algorithm = tt.algorithm
+			key.Digits = 8
+
+			code, expiresAt, err := key.TOTP(time.Unix(tt.unix, 0))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, code)
+			assert.Equal(t, (tt.unix/30+1)*30, expiresAt.Unix())
+		})
+	}
+}
+
+func TestKey_TOTP_Fails(t *testing.T) {
+	t.Parallel()
+
+	key := otp.NewKey(rfcSecretSHA1)
+	key.Period = 0
+
+	_, _, err := key.TOTP(time.Now())
+	require.ErrorIs(t, err, otp.ErrInvalidPeriod)
+
+	key = otp.NewKey("not base32!")
+
+	_, _, err = key.TOTP(time.Now())
+	assert.ErrorIs(t, err, otp.ErrInvalidSecret)
+}
+
+func TestParseURI(t *testing.T) {
+	t.Parallel()
+
+	key, err := otp.ParseURI(
+		"otpauth://totp/ACME%20Co:john@example.com?secret=JBSWY3DPEHPK3PXP&issuer=ACME&algorithm=sha256&digits=8&period=60",
+	)
+	require.NoError(t, err)
+	assert.Equal(t, &otp.Key{
+		Kind:      otp.KindTOTP,
+		Issuer:    "ACME",
+		Account:   "john@example.com",
+		Secret:    "JBSWY3DPEHPK3PXP",
+		Algorithm: otp.AlgorithmSHA256,
+		Digits:    8,
+		Period:    60,
+	}, key)
+
+	key, err = otp.ParseURI("otpauth://hotp/label?secret=JBSWY3DPEHPK3PXP&counter=7")
+	require.NoError(t, err)
+	assert.Equal(t, &otp.Key{
+		Kind:      otp.KindHOTP,
+		Account:   "label",
+		Secret:    "JBSWY3DPEHPK3PXP",
+		Algorithm: otp.AlgorithmSHA1,
+		Digits:    otp.DefaultDigits,
+		Period:    otp.DefaultPeriod,
+		Counter:   7,
+	}, key)
+}
+
+func TestParseURI_Fails(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		uri  string
+		err  error
+	}{
+		{name: "scheme", uri: "https://totp/label?secret=JBSWY3DPEHPK3PXP", err: otp.ErrInvalidURI},
+		{name: "kind", uri: "otpauth://motp/label?secret=JBSWY3DPEHPK3PXP", err: otp.ErrUnsupportedKind},
+		{name: "secret", uri: "otpauth://totp/label?secret=1", err: otp.ErrInvalidSecret},
+		{name: "no secret", uri: "otpauth://totp/label", err: otp.ErrInvalidSecret},
+		{name: "algorithm", uri: "otpauth://totp/label?secret=JBSWY3DPEHPK3PXP&algorithm=md5", err: otp.ErrUnsupportedAlgorithm},
+		{name: "digits", uri: "otpauth://totp/label?secret=JBSWY3DPEHPK3PXP&digits=4", err: otp.ErrInvalidDigits},
+		{name: "digits nan", uri: "otpauth://totp/label?secret=JBSWY3DPEHPK3PXP&digits=a", err: otp.ErrInvalidDigits},
+		{name: "period", uri: "otpauth://totp/label?secret=JBSWY3DPEHPK3PXP&period=0", err: otp.ErrInvalidPeriod},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := otp.ParseURI(tt.uri)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
@@ -0,0 +1,268 @@
+// Package pwgen generates random passwords and diceware-style passphrases.
+package pwgen
+
+import (
+	"crypto/rand"
+	_ "embed"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+	"unicode"
+)
+
+var (
+	ErrInvalidLength = errors.New("invalid length")
+	ErrNoClasses     = errors.New("no character classes")
+	ErrInvalidPolicy = errors.New("invalid policy")
+)
+
+const (
+	Lower   = "abcdefghijklmnopqrstuvwxyz"
+	Upper   = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	Digits  = "0123456789"
+	Symbols = "!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~"
+
+	// Ambiguous are the characters easily confused with each other when read.
+	Ambiguous = "Il1|O0o`'\""
+
+	// otherPool is the pool size assumed for non-ASCII characters.
+	otherPool = 100
+)
+
+//go:embed words.txt
+var wordList string
+
+// words is the diceware-style list of 2048 words, so each word gives 11 bits of entropy.
+var words = strings.Fields(wordList)
+
+// Result is a generated password with its entropy estimate in bits.
+//
+// The estimate assumes the attacker knows the policy.
+type Result struct {
+	Password string
+	Entropy  float64
+}
+
+// Policy is a policy of a random character password.
+type Policy struct {
+	Length           int
+	Lower            bool
+	Upper            bool
+	Digits           bool
+	Symbols          bool
+	ExcludeAmbiguous bool
+	MinLower         int
+	MinUpper         int
+	MinDigits        int
+	MinSymbols       int
+}
+
+// DefaultPolicy returns a policy of 20 characters of all classes.
+func DefaultPolicy() Policy {
+	return Policy{Length: 20, Lower: true, Upper: true, Digits: true, Symbols: true} // nolint: mnd
+}
+
+type class struct {
+	chars string
+	min   int
+}
+
+// Generate generates a password by the policy.
+//
+// At least the minimum count of characters of each class is used, the rest is picked from all enabled classes.
+func Generate(p Policy) (*Result, error) {
+	if p.Length <= 0 {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidLength, p.Length)
+	}
+
+	classes := make([]class, 0, 4) // nolint: mnd
+	total := 0
+
+	for _, c := range []struct {
+		enabled bool
+		chars   string
+		min     int
+	}{
+		{p.Lower, Lower, p.MinLower},
+		{p.Upper, Upper, p.MinUpper},
+		{p.Digits, Digits, p.MinDigits},
+		{p.Symbols, Symbols, p.MinSymbols},
+	} {
+		if c.min < 0 || (!c.enabled && c.min > 0) {
+			return nil, fmt.Errorf("%w: minimum of a disabled class", ErrInvalidPolicy)
+		}
+
+		if !c.enabled {
+			continue
+		}
+
+		chars := c.chars
+		if p.ExcludeAmbiguous {
+			chars = strings.Map(func(r rune) rune {
+				if strings.ContainsRune(Ambiguous, r) {
+					return -1
+				}
+
+				return r
+			}, chars)
+		}
+
+		classes = append(classes, class{chars: chars, min: c.min})
+		total += c.min
+	}
+
+	if len(classes) == 0 {
+		return nil, ErrNoClasses
+	}
+
+	if total > p.Length {
+		return nil, fmt.Errorf("%w: minimums exceed the length %d", ErrInvalidPolicy, p.Length)
+	}
+
+	var alphabet strings.Builder
+
+	password := make([]byte, 0, p.Length)
+
+	for _, c := range classes {
+		alphabet.WriteString(c.chars)
+
+		for range c.min {
+			ch, err := pick(c.chars)
+			if err != nil {
+				return nil, err
+			}
+
+			password = append(password, ch)
+		}
+	}
+
+	all := alphabet.String()
+	for len(password) < p.Length {
+		ch, err := pick(all)
+		if err != nil {
+			return nil, err
+		}
+
+		password = append(password, ch)
+	}
+
+	if err := shuffle(password); err != nil {
+		return nil, err
+	}
+
+	return &Result{
+		Password: string(password),
+		Entropy:  float64(p.Length) * math.Log2(float64(len(all))),
+	}, nil
+}
+
+// WordsPolicy is a policy of a diceware-style passphrase.
+type WordsPolicy struct {
+	Words      int
+	Separator  string
+	Capitalize bool
+	// Digit appends a random digit to a random word.
+	Digit bool
+}
+
+// GenerateWords generates a passphrase of random words by the policy.
+func GenerateWords(p WordsPolicy) (*Result, error) {
+	if p.Words <= 0 {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidLength, p.Words)
+	}
+
+	chosen := make([]string, p.Words)
+
+	for i := range chosen {
+		n, err := randInt(len(words))
+		if err != nil {
+			return nil, err
+		}
+
+		chosen[i] = words[n]
+		if p.Capitalize {
+			chosen[i] = string(unicode.ToUpper(rune(chosen[i][0]))) + chosen[i][1:]
+		}
+	}
+
+	entropy := float64(p.Words) * math.Log2(float64(len(words)))
+
+	if p.Digit {
+		i, err := randInt(p.Words)
+		if err != nil {
+			return nil, err
+		}
+
+		digit, err := pick(Digits)
+		if err != nil {
+			return nil, err
+		}
+
+		chosen[i] += string(digit)
+		entropy += math.Log2(float64(len(Digits) * p.Words))
+	}
+
+	return &Result{Password: strings.Join(chosen, p.Separator), Entropy: entropy}, nil
+}
+
+func pick(chars string) (byte, error) {
+	n, err := randInt(len(chars))
+	if err != nil {
+		return 0, err
+	}
+
+	return chars[n], nil
+}
+
+// shuffle shuffles the bytes with the Fisher-Yates algorithm.
+func shuffle(b []byte) error {
+	for i := len(b) - 1; i > 0; i-- {
+		j, err := randInt(i + 1)
+		if err != nil {
+			return err
+		}
+
+		b[i], b[j] = b[j], b[i]
+	}
+
+	return nil
+}
+
+func randInt(n int) (int, error) {
+	v, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		return 0, err
+	}
+
+	return int(v.Int64()), nil
+}
+
+// Entropy estimates the entropy of a password in bits.
+//
+// It is a naive estimate by the length and the pool of the used character classes,
+// so it overrates passwords made of dictionary words or patterns.
+func Entropy(password string) float64 {
+	var pool int
+
+	for _, chars := range []string{Lower, Upper, Digits, Symbols} {
+		if strings.ContainsAny(password, chars) {
+			pool += len(chars)
+		}
+	}
+
+	for _, r := range password {
+		if r > unicode.MaxASCII {
+			pool += otherPool
+
+			break
+		}
+	}
+
+	if pool == 0 {
+		return 0
+	}
+
+	return float64(len([]rune(password))) * math.Log2(float64(pool))
+}
@@ -0,0 +1,109 @@
+package pwgen_test
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"unicode"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/novoseltcev/passkeeper/pkg/pwgen"
+)
+
+func count(s, chars string) int {
+	n := 0
+
+	for _, r := range s {
+		if strings.ContainsRune(chars, r) {
+			n++
+		}
+	}
+
+	return n
+}
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+
+	result, err := pwgen.Generate(pwgen.DefaultPolicy())
+	require.NoError(t, err)
+	assert.Len(t, result.Password, 20)
+	assert.InDelta(t, 20*math.Log2(94), result.Entropy, 0.001)
+}
+
+func TestGenerate_Minimums(t *testing.T) {
+	t.Parallel()
+
+	for range 50 {
+		result, err := pwgen.Generate(pwgen.Policy{
+			Length:           8,
+			Lower:            true,
+			Digits:           true,
+			Symbols:          true,
+			ExcludeAmbiguous: true,
+			MinDigits:        3,
+			MinSymbols:       2,
+		})
+		require.NoError(t, err)
+		require.Len(t, result.Password, 8)
+		assert.GreaterOrEqual(t, count(result.Password, pwgen.Digits), 3)
+		assert.GreaterOrEqual(t, count(result.Password, pwgen.Symbols), 2)
+		assert.Zero(t, count(result.Password, pwgen.Upper))
+		assert.Zero(t, count(result.Password, pwgen.Ambiguous))
+	}
+}
+
+func TestGenerate_Fails(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		policy pwgen.Policy
+		err    error
+	}{
+		{name: "length", policy: pwgen.Policy{Lower: true}, err: pwgen.ErrInvalidLength},
+		{name: "no classes", policy: pwgen.Policy{Length: 8}, err: pwgen.ErrNoClasses},
+		{name: "disabled minimum", policy: pwgen.Policy{Length: 8, Lower: true, MinDigits: 1}, err: pwgen.ErrInvalidPolicy},
+		{name: "minimums", policy: pwgen.Policy{Length: 2, Lower: true, MinLower: 3}, err: pwgen.ErrInvalidPolicy},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := pwgen.Generate(tt.policy)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestGenerateWords(t *testing.T) {
+	t.Parallel()
+
+	result, err := pwgen.GenerateWords(pwgen.WordsPolicy{Words: 5, Separator: "-", Capitalize: true, Digit: true})
+	require.NoError(t, err)
+
+	parts := strings.Split(result.Password, "-")
+	require.Len(t, parts, 5)
+
+	for _, part := range parts {
+		assert.True(t, unicode.IsUpper(rune(part[0])))
+	}
+
+	assert.Equal(t, 1, count(result.Password, pwgen.Digits))
+	assert.InDelta(t, 5*11+math.Log2(50), result.Entropy, 0.001)
+
+	_, err = pwgen.GenerateWords(pwgen.WordsPolicy{})
+	assert.ErrorIs(t, err, pwgen.ErrInvalidLength)
+}
+
+func TestEntropy(t *testing.T) {
+	t.Parallel()
+
+	assert.Zero(t, pwgen.Entropy(""))
+	assert.InDelta(t, 8*math.Log2(10), pwgen.Entropy("12345678"), 0.001)
+	assert.InDelta(t, 4*math.Log2(62), pwgen.Entropy("aZ9b"), 0.001)
+	assert.InDelta(t, 2*math.Log2(126), pwgen.Entropy("aя"), 0.001)
+}
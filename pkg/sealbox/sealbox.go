@@ -0,0 +1,104 @@
+// Package sealbox encrypts messages to X25519 public keys.
+//
+// A sealed box is the ephemeral public key followed by the message encrypted with AES-256-GCM
+// under SHA-256 of the shared secret and both public keys, so only the recipient can open it.
+package sealbox
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+)
+
+const KeySize = 32
+
+var ErrInvalidBox = errors.New("invalid sealed box")
+
+// GenerateKey generates an X25519 key pair.
+func GenerateKey() (public, private []byte, err error) {
+	key, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return key.PublicKey().Bytes(), key.Bytes(), nil
+}
+
+// Seal encrypts the message to the public key.
+func Seal(public, msg []byte) ([]byte, error) {
+	recipient, err := ecdh.X25519().NewPublicKey(public)
+	if err != nil {
+		return nil, err
+	}
+
+	ephemeral, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	shared, err := ephemeral.ECDH(recipient)
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := newGCM(shared, ephemeral.PublicKey().Bytes(), public)
+	if err != nil {
+		return nil, err
+	}
+
+	// the key is never reused, so is the zero nonce
+	nonce := make([]byte, gcm.NonceSize())
+
+	return gcm.Seal(ephemeral.PublicKey().Bytes(), nonce, msg, nil), nil
+}
+
+// Open decrypts the sealed box with the private key.
+func Open(private, box []byte) ([]byte, error) {
+	if len(box) < KeySize {
+		return nil, ErrInvalidBox
+	}
+
+	key, err := ecdh.X25519().NewPrivateKey(private)
+	if err != nil {
+		return nil, err
+	}
+
+	ephemeral, err := ecdh.X25519().NewPublicKey(box[:KeySize])
+	if err != nil {
+		return nil, err
+	}
+
+	shared, err := key.ECDH(ephemeral)
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := newGCM(shared, box[:KeySize], key.PublicKey().Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := gcm.Open(nil, make([]byte, gcm.NonceSize()), box[KeySize:], nil)
+	if err != nil {
+		return nil, ErrInvalidBox
+	}
+
+	return msg, nil
+}
+
+func newGCM(shared, ephemeral, recipient []byte) (cipher.AEAD, error) {
+	hash := sha256.New()
+	hash.Write(shared)
+	hash.Write(ephemeral)
+	hash.Write(recipient)
+
+	block, err := aes.NewCipher(hash.Sum(nil))
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
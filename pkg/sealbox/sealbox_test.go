@@ -0,0 +1,49 @@
+package sealbox_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/novoseltcev/passkeeper/pkg/sealbox"
+)
+
+func TestSealOpen(t *testing.T) {
+	t.Parallel()
+
+	public, private, err := sealbox.GenerateKey()
+	require.NoError(t, err)
+	assert.Len(t, public, sealbox.KeySize)
+	assert.Len(t, private, sealbox.KeySize)
+
+	box, err := sealbox.Seal(public, []byte("secret"))
+	require.NoError(t, err)
+
+	other, err := sealbox.Seal(public, []byte("secret"))
+	require.NoError(t, err)
+	assert.NotEqual(t, box, other)
+
+	msg, err := sealbox.Open(private, box)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("secret"), msg)
+}
+
+func TestOpen_Fails(t *testing.T) {
+	t.Parallel()
+
+	public, _, err := sealbox.GenerateKey()
+	require.NoError(t, err)
+
+	_, private, err := sealbox.GenerateKey()
+	require.NoError(t, err)
+
+	box, err := sealbox.Seal(public, []byte("secret"))
+	require.NoError(t, err)
+
+	_, err = sealbox.Open(private, box)
+	require.ErrorIs(t, err, sealbox.ErrInvalidBox)
+
+	_, err = sealbox.Open(private, box[:10])
+	require.ErrorIs(t, err, sealbox.ErrInvalidBox)
+}
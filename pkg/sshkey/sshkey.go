@@ -0,0 +1,155 @@
+// Package sshkey generates and checks OpenSSH key pairs.
+package sshkey
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+)
+
+var (
+	ErrUnsupportedAlgorithm = errors.New("unsupported algorithm")
+	ErrInvalidPrivateKey    = errors.New("invalid private key")
+	ErrInvalidPublicKey     = errors.New("invalid public key")
+	ErrKeyMismatch          = errors.New("private key does not match public key")
+)
+
+const (
+	AlgorithmEd25519 = "ed25519"
+	AlgorithmRSA     = "rsa"
+
+	DefaultRSABits = 4096
+)
+
+// KeyPair is an OpenSSH key pair.
+//
+// PrivateKey is PEM encoded in the OpenSSH format, PublicKey is in the authorized_keys format.
+type KeyPair struct {
+	PrivateKey string
+	PublicKey  string
+}
+
+// Generate generates a new key pair.
+//
+// The bits are used only by RSA, zero means DefaultRSABits.
+// The private key is encrypted when passphrase is not empty.
+func Generate(algorithm string, bits int, comment, passphrase string) (*KeyPair, error) {
+	var (
+		private crypto.PrivateKey
+		public  crypto.PublicKey
+	)
+
+	switch algorithm {
+	case AlgorithmEd25519:
+		pub, priv, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+
+		private, public = priv, pub
+	case AlgorithmRSA:
+		if bits == 0 {
+			bits = DefaultRSABits
+		}
+
+		priv, err := rsa.GenerateKey(rand.Reader, bits)
+		if err != nil {
+			return nil, err
+		}
+
+		private, public = priv, &priv.PublicKey
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedAlgorithm, algorithm)
+	}
+
+	var (
+		block *pem.Block
+		err   error
+	)
+
+	if passphrase == "" {
+		block, err = ssh.MarshalPrivateKey(private, comment)
+	} else {
+		block, err = ssh.MarshalPrivateKeyWithPassphrase(private, comment, []byte(passphrase))
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	sshPublic, err := ssh.NewPublicKey(public)
+	if err != nil {
+		return nil, err
+	}
+
+	authorizedKey := strings.TrimSpace(string(ssh.MarshalAuthorizedKey(sshPublic)))
+	if comment != "" {
+		authorizedKey += " " + comment
+	}
+
+	return &KeyPair{
+		PrivateKey: string(pem.EncodeToMemory(block)),
+		PublicKey:  authorizedKey,
+	}, nil
+}
+
+// Check checks that the private key can be parsed with the passphrase and matches the public key.
+func Check(privateKey, publicKey, passphrase string) error {
+	var (
+		raw any
+		err error
+	)
+
+	if passphrase == "" {
+		raw, err = ssh.ParseRawPrivateKey([]byte(privateKey))
+	} else {
+		raw, err = ssh.ParseRawPrivateKeyWithPassphrase([]byte(privateKey), []byte(passphrase))
+	}
+
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidPrivateKey, err)
+	}
+
+	signer, err := ssh.NewSignerFromKey(raw)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidPrivateKey, err)
+	}
+
+	public, err := parsePublicKey(publicKey)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(signer.PublicKey().Marshal(), public.Marshal()) {
+		return ErrKeyMismatch
+	}
+
+	return nil
+}
+
+// Fingerprint returns the SHA256 fingerprint of the public key in the format of ssh-keygen.
+func Fingerprint(publicKey string) (string, error) {
+	public, err := parsePublicKey(publicKey)
+	if err != nil {
+		return "", err
+	}
+
+	return ssh.FingerprintSHA256(public), nil
+}
+
+func parsePublicKey(publicKey string) (ssh.PublicKey, error) {
+	public, _, _, _, err := ssh.ParseAuthorizedKey([]byte(publicKey)) // nolint: dogsled
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPublicKey, err)
+	}
+
+	return public, nil
+}
@@ -0,0 +1,84 @@
+package sshkey_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/novoseltcev/passkeeper/pkg/sshkey"
+)
+
+func TestGenerate_and_Check(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		algorithm  string
+		bits       int
+		passphrase string
+		prefix     string
+	}{
+		{name: "ed25519", algorithm: sshkey.AlgorithmEd25519, prefix: "ssh-ed25519 "},
+		{name: "ed25519 encrypted", algorithm: sshkey.AlgorithmEd25519, passphrase: "secret", prefix: "ssh-ed25519 "},
+		{name: "rsa", algorithm: sshkey.AlgorithmRSA, bits: 2048, prefix: "ssh-rsa "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			pair, err := sshkey.Generate(tt.algorithm, tt.bits, "user@host", tt.passphrase)
+			require.NoError(t, err)
+			assert.True(t, strings.HasPrefix(pair.PublicKey, tt.prefix))
+			assert.True(t, strings.HasSuffix(pair.PublicKey, " user@host"))
+			assert.Contains(t, pair.PrivateKey, "BEGIN OPENSSH PRIVATE KEY")
+
+			require.NoError(t, sshkey.Check(pair.PrivateKey, pair.PublicKey, tt.passphrase))
+
+			fingerprint, err := sshkey.Fingerprint(pair.PublicKey)
+			require.NoError(t, err)
+			assert.True(t, strings.HasPrefix(fingerprint, "SHA256:"))
+		})
+	}
+}
+
+func TestGenerate_Fails_Algorithm(t *testing.T) {
+	t.Parallel()
+
+	_, err := sshkey.Generate("dsa", 0, "", "")
+	assert.ErrorIs(t, err, sshkey.ErrUnsupportedAlgorithm)
+}
+
+func TestCheck_Fails(t *testing.T) {
+	t.Parallel()
+
+	pair, err := sshkey.Generate(sshkey.AlgorithmEd25519, 0, "", "secret")
+	require.NoError(t, err)
+
+	other, err := sshkey.Generate(sshkey.AlgorithmEd25519, 0, "", "")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		private    string
+		public     string
+		passphrase string
+		err        error
+	}{
+		{name: "invalid private", private: "key", public: pair.PublicKey, err: sshkey.ErrInvalidPrivateKey},
+		{name: "wrong passphrase", private: pair.PrivateKey, public: pair.PublicKey, passphrase: "wrong", err: sshkey.ErrInvalidPrivateKey},
+		{name: "no passphrase", private: pair.PrivateKey, public: pair.PublicKey, err: sshkey.ErrInvalidPrivateKey},
+		{name: "invalid public", private: other.PrivateKey, public: "key", err: sshkey.ErrInvalidPublicKey},
+		{name: "mismatch", private: other.PrivateKey, public: pair.PublicKey, err: sshkey.ErrKeyMismatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.ErrorIs(t, sshkey.Check(tt.private, tt.public, tt.passphrase), tt.err)
+		})
+	}
+}
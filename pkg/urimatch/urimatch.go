@@ -0,0 +1,160 @@
+// Package urimatch matches the URLs of websites against the URIs of saved credentials.
+//
+// A URI is matched with one of the strategies. URIs without a scheme are taken as https ones.
+package urimatch
+
+import (
+	"errors"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/publicsuffix"
+)
+
+var (
+	ErrInvalidURI      = errors.New("invalid uri")
+	ErrInvalidStrategy = errors.New("invalid match strategy")
+)
+
+// Strategy is the way a URI matches URLs.
+type Strategy string
+
+const (
+	// Domain matches the URLs of the same registrable domain, e.g. github.com matches gist.github.com.
+	//
+	// It is the strategy of the empty one.
+	Domain Strategy = "domain"
+	// Host matches the URLs of the same host and port.
+	Host Strategy = "host"
+	// Prefix matches the URLs of the same scheme and host, which paths start with the path of the URI.
+	//
+	// The path is matched by its segments, e.g. /app matches /app/login, but not /apple.
+	Prefix Strategy = "prefix"
+	// Regex matches the URLs, which match the regular expression of the URI.
+	Regex Strategy = "regex"
+)
+
+// Strategies are all the strategies.
+var Strategies = []Strategy{Domain, Host, Prefix, Regex} // nolint: gochecknoglobals
+
+// Validate checks that the URI can be matched with the strategy.
+func Validate(uri string, strategy Strategy) error {
+	switch strategy {
+	case "", Domain, Host, Prefix:
+		if _, err := parse(uri); err != nil {
+			return err
+		}
+	case Regex:
+		if _, err := regexp.Compile(uri); err != nil {
+			return errors.Join(ErrInvalidURI, err)
+		}
+	default:
+		return ErrInvalidStrategy
+	}
+
+	return nil
+}
+
+// Match reports whether the URL matches the URI with the strategy, invalid ones match nothing.
+func Match(uri string, strategy Strategy, rawURL string) bool {
+	switch strategy {
+	case Regex:
+		re, err := regexp.Compile(uri)
+
+		return err == nil && re.MatchString(rawURL)
+	case "", Domain, Host, Prefix:
+		target, err := parse(rawURL)
+		if err != nil {
+			return false
+		}
+
+		u, err := parse(uri)
+		if err != nil {
+			return false
+		}
+
+		switch strategy {
+		case Prefix:
+			// both are normalised, so a URI without a scheme or with another case of the host matches
+			return u.Scheme == target.Scheme && u.Host == target.Host && hasPathPrefix(target, u)
+		case Host:
+			return u.Host == target.Host
+		default:
+			return baseDomain(u) == baseDomain(target)
+		}
+	default:
+		return false
+	}
+}
+
+// hasPathPrefix reports whether the path of the URL starts with the segments of the path of the URI,
+// the query of the URI is a prefix of the query of the same path.
+func hasPathPrefix(target, u *url.URL) bool {
+	if u.RawQuery != "" {
+		return target.Path == u.Path && strings.HasPrefix(target.RawQuery, u.RawQuery)
+	}
+
+	prefix := strings.TrimSuffix(u.Path, "/")
+
+	return target.Path == prefix || strings.HasPrefix(target.Path, prefix+"/")
+}
+
+// Keys returns the keys of an index, which the URI is found by from the URLs it may match.
+//
+// The URLs of a prefix start with its host, so they are found by the host. All the regular expressions have
+// the same key, as any URL may match them.
+func Keys(uri string, strategy Strategy) []string {
+	if strategy == Regex {
+		return []string{"regex"}
+	}
+
+	u, err := parse(uri)
+	if err != nil {
+		return nil
+	}
+
+	if strategy == Host || strategy == Prefix {
+		return []string{"host:" + u.Host}
+	}
+
+	return []string{"domain:" + baseDomain(u)}
+}
+
+// LookupKeys returns the keys of an index to look the URIs up, which may match the URL.
+func LookupKeys(rawURL string) ([]string, error) {
+	u, err := parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{"domain:" + baseDomain(u), "host:" + u.Host, "regex"}, nil
+}
+
+func parse(raw string) (*url.URL, error) {
+	raw = strings.TrimSpace(raw)
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil || u.Hostname() == "" {
+		return nil, ErrInvalidURI
+	}
+
+	u.Host = strings.ToLower(u.Host)
+
+	return u, nil
+}
+
+// baseDomain returns the registrable domain of the host, it is the host itself for IP addresses and local names.
+func baseDomain(u *url.URL) string {
+	host := u.Hostname()
+
+	domain, err := publicsuffix.EffectiveTLDPlusOne(host)
+	if err != nil {
+		return host
+	}
+
+	return domain
+}
@@ -0,0 +1,114 @@
+package urimatch_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/novoseltcev/passkeeper/pkg/urimatch"
+)
+
+func TestMatch(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		uri      string
+		strategy urimatch.Strategy
+		url      string
+		want     bool
+	}{
+		{name: "domain subdomain", uri: "github.com", strategy: urimatch.Domain, url: "https://gist.github.com", want: true},
+		{name: "domain default", uri: "https://github.com", url: "https://GitHub.com/login", want: true},
+		{name: "domain public suffix", uri: "alice.github.io", url: "https://bob.github.io", want: false},
+		{name: "domain other", uri: "github.com", strategy: urimatch.Domain, url: "https://github.com.evil.org"},
+		{name: "domain ip", uri: "10.0.0.1", url: "http://10.0.0.1:8080/admin", want: true},
+		{name: "host", uri: "github.com", strategy: urimatch.Host, url: "https://github.com/login", want: true},
+		{name: "host subdomain", uri: "github.com", strategy: urimatch.Host, url: "https://gist.github.com"},
+		{name: "host port", uri: "localhost:8080", strategy: urimatch.Host, url: "http://localhost:8081"},
+		{
+			name: "prefix", uri: "https://example.com/app", strategy: urimatch.Prefix,
+			url: "https://example.com/app/login", want: true,
+		},
+		{name: "prefix other", uri: "https://example.com/app", strategy: urimatch.Prefix, url: "https://example.com/"},
+		{
+			name: "prefix without scheme", uri: "example.com/login", strategy: urimatch.Prefix,
+			url: "https://example.com/login", want: true,
+		},
+		{
+			name: "prefix host case", uri: "https://Example.com/login", strategy: urimatch.Prefix,
+			url: "https://example.com/login?next=/", want: true,
+		},
+		{
+			name: "prefix other host", uri: "https://example.com", strategy: urimatch.Prefix,
+			url: "https://example.com.evil.com/login",
+		},
+		{
+			name: "prefix other port", uri: "https://example.com", strategy: urimatch.Prefix,
+			url: "https://example.com:8443/login",
+		},
+		{name: "prefix segment", uri: "https://example.com/app", strategy: urimatch.Prefix, url: "https://example.com/apple"},
+		{
+			name: "prefix trailing slash", uri: "https://example.com/app/", strategy: urimatch.Prefix,
+			url: "https://example.com/app", want: true,
+		},
+		{
+			name: "prefix query", uri: "https://example.com/login?tenant=a", strategy: urimatch.Prefix,
+			url: "https://example.com/login?tenant=a&next=/", want: true,
+		},
+		{
+			name: "prefix other query", uri: "https://example.com/login?tenant=a", strategy: urimatch.Prefix,
+			url: "https://example.com/login?tenant=b",
+		},
+		{name: "prefix other scheme", uri: "example.com/login", strategy: urimatch.Prefix, url: "http://example.com/login"},
+		{name: "prefix invalid url", uri: "example.com", strategy: urimatch.Prefix, url: "://"},
+		{
+			name: "regex", uri: `^https://[a-z]+\.example\.com/`, strategy: urimatch.Regex,
+			url: "https://shop.example.com/cart", want: true,
+		},
+		{name: "regex other", uri: `^https://[a-z]+\.example\.com/`, strategy: urimatch.Regex, url: "https://example.com/"},
+		{name: "invalid url", uri: "github.com", url: "://"},
+		{name: "invalid strategy", uri: "github.com", strategy: "any", url: "https://github.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, urimatch.Match(tt.uri, tt.strategy, tt.url))
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, urimatch.Validate("github.com", ""))
+	require.NoError(t, urimatch.Validate("https://example.com/app", urimatch.Prefix))
+	require.NoError(t, urimatch.Validate(`^https://.*\.example\.com/`, urimatch.Regex))
+
+	require.ErrorIs(t, urimatch.Validate("", urimatch.Domain), urimatch.ErrInvalidURI)
+	require.ErrorIs(t, urimatch.Validate("https://", urimatch.Host), urimatch.ErrInvalidURI)
+	require.ErrorIs(t, urimatch.Validate("(", urimatch.Regex), urimatch.ErrInvalidURI)
+	require.ErrorIs(t, urimatch.Validate("github.com", "exact"), urimatch.ErrInvalidStrategy)
+}
+
+// TestKeys checks that the URIs are found by the keys of the URLs they match.
+func TestKeys(t *testing.T) {
+	t.Parallel()
+
+	keys, err := urimatch.LookupKeys("https://gist.github.com:443/x")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"domain:github.com", "host:gist.github.com:443", "regex"}, keys)
+
+	assert.Subset(t, keys, urimatch.Keys("github.com", urimatch.Domain))
+	assert.Subset(t, keys, urimatch.Keys("https://gist.github.com:443", urimatch.Host))
+	assert.Subset(t, keys, urimatch.Keys("https://gist.github.com:443/", urimatch.Prefix))
+	assert.Subset(t, keys, urimatch.Keys(".*", urimatch.Regex))
+	assert.NotSubset(t, keys, urimatch.Keys("github.com", urimatch.Host))
+	assert.Empty(t, urimatch.Keys("", urimatch.Domain))
+
+	_, err = urimatch.LookupKeys("")
+	require.ErrorIs(t, err, urimatch.ErrInvalidURI)
+}
@@ -0,0 +1,230 @@
+// Package vaultexport seals vault exports with a password.
+//
+// An export is a JSON document with the parameters to derive the key and the sealed vault:
+//
+//	{
+//	  "format": "passkeeper-export",
+//	  "version": 1,
+//	  "kdf": {"name": "argon2id", "salt": "<base64>", "time": 3, "memory": 65536, "threads": 4},
+//	  "cipher": "aes-256-gcm",
+//	  "data": "<base64>"
+//	}
+//
+// The 32 bytes key is derived from the password with Argon2id, the memory is in KiB.
+// The data is a random 12 bytes nonce followed by the vault encrypted with AES-256-GCM,
+// the header without the data is the additional authenticated data, so the parameters can't be changed.
+//
+// The vault is a JSON document with the exported secrets:
+//
+//	{
+//	  "exported_at": "2024-01-01T00:00:00Z",
+//	  "items": [
+//	    {
+//	      "name": "github",
+//	      "type": 1,
+//	      "data": {"login": "john", "password": "p@ssw0rd", "meta": {}},
+//	      "created_at": "2023-01-01T00:00:00Z",
+//	      "updated_at": "2023-06-01T00:00:00Z",
+//	      "expires_at": "2025-01-01T00:00:00Z",
+//	      "rotate_every": 7776000
+//	    }
+//	  ]
+//	}
+//
+// The type is the ID of the secret type: 1 password, 2 card, 3 text, 4 file, 5 otp, 6 ssh key and 7 custom.
+// The data is the decrypted data of the type, the same as its request body without the passphrase,
+// the content of files is hex encoded. The expiration and the rotation period in seconds are omitted if not set.
+package vaultexport
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"golang.org/x/crypto/argon2"
+)
+
+const (
+	Format  = "passkeeper-export"
+	Version = 1
+
+	KDFArgon2id  = "argon2id"
+	CipherAESGCM = "aes-256-gcm"
+)
+
+const (
+	keySize  = 32
+	saltSize = 16
+
+	defaultTime    = 3
+	defaultMemory  = 64 * 1024
+	defaultThreads = 4
+
+	// the limits keep the archives from exhausting the resources of the one who opens them
+	maxTime    = 10
+	maxMemory  = 1024 * 1024
+	maxThreads = 16
+)
+
+var (
+	ErrInvalidArchive     = errors.New("invalid export archive")
+	ErrUnsupportedVersion = errors.New("unsupported export version")
+	ErrInvalidPassword    = errors.New("invalid export password")
+)
+
+// KDF are the parameters of the key derivation.
+type KDF struct {
+	Name    string `json:"name"`
+	Salt    []byte `json:"salt"`
+	Time    uint32 `json:"time"`
+	Memory  uint32 `json:"memory"`
+	Threads uint8  `json:"threads"`
+}
+
+// Archive is the sealed export.
+type Archive struct {
+	Format  string `json:"format"`
+	Version int    `json:"version"`
+	KDF     KDF    `json:"kdf"`
+	Cipher  string `json:"cipher"`
+	Data    []byte `json:"data"`
+}
+
+// Vault is the content of the export.
+type Vault struct {
+	ExportedAt time.Time `json:"exported_at"`
+	Items      []Item    `json:"items"`
+}
+
+// Item is an exported secret.
+type Item struct {
+	Name      string          `json:"name"`
+	Type      int             `json:"type"`
+	Data      json.RawMessage `json:"data"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+	// ExpiresAt is nil if the secret does not expire.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	// RotateEvery is the rotation period in seconds, it is zero if the secret does not need rotation.
+	RotateEvery int64 `json:"rotate_every,omitempty"`
+}
+
+// Seal encrypts the vault with the password and returns the archive.
+func Seal(password string, vault *Vault) ([]byte, error) {
+	plain, err := json.Marshal(vault)
+	if err != nil {
+		return nil, err
+	}
+
+	salt := make([]byte, saltSize)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, err
+	}
+
+	archive := &Archive{
+		Format:  Format,
+		Version: Version,
+		KDF:     KDF{Name: KDFArgon2id, Salt: salt, Time: defaultTime, Memory: defaultMemory, Threads: defaultThreads},
+		Cipher:  CipherAESGCM,
+	}
+
+	gcm, ad, err := archive.gcm(password)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	archive.Data = gcm.Seal(nonce, nonce, plain, ad)
+
+	return json.Marshal(archive)
+}
+
+// Open decrypts the archive with the password and returns the vault.
+func Open(password string, data []byte) (*Vault, error) {
+	var archive Archive
+	if err := json.Unmarshal(data, &archive); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidArchive, err)
+	}
+
+	if err := archive.check(); err != nil {
+		return nil, err
+	}
+
+	gcm, ad, err := archive.gcm(password)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(archive.Data) < gcm.NonceSize() {
+		return nil, ErrInvalidArchive
+	}
+
+	nonce, sealed := archive.Data[:gcm.NonceSize()], archive.Data[gcm.NonceSize():]
+
+	plain, err := gcm.Open(nil, nonce, sealed, ad)
+	if err != nil {
+		return nil, ErrInvalidPassword
+	}
+
+	var vault Vault
+	if err := json.Unmarshal(plain, &vault); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidArchive, err)
+	}
+
+	return &vault, nil
+}
+
+func (a *Archive) check() error {
+	if a.Format != Format {
+		return fmt.Errorf("%w: unknown format %q", ErrInvalidArchive, a.Format)
+	}
+
+	if a.Version != Version {
+		return fmt.Errorf("%w: %d", ErrUnsupportedVersion, a.Version)
+	}
+
+	if a.KDF.Name != KDFArgon2id || a.Cipher != CipherAESGCM {
+		return fmt.Errorf("%w: unknown kdf %q or cipher %q", ErrInvalidArchive, a.KDF.Name, a.Cipher)
+	}
+
+	if len(a.KDF.Salt) == 0 || a.KDF.Time == 0 || a.KDF.Time > maxTime ||
+		a.KDF.Memory == 0 || a.KDF.Memory > maxMemory || a.KDF.Threads == 0 || a.KDF.Threads > maxThreads {
+		return fmt.Errorf("%w: invalid kdf parameters", ErrInvalidArchive)
+	}
+
+	return nil
+}
+
+// gcm derives the key from the password and returns the cipher with the additional data of the header.
+func (a *Archive) gcm(password string) (cipher.AEAD, []byte, error) {
+	header := *a
+	header.Data = nil
+
+	ad, err := json.Marshal(&header)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	key := argon2.IDKey([]byte(password), a.KDF.Salt, a.KDF.Time, a.KDF.Memory, a.KDF.Threads, keySize)
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return gcm, ad, nil
+}
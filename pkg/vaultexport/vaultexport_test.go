@@ -0,0 +1,84 @@
+package vaultexport_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/novoseltcev/passkeeper/pkg/vaultexport"
+)
+
+const testPassword = "export-password"
+
+var testVault = &vaultexport.Vault{
+	ExportedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	Items: []vaultexport.Item{{
+		Name:        "github",
+		Type:        1,
+		Data:        json.RawMessage(`{"login":"john","password":"p@ssw0rd","meta":{}}`),
+		CreatedAt:   time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		UpdatedAt:   time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC),
+		RotateEvery: 86400,
+	}},
+}
+
+// The tests derive keys with Argon2id, which is heavy, so they don't run in parallel.
+
+func TestSealOpen(t *testing.T) {
+	data, err := vaultexport.Seal(testPassword, testVault)
+	require.NoError(t, err)
+
+	var archive vaultexport.Archive
+	require.NoError(t, json.Unmarshal(data, &archive))
+	assert.Equal(t, vaultexport.Format, archive.Format)
+	assert.Equal(t, vaultexport.Version, archive.Version)
+	assert.Equal(t, vaultexport.KDFArgon2id, archive.KDF.Name)
+	assert.NotContains(t, string(data), "p@ssw0rd")
+
+	vault, err := vaultexport.Open(testPassword, data)
+	require.NoError(t, err)
+	assert.Equal(t, testVault, vault)
+}
+
+func TestOpen_Fails(t *testing.T) {
+	data, err := vaultexport.Seal(testPassword, testVault)
+	require.NoError(t, err)
+
+	_, err = vaultexport.Open("other", data)
+	require.ErrorIs(t, err, vaultexport.ErrInvalidPassword)
+
+	var archive vaultexport.Archive
+	require.NoError(t, json.Unmarshal(data, &archive))
+
+	tests := []struct {
+		name   string
+		modify func(a *vaultexport.Archive)
+		err    error
+	}{
+		{name: "format", modify: func(a *vaultexport.Archive) { a.Format = "other" }, err: vaultexport.ErrInvalidArchive},
+		{name: "version", modify: func(a *vaultexport.Archive) { a.Version = 2 }, err: vaultexport.ErrUnsupportedVersion},
+		{name: "cipher", modify: func(a *vaultexport.Archive) { a.Cipher = "none" }, err: vaultexport.ErrInvalidArchive},
+		{name: "memory", modify: func(a *vaultexport.Archive) { a.KDF.Memory = 1 << 30 }, err: vaultexport.ErrInvalidArchive},
+		{name: "kdf", modify: func(a *vaultexport.Archive) { a.KDF.Time = 1 }, err: vaultexport.ErrInvalidPassword},
+		{name: "short", modify: func(a *vaultexport.Archive) { a.Data = a.Data[:4] }, err: vaultexport.ErrInvalidArchive},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			modified := archive
+			tt.modify(&modified)
+
+			data, err := json.Marshal(&modified)
+			require.NoError(t, err)
+
+			_, err = vaultexport.Open(testPassword, data)
+			require.ErrorIs(t, err, tt.err)
+		})
+	}
+
+	_, err = vaultexport.Open(testPassword, []byte("{"))
+	require.ErrorIs(t, err, vaultexport.ErrInvalidArchive)
+}